@@ -285,6 +285,28 @@ func TestMCPServerSpecDeepCopyNilFields(t *testing.T) {
 	}
 }
 
+func TestMCPServerSpecCanaryDeepCopy(t *testing.T) {
+	original := MCPServerSpec{
+		Image: "test-image",
+		Canary: &CanarySpec{
+			Enabled:     true,
+			Weight:      10,
+			HeaderMatch: &CanaryHeaderMatch{Name: "X-Canary", Value: "always"},
+		},
+	}
+
+	copied := original.DeepCopy()
+
+	if copied.Canary == original.Canary || copied.Canary.HeaderMatch == original.Canary.HeaderMatch {
+		t.Fatal("Deep copy failed: Canary pointers are shared")
+	}
+	copied.Canary.Weight = 50
+	copied.Canary.HeaderMatch.Value = "never"
+	if original.Canary.Weight != 10 || original.Canary.HeaderMatch.Value != "always" {
+		t.Errorf("Deep copy failed: modifying copied Canary affected original: %+v", original.Canary)
+	}
+}
+
 func assertSpecSimpleFields(t *testing.T, copied, original *MCPServerSpec) {
 	t.Helper()
 	if copied == nil || original == nil {
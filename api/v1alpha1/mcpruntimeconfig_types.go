@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MCPRuntimeConfigName is the name of the MCPRuntimeConfig the operator reads.
+const MCPRuntimeConfigName = "default"
+
+//+kubebuilder:object:generate=true
+
+// MCPRuntimeConfigSpec holds platform-wide defaults for MCPServers. Fields left
+// empty fall back to the operator's environment variables.
+type MCPRuntimeConfigSpec struct {
+	// DefaultIngressHost is used by MCPServers that set no ingressHost
+	DefaultIngressHost string `json:"defaultIngressHost,omitempty"`
+
+	// DefaultIngressClass is used by MCPServers that set no ingressClass
+	DefaultIngressClass string `json:"defaultIngressClass,omitempty"`
+
+	// ProvisionedRegistry is the registry used by MCPServers with useProvisionedRegistry
+	ProvisionedRegistry *ProvisionedRegistryRef `json:"provisionedRegistry,omitempty"`
+
+	// DefaultResources apply to MCPServers that leave a resource request or limit unset
+	DefaultResources *ResourceRequirements `json:"defaultResources,omitempty"`
+
+	// NamespaceResources override defaultResources for MCPServers in the named namespace, so
+	// for example dev namespaces get small defaults and prod larger ones
+	NamespaceResources map[string]ResourceRequirements `json:"namespaceResources,omitempty"`
+
+	// ProbeDefaults tune the probes of MCPServers that leave a probe unset
+	ProbeDefaults *ProbesSpec `json:"probeDefaults,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// ProvisionedRegistryRef points at a provisioned registry and its pull secret
+type ProvisionedRegistryRef struct {
+	// URL is the registry host (and optional port) images are rewritten to
+	URL string `json:"url"`
+
+	// SecretName is an image pull secret for the registry in each server namespace
+	SecretName string `json:"secretName,omitempty"`
+
+	// PathPrefix is the project or org images are rewritten under, such as "mcp" for
+	// harbor.example.com/mcp/<image>
+	PathPrefix string `json:"pathPrefix,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Ingress Host",type="string",JSONPath=".spec.defaultIngressHost"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MCPRuntimeConfig is the Schema for the cluster-wide platform settings. The
+// operator reads the object named "default" and applies changes without a restart.
+type MCPRuntimeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MCPRuntimeConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MCPRuntimeConfigList contains a list of MCPRuntimeConfig
+type MCPRuntimeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPRuntimeConfig `json:"items"`
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,6 +15,13 @@ type MCPServerSpec struct {
 	// ImageTag is the tag of the container image (defaults to "latest")
 	ImageTag string `json:"imageTag,omitempty"`
 
+	// ImageVariants maps node architectures (kubernetes.io/arch values such as amd64 or arm64)
+	// to the image built for them. When set, the server runs one Deployment per architecture,
+	// each pinned to its nodes and running spec.replicas pods, instead of a single Deployment
+	// of spec.image. Variant images take imageTag and the registry override like spec.image.
+	// Ignored for job-mode servers
+	ImageVariants map[string]string `json:"imageVariants,omitempty"`
+
 	// RegistryOverride, if set, overrides the registry portion of the image (e.g., registry.example.com)
 	RegistryOverride string `json:"registryOverride,omitempty"`
 
@@ -32,15 +40,54 @@ type MCPServerSpec struct {
 	// ServicePort is the port exposed by the service (defaults to 80)
 	ServicePort int32 `json:"servicePort,omitempty"`
 
-	// IngressPath is the path for the ingress route (defaults to /{name}/mcp)
+	// IngressPath is the path for the ingress route (defaults to /{name}/mcp, or
+	// /{namespace}/{name}/mcp with the operator's --namespaced-ingress-paths, suffixed -2, -3...
+	// when another server on the host has it). The operator adds a missing leading slash and
+	// drops trailing and duplicate slashes
 	IngressPath string `json:"ingressPath,omitempty"`
 
-	// IngressHost is the hostname for the ingress (optional; defaults from MCP_DEFAULT_INGRESS_HOST env var if set on the operator)
+	// IngressStripPrefix removes IngressPath from request paths before they reach the server,
+	// so the server sees /{rest} for a request to {ingressPath}/{rest}. Traefik and nginx only
+	IngressStripPrefix bool `json:"ingressStripPrefix,omitempty"`
+
+	// IngressHost is the hostname for the ingress (optional; defaults from MCP_DEFAULT_INGRESS_HOST env var if set on the operator,
+	// otherwise auto-detected from the ingress controller's LoadBalancer address)
 	IngressHost string `json:"ingressHost,omitempty"`
 
-	// IngressClass is the ingress class to use (e.g., "traefik", "nginx", "istio"). Defaults to "traefik"
+	// IngressClass is the ingress class to use (e.g., "traefik", "nginx", "istio"). Defaults to the operator's
+	// MCP_DEFAULT_INGRESS_CLASS, then the cluster's default IngressClass, then "traefik"
 	IngressClass string `json:"ingressClass,omitempty"`
 
+	// IngressProvider selects how the server is exposed: an Ingress for "traefik", "nginx" or
+	// "istio", an HTTPRoute attached to the operator's MCP_GATEWAY for "gateway-api", or
+	// nothing but the in-cluster Service for "none". Defaults to the operator's
+	// MCP_INGRESS_PROVIDER, then the provider named by ingressClass
+	//+kubebuilder:validation:Enum=traefik;nginx;istio;gateway-api;none
+	IngressProvider string `json:"ingressProvider,omitempty"`
+
+	// TLSOnly exposes the server only on the TLS (websecure) entrypoint when the platform serves
+	// HTTP and TLS side by side; otherwise it is exposed on both. Traefik only
+	TLSOnly bool `json:"tlsOnly,omitempty"`
+
+	// SessionAffinity keeps a client on one pod when the server runs several replicas, for
+	// servers holding session state such as streaming MCP sessions. "clientIP" sets the Service
+	// sessionAffinity and, with nginx, hashes requests by client address; "cookie" sets a sticky
+	// cookie through the Traefik or nginx ingress
+	//+kubebuilder:validation:Enum=clientIP;cookie
+	SessionAffinity string `json:"sessionAffinity,omitempty"`
+
+	// IPFamilies are the IP families of the server's Service, primary first, for IPv6-only
+	// and dual-stack clusters. Defaults to the operator's MCP_SERVICE_IP_FAMILIES, then the
+	// cluster's default family. The primary family cannot change once the Service exists
+	//+kubebuilder:validation:MaxItems=2
+	//+kubebuilder:validation:items:Enum=IPv4;IPv6
+	IPFamilies []string `json:"ipFamilies,omitempty"`
+
+	// IPFamilyPolicy is the ipFamilyPolicy of the server's Service. Defaults to PreferDualStack
+	// with two IP families and SingleStack otherwise
+	//+kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	IPFamilyPolicy string `json:"ipFamilyPolicy,omitempty"`
+
 	// IngressAnnotations are additional annotations for the ingress controller
 	IngressAnnotations map[string]string `json:"ingressAnnotations,omitempty"`
 
@@ -49,6 +96,233 @@ type MCPServerSpec struct {
 
 	// EnvVars are environment variables to pass to the container
 	EnvVars []EnvVar `json:"envVars,omitempty"`
+
+	// EnvFrom exposes the keys of Secrets and ConfigMaps in the server's namespace as environment
+	// variables. The operator watches them and rolls the Deployment when their data changes
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// TopologySpreadConstraints control how pods are spread across zones, nodes, or other topology domains.
+	// Pod label selectors default to the server's pods when labelSelector is unset.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the PriorityClass for the server's pods, used for scheduling and eviction under node pressure
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// SafeToEvict sets the cluster-autoscaler.kubernetes.io/safe-to-evict annotation on the server's pods.
+	// Set false to keep long-lived sessions from being disrupted by node scale-down, or true to let the
+	// autoscaler bin-pack stateless servers. Defaults to the operator's --default-safe-to-evict policy
+	// +optional
+	SafeToEvict *bool `json:"safeToEvict,omitempty"`
+
+	// CrashLoopPolicy stops a server whose containers keep restarting instead of letting them crash forever
+	// +optional
+	CrashLoopPolicy *CrashLoopPolicy `json:"crashLoopPolicy,omitempty"`
+
+	// Canary marks this server as a canary of another MCPServer serving the same ingress host and path;
+	// its Ingress is rendered as a canary that receives a share of that traffic
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// Mirror copies the requests this server receives to a shadow MCPServer in the same namespace;
+	// the shadow's responses are discarded, so clients are not affected. nginx ingress class only
+	Mirror *MirrorSpec `json:"mirror,omitempty"`
+
+	// Auth protects the MCP endpoint at the ingress, either with basic auth or by delegating each request
+	// to an auth service. Traefik and nginx ingress classes only
+	Auth *AuthSpec `json:"auth,omitempty"`
+
+	// ExternalDNS adds external-dns annotations to the Ingress so a DNS record is created for the ingress host
+	ExternalDNS *ExternalDNSSpec `json:"externalDNS,omitempty"`
+
+	// Probes tunes the liveness and readiness probes and adds an optional startup probe
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// SyncSecrets lists Secrets and ConfigMaps in the operator's sync namespace (mcp-runtime by default)
+	// that are copied into the server's namespace under the same name and kept up to date. Only sources
+	// labeled mcpruntime.org/syncable=true are copied
+	SyncSecrets []SyncSecretRef `json:"syncSecrets,omitempty"`
+
+	// Mode is "server" (the default: a Deployment behind a Service and Ingress) or "job": a one-shot
+	// Job that runs the image to completion, e.g. to build an index or migrate data. It cannot be changed
+	//+kubebuilder:validation:Enum=server;job
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="mode is immutable"
+	Mode string `json:"mode,omitempty"`
+
+	// Job tunes the Job created in job mode
+	Job *JobSpec `json:"job,omitempty"`
+
+	// DeployAs names a ServiceAccount in the server's namespace that the operator impersonates
+	// to create and update the server's Deployment, Service, Ingress and other child resources,
+	// so the ServiceAccount's RBAC bounds what the server may create. The MCPServer status,
+	// namespace quota and synced secrets are still written as the operator
+	//+kubebuilder:validation:MaxLength=253
+	DeployAs string `json:"deployAs,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// JobSpec configures the Job of a job-mode MCPServer
+type JobSpec struct {
+	// BackoffLimit is the number of retries before the Job is marked failed (Kubernetes default: 6)
+	//+kubebuilder:validation:Minimum=0
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// TTLSecondsAfterFinished deletes the finished Job (and its pods) after this many seconds;
+	// the MCPServer keeps reporting the result
+	//+kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// CrashLoopPolicy limits how often the containers of a server may restart
+type CrashLoopPolicy struct {
+	// MaxRestarts is the number of container restarts, summed over the server's pods, tolerated within the window
+	//+kubebuilder:validation:Minimum=1
+	MaxRestarts int32 `json:"maxRestarts"`
+
+	// WindowSeconds is the length of the window restarts are counted in (default 600)
+	//+kubebuilder:validation:Minimum=1
+	WindowSeconds *int32 `json:"windowSeconds,omitempty"`
+
+	// Action is taken when maxRestarts is exceeded: pause (the default) scales the server to zero until it
+	// is resumed with "mcp-runtime server resume"; notify only emits a warning event
+	//+kubebuilder:validation:Enum=pause;notify
+	Action string `json:"action,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// SyncSecretRef names a Secret or ConfigMap in the operator's sync namespace
+type SyncSecretRef struct {
+	// Name of the Secret or ConfigMap
+	Name string `json:"name"`
+
+	// Kind is Secret or ConfigMap (defaults to Secret)
+	//+kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind string `json:"kind,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// ProbesSpec tunes the TCP probes on the server port
+type ProbesSpec struct {
+	// Liveness overrides the liveness probe timing (defaults: initialDelaySeconds 5, periodSeconds 10)
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+
+	// Readiness overrides the readiness probe timing (defaults: initialDelaySeconds 3, periodSeconds 5)
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+
+	// Startup adds a startup probe that holds off liveness and readiness checks until the server
+	// accepts connections (defaults: periodSeconds 10, failureThreshold 30)
+	Startup *ProbeSpec `json:"startup,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// ProbeSpec holds probe timing; unset fields keep their defaults
+type ProbeSpec struct {
+	// InitialDelaySeconds is the delay before the first probe
+	//+kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is how often the probe runs
+	//+kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is how long a probe may take
+	//+kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures before the probe fails
+	//+kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold is the number of consecutive successes before the probe passes (must be 1 for liveness and startup)
+	//+kubebuilder:validation:Minimum=1
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// AuthSpec configures authentication of the MCP endpoint by the ingress controller
+// +kubebuilder:validation:XValidation:rule="self.type != 'basic' || has(self.secretRef)",message="secretRef is required for basic auth"
+// +kubebuilder:validation:XValidation:rule="self.type == 'basic' || has(self.url)",message="url is required for bearer and oidc auth"
+type AuthSpec struct {
+	// Type is basic (htpasswd users from a Secret), bearer (requests and their Authorization header are
+	// checked by the auth service at URL) or oidc (like bearer, with browsers sent to SigninURL to log in)
+	//+kubebuilder:validation:Enum=basic;bearer;oidc
+	Type string `json:"type"`
+
+	// SecretRef names a Secret in the server's namespace holding htpasswd entries for basic auth,
+	// under the "users" key for traefik and the "auth" key for nginx
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// URL is the auth service for bearer and oidc, e.g. oauth2-proxy's /oauth2/auth; a 2xx response
+	// lets the request through
+	URL string `json:"url,omitempty"`
+
+	// SigninURL is where nginx redirects unauthenticated oidc requests, e.g. oauth2-proxy's /oauth2/start.
+	// Traefik returns the auth service's redirect instead
+	SigninURL string `json:"signinURL,omitempty"`
+
+	// ResponseHeaders are headers of the auth service response passed on to the server, e.g. X-Auth-Request-User
+	ResponseHeaders []string `json:"responseHeaders,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// ExternalDNSSpec configures the DNS record external-dns creates for the ingress host
+type ExternalDNSSpec struct {
+	// Enabled adds the external-dns annotations to the Ingress
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TTL is the record TTL in seconds (provider default when unset)
+	//+kubebuilder:validation:Minimum=0
+	TTL int32 `json:"ttl,omitempty"`
+
+	// Target overrides the record target (IP or hostname); defaults to the Ingress load balancer address
+	Target string `json:"target,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// CanarySpec defines how much traffic a canary MCPServer receives
+type CanarySpec struct {
+	// Enabled turns the canary routing on; when false the server's Ingress is a regular route
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Weight is the percentage of requests routed to this server
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight,omitempty"`
+
+	// HeaderMatch routes requests carrying the header to this server regardless of Weight
+	HeaderMatch *CanaryHeaderMatch `json:"headerMatch,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// CanaryHeaderMatch selects requests by header
+type CanaryHeaderMatch struct {
+	// Name is the request header name
+	Name string `json:"name"`
+
+	// Value is the header value to match; when empty, a value of "always" routes to the canary and "never" does not
+	Value string `json:"value,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// MirrorSpec defines where a server's traffic is mirrored
+type MirrorSpec struct {
+	// TargetServer is the name of the shadow MCPServer in the same namespace
+	TargetServer string `json:"targetServer"`
+
+	// Percentage is the share of requests mirrored; defaults to 100. ingress-nginx mirrors every
+	// request, so lower values are rejected for the nginx ingress class
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	Percentage int32 `json:"percentage,omitempty"`
 }
 
 //+kubebuilder:object:generate=true
@@ -71,7 +345,10 @@ type ResourceList struct {
 
 // EnvVar represents an environment variable
 type EnvVar struct {
-	Name  string `json:"name"`
+	Name string `json:"name"`
+	// Value may contain templates: {{ .ServerName }} and {{ .Namespace }} expand to
+	// literals; {{ .PodIP }}, {{ .PodName }}, {{ .NodeName }} and {{ .HostIP }} are
+	// resolved through the downward API when the pod starts.
 	Value string `json:"value"`
 }
 
@@ -85,17 +362,123 @@ type MCPServerStatus struct {
 	// Message provides additional information about the status
 	Message string `json:"message,omitempty"`
 
-	// Conditions represent the latest available observations
+	// ObservedGeneration is the metadata.generation the status was computed for.
+	// GitOps tools compare it with metadata.generation to tell stale status apart.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the latest available observations. Ready is True only
+	// when the Deployment, Service and Ingress are all ready.
 	Conditions []Condition `json:"conditions,omitempty"`
 
 	// DeploymentReady indicates if the deployment is ready
 	DeploymentReady bool `json:"deploymentReady,omitempty"`
 
+	// Replicas is the number of pods of the server's Deployments, summed over image variants
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of those pods that are ready
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// UpdatedReplicas is the number of pods running the current pod template
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// UnavailableReplicas is the number of pods still needed for the desired replica count
+	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
+
+	// RolloutComplete is true once every Deployment has rolled out its current pod
+	// template, with no old pods left and all new pods available
+	RolloutComplete bool `json:"rolloutComplete,omitempty"`
+
 	// ServiceReady indicates if the service is ready
 	ServiceReady bool `json:"serviceReady,omitempty"`
 
 	// IngressReady indicates if the ingress is ready
 	IngressReady bool `json:"ingressReady,omitempty"`
+
+	// IngressClass is the ingress class the Ingress uses, including a detected cluster default
+	IngressClass string `json:"ingressClass,omitempty"`
+
+	// IngressProvider is the provider that last exposed the server, so its resources are
+	// removed when the server switches to another
+	IngressProvider string `json:"ingressProvider,omitempty"`
+
+	// IngressHost is the host the Ingress serves, including an auto-detected one
+	IngressHost string `json:"ingressHost,omitempty"`
+
+	// IngressPath is the path the Ingress routes, including a defaulted one that was
+	// suffixed to avoid another server's path on the same host; a defaulted path is kept
+	// while it stays free
+	IngressPath string `json:"ingressPath,omitempty"`
+
+	// URL is the externally reachable endpoint of the server (scheme, host and path)
+	URL string `json:"url,omitempty"`
+
+	// History lists the last images that became ready, oldest first. It is what
+	// "mcp-runtime server rollback" restores from.
+	History []Revision `json:"history,omitempty"`
+
+	// CompletionTime is when the Job of a job-mode server succeeded
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CrashLoop counts container restarts for spec.crashLoopPolicy
+	CrashLoop *CrashLoopStatus `json:"crashLoop,omitempty"`
+
+	// Variants reports, per spec.imageVariants entry, which image runs where
+	Variants []VariantStatus `json:"variants,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// VariantStatus is the observed state of one architecture variant
+type VariantStatus struct {
+	// Arch is the node architecture the variant is pinned to
+	Arch string `json:"arch"`
+
+	// Image is the resolved image the variant runs
+	Image string `json:"image"`
+
+	// Deployment is the name of the variant's Deployment
+	Deployment string `json:"deployment"`
+
+	// ReadyReplicas is the number of ready pods of the variant
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Nodes lists the nodes running ready pods of the variant
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// CrashLoopStatus is the current restart window of spec.crashLoopPolicy
+type CrashLoopStatus struct {
+	// WindowStart is when the current window began
+	WindowStart metav1.Time `json:"windowStart"`
+
+	// BaselineRestarts is the total restart count of the server's containers at the start of the window
+	BaselineRestarts int32 `json:"baselineRestarts"`
+
+	// Restarts is the number of restarts counted in the current window
+	Restarts int32 `json:"restarts"`
+
+	// Notified is set once the notify action has fired in the current window
+	Notified bool `json:"notified,omitempty"`
+}
+
+//+kubebuilder:object:generate=true
+
+// Revision is an image the server ran while Ready
+type Revision struct {
+	// Revision increases by one for every new image
+	Revision int64 `json:"revision"`
+
+	// Image is the spec.image of the revision
+	Image string `json:"image"`
+
+	// ImageTag is the spec.imageTag of the revision
+	ImageTag string `json:"imageTag,omitempty"`
+
+	// ReadyAt is when the revision first became ready
+	ReadyAt metav1.Time `json:"readyAt,omitempty"`
 }
 
 //+kubebuilder:object:generate=true
@@ -107,6 +490,7 @@ type Condition struct {
 	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
 	Reason             string                 `json:"reason,omitempty"`
 	Message            string                 `json:"message,omitempty"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -114,6 +498,7 @@ type Condition struct {
 //+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 //+kubebuilder:printcolumn:name="Image",type="string",JSONPath=".spec.image"
 //+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.deploymentReady"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // MCPServer is the Schema for the mcpservers API
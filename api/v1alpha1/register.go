@@ -3,4 +3,5 @@ package v1alpha1
 func init() {
 	// Register the types with the scheme builder
 	SchemeBuilder.Register(&MCPServer{}, &MCPServerList{})
+	SchemeBuilder.Register(&MCPRuntimeConfig{}, &MCPRuntimeConfigList{})
 }
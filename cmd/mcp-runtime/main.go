@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"mcp-runtime/internal/cli"
+	"mcp-runtime/internal/tracing"
 )
 
 var (
@@ -16,6 +23,19 @@ var (
 	commit  = "none"
 	date    = "unknown"
 	debug   = false
+
+	// namespace is the global --namespace flag; empty defers to the saved or built-in default.
+	namespace string
+
+	// errorFormat is the global --error-format flag: text or json.
+	errorFormat = cli.ErrorFormatText
+
+	// quiet and verbose are the global --quiet and --verbose flags for tool output.
+	quiet   bool
+	verbose bool
+
+	// commandSpan covers the whole invocation of the selected subcommand.
+	commandSpan trace.Span
 )
 
 func main() {
@@ -26,14 +46,65 @@ func main() {
 	}
 	defer logger.Sync()
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "mcp-runtime-cli", version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to set up tracing: %v\n", err)
+	}
+
+	preparseErrorFormat(os.Args[1:])
+	// The exec policy wraps the executors the commands are created with.
+	if err := cli.ConfigureExecPolicy(logger); err != nil {
+		cli.WriteError(os.Stderr, err, errorFormat)
+		os.Exit(1)
+	}
 	initCommands(logger)
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	// Cancel in-flight kubectl/docker commands on Ctrl-C instead of leaving them running;
+	// a second Ctrl-C exits at once. Temp files left by either path are removed.
+	ctx, stop := cli.NotifyInterrupt(context.Background())
+	err = rootCmd.ExecuteContext(ctx)
+	stop()
+	cli.CleanupTempFiles()
+	endCommandSpan(err)
+	_ = shutdownTracing(context.Background())
+	if err != nil {
+		cli.WriteError(os.Stderr, err, errorFormat)
 		os.Exit(1)
 	}
 }
 
+// preparseErrorFormat reads --error-format before cobra parses the command line, so
+// unknown flags and commands are reported in the requested format too.
+func preparseErrorFormat(args []string) {
+	fs := pflag.NewFlagSet("error-format", pflag.ContinueOnError)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&errorFormat, "error-format", errorFormat, "")
+	_ = fs.Parse(args)
+	// Usage text would corrupt the json document on stderr.
+	rootCmd.SilenceUsage = errorFormat == cli.ErrorFormatJSON
+}
+
+// startCommandSpan starts the span for the invoked command and makes its
+// context the parent of spans and commands started by CLI helpers.
+func startCommandSpan(cmd *cobra.Command) {
+	ctx, span := otel.Tracer("mcp-runtime/cli").Start(cmd.Context(), cmd.CommandPath())
+	commandSpan = span
+	cmd.SetContext(ctx)
+	cli.SetCommandContext(ctx)
+}
+
+func endCommandSpan(err error) {
+	if commandSpan == nil {
+		return
+	}
+	if err != nil {
+		commandSpan.RecordError(err)
+		commandSpan.SetStatus(codes.Error, err.Error())
+	}
+	commandSpan.End()
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "mcp-runtime",
 	Short: "MCP Runtime Management CLI",
@@ -43,14 +114,29 @@ var rootCmd = &cobra.Command{
 - MCP server deployments
 - Platform configuration`,
 	Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	// main prints the final error itself so it can honour --error-format.
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := cli.ValidateErrorFormat(errorFormat); err != nil {
+			return err
+		}
 		// Set debug mode globally so logStructuredError can check it
 		cli.SetDebugMode(debug)
+		cli.SetNamespaceOverride(namespace)
+		if err := cli.SetOutputMode(quiet, verbose); err != nil {
+			return err
+		}
+		startCommandSpan(cmd)
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug mode with structured error logging")
+	rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", cli.ErrorFormatText, "Format of the error printed on failure: text or json")
+	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "Show a spinner instead of the output of long-running tools (docker, kind, eksctl)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Show all output of long-running tools, including progress updates")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "Namespace to operate in (default: the saved namespace or mcp-servers; registry commands use registry)")
 }
 
 func initCommands(logger *zap.Logger) {
@@ -60,6 +146,20 @@ func initCommands(logger *zap.Logger) {
 	rootCmd.AddCommand(cli.NewSetupCmd(logger))
 	rootCmd.AddCommand(cli.NewStatusCmd(logger))
 	rootCmd.AddCommand(cli.NewPipelineCmd(logger))
+	rootCmd.AddCommand(cli.NewIngressCmd(logger))
+	rootCmd.AddCommand(cli.NewContextCmd(logger))
+	rootCmd.AddCommand(cli.NewDoctorCmd(logger))
+	rootCmd.AddCommand(cli.NewRBACCmd(logger))
+	rootCmd.AddCommand(cli.NewBackupCmd(logger))
+	rootCmd.AddCommand(cli.NewOperatorCmd(logger))
+	rootCmd.AddCommand(cli.NewSmokeTestCmd(logger))
+	rootCmd.AddCommand(cli.NewConfigCmd(logger))
+	rootCmd.AddCommand(cli.NewObservabilityCmd(logger))
+	rootCmd.AddCommand(cli.NewDashboardCmd(logger))
+	rootCmd.AddCommand(cli.NewTeamCmd(logger))
+	rootCmd.AddCommand(cli.NewServeAPICmd(logger))
+	rootCmd.AddCommand(cli.NewEventsCmd(logger))
+	rootCmd.AddCommand(cli.NewCleanCmd(logger))
 }
 
 // newConsoleLogger returns a human-friendly console logger with timestamps and caller info.
@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
+
+	"mcp-runtime/internal/cli"
 )
 
 func TestRootCommandHelp(t *testing.T) {
@@ -28,3 +31,26 @@ func TestRootCommandHelp(t *testing.T) {
 		t.Fatalf("help output missing expected text")
 	}
 }
+
+func TestErrorFormatFlag(t *testing.T) {
+	logger, err := newConsoleLogger(false)
+	if err != nil {
+		t.Fatalf("newConsoleLogger() error: %v", err)
+	}
+	defer logger.Sync()
+	initCommands(logger)
+	defer func() { errorFormat = cli.ErrorFormatText }()
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs([]string{"config", "get", "--error-format", "yaml"})
+
+	err = rootCmd.Execute()
+	if !errors.Is(err, cli.ErrInvalidErrorFormat) {
+		t.Fatalf("rootCmd.Execute() error = %v, want ErrInvalidErrorFormat", err)
+	}
+	if got := cli.ErrorID(err); got != "MCP-CLI-025" {
+		t.Fatalf("ErrorID() = %q, want MCP-CLI-025", got)
+	}
+}
@@ -1,24 +1,38 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
 	"mcp-runtime/internal/operator"
+	"mcp-runtime/internal/tracing"
 )
 
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// Set at build time with -ldflags "-X main.version=... -X main.commit=... -X main.date=...".
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
 )
 
 func init() {
@@ -34,6 +48,16 @@ func main() {
 	}
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&cfg.zapOptions)))
+	operator.SetBuildInfo(operator.BuildInfo{Version: version, Commit: commit, Date: date})
+	setupLog.Info("Starting operator", "version", version, "commit", commit, "built", date)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "mcp-runtime-operator", version)
+	if err != nil {
+		setupLog.Error(err, "failed to set up tracing; continuing without it")
+	}
+	if tracing.Enabled(os.Getenv) {
+		setupLog.Info("OpenTelemetry tracing enabled", "endpoint", os.Getenv(tracing.EnvOTLPEndpoint))
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), newManagerOptions(cfg))
 	if err != nil {
@@ -47,15 +71,91 @@ func main() {
 		setupLog.Info("Provisioned registry configured", "url", registryConfig.URL)
 	}
 
+	quotaConfig := quotaConfigFromEnv(os.Getenv)
+	if quotaConfig != nil {
+		if err := quotaConfig.Validate(); err != nil {
+			setupLog.Error(err, "invalid namespace quota configuration")
+			os.Exit(1)
+		}
+		setupLog.Info("Namespace quota enforcement enabled")
+	}
+
+	ipFamilies, err := operator.ParseIPFamilies(os.Getenv("MCP_SERVICE_IP_FAMILIES"))
+	if err != nil {
+		setupLog.Error(err, "invalid MCP_SERVICE_IP_FAMILIES")
+		os.Exit(1)
+	}
+
+	ingressProvider := os.Getenv("MCP_INGRESS_PROVIDER")
+	if err := operator.ValidateIngressProvider(ingressProvider); err != nil {
+		setupLog.Error(err, "invalid MCP_INGRESS_PROVIDER")
+		os.Exit(1)
+	}
+
+	var imageVerifier operator.ImageVerifier
+	if signatureConfig := signatureConfigFromEnv(os.Getenv); signatureConfig != nil {
+		if err := signatureConfig.Validate(); err != nil {
+			setupLog.Error(err, "invalid image signature verification configuration")
+			os.Exit(1)
+		}
+		imageVerifier = operator.NewCosignVerifier(*signatureConfig)
+		setupLog.Info("Image signature verification enabled")
+	}
+
+	var k8sClient client.Client = mgr.GetClient()
+	var apiReader client.Reader = mgr.GetAPIReader()
+	if cfg.chaosErrorRate > 0 {
+		injector := operator.NewChaosInjector(cfg.chaosErrorRate, cfg.chaosSeed)
+		k8sClient = injector.Client(k8sClient)
+		apiReader = injector.Reader(apiReader)
+		setupLog.Info("Chaos mode: injecting API errors into reconciler calls", "rate", cfg.chaosErrorRate, "seed", cfg.chaosSeed)
+	}
+
+	ingressClasses := &operator.IngressClassDetector{Reader: apiReader}
+	if class, err := ingressClasses.Detect(context.Background()); err != nil {
+		setupLog.Error(err, "failed to detect the default ingress class; retrying in the background")
+	} else if class != "" {
+		setupLog.Info("Detected default ingress class", "class", class)
+	}
+	if err := mgr.Add(ingressClasses); err != nil {
+		setupLog.Error(err, "unable to add ingress class detection")
+		os.Exit(1)
+	}
+
 	if err = (&operator.MCPServerReconciler{
-		Client:              mgr.GetClient(),
-		Scheme:              mgr.GetScheme(),
-		DefaultIngressHost:  os.Getenv("MCP_DEFAULT_INGRESS_HOST"),
-		ProvisionedRegistry: registryConfig,
+		Client:                 k8sClient,
+		Scheme:                 mgr.GetScheme(),
+		DefaultIngressHost:     os.Getenv("MCP_DEFAULT_INGRESS_HOST"),
+		DefaultIngressClass:    os.Getenv("MCP_DEFAULT_INGRESS_CLASS"),
+		IngressClasses:         ingressClasses,
+		IngressProvider:        ingressProvider,
+		Gateway:                os.Getenv("MCP_GATEWAY"),
+		ProvisionedRegistry:    registryConfig,
+		RegistryPullSecret:     os.Getenv("MCP_REGISTRY_PULL_SECRET"),
+		NamespaceQuota:         quotaConfig,
+		ImageVerifier:          imageVerifier,
+		IngressTLS:             os.Getenv("MCP_INGRESS_TLS") == "true",
+		IngressDual:            os.Getenv("MCP_INGRESS_DUAL") == "true",
+		MaintenanceNamespace:   cfg.maintenanceNamespace,
+		SyncNamespace:          cfg.syncNamespace,
+		DefaultSafeToEvict:     cfg.defaultSafeToEvict,
+		NamespacedIngressPaths: cfg.namespacedPaths,
+		DefaultIPFamilies:      ipFamilies,
+		APIReader:              apiReader,
+		Recorder:               mgr.GetEventRecorderFor("mcpserver-controller"),
+		ImpersonatingClient:    operator.NewImpersonatingClientFunc(mgr.GetConfig(), mgr.GetScheme(), mgr.GetRESTMapper()),
+		RequireDeployAs:        os.Getenv("MCP_REQUIRE_DEPLOY_AS") == "true",
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MCPServer")
 		os.Exit(1)
 	}
+	if err = (&operator.TTLReconciler{
+		Client:   k8sClient,
+		Recorder: mgr.GetEventRecorderFor("mcpserver-ttl-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MCPServerTTL")
+		os.Exit(1)
+	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -69,14 +169,25 @@ func main() {
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
+		_ = shutdownTracing(context.Background())
 		os.Exit(1)
 	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
 }
 
 type operatorConfig struct {
 	metricsAddr          string
 	probeAddr            string
+	pprofAddr            string
 	enableLeaderElection bool
+	maintenanceNamespace string
+	syncNamespace        string
+	namespacedPaths      bool
+	defaultSafeToEvict   *bool
+	chaosErrorRate       float64
+	chaosSeed            int64
 	zapOptions           zap.Options
 }
 
@@ -89,24 +200,72 @@ func parseConfig(fs *flag.FlagSet, args []string) (*operatorConfig, error) {
 
 	fs.StringVar(&cfg.metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	fs.StringVar(&cfg.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	fs.StringVar(&cfg.pprofAddr, "pprof-bind-address", "", "The address the net/http/pprof endpoint binds to, e.g. 127.0.0.1:6060. Empty disables it.")
 	fs.BoolVar(&cfg.enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+	fs.StringVar(&cfg.maintenanceNamespace, "maintenance-namespace", "mcp-runtime", "Namespace of the "+operator.MaintenanceConfigMapName+" ConfigMap that pauses reconciliation. Empty disables maintenance mode.")
+	fs.StringVar(&cfg.syncNamespace, "sync-namespace", "mcp-runtime", "Namespace of the Secrets and ConfigMaps that MCPServers copy with spec.syncSecrets. Empty disables syncing.")
+	fs.BoolVar(&cfg.namespacedPaths, "namespaced-ingress-paths", false, "Default spec.ingressPath to /<namespace>/<name>/mcp instead of /<name>/mcp, so servers of the same name in different namespaces can share a host.")
+	var safeToEvict string
+	fs.StringVar(&safeToEvict, "default-safe-to-evict", "", "cluster-autoscaler safe-to-evict annotation for servers without spec.safeToEvict: true, false, or empty to add none.")
+	if operator.ChaosBuild {
+		fs.Float64Var(&cfg.chaosErrorRate, "chaos-error-rate", 0, "Share (0-1) of reconciler API calls that fail with an injected conflict, throttling or timeout error. Test builds only.")
+		fs.Int64Var(&cfg.chaosSeed, "chaos-seed", time.Now().UnixNano(), "Seed for --chaos-error-rate, to replay a run.")
+	}
 	cfg.zapOptions.BindFlags(fs)
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
 	}
+	if safeToEvict != "" {
+		safe, err := strconv.ParseBool(safeToEvict)
+		if err != nil {
+			return nil, fmt.Errorf("--default-safe-to-evict must be true, false or empty, got %q", safeToEvict)
+		}
+		cfg.defaultSafeToEvict = &safe
+	}
+	if cfg.chaosErrorRate < 0 || cfg.chaosErrorRate > 1 {
+		return nil, fmt.Errorf("--chaos-error-rate must be between 0 and 1, got %v", cfg.chaosErrorRate)
+	}
 
 	return &cfg, nil
 }
 
 func newManagerOptions(cfg *operatorConfig) ctrl.Options {
-	return ctrl.Options{
+	opts := ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                server.Options{BindAddress: cfg.metricsAddr},
 		HealthProbeBindAddress: cfg.probeAddr,
+		PprofBindAddress:       cfg.pprofAddr,
 		LeaderElection:         cfg.enableLeaderElection,
 		LeaderElectionID:       "mcp-runtime-operator.mcpruntime.org",
 	}
+	// Only the maintenance ConfigMap and sync sources are read through the cache,
+	// so don't cache ConfigMaps and Secrets cluster-wide. Elsewhere only envFrom sources
+	// labeled mcpruntime.org/env-source are cached, so their changes still roll servers.
+	configMapNamespaces := map[string]cache.Config{}
+	for _, ns := range []string{cfg.maintenanceNamespace, cfg.syncNamespace} {
+		if ns != "" {
+			configMapNamespaces[ns] = cache.Config{}
+		}
+	}
+	if len(configMapNamespaces) > 0 {
+		configMapNamespaces[cache.AllNamespaces] = envSourceCacheConfig()
+		opts.Cache = cache.Options{ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {Namespaces: configMapNamespaces},
+		}}
+	}
+	if cfg.syncNamespace != "" {
+		opts.Cache.ByObject[&corev1.Secret{}] = cache.ByObject{Namespaces: map[string]cache.Config{
+			cfg.syncNamespace:   {},
+			cache.AllNamespaces: envSourceCacheConfig(),
+		}}
+	}
+	return opts
+}
+
+// envSourceCacheConfig caches the Secrets or ConfigMaps labeled as envFrom sources.
+func envSourceCacheConfig() cache.Config {
+	return cache.Config{LabelSelector: labels.SelectorFromSet(labels.Set{operator.LabelEnvSource: "true"})}
 }
 
 func registryConfigFromEnv(getenv func(string) string) *operator.RegistryConfig {
@@ -120,5 +279,44 @@ func registryConfigFromEnv(getenv func(string) string) *operator.RegistryConfig
 		Username:   getenv("PROVISIONED_REGISTRY_USERNAME"),
 		Password:   getenv("PROVISIONED_REGISTRY_PASSWORD"),
 		SecretName: getenv("PROVISIONED_REGISTRY_SECRET_NAME"),
+		PathPrefix: getenv("PROVISIONED_REGISTRY_PATH_PREFIX"),
+	}
+}
+
+// quotaConfigFromEnv returns the namespace quota to enforce, or nil when
+// MCP_NAMESPACE_QUOTA is not "true". Unset totals fall back to the operator
+// package defaults, which the CLI also uses.
+func quotaConfigFromEnv(getenv func(string) string) *operator.QuotaConfig {
+	if getenv("MCP_NAMESPACE_QUOTA") != "true" {
+		return nil
+	}
+
+	orDefault := func(key, fallback string) string {
+		if v := getenv(key); v != "" {
+			return v
+		}
+		return fallback
+	}
+	return &operator.QuotaConfig{
+		RequestsCPU:    orDefault("MCP_QUOTA_REQUESTS_CPU", operator.DefaultQuotaRequestsCPU),
+		RequestsMemory: orDefault("MCP_QUOTA_REQUESTS_MEMORY", operator.DefaultQuotaRequestsMemory),
+		LimitsCPU:      orDefault("MCP_QUOTA_LIMITS_CPU", operator.DefaultQuotaLimitsCPU),
+		LimitsMemory:   orDefault("MCP_QUOTA_LIMITS_MEMORY", operator.DefaultQuotaLimitsMemory),
+		Pods:           orDefault("MCP_QUOTA_PODS", strconv.Itoa(operator.DefaultQuotaPods)),
+		MaxCPU:         orDefault("MCP_QUOTA_MAX_CPU", operator.DefaultLimitRangeMaxCPU),
+		MaxMemory:      orDefault("MCP_QUOTA_MAX_MEMORY", operator.DefaultLimitRangeMaxMemory),
+	}
+}
+
+// signatureConfigFromEnv returns the image signature verification settings, or
+// nil when MCP_VERIFY_IMAGE_SIGNATURES is not "true".
+func signatureConfigFromEnv(getenv func(string) string) *operator.SignatureConfig {
+	if getenv("MCP_VERIFY_IMAGE_SIGNATURES") != "true" {
+		return nil
+	}
+	return &operator.SignatureConfig{
+		PublicKey:             getenv("MCP_COSIGN_PUBLIC_KEY"),
+		CertificateIdentity:   getenv("MCP_COSIGN_CERTIFICATE_IDENTITY"),
+		CertificateOIDCIssuer: getenv("MCP_COSIGN_CERTIFICATE_OIDC_ISSUER"),
 	}
 }
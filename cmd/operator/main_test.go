@@ -5,6 +5,10 @@ import (
 	"io"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
 	"mcp-runtime/internal/operator"
 )
 
@@ -22,6 +26,7 @@ func TestRegistryConfigFromEnv(t *testing.T) {
 			"PROVISIONED_REGISTRY_USERNAME":    "user",
 			"PROVISIONED_REGISTRY_PASSWORD":    "pass",
 			"PROVISIONED_REGISTRY_SECRET_NAME": "secret",
+			"PROVISIONED_REGISTRY_PATH_PREFIX": "mcp",
 		}
 		getenv := func(key string) string { return env[key] }
 
@@ -35,6 +40,7 @@ func TestRegistryConfigFromEnv(t *testing.T) {
 			Username:   "user",
 			Password:   "pass",
 			SecretName: "secret",
+			PathPrefix: "mcp",
 		}
 
 		if *got != *want {
@@ -59,12 +65,21 @@ func TestParseConfig(t *testing.T) {
 		if cfg.probeAddr != ":8081" {
 			t.Fatalf("unexpected probeAddr: %q", cfg.probeAddr)
 		}
+		if cfg.pprofAddr != "" {
+			t.Fatalf("expected pprof disabled by default, got %q", cfg.pprofAddr)
+		}
 		if cfg.enableLeaderElection {
 			t.Fatalf("expected leader election disabled by default")
 		}
 		if !cfg.zapOptions.Development {
 			t.Fatalf("expected development logging default")
 		}
+		if cfg.maintenanceNamespace != "mcp-runtime" {
+			t.Fatalf("unexpected maintenanceNamespace: %q", cfg.maintenanceNamespace)
+		}
+		if cfg.syncNamespace != "mcp-runtime" {
+			t.Fatalf("unexpected syncNamespace: %q", cfg.syncNamespace)
+		}
 	})
 
 	t.Run("overrides", func(t *testing.T) {
@@ -75,6 +90,7 @@ func TestParseConfig(t *testing.T) {
 			"--metrics-bind-address=localhost:9090",
 			"--health-probe-bind-address=localhost:9091",
 			"--leader-elect",
+			"--pprof-bind-address=127.0.0.1:6060",
 		}
 		cfg, err := parseConfig(fs, args)
 		if err != nil {
@@ -90,6 +106,53 @@ func TestParseConfig(t *testing.T) {
 		if !cfg.enableLeaderElection {
 			t.Fatalf("expected leader election enabled")
 		}
+		if cfg.pprofAddr != "127.0.0.1:6060" {
+			t.Fatalf("unexpected pprofAddr: %q", cfg.pprofAddr)
+		}
+	})
+
+	t.Run("default safe-to-evict", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		cfg, err := parseConfig(fs, []string{"--default-safe-to-evict=false"})
+		if err != nil {
+			t.Fatalf("parseConfig() error: %v", err)
+		}
+		if cfg.defaultSafeToEvict == nil || *cfg.defaultSafeToEvict {
+			t.Fatalf("expected default safe-to-evict false, got %v", cfg.defaultSafeToEvict)
+		}
+
+		fs = flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		if _, err := parseConfig(fs, []string{"--default-safe-to-evict=sometimes"}); err == nil {
+			t.Fatalf("expected an error for an invalid policy")
+		}
+	})
+
+	t.Run("chaos flags", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		cfg, err := parseConfig(fs, []string{"--chaos-error-rate=0.25", "--chaos-seed=7"})
+		if !operator.ChaosBuild {
+			if err == nil {
+				t.Fatalf("expected --chaos-error-rate to be rejected outside chaos builds")
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("parseConfig() error: %v", err)
+		}
+		if cfg.chaosErrorRate != 0.25 || cfg.chaosSeed != 7 {
+			t.Fatalf("unexpected chaos config: rate %v seed %d", cfg.chaosErrorRate, cfg.chaosSeed)
+		}
+
+		fs = flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		if _, err := parseConfig(fs, []string{"--chaos-error-rate=1.5"}); err == nil {
+			t.Fatalf("expected an error for a rate above 1")
+		}
 	})
 }
 
@@ -97,6 +160,7 @@ func TestNewManagerOptions(t *testing.T) {
 	cfg := &operatorConfig{
 		metricsAddr:          "localhost:9999",
 		probeAddr:            "localhost:9998",
+		pprofAddr:            "127.0.0.1:6060",
 		enableLeaderElection: true,
 	}
 
@@ -111,10 +175,144 @@ func TestNewManagerOptions(t *testing.T) {
 	if opts.HealthProbeBindAddress != "localhost:9998" {
 		t.Fatalf("unexpected probe addr: %q", opts.HealthProbeBindAddress)
 	}
+	if opts.PprofBindAddress != "127.0.0.1:6060" {
+		t.Fatalf("unexpected pprof addr: %q", opts.PprofBindAddress)
+	}
 	if !opts.LeaderElection {
 		t.Fatalf("expected leader election enabled")
 	}
 	if opts.LeaderElectionID != "mcp-runtime-operator.mcpruntime.org" {
 		t.Fatalf("unexpected leader election id: %q", opts.LeaderElectionID)
 	}
+	if opts.Cache.ByObject != nil {
+		t.Fatalf("expected no cache restrictions without a maintenance namespace")
+	}
+}
+
+func TestNewManagerOptionsMaintenanceNamespace(t *testing.T) {
+	opts := newManagerOptions(&operatorConfig{maintenanceNamespace: "mcp-runtime"})
+
+	if len(opts.Cache.ByObject) != 1 {
+		t.Fatalf("expected one cache restriction, got %d", len(opts.Cache.ByObject))
+	}
+	for obj, byObject := range opts.Cache.ByObject {
+		if _, ok := obj.(*corev1.ConfigMap); !ok {
+			t.Fatalf("expected ConfigMap cache restriction, got %T", obj)
+		}
+		if _, ok := byObject.Namespaces["mcp-runtime"]; !ok || len(byObject.Namespaces) != 2 {
+			t.Fatalf("unexpected ConfigMap cache namespaces: %v", byObject.Namespaces)
+		}
+		assertEnvSourceCache(t, byObject)
+	}
+}
+
+func TestNewManagerOptionsSyncNamespace(t *testing.T) {
+	opts := newManagerOptions(&operatorConfig{maintenanceNamespace: "mcp-runtime", syncNamespace: "shared"})
+
+	if len(opts.Cache.ByObject) != 2 {
+		t.Fatalf("expected two cache restrictions, got %d", len(opts.Cache.ByObject))
+	}
+	for obj, byObject := range opts.Cache.ByObject {
+		switch obj.(type) {
+		case *corev1.ConfigMap:
+			_, maintenance := byObject.Namespaces["mcp-runtime"]
+			_, sync := byObject.Namespaces["shared"]
+			if !maintenance || !sync || len(byObject.Namespaces) != 3 {
+				t.Fatalf("unexpected ConfigMap cache namespaces: %v", byObject.Namespaces)
+			}
+			assertEnvSourceCache(t, byObject)
+		case *corev1.Secret:
+			if _, ok := byObject.Namespaces["shared"]; !ok || len(byObject.Namespaces) != 2 {
+				t.Fatalf("unexpected Secret cache namespaces: %v", byObject.Namespaces)
+			}
+			assertEnvSourceCache(t, byObject)
+		default:
+			t.Fatalf("unexpected cache restriction for %T", obj)
+		}
+	}
+}
+
+// assertEnvSourceCache checks that objects in other namespaces are cached only when labeled
+// as envFrom sources.
+func assertEnvSourceCache(t *testing.T, byObject cache.ByObject) {
+	t.Helper()
+	config, ok := byObject.Namespaces[cache.AllNamespaces]
+	if !ok || config.LabelSelector == nil {
+		t.Fatalf("expected a label-selected cache for other namespaces, got %v", byObject.Namespaces)
+	}
+	if !config.LabelSelector.Matches(labels.Set{operator.LabelEnvSource: "true"}) || config.LabelSelector.Matches(labels.Set{}) {
+		t.Fatalf("unexpected env source selector %s", config.LabelSelector)
+	}
+}
+
+func TestQuotaConfigFromEnv(t *testing.T) {
+	t.Run("disabled_returns_nil", func(t *testing.T) {
+		getenv := func(string) string { return "" }
+		if got := quotaConfigFromEnv(getenv); got != nil {
+			t.Fatalf("expected nil config when quota is disabled")
+		}
+	})
+
+	t.Run("applies_overrides_and_defaults", func(t *testing.T) {
+		env := map[string]string{
+			"MCP_NAMESPACE_QUOTA":    "true",
+			"MCP_QUOTA_REQUESTS_CPU": "2",
+			"MCP_QUOTA_PODS":         "5",
+		}
+		getenv := func(key string) string { return env[key] }
+
+		got := quotaConfigFromEnv(getenv)
+		if got == nil {
+			t.Fatalf("expected config")
+		}
+		if got.RequestsCPU != "2" || got.Pods != "5" {
+			t.Fatalf("overrides not applied: %+v", *got)
+		}
+		if got.RequestsMemory != operator.DefaultQuotaRequestsMemory || got.MaxCPU != operator.DefaultLimitRangeMaxCPU {
+			t.Fatalf("defaults not applied: %+v", *got)
+		}
+		if err := got.Validate(); err != nil {
+			t.Fatalf("expected valid config: %v", err)
+		}
+	})
+}
+
+func TestSignatureConfigFromEnv(t *testing.T) {
+	t.Run("disabled_returns_nil", func(t *testing.T) {
+		getenv := func(string) string { return "" }
+		if got := signatureConfigFromEnv(getenv); got != nil {
+			t.Fatalf("expected nil config when verification is disabled")
+		}
+	})
+
+	t.Run("key_based", func(t *testing.T) {
+		env := map[string]string{
+			"MCP_VERIFY_IMAGE_SIGNATURES": "true",
+			"MCP_COSIGN_PUBLIC_KEY":       "/etc/cosign/cosign.pub",
+		}
+		getenv := func(key string) string { return env[key] }
+
+		got := signatureConfigFromEnv(getenv)
+		if got == nil {
+			t.Fatalf("expected config")
+		}
+		if got.PublicKey != "/etc/cosign/cosign.pub" {
+			t.Fatalf("unexpected public key %q", got.PublicKey)
+		}
+		if err := got.Validate(); err != nil {
+			t.Fatalf("expected valid config: %v", err)
+		}
+	})
+
+	t.Run("enabled_without_key_is_invalid", func(t *testing.T) {
+		getenv := func(key string) string {
+			if key == "MCP_VERIFY_IMAGE_SIGNATURES" {
+				return "true"
+			}
+			return ""
+		}
+		if err := signatureConfigFromEnv(getenv).Validate(); err == nil {
+			t.Fatalf("expected error without key or identity")
+		}
+	})
 }
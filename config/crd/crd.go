@@ -0,0 +1,10 @@
+// Package crd embeds the platform's CustomResourceDefinitions so the CLI can validate
+// manifests without cluster access.
+package crd
+
+import _ "embed"
+
+// MCPServer is the MCPServer CustomResourceDefinition.
+//
+//go:embed bases/mcpruntime.org_mcpservers.yaml
+var MCPServer []byte
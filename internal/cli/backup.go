@@ -0,0 +1,472 @@
+package cli
+
+// This file implements the "backup" command, which exports platform state to a tarball and
+// restores it: all MCPServers, the operator's environment (its configuration), registry
+// credential secrets, and optionally the list of images in the internal registry.
+// Restoring into a fresh cluster expects "mcp-runtime setup" to have run there first.
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// backupFormatVersion is bumped when the archive layout changes incompatibly.
+const backupFormatVersion = 1
+
+// Archive entries.
+const (
+	backupMetadataFile    = "backup.json"
+	backupServersFile     = "mcpservers.json"
+	backupSecretsFile     = "secrets.json"
+	backupOperatorEnvFile = "operator-env.json"
+	backupImagesFile      = "images.txt"
+)
+
+// backupMetadata describes a backup archive.
+type backupMetadata struct {
+	Version   int    `json:"version"`
+	CreatedAt string `json:"createdAt"`
+	Servers   int    `json:"servers"`
+	Secrets   int    `json:"secrets"`
+	Images    int    `json:"images"`
+}
+
+// serverAssignedFields are metadata fields dropped on export so objects can be
+// created in another cluster.
+var serverAssignedFields = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"}
+
+// BackupManager exports and restores platform state with injected dependencies.
+type BackupManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewBackupManager creates a BackupManager with the given dependencies.
+func NewBackupManager(kubectl *KubectlClient, logger *zap.Logger) *BackupManager {
+	return &BackupManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultBackupManager returns a BackupManager using default clients.
+func DefaultBackupManager(logger *zap.Logger) *BackupManager {
+	return NewBackupManager(kubectlClient, logger)
+}
+
+// NewBackupCmd returns the backup subcommand.
+func NewBackupCmd(logger *zap.Logger) *cobra.Command {
+	return NewBackupCmdWithManager(DefaultBackupManager(logger))
+}
+
+// NewBackupCmdWithManager returns the backup subcommand using the provided manager.
+func NewBackupCmdWithManager(mgr *BackupManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up and restore platform state",
+		Long:  "Export MCPServers, operator configuration and registry credentials to a tarball, and restore them into a cluster",
+	}
+
+	cmd.AddCommand(mgr.newBackupCreateCmd())
+	cmd.AddCommand(mgr.newBackupRestoreCmd())
+
+	return cmd
+}
+
+func (m *BackupManager) newBackupCreateCmd() *cobra.Command {
+	var output string
+	var includeImages bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Export platform state to a tarball",
+		Long: `Export all MCPServers, the operator's environment and the registry credential
+secrets to a gzipped tarball. The archive contains credentials; store it securely.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				output = fmt.Sprintf("mcp-runtime-backup-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+			}
+			return m.Create(output, includeImages)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Archive to write (default mcp-runtime-backup-<timestamp>.tar.gz)")
+	cmd.Flags().BoolVar(&includeImages, "include-images", false, "Also record the images in the internal registry")
+
+	return cmd
+}
+
+func (m *BackupManager) newBackupRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore platform state from a tarball",
+		Long: `Apply the secrets, operator environment and MCPServers from a backup archive to the
+current cluster. Run "mcp-runtime setup" on a new cluster first so the CRD and operator exist.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Restore(args[0])
+		},
+	}
+}
+
+// Create writes a backup archive of the current cluster to output.
+func (m *BackupManager) Create(output string, includeImages bool) error {
+	entries, meta, err := m.collect(includeImages)
+	if err != nil {
+		return m.backupError(ErrCreateBackupFailed, err, "Failed to collect platform state", map[string]any{"output": output})
+	}
+	if err := writeBackupArchive(output, entries); err != nil {
+		return m.backupError(ErrCreateBackupFailed, err, "Failed to write backup archive", map[string]any{"output": output})
+	}
+
+	Success(fmt.Sprintf("Backup written to %s", output))
+	TableBoxed([][]string{
+		{"Item", "Count"},
+		{"MCPServers", fmt.Sprint(meta.Servers)},
+		{"Secrets", fmt.Sprint(meta.Secrets)},
+		{"Images", fmt.Sprint(meta.Images)},
+	})
+	Warn("The archive contains registry credentials; store it securely")
+	return nil
+}
+
+// collect reads the platform state into archive entries.
+func (m *BackupManager) collect(includeImages bool) (map[string][]byte, backupMetadata, error) {
+	meta := backupMetadata{Version: backupFormatVersion, CreatedAt: time.Now().UTC().Format(time.RFC3339)}
+	entries := map[string][]byte{}
+
+	Info("Exporting MCPServers")
+	// #nosec G204 -- fixed kubectl command.
+	out, err := m.kubectl.Output([]string{"get", "mcpservers", "--all-namespaces", "-o", "json"})
+	if err != nil {
+		return nil, meta, fmt.Errorf("list MCPServers: %w", err)
+	}
+	servers, err := sanitizeObjectList(out)
+	if err != nil {
+		return nil, meta, fmt.Errorf("parse MCPServers: %w", err)
+	}
+	meta.Servers = len(servers)
+	if entries[backupServersFile], err = marshalObjectList(servers); err != nil {
+		return nil, meta, err
+	}
+
+	Info("Exporting registry credentials")
+	var secrets []map[string]any
+	for _, ref := range backupSecretRefs(servers) {
+		namespace, name, _ := strings.Cut(ref, "/")
+		// #nosec G204 -- secret names from MCPServer specs and constants; kubectl validates names.
+		out, err := m.kubectl.Output([]string{"get", "secret", name, "-n", namespace, "-o", "json", "--ignore-not-found"})
+		if err != nil {
+			return nil, meta, fmt.Errorf("get secret %s: %w", ref, err)
+		}
+		if len(bytes.TrimSpace(out)) == 0 {
+			continue
+		}
+		var secret map[string]any
+		if err := json.Unmarshal(out, &secret); err != nil {
+			return nil, meta, fmt.Errorf("parse secret %s: %w", ref, err)
+		}
+		secrets = append(secrets, sanitizeObject(secret))
+	}
+	meta.Secrets = len(secrets)
+	if entries[backupSecretsFile], err = marshalObjectList(secrets); err != nil {
+		return nil, meta, err
+	}
+
+	Info("Exporting operator configuration")
+	// #nosec G204 -- fixed kubectl command.
+	env, err := m.kubectl.Output([]string{"get", "deployment", OperatorDeploymentName, "-n", NamespaceMCPRuntime, "-o", "jsonpath={.spec.template.spec.containers[0].env}"})
+	if err != nil {
+		Warn(fmt.Sprintf("Operator deployment not readable, skipping its configuration: %v", err))
+	} else if len(bytes.TrimSpace(env)) > 0 {
+		entries[backupOperatorEnvFile] = env
+	}
+
+	if includeImages {
+		Info("Listing registry images")
+		images, err := m.registryImages()
+		if err != nil {
+			return nil, meta, fmt.Errorf("list registry images: %w", err)
+		}
+		meta.Images = len(images)
+		entries[backupImagesFile] = []byte(strings.Join(images, "\n") + "\n")
+	}
+
+	if entries[backupMetadataFile], err = json.MarshalIndent(meta, "", "  "); err != nil {
+		return nil, meta, err
+	}
+	return entries, meta, nil
+}
+
+// backupSecretRefs returns namespace/name of the default registry secrets and
+// every image pull secret referenced by servers, sorted and deduplicated.
+func backupSecretRefs(servers []map[string]any) []string {
+	refs := map[string]bool{
+		NamespaceMCPRuntime + "/" + defaultRegistrySecretName: true,
+		serverNamespace() + "/" + defaultRegistrySecretName:   true,
+	}
+	for _, server := range servers {
+		metadata, _ := server["metadata"].(map[string]any)
+		spec, _ := server["spec"].(map[string]any)
+		namespace, _ := metadata["namespace"].(string)
+		pullSecrets, _ := spec["imagePullSecrets"].([]any)
+		for _, s := range pullSecrets {
+			if name, ok := s.(string); ok && name != "" && namespace != "" {
+				refs[namespace+"/"+name] = true
+			}
+		}
+	}
+	out := make([]string, 0, len(refs))
+	for ref := range refs {
+		out = append(out, ref)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// registryImages returns repo:tag for every tag in the internal registry.
+func (m *BackupManager) registryImages() ([]string, error) {
+	get := internalRegistryAPI(m.kubectl, NamespaceRegistry)
+	out, err := get("/v2/_catalog?n=10000")
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	repos, err := parseRegistryCatalog(out)
+	if err != nil {
+		return nil, err
+	}
+	var images []string
+	for _, repo := range repos {
+		out, err := get("/v2/" + repo + "/tags/list")
+		if err != nil {
+			return nil, fmt.Errorf("list tags of %s: %w", repo, err)
+		}
+		var tags struct {
+			Tags []string `json:"tags"`
+		}
+		if err := json.Unmarshal(out, &tags); err != nil {
+			return nil, fmt.Errorf("parse tags of %s: %w", repo, err)
+		}
+		for _, tag := range tags.Tags {
+			images = append(images, repo+":"+tag)
+		}
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// Restore applies a backup archive to the current cluster.
+func (m *BackupManager) Restore(path string) error {
+	entries, err := readBackupArchive(path)
+	if err != nil {
+		return m.backupError(ErrInvalidBackup, err, "Failed to read backup archive", map[string]any{"archive": path})
+	}
+	var meta backupMetadata
+	if err := json.Unmarshal(entries[backupMetadataFile], &meta); err != nil {
+		return m.backupError(ErrInvalidBackup, fmt.Errorf("read %s: %w", backupMetadataFile, err), "Invalid backup archive", map[string]any{"archive": path})
+	}
+	if meta.Version != backupFormatVersion {
+		return m.backupError(ErrInvalidBackup, fmt.Errorf("unsupported backup version %d (want %d)", meta.Version, backupFormatVersion), "Invalid backup archive", map[string]any{"archive": path})
+	}
+	Info(fmt.Sprintf("Restoring backup from %s", meta.CreatedAt))
+
+	if meta.Secrets > 0 {
+		Info(fmt.Sprintf("Restoring %d secret(s)", meta.Secrets))
+		if err := m.applyManifest(entries[backupSecretsFile]); err != nil {
+			return m.backupError(ErrRestoreBackupFailed, err, "Failed to restore secrets", map[string]any{"archive": path})
+		}
+	}
+
+	if env := entries[backupOperatorEnvFile]; len(env) > 0 {
+		Info("Restoring operator configuration")
+		if err := m.restoreOperatorEnv(env); err != nil {
+			return m.backupError(ErrRestoreBackupFailed, err, "Failed to restore operator configuration", map[string]any{"archive": path})
+		}
+	}
+
+	if meta.Servers > 0 {
+		Info(fmt.Sprintf("Restoring %d MCPServer(s)", meta.Servers))
+		if err := m.applyManifest(entries[backupServersFile]); err != nil {
+			return m.backupError(ErrRestoreBackupFailed, err, "Failed to restore MCPServers", map[string]any{"archive": path})
+		}
+	}
+
+	Success("Backup restored")
+	if meta.Images > 0 {
+		Info(fmt.Sprintf("The backup lists %d registry image(s); re-push them with:", meta.Images))
+		Info(fmt.Sprintf("  tar -xzf %s %s && mcp-runtime registry push --image-file %s", path, backupImagesFile, backupImagesFile))
+	}
+	return nil
+}
+
+// applyManifest applies a JSON manifest through stdin.
+func (m *BackupManager) applyManifest(manifest []byte) error {
+	// #nosec G204 -- fixed kubectl command; manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(bytes.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	return cmd.Run()
+}
+
+// restoreOperatorEnv replaces the operator container's environment with the backed up one.
+func (m *BackupManager) restoreOperatorEnv(env []byte) error {
+	var vars []any
+	if err := json.Unmarshal(env, &vars); err != nil {
+		return fmt.Errorf("parse %s: %w", backupOperatorEnvFile, err)
+	}
+	patch, err := json.Marshal([]map[string]any{{
+		"op":    "replace",
+		"path":  "/spec/template/spec/containers/0/env",
+		"value": vars,
+	}})
+	if err != nil {
+		return err
+	}
+	// #nosec G204 -- fixed deployment; patch built from the backup archive.
+	return m.kubectl.RunWithOutput([]string{"patch", "deployment", OperatorDeploymentName, "-n", NamespaceMCPRuntime, "--type=json", "-p", string(patch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+}
+
+func (m *BackupManager) backupError(sentinel, err error, msg string, context map[string]any) error {
+	context["component"] = "backup"
+	wrappedErr := wrapWithSentinelAndContext(sentinel, err, fmt.Sprintf("%s: %v", strings.ToLower(msg), err), context)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
+
+// sanitizeObjectList parses a kubectl List and strips server-assigned fields from its items.
+func sanitizeObjectList(data []byte) ([]map[string]any, error) {
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		list.Items[i] = sanitizeObject(list.Items[i])
+	}
+	return list.Items, nil
+}
+
+// sanitizeObject drops status and server-assigned metadata from obj.
+func sanitizeObject(obj map[string]any) map[string]any {
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		for _, field := range serverAssignedFields {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	return obj
+}
+
+// marshalObjectList renders items as an indented v1 List.
+func marshalObjectList(items []map[string]any) ([]byte, error) {
+	if items == nil {
+		items = []map[string]any{}
+	}
+	return json.MarshalIndent(map[string]any{"apiVersion": "v1", "kind": "List", "items": items}, "", "  ")
+}
+
+// writeBackupArchive writes entries to a gzipped tarball readable only by the owner.
+func writeBackupArchive(path string, entries map[string][]byte) error {
+	// #nosec G304 -- archive path from CLI flag.
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	now := time.Now()
+	for _, name := range names {
+		data := entries[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: now}); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// maxBackupEntrySize bounds a single archive entry when reading.
+const maxBackupEntrySize = 64 << 20
+
+// readBackupArchive reads the entries of a gzipped tarball.
+func readBackupArchive(path string) (map[string][]byte, error) {
+	// #nosec G304 -- archive path from CLI argument.
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	entries := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > maxBackupEntrySize {
+			return nil, fmt.Errorf("entry %s too large (%d bytes)", hdr.Name, hdr.Size)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBackupEntrySize))
+		if err != nil {
+			return nil, err
+		}
+		entries[hdr.Name] = data
+	}
+	if _, ok := entries[backupMetadataFile]; !ok {
+		return nil, fmt.Errorf("%s missing", backupMetadataFile)
+	}
+	return entries, nil
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const backupTestServers = `{"apiVersion":"v1","kind":"List","items":[{
+  "apiVersion":"mcpruntime.org/v1alpha1","kind":"MCPServer",
+  "metadata":{"name":"app","namespace":"team-a","uid":"123","resourceVersion":"42","creationTimestamp":"2025-01-01T00:00:00Z",
+    "annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}"}},
+  "spec":{"image":"app","imagePullSecrets":["team-a-pull"]},
+  "status":{"phase":"Running"}}]}`
+
+func backupTestExecutor(applied *[]string) *MockExecutor {
+	return &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "get mcpservers"):
+			cmd.OutputData = []byte(backupTestServers)
+		case strings.HasPrefix(args, "get secret team-a-pull -n team-a"):
+			cmd.OutputData = []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"team-a-pull","namespace":"team-a","uid":"9"},"data":{".dockerconfigjson":"e30="}}`)
+		case strings.HasPrefix(args, "get deployment"):
+			cmd.OutputData = []byte(`[{"name":"MCP_INGRESS_TLS","value":"true"}]`)
+		case strings.Contains(args, "/v2/_catalog"):
+			cmd.OutputData = []byte(`{"repositories":["app"]}`)
+		case strings.Contains(args, "/v2/app/tags/list"):
+			cmd.OutputData = []byte(`{"name":"app","tags":["v2","v1"]}`)
+		case args == "apply -f -":
+			cmd.RunFunc = func() error {
+				data, _ := io.ReadAll(cmd.StdinR)
+				*applied = append(*applied, string(data))
+				return nil
+			}
+		}
+		return cmd
+	}}
+}
+
+func TestBackupSecretRefs(t *testing.T) {
+	servers := []map[string]any{{
+		"metadata": map[string]any{"namespace": "team-a"},
+		"spec":     map[string]any{"imagePullSecrets": []any{"pull", "pull"}},
+	}}
+	got := strings.Join(backupSecretRefs(servers), ",")
+	want := "mcp-runtime/mcp-runtime-registry-creds,mcp-servers/mcp-runtime-registry-creds,team-a/pull"
+	if got != want {
+		t.Fatalf("secret refs = %q, want %q", got, want)
+	}
+}
+
+func TestBackupCreateAndRestore(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	var applied []string
+	mock := backupTestExecutor(&applied)
+	mgr := NewBackupManager(&KubectlClient{exec: mock}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.Create(archive, true); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	entries, err := readBackupArchive(archive)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	var meta backupMetadata
+	if err := json.Unmarshal(entries[backupMetadataFile], &meta); err != nil {
+		t.Fatalf("metadata: %v", err)
+	}
+	if meta.Servers != 1 || meta.Secrets != 1 || meta.Images != 2 {
+		t.Fatalf("unexpected metadata %+v", meta)
+	}
+	servers := string(entries[backupServersFile])
+	for _, field := range []string{`"uid"`, `"resourceVersion"`, `"status"`, "last-applied-configuration"} {
+		if strings.Contains(servers, field) {
+			t.Fatalf("expected %s to be stripped:\n%s", field, servers)
+		}
+	}
+	if got := string(entries[backupImagesFile]); got != "app:v1\napp:v2\n" {
+		t.Fatalf("unexpected images %q", got)
+	}
+
+	mock.Commands = nil
+	if err := mgr.Restore(archive); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if len(applied) != 2 || !strings.Contains(applied[0], `"kind": "Secret"`) || !strings.Contains(applied[1], `"kind": "MCPServer"`) {
+		t.Fatalf("expected secrets then servers to be applied, got %v", applied)
+	}
+	var patched bool
+	for _, c := range mock.Commands {
+		if c.Name == "kubectl" && len(c.Args) > 0 && c.Args[0] == "patch" {
+			patched = strings.Contains(strings.Join(c.Args, " "), "MCP_INGRESS_TLS")
+		}
+	}
+	if !patched {
+		t.Fatalf("expected operator env to be patched, got %v", mock.Commands)
+	}
+}
+
+func TestBackupRestoreRejectsInvalidArchive(t *testing.T) {
+	mgr := NewBackupManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	missing := filepath.Join(t.TempDir(), "missing.tar.gz")
+	if err := mgr.Restore(missing); !errors.Is(err, ErrInvalidBackup) {
+		t.Fatalf("expected ErrInvalidBackup, got %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "future.tar.gz")
+	if err := writeBackupArchive(archive, map[string][]byte{backupMetadataFile: []byte(`{"version":99}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Restore(archive); !errors.Is(err, ErrInvalidBackup) {
+		t.Fatalf("expected ErrInvalidBackup for unknown version, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ package cli
 // Example usage:
 //   mcp-runtime server build image my-server --tag v1.0.0
 //   mcp-runtime server build image my-server --dockerfile custom.Dockerfile --registry my-registry.com
+//   mcp-runtime server build image my-server --context ./server --in-cluster --builder buildkit
 
 import (
 	"fmt"
@@ -31,13 +32,21 @@ func newBuildImageCmd(logger *zap.Logger) *cobra.Command {
 	var registryURL string
 	var tag string
 	var context string
+	var inCluster bool
+	var builder string
 
 	cmd := &cobra.Command{
 		Use:   "image <server-name>",
 		Short: "Build Docker image for an MCP server",
-		Long:  `Build a Docker image from Dockerfile and update metadata file.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Build a Docker image from Dockerfile and update metadata file.
+
+With --in-cluster the build runs in a kaniko or buildkit pod that pushes straight
+to the platform registry, so no local docker daemon is needed.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if inCluster {
+				return buildImageInClusterForServer(logger, args[0], dockerfile, metadataFile, metadataDir, registryURL, tag, context, builder, registryNamespace())
+			}
 			return buildImage(logger, args[0], dockerfile, metadataFile, metadataDir, registryURL, tag, context)
 		},
 	}
@@ -48,6 +57,8 @@ func newBuildImageCmd(logger *zap.Logger) *cobra.Command {
 	cmd.Flags().StringVar(&registryURL, "registry", "", "Registry URL (defaults to platform registry)")
 	cmd.Flags().StringVar(&tag, "tag", "", "Image tag (defaults to git SHA or 'latest')")
 	cmd.Flags().StringVar(&context, "context", ".", "Build context directory")
+	cmd.Flags().BoolVar(&inCluster, "in-cluster", false, "Build inside the cluster and push to the platform registry (no local docker needed)")
+	cmd.Flags().StringVar(&builder, "builder", builderKaniko, "In-cluster builder ("+strings.Join(imageBuilders, "|")+")")
 
 	return cmd
 }
@@ -71,19 +82,13 @@ func buildImage(logger *zap.Logger, serverName, dockerfile, metadataFile, metada
 
 	// Build Docker image
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	buildCmd, err := execCommandWithValidators("docker", []string{
-		"build",
-		"-f", dockerfile,
-		"-t", fullImage,
-		context,
-	})
+	buildArgs := append([]string{"build", "-f", dockerfile, "-t", fullImage}, proxyBuildArgs()...)
+	buildCmd, err := execCommandWithValidators(containerTool(), append(buildArgs, context))
 	if err != nil {
 		return err
 	}
-	buildCmd.SetStdout(os.Stdout)
-	buildCmd.SetStderr(os.Stderr)
 
-	if err := buildCmd.Run(); err != nil {
+	if err := runStep("build", buildCmd); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrBuildImageFailed,
 			err,
@@ -105,6 +110,46 @@ func buildImage(logger *zap.Logger, serverName, dockerfile, metadataFile, metada
 	return nil
 }
 
+func buildImageInClusterForServer(logger *zap.Logger, serverName, dockerfile, metadataFile, metadataDir, registryURL, tag, context, builder, namespace string) error {
+	if err := validateImageBuilder(builder); err != nil {
+		Error("Invalid builder")
+		logStructuredError(logger, err, "Invalid builder")
+		return err
+	}
+	relDockerfile, err := dockerfileInContext(context, dockerfile)
+	if err != nil {
+		Error("Invalid dockerfile")
+		logStructuredError(logger, err, "Invalid dockerfile")
+		return err
+	}
+
+	if registryURL == "" {
+		registryURL = getPlatformRegistryURL(logger)
+	}
+	if tag == "" {
+		tag = getGitTag()
+	}
+
+	logger.Info("Building image in cluster", zap.String("server", serverName), zap.String("builder", builder))
+
+	imageName := fmt.Sprintf("%s/%s", registryURL, serverName)
+	if err := buildImageInCluster(kubectlClient, logger, inClusterBuild{
+		Builder:     builder,
+		Namespace:   namespace,
+		Context:     context,
+		Dockerfile:  relDockerfile,
+		Destination: fmt.Sprintf("%s:%s", imageName, tag),
+	}); err != nil {
+		return err
+	}
+
+	if err := updateMetadataImage(serverName, imageName, tag, metadataFile, metadataDir); err != nil {
+		logger.Warn("Failed to update metadata", zap.Error(err))
+	}
+
+	return nil
+}
+
 func updateMetadataImage(serverName, imageName, tag, metadataFile, metadataDir string) error {
 	// Find the metadata file containing this server
 	var targetFile string
@@ -188,6 +233,12 @@ func updateMetadataImage(serverName, imageName, tag, metadataFile, metadataDir s
 }
 
 func getPlatformRegistryURL(logger *zap.Logger) string {
+	// A local registry advertised by the cluster (kind) is reachable from this machine
+	// and from the nodes under the same name, so it wins over the in-cluster registry.
+	if host := localRegistryHost(kubectlClient, logger); host != "" {
+		return host
+	}
+
 	// Try to get from kubectl
 	// #nosec G204 -- fixed arguments, no user input.
 	ipCmd, ipErr := kubectlClient.CommandArgs([]string{"get", "service", "registry", "-n", "registry", "-o", "jsonpath={.spec.clusterIP}"})
@@ -0,0 +1,259 @@
+package cli
+
+// This file implements in-cluster image builds for "server build image --in-cluster".
+// The build context is streamed as a gzipped tarball to a short-lived kaniko or buildkit
+// pod that pushes straight to the platform registry, so no local docker daemon is needed
+// (and arm64 workstations can build images for amd64 nodes).
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	builderKaniko   = "kaniko"
+	builderBuildkit = "buildkit"
+)
+
+// imageBuilders lists the in-cluster builders.
+var imageBuilders = []string{builderKaniko, builderBuildkit}
+
+// inClusterBuild describes one in-cluster build.
+type inClusterBuild struct {
+	Builder   string
+	Namespace string
+	// Context is the local build context directory.
+	Context string
+	// Dockerfile is the Dockerfile path relative to Context (slash-separated).
+	Dockerfile  string
+	Destination string
+}
+
+// validateImageBuilder checks that builder is a supported in-cluster builder.
+func validateImageBuilder(builder string) error {
+	if !slices.Contains(imageBuilders, builder) {
+		return newWithSentinel(ErrInvalidBuilder, fmt.Sprintf("unsupported builder %q (use one of: %s)", builder, strings.Join(imageBuilders, ", ")))
+	}
+	return nil
+}
+
+// dockerfileInContext returns the Dockerfile path relative to the build context.
+// A relative dockerfile is resolved against the current directory, like docker build -f.
+func dockerfileInContext(contextDir, dockerfile string) (string, error) {
+	absContext, err := filepath.Abs(contextDir)
+	if err != nil {
+		return "", err
+	}
+	absDockerfile, err := filepath.Abs(dockerfile)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absContext, absDockerfile)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", newWithSentinel(ErrInvalidBuilder, fmt.Sprintf("dockerfile %q must be inside the build context %q for in-cluster builds", dockerfile, contextDir))
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// archiveBuildContext writes dir to w as a gzipped tarball, skipping .git.
+func archiveBuildContext(dir string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path) // #nosec G304 -- walking the user-selected build context.
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// builderDockerConfigDir is where builder pods read the registry pull secret as config.json.
+const builderDockerConfigDir = "/kaniko/.docker"
+
+// builderPodOverrides returns the strategic-merge override of the builder pod. With
+// pushSecret set, the dockerconfigjson secret is mounted as the builder's docker config;
+// with proxySecret set, the environment is loaded from it.
+func builderPodOverrides(podName, builder, pushSecret, proxySecret string) (string, error) {
+	container := map[string]any{"name": podName}
+	if proxySecret != "" {
+		container["envFrom"] = proxyEnvFrom(proxySecret)
+	}
+	spec := map[string]any{"containers": []any{container}}
+	if builder == builderBuildkit {
+		// Rootless buildkit needs unconfined seccomp/AppArmor profiles to create its user namespace.
+		container["securityContext"] = map[string]any{
+			"seccompProfile":  map[string]string{"type": "Unconfined"},
+			"appArmorProfile": map[string]string{"type": "Unconfined"},
+		}
+	}
+	if pushSecret != "" {
+		container["volumeMounts"] = []any{map[string]any{"name": "docker-config", "mountPath": builderDockerConfigDir, "readOnly": true}}
+		spec["volumes"] = []any{map[string]any{
+			"name": "docker-config",
+			"secret": map[string]any{
+				"secretName": pushSecret,
+				"optional":   true,
+				"items":      []any{map[string]string{"key": ".dockerconfigjson", "path": "config.json"}},
+			},
+		}}
+	}
+	if len(container) == 1 {
+		return "", nil
+	}
+	out, err := json.Marshal(map[string]any{"apiVersion": "v1", "spec": spec})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// builderRunArgs returns the "kubectl run" arguments of the builder pod, which reads
+// the gzipped build context from stdin and is removed when the build exits.
+func builderRunArgs(podName string, b inClusterBuild, pushSecret string) ([]string, error) {
+	args := []string{"run", podName, "-n", b.Namespace, "-i", "--rm", "--quiet", "--restart=Never"}
+	overrides, err := builderPodOverrides(podName, b.Builder, pushSecret, proxyPodSecretName())
+	if err != nil {
+		return nil, err
+	}
+	if overrides != "" {
+		args = append(args, "--override-type=strategic", "--overrides="+overrides)
+	}
+	if b.Builder == builderBuildkit {
+		script := fmt.Sprintf("mkdir -p /tmp/context && tar -xzf - -C /tmp/context && buildctl-daemonless.sh build"+
+			" --frontend dockerfile.v0 --local context=/tmp/context --local dockerfile=/tmp/context"+
+			" --opt filename=%s --output type=image,name=%s,push=true,registry.insecure=true",
+			shellQuote(b.Dockerfile), shellQuote(b.Destination))
+		return append(args, "--image="+GetBuildkitImage(), "--env=BUILDKITD_FLAGS=--oci-worker-no-process-sandbox",
+			"--env=DOCKER_CONFIG="+builderDockerConfigDir, "--command", "--", "sh", "-c", script), nil
+	}
+	// The platform registry serves plain HTTP inside the cluster.
+	return append(args, "--image="+GetKanikoImage(), "--",
+		"--context=tar://stdin", "--dockerfile="+b.Dockerfile, "--destination="+b.Destination,
+		"--insecure", "--skip-tls-verify"), nil
+}
+
+// buildImageInCluster builds and pushes an image with a builder pod in the cluster.
+func buildImageInCluster(kubectl *KubectlClient, logger *zap.Logger, b inClusterBuild) error {
+	var archive bytes.Buffer
+	if err := archiveBuildContext(b.Context, &archive); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrArchiveContextFailed,
+			err,
+			fmt.Sprintf("failed to archive build context %q: %v", b.Context, err),
+			map[string]any{"context": b.Context, "component": "build"},
+		)
+		Error("Failed to archive build context")
+		logStructuredError(logger, wrappedErr, "Failed to archive build context")
+		return wrappedErr
+	}
+
+	podName := fmt.Sprintf("image-builder-%d", time.Now().UnixNano())
+	Info(fmt.Sprintf("Building %s with %s in namespace %s (context %s)", b.Destination, b.Builder, b.Namespace, formatBytes(int64(archive.Len()))))
+
+	// An authenticated internal registry needs its pull secret in the builder namespace;
+	// setup only creates it in the platform namespaces.
+	var pushSecret string
+	if creds, ok := internalRegistryCredentials(kubectl); ok {
+		pushSecret = registryPullSecretName
+		if !slices.Contains(registryPullSecretNamespaces(), b.Namespace) {
+			if err := ensureImagePullSecretWithKubectl(kubectl, b.Namespace, pushSecret, imageRegistry(b.Destination), creds.Username, creds.Password); err != nil {
+				wrappedErr := wrapWithSentinelAndContext(
+					ErrBuildImageFailed,
+					err,
+					fmt.Sprintf("failed to create push secret %s in %s: %v", pushSecret, b.Namespace, err),
+					map[string]any{"namespace": b.Namespace, "secret": pushSecret, "component": "build"},
+				)
+				Error("Failed to create push secret")
+				logStructuredError(logger, wrappedErr, "Failed to create push secret")
+				return wrappedErr
+			}
+		}
+	}
+	err := ensureProxyPodSecret(kubectl, b.Namespace)
+	var args []string
+	if err == nil {
+		args, err = builderRunArgs(podName, b, pushSecret)
+	}
+	var cmd Command
+	if err == nil {
+		// #nosec G204 -- builder images from config; destination and dockerfile validated and shell-quoted.
+		cmd, err = kubectl.CommandArgs(args)
+	}
+	if err == nil {
+		cmd.SetStdin(&archive)
+		cmd.SetStdout(DefaultPrinter.Stdout())
+		cmd.SetStderr(DefaultPrinter.Stderr())
+		err = cmd.Run()
+	}
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrBuildImageFailed,
+			err,
+			fmt.Sprintf("in-cluster build of %s failed: %v", b.Destination, err),
+			map[string]any{"image": b.Destination, "builder": b.Builder, "namespace": b.Namespace, "pod": podName, "component": "build"},
+		)
+		Error("In-cluster build failed")
+		logStructuredError(logger, wrappedErr, "In-cluster build failed")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("Built and pushed %s", b.Destination))
+	return nil
+}
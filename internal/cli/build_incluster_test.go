@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestValidateImageBuilder(t *testing.T) {
+	for _, builder := range []string{"kaniko", "buildkit"} {
+		if err := validateImageBuilder(builder); err != nil {
+			t.Fatalf("unexpected error for %s: %v", builder, err)
+		}
+	}
+	if err := validateImageBuilder("docker"); !errors.Is(err, ErrInvalidBuilder) {
+		t.Fatalf("expected ErrInvalidBuilder, got %v", err)
+	}
+}
+
+func TestDockerfileInContext(t *testing.T) {
+	dir := t.TempDir()
+
+	rel, err := dockerfileInContext(dir, filepath.Join(dir, "build", "Dockerfile"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rel != "build/Dockerfile" {
+		t.Fatalf("expected build/Dockerfile, got %q", rel)
+	}
+
+	if _, err := dockerfileInContext(filepath.Join(dir, "app"), filepath.Join(dir, "Dockerfile")); !errors.Is(err, ErrInvalidBuilder) {
+		t.Fatalf("expected ErrInvalidBuilder for dockerfile outside context, got %v", err)
+	}
+}
+
+func TestArchiveBuildContext(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"Dockerfile":    "FROM scratch\n",
+		"src/main.go":   "package main\n",
+		".git/HEAD":     "ref: refs/heads/main\n",
+		".git/config":   "[core]\n",
+		"src/README.md": "docs\n",
+	} {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := archiveBuildContext(dir, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("archive is not gzipped: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read archive: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	for _, want := range []string{"Dockerfile", "src", "src/main.go", "src/README.md"} {
+		if !slices.Contains(names, want) {
+			t.Fatalf("expected %s in archive, got %v", want, names)
+		}
+	}
+	for _, name := range names {
+		if strings.HasPrefix(name, ".git") {
+			t.Fatalf("expected .git to be skipped, got %v", names)
+		}
+	}
+}
+
+func TestBuilderRunArgs(t *testing.T) {
+	b := inClusterBuild{Builder: builderKaniko, Namespace: "registry", Dockerfile: "Dockerfile", Destination: "10.0.0.1:5000/app:v1"}
+
+	runArgs, err := builderRunArgs("image-builder-1", b, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := strings.Join(runArgs, " ")
+	if strings.Contains(args, "--overrides") {
+		t.Fatalf("expected no overrides for kaniko without a push secret, got %q", args)
+	}
+	for _, want := range []string{"run image-builder-1 -n registry -i --rm", "--image=" + GetKanikoImage(), "--context=tar://stdin", "--destination=10.0.0.1:5000/app:v1", "--insecure"} {
+		if !strings.Contains(args, want) {
+			t.Fatalf("expected %q in kaniko args %q", want, args)
+		}
+	}
+
+	b.Builder = builderBuildkit
+	b.Dockerfile = "it's/Dockerfile"
+	if runArgs, err = builderRunArgs("image-builder-1", b, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args = strings.Join(runArgs, " ")
+	for _, want := range []string{"--image=" + GetBuildkitImage(), "BUILDKITD_FLAGS=--oci-worker-no-process-sandbox", `"seccompProfile":{"type":"Unconfined"}`, "tar -xzf - -C /tmp/context", `--opt filename='it'\''s/Dockerfile'`, "name='10.0.0.1:5000/app:v1',push=true"} {
+		if !strings.Contains(args, want) {
+			t.Fatalf("expected %q in buildkit args %q", want, args)
+		}
+	}
+}
+
+func TestBuilderRunArgsPushSecret(t *testing.T) {
+	b := inClusterBuild{Builder: builderKaniko, Namespace: "registry", Dockerfile: "Dockerfile", Destination: "10.0.0.1:5000/app:v1"}
+
+	runArgs, err := builderRunArgs("image-builder-1", b, registryPullSecretName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := strings.Join(runArgs, " ")
+	for _, want := range []string{`"secretName":"registry-pull-creds"`, `"mountPath":"/kaniko/.docker"`, `"path":"config.json"`} {
+		if !strings.Contains(args, want) {
+			t.Fatalf("expected %q in args %q", want, args)
+		}
+	}
+}
+
+func TestBuildImageInCluster(t *testing.T) {
+	clearProxyEnv(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	b := inClusterBuild{Builder: builderKaniko, Namespace: "registry", Context: dir, Dockerfile: "Dockerfile", Destination: "registry.local/app:v1"}
+
+	t.Run("streams context to builder pod", func(t *testing.T) {
+		var stdin []byte
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			if spec.Args[0] == "run" {
+				cmd.RunFunc = func() error {
+					var err error
+					stdin, err = io.ReadAll(cmd.StdinR)
+					return err
+				}
+			}
+			return cmd
+		}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := buildImageInCluster(&KubectlClient{exec: mock}, zap.NewNop(), b); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if last := mock.LastCommand(); last.Args[0] != "run" || contains(last.Args, "--overrides") {
+			t.Fatalf("expected kubectl run without overrides, got %v", last.Args)
+		}
+		if _, err := gzip.NewReader(bytes.NewReader(stdin)); err != nil {
+			t.Fatalf("expected gzipped context on stdin: %v", err)
+		}
+		if !strings.Contains(buf.String(), "Built and pushed registry.local/app:v1") {
+			t.Fatalf("expected success output, got %q", buf.String())
+		}
+	})
+
+	t.Run("creates the push secret in the builder namespace", func(t *testing.T) {
+		var secret []byte
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			switch {
+			case contains(spec.Args, registryAuthSecretName):
+				cmd.OutputData = registryAuthSecretOutput("mcp-runtime", "s3cret")
+			case spec.Args[0] == "apply":
+				cmd.RunFunc = func() error {
+					var err error
+					secret, err = io.ReadAll(cmd.StdinR)
+					return err
+				}
+			}
+			return cmd
+		}
+		setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+		team := b
+		team.Namespace = "team-a"
+		if err := buildImageInCluster(&KubectlClient{exec: mock}, zap.NewNop(), team); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(secret), "name: "+registryPullSecretName) || !strings.Contains(string(secret), "namespace: team-a") {
+			t.Fatalf("expected the push secret to be applied in team-a, got %q", secret)
+		}
+		if last := mock.LastCommand(); last.Args[0] != "run" || !strings.Contains(strings.Join(last.Args, " "), registryPullSecretName) {
+			t.Fatalf("expected the builder to mount the push secret, got %v", last.Args)
+		}
+	})
+
+	t.Run("wraps builder failures", func(t *testing.T) {
+		mock := &MockExecutor{DefaultRunErr: errors.New("pod terminated (Error)")}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := buildImageInCluster(&KubectlClient{exec: mock}, zap.NewNop(), b); !errors.Is(err, ErrBuildImageFailed) {
+			t.Fatalf("expected ErrBuildImageFailed, got %v", err)
+		}
+	})
+
+	t.Run("fails on missing context", func(t *testing.T) {
+		mock := &MockExecutor{}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		missing := b
+		missing.Context = filepath.Join(dir, "missing")
+		if err := buildImageInCluster(&KubectlClient{exec: mock}, zap.NewNop(), missing); !errors.Is(err, ErrArchiveContextFailed) {
+			t.Fatalf("expected ErrArchiveContextFailed, got %v", err)
+		}
+		if len(mock.Commands) != 0 {
+			t.Fatalf("expected no kubectl calls, got %v", mock.Commands)
+		}
+	})
+}
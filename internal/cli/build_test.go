@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -30,7 +31,7 @@ func TestNewBuildImageCmd(t *testing.T) {
 			t.Fatal("newBuildImageCmd should have flags")
 		}
 
-		expectedFlags := []string{"dockerfile", "metadata-file", "metadata-dir", "registry", "tag", "context"}
+		expectedFlags := []string{"dockerfile", "metadata-file", "metadata-dir", "registry", "tag", "context", "in-cluster", "builder"}
 		for _, name := range expectedFlags {
 			if flags.Lookup(name) == nil {
 				t.Errorf("expected flag %q not found", name)
@@ -231,7 +232,7 @@ type validatorFailingExecutor struct {
 	err error
 }
 
-func (v *validatorFailingExecutor) Command(name string, args []string, validators ...ExecValidator) (Command, error) {
+func (v *validatorFailingExecutor) Command(ctx context.Context, name string, args []string, validators ...ExecValidator) (Command, error) {
 	return nil, v.err
 }
 
@@ -5,7 +5,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -88,7 +87,7 @@ func (m *CertManager) newCertWaitCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout for certificate readiness (default from MCP_CERT_TIMEOUT)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Timeout for certificate readiness (default from MCP_RUNTIME_CERT_TIMEOUT)")
 	return cmd
 }
 
@@ -225,12 +224,12 @@ func checkCertificateWithKubectl(kubectl KubectlRunner, name, namespace string)
 
 func applyClusterIssuerWithKubectl(kubectl KubectlRunner) error {
 	// #nosec G204 -- fixed file path from repository.
-	return kubectl.RunWithOutput([]string{"apply", "-f", clusterIssuerManifestPath}, os.Stdout, os.Stderr)
+	return kubectl.RunWithOutput([]string{"apply", "-f", clusterIssuerManifestPath}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
 
 func applyRegistryCertificateWithKubectl(kubectl KubectlRunner) error {
 	// #nosec G204 -- fixed file path from repository.
-	return kubectl.RunWithOutput([]string{"apply", "-f", registryCertificateManifestPath}, os.Stdout, os.Stderr)
+	return kubectl.RunWithOutput([]string{"apply", "-f", registryCertificateManifestPath}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
 
 func waitForCertificateReadyWithKubectl(kubectl KubectlRunner, name, namespace string, timeout time.Duration) error {
@@ -239,5 +238,5 @@ func waitForCertificateReadyWithKubectl(kubectl KubectlRunner, name, namespace s
 		"wait", "--for=condition=Ready",
 		"certificate/" + name, "-n", namespace,
 		fmt.Sprintf("--timeout=%s", timeout),
-	}, os.Stdout, os.Stderr)
+	}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
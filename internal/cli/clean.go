@@ -0,0 +1,85 @@
+package cli
+
+// This file implements "clean": it removes the temporary manifests, image archives, kind
+// configs and kustomizations that commands killed before their cleanup ran left behind, in
+// the working directory and the system temp directory.
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// NewCleanCmd returns the clean command.
+func NewCleanCmd(logger *zap.Logger) *cobra.Command {
+	var olderThan time.Duration
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove temporary files left by interrupted commands",
+		Long: `Remove the temporary files mcp-runtime commands leave behind when they are killed
+before cleaning up: rendered manifests (manager-*.yaml, mcpserver-*.yaml), image archives
+(mcp-img-*.tar), kind configs and operator kustomizations. The working directory and the
+system temp directory are searched; files newer than --older-than are kept, since they may
+belong to a command that is still running.`,
+		Example: `  mcp-runtime clean --dry-run
+  mcp-runtime clean --older-than 0`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cleanTempFiles(logger, ".", os.TempDir(), olderThan, dryRun)
+		},
+	}
+
+	cmd.Flags().DurationVar(&olderThan, "older-than", time.Hour, "Only remove files last modified longer ago than this")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the files without removing them")
+
+	return cmd
+}
+
+// cleanTempFiles removes the temporary files in workDir and tempDir older than olderThan.
+func cleanTempFiles(logger *zap.Logger, workDir, tempDir string, olderThan time.Duration, dryRun bool) error {
+	now := time.Now()
+	stale, err := findStaleTempFiles(workDir, tempDir, now.Add(-olderThan))
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(ErrCleanTempFilesFailed, err, fmt.Sprintf("failed to list temporary files: %v", err), map[string]any{"work_dir": workDir, "temp_dir": tempDir, "component": "clean"})
+		Error("Failed to list temporary files")
+		logStructuredError(logger, wrappedErr, "Failed to list temporary files")
+		return wrappedErr
+	}
+	if len(stale) == 0 {
+		Info("No leftover temporary files")
+		return nil
+	}
+
+	rows := [][]string{{"PATH", "SIZE", "AGE"}}
+	var total int64
+	for _, f := range stale {
+		rows = append(rows, []string{f.Path, formatBytes(f.Size), humanAge(f.ModTime, now)})
+		total += f.Size
+	}
+	Table(rows)
+	if dryRun {
+		Info(fmt.Sprintf("Would remove %d files (%s)", len(stale), formatBytes(total)))
+		return nil
+	}
+
+	var errs []error
+	for _, f := range stale {
+		if err := os.RemoveAll(f.Path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(ErrCleanTempFilesFailed, err, fmt.Sprintf("failed to remove temporary files: %v", err), map[string]any{"work_dir": workDir, "temp_dir": tempDir, "component": "clean"})
+		Error("Failed to remove temporary files")
+		logStructuredError(logger, wrappedErr, "Failed to remove temporary files")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("Removed %d files (%s)", len(stale), formatBytes(total)))
+	return nil
+}
@@ -1,39 +1,75 @@
 package cli
 
 // This file implements KubectlClient, a wrapper around kubectl command execution.
-// It provides validation, security checks, and a clean interface for running kubectl commands.
+// It configures execx.Kubectl from the CLI settings (timeout, selected context) and adds
+// tracing spans and the CLI's error sentinels on top of it.
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"os"
+	"time"
+
+	"mcp-runtime/pkg/execx"
 )
 
 // KubectlClient wraps kubectl command execution with validation.
+// It holds no mutable state and is safe for concurrent use.
 type KubectlClient struct {
 	exec       Executor
 	validators []ExecValidator
+	// timeout bounds each kubectl invocation; zero disables the limit.
+	timeout time.Duration
+	// kubeContext, when set, is passed as --context to every invocation.
+	kubeContext string
 }
 
 // NewKubectlClient creates a KubectlClient with default validators.
 func NewKubectlClient(exec Executor) (*KubectlClient, error) {
-	root, err := os.Getwd()
+	kubectl, err := execx.NewKubectl(exec)
 	if err != nil {
 		return nil, wrapWithSentinel(ErrGetWorkingDirectoryFailed, err, fmt.Sprintf("get working directory: %v", err))
 	}
 	return &KubectlClient{
-		exec: exec,
-		validators: []ExecValidator{
-			NoControlChars(), // Prevent YAML/command injection via control chars
-			PathUnder(root),
-		},
+		exec:        exec,
+		validators:  kubectl.Validators,
+		timeout:     GetKubectlTimeout(),
+		kubeContext: loadKubeContext(),
 	}, nil
 }
 
-// CommandArgs builds a kubectl command with the given arguments.
-// Validates arguments against configured validators before building.
+// kubectl returns the execx client configured like c.
+func (c *KubectlClient) kubectl() *execx.Kubectl {
+	return &execx.Kubectl{Exec: c.exec, Validators: c.validators, Timeout: c.timeout, Context: c.kubeContext}
+}
+
+// CommandArgs builds a kubectl command bound to the current command context.
 func (c *KubectlClient) CommandArgs(args []string) (Command, error) {
-	return c.exec.Command("kubectl", args, c.validators...)
+	return c.CommandArgsContext(commandContext(), args)
+}
+
+// CommandArgsContext builds a kubectl command with the given arguments.
+// Validates arguments against configured validators before building.
+// The command is killed when ctx is cancelled or the per-command timeout expires.
+// Each execution of the returned command is recorded as a tracing span.
+func (c *KubectlClient) CommandArgsContext(ctx context.Context, args []string) (Command, error) {
+	kubectl := c.kubectl()
+	cmd, err := kubectl.CommandArgsContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return newTracedCommand(&sentinelCommand{Command: cmd}, "kubectl", kubectl.WithContext(args)), nil
+}
+
+// withContext prepends --context for the selected context unless args already name one.
+func (c *KubectlClient) withContext(args []string) []string {
+	return c.kubectl().WithContext(args)
+}
+
+// timeoutFor returns the timeout for a kubectl invocation; see execx.Kubectl.TimeoutFor.
+func (c *KubectlClient) timeoutFor(args []string) time.Duration {
+	return c.kubectl().TimeoutFor(args)
 }
 
 // Output runs kubectl with the given arguments and returns stdout.
@@ -74,6 +110,42 @@ func (c *KubectlClient) RunWithOutput(args []string, stdout, stderr io.Writer) e
 	return cmd.Run()
 }
 
+// sentinelCommand reports deadline and interrupt failures with the CLI's sentinels.
+type sentinelCommand struct {
+	Command
+}
+
+func (c *sentinelCommand) Output() ([]byte, error) {
+	out, err := c.Command.Output()
+	return out, wrapKubectlContextErr(err)
+}
+
+func (c *sentinelCommand) CombinedOutput() ([]byte, error) {
+	out, err := c.Command.CombinedOutput()
+	return out, wrapKubectlContextErr(err)
+}
+
+func (c *sentinelCommand) Run() error {
+	return wrapKubectlContextErr(c.Command.Run())
+}
+
+// wrapKubectlContextErr maps an execx.ContextError to ErrCommandTimeout or ErrCommandCanceled.
+func wrapKubectlContextErr(err error) error {
+	var ctxErr *execx.ContextError
+	if !errors.As(err, &ctxErr) {
+		return err
+	}
+	if ctxErr.TimedOut() {
+		return wrapWithSentinelAndContext(
+			ErrCommandTimeout,
+			err,
+			fmt.Sprintf("kubectl %s timed out after %s (set MCP_RUNTIME_KUBECTL_TIMEOUT to adjust)", ctxErr.Verb, ctxErr.Timeout),
+			map[string]any{"command": "kubectl " + ctxErr.Verb, "timeout": ctxErr.Timeout.String(), "component": "kubectl"},
+		)
+	}
+	return wrapWithSentinel(ErrCommandCanceled, err, fmt.Sprintf("kubectl %s interrupted", ctxErr.Verb))
+}
+
 var kubectlClient = mustNewKubectlClient()
 
 func mustNewKubectlClient() *KubectlClient {
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// contextExecutor returns commands that block until their context is done.
+type contextExecutor struct{}
+
+func (contextExecutor) Command(ctx context.Context, name string, args []string, validators ...ExecValidator) (Command, error) {
+	return &MockCommand{Args: args, RunFunc: func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}, nil
+}
+
+func TestKubectlClientTimeoutFor(t *testing.T) {
+	client := &KubectlClient{timeout: time.Minute}
+
+	tests := []struct {
+		name string
+		args []string
+		want time.Duration
+	}{
+		{"default", []string{"get", "pods"}, time.Minute},
+		{"explicit_timeout_flag", []string{"wait", "--for=condition=Ready", "pod/x", "--timeout=60s"}, 2 * time.Minute},
+		{"explicit_timeout_arg", []string{"rollout", "status", "deploy/x", "--timeout", "5m"}, 6 * time.Minute},
+		{"follow_logs", []string{"logs", "-l", "app=x", "-f"}, 0},
+		{"apply_file_is_bounded", []string{"apply", "-f", "x.yaml"}, time.Minute},
+		{"watch", []string{"get", "pods", "-w"}, 0},
+		{"port_forward", []string{"port-forward", "svc/registry", "5000:5000"}, 0},
+		{"attached_run", []string{"run", "builder", "-i", "--rm", "--image=kaniko"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.timeoutFor(tt.args); got != tt.want {
+				t.Fatalf("timeoutFor(%v) = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("zero_disables", func(t *testing.T) {
+		if got := (&KubectlClient{}).timeoutFor([]string{"get", "pods"}); got != 0 {
+			t.Fatalf("expected no timeout, got %s", got)
+		}
+	})
+}
+
+func TestKubectlClientContext(t *testing.T) {
+	t.Run("reports_timeout", func(t *testing.T) {
+		client := &KubectlClient{exec: contextExecutor{}, timeout: 10 * time.Millisecond}
+
+		err := client.Run([]string{"get", "pods"})
+		if !errors.Is(err, ErrCommandTimeout) {
+			t.Fatalf("expected ErrCommandTimeout, got %v", err)
+		}
+	})
+
+	t.Run("reports_cancellation", func(t *testing.T) {
+		client := &KubectlClient{exec: contextExecutor{}}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		cmd, err := client.CommandArgsContext(ctx, []string{"get", "pods"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := cmd.Run(); !errors.Is(err, ErrCommandCanceled) {
+			t.Fatalf("expected ErrCommandCanceled, got %v", err)
+		}
+	})
+
+	t.Run("uses_command_context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetCommandContext(ctx)
+		t.Cleanup(func() { SetCommandContext(context.Background()) })
+		client := &KubectlClient{exec: contextExecutor{}}
+
+		if err := client.Run([]string{"get", "pods"}); !errors.Is(err, ErrCommandCanceled) {
+			t.Fatalf("expected ErrCommandCanceled, got %v", err)
+		}
+	})
+}
+
+func TestSleepContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -20,6 +20,8 @@ type ingressOptions struct {
 	mode     string
 	manifest string
 	force    bool
+	// images retargets the ingress controller image in offline setup.
+	images []offlineImage
 }
 
 // ClusterManager handles cluster operations with injected dependencies.
@@ -61,6 +63,7 @@ func NewClusterCmdWithManager(mgr *ClusterManager) *cobra.Command {
 	cmd.AddCommand(mgr.newClusterStatusCmd())
 	cmd.AddCommand(mgr.newClusterConfigCmd())
 	cmd.AddCommand(mgr.newClusterProvisionCmd())
+	cmd.AddCommand(mgr.newClusterDeleteCmd())
 	cmd.AddCommand(mgr.newClusterCertCmd())
 
 	return cmd
@@ -69,18 +72,43 @@ func NewClusterCmdWithManager(mgr *ClusterManager) *cobra.Command {
 func (m *ClusterManager) newClusterInitCmd() *cobra.Command {
 	var kubeconfig string
 	var context string
+	var withQuotas bool
+	quota := DefaultQuotaOptions()
 
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize cluster configuration",
 		Long:  "Initialize and configure the Kubernetes cluster for MCP platform",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.InitCluster(kubeconfig, context)
+			if withQuotas {
+				// Fail on bad quota flags before touching the cluster.
+				if err := quota.Validate(); err != nil {
+					Error("Invalid quota settings")
+					logStructuredError(m.logger, err, "Invalid quota settings")
+					return err
+				}
+			}
+			if err := m.InitCluster(kubeconfig, context); err != nil {
+				return err
+			}
+			if !withQuotas {
+				return nil
+			}
+			m.logger.Info("Applying namespace quota", zap.String("namespace", serverNamespace()))
+			return m.ApplyNamespaceQuotas(serverNamespace(), quota)
 		},
 	}
 
 	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
 	cmd.Flags().StringVar(&context, "context", "", "Kubernetes context to use")
+	cmd.Flags().BoolVar(&withQuotas, "with-quotas", false, "Create a ResourceQuota and LimitRange in the servers namespace")
+	cmd.Flags().StringVar(&quota.RequestsCPU, "quota-requests-cpu", quota.RequestsCPU, "Total CPU requests allowed in the namespace (with --with-quotas)")
+	cmd.Flags().StringVar(&quota.RequestsMemory, "quota-requests-memory", quota.RequestsMemory, "Total memory requests allowed in the namespace (with --with-quotas)")
+	cmd.Flags().StringVar(&quota.LimitsCPU, "quota-limits-cpu", quota.LimitsCPU, "Total CPU limits allowed in the namespace (with --with-quotas)")
+	cmd.Flags().StringVar(&quota.LimitsMemory, "quota-limits-memory", quota.LimitsMemory, "Total memory limits allowed in the namespace (with --with-quotas)")
+	cmd.Flags().IntVar(&quota.Pods, "quota-pods", quota.Pods, "Maximum number of pods in the namespace (with --with-quotas)")
+	cmd.Flags().StringVar(&quota.MaxCPU, "quota-max-cpu", quota.MaxCPU, "Largest CPU limit a single container may request (with --with-quotas)")
+	cmd.Flags().StringVar(&quota.MaxMemory, "quota-max-memory", quota.MaxMemory, "Largest memory limit a single container may request (with --with-quotas)")
 
 	return cmd
 }
@@ -155,13 +183,23 @@ func (m *ClusterManager) newClusterProvisionCmd() *cobra.Command {
 	var region string
 	var nodeCount int
 	var clusterName string
+	var localRegistry bool
+	var eks eksOptions
 
 	cmd := &cobra.Command{
 		Use:   "provision",
 		Short: "Provision a new cluster",
-		Long:  "Provision a new Kubernetes cluster (requires cloud provider credentials)",
+		Long: `Provision a new Kubernetes cluster (requires cloud provider credentials).
+
+With kind, a local registry (kind-registry) is started on localhost:5001 and wired into the
+cluster, so images pushed with 'docker push localhost:5001/<image>' can be pulled by pods
+under the same name. Disable it with --local-registry=false.
+
+With eks, the node group and VPC flags map to eksctl flags; pass --config-file to use a
+complete eksctl ClusterConfig instead. Re-running provision resumes: an existing cluster is
+not recreated and only a missing node group is added.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.ProvisionCluster(provider, region, nodeCount, clusterName)
+			return m.ProvisionCluster(provider, region, nodeCount, clusterName, localRegistry, eks)
 		},
 	}
 
@@ -169,6 +207,18 @@ func (m *ClusterManager) newClusterProvisionCmd() *cobra.Command {
 	cmd.Flags().StringVar(&region, "region", "us-west-1", "Region for cluster")
 	cmd.Flags().IntVar(&nodeCount, "nodes", 3, "Number of nodes")
 	cmd.Flags().StringVar(&clusterName, "name", defaultClusterName, "Cluster name (used by supported providers)")
+	cmd.Flags().BoolVar(&localRegistry, "local-registry", true, "Run a local registry on localhost:5001 for the cluster (kind only)")
+	cmd.Flags().StringVar(&eks.nodeType, "node-type", "", "Node instance type; a comma-separated list with --spot (eks only)")
+	cmd.Flags().BoolVar(&eks.spot, "spot", false, "Use spot instead of on-demand instances (eks only)")
+	cmd.Flags().IntVar(&eks.minNodes, "nodes-min", 0, "Minimum size of the managed node group (eks only)")
+	cmd.Flags().IntVar(&eks.maxNodes, "nodes-max", 0, "Maximum size of the managed node group (eks only)")
+	cmd.Flags().StringVar(&eks.kubernetesVersion, "kubernetes-version", "", "Kubernetes version, e.g. 1.30 (eks only)")
+	cmd.Flags().StringVar(&eks.nodegroupName, "nodegroup-name", "", "Name of the managed node group (eks only)")
+	cmd.Flags().StringVar(&eks.vpcCIDR, "vpc-cidr", "", "CIDR of a new VPC for the cluster (eks only)")
+	cmd.Flags().StringSliceVar(&eks.privateSubnets, "vpc-private-subnets", nil, "IDs of existing private subnets to use (eks only)")
+	cmd.Flags().StringSliceVar(&eks.publicSubnets, "vpc-public-subnets", nil, "IDs of existing public subnets to use (eks only)")
+	cmd.Flags().BoolVar(&eks.privateNetworking, "node-private-networking", false, "Place nodes in private subnets only (eks only)")
+	cmd.Flags().StringVar(&eks.configFile, "config-file", "", "eksctl ClusterConfig file passed through to eksctl; replaces the other eks flags (eks only)")
 
 	return cmd
 }
@@ -184,7 +234,7 @@ func (m *ClusterManager) InitCluster(kubeconfig, context string) error {
 	// Install CRD
 	m.logger.Info("Installing CRD")
 	// #nosec G204 -- fixed file path from repository.
-	if err := m.kubectl.Run([]string{"apply", "--validate=false", "-f", "config/crd/bases/mcpruntime.org_mcpservers.yaml"}); err != nil {
+	if err := m.kubectl.Run([]string{"apply", "--validate=false", "-f", "config/crd/bases"}); err != nil {
 		wrappedErr := wrapWithSentinel(ErrInstallCRDFailed, err, fmt.Sprintf("failed to install CRD: %v", err))
 		Error("Failed to install CRD")
 		logStructuredError(m.logger, wrappedErr, "Failed to install CRD")
@@ -205,16 +255,17 @@ func (m *ClusterManager) InitCluster(kubeconfig, context string) error {
 		return wrappedErr
 	}
 
-	m.logger.Info("Creating mcp-servers namespace")
-	if err := m.EnsureNamespace(NamespaceMCPServers); err != nil {
+	serversNamespace := serverNamespace()
+	m.logger.Info("Creating servers namespace", zap.String("namespace", serversNamespace))
+	if err := m.EnsureNamespace(serversNamespace); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrEnsureServersNamespaceFailed,
 			err,
-			fmt.Sprintf("failed to ensure mcp-servers namespace: %v", err),
-			map[string]any{"namespace": NamespaceMCPServers, "component": "cluster"},
+			fmt.Sprintf("failed to ensure servers namespace %s: %v", serversNamespace, err),
+			map[string]any{"namespace": serversNamespace, "component": "cluster"},
 		)
-		Error("Failed to ensure mcp-servers namespace")
-		logStructuredError(m.logger, wrappedErr, "Failed to ensure mcp-servers namespace")
+		Error("Failed to ensure servers namespace")
+		logStructuredError(m.logger, wrappedErr, "Failed to ensure servers namespace")
 		return wrappedErr
 	}
 
@@ -320,12 +371,12 @@ func configureEKSKubeconfig(exec Executor, region, clusterName, kubeconfig strin
 		args = append(args, "--kubeconfig", kubeconfig)
 	}
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	cmd, err := exec.Command("aws", args, AllowlistBins("aws"), NoShellMeta(), NoControlChars())
+	cmd, err := exec.Command(commandContext(), "aws", args, AllowlistBins("aws"), NoShellMeta(), NoControlChars())
 	if err != nil {
 		return err
 	}
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
 	return cmd.Run()
 }
 
@@ -347,21 +398,21 @@ func (m *ClusterManager) CheckClusterStatus() error {
 	// Check nodes
 	Section("Nodes")
 	// #nosec G204 -- fixed kubectl command.
-	if err := m.kubectl.RunWithOutput([]string{"get", "nodes"}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"get", "nodes"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		Warn(fmt.Sprintf("Failed to get nodes: %v", err))
 	}
 
 	// Check CRD
 	Section("MCP CRD")
 	// #nosec G204 -- fixed kubectl command.
-	if err := m.kubectl.RunWithOutput([]string{"get", "crd", MCPServerCRDName}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"get", "crd", MCPServerCRDName}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		Warn(fmt.Sprintf("Failed to get MCP CRD: %v", err))
 	}
 
 	// Check operator
 	Section("Operator")
 	// #nosec G204 -- fixed kubectl command with hardcoded namespace.
-	if err := m.kubectl.RunWithOutput([]string{"get", "pods", "-n", NamespaceMCPRuntime}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"get", "pods", "-n", NamespaceMCPRuntime}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		Warn(fmt.Sprintf("Failed to get operator pods: %v", err))
 	}
 
@@ -415,15 +466,14 @@ func (m *ClusterManager) ConfigureCluster(ingress ingressOptions) error {
 		}
 	}
 
-	args := []string{"apply"}
-	if useKustomize {
-		args = append(args, "-k", manifestArg)
-	} else {
-		args = append(args, "-f", manifest)
+	args, cleanup, err := platformApplyArgs(manifestArg, useKustomize, ingress.images)
+	if err != nil {
+		return wrapWithSentinel(ErrInstallIngressControllerFailed, err, fmt.Sprintf("failed to prepare ingress manifest %q: %v", manifest, err))
 	}
+	defer cleanup()
 
 	// #nosec G204 -- manifest path from internal config or CLI flag with file validation.
-	if err := m.kubectl.RunWithOutput(args, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrInstallIngressControllerFailed,
 			err,
@@ -440,17 +490,18 @@ func (m *ClusterManager) ConfigureCluster(ingress ingressOptions) error {
 	return nil
 }
 
-// ProvisionCluster provisions a new Kubernetes cluster.
-func (m *ClusterManager) ProvisionCluster(provider, region string, nodeCount int, clusterName string) error {
+// ProvisionCluster provisions a new Kubernetes cluster. localRegistry wires a local
+// registry into kind clusters and eks configures EKS clusters; other providers ignore them.
+func (m *ClusterManager) ProvisionCluster(provider, region string, nodeCount int, clusterName string, localRegistry bool, eks eksOptions) error {
 	m.logger.Info("Provisioning cluster", zap.String("provider", provider), zap.String("region", region), zap.String("name", clusterName))
 
 	switch provider {
 	case "kind":
-		return m.provisionKindCluster(nodeCount, clusterName)
+		return m.provisionKindCluster(nodeCount, clusterName, localRegistry)
 	case "gke":
 		return provisionGKECluster(m.logger, region, nodeCount, clusterName)
 	case "eks":
-		return provisionEKSCluster(m.logger, m.exec, region, nodeCount, clusterName)
+		return provisionEKSCluster(m.logger, m.exec, region, nodeCount, clusterName, eks)
 	case "aks":
 		return provisionAKSCluster(m.logger, region, nodeCount, clusterName)
 	default:
@@ -461,7 +512,7 @@ func (m *ClusterManager) ProvisionCluster(provider, region string, nodeCount int
 	}
 }
 
-func (m *ClusterManager) provisionKindCluster(nodeCount int, name string) error {
+func (m *ClusterManager) provisionKindCluster(nodeCount int, name string, localRegistry bool) error {
 	m.logger.Info("Provisioning Kind cluster")
 
 	clusterName := name
@@ -469,6 +520,25 @@ func (m *ClusterManager) provisionKindCluster(nodeCount int, name string) error
 		clusterName = defaultClusterName
 	}
 
+	rt, err := detectContainerRuntime(m.exec)
+	if err != nil {
+		Error("No container runtime found (run: mcp-runtime doctor)")
+		logStructuredError(m.logger, err, "No container runtime found")
+		return err
+	}
+	Info("Using container runtime: " + rt.String())
+	if err := useContainerRuntime(rt); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrSelectContainerRuntimeFailed,
+			err,
+			fmt.Sprintf("failed to select container runtime: %v", err),
+			map[string]any{"provider": rt.Provider, "source": rt.Source, "component": "cluster"},
+		)
+		Error("Failed to select container runtime")
+		logStructuredError(m.logger, wrappedErr, "Failed to select container runtime")
+		return wrappedErr
+	}
+
 	config := `kind: Cluster
 apiVersion: kind.x-k8s.io/v1alpha4
 nodes:
@@ -477,16 +547,22 @@ nodes:
 	for i := 1; i < nodeCount; i++ {
 		config += "- role: worker\n"
 	}
+	if localRegistry {
+		config += kindRegistryContainerdPatch
+		if err := m.ensureKindRegistry(rt.Provider); err != nil {
+			return m.kindRegistryError(err, clusterName)
+		}
+	}
 
 	// Write config to temp file
-	tmp, err := os.CreateTemp("", "mcp-kind-config-*.yaml")
+	tmp, err := tempKindConfig.create()
 	if err != nil {
 		wrappedErr := wrapWithSentinel(ErrCreateKindConfigFailed, err, fmt.Sprintf("failed to create temp kind config: %v", err))
 		Error("Failed to create kind config")
 		logStructuredError(m.logger, wrappedErr, "Failed to create kind config")
 		return wrappedErr
 	}
-	defer os.Remove(tmp.Name())
+	defer removeTempFile(tmp.Name())
 	if _, err := tmp.WriteString(config); err != nil {
 		if closeErr := tmp.Close(); closeErr != nil {
 			wrappedErr := wrapWithSentinel(ErrCloseKindConfigFailed, errors.Join(err, closeErr), fmt.Sprintf("failed to close kind config after write error: %v", closeErr))
@@ -507,14 +583,12 @@ nodes:
 	}
 
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	cmd, err := m.exec.Command("kind", []string{"create", "cluster", "--config", tmp.Name(), "--name", clusterName})
+	cmd, err := m.exec.Command(commandContext(), "kind", []string{"create", "cluster", "--config", tmp.Name(), "--name", clusterName})
 	if err != nil {
 		return err
 	}
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
 
-	if err := cmd.Run(); err != nil {
+	if err := runStep("kind", cmd); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrCreateKindClusterFailed,
 			err,
@@ -526,10 +600,29 @@ nodes:
 		return wrappedErr
 	}
 
+	if localRegistry {
+		if err := m.wireKindRegistry(rt.Provider, clusterName); err != nil {
+			return m.kindRegistryError(err, clusterName)
+		}
+		Success(fmt.Sprintf("Local registry ready: docker push %s/<image>", kindRegistryHost))
+	}
+
 	m.logger.Info("Kind cluster provisioned successfully")
 	return nil
 }
 
+func (m *ClusterManager) kindRegistryError(err error, clusterName string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrSetupKindRegistryFailed,
+		err,
+		fmt.Sprintf("failed to set up local registry: %v", err),
+		map[string]any{"cluster_name": clusterName, "registry": kindRegistryHost, "component": "cluster"},
+	)
+	Error("Failed to set up local registry")
+	logStructuredError(m.logger, wrappedErr, "Failed to set up local registry")
+	return wrappedErr
+}
+
 func provisionGKECluster(logger *zap.Logger, region string, nodeCount int, clusterName string) error {
 	if clusterName == "" {
 		clusterName = defaultClusterName
@@ -540,42 +633,6 @@ func provisionGKECluster(logger *zap.Logger, region string, nodeCount int, clust
 	return err
 }
 
-func provisionEKSCluster(logger *zap.Logger, exec Executor, region string, nodeCount int, clusterName string) error {
-	if clusterName == "" {
-		clusterName = defaultClusterName
-	}
-
-	args := []string{
-		"create",
-		"cluster",
-		"--name", clusterName,
-		"--region", region,
-		"--nodes", fmt.Sprintf("%d", nodeCount),
-	}
-	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	cmd, err := exec.Command("eksctl", args, AllowlistBins("eksctl"), NoShellMeta(), NoControlChars())
-	if err != nil {
-		return err
-	}
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
-
-	logger.Info("Provisioning EKS cluster with eksctl", zap.String("name", clusterName), zap.String("region", region), zap.Int("nodes", nodeCount))
-	if err := cmd.Run(); err != nil {
-		wrappedErr := wrapWithSentinelAndContext(
-			ErrProvisionEKSFailed,
-			err,
-			fmt.Sprintf("failed to provision EKS cluster: %v", err),
-			map[string]any{"cluster_name": clusterName, "region": region, "node_count": nodeCount, "component": "cluster"},
-		)
-		Error("Failed to provision EKS cluster")
-		logStructuredError(logger, wrappedErr, "Failed to provision EKS cluster")
-		return wrappedErr
-	}
-	logger.Info("EKS cluster provisioned successfully", zap.String("name", clusterName))
-	return nil
-}
-
 func provisionAKSCluster(logger *zap.Logger, region string, nodeCount int, clusterName string) error {
 	if clusterName == "" {
 		clusterName = defaultClusterName
@@ -599,8 +656,8 @@ metadata:
 		return err
 	}
 	cmd.SetStdin(strings.NewReader(nsYAML))
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
 	return cmd.Run()
 }
 
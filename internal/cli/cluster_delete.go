@@ -0,0 +1,263 @@
+package cli
+
+// This file implements "cluster delete", the counterpart of "cluster provision" for kind and
+// EKS. It asks for confirmation unless --yes is passed, deletes the cluster with kind or
+// eksctl, and then removes the kubeconfig contexts, clusters and users that pointed at it,
+// together with the mcp-runtime context selection when it named one of them.
+//
+// Example usage:
+//   mcp-runtime cluster delete --provider kind --name mcp-runtime
+//   mcp-runtime cluster delete --provider eks --name prod --region eu-west-1 --yes
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// confirmInput is where confirmation prompts read the answer; a test seam.
+var confirmInput io.Reader = os.Stdin
+
+// clusterDeleteOptions selects the cluster to delete.
+type clusterDeleteOptions struct {
+	Provider string
+	Name     string
+	Region   string
+	Yes      bool
+}
+
+// kubeconfigView is the part of "kubectl config view -o json" cleanup needs.
+type kubeconfigView struct {
+	Contexts []struct {
+		Name    string `json:"name"`
+		Context struct {
+			Cluster string `json:"cluster"`
+			User    string `json:"user"`
+		} `json:"context"`
+	} `json:"contexts"`
+	Clusters []struct {
+		Name string `json:"name"`
+	} `json:"clusters"`
+}
+
+// kubeconfigEntries are the kubeconfig entries of a deleted cluster.
+type kubeconfigEntries struct {
+	Contexts []string
+	Clusters []string
+	Users    []string
+}
+
+func (m *ClusterManager) newClusterDeleteCmd() *cobra.Command {
+	opts := clusterDeleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a cluster created with cluster provision",
+		Long: `Delete a kind or EKS cluster and remove the kubeconfig contexts that point at it.
+
+kind clusters are deleted with 'kind delete cluster'; the local registry container is kept
+for other clusters. EKS clusters are deleted with 'eksctl delete cluster', which waits until
+the node groups and the CloudFormation stacks are gone.
+
+The command asks for confirmation; pass --yes to skip it, e.g. in CI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.DeleteCluster(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Provider, "provider", "kind", "Cloud provider (kind, eks)")
+	cmd.Flags().StringVar(&opts.Name, "name", defaultClusterName, "Cluster name")
+	cmd.Flags().StringVar(&opts.Region, "region", "", "Region of the cluster (eks only; default: the AWS CLI region)")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Delete without asking for confirmation")
+
+	return cmd
+}
+
+// DeleteCluster deletes the cluster described by opts and cleans up its kubeconfig entries.
+func (m *ClusterManager) DeleteCluster(opts clusterDeleteOptions) error {
+	if opts.Name == "" {
+		opts.Name = defaultClusterName
+	}
+	if opts.Provider != "kind" && opts.Provider != "eks" {
+		err := newWithSentinel(ErrUnsupportedProvider, fmt.Sprintf("unsupported provider for cluster delete: %s (use kind or eks)", opts.Provider))
+		Error("Unsupported provider")
+		logStructuredError(m.logger, err, "Unsupported provider")
+		return err
+	}
+
+	target := fmt.Sprintf("%s cluster %q", opts.Provider, opts.Name)
+	if opts.Region != "" {
+		target += " in " + opts.Region
+	}
+	if !opts.Yes && !confirm(fmt.Sprintf("Delete %s? This cannot be undone", target)) {
+		err := newWithSentinel(ErrClusterDeleteAborted, "cluster delete not confirmed (pass --yes to skip the prompt)")
+		Error("Cluster delete aborted")
+		logStructuredError(m.logger, err, "Cluster delete aborted")
+		return err
+	}
+
+	m.logger.Info("Deleting cluster", zap.String("provider", opts.Provider), zap.String("name", opts.Name), zap.String("region", opts.Region))
+	var err error
+	if opts.Provider == "kind" {
+		err = m.deleteKindCluster(opts.Name)
+	} else {
+		err = m.deleteEKSCluster(opts.Name, opts.Region)
+	}
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrDeleteClusterFailed,
+			err,
+			fmt.Sprintf("failed to delete %s: %v", target, err),
+			map[string]any{"provider": opts.Provider, "cluster_name": opts.Name, "region": opts.Region, "component": "cluster"},
+		)
+		Error("Failed to delete cluster")
+		logStructuredError(m.logger, wrappedErr, "Failed to delete cluster")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("Deleted %s", target))
+
+	m.cleanupKubeconfig(opts.Provider, opts.Name)
+	return nil
+}
+
+func (m *ClusterManager) deleteKindCluster(name string) error {
+	rt, err := detectContainerRuntime(m.exec)
+	if err != nil {
+		return err
+	}
+	if err := useContainerRuntime(rt); err != nil {
+		return err
+	}
+	// #nosec G204 -- fixed kind command; name from a CLI flag.
+	cmd, err := m.exec.Command(commandContext(), "kind", []string{"delete", "cluster", "--name", name}, AllowlistBins("kind"), NoShellMeta(), NoControlChars())
+	if err != nil {
+		return err
+	}
+	return runStep("kind", cmd)
+}
+
+func (m *ClusterManager) deleteEKSCluster(name, region string) error {
+	args := []string{"delete", "cluster", "--name", name, "--wait"}
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	// #nosec G204 -- fixed eksctl command; name and region from CLI flags.
+	cmd, err := m.exec.Command(commandContext(), "eksctl", args, AllowlistBins("eksctl"), NoShellMeta(), NoControlChars())
+	if err != nil {
+		return err
+	}
+	return runStep("eksctl", cmd)
+}
+
+// cleanupKubeconfig removes the kubeconfig entries of the deleted cluster. Failures only warn:
+// the cluster is already gone.
+func (m *ClusterManager) cleanupKubeconfig(provider, name string) {
+	// #nosec G204 -- fixed kubectl command.
+	out, err := m.kubectl.Output([]string{"config", "view", "-o", "json"})
+	if err != nil {
+		Warn(fmt.Sprintf("Could not read kubeconfig to remove the cluster's contexts: %v", err))
+		return
+	}
+	var view kubeconfigView
+	if err := json.Unmarshal(out, &view); err != nil {
+		Warn(fmt.Sprintf("Could not parse kubeconfig to remove the cluster's contexts: %v", err))
+		return
+	}
+	entries := staleKubeconfigEntries(view, provider, name)
+
+	steps := [][]string{}
+	for _, context := range entries.Contexts {
+		steps = append(steps, []string{"config", "delete-context", context})
+	}
+	for _, cluster := range entries.Clusters {
+		steps = append(steps, []string{"config", "delete-cluster", cluster})
+	}
+	for _, user := range entries.Users {
+		steps = append(steps, []string{"config", "delete-user", user})
+	}
+	for _, args := range steps {
+		// #nosec G204 -- entry names come from the kubeconfig.
+		if _, err := m.kubectl.CombinedOutput(args); err != nil {
+			Warn(fmt.Sprintf("Could not remove kubeconfig entry %s: %v", args[2], err))
+		}
+	}
+	if len(entries.Contexts) > 0 {
+		Info(fmt.Sprintf("Removed kubeconfig contexts: %s", strings.Join(entries.Contexts, ", ")))
+	}
+
+	if saved := loadKubeContext(); saved != "" && slices.Contains(entries.Contexts, saved) {
+		if err := saveKubeContext(""); err != nil {
+			Warn(fmt.Sprintf("Could not clear the mcp-runtime context selection: %v", err))
+			return
+		}
+		Info(fmt.Sprintf("Cleared the mcp-runtime context selection %q", saved))
+	}
+}
+
+// staleKubeconfigEntries returns the contexts pointing at the cluster provider created as
+// name, those clusters, and the users no remaining context uses.
+func staleKubeconfigEntries(view kubeconfigView, provider, name string) kubeconfigEntries {
+	var entries kubeconfigEntries
+	for _, cluster := range view.Clusters {
+		if kubeconfigClusterMatches(provider, name, cluster.Name) {
+			entries.Clusters = append(entries.Clusters, cluster.Name)
+		}
+	}
+	removedUsers := map[string]bool{}
+	keptUsers := map[string]bool{}
+	for _, context := range view.Contexts {
+		if kubeconfigClusterMatches(provider, name, context.Context.Cluster) {
+			entries.Contexts = append(entries.Contexts, context.Name)
+			removedUsers[context.Context.User] = true
+			continue
+		}
+		keptUsers[context.Context.User] = true
+	}
+	for user := range removedUsers {
+		if user != "" && !keptUsers[user] {
+			entries.Users = append(entries.Users, user)
+		}
+	}
+	slices.Sort(entries.Users)
+	return entries
+}
+
+// kubeconfigClusterMatches reports whether a kubeconfig cluster entry belongs to the cluster
+// provider created as name: kind writes kind-<name>; eksctl writes <name>.<region>.eksctl.io
+// and aws eks update-kubeconfig the cluster ARN.
+func kubeconfigClusterMatches(provider, name, cluster string) bool {
+	if provider == "kind" {
+		return cluster == "kind-"+name
+	}
+	if strings.HasSuffix(cluster, ":cluster/"+name) {
+		return true
+	}
+	region, ok := strings.CutPrefix(cluster, name+".")
+	if !ok {
+		return false
+	}
+	region, ok = strings.CutSuffix(region, ".eksctl.io")
+	return ok && region != "" && !strings.Contains(region, ".")
+}
+
+// confirm asks question on the terminal and reports whether the answer is yes. It returns
+// false when there is no answer, such as in CI without a terminal.
+func confirm(question string) bool {
+	Warn(question + " [y/N]")
+	answer, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const testKubeconfigView = `{
+  "contexts": [
+    {"name": "kind-mcp-runtime", "context": {"cluster": "kind-mcp-runtime", "user": "kind-mcp-runtime"}},
+    {"name": "kind-other", "context": {"cluster": "kind-other", "user": "kind-other"}},
+    {"name": "admin@prod.eu-west-1.eksctl.io", "context": {"cluster": "prod.eu-west-1.eksctl.io", "user": "admin@prod.eu-west-1.eksctl.io"}},
+    {"name": "prod-arn", "context": {"cluster": "arn:aws:eks:eu-west-1:123456789012:cluster/prod", "user": "shared"}},
+    {"name": "staging", "context": {"cluster": "arn:aws:eks:eu-west-1:123456789012:cluster/prod-staging", "user": "shared"}}
+  ],
+  "clusters": [
+    {"name": "kind-mcp-runtime"},
+    {"name": "kind-other"},
+    {"name": "prod.eu-west-1.eksctl.io"},
+    {"name": "arn:aws:eks:eu-west-1:123456789012:cluster/prod"},
+    {"name": "arn:aws:eks:eu-west-1:123456789012:cluster/prod-staging"}
+  ]
+}`
+
+// deleteMock records commands and answers "kubectl config view" with testKubeconfigView.
+func deleteMock(runErr error) *MockExecutor {
+	return &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			if strings.Contains(strings.Join(spec.Args, " "), "config view") {
+				return &MockCommand{Args: spec.Args, OutputData: []byte(testKubeconfigView)}
+			}
+			if spec.Name == "kind" || spec.Name == "eksctl" {
+				return &MockCommand{Args: spec.Args, RunErr: runErr}
+			}
+			return &MockCommand{Args: spec.Args}
+		},
+	}
+}
+
+func withConfirmInput(t *testing.T, answer string) {
+	t.Helper()
+	original := confirmInput
+	t.Cleanup(func() { confirmInput = original })
+	confirmInput = strings.NewReader(answer)
+}
+
+func commandLines(mock *MockExecutor) []string {
+	lines := []string{}
+	for _, cmd := range mock.Commands {
+		lines = append(lines, strings.Join(cmd.Args, " "))
+	}
+	return lines
+}
+
+func TestDeleteCluster(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(kubeContextEnv, "")
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv(kindProviderEnv, "")
+
+	t.Run("eks with --yes removes its kubeconfig entries", func(t *testing.T) {
+		if err := saveKubeContext("prod-arn"); err != nil {
+			t.Fatal(err)
+		}
+		mock := deleteMock(nil)
+		mgr := NewClusterManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+
+		if err := mgr.DeleteCluster(clusterDeleteOptions{Provider: "eks", Name: "prod", Region: "eu-west-1", Yes: true}); err != nil {
+			t.Fatalf("DeleteCluster() error: %v", err)
+		}
+		want := []string{
+			"delete cluster --name prod --wait --region eu-west-1",
+			"config view -o json",
+			"config delete-context admin@prod.eu-west-1.eksctl.io",
+			"config delete-context prod-arn",
+			"config delete-cluster prod.eu-west-1.eksctl.io",
+			"config delete-cluster arn:aws:eks:eu-west-1:123456789012:cluster/prod",
+			"config delete-user admin@prod.eu-west-1.eksctl.io",
+		}
+		if got := commandLines(mock); !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected commands:\n got %q\nwant %q", got, want)
+		}
+		if saved := loadKubeContext(); saved != "" {
+			t.Fatalf("expected the saved context to be cleared, got %q", saved)
+		}
+	})
+
+	t.Run("kind after confirmation", func(t *testing.T) {
+		withConfirmInput(t, "y\n")
+		mock := deleteMock(nil)
+		mgr := NewClusterManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+
+		if err := mgr.DeleteCluster(clusterDeleteOptions{Provider: "kind", Name: "mcp-runtime"}); err != nil {
+			t.Fatalf("DeleteCluster() error: %v", err)
+		}
+		if !mock.HasCommand("kind") {
+			t.Fatalf("expected kind delete cluster, got %q", commandLines(mock))
+		}
+		got := commandLines(mock)
+		want := []string{"config delete-context kind-mcp-runtime", "config delete-cluster kind-mcp-runtime", "config delete-user kind-mcp-runtime"}
+		if !reflect.DeepEqual(got[len(got)-3:], want) {
+			t.Fatalf("unexpected kubeconfig cleanup %q", got)
+		}
+	})
+
+	t.Run("declined or unanswered prompt aborts", func(t *testing.T) {
+		for _, answer := range []string{"n\n", ""} {
+			withConfirmInput(t, answer)
+			mock := deleteMock(nil)
+			mgr := NewClusterManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+
+			err := mgr.DeleteCluster(clusterDeleteOptions{Provider: "eks", Name: "prod"})
+			if !errors.Is(err, ErrClusterDeleteAborted) {
+				t.Fatalf("answer %q: expected ErrClusterDeleteAborted, got %v", answer, err)
+			}
+			if len(mock.Commands) != 0 {
+				t.Fatalf("answer %q: expected no commands, got %q", answer, commandLines(mock))
+			}
+		}
+	})
+
+	t.Run("failed delete keeps kubeconfig", func(t *testing.T) {
+		mock := deleteMock(errors.New("exit status 1"))
+		mgr := NewClusterManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+
+		err := mgr.DeleteCluster(clusterDeleteOptions{Provider: "eks", Name: "prod", Yes: true})
+		if !errors.Is(err, ErrDeleteClusterFailed) {
+			t.Fatalf("expected ErrDeleteClusterFailed, got %v", err)
+		}
+		if len(mock.Commands) != 1 {
+			t.Fatalf("expected only the eksctl command, got %q", commandLines(mock))
+		}
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		mgr := NewClusterManager(&KubectlClient{exec: &MockExecutor{}}, &MockExecutor{}, zap.NewNop())
+		if err := mgr.DeleteCluster(clusterDeleteOptions{Provider: "gke", Yes: true}); !errors.Is(err, ErrUnsupportedProvider) {
+			t.Fatalf("expected ErrUnsupportedProvider, got %v", err)
+		}
+	})
+}
+
+func TestKubeconfigClusterMatches(t *testing.T) {
+	tests := []struct {
+		provider, name, cluster string
+		want                    bool
+	}{
+		{"kind", "dev", "kind-dev", true},
+		{"kind", "dev", "kind-dev2", false},
+		{"eks", "prod", "prod.us-east-1.eksctl.io", true},
+		{"eks", "prod", "prod.staging.us-east-1.eksctl.io", false},
+		{"eks", "prod", "arn:aws:eks:us-east-1:1:cluster/prod", true},
+		{"eks", "prod", "arn:aws:eks:us-east-1:1:cluster/preprod", false},
+	}
+	for _, tt := range tests {
+		if got := kubeconfigClusterMatches(tt.provider, tt.name, tt.cluster); got != tt.want {
+			t.Errorf("kubeconfigClusterMatches(%q, %q, %q) = %v, want %v", tt.provider, tt.name, tt.cluster, got, tt.want)
+		}
+	}
+}
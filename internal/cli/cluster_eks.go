@@ -0,0 +1,325 @@
+package cli
+
+// This file provisions EKS clusters with eksctl. Node group and VPC settings map to eksctl
+// flags, or a complete eksctl config file is passed through with --config-file. Provisioning
+// can be re-run: an existing cluster is not recreated, only a missing node group is added,
+// and eksctl lookups are retried with backoff while AWS throttles the API.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// eksctlAttempts is how often a throttled eksctl lookup is tried.
+const eksctlAttempts = 5
+
+// eksctlRetryDelay is the first backoff after a throttled lookup; it doubles per attempt.
+// It is a variable so tests can shorten it.
+var eksctlRetryDelay = 2 * time.Second
+
+// kubernetesVersionRe matches a Kubernetes minor version such as 1.30.
+var kubernetesVersionRe = regexp.MustCompile(`^\d+\.\d+$`)
+
+// eksOptions are the eksctl settings of "cluster provision --provider eks".
+type eksOptions struct {
+	nodeType          string
+	spot              bool
+	minNodes          int
+	maxNodes          int
+	kubernetesVersion string
+	nodegroupName     string
+	vpcCIDR           string
+	privateSubnets    []string
+	publicSubnets     []string
+	privateNetworking bool
+	configFile        string
+}
+
+// hasFlags reports whether any setting other than the config file is set.
+func (o eksOptions) hasFlags() bool {
+	return o.nodeType != "" || o.spot || o.minNodes > 0 || o.maxNodes > 0 || o.kubernetesVersion != "" ||
+		o.nodegroupName != "" || o.vpcCIDR != "" || len(o.privateSubnets) > 0 || len(o.publicSubnets) > 0 ||
+		o.privateNetworking
+}
+
+// validate checks the options against each other and nodeCount.
+func (o eksOptions) validate(nodeCount int) error {
+	if o.configFile != "" {
+		if o.hasFlags() {
+			return errors.New("--config-file cannot be combined with other EKS flags; set them in the config file")
+		}
+		return nil
+	}
+	switch {
+	case o.minNodes < 0 || o.maxNodes < 0:
+		return errors.New("--nodes-min and --nodes-max must not be negative")
+	case o.minNodes > 0 && nodeCount < o.minNodes:
+		return fmt.Errorf("--nodes %d is below --nodes-min %d", nodeCount, o.minNodes)
+	case o.maxNodes > 0 && nodeCount > o.maxNodes:
+		return fmt.Errorf("--nodes %d is above --nodes-max %d", nodeCount, o.maxNodes)
+	case strings.Contains(o.nodeType, ",") && !o.spot:
+		return errors.New("several --node-type values require --spot")
+	case o.kubernetesVersion != "" && !kubernetesVersionRe.MatchString(o.kubernetesVersion):
+		return fmt.Errorf("invalid --kubernetes-version %q (use a minor version such as 1.30)", o.kubernetesVersion)
+	case o.vpcCIDR != "" && (len(o.privateSubnets) > 0 || len(o.publicSubnets) > 0):
+		return errors.New("--vpc-cidr creates a new VPC and cannot be combined with existing subnets")
+	}
+	return nil
+}
+
+// clusterArgs returns the eksctl flags that only apply when the cluster is created.
+func (o eksOptions) clusterArgs() []string {
+	var args []string
+	if o.kubernetesVersion != "" {
+		args = append(args, "--version", o.kubernetesVersion)
+	}
+	if o.vpcCIDR != "" {
+		args = append(args, "--vpc-cidr", o.vpcCIDR)
+	}
+	if len(o.privateSubnets) > 0 {
+		args = append(args, "--vpc-private-subnets", strings.Join(o.privateSubnets, ","))
+	}
+	if len(o.publicSubnets) > 0 {
+		args = append(args, "--vpc-public-subnets", strings.Join(o.publicSubnets, ","))
+	}
+	return args
+}
+
+// nodegroupArgs returns the eksctl flags of the managed node group.
+func (o eksOptions) nodegroupArgs(nodeCount int) []string {
+	var args []string
+	if o.nodegroupName != "" {
+		args = append(args, "--nodegroup-name", o.nodegroupName)
+	}
+	if o.nodeType != "" {
+		if o.spot {
+			args = append(args, "--instance-types", o.nodeType)
+		} else {
+			args = append(args, "--node-type", o.nodeType)
+		}
+	}
+	if o.spot {
+		args = append(args, "--spot")
+	}
+	args = append(args, "--nodes", strconv.Itoa(nodeCount))
+	if o.minNodes > 0 {
+		args = append(args, "--nodes-min", strconv.Itoa(o.minNodes))
+	}
+	if o.maxNodes > 0 {
+		args = append(args, "--nodes-max", strconv.Itoa(o.maxNodes))
+	}
+	if o.privateNetworking {
+		args = append(args, "--node-private-networking")
+	}
+	return args
+}
+
+func provisionEKSCluster(logger *zap.Logger, exec Executor, region string, nodeCount int, clusterName string, opts eksOptions) error {
+	if clusterName == "" {
+		clusterName = defaultClusterName
+	}
+	if err := opts.validate(nodeCount); err != nil {
+		wrappedErr := newWithSentinel(ErrInvalidEKSOptions, err.Error())
+		Error("Invalid EKS options")
+		logStructuredError(logger, wrappedErr, "Invalid EKS options")
+		return wrappedErr
+	}
+	if opts.configFile != "" {
+		name, fileRegion, err := readEKSConfigFile(opts.configFile)
+		if err != nil {
+			wrappedErr := wrapWithSentinel(ErrInvalidEKSOptions, err, fmt.Sprintf("invalid --config-file %s: %v", opts.configFile, err))
+			Error("Invalid eksctl config file")
+			logStructuredError(logger, wrappedErr, "Invalid eksctl config file")
+			return wrappedErr
+		}
+		clusterName, region = name, fileRegion
+	}
+
+	args, err := eksProvisionArgs(exec, region, nodeCount, clusterName, opts)
+	if err != nil {
+		return eksProvisionError(logger, err, clusterName, region, nodeCount)
+	}
+	if args == nil {
+		Info(fmt.Sprintf("EKS cluster %s and its node group already exist; nothing to do", clusterName))
+		return nil
+	}
+
+	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
+	cmd, err := exec.Command(commandContext(), "eksctl", args, AllowlistBins("eksctl"), NoShellMeta(), NoControlChars())
+	if err != nil {
+		return err
+	}
+	logger.Info("Provisioning EKS cluster with eksctl", zap.String("name", clusterName), zap.String("region", region), zap.Int("nodes", nodeCount), zap.String("create", args[1]))
+	if err := runStep("eksctl", cmd); err != nil {
+		return eksProvisionError(logger, err, clusterName, region, nodeCount)
+	}
+	logger.Info("EKS cluster provisioned successfully", zap.String("name", clusterName))
+	return nil
+}
+
+func eksProvisionError(logger *zap.Logger, err error, clusterName, region string, nodeCount int) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrProvisionEKSFailed,
+		err,
+		fmt.Sprintf("failed to provision EKS cluster: %v", err),
+		map[string]any{"cluster_name": clusterName, "region": region, "node_count": nodeCount, "component": "cluster"},
+	)
+	Error("Failed to provision EKS cluster")
+	logStructuredError(logger, wrappedErr, "Failed to provision EKS cluster")
+	return wrappedErr
+}
+
+// eksProvisionArgs returns the eksctl command that brings the cluster to the requested
+// state: create cluster when it does not exist, create nodegroup when it exists without
+// the node group, and nil when there is nothing to do.
+func eksProvisionArgs(exec Executor, region string, nodeCount int, clusterName string, opts eksOptions) ([]string, error) {
+	exists, err := eksClusterExists(exec, clusterName, region)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if opts.configFile != "" {
+			return []string{"create", "cluster", "--config-file", opts.configFile}, nil
+		}
+		args := []string{"create", "cluster", "--name", clusterName, "--region", region}
+		args = append(args, opts.clusterArgs()...)
+		return append(args, opts.nodegroupArgs(nodeCount)...), nil
+	}
+
+	if opts.configFile != "" {
+		// eksctl skips the node groups of the file that already exist.
+		Info(fmt.Sprintf("EKS cluster %s already exists; creating missing node groups from %s", clusterName, opts.configFile))
+		return []string{"create", "nodegroup", "--config-file", opts.configFile}, nil
+	}
+	groups, err := eksNodegroups(exec, clusterName, region)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if opts.nodegroupName == "" || group == opts.nodegroupName {
+			return nil, nil
+		}
+	}
+	Info(fmt.Sprintf("EKS cluster %s already exists; adding the missing node group", clusterName))
+	args := []string{"create", "nodegroup", "--cluster", clusterName, "--region", region}
+	return append(args, opts.nodegroupArgs(nodeCount)...), nil
+}
+
+// eksClusterExists reports whether eksctl finds the cluster.
+func eksClusterExists(exec Executor, clusterName, region string) (bool, error) {
+	out, stderr, err := eksctlLookup(exec, []string{"get", "cluster", "--name", clusterName, "--region", region, "-o", "json"})
+	if err != nil {
+		if eksNotFound(stderr, err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("look up EKS cluster %s: %w", clusterName, err)
+	}
+	var clusters []json.RawMessage
+	if len(bytes.TrimSpace(out)) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(out, &clusters); err != nil {
+		return false, fmt.Errorf("parse eksctl get cluster output: %w", err)
+	}
+	return len(clusters) > 0, nil
+}
+
+// eksNodegroups returns the node group names of the cluster.
+func eksNodegroups(exec Executor, clusterName, region string) ([]string, error) {
+	out, stderr, err := eksctlLookup(exec, []string{"get", "nodegroup", "--cluster", clusterName, "--region", region, "-o", "json"})
+	if err != nil {
+		if eksNotFound(stderr, err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list node groups of EKS cluster %s: %w", clusterName, err)
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	var groups []struct {
+		Name string `json:"Name"`
+	}
+	if err := json.Unmarshal(out, &groups); err != nil {
+		return nil, fmt.Errorf("parse eksctl get nodegroup output: %w", err)
+	}
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return names, nil
+}
+
+// eksctlLookup runs a read-only eksctl command and returns its stdout and stderr. Throttled
+// calls are retried with exponential backoff.
+func eksctlLookup(exec Executor, args []string) ([]byte, string, error) {
+	delay := eksctlRetryDelay
+	for attempt := 1; ; attempt++ {
+		// #nosec G204 -- fixed eksctl verbs; names and regions are validated by NoShellMeta.
+		cmd, err := exec.Command(commandContext(), "eksctl", args, AllowlistBins("eksctl"), NoShellMeta(), NoControlChars())
+		if err != nil {
+			return nil, "", err
+		}
+		var stderr bytes.Buffer
+		cmd.SetStderr(&stderr)
+		out, err := cmd.Output()
+		if err == nil || attempt == eksctlAttempts || !eksThrottled(stderr.String(), err) {
+			return out, stderr.String(), err
+		}
+		if sleepErr := sleepContext(commandContext(), delay); sleepErr != nil {
+			return nil, stderr.String(), sleepErr
+		}
+		delay *= 2
+	}
+}
+
+// eksThrottled reports whether an eksctl failure is AWS API rate limiting.
+func eksThrottled(stderr string, err error) bool {
+	text := stderr + " " + err.Error()
+	for _, marker := range []string{"Throttling", "ThrottlingException", "Rate exceeded", "TooManyRequests"} {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// eksNotFound reports whether an eksctl failure means the cluster or node group does not exist.
+func eksNotFound(stderr string, err error) bool {
+	text := stderr + " " + err.Error()
+	return strings.Contains(text, "ResourceNotFoundException") || strings.Contains(text, "No cluster found")
+}
+
+// readEKSConfigFile returns the cluster name and region of an eksctl ClusterConfig.
+func readEKSConfigFile(path string) (string, string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's --config-file.
+	if err != nil {
+		return "", "", err
+	}
+	var cfg struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name   string `yaml:"name"`
+			Region string `yaml:"region"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return "", "", err
+	}
+	if cfg.Kind != "ClusterConfig" {
+		return "", "", fmt.Errorf("kind is %q, want ClusterConfig", cfg.Kind)
+	}
+	if cfg.Metadata.Name == "" || cfg.Metadata.Region == "" {
+		return "", "", errors.New("metadata.name and metadata.region are required")
+	}
+	return cfg.Metadata.Name, cfg.Metadata.Region, nil
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// eksctlMock answers eksctl lookups from a map of joined args prefixes and records creates.
+func eksctlMock(lookups map[string]*MockCommand) *MockExecutor {
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		args := strings.Join(spec.Args, " ")
+		for prefix, cmd := range lookups {
+			if strings.HasPrefix(args, prefix) {
+				return cmd
+			}
+		}
+		return &MockCommand{}
+	}
+	return mock
+}
+
+func TestProvisionEKSClusterOptions(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	mock := eksctlMock(map[string]*MockCommand{
+		"get cluster": {OutputErr: errors.New("ResourceNotFoundException: No cluster found for name: prod")},
+	})
+	opts := eksOptions{
+		nodeType:          "m6i.large,m5.large",
+		spot:              true,
+		minNodes:          2,
+		maxNodes:          6,
+		kubernetesVersion: "1.30",
+		nodegroupName:     "workers",
+		privateSubnets:    []string{"subnet-a", "subnet-b"},
+		privateNetworking: true,
+	}
+	if err := provisionEKSCluster(zap.NewNop(), mock, "eu-west-1", 3, "prod", opts); err != nil {
+		t.Fatalf("provisionEKSCluster() error: %v", err)
+	}
+	got := strings.Join(mock.LastCommand().Args, " ")
+	want := "create cluster --name prod --region eu-west-1 --version 1.30 --vpc-private-subnets subnet-a,subnet-b " +
+		"--nodegroup-name workers --instance-types m6i.large,m5.large --spot --nodes 3 --nodes-min 2 --nodes-max 6 --node-private-networking"
+	if got != want {
+		t.Fatalf("eksctl args:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestProvisionEKSClusterResumes(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	existing := &MockCommand{OutputData: []byte(`[{"Name":"prod","Status":"ACTIVE"}]`)}
+
+	t.Run("adds a missing node group", func(t *testing.T) {
+		mock := eksctlMock(map[string]*MockCommand{
+			"get cluster":   existing,
+			"get nodegroup": {OutputData: []byte(`[{"Name":"system"}]`)},
+		})
+		opts := eksOptions{nodegroupName: "workers", nodeType: "m6i.large", kubernetesVersion: "1.30"}
+		if err := provisionEKSCluster(zap.NewNop(), mock, "eu-west-1", 2, "prod", opts); err != nil {
+			t.Fatalf("provisionEKSCluster() error: %v", err)
+		}
+		got := strings.Join(mock.LastCommand().Args, " ")
+		if got != "create nodegroup --cluster prod --region eu-west-1 --nodegroup-name workers --node-type m6i.large --nodes 2" {
+			t.Fatalf("unexpected eksctl args %q", got)
+		}
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		mock := eksctlMock(map[string]*MockCommand{
+			"get cluster":   existing,
+			"get nodegroup": {OutputData: []byte(`[{"Name":"ng-1a2b3c"}]`)},
+		})
+		if err := provisionEKSCluster(zap.NewNop(), mock, "eu-west-1", 2, "prod", eksOptions{}); err != nil {
+			t.Fatalf("provisionEKSCluster() error: %v", err)
+		}
+		for _, cmd := range mock.Commands {
+			if cmd.Args[0] == "create" {
+				t.Fatalf("unexpected %v", cmd.Args)
+			}
+		}
+	})
+
+	t.Run("retries throttled lookups", func(t *testing.T) {
+		prev := eksctlRetryDelay
+		eksctlRetryDelay = 0
+		t.Cleanup(func() { eksctlRetryDelay = prev })
+
+		lookups := 0
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			if spec.Args[0] != "get" {
+				return &MockCommand{}
+			}
+			lookups++
+			if lookups < 3 {
+				return &MockCommand{OutputErr: errors.New("ThrottlingException: Rate exceeded")}
+			}
+			return &MockCommand{OutputErr: errors.New("ResourceNotFoundException")}
+		}
+		if err := provisionEKSCluster(zap.NewNop(), mock, "eu-west-1", 2, "prod", eksOptions{}); err != nil {
+			t.Fatalf("provisionEKSCluster() error: %v", err)
+		}
+		if lookups != 3 || mock.LastCommand().Args[1] != "cluster" {
+			t.Fatalf("lookups = %d, last command %v", lookups, mock.LastCommand().Args)
+		}
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		mock := eksctlMock(map[string]*MockCommand{
+			"get cluster": {OutputErr: errors.New("NoCredentialProviders: no valid providers in chain")},
+		})
+		err := provisionEKSCluster(zap.NewNop(), mock, "eu-west-1", 2, "prod", eksOptions{})
+		if !errors.Is(err, ErrProvisionEKSFailed) || !strings.Contains(err.Error(), "NoCredentialProviders") {
+			t.Fatalf("expected ErrProvisionEKSFailed, got %v", err)
+		}
+	})
+}
+
+func TestProvisionEKSClusterConfigFile(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	path := filepath.Join(t.TempDir(), "cluster.yaml")
+	config := "apiVersion: eksctl.io/v1alpha5\nkind: ClusterConfig\nmetadata:\n  name: from-file\n  region: ap-south-1\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &MockExecutor{}
+	if err := provisionEKSCluster(zap.NewNop(), mock, "us-west-1", 3, defaultClusterName, eksOptions{configFile: path}); err != nil {
+		t.Fatalf("provisionEKSCluster() error: %v", err)
+	}
+	if got := strings.Join(mock.Commands[0].Args, " "); got != "get cluster --name from-file --region ap-south-1 -o json" {
+		t.Fatalf("expected the lookup to use the file's cluster, got %q", got)
+	}
+	if got := strings.Join(mock.LastCommand().Args, " "); got != "create cluster --config-file "+path {
+		t.Fatalf("unexpected eksctl args %q", got)
+	}
+}
+
+func TestEKSOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  eksOptions
+		nodes int
+		want  string
+	}{
+		{"valid", eksOptions{minNodes: 1, maxNodes: 5, nodeType: "t3.large"}, 3, ""},
+		{"config file with flags", eksOptions{configFile: "c.yaml", spot: true}, 3, "--config-file cannot be combined"},
+		{"below min", eksOptions{minNodes: 4}, 3, "below --nodes-min"},
+		{"above max", eksOptions{maxNodes: 2}, 3, "above --nodes-max"},
+		{"several types on demand", eksOptions{nodeType: "m5.large,m6i.large"}, 3, "require --spot"},
+		{"bad version", eksOptions{kubernetesVersion: "v1.30.2"}, 3, "invalid --kubernetes-version"},
+		{"cidr with subnets", eksOptions{vpcCIDR: "10.0.0.0/16", publicSubnets: []string{"subnet-a"}}, 3, "cannot be combined with existing subnets"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate(tt.nodes)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	mock := &MockExecutor{}
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	if err := provisionEKSCluster(zap.NewNop(), mock, "us-west-1", 3, "x", eksOptions{maxNodes: 1}); !errors.Is(err, ErrInvalidEKSOptions) {
+		t.Fatalf("expected ErrInvalidEKSOptions, got %v", err)
+	}
+	if len(mock.Commands) != 0 {
+		t.Fatalf("invalid options must not run eksctl: %v", mock.Commands)
+	}
+}
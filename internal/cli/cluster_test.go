@@ -371,7 +371,7 @@ func TestClusterManager_ConfigureKubeconfigFromProvider(t *testing.T) {
 func TestProvisionEKSCluster(t *testing.T) {
 	t.Run("uses eksctl with args", func(t *testing.T) {
 		mock := &MockExecutor{}
-		err := provisionEKSCluster(zap.NewNop(), mock, "us-west-2", 3, "my-eks")
+		err := provisionEKSCluster(zap.NewNop(), mock, "us-west-2", 3, "my-eks", eksOptions{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -396,7 +396,7 @@ func TestProvisionEKSCluster(t *testing.T) {
 
 	t.Run("defaults cluster name when empty", func(t *testing.T) {
 		mock := &MockExecutor{}
-		err := provisionEKSCluster(zap.NewNop(), mock, "us-west-2", 2, "")
+		err := provisionEKSCluster(zap.NewNop(), mock, "us-west-2", 2, "", eksOptions{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -513,7 +513,7 @@ func TestProvisionCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.ProvisionCluster("kind", "us-west-2", 3, "test-cluster")
+		err := mgr.ProvisionCluster("kind", "us-west-2", 3, "test-cluster", false, eksOptions{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -527,7 +527,7 @@ func TestProvisionCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.ProvisionCluster("gke", "us-west-2", 3, "test-cluster")
+		err := mgr.ProvisionCluster("gke", "us-west-2", 3, "test-cluster", false, eksOptions{})
 		if err == nil {
 			t.Fatal("expected error for gke")
 		}
@@ -541,7 +541,7 @@ func TestProvisionCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.ProvisionCluster("aks", "us-west-2", 3, "test-cluster")
+		err := mgr.ProvisionCluster("aks", "us-west-2", 3, "test-cluster", false, eksOptions{})
 		if err == nil {
 			t.Fatal("expected error for aks")
 		}
@@ -555,7 +555,7 @@ func TestProvisionCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.ProvisionCluster("unknown", "us-west-2", 3, "test-cluster")
+		err := mgr.ProvisionCluster("unknown", "us-west-2", 3, "test-cluster", false, eksOptions{})
 		if err == nil {
 			t.Fatal("expected error for unknown provider")
 		}
@@ -569,7 +569,7 @@ func TestProvisionCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.ProvisionCluster("eks", "us-west-2", 3, "test-cluster")
+		err := mgr.ProvisionCluster("eks", "us-west-2", 3, "test-cluster", false, eksOptions{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -585,7 +585,7 @@ func TestProvisionKindCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.provisionKindCluster(3, "")
+		err := mgr.provisionKindCluster(3, "", false)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -604,7 +604,7 @@ func TestProvisionKindCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.provisionKindCluster(2, "my-cluster")
+		err := mgr.provisionKindCluster(2, "my-cluster", false)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -620,7 +620,7 @@ func TestProvisionKindCluster(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
 
-		err := mgr.provisionKindCluster(1, "test")
+		err := mgr.provisionKindCluster(1, "test", false)
 		if err == nil {
 			t.Fatal("expected error when kind fails")
 		}
@@ -653,7 +653,7 @@ func TestProvisionAKSCluster(t *testing.T) {
 
 func TestProvisionEKSClusterError(t *testing.T) {
 	mock := &MockExecutor{DefaultRunErr: errors.New("eksctl failed")}
-	err := provisionEKSCluster(zap.NewNop(), mock, "us-west-2", 3, "test")
+	err := provisionEKSCluster(zap.NewNop(), mock, "us-west-2", 3, "test", eksOptions{})
 	if err == nil {
 		t.Fatal("expected error when eksctl fails")
 	}
@@ -867,7 +867,7 @@ func TestInitClusterErrors(t *testing.T) {
 				cmd := &MockCommand{Args: spec.Args}
 				if contains(spec.Args, "apply") &&
 					contains(spec.Args, "-f") &&
-					contains(spec.Args, "config/crd/bases/mcpruntime.org_mcpservers.yaml") {
+					contains(spec.Args, "config/crd/bases") {
 					cmd.RunErr = errors.New("crd install failed")
 				}
 				return cmd
@@ -12,14 +12,21 @@ import (
 // CLIConfig holds all CLI configuration loaded from environment variables.
 // Use LoadCLIConfig() to create an instance with values from the environment.
 type CLIConfig struct {
-	// Timeouts
+	// Timeouts; MCP_RUNTIME_* variables take precedence over the older MCP_* names
 	DeploymentTimeout time.Duration
 	CertTimeout       time.Duration
+	KubectlTimeout    time.Duration // Per-invocation kubectl limit; 0 disables
 
 	// Registry settings
 	RegistryPort  int
 	SkopeoImage   string
+	KanikoImage   string // Builder image for in-cluster kaniko builds
+	BuildkitImage string // Builder image for in-cluster buildkit builds
 	OperatorImage string // Override for operator image
+	// ContainerTool is the image CLI (docker, podman or nerdctl); empty or "auto" detects it
+	ContainerTool string
+	// HelperPodTemplate is a path to a helper pod template file; empty means unconstrained
+	HelperPodTemplate string
 
 	// Server defaults
 	DefaultServerPort int
@@ -28,14 +35,19 @@ type CLIConfig struct {
 	ProvisionedRegistryURL      string
 	ProvisionedRegistryUsername string
 	ProvisionedRegistryPassword string
+	// ProvisionedRegistryPathPrefix is the project or org images are pushed under
+	ProvisionedRegistryPathPrefix string
 }
 
 // Default values
 const (
 	defaultDeploymentTimeout = 5 * time.Minute
 	defaultCertTimeout       = 60 * time.Second
+	defaultKubectlTimeout    = 2 * time.Minute
 	defaultRegistryPort      = 5000
 	defaultSkopeoImage       = "quay.io/skopeo/stable:v1.14"
+	defaultKanikoImage       = "gcr.io/kaniko-project/executor:v1.23.2"
+	defaultBuildkitImage     = "moby/buildkit:v0.13.2-rootless"
 	defaultServerPort        = 8088
 )
 
@@ -45,15 +57,21 @@ var DefaultCLIConfig = LoadCLIConfig()
 // LoadCLIConfig loads CLI configuration from environment variables.
 func LoadCLIConfig() *CLIConfig {
 	return &CLIConfig{
-		DeploymentTimeout:           parseDurationEnv("MCP_DEPLOYMENT_TIMEOUT", defaultDeploymentTimeout),
-		CertTimeout:                 parseDurationEnv("MCP_CERT_TIMEOUT", defaultCertTimeout),
-		RegistryPort:                parseIntEnv("MCP_REGISTRY_PORT", defaultRegistryPort),
-		SkopeoImage:                 getEnvOrDefault("MCP_SKOPEO_IMAGE", defaultSkopeoImage),
-		OperatorImage:               os.Getenv("MCP_OPERATOR_IMAGE"), // No default, empty means auto
-		DefaultServerPort:           parseIntEnv("MCP_DEFAULT_SERVER_PORT", defaultServerPort),
-		ProvisionedRegistryURL:      os.Getenv("PROVISIONED_REGISTRY_URL"),
-		ProvisionedRegistryUsername: os.Getenv("PROVISIONED_REGISTRY_USERNAME"),
-		ProvisionedRegistryPassword: os.Getenv("PROVISIONED_REGISTRY_PASSWORD"),
+		DeploymentTimeout:             parseDurationEnv("MCP_RUNTIME_DEPLOYMENT_TIMEOUT", parseDurationEnv("MCP_DEPLOYMENT_TIMEOUT", defaultDeploymentTimeout)),
+		CertTimeout:                   parseDurationEnv("MCP_RUNTIME_CERT_TIMEOUT", parseDurationEnv("MCP_CERT_TIMEOUT", defaultCertTimeout)),
+		KubectlTimeout:                parseDurationEnv("MCP_RUNTIME_KUBECTL_TIMEOUT", parseDurationEnv("MCP_KUBECTL_TIMEOUT", defaultKubectlTimeout)),
+		RegistryPort:                  parseIntEnv("MCP_REGISTRY_PORT", defaultRegistryPort),
+		SkopeoImage:                   getEnvOrDefault("MCP_SKOPEO_IMAGE", defaultSkopeoImage),
+		KanikoImage:                   getEnvOrDefault("MCP_KANIKO_IMAGE", defaultKanikoImage),
+		BuildkitImage:                 getEnvOrDefault("MCP_BUILDKIT_IMAGE", defaultBuildkitImage),
+		OperatorImage:                 os.Getenv("MCP_OPERATOR_IMAGE"), // No default, empty means auto
+		ContainerTool:                 os.Getenv("MCP_CONTAINER_TOOL"),
+		HelperPodTemplate:             os.Getenv("MCP_HELPER_POD_TEMPLATE"),
+		DefaultServerPort:             parseIntEnv("MCP_DEFAULT_SERVER_PORT", defaultServerPort),
+		ProvisionedRegistryURL:        os.Getenv("PROVISIONED_REGISTRY_URL"),
+		ProvisionedRegistryUsername:   os.Getenv("PROVISIONED_REGISTRY_USERNAME"),
+		ProvisionedRegistryPassword:   os.Getenv("PROVISIONED_REGISTRY_PASSWORD"),
+		ProvisionedRegistryPathPrefix: os.Getenv("PROVISIONED_REGISTRY_PATH_PREFIX"),
 	}
 }
 
@@ -97,6 +115,11 @@ func GetCertTimeout() time.Duration {
 	return DefaultCLIConfig.CertTimeout
 }
 
+// GetKubectlTimeout returns the per-invocation kubectl timeout.
+func GetKubectlTimeout() time.Duration {
+	return DefaultCLIConfig.KubectlTimeout
+}
+
 // GetRegistryPort returns the registry port.
 func GetRegistryPort() int {
 	return DefaultCLIConfig.RegistryPort
@@ -107,6 +130,26 @@ func GetSkopeoImage() string {
 	return DefaultCLIConfig.SkopeoImage
 }
 
+// GetKanikoImage returns the kaniko executor image for in-cluster builds.
+func GetKanikoImage() string {
+	return DefaultCLIConfig.KanikoImage
+}
+
+// GetBuildkitImage returns the rootless buildkit image for in-cluster builds.
+func GetBuildkitImage() string {
+	return DefaultCLIConfig.BuildkitImage
+}
+
+// GetHelperPodTemplate returns the path of the in-cluster push helper pod template, empty if not set.
+func GetHelperPodTemplate() string {
+	return DefaultCLIConfig.HelperPodTemplate
+}
+
+// GetContainerTool returns the configured image CLI, empty if not set.
+func GetContainerTool() string {
+	return DefaultCLIConfig.ContainerTool
+}
+
 // GetOperatorImageOverride returns the operator image override, empty if not set.
 func GetOperatorImageOverride() string {
 	return DefaultCLIConfig.OperatorImage
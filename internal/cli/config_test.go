@@ -46,12 +46,30 @@ func TestGetEnvOrDefault(t *testing.T) {
 	}
 }
 
+func TestLoadCLIConfigPrefersRuntimeTimeoutEnv(t *testing.T) {
+	t.Setenv("MCP_DEPLOYMENT_TIMEOUT", "3s")
+	t.Setenv("MCP_RUNTIME_DEPLOYMENT_TIMEOUT", "9s")
+	t.Setenv("MCP_RUNTIME_KUBECTL_TIMEOUT", "0s")
+
+	cfg := LoadCLIConfig()
+	if cfg.DeploymentTimeout != 9*time.Second {
+		t.Fatalf("expected deployment timeout 9s, got %s", cfg.DeploymentTimeout)
+	}
+	if cfg.KubectlTimeout != 0 {
+		t.Fatalf("expected kubectl timeout disabled, got %s", cfg.KubectlTimeout)
+	}
+}
+
 func TestLoadCLIConfigWithProvisionedRegistry(t *testing.T) {
 	t.Setenv("MCP_DEPLOYMENT_TIMEOUT", "3s")
 	t.Setenv("MCP_CERT_TIMEOUT", "30s")
+	t.Setenv("MCP_KUBECTL_TIMEOUT", "45s")
 	t.Setenv("MCP_REGISTRY_PORT", "6000")
 	t.Setenv("MCP_SKOPEO_IMAGE", "example/skopeo:latest")
+	t.Setenv("MCP_KANIKO_IMAGE", "example/kaniko:latest")
+	t.Setenv("MCP_BUILDKIT_IMAGE", "example/buildkit:rootless")
 	t.Setenv("MCP_OPERATOR_IMAGE", "example/operator:latest")
+	t.Setenv("MCP_HELPER_POD_TEMPLATE", "helper-pod.yaml")
 	t.Setenv("MCP_DEFAULT_SERVER_PORT", "9000")
 	t.Setenv("PROVISIONED_REGISTRY_URL", "registry.example.com")
 	t.Setenv("PROVISIONED_REGISTRY_USERNAME", "user")
@@ -64,15 +82,24 @@ func TestLoadCLIConfigWithProvisionedRegistry(t *testing.T) {
 	if cfg.CertTimeout != 30*time.Second {
 		t.Fatalf("expected cert timeout 30s, got %s", cfg.CertTimeout)
 	}
+	if cfg.KubectlTimeout != 45*time.Second {
+		t.Fatalf("expected kubectl timeout 45s, got %s", cfg.KubectlTimeout)
+	}
 	if cfg.RegistryPort != 6000 {
 		t.Fatalf("expected registry port 6000, got %d", cfg.RegistryPort)
 	}
 	if cfg.SkopeoImage != "example/skopeo:latest" {
 		t.Fatalf("expected skopeo image override, got %q", cfg.SkopeoImage)
 	}
+	if cfg.KanikoImage != "example/kaniko:latest" || cfg.BuildkitImage != "example/buildkit:rootless" {
+		t.Fatalf("expected builder image overrides, got %q/%q", cfg.KanikoImage, cfg.BuildkitImage)
+	}
 	if cfg.OperatorImage != "example/operator:latest" {
 		t.Fatalf("expected operator image override, got %q", cfg.OperatorImage)
 	}
+	if cfg.HelperPodTemplate != "helper-pod.yaml" {
+		t.Fatalf("expected helper pod template override, got %q", cfg.HelperPodTemplate)
+	}
 	if cfg.DefaultServerPort != 9000 {
 		t.Fatalf("expected default server port 9000, got %d", cfg.DefaultServerPort)
 	}
@@ -1,5 +1,7 @@
 package cli
 
+import "mcp-runtime/internal/operator"
+
 // This file defines constants used across the CLI, including:
 //   - Kubernetes namespace names
 //   - Deployment and resource names
@@ -14,6 +16,12 @@ const (
 
 	// NamespaceMCPServers is the default namespace for MCP server deployments.
 	NamespaceMCPServers = "mcp-servers"
+
+	// NamespaceMonitoring is the namespace for the optional observability stack.
+	NamespaceMonitoring = "mcp-monitoring"
+
+	// NamespaceExternalDNS is the namespace for the optional external-dns deployment.
+	NamespaceExternalDNS = "external-dns"
 )
 
 // Deployment and resource names.
@@ -21,6 +29,26 @@ const (
 	// OperatorDeploymentName is the name of the operator deployment.
 	OperatorDeploymentName = "mcp-runtime-operator-controller-manager"
 
+	// OperatorServiceAccountName is the ServiceAccount the operator runs as.
+	OperatorServiceAccountName = "mcp-runtime-operator-controller-manager"
+
+	// OperatorPDBName is the name of the operator PodDisruptionBudget.
+	OperatorPDBName = "mcp-runtime-operator-controller-manager"
+
+	// OperatorLeaseName is the leader election Lease of the operator (its LeaderElectionID).
+	OperatorLeaseName = "mcp-runtime-operator.mcpruntime.org"
+
+	// OperatorMaintenanceConfigMapName pauses the operator's changes to server resources
+	// while its "paused" key is "true".
+	OperatorMaintenanceConfigMapName = "mcp-runtime-maintenance"
+
+	// RegistryConfigSecretName holds the external registry config written by
+	// "registry provision --store cluster" in the operator namespace.
+	RegistryConfigSecretName = "mcp-runtime-registry-config"
+
+	// DefaultOperatorReplicas is the number of operator replicas setup deploys.
+	DefaultOperatorReplicas = 2
+
 	// RegistryDeploymentName is the name of the registry deployment.
 	RegistryDeploymentName = "registry"
 
@@ -50,6 +78,21 @@ const (
 
 	// LabelManagedByValue is the value for the managed-by label.
 	LabelManagedByValue = "mcp-runtime"
+
+	// LabelPreviewBranch marks a preview MCPServer with the branch it was created for.
+	LabelPreviewBranch = "mcpruntime.org/preview-branch"
+)
+
+// Annotations recognized on MCPServer resources.
+const (
+	// AnnotationProtected marks an MCPServer that must not be deleted without --force.
+	AnnotationProtected = "mcpruntime.org/protected"
+
+	// AnnotationSuspendedReplicas records the replica count of a suspended MCPServer.
+	AnnotationSuspendedReplicas = "mcpruntime.org/suspended-replicas"
+
+	// AnnotationTTL is the lifetime after which the operator deletes an MCPServer.
+	AnnotationTTL = "mcpruntime.org/ttl"
 )
 
 // Selector strings for kubectl queries.
@@ -63,3 +106,49 @@ const (
 	// SelectorManagedBy is the label selector for MCP-managed resources.
 	SelectorManagedBy = "app.kubernetes.io/managed-by=mcp-runtime"
 )
+
+// Namespace quota defaults applied by "cluster init --with-quotas". They are
+// the operator's defaults, so CLI-created and operator-enforced quotas match.
+const (
+	// QuotaName is the name of the ResourceQuota created in MCP server namespaces.
+	QuotaName = operator.QuotaName
+
+	// LimitRangeName is the name of the LimitRange created in MCP server namespaces.
+	LimitRangeName = operator.LimitRangeName
+
+	// DefaultQuotaRequestsCPU is the total CPU requests allowed in the namespace.
+	DefaultQuotaRequestsCPU = operator.DefaultQuotaRequestsCPU
+
+	// DefaultQuotaRequestsMemory is the total memory requests allowed in the namespace.
+	DefaultQuotaRequestsMemory = operator.DefaultQuotaRequestsMemory
+
+	// DefaultQuotaLimitsCPU is the total CPU limits allowed in the namespace.
+	DefaultQuotaLimitsCPU = operator.DefaultQuotaLimitsCPU
+
+	// DefaultQuotaLimitsMemory is the total memory limits allowed in the namespace.
+	DefaultQuotaLimitsMemory = operator.DefaultQuotaLimitsMemory
+
+	// DefaultQuotaPods is the maximum number of pods in the namespace.
+	DefaultQuotaPods = operator.DefaultQuotaPods
+
+	// DefaultLimitRangeMaxCPU is the largest CPU limit a single container may set.
+	DefaultLimitRangeMaxCPU = operator.DefaultLimitRangeMaxCPU
+
+	// DefaultLimitRangeMaxMemory is the largest memory limit a single container may set.
+	DefaultLimitRangeMaxMemory = operator.DefaultLimitRangeMaxMemory
+)
+
+// Container resource defaults, kept in sync with the operator's defaults.
+const (
+	// DefaultContainerRequestCPU is the default CPU request for containers.
+	DefaultContainerRequestCPU = "50m"
+
+	// DefaultContainerRequestMemory is the default memory request for containers.
+	DefaultContainerRequestMemory = "64Mi"
+
+	// DefaultContainerLimitCPU is the default CPU limit for containers.
+	DefaultContainerLimitCPU = "500m"
+
+	// DefaultContainerLimitMemory is the default memory limit for containers.
+	DefaultContainerLimitMemory = "256Mi"
+)
@@ -0,0 +1,211 @@
+package cli
+
+// This file detects the container runtime kind provisions nodes with.
+// It accepts the docker CLI's current context first, then probes the well-known sockets of
+// Docker Desktop, colima, rootless Docker and Podman per platform, and finally falls back to
+// Podman (including podman machine) through kind's podman provider.
+//
+// It also selects the image CLI (docker, podman or nerdctl) that setup and the build and push
+// commands use, so hosts with only containerd or Podman can build and push images.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Container runtime providers understood by kind.
+const (
+	containerRuntimeDocker = "docker"
+	containerRuntimePodman = "podman"
+)
+
+// containerRuntimeNerdctl is the containerd CLI; kind cannot use it, but it builds and pushes images.
+const containerRuntimeNerdctl = "nerdctl"
+
+// containerToolAuto detects the image CLI.
+const containerToolAuto = "auto"
+
+// containerTools are the image CLIs that can build, push, save and load images, in detection order.
+var containerTools = []string{containerRuntimeDocker, containerRuntimePodman, containerRuntimeNerdctl}
+
+// containerToolProbes check that an image CLI reaches its daemon or containerd.
+var containerToolProbes = map[string][]string{
+	containerRuntimeDocker:  {"info", "--format", "{{.ServerVersion}}"},
+	containerRuntimePodman:  {"info", "--format", "{{.Version.Version}}"},
+	containerRuntimeNerdctl: {"info", "--format", "{{.ServerVersion}}"},
+}
+
+// selectedContainerTool is the image CLI chosen by setup's --container-tool; empty defers
+// to MCP_CONTAINER_TOOL and then docker.
+var selectedContainerTool string
+
+// kindProviderEnv selects kind's node provider.
+const kindProviderEnv = "KIND_EXPERIMENTAL_PROVIDER"
+
+// containerRuntime is a reachable container runtime and how to point kind at it.
+type containerRuntime struct {
+	// Provider is the kind provider (docker or podman).
+	Provider string
+	// Source describes where the runtime was found (e.g. "Docker Desktop", "colima").
+	Source string
+	// Host is the DOCKER_HOST to export for kind; empty keeps the docker CLI's own context.
+	Host string
+}
+
+// String describes the runtime for diagnostics.
+func (r containerRuntime) String() string {
+	if r.Host != "" {
+		return fmt.Sprintf("%s (%s, %s)", r.Provider, r.Source, r.Host)
+	}
+	return fmt.Sprintf("%s (%s)", r.Provider, r.Source)
+}
+
+// dockerSocketCandidate is a well-known docker-compatible socket for a platform.
+type dockerSocketCandidate struct {
+	source string
+	host   string
+}
+
+// statPath is a test seam for socket existence checks.
+var statPath = os.Stat
+
+// hostOS is a test seam for the platform the CLI runs on.
+var hostOS = runtime.GOOS
+
+// dockerSocketCandidates lists well-known docker-compatible sockets for goos, in lookup order.
+func dockerSocketCandidates(goos, home, xdgRuntimeDir string) []dockerSocketCandidate {
+	switch goos {
+	case "darwin":
+		return []dockerSocketCandidate{
+			{"Docker Desktop", "unix://" + filepath.Join(home, ".docker", "run", "docker.sock")},
+			{"colima", "unix://" + filepath.Join(home, ".colima", "default", "docker.sock")},
+			{"colima", "unix://" + filepath.Join(home, ".colima", "docker.sock")},
+			{"Rancher Desktop", "unix://" + filepath.Join(home, ".rd", "docker.sock")},
+			{"Docker Desktop", "unix:///var/run/docker.sock"},
+		}
+	case "windows":
+		return []dockerSocketCandidate{
+			{"Docker Desktop", "npipe:////./pipe/docker_engine"},
+			{"Docker Desktop", "npipe:////./pipe/dockerDesktopLinuxEngine"},
+		}
+	default:
+		candidates := []dockerSocketCandidate{{"Docker", "unix:///var/run/docker.sock"}}
+		if xdgRuntimeDir != "" {
+			candidates = append(candidates, dockerSocketCandidate{"rootless Docker", "unix://" + filepath.Join(xdgRuntimeDir, "docker.sock")})
+		}
+		return append(candidates, dockerSocketCandidate{"Docker Desktop", "unix://" + filepath.Join(home, ".docker", "desktop", "docker.sock")})
+	}
+}
+
+// socketExists reports whether a unix socket host exists; named pipes are probed by the docker CLI.
+func socketExists(host string) bool {
+	path, ok := strings.CutPrefix(host, "unix://")
+	if !ok {
+		return true
+	}
+	_, err := statPath(path)
+	return err == nil
+}
+
+// runtimeProbe runs a container CLI and reports whether it succeeded.
+func runtimeProbe(exec Executor, bin string, args ...string) bool {
+	// #nosec G204 -- fixed binaries; args are well-known socket addresses.
+	cmd, err := exec.Command(commandContext(), bin, args, AllowlistBins(containerTools...), NoShellMeta(), NoControlChars())
+	if err != nil {
+		return false
+	}
+	_, err = cmd.Output()
+	return err == nil
+}
+
+// detectContainerRuntime returns the first reachable container runtime, or ErrNoContainerRuntime.
+func detectContainerRuntime(exec Executor) (containerRuntime, error) {
+	if runtimeProbe(exec, containerRuntimeDocker, "info", "--format", "{{.ServerVersion}}") {
+		source := "docker context"
+		if os.Getenv("DOCKER_HOST") != "" {
+			source = "DOCKER_HOST"
+		}
+		return containerRuntime{Provider: containerRuntimeDocker, Source: source}, nil
+	}
+
+	home, _ := os.UserHomeDir()
+	for _, c := range dockerSocketCandidates(hostOS, home, os.Getenv("XDG_RUNTIME_DIR")) {
+		if !socketExists(c.host) {
+			continue
+		}
+		if runtimeProbe(exec, containerRuntimeDocker, "--host", c.host, "info", "--format", "{{.ServerVersion}}") {
+			return containerRuntime{Provider: containerRuntimeDocker, Source: c.source, Host: c.host}, nil
+		}
+	}
+
+	if runtimeProbe(exec, containerRuntimePodman, "info", "--format", "{{.Version.Version}}") {
+		source := "Podman"
+		if hostOS != "linux" {
+			source = "podman machine"
+		}
+		return containerRuntime{Provider: containerRuntimePodman, Source: source}, nil
+	}
+
+	return containerRuntime{}, newWithSentinel(ErrNoContainerRuntime, "no running container runtime found: "+containerRuntimeHint(hostOS))
+}
+
+// containerTool returns the image CLI to build, push, save and load images with.
+func containerTool() string {
+	if selectedContainerTool != "" {
+		return selectedContainerTool
+	}
+	if tool := GetContainerTool(); tool != "" && tool != containerToolAuto {
+		return tool
+	}
+	return containerRuntimeDocker
+}
+
+// resolveContainerTool validates requested, or with "auto" (or empty) returns
+// MCP_CONTAINER_TOOL when set, else the first image CLI that reaches its runtime. Detection
+// falls back to docker so that its errors explain what is missing.
+func resolveContainerTool(exec Executor, requested string) (string, error) {
+	if requested == "" || requested == containerToolAuto {
+		requested = GetContainerTool()
+	}
+	if requested != "" && requested != containerToolAuto {
+		if !slices.Contains(containerTools, requested) {
+			return "", newWithSentinel(ErrInvalidContainerTool, fmt.Sprintf("unknown container tool %q (use %s|%s)", requested, containerToolAuto, strings.Join(containerTools, "|")))
+		}
+		return requested, nil
+	}
+	for _, tool := range containerTools {
+		if runtimeProbe(exec, tool, containerToolProbes[tool]...) {
+			return tool, nil
+		}
+	}
+	return containerRuntimeDocker, nil
+}
+
+// containerRuntimeHint suggests how to get a container runtime running on goos.
+func containerRuntimeHint(goos string) string {
+	switch goos {
+	case "darwin":
+		return "start Docker Desktop, run `colima start`, or run `podman machine start`"
+	case "windows":
+		return "start Docker Desktop (WSL 2 backend) or run `podman machine start`"
+	default:
+		return "install Docker or Podman, start the daemon (e.g. `sudo systemctl start docker`) and make sure your user can access /var/run/docker.sock"
+	}
+}
+
+// useContainerRuntime points kind at rt through DOCKER_HOST and KIND_EXPERIMENTAL_PROVIDER.
+func useContainerRuntime(rt containerRuntime) error {
+	if rt.Host != "" {
+		if err := os.Setenv("DOCKER_HOST", rt.Host); err != nil {
+			return err
+		}
+	}
+	if rt.Provider == containerRuntimePodman {
+		return os.Setenv(kindProviderEnv, containerRuntimePodman)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func stubRuntimeHost(t *testing.T, goos string, sockets ...string) {
+	t.Helper()
+	origOS, origStat := hostOS, statPath
+	t.Cleanup(func() { hostOS, statPath = origOS, origStat })
+	hostOS = goos
+	statPath = func(path string) (os.FileInfo, error) {
+		for _, s := range sockets {
+			if strings.HasSuffix(path, s) {
+				return nil, nil
+			}
+		}
+		return nil, fs.ErrNotExist
+	}
+}
+
+// runtimeExecutor succeeds for commands whose joined "name args" contain any of ok.
+func runtimeExecutor(ok ...string) *MockExecutor {
+	return &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			line := spec.Name + " " + strings.Join(spec.Args, " ")
+			for _, o := range ok {
+				if strings.HasPrefix(line, o) {
+					return &MockCommand{Args: spec.Args}
+				}
+			}
+			return &MockCommand{Args: spec.Args, OutputErr: errors.New("cannot connect")}
+		},
+	}
+}
+
+func TestDockerSocketCandidates(t *testing.T) {
+	darwin := dockerSocketCandidates("darwin", "/Users/dev", "")
+	if darwin[0].host != "unix:///Users/dev/.docker/run/docker.sock" || darwin[1].source != "colima" {
+		t.Fatalf("unexpected darwin candidates %+v", darwin)
+	}
+	windows := dockerSocketCandidates("windows", `C:\Users\dev`, "")
+	if windows[0].host != "npipe:////./pipe/docker_engine" {
+		t.Fatalf("unexpected windows candidates %+v", windows)
+	}
+	linux := dockerSocketCandidates("linux", "/home/dev", "/run/user/1000")
+	if linux[0].host != "unix:///var/run/docker.sock" || linux[1].host != "unix:///run/user/1000/docker.sock" {
+		t.Fatalf("unexpected linux candidates %+v", linux)
+	}
+}
+
+func TestDetectContainerRuntime(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+
+	t.Run("uses the docker CLI context", func(t *testing.T) {
+		stubRuntimeHost(t, "linux")
+		rt, err := detectContainerRuntime(runtimeExecutor("docker info"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rt.Provider != containerRuntimeDocker || rt.Host != "" {
+			t.Fatalf("unexpected runtime %+v", rt)
+		}
+	})
+
+	t.Run("finds the colima socket on macOS", func(t *testing.T) {
+		stubRuntimeHost(t, "darwin", ".colima/default/docker.sock")
+		rt, err := detectContainerRuntime(runtimeExecutor("docker --host unix://"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rt.Source != "colima" || !strings.HasSuffix(rt.Host, ".colima/default/docker.sock") {
+			t.Fatalf("unexpected runtime %+v", rt)
+		}
+	})
+
+	t.Run("falls back to podman machine", func(t *testing.T) {
+		stubRuntimeHost(t, "windows")
+		rt, err := detectContainerRuntime(runtimeExecutor("podman info"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rt.Provider != containerRuntimePodman || rt.Source != "podman machine" {
+			t.Fatalf("unexpected runtime %+v", rt)
+		}
+	})
+
+	t.Run("reports a platform hint when nothing runs", func(t *testing.T) {
+		stubRuntimeHost(t, "darwin")
+		_, err := detectContainerRuntime(runtimeExecutor())
+		if !errors.Is(err, ErrNoContainerRuntime) {
+			t.Fatalf("expected ErrNoContainerRuntime, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "colima start") {
+			t.Fatalf("expected macOS hint, got %v", err)
+		}
+	})
+}
+
+func TestUseContainerRuntime(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv(kindProviderEnv, "")
+
+	if err := useContainerRuntime(containerRuntime{Provider: containerRuntimeDocker, Host: "unix:///tmp/docker.sock"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("DOCKER_HOST"); got != "unix:///tmp/docker.sock" {
+		t.Fatalf("expected DOCKER_HOST to be set, got %q", got)
+	}
+
+	if err := useContainerRuntime(containerRuntime{Provider: containerRuntimePodman}); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv(kindProviderEnv); got != containerRuntimePodman {
+		t.Fatalf("expected podman provider, got %q", got)
+	}
+}
+
+func TestResolveContainerTool(t *testing.T) {
+	origConfig := DefaultCLIConfig
+	t.Cleanup(func() { DefaultCLIConfig = origConfig })
+	DefaultCLIConfig = &CLIConfig{}
+
+	t.Run("detects the first reachable tool", func(t *testing.T) {
+		tool, err := resolveContainerTool(runtimeExecutor("nerdctl info"), containerToolAuto)
+		if err != nil || tool != containerRuntimeNerdctl {
+			t.Fatalf("resolveContainerTool() = %q, %v", tool, err)
+		}
+	})
+
+	t.Run("falls back to docker", func(t *testing.T) {
+		tool, err := resolveContainerTool(runtimeExecutor(), containerToolAuto)
+		if err != nil || tool != containerRuntimeDocker {
+			t.Fatalf("resolveContainerTool() = %q, %v", tool, err)
+		}
+	})
+
+	t.Run("explicit tool skips detection", func(t *testing.T) {
+		mock := runtimeExecutor()
+		tool, err := resolveContainerTool(mock, containerRuntimePodman)
+		if err != nil || tool != containerRuntimePodman || len(mock.Commands) != 0 {
+			t.Fatalf("resolveContainerTool() = %q, %v after %d probes", tool, err, len(mock.Commands))
+		}
+	})
+
+	t.Run("MCP_CONTAINER_TOOL applies to auto", func(t *testing.T) {
+		DefaultCLIConfig = &CLIConfig{ContainerTool: containerRuntimeNerdctl}
+		defer func() { DefaultCLIConfig = &CLIConfig{} }()
+		tool, err := resolveContainerTool(runtimeExecutor("docker info"), containerToolAuto)
+		if err != nil || tool != containerRuntimeNerdctl {
+			t.Fatalf("resolveContainerTool() = %q, %v", tool, err)
+		}
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		if _, err := resolveContainerTool(runtimeExecutor(), "buildah"); !errors.Is(err, ErrInvalidContainerTool) {
+			t.Fatalf("expected ErrInvalidContainerTool, got %v", err)
+		}
+	})
+}
+
+func TestContainerToolUsedForPush(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	origSelected := selectedContainerTool
+	t.Cleanup(func() { selectedContainerTool = origSelected })
+	selectedContainerTool = containerRuntimePodman
+
+	mock := &MockExecutor{}
+	mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+	_ = mgr.PushDirect("app:dev", "registry.local/app:dev")
+	if mock.HasCommand(containerRuntimeDocker) || !mock.HasCommand(containerRuntimePodman) {
+		t.Fatalf("expected podman to tag and push, got %+v", mock.Commands)
+	}
+}
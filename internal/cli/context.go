@@ -0,0 +1,42 @@
+package cli
+
+// This file holds the context shared by CLI helpers for the running command.
+// The root command sets it so helpers pick up the command's trace span and are
+// cancelled when the user interrupts the CLI (SIGINT/SIGTERM).
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	commandCtxMu sync.RWMutex
+	commandCtx   = context.Background()
+)
+
+// SetCommandContext sets the context used by CLI helpers for spans and command execution.
+// The root command sets this to a signal-aware context carrying the command span.
+func SetCommandContext(ctx context.Context) {
+	commandCtxMu.Lock()
+	defer commandCtxMu.Unlock()
+	commandCtx = ctx
+}
+
+func commandContext() context.Context {
+	commandCtxMu.RLock()
+	defer commandCtxMu.RUnlock()
+	return commandCtx
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx.Err() in the latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,758 @@
+package cli
+
+// This file implements "dashboard", an interactive terminal view of the MCP servers in a
+// namespace: their phase, replicas and endpoint, plus the recent events of the selected
+// server. Keys open its logs or description, scale it and delete it. The screen is drawn
+// with ANSI escapes on a raw terminal and refreshed every --interval; refreshes load in
+// the background so keys stay responsive while kubectl is slow.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// dashboardLogTail is how many log lines the logs view loads per pod.
+	dashboardLogTail = 200
+
+	// dashboardHelp is the key legend shown under the server table.
+	dashboardHelp = "↑/↓ select  l logs  d describe  s scale  ctrl-d delete  r refresh  q quit"
+
+	// dashboardViewHelp is the key legend of the logs and describe views.
+	dashboardViewHelp = "↑/↓ scroll  pgup/pgdn page  esc back  q quit"
+)
+
+// dashboardMode is what the dashboard is showing or asking for.
+type dashboardMode int
+
+const (
+	dashboardList dashboardMode = iota
+	dashboardView
+	dashboardScale
+	dashboardConfirmDelete
+)
+
+// dashboardServer is one row of the server table.
+type dashboardServer struct {
+	Namespace string
+	Name      string
+	Phase     string
+	Ready     string
+	Replicas  string
+	URL       string
+}
+
+// dashboardEvent is one recent event of a server or its pods.
+type dashboardEvent struct {
+	Time    time.Time
+	Type    string
+	Object  string
+	Reason  string
+	Message string
+}
+
+// dashboardSnapshot is the result of one server list load: the servers, and the events of
+// the server that was selected when the load started.
+type dashboardSnapshot struct {
+	servers   []dashboardServer
+	err       error
+	eventsOf  dashboardServer
+	events    []dashboardEvent
+	eventsErr error
+}
+
+// dashboard holds the state of one dashboard session. Only run touches the terminal;
+// everything else works on this state, so keys can be replayed in tests.
+type dashboard struct {
+	mgr       *ServerManager
+	namespace string // "" shows every namespace
+
+	servers  []dashboardServer
+	events   []dashboardEvent
+	selected int
+	status   string
+
+	mode   dashboardMode
+	title  string   // title of the logs or describe view
+	lines  []string // content of the logs or describe view
+	offset int      // first line shown in the view
+	input  string   // replica count typed at the scale prompt
+
+	width, height int
+
+	// refreshes delivers background loads to run's loop; nil loads synchronously.
+	refreshes  chan dashboardSnapshot
+	refreshing bool // a background load is in flight
+}
+
+// NewDashboardCmd returns the dashboard command.
+func NewDashboardCmd(logger *zap.Logger) *cobra.Command {
+	mgr := DefaultServerManager(logger)
+	var allNamespaces bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Interactive terminal dashboard of MCP servers",
+		Long: `Show an interactive, auto-refreshing view of the MCP servers in a namespace: their
+phase, ready and desired replicas, endpoint, and the recent events of the selected server
+and its pods.
+
+Keys:
+  up/down, j/k   select a server
+  l              show the server's logs
+  d              describe the server
+  s              scale the server (type the replica count, then enter)
+  ctrl-d         delete the server (protected servers are refused)
+  r              refresh now
+  esc            go back from a view or prompt
+  q, ctrl-c      quit
+
+The dashboard needs an interactive terminal.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := serverNamespace()
+			if allNamespaces {
+				namespace = ""
+			}
+			return mgr.RunDashboard(namespace, interval)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Show servers in every namespace")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Refresh interval")
+
+	return cmd
+}
+
+// RunDashboard runs the dashboard for namespace, or every namespace when it is empty,
+// until the user quits or the command is interrupted.
+func (m *ServerManager) RunDashboard(namespace string, interval time.Duration) error {
+	if interval <= 0 {
+		err := newWithSentinel(ErrDashboardUnavailable, fmt.Sprintf("--interval must be positive, got %s", interval))
+		Error("Invalid dashboard options")
+		logStructuredError(m.logger, err, "Invalid dashboard options")
+		return err
+	}
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		err := newWithSentinel(ErrDashboardUnavailable, "the dashboard needs an interactive terminal; use 'server status' or 'server top --watch' instead")
+		Error("Dashboard unavailable")
+		logStructuredError(m.logger, err, "Dashboard unavailable")
+		return err
+	}
+
+	oldState, err := term.MakeRaw(inFd)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrDashboardUnavailable,
+			err,
+			fmt.Sprintf("failed to switch the terminal to raw mode: %v", err),
+			map[string]any{"component": "dashboard"},
+		)
+		Error("Dashboard unavailable")
+		logStructuredError(m.logger, wrappedErr, "Dashboard unavailable")
+		return wrappedErr
+	}
+	// Use the alternate screen so the shell's scrollback is restored on exit.
+	fmt.Fprint(os.Stdout, "\033[?1049h\033[?25l")
+	defer func() {
+		fmt.Fprint(os.Stdout, "\033[?25h\033[?1049l")
+		_ = term.Restore(inFd, oldState)
+	}()
+
+	// The reader goroutine stays blocked on stdin after quitting; the process exits soon after.
+	keys := make(chan []byte)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+
+	// One load is in flight at a time, so the buffered send never blocks after quitting.
+	d := &dashboard{mgr: m, namespace: namespace, refreshes: make(chan dashboardSnapshot, 1)}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	ctx := commandContext()
+
+	d.requestRefresh()
+	for {
+		d.width, d.height = 80, 24
+		if w, h, err := term.GetSize(outFd); err == nil {
+			d.width, d.height = w, h
+		}
+		d.draw(os.Stdout)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.requestRefresh()
+		case snapshot := <-d.refreshes:
+			d.refreshing = false
+			d.apply(snapshot)
+		case input, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseDashboardKeys(input) {
+				if d.handleKey(key) {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// parseDashboardKeys splits raw terminal input into key names: "up", "down", "pgup",
+// "pgdown", "enter", "esc", "backspace", "ctrl-c", "ctrl-d", or the typed character.
+func parseDashboardKeys(input []byte) []string {
+	sequences := map[string]string{"\033[A": "up", "\033[B": "down", "\033[5~": "pgup", "\033[6~": "pgdown", "\033OA": "up", "\033OB": "down"}
+	var keys []string
+	for len(input) > 0 {
+		matched := false
+		for seq, key := range sequences {
+			if strings.HasPrefix(string(input), seq) {
+				keys = append(keys, key)
+				input = input[len(seq):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if len(input) > 2 && input[0] == 0x1b && (input[1] == '[' || input[1] == 'O') {
+			// Skip other escape sequences, such as left/right arrows, up to their final byte.
+			end := 2
+			for end < len(input) && (input[end] < 0x40 || input[end] > 0x7e) {
+				end++
+			}
+			input = input[min(end+1, len(input)):]
+			continue
+		}
+		switch b := input[0]; b {
+		case '\r', '\n':
+			keys = append(keys, "enter")
+		case 0x1b:
+			keys = append(keys, "esc")
+		case 0x7f, 0x08:
+			keys = append(keys, "backspace")
+		case 0x03:
+			keys = append(keys, "ctrl-c")
+		case 0x04:
+			keys = append(keys, "ctrl-d")
+		default:
+			keys = append(keys, string(rune(b)))
+		}
+		input = input[1:]
+	}
+	return keys
+}
+
+// handleKey applies one key and reports whether the dashboard should quit.
+func (d *dashboard) handleKey(key string) bool {
+	if key == "ctrl-c" {
+		return true
+	}
+	switch d.mode {
+	case dashboardView:
+		page := max(d.viewHeight()-1, 1)
+		switch key {
+		case "q":
+			return true
+		case "esc":
+			d.mode = dashboardList
+		case "up", "k":
+			d.scroll(-1)
+		case "down", "j":
+			d.scroll(1)
+		case "pgup":
+			d.scroll(-page)
+		case "pgdown", " ":
+			d.scroll(page)
+		}
+	case dashboardScale:
+		switch {
+		case key == "esc":
+			d.mode, d.status = dashboardList, ""
+		case key == "enter":
+			d.mode = dashboardList
+			d.scaleSelected(d.input)
+		case key == "backspace":
+			if d.input != "" {
+				d.input = d.input[:len(d.input)-1]
+			}
+		case len(key) == 1 && key[0] >= '0' && key[0] <= '9' && len(d.input) < 4:
+			d.input += key
+		}
+	case dashboardConfirmDelete:
+		d.mode = dashboardList
+		if key == "y" || key == "Y" {
+			d.deleteSelected()
+		} else {
+			d.status = "Delete canceled"
+		}
+	default:
+		switch key {
+		case "q":
+			return true
+		case "up", "k":
+			d.selectServer(d.selected - 1)
+		case "down", "j":
+			d.selectServer(d.selected + 1)
+		case "r":
+			d.requestRefresh()
+		case "l":
+			d.showLogs()
+		case "d":
+			d.describeSelected()
+		case "s":
+			if server, ok := d.current(); ok {
+				d.mode, d.input = dashboardScale, ""
+				d.status = fmt.Sprintf("Scale %s/%s to (currently %s): ", server.Namespace, server.Name, orDash(server.Replicas))
+			}
+		case "ctrl-d":
+			if server, ok := d.current(); ok {
+				d.mode = dashboardConfirmDelete
+				d.status = fmt.Sprintf("Delete %s/%s? (y/N) ", server.Namespace, server.Name)
+			}
+		}
+	}
+	return false
+}
+
+func (d *dashboard) current() (dashboardServer, bool) {
+	if d.selected < 0 || d.selected >= len(d.servers) {
+		return dashboardServer{}, false
+	}
+	return d.servers[d.selected], true
+}
+
+func (d *dashboard) selectServer(i int) {
+	if len(d.servers) == 0 {
+		d.selected = 0
+		return
+	}
+	d.selected = min(max(i, 0), len(d.servers)-1)
+	d.loadEvents()
+}
+
+func (d *dashboard) scroll(delta int) {
+	d.offset = min(max(d.offset+delta, 0), max(len(d.lines)-d.viewHeight(), 0))
+}
+
+// viewHeight is the number of content lines the logs and describe views can show.
+func (d *dashboard) viewHeight() int {
+	return max(d.height-3, 1)
+}
+
+// refresh reloads the servers, keeping the selection on the same server when it still exists.
+func (d *dashboard) refresh() {
+	previous, _ := d.current()
+	d.apply(d.mgr.dashboardSnapshot(d.namespace, previous, d.selected))
+}
+
+// requestRefresh starts a background reload whose result arrives on d.refreshes, unless
+// one is already in flight. Without a refreshes channel it reloads synchronously.
+func (d *dashboard) requestRefresh() {
+	if d.refreshes == nil {
+		d.refresh()
+		return
+	}
+	if d.refreshing {
+		return
+	}
+	d.refreshing = true
+	previous, _ := d.current()
+	mgr, namespace, selected := d.mgr, d.namespace, d.selected
+	go func() {
+		d.refreshes <- mgr.dashboardSnapshot(namespace, previous, selected)
+	}()
+}
+
+// apply shows a loaded snapshot. The selection follows the server selected now, which may
+// differ from the one the load started with; its events are reloaded in that case.
+func (d *dashboard) apply(snapshot dashboardSnapshot) {
+	previous, _ := d.current()
+	if snapshot.err != nil {
+		d.status = "Failed to list servers: " + snapshot.err.Error()
+		return
+	}
+	d.servers = snapshot.servers
+	d.selected = followSelection(snapshot.servers, previous, d.selected)
+	server, ok := d.current()
+	if !ok || !sameServer(server, snapshot.eventsOf) {
+		d.loadEvents()
+		return
+	}
+	if snapshot.eventsErr != nil {
+		d.status = "Failed to list events: " + snapshot.eventsErr.Error()
+		return
+	}
+	d.events = snapshot.events
+}
+
+// dashboardSnapshot loads the servers of namespace and the events of the server that
+// followSelection picks from previous and selected. It does not touch dashboard state, so
+// it can run off the key loop.
+func (m *ServerManager) dashboardSnapshot(namespace string, previous dashboardServer, selected int) dashboardSnapshot {
+	servers, err := m.dashboardServers(namespace)
+	if err != nil {
+		return dashboardSnapshot{err: err}
+	}
+	snapshot := dashboardSnapshot{servers: servers}
+	if i := followSelection(servers, previous, selected); i < len(servers) {
+		snapshot.eventsOf = servers[i]
+		snapshot.events, snapshot.eventsErr = m.dashboardEvents(servers[i])
+	}
+	return snapshot
+}
+
+// followSelection returns the index of previous in servers, or selected clamped to the list.
+func followSelection(servers []dashboardServer, previous dashboardServer, selected int) int {
+	for i, s := range servers {
+		if sameServer(s, previous) {
+			return i
+		}
+	}
+	return min(selected, max(len(servers)-1, 0))
+}
+
+func sameServer(a, b dashboardServer) bool {
+	return a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+func (d *dashboard) loadEvents() {
+	server, ok := d.current()
+	if !ok {
+		d.events = nil
+		return
+	}
+	events, err := d.mgr.dashboardEvents(server)
+	if err != nil {
+		d.status = "Failed to list events: " + err.Error()
+		return
+	}
+	d.events = events
+}
+
+func (d *dashboard) showLogs() {
+	server, ok := d.current()
+	if !ok {
+		return
+	}
+	// #nosec G204 -- name/namespace come from the cluster's MCPServer list; fixed tail count.
+	out, err := d.mgr.kubectl.Output([]string{"logs", "-l", LabelApp + "=" + server.Name, "-n", server.Namespace, "--tail", strconv.Itoa(dashboardLogTail), "--prefix", "--all-containers"})
+	d.openView("Logs of "+server.Namespace+"/"+server.Name, out, err)
+	d.offset = max(len(d.lines)-d.viewHeight(), 0)
+}
+
+func (d *dashboard) describeSelected() {
+	server, ok := d.current()
+	if !ok {
+		return
+	}
+	// #nosec G204 -- name/namespace come from the cluster's MCPServer list.
+	out, err := d.mgr.kubectl.Output([]string{"describe", "mcpserver", server.Name, "-n", server.Namespace})
+	d.openView("Describe "+server.Namespace+"/"+server.Name, out, err)
+}
+
+func (d *dashboard) openView(title string, out []byte, err error) {
+	if err != nil {
+		d.status = title + " failed: " + err.Error()
+		return
+	}
+	d.mode, d.title, d.offset = dashboardView, title, 0
+	d.lines = strings.Split(strings.TrimRight(strings.ReplaceAll(string(out), "\t", "    "), "\n"), "\n")
+}
+
+func (d *dashboard) scaleSelected(input string) {
+	server, ok := d.current()
+	if !ok {
+		return
+	}
+	replicas, err := strconv.Atoi(input)
+	if err != nil {
+		d.status = "Scale canceled: enter a replica count"
+		return
+	}
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+	// #nosec G204 -- name/namespace come from the cluster's MCPServer list; patch holds an integer.
+	if _, err := d.mgr.kubectl.Output([]string{"patch", "mcpserver", server.Name, "-n", server.Namespace, "--type=merge", "-p", patch}); err != nil {
+		d.status = "Scale failed: " + err.Error()
+		return
+	}
+	d.status = fmt.Sprintf("Scaled %s/%s to %d replicas", server.Namespace, server.Name, replicas)
+	d.requestRefresh()
+}
+
+func (d *dashboard) deleteSelected() {
+	server, ok := d.current()
+	if !ok {
+		return
+	}
+	if d.mgr.isServerProtected(server.Name, server.Namespace) {
+		d.status = fmt.Sprintf("%s/%s is protected (%s=true); use 'server delete --force'", server.Namespace, server.Name, AnnotationProtected)
+		return
+	}
+	d.mgr.logger.Info("Deleting MCP server", zap.String("name", server.Name), zap.String("namespace", server.Namespace))
+	// #nosec G204 -- name/namespace come from the cluster's MCPServer list.
+	if _, err := d.mgr.kubectl.Output([]string{"delete", "mcpserver", server.Name, "-n", server.Namespace, "--wait=false"}); err != nil {
+		d.status = "Delete failed: " + err.Error()
+		return
+	}
+	d.status = fmt.Sprintf("Deleted %s/%s", server.Namespace, server.Name)
+	d.requestRefresh()
+}
+
+// dashboardServers lists the servers of namespace, or of every namespace when it is empty,
+// with the ready replicas of their Deployments.
+func (m *ServerManager) dashboardServers(namespace string) ([]dashboardServer, error) {
+	scope := []string{"-n", namespace}
+	if namespace == "" {
+		scope = []string{"--all-namespaces"}
+	}
+	// #nosec G204 -- namespace from CLI flag; kubectl validates namespace names.
+	out, err := m.kubectl.Output(append([]string{"get", "mcpservers", "-o", "json"}, scope...))
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Replicas *int32 `json:"replicas"`
+			} `json:"spec"`
+			Status struct {
+				Phase string `json:"phase"`
+				URL   string `json:"url"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse MCPServers: %w", err)
+	}
+
+	// #nosec G204 -- namespace from CLI flag; fixed label selector.
+	out, err = m.kubectl.Output(append([]string{"get", "deployments", "-l", SelectorManagedBy, "-o", `jsonpath={range .items[*]}{.metadata.namespace}/{.metadata.name}={.status.readyReplicas}{"\n"}{end}`}, scope...))
+	if err != nil {
+		return nil, err
+	}
+	ready := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if key, count, ok := strings.Cut(line, "="); ok {
+			ready[key] = count
+		}
+	}
+
+	servers := make([]dashboardServer, 0, len(list.Items))
+	for _, item := range list.Items {
+		server := dashboardServer{
+			Namespace: item.Metadata.Namespace,
+			Name:      item.Metadata.Name,
+			Phase:     item.Status.Phase,
+			Ready:     "0",
+			URL:       item.Status.URL,
+		}
+		if item.Spec.Replicas != nil {
+			server.Replicas = strconv.Itoa(int(*item.Spec.Replicas))
+		}
+		if count := ready[server.Namespace+"/"+server.Name]; count != "" {
+			server.Ready = count
+		}
+		servers = append(servers, server)
+	}
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].Namespace != servers[j].Namespace {
+			return servers[i].Namespace < servers[j].Namespace
+		}
+		return servers[i].Name < servers[j].Name
+	})
+	return servers, nil
+}
+
+// dashboardEvents returns the events of server and of the objects named after it (its
+// Deployment, ReplicaSets and pods), newest first.
+func (m *ServerManager) dashboardEvents(server dashboardServer) ([]dashboardEvent, error) {
+	// #nosec G204 -- namespace comes from the cluster's MCPServer list.
+	out, err := m.kubectl.Output([]string{"get", "events", "-n", server.Namespace, "-o", "json"})
+	if err != nil {
+		return nil, err
+	}
+	var list corev1.EventList
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse events: %w", err)
+	}
+	var events []dashboardEvent
+	for _, e := range list.Items {
+		name := e.InvolvedObject.Name
+		if !ownsObject(server.Name, name) {
+			continue
+		}
+		events = append(events, dashboardEvent{
+			Time:    eventTime(e),
+			Type:    e.Type,
+			Object:  strings.ToLower(e.InvolvedObject.Kind) + "/" + name,
+			Reason:  e.Reason,
+			Message: strings.TrimSpace(e.Message),
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.After(events[j].Time) })
+	return events, nil
+}
+
+// draw writes the current screen to w.
+func (d *dashboard) draw(w io.Writer) {
+	lines := d.render(time.Now())
+	for i, line := range lines {
+		lines[i] = truncateDashboardLine(line, d.width)
+	}
+	// Raw mode does not translate "\n", so every line returns the cursor explicitly.
+	fmt.Fprint(w, "\033[H\033[2J"+strings.Join(lines, "\r\n"))
+}
+
+// render returns the screen's lines, at most d.height of them.
+func (d *dashboard) render(now time.Time) []string {
+	scope := d.namespace
+	if scope == "" {
+		scope = "all namespaces"
+	}
+	header := fmt.Sprintf("MCP Runtime dashboard — %s — %d server(s) — %s", scope, len(d.servers), now.Format("15:04:05"))
+
+	if d.mode == dashboardView {
+		lines := []string{header, d.title}
+		end := min(d.offset+d.viewHeight(), len(d.lines))
+		lines = append(lines, d.lines[d.offset:end]...)
+		for len(lines) < d.height-1 {
+			lines = append(lines, "")
+		}
+		return append(lines, dashboardViewHelp)
+	}
+
+	lines := []string{header, ""}
+	if len(d.servers) == 0 {
+		lines = append(lines, "No MCP servers found")
+	} else {
+		rows := [][]string{{"NAME", "PHASE", "READY", "ENDPOINT"}}
+		if d.namespace == "" {
+			rows[0] = append([]string{"NAMESPACE"}, rows[0]...)
+		}
+		for _, s := range d.servers {
+			row := []string{s.Name, orDash(s.Phase), s.Ready + "/" + orDash(s.Replicas), orDash(s.URL)}
+			if d.namespace == "" {
+				row = append([]string{s.Namespace}, row...)
+			}
+			rows = append(rows, row)
+		}
+		for i, line := range alignDashboardColumns(rows) {
+			if i > 0 && i-1 == d.selected {
+				// Reverse video marks the selected server.
+				line = "\033[7m" + line + "\033[0m"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	lines = append(lines, "")
+	if server, ok := d.current(); ok {
+		lines = append(lines, "Recent events of "+server.Name)
+		if len(d.events) == 0 {
+			lines = append(lines, "  none")
+		}
+		for _, e := range d.events {
+			if len(lines) >= d.height-2 {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("  %-6s %-8s %-28s %-18s %s", humanAge(e.Time, now), e.Type, e.Object, e.Reason, e.Message))
+		}
+	}
+	for len(lines) < d.height-2 {
+		lines = append(lines, "")
+	}
+	if len(lines) > d.height-2 {
+		lines = lines[:max(d.height-2, 0)]
+	}
+	status := d.status
+	if d.mode == dashboardScale {
+		status += d.input
+	}
+	return append(lines, status, dashboardHelp)
+}
+
+// alignDashboardColumns pads rows into left-aligned columns separated by two spaces.
+func alignDashboardColumns(rows [][]string) []string {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell + strings.Repeat(" ", widths[i]-len([]rune(cell))+2))
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
+
+// truncateDashboardLine cuts line to width visible characters, keeping escape sequences
+// and resetting attributes when it cuts.
+func truncateDashboardLine(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+	var b strings.Builder
+	visible := 0
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == 0x1b {
+			j := i
+			for j < len(runes) && !(runes[j] >= 'a' && runes[j] <= 'z' || runes[j] >= 'A' && runes[j] <= 'Z') {
+				j++
+			}
+			if j < len(runes) {
+				b.WriteString(string(runes[i : j+1]))
+			}
+			i = j
+			continue
+		}
+		if visible == width {
+			b.WriteString("\033[0m")
+			return b.String()
+		}
+		b.WriteRune(runes[i])
+		visible++
+	}
+	return b.String()
+}
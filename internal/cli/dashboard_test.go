@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	testDashboardServers = `{"items":[
+  {"metadata":{"name":"weather","namespace":"mcp-servers"},"spec":{"replicas":2},"status":{"phase":"Ready","url":"http://mcp.example.com/weather/mcp"}},
+  {"metadata":{"name":"search","namespace":"mcp-servers"},"spec":{"replicas":1},"status":{"phase":"Pending"}}
+]}`
+	testDashboardEvents = `{"items":[
+  {"involvedObject":{"kind":"Pod","name":"weather-7d9f-abcde"},"type":"Warning","reason":"BackOff","message":"Back-off restarting","lastTimestamp":"2026-01-01T00:02:00Z"},
+  {"involvedObject":{"kind":"MCPServer","name":"weather"},"type":"Normal","reason":"Deployed","message":"Deployment created","lastTimestamp":"2026-01-01T00:01:00Z"},
+  {"involvedObject":{"kind":"Pod","name":"weatherman-1"},"type":"Normal","reason":"Pulled","lastTimestamp":"2026-01-01T00:03:00Z"}
+]}`
+)
+
+// dashboardMock answers the dashboard's kubectl calls; protected names the servers that
+// carry the protected annotation.
+func dashboardMock(protected string) *MockExecutor {
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "get mcpservers"):
+			return &MockCommand{OutputData: []byte(testDashboardServers)}
+		case strings.HasPrefix(args, "get deployments"):
+			return &MockCommand{OutputData: []byte("mcp-servers/weather=2\n")}
+		case strings.HasPrefix(args, "get events"):
+			return &MockCommand{OutputData: []byte(testDashboardEvents)}
+		case strings.HasPrefix(args, "get mcpserver "):
+			if spec.Args[2] == protected {
+				return &MockCommand{OutputData: []byte("true")}
+			}
+		case strings.HasPrefix(args, "describe"):
+			return &MockCommand{OutputData: []byte("Name:\tsearch\nSpec:\n  Replicas:\t1\n")}
+		}
+		return &MockCommand{}
+	}
+	return mock
+}
+
+func newTestDashboard(mock *MockExecutor) *dashboard {
+	d := &dashboard{mgr: NewServerManager(&KubectlClient{exec: mock}, zap.NewNop()), namespace: "mcp-servers", width: 120, height: 20}
+	d.refresh()
+	return d
+}
+
+func TestParseDashboardKeys(t *testing.T) {
+	got := parseDashboardKeys([]byte("\033[Bq\r\033\x7f\x04\033[C5\033[6~"))
+	want := []string{"down", "q", "enter", "esc", "backspace", "ctrl-d", "5", "pgdown"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseDashboardKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestDashboardRefresh(t *testing.T) {
+	d := newTestDashboard(dashboardMock(""))
+
+	if len(d.servers) != 2 || d.servers[0].Name != "search" || d.servers[1].Name != "weather" {
+		t.Fatalf("expected servers sorted by name, got %+v", d.servers)
+	}
+	if d.servers[0].Ready != "0" || d.servers[1].Ready != "2" || d.servers[1].Replicas != "2" {
+		t.Fatalf("unexpected replicas %+v", d.servers)
+	}
+
+	d.handleKey("down")
+	if len(d.events) != 2 || d.events[0].Reason != "BackOff" || d.events[1].Object != "mcpserver/weather" {
+		t.Fatalf("expected the weather events newest first, got %+v", d.events)
+	}
+
+	// The selection follows the server across refreshes.
+	d.refresh()
+	if server, _ := d.current(); server.Name != "weather" {
+		t.Fatalf("selection moved to %s", server.Name)
+	}
+}
+
+func TestDashboardBackgroundRefresh(t *testing.T) {
+	mock := dashboardMock("")
+	release := make(chan struct{})
+	listMock := mock.CommandFunc
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		if strings.HasPrefix(strings.Join(spec.Args, " "), "get mcpservers") {
+			<-release
+		}
+		return listMock(spec)
+	}
+	d := &dashboard{mgr: NewServerManager(&KubectlClient{exec: mock}, zap.NewNop()), namespace: "mcp-servers", width: 120, height: 20, refreshes: make(chan dashboardSnapshot, 1)}
+
+	// Keys are handled while the load is blocked, and a second request does not start another load.
+	d.requestRefresh()
+	d.handleKey("r")
+	if !d.handleKey("q") {
+		t.Fatal("q should quit while a refresh is in flight")
+	}
+	close(release)
+
+	d.apply(<-d.refreshes)
+	if len(d.servers) != 2 || d.servers[0].Name != "search" {
+		t.Fatalf("expected the loaded servers, got %+v", d.servers)
+	}
+	select {
+	case snapshot := <-d.refreshes:
+		t.Fatalf("expected a single load, got another %+v", snapshot)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDashboardRender(t *testing.T) {
+	d := newTestDashboard(dashboardMock(""))
+	lines := d.render(time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC))
+	screen := strings.Join(lines, "\n")
+
+	if len(lines) != d.height {
+		t.Fatalf("expected %d lines, got %d", d.height, len(lines))
+	}
+	for _, want := range []string{"mcp-servers — 2 server(s)", "NAME     PHASE    READY  ENDPOINT", "\033[7msearch   Pending  0/1    -\033[0m", "http://mcp.example.com/weather/mcp", "Recent events of search", dashboardHelp} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("expected %q on screen:\n%s", want, screen)
+		}
+	}
+}
+
+func TestDashboardActions(t *testing.T) {
+	t.Run("scale patches replicas", func(t *testing.T) {
+		mock := dashboardMock("")
+		d := newTestDashboard(mock)
+		for _, key := range []string{"s", "1", "2", "backspace", "3", "enter"} {
+			d.handleKey(key)
+		}
+		var patched []string
+		for _, cmd := range mock.Commands {
+			if cmd.Args[0] == "patch" {
+				patched = cmd.Args
+			}
+		}
+		if !reflect.DeepEqual(patched, []string{"patch", "mcpserver", "search", "-n", "mcp-servers", "--type=merge", "-p", `{"spec":{"replicas":13}}`}) {
+			t.Fatalf("unexpected patch %v", patched)
+		}
+		if d.mode != dashboardList || d.status != "Scaled mcp-servers/search to 13 replicas" {
+			t.Fatalf("unexpected state mode=%d status=%q", d.mode, d.status)
+		}
+	})
+
+	t.Run("delete needs confirmation", func(t *testing.T) {
+		mock := dashboardMock("")
+		d := newTestDashboard(mock)
+		d.handleKey("ctrl-d")
+		d.handleKey("n")
+		d.handleKey("ctrl-d")
+		d.handleKey("y")
+		var deletes int
+		for _, cmd := range mock.Commands {
+			if cmd.Args[0] == "delete" {
+				deletes++
+			}
+		}
+		if deletes != 1 || d.status != "Deleted mcp-servers/search" {
+			t.Fatalf("expected one delete, got %d (status %q)", deletes, d.status)
+		}
+	})
+
+	t.Run("protected servers are refused", func(t *testing.T) {
+		mock := dashboardMock("search")
+		d := newTestDashboard(mock)
+		d.handleKey("ctrl-d")
+		d.handleKey("y")
+		if mock.LastCommand().Args[0] == "delete" || !strings.Contains(d.status, "is protected") {
+			t.Fatalf("expected a refusal, got status %q", d.status)
+		}
+	})
+
+	t.Run("describe opens a view", func(t *testing.T) {
+		d := newTestDashboard(dashboardMock(""))
+		d.handleKey("d")
+		if d.mode != dashboardView || d.title != "Describe mcp-servers/search" || d.lines[1] != "Spec:" {
+			t.Fatalf("unexpected view %q %q", d.title, d.lines)
+		}
+		if d.handleKey("esc"); d.mode != dashboardList {
+			t.Fatal("esc should return to the server list")
+		}
+		if !d.handleKey("q") {
+			t.Fatal("q should quit")
+		}
+	})
+
+	t.Run("failed logs stay in the list", func(t *testing.T) {
+		mock := dashboardMock("")
+		d := newTestDashboard(mock)
+		mock.CommandFunc = func(ExecSpec) *MockCommand { return &MockCommand{OutputErr: errors.New("no pods")} }
+		d.handleKey("l")
+		if d.mode != dashboardList || !strings.Contains(d.status, "no pods") {
+			t.Fatalf("unexpected state mode=%d status=%q", d.mode, d.status)
+		}
+	})
+}
+
+func TestRunDashboardRequiresPositiveInterval(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	if err := mgr.RunDashboard("mcp-servers", 0); !errors.Is(err, ErrDashboardUnavailable) {
+		t.Fatalf("expected ErrDashboardUnavailable, got %v", err)
+	}
+}
+
+func TestTruncateDashboardLine(t *testing.T) {
+	if got := truncateDashboardLine("\033[7mabcdef\033[0m", 3); got != "\033[7mabc\033[0m" {
+		t.Fatalf("truncateDashboardLine() = %q", got)
+	}
+	if got := truncateDashboardLine("abc", 10); got != "abc" {
+		t.Fatalf("truncateDashboardLine() = %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+package cli
+
+// This file implements the "doctor" command, which diagnoses the local environment:
+// the container runtime kind would use, the kind and kubectl binaries, and cluster access.
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Doctor check outcomes.
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// doctorCheck is the result of one diagnostic.
+type doctorCheck struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// DoctorManager runs environment diagnostics with injected dependencies.
+type DoctorManager struct {
+	kubectl *KubectlClient
+	exec    Executor
+	logger  *zap.Logger
+}
+
+// NewDoctorManager creates a DoctorManager with the given dependencies.
+func NewDoctorManager(kubectl *KubectlClient, exec Executor, logger *zap.Logger) *DoctorManager {
+	return &DoctorManager{
+		kubectl: kubectl,
+		exec:    exec,
+		logger:  logger,
+	}
+}
+
+// DefaultDoctorManager returns a DoctorManager using default clients.
+func DefaultDoctorManager(logger *zap.Logger) *DoctorManager {
+	return NewDoctorManager(kubectlClient, execExecutor, logger)
+}
+
+// NewDoctorCmd returns the doctor subcommand.
+func NewDoctorCmd(logger *zap.Logger) *cobra.Command {
+	return NewDoctorCmdWithManager(DefaultDoctorManager(logger))
+}
+
+// NewDoctorCmdWithManager returns the doctor subcommand using the provided manager.
+func NewDoctorCmdWithManager(mgr *DoctorManager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the local environment",
+		Long: `Check the container runtime used for kind clusters (Docker, Docker Desktop,
+colima, Rancher Desktop or Podman), the kind and kubectl binaries, and access
+to the current cluster.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mgr.Run()
+		},
+	}
+}
+
+// Run executes all checks, prints them, and fails if any required check failed.
+func (m *DoctorManager) Run() error {
+	checks := m.checks()
+
+	rows := [][]string{{"Check", "Status", "Detail"}}
+	var failed []string
+	for _, c := range checks {
+		status := Green(c.Status)
+		switch c.Status {
+		case doctorWarn:
+			status = Yellow(c.Status)
+		case doctorFail:
+			status = Red(c.Status)
+			failed = append(failed, c.Name)
+		}
+		rows = append(rows, []string{c.Name, status, c.Detail})
+	}
+	Header("Environment")
+	DefaultPrinter.Println()
+	TableBoxed(rows)
+
+	if len(failed) > 0 {
+		err := newWithSentinel(ErrDoctorChecksFailed, fmt.Sprintf("failed checks: %s", strings.Join(failed, ", ")))
+		Error("Some checks failed")
+		logStructuredError(m.logger, err, "Some checks failed")
+		return err
+	}
+	Success("All required checks passed")
+	return nil
+}
+
+func (m *DoctorManager) checks() []doctorCheck {
+	checks := []doctorCheck{{Name: "Platform", Status: doctorOK, Detail: runtime.GOOS + "/" + runtime.GOARCH}}
+
+	if rt, err := detectContainerRuntime(m.exec); err != nil {
+		checks = append(checks, doctorCheck{Name: "Container runtime", Status: doctorFail, Detail: containerRuntimeHint(hostOS)})
+	} else {
+		checks = append(checks, doctorCheck{Name: "Container runtime", Status: doctorOK, Detail: rt.String()})
+	}
+
+	if version, ok := m.binaryVersion("kind", "version"); ok {
+		checks = append(checks, doctorCheck{Name: "kind", Status: doctorOK, Detail: version})
+	} else {
+		checks = append(checks, doctorCheck{Name: "kind", Status: doctorWarn, Detail: "not found (needed for cluster provision --provider kind)"})
+	}
+
+	if version, ok := m.binaryVersion("kubectl", "version", "--client"); ok {
+		checks = append(checks, doctorCheck{Name: "kubectl", Status: doctorOK, Detail: version})
+	} else {
+		checks = append(checks, doctorCheck{Name: "kubectl", Status: doctorFail, Detail: "not found in PATH"})
+	}
+
+	// #nosec G204 -- fixed kubectl command.
+	if _, err := m.kubectl.Output([]string{"get", "namespace", "default", "-o", "name"}); err != nil {
+		checks = append(checks, doctorCheck{Name: "Cluster access", Status: doctorWarn, Detail: "current context unreachable (run: mcp-runtime cluster provision or mcp-runtime context list)"})
+	} else {
+		checks = append(checks, doctorCheck{Name: "Cluster access", Status: doctorOK, Detail: "reachable"})
+	}
+
+	return checks
+}
+
+// binaryVersion runs a version command and returns the first line of its output.
+func (m *DoctorManager) binaryVersion(bin string, args ...string) (string, bool) {
+	// #nosec G204 -- fixed binaries and version arguments.
+	cmd, err := m.exec.Command(commandContext(), bin, args, AllowlistBins("kind", "kubectl"), NoShellMeta(), NoControlChars())
+	if err != nil {
+		return "", false
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return line, true
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestDoctorManager_Run(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+
+	t.Run("passes with a running runtime", func(t *testing.T) {
+		stubRuntimeHost(t, "linux")
+		mock := &MockExecutor{DefaultOutput: []byte("kind v0.23.0 go1.22 linux/amd64\n")}
+		mgr := NewDoctorManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "docker (docker context)") {
+			t.Fatalf("expected runtime in output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("fails with a hint when no runtime runs", func(t *testing.T) {
+		stubRuntimeHost(t, "windows")
+		mock := runtimeExecutor("kind", "kubectl")
+		mgr := NewDoctorManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Run(); !errors.Is(err, ErrDoctorChecksFailed) {
+			t.Fatalf("expected ErrDoctorChecksFailed, got %v", err)
+		}
+		if !strings.Contains(buf.String(), "WSL 2") {
+			t.Fatalf("expected Windows hint in output:\n%s", buf.String())
+		}
+	})
+}
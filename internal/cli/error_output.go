@@ -0,0 +1,141 @@
+package cli
+
+// This file renders the error that ends a command. Every sentinel carries a stable
+// MCP-<CATEGORY>-<NNN> id (see docs/errors.md for what to do about each one); the text
+// format prefixes the message with it and the json format emits {code, message, context}
+// so scripts can branch on the id instead of parsing messages.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"mcp-runtime/pkg/errx"
+)
+
+// Error output formats accepted by --error-format.
+const (
+	ErrorFormatText = "text"
+	ErrorFormatJSON = "json"
+)
+
+// UnknownErrorID is reported for errors that carry no registered sentinel, such as
+// flag parsing errors from cobra.
+const UnknownErrorID = "MCP-CLI-000"
+
+// errorReport is the json form of a failed command.
+type errorReport struct {
+	Code     string         `json:"code"`
+	Category string         `json:"category"`
+	Message  string         `json:"message"`
+	Context  map[string]any `json:"context,omitempty"`
+}
+
+// ValidateErrorFormat reports whether format is a supported --error-format value.
+func ValidateErrorFormat(format string) error {
+	switch format {
+	case ErrorFormatText, ErrorFormatJSON:
+		return nil
+	}
+	return newWithSentinel(ErrInvalidErrorFormat, fmt.Sprintf("invalid --error-format %q (use %s or %s)", format, ErrorFormatText, ErrorFormatJSON))
+}
+
+// ErrorID returns the stable id of the innermost registered sentinel in err's chain,
+// or UnknownErrorID when there is none.
+func ErrorID(err error) string {
+	if spec, ok := sentinelSpec(err); ok {
+		return spec.id
+	}
+	return UnknownErrorID
+}
+
+// sentinelSpec walks err's chain and returns the spec of the innermost registered sentinel,
+// either the error itself or the base of an errx.Error. The innermost one is the most
+// specific: callers such as the setup steps wrap every failure in a generic sentinel.
+func sentinelSpec(err error) (errorSpec, bool) {
+	var found errorSpec
+	var ok bool
+	walkErrorChain(err, func(e error) {
+		// Map lookups panic on uncomparable dynamic types, which some error structs are.
+		if reflect.TypeOf(e).Comparable() {
+			if spec, registered := errorSpecs[e]; registered {
+				found, ok = spec, true
+				return
+			}
+		}
+		if errxErr, isErrx := e.(*errx.Error); isErrx {
+			if spec, registered := errorSpecs[errxErr.Base()]; registered {
+				found, ok = spec, true
+			}
+		}
+	})
+	return found, ok
+}
+
+// errorContext merges the context of every errx.Error in err's chain; inner errors win
+// on conflicting keys, matching the sentinel sentinelSpec reports.
+func errorContext(err error) map[string]any {
+	var merged map[string]any
+	walkErrorChain(err, func(e error) {
+		errxErr, ok := e.(*errx.Error)
+		if !ok {
+			return
+		}
+		for key, value := range errxErr.Context() {
+			if merged == nil {
+				merged = map[string]any{}
+			}
+			merged[key] = value
+		}
+	})
+	return merged
+}
+
+// walkErrorChain calls visit for err and everything it wraps, outermost first, following
+// both Unwrap() error and Unwrap() []error.
+func walkErrorChain(err error, visit func(error)) {
+	if err == nil {
+		return
+	}
+	visit(err)
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			walkErrorChain(inner, visit)
+		}
+	case interface{ Unwrap() error }:
+		walkErrorChain(e.Unwrap(), visit)
+	}
+}
+
+// WriteError prints err to w in format. The text format is "Error [id]: message";
+// errors without a registered sentinel keep the plain "Error: message" form.
+func WriteError(w io.Writer, err error, format string) {
+	if err == nil {
+		return
+	}
+	spec, ok := sentinelSpec(err)
+	if format == ErrorFormatJSON {
+		report := errorReport{Code: UnknownErrorID, Category: errx.DescCLI, Message: err.Error()}
+		if ok {
+			report.Code, report.Category = spec.id, spec.description
+		}
+		report.Context = errorContext(err)
+		data, marshalErr := json.Marshal(report)
+		if marshalErr == nil {
+			_, _ = fmt.Fprintln(w, string(data))
+			return
+		}
+		// Context values that cannot be marshalled should not hide the error itself.
+		report.Context = nil
+		data, _ = json.Marshal(report)
+		_, _ = fmt.Fprintln(w, string(data))
+		return
+	}
+	if !ok {
+		_, _ = fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Error [%s]: %v\n", spec.id, err)
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestErrorIDsAreUniqueAndWellFormed(t *testing.T) {
+	idRe := regexp.MustCompile(`^MCP-[A-Z]+-\d{3}$`)
+	seen := map[string]error{}
+	for sentinel, spec := range errorSpecs {
+		if !idRe.MatchString(spec.id) {
+			t.Errorf("sentinel %q has malformed id %q", sentinel, spec.id)
+		}
+		if prev, ok := seen[spec.id]; ok {
+			t.Errorf("id %s used by both %q and %q", spec.id, prev, sentinel)
+		}
+		seen[spec.id] = sentinel
+	}
+}
+
+func TestErrorIDsAreDocumented(t *testing.T) {
+	data, err := os.ReadFile("../../docs/errors.md")
+	if err != nil {
+		t.Fatalf("read docs/errors.md: %v", err)
+	}
+	for sentinel, spec := range errorSpecs {
+		if !strings.Contains(string(data), "`"+spec.id+"`") {
+			t.Errorf("%s (%q) is missing from docs/errors.md", spec.id, sentinel)
+		}
+	}
+}
+
+func TestErrorID(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "bare sentinel", err: ErrImageRequired, want: "MCP-CLI-001"},
+		{name: "errx wrapper", err: newWithSentinel(ErrDeployRegistryFailed, "failed to deploy registry"), want: "MCP-SETUP-004"},
+		{name: "fmt wrapped", err: fmt.Errorf("step: %w", wrapWithSentinel(ErrServerNotReady, errors.New("timeout"), "not ready")), want: "MCP-SERVER-015"},
+		{name: "innermost wins", err: wrapWithSentinel(ErrSetupStepFailed, newWithSentinel(ErrDeployRegistryFailed, "inner"), "outer"), want: "MCP-SETUP-004"},
+		{name: "joined", err: fmt.Errorf("%w: %w", errors.New("step"), newWithSentinel(ErrDeployRegistryFailed, "inner")), want: "MCP-SETUP-004"},
+		{name: "unregistered", err: errors.New("unknown flag: --foo"), want: UnknownErrorID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorID(tt.err); got != tt.want {
+				t.Fatalf("ErrorID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	err := wrapWithSentinelAndContext(
+		ErrDeployRegistryFailed,
+		errors.New("pods not ready"),
+		"failed to deploy registry: pods not ready",
+		map[string]any{"namespace": "registry", "component": "registry"},
+	)
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		WriteError(&buf, err, ErrorFormatText)
+		if got, want := buf.String(), "Error [MCP-SETUP-004]: failed to deploy registry: pods not ready\n"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("text without sentinel", func(t *testing.T) {
+		var buf bytes.Buffer
+		WriteError(&buf, errors.New("unknown command"), ErrorFormatText)
+		if got, want := buf.String(), "Error: unknown command\n"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		WriteError(&buf, err, ErrorFormatJSON)
+		var report errorReport
+		if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+			t.Fatalf("output is not json: %v\n%s", err, buf.String())
+		}
+		if report.Code != "MCP-SETUP-004" || report.Message != "failed to deploy registry: pods not ready" {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+		if report.Context["namespace"] != "registry" || report.Context["component"] != "registry" {
+			t.Fatalf("context = %v", report.Context)
+		}
+	})
+
+	t.Run("json reports the setup step's own sentinel", func(t *testing.T) {
+		stepErr := wrapWithSentinelAndContext(ErrSetupStepFailed, err, "setup step registry failed", map[string]any{"step": "registry", "component": "setup"})
+		var buf bytes.Buffer
+		WriteError(&buf, stepErr, ErrorFormatJSON)
+		var report errorReport
+		if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+			t.Fatalf("output is not json: %v\n%s", err, buf.String())
+		}
+		if report.Code != "MCP-SETUP-004" {
+			t.Fatalf("code = %s, want MCP-SETUP-004", report.Code)
+		}
+		if report.Context["step"] != "registry" || report.Context["namespace"] != "registry" || report.Context["component"] != "registry" {
+			t.Fatalf("context = %v", report.Context)
+		}
+	})
+
+	t.Run("json without sentinel", func(t *testing.T) {
+		var buf bytes.Buffer
+		WriteError(&buf, errors.New("unknown command"), ErrorFormatJSON)
+		if got, want := strings.TrimSpace(buf.String()), `{"code":"MCP-CLI-000","category":"CLI/argument validation error","message":"unknown command"}`; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
+
+func TestValidateErrorFormat(t *testing.T) {
+	for _, format := range []string{ErrorFormatText, ErrorFormatJSON} {
+		if err := ValidateErrorFormat(format); err != nil {
+			t.Fatalf("ValidateErrorFormat(%q) error: %v", format, err)
+		}
+	}
+	err := ValidateErrorFormat("yaml")
+	if !errors.Is(err, ErrInvalidErrorFormat) {
+		t.Fatalf("ValidateErrorFormat(yaml) = %v, want ErrInvalidErrorFormat", err)
+	}
+}
@@ -36,15 +36,18 @@ func IsDebugMode() bool {
 }
 
 type errorSpec struct {
+	id          string
 	code        string
 	description string
 }
 
 // newSentinelError creates a sentinel error and registers it in errorSpecs in one step.
 // This eliminates redundancy between error definitions and errorSpecs mapping.
-func newSentinelError(msg string, code, description string) error {
+// id is the stable MCP-<CATEGORY>-<NNN> identifier shown to users and documented in
+// docs/errors.md; never renumber an existing id, append new ones to their category.
+func newSentinelError(id, msg string, code, description string) error {
 	err := errors.New(msg)
-	errorSpecs[err] = errorSpec{code: code, description: description}
+	errorSpecs[err] = errorSpec{id: id, code: code, description: description}
 	return err
 }
 
@@ -91,131 +94,241 @@ func wrapWithSentinelAndContext(base, cause error, msg string, context map[strin
 // Errors are defined and registered in one step using newSentinelError to eliminate redundancy.
 var (
 	// CLI errors.
-	ErrImageRequired             = newSentinelError("image is required", errx.CodeCLI, errx.DescCLI)
-	ErrInvalidServerName         = newSentinelError("invalid server name", errx.CodeCLI, errx.DescCLI)
-	ErrGetWorkingDirectoryFailed = newSentinelError("get working directory", errx.CodeCLI, errx.DescCLI)
-	ErrControlCharsNotAllowed    = newSentinelError("value must not contain control characters", errx.CodeCLI, errx.DescCLI)
-	ErrFieldRequired             = newSentinelError("field is required", errx.CodeCLI, errx.DescCLI)
-	ErrGetHomeDirectoryFailed    = newSentinelError("failed to get home directory", errx.CodeCLI, errx.DescCLI)
-	ErrUnknownRegistryMode       = newSentinelError("unknown registry mode", errx.CodeCLI, errx.DescCLI)
+	ErrImageRequired             = newSentinelError("MCP-CLI-001", "image is required", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidServerName         = newSentinelError("MCP-CLI-002", "invalid server name", errx.CodeCLI, errx.DescCLI)
+	ErrGetWorkingDirectoryFailed = newSentinelError("MCP-CLI-003", "get working directory", errx.CodeCLI, errx.DescCLI)
+	ErrControlCharsNotAllowed    = newSentinelError("MCP-CLI-004", "value must not contain control characters", errx.CodeCLI, errx.DescCLI)
+	ErrFieldRequired             = newSentinelError("MCP-CLI-005", "field is required", errx.CodeCLI, errx.DescCLI)
+	ErrGetHomeDirectoryFailed    = newSentinelError("MCP-CLI-006", "failed to get home directory", errx.CodeCLI, errx.DescCLI)
+	ErrUnknownRegistryMode       = newSentinelError("MCP-CLI-007", "unknown registry mode", errx.CodeCLI, errx.DescCLI)
+	ErrCommandTimeout            = newSentinelError("MCP-CLI-008", "command timed out", errx.CodeCLI, errx.DescCLI)
+	ErrCommandCanceled           = newSentinelError("MCP-CLI-009", "command interrupted", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidSBOMFormat         = newSentinelError("MCP-CLI-010", "invalid SBOM format", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidHelperPodTemplate  = newSentinelError("MCP-CLI-011", "invalid helper pod template", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidOperatorReplicas   = newSentinelError("MCP-CLI-012", "invalid operator replicas", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidPushImages         = newSentinelError("MCP-CLI-013", "invalid push images", errx.CodeCLI, errx.DescCLI)
+	ErrReadImageListFailed       = newSentinelError("MCP-CLI-014", "failed to read image list", errx.CodeCLI, errx.DescCLI)
+	ErrDoctorChecksFailed        = newSentinelError("MCP-CLI-015", "environment checks failed", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidExternalDNS        = newSentinelError("MCP-CLI-016", "invalid external-dns settings", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidRegistryAuth       = newSentinelError("MCP-CLI-017", "invalid registry auth mode", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidSetupTimeout       = newSentinelError("MCP-CLI-018", "invalid setup timeout", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidRepository         = newSentinelError("MCP-CLI-019", "invalid repository name", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidNotifyURL          = newSentinelError("MCP-CLI-020", "invalid notify webhook URL", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidOperatorOptions    = newSentinelError("MCP-CLI-021", "invalid operator options", errx.CodeCLI, errx.DescCLI)
+	ErrReadSetupConfigFailed     = newSentinelError("MCP-CLI-022", "failed to read setup config", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidTopOptions         = newSentinelError("MCP-CLI-023", "invalid top options", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidImageReference     = newSentinelError("MCP-CLI-024", "invalid image reference", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidErrorFormat        = newSentinelError("MCP-CLI-025", "invalid error format", errx.CodeCLI, errx.DescCLI)
+	ErrDashboardUnavailable      = newSentinelError("MCP-CLI-026", "dashboard unavailable", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidOutputMode         = newSentinelError("MCP-CLI-027", "invalid output mode", errx.CodeCLI, errx.DescCLI)
+	ErrAPITokenRequired          = newSentinelError("MCP-CLI-028", "API token is required", errx.CodeCLI, errx.DescCLI)
+	ErrAPIUnauthorized           = newSentinelError("MCP-CLI-029", "API request unauthorized", errx.CodeCLI, errx.DescCLI)
+	ErrInvalidAPIRequest         = newSentinelError("MCP-CLI-030", "invalid API request", errx.CodeCLI, errx.DescCLI)
+	ErrServeAPIFailed            = newSentinelError("MCP-CLI-031", "API server failed", errx.CodeCLI, errx.DescCLI)
+	ErrCleanTempFilesFailed      = newSentinelError("MCP-CLI-032", "failed to clean temporary files", errx.CodeCLI, errx.DescCLI)
+	ErrExecPolicyViolation       = newSentinelError("MCP-CLI-033", "command refused by exec policy", errx.CodeCLI, errx.DescCLI)
 
 	// Pipeline errors.
-	ErrLoadMetadataFailed      = newSentinelError("failed to load metadata", errx.CodePipeline, errx.DescPipeline)
-	ErrNoServersInMetadata     = newSentinelError("no servers found in metadata", errx.CodePipeline, errx.DescPipeline)
-	ErrGenerateCRDsFailed      = newSentinelError("failed to generate CRDs", errx.CodePipeline, errx.DescPipeline)
-	ErrListManifestFilesFailed = newSentinelError("failed to list manifest files", errx.CodePipeline, errx.DescPipeline)
-	ErrNoManifestFilesFound    = newSentinelError("no manifest files found", errx.CodePipeline, errx.DescPipeline)
-	ErrApplyManifestFailed     = newSentinelError("failed to apply manifest", errx.CodePipeline, errx.DescPipeline)
+	ErrLoadMetadataFailed      = newSentinelError("MCP-PIPELINE-001", "failed to load metadata", errx.CodePipeline, errx.DescPipeline)
+	ErrNoServersInMetadata     = newSentinelError("MCP-PIPELINE-002", "no servers found in metadata", errx.CodePipeline, errx.DescPipeline)
+	ErrGenerateCRDsFailed      = newSentinelError("MCP-PIPELINE-003", "failed to generate CRDs", errx.CodePipeline, errx.DescPipeline)
+	ErrListManifestFilesFailed = newSentinelError("MCP-PIPELINE-004", "failed to list manifest files", errx.CodePipeline, errx.DescPipeline)
+	ErrNoManifestFilesFound    = newSentinelError("MCP-PIPELINE-005", "no manifest files found", errx.CodePipeline, errx.DescPipeline)
+	ErrApplyManifestFailed     = newSentinelError("MCP-PIPELINE-006", "failed to apply manifest", errx.CodePipeline, errx.DescPipeline)
 
 	// Operator errors.
-	ErrOperatorNotFound = newSentinelError("operator not found", errx.CodeOperator, errx.DescOperator)
-	ErrOperatorNotReady = newSentinelError("operator not ready", errx.CodeOperator, errx.DescOperator)
+	ErrOperatorNotFound      = newSentinelError("MCP-OPERATOR-001", "operator not found", errx.CodeOperator, errx.DescOperator)
+	ErrOperatorNotReady      = newSentinelError("MCP-OPERATOR-002", "operator not ready", errx.CodeOperator, errx.DescOperator)
+	ErrOperatorProfileFailed = newSentinelError("MCP-OPERATOR-003", "failed to profile the operator", errx.CodeOperator, errx.DescOperator)
 
 	// Setup errors.
-	ErrClusterInitFailed                  = newSentinelError("failed to initialize cluster", errx.CodeSetup, errx.DescSetup)
-	ErrClusterConfigFailed                = newSentinelError("cluster configuration failed", errx.CodeSetup, errx.DescSetup)
-	ErrTLSSetupFailed                     = newSentinelError("TLS setup failed", errx.CodeSetup, errx.DescSetup)
-	ErrDeployRegistryFailed               = newSentinelError("failed to deploy registry", errx.CodeSetup, errx.DescSetup)
-	ErrOperatorImageBuildFailed           = newSentinelError("operator image build failed", errx.CodeSetup, errx.DescSetup)
-	ErrEnsureRegistryNamespaceFailed      = newSentinelError("failed to ensure registry namespace", errx.CodeSetup, errx.DescSetup)
-	ErrPushOperatorImageInternalFailed    = newSentinelError("failed to push operator image to internal registry", errx.CodeSetup, errx.DescSetup)
-	ErrOperatorDeploymentFailed           = newSentinelError("operator deployment failed", errx.CodeSetup, errx.DescSetup)
-	ErrConfigureExternalRegistryEnvFailed = newSentinelError("failed to configure external registry env on operator", errx.CodeSetup, errx.DescSetup)
-	ErrRestartOperatorDeploymentFailed    = newSentinelError("failed to restart operator deployment after registry env update", errx.CodeSetup, errx.DescSetup)
-	ErrCRDCheckFailed                     = newSentinelError("CRD check failed", errx.CodeSetup, errx.DescSetup)
-	ErrRenderSecretManifestFailed         = newSentinelError("render secret manifest", errx.CodeSetup, errx.DescSetup)
-	ErrApplySecretManifestFailed          = newSentinelError("apply secret manifest", errx.CodeSetup, errx.DescSetup)
-	ErrMarshalDockerConfigFailed          = newSentinelError("marshal docker config", errx.CodeSetup, errx.DescSetup)
-	ErrApplyImagePullSecretFailed         = newSentinelError("apply imagePullSecret", errx.CodeSetup, errx.DescSetup)
-	ErrPushImageInClusterFailed           = newSentinelError("failed to push image in-cluster", errx.CodeSetup, errx.DescSetup)
-	ErrSetupStepFailed                    = newSentinelError("setup step failed", errx.CodeSetup, errx.DescSetup)
-	ErrApplyCRDFailed                     = newSentinelError("failed to apply CRD", errx.CodeSetup, errx.DescSetup)
-	ErrEnsureOperatorNamespaceFailed      = newSentinelError("failed to ensure operator namespace", errx.CodeSetup, errx.DescSetup)
-	ErrApplyRBACFailed                    = newSentinelError("failed to apply RBAC", errx.CodeSetup, errx.DescSetup)
-	ErrReadManagerYAMLFailed              = newSentinelError("failed to read manager.yaml", errx.CodeSetup, errx.DescSetup)
-	ErrCreateTempFileFailed               = newSentinelError("failed to create temp file", errx.CodeSetup, errx.DescSetup)
-	ErrCloseTempFileFailed                = newSentinelError("failed to close temp file", errx.CodeSetup, errx.DescSetup)
-	ErrWriteTempFileFailed                = newSentinelError("failed to write temp file", errx.CodeSetup, errx.DescSetup)
-	ErrApplyManagerDeploymentFailed       = newSentinelError("failed to apply manager deployment", errx.CodeSetup, errx.DescSetup)
-	ErrClusterIssuerApplyFailed           = newSentinelError("failed to apply ClusterIssuer", errx.CodeSetup, errx.DescSetup)
-	ErrCreateRegistryNamespaceFailed      = newSentinelError("failed to create registry namespace", errx.CodeSetup, errx.DescSetup)
-	ErrApplyCertificateFailed             = newSentinelError("failed to apply Certificate", errx.CodeSetup, errx.DescSetup)
+	ErrClusterInitFailed                  = newSentinelError("MCP-SETUP-001", "failed to initialize cluster", errx.CodeSetup, errx.DescSetup)
+	ErrClusterConfigFailed                = newSentinelError("MCP-SETUP-002", "cluster configuration failed", errx.CodeSetup, errx.DescSetup)
+	ErrTLSSetupFailed                     = newSentinelError("MCP-SETUP-003", "TLS setup failed", errx.CodeSetup, errx.DescSetup)
+	ErrDeployRegistryFailed               = newSentinelError("MCP-SETUP-004", "failed to deploy registry", errx.CodeSetup, errx.DescSetup)
+	ErrOperatorImageBuildFailed           = newSentinelError("MCP-SETUP-005", "operator image build failed", errx.CodeSetup, errx.DescSetup)
+	ErrEnsureRegistryNamespaceFailed      = newSentinelError("MCP-SETUP-006", "failed to ensure registry namespace", errx.CodeSetup, errx.DescSetup)
+	ErrPushOperatorImageInternalFailed    = newSentinelError("MCP-SETUP-007", "failed to push operator image to internal registry", errx.CodeSetup, errx.DescSetup)
+	ErrOperatorDeploymentFailed           = newSentinelError("MCP-SETUP-008", "operator deployment failed", errx.CodeSetup, errx.DescSetup)
+	ErrConfigureExternalRegistryEnvFailed = newSentinelError("MCP-SETUP-009", "failed to configure external registry env on operator", errx.CodeSetup, errx.DescSetup)
+	ErrRestartOperatorDeploymentFailed    = newSentinelError("MCP-SETUP-010", "failed to restart operator deployment after registry env update", errx.CodeSetup, errx.DescSetup)
+	ErrCRDCheckFailed                     = newSentinelError("MCP-SETUP-011", "CRD check failed", errx.CodeSetup, errx.DescSetup)
+	ErrRenderSecretManifestFailed         = newSentinelError("MCP-SETUP-012", "render secret manifest", errx.CodeSetup, errx.DescSetup)
+	ErrApplySecretManifestFailed          = newSentinelError("MCP-SETUP-013", "apply secret manifest", errx.CodeSetup, errx.DescSetup)
+	ErrMarshalDockerConfigFailed          = newSentinelError("MCP-SETUP-014", "marshal docker config", errx.CodeSetup, errx.DescSetup)
+	ErrApplyImagePullSecretFailed         = newSentinelError("MCP-SETUP-015", "apply imagePullSecret", errx.CodeSetup, errx.DescSetup)
+	ErrPushImageInClusterFailed           = newSentinelError("MCP-SETUP-016", "failed to push image in-cluster", errx.CodeSetup, errx.DescSetup)
+	ErrSetupStepFailed                    = newSentinelError("MCP-SETUP-017", "setup step failed", errx.CodeSetup, errx.DescSetup)
+	ErrApplyCRDFailed                     = newSentinelError("MCP-SETUP-018", "failed to apply CRD", errx.CodeSetup, errx.DescSetup)
+	ErrEnsureOperatorNamespaceFailed      = newSentinelError("MCP-SETUP-019", "failed to ensure operator namespace", errx.CodeSetup, errx.DescSetup)
+	ErrApplyRBACFailed                    = newSentinelError("MCP-SETUP-020", "failed to apply RBAC", errx.CodeSetup, errx.DescSetup)
+	ErrReadManagerYAMLFailed              = newSentinelError("MCP-SETUP-021", "failed to read manager.yaml", errx.CodeSetup, errx.DescSetup)
+	ErrCreateTempFileFailed               = newSentinelError("MCP-SETUP-022", "failed to create temp file", errx.CodeSetup, errx.DescSetup)
+	ErrCloseTempFileFailed                = newSentinelError("MCP-SETUP-023", "failed to close temp file", errx.CodeSetup, errx.DescSetup)
+	ErrWriteTempFileFailed                = newSentinelError("MCP-SETUP-024", "failed to write temp file", errx.CodeSetup, errx.DescSetup)
+	ErrApplyManagerDeploymentFailed       = newSentinelError("MCP-SETUP-025", "failed to apply manager deployment", errx.CodeSetup, errx.DescSetup)
+	ErrClusterIssuerApplyFailed           = newSentinelError("MCP-SETUP-026", "failed to apply ClusterIssuer", errx.CodeSetup, errx.DescSetup)
+	ErrCreateRegistryNamespaceFailed      = newSentinelError("MCP-SETUP-027", "failed to create registry namespace", errx.CodeSetup, errx.DescSetup)
+	ErrApplyCertificateFailed             = newSentinelError("MCP-SETUP-028", "failed to apply Certificate", errx.CodeSetup, errx.DescSetup)
+	ErrOfflineImagesMissing               = newSentinelError("MCP-SETUP-029", "required images missing for offline setup", errx.CodeSetup, errx.DescSetup)
+	ErrImagesDirInvalid                   = newSentinelError("MCP-SETUP-030", "images directory not usable", errx.CodeSetup, errx.DescSetup)
+	ErrLoadImageArchiveFailed             = newSentinelError("MCP-SETUP-031", "failed to load image archive", errx.CodeSetup, errx.DescSetup)
+	ErrDeployObservabilityFailed          = newSentinelError("MCP-SETUP-032", "failed to deploy observability stack", errx.CodeSetup, errx.DescSetup)
+	ErrGrafanaAdminSecretFailed           = newSentinelError("MCP-SETUP-033", "failed to create Grafana admin secret", errx.CodeSetup, errx.DescSetup)
+	ErrObservabilityNotReady              = newSentinelError("MCP-SETUP-034", "observability stack not ready", errx.CodeSetup, errx.DescSetup)
+	ErrExportDashboardsFailed             = newSentinelError("MCP-SETUP-035", "failed to export dashboards", errx.CodeSetup, errx.DescSetup)
+	ErrApplyDashboardsFailed              = newSentinelError("MCP-SETUP-036", "failed to apply dashboards", errx.CodeSetup, errx.DescSetup)
+	ErrApplyOperatorPDBFailed             = newSentinelError("MCP-SETUP-037", "failed to apply operator PodDisruptionBudget", errx.CodeSetup, errx.DescSetup)
+	ErrOperatorFailoverFailed             = newSentinelError("MCP-SETUP-038", "operator leader failover check failed", errx.CodeSetup, errx.DescSetup)
+	ErrDeployExternalDNSFailed            = newSentinelError("MCP-SETUP-039", "failed to deploy external-dns", errx.CodeSetup, errx.DescSetup)
+	ErrExternalDNSNotReady                = newSentinelError("MCP-SETUP-040", "external-dns not ready", errx.CodeSetup, errx.DescSetup)
+	ErrEnableRegistryAuthFailed           = newSentinelError("MCP-SETUP-041", "failed to enable registry authentication", errx.CodeSetup, errx.DescSetup)
+	ErrConfigureDualIngressFailed         = newSentinelError("MCP-SETUP-042", "failed to enable dual ingress", errx.CodeSetup, errx.DescSetup)
+	ErrPreflightFailed                    = newSentinelError("MCP-SETUP-043", "setup pre-flight checks failed", errx.CodeSetup, errx.DescSetup)
+	ErrInvalidContainerTool               = newSentinelError("MCP-SETUP-044", "invalid container tool", errx.CodeSetup, errx.DescSetup)
+	ErrInvalidServiceIPFamilies           = newSentinelError("MCP-SETUP-045", "invalid service IP families", errx.CodeSetup, errx.DescSetup)
+	ErrConfigureServiceIPFamiliesFailed   = newSentinelError("MCP-SETUP-046", "failed to configure service IP families", errx.CodeSetup, errx.DescSetup)
+	ErrInvalidSetupProfile                = newSentinelError("MCP-SETUP-047", "invalid setup profile", errx.CodeSetup, errx.DescSetup)
+	ErrConfigureNamespaceQuotasFailed     = newSentinelError("MCP-SETUP-048", "failed to configure namespace quotas", errx.CodeSetup, errx.DescSetup)
+	ErrInvalidProxy                       = newSentinelError("MCP-SETUP-049", "invalid proxy settings", errx.CodeSetup, errx.DescSetup)
+	ErrConfigureProxyFailed               = newSentinelError("MCP-SETUP-050", "failed to configure the operator proxy", errx.CodeSetup, errx.DescSetup)
+	ErrOfflineMirrorRequired              = newSentinelError("MCP-SETUP-051", "offline setup needs an image mirror", errx.CodeSetup, errx.DescSetup)
 
 	// Cert errors.
-	ErrCertManagerNotInstalled     = newSentinelError("cert-manager not installed", errx.CodeCert, errx.DescCert)
-	ErrCASecretNotFound            = newSentinelError("CA secret not found", errx.CodeCert, errx.DescCert)
-	ErrCertificateNotReady         = newSentinelError("certificate not ready", errx.CodeCert, errx.DescCert)
-	ErrClusterIssuerNotFound       = newSentinelError("ClusterIssuer not found", errx.CodeCert, errx.DescCert)
-	ErrRegistryCertificateNotFound = newSentinelError("registry Certificate not found", errx.CodeCert, errx.DescCert)
+	ErrCertManagerNotInstalled     = newSentinelError("MCP-CERT-001", "cert-manager not installed", errx.CodeCert, errx.DescCert)
+	ErrCASecretNotFound            = newSentinelError("MCP-CERT-002", "CA secret not found", errx.CodeCert, errx.DescCert)
+	ErrCertificateNotReady         = newSentinelError("MCP-CERT-003", "certificate not ready", errx.CodeCert, errx.DescCert)
+	ErrClusterIssuerNotFound       = newSentinelError("MCP-CERT-004", "ClusterIssuer not found", errx.CodeCert, errx.DescCert)
+	ErrRegistryCertificateNotFound = newSentinelError("MCP-CERT-005", "registry Certificate not found", errx.CodeCert, errx.DescCert)
 
 	// Cluster errors.
-	ErrCRDNotInstalled                = newSentinelError("MCPServer CRD not installed", errx.CodeCluster, errx.DescCluster)
-	ErrClusterNotAccessible           = newSentinelError("cluster not accessible", errx.CodeCluster, errx.DescCluster)
-	ErrNamespaceNotFound              = newSentinelError("namespace not found", errx.CodeCluster, errx.DescCluster)
-	ErrDeploymentTimeout              = newSentinelError("deployment timed out waiting for readiness", errx.CodeCluster, errx.DescCluster)
-	ErrInstallCRDFailed               = newSentinelError("failed to install CRD", errx.CodeCluster, errx.DescCluster)
-	ErrEnsureRuntimeNamespaceFailed   = newSentinelError("failed to ensure mcp-runtime namespace", errx.CodeCluster, errx.DescCluster)
-	ErrEnsureServersNamespaceFailed   = newSentinelError("failed to ensure mcp-servers namespace", errx.CodeCluster, errx.DescCluster)
-	ErrKubeconfigNotReadable          = newSentinelError("kubeconfig not found or not readable", errx.CodeCluster, errx.DescCluster)
-	ErrSetKubeconfigFailed            = newSentinelError("failed to set KUBECONFIG", errx.CodeCluster, errx.DescCluster)
-	ErrSetContextFailed               = newSentinelError("failed to set context", errx.CodeCluster, errx.DescCluster)
-	ErrAKSKubeconfigNotImplemented    = newSentinelError("AKS kubeconfig not yet implemented", errx.CodeCluster, errx.DescCluster)
-	ErrGKEKubeconfigNotImplemented    = newSentinelError("GKE kubeconfig not yet implemented", errx.CodeCluster, errx.DescCluster)
-	ErrUnsupportedProvider            = newSentinelError("unsupported provider", errx.CodeCluster, errx.DescCluster)
-	ErrUnsupportedIngressController   = newSentinelError("unsupported ingress controller", errx.CodeCluster, errx.DescCluster)
-	ErrInstallIngressControllerFailed = newSentinelError("failed to install ingress controller", errx.CodeCluster, errx.DescCluster)
-	ErrCreateKindConfigFailed         = newSentinelError("failed to create temp kind config", errx.CodeCluster, errx.DescCluster)
-	ErrCloseKindConfigFailed          = newSentinelError("failed to close kind config", errx.CodeCluster, errx.DescCluster)
-	ErrWriteKindConfigFailed          = newSentinelError("failed to write kind config", errx.CodeCluster, errx.DescCluster)
-	ErrCreateKindClusterFailed        = newSentinelError("failed to create kind cluster", errx.CodeCluster, errx.DescCluster)
-	ErrGKEProvisioningNotImplemented  = newSentinelError("GKE provisioning not yet implemented", errx.CodeCluster, errx.DescCluster)
-	ErrProvisionEKSFailed             = newSentinelError("failed to provision EKS cluster", errx.CodeCluster, errx.DescCluster)
-	ErrAKSProvisioningNotImplemented  = newSentinelError("AKS provisioning not yet implemented", errx.CodeCluster, errx.DescCluster)
+	ErrCRDNotInstalled                = newSentinelError("MCP-CLUSTER-001", "MCPServer CRD not installed", errx.CodeCluster, errx.DescCluster)
+	ErrClusterNotAccessible           = newSentinelError("MCP-CLUSTER-002", "cluster not accessible", errx.CodeCluster, errx.DescCluster)
+	ErrNamespaceNotFound              = newSentinelError("MCP-CLUSTER-003", "namespace not found", errx.CodeCluster, errx.DescCluster)
+	ErrDeploymentTimeout              = newSentinelError("MCP-CLUSTER-004", "deployment timed out waiting for readiness", errx.CodeCluster, errx.DescCluster)
+	ErrInstallCRDFailed               = newSentinelError("MCP-CLUSTER-005", "failed to install CRD", errx.CodeCluster, errx.DescCluster)
+	ErrEnsureRuntimeNamespaceFailed   = newSentinelError("MCP-CLUSTER-006", "failed to ensure mcp-runtime namespace", errx.CodeCluster, errx.DescCluster)
+	ErrEnsureServersNamespaceFailed   = newSentinelError("MCP-CLUSTER-007", "failed to ensure mcp-servers namespace", errx.CodeCluster, errx.DescCluster)
+	ErrKubeconfigNotReadable          = newSentinelError("MCP-CLUSTER-008", "kubeconfig not found or not readable", errx.CodeCluster, errx.DescCluster)
+	ErrSetKubeconfigFailed            = newSentinelError("MCP-CLUSTER-009", "failed to set KUBECONFIG", errx.CodeCluster, errx.DescCluster)
+	ErrSetContextFailed               = newSentinelError("MCP-CLUSTER-010", "failed to set context", errx.CodeCluster, errx.DescCluster)
+	ErrAKSKubeconfigNotImplemented    = newSentinelError("MCP-CLUSTER-011", "AKS kubeconfig not yet implemented", errx.CodeCluster, errx.DescCluster)
+	ErrGKEKubeconfigNotImplemented    = newSentinelError("MCP-CLUSTER-012", "GKE kubeconfig not yet implemented", errx.CodeCluster, errx.DescCluster)
+	ErrUnsupportedProvider            = newSentinelError("MCP-CLUSTER-013", "unsupported provider", errx.CodeCluster, errx.DescCluster)
+	ErrUnsupportedIngressController   = newSentinelError("MCP-CLUSTER-014", "unsupported ingress controller", errx.CodeCluster, errx.DescCluster)
+	ErrInstallIngressControllerFailed = newSentinelError("MCP-CLUSTER-015", "failed to install ingress controller", errx.CodeCluster, errx.DescCluster)
+	ErrCreateKindConfigFailed         = newSentinelError("MCP-CLUSTER-016", "failed to create temp kind config", errx.CodeCluster, errx.DescCluster)
+	ErrCloseKindConfigFailed          = newSentinelError("MCP-CLUSTER-017", "failed to close kind config", errx.CodeCluster, errx.DescCluster)
+	ErrWriteKindConfigFailed          = newSentinelError("MCP-CLUSTER-018", "failed to write kind config", errx.CodeCluster, errx.DescCluster)
+	ErrCreateKindClusterFailed        = newSentinelError("MCP-CLUSTER-019", "failed to create kind cluster", errx.CodeCluster, errx.DescCluster)
+	ErrSetupKindRegistryFailed        = newSentinelError("MCP-CLUSTER-020", "failed to set up local registry", errx.CodeCluster, errx.DescCluster)
+	ErrNoContainerRuntime             = newSentinelError("MCP-CLUSTER-021", "no container runtime found", errx.CodeCluster, errx.DescCluster)
+	ErrSelectContainerRuntimeFailed   = newSentinelError("MCP-CLUSTER-022", "failed to select container runtime", errx.CodeCluster, errx.DescCluster)
+	ErrGKEProvisioningNotImplemented  = newSentinelError("MCP-CLUSTER-023", "GKE provisioning not yet implemented", errx.CodeCluster, errx.DescCluster)
+	ErrProvisionEKSFailed             = newSentinelError("MCP-CLUSTER-024", "failed to provision EKS cluster", errx.CodeCluster, errx.DescCluster)
+	ErrAKSProvisioningNotImplemented  = newSentinelError("MCP-CLUSTER-025", "AKS provisioning not yet implemented", errx.CodeCluster, errx.DescCluster)
+	ErrInvalidQuota                   = newSentinelError("MCP-CLUSTER-026", "invalid namespace quota", errx.CodeCluster, errx.DescCluster)
+	ErrApplyQuotaFailed               = newSentinelError("MCP-CLUSTER-027", "failed to apply namespace quota", errx.CodeCluster, errx.DescCluster)
+	ErrListIngressHostsFailed         = newSentinelError("MCP-CLUSTER-028", "failed to list ingress hosts", errx.CodeCluster, errx.DescCluster)
+	ErrDetectIngressAddressFailed     = newSentinelError("MCP-CLUSTER-029", "failed to detect ingress address", errx.CodeCluster, errx.DescCluster)
+	ErrUpdateHostsFileFailed          = newSentinelError("MCP-CLUSTER-030", "failed to update hosts file", errx.CodeCluster, errx.DescCluster)
+	ErrListKubeContextsFailed         = newSentinelError("MCP-CLUSTER-031", "failed to list kubeconfig contexts", errx.CodeCluster, errx.DescCluster)
+	ErrKubeContextNotFound            = newSentinelError("MCP-CLUSTER-032", "kubeconfig context not found", errx.CodeCluster, errx.DescCluster)
+	ErrSaveKubeContextFailed          = newSentinelError("MCP-CLUSTER-033", "failed to save context selection", errx.CodeCluster, errx.DescCluster)
+	ErrCheckPermissionsFailed         = newSentinelError("MCP-CLUSTER-034", "failed to check permissions", errx.CodeCluster, errx.DescCluster)
+	ErrListRoleBindingsFailed         = newSentinelError("MCP-CLUSTER-035", "failed to list role bindings", errx.CodeCluster, errx.DescCluster)
+	ErrCreateBackupFailed             = newSentinelError("MCP-CLUSTER-036", "failed to create backup", errx.CodeCluster, errx.DescCluster)
+	ErrRestoreBackupFailed            = newSentinelError("MCP-CLUSTER-037", "failed to restore backup", errx.CodeCluster, errx.DescCluster)
+	ErrInvalidBackup                  = newSentinelError("MCP-CLUSTER-038", "invalid backup archive", errx.CodeCluster, errx.DescCluster)
+	ErrSetMaintenanceModeFailed       = newSentinelError("MCP-CLUSTER-039", "failed to set operator maintenance mode", errx.CodeCluster, errx.DescCluster)
+	ErrGetMaintenanceModeFailed       = newSentinelError("MCP-CLUSTER-040", "failed to read operator maintenance mode", errx.CodeCluster, errx.DescCluster)
+	ErrInvalidEKSOptions              = newSentinelError("MCP-CLUSTER-041", "invalid EKS provisioning options", errx.CodeCluster, errx.DescCluster)
+	ErrInvalidTeam                    = newSentinelError("MCP-CLUSTER-042", "invalid team", errx.CodeCluster, errx.DescCluster)
+	ErrCreateTeamFailed               = newSentinelError("MCP-CLUSTER-043", "failed to create team", errx.CodeCluster, errx.DescCluster)
+	ErrListTeamsFailed                = newSentinelError("MCP-CLUSTER-044", "failed to list teams", errx.CodeCluster, errx.DescCluster)
+	ErrDeleteClusterFailed            = newSentinelError("MCP-CLUSTER-045", "failed to delete cluster", errx.CodeCluster, errx.DescCluster)
+	ErrClusterDeleteAborted           = newSentinelError("MCP-CLUSTER-046", "cluster delete not confirmed", errx.CodeCluster, errx.DescCluster)
 
 	// Registry errors.
-	ErrRegistryNotReady            = newSentinelError("registry not ready", errx.CodeRegistry, errx.DescRegistry)
-	ErrRegistryNotFound            = newSentinelError("registry not found", errx.CodeRegistry, errx.DescRegistry)
-	ErrBuildOperatorImageFailed    = newSentinelError("failed to build operator image", errx.CodeRegistry, errx.DescRegistry)
-	ErrPushOperatorImageFailed     = newSentinelError("failed to push operator image", errx.CodeRegistry, errx.DescRegistry)
-	ErrUnsupportedRegistryType     = newSentinelError("unsupported registry type", errx.CodeRegistry, errx.DescRegistry)
-	ErrEnsureNamespaceFailed       = newSentinelError("failed to ensure namespace", errx.CodeRegistry, errx.DescRegistry)
-	ErrReadRegistryStorageFailed   = newSentinelError("failed to read current registry storage size", errx.CodeRegistry, errx.DescRegistry)
-	ErrUpdateRegistryStorageFailed = newSentinelError("failed to update registry storage size", errx.CodeRegistry, errx.DescRegistry)
-	ErrRegistryLoginFailed         = newSentinelError("failed to login to registry", errx.CodeRegistry, errx.DescRegistry)
-	ErrTagImageFailed              = newSentinelError("failed to tag image", errx.CodeRegistry, errx.DescRegistry)
-	ErrPushImageFailed             = newSentinelError("failed to push image", errx.CodeRegistry, errx.DescRegistry)
-	ErrHelperNamespaceNotFound     = newSentinelError("helper namespace not found", errx.CodeRegistry, errx.DescRegistry)
-	ErrSaveImageFailed             = newSentinelError("failed to save image", errx.CodeRegistry, errx.DescRegistry)
-	ErrStartHelperPodFailed        = newSentinelError("failed to start helper pod", errx.CodeRegistry, errx.DescRegistry)
-	ErrHelperPodNotReady           = newSentinelError("helper pod not ready", errx.CodeRegistry, errx.DescRegistry)
-	ErrCopyImageToHelperFailed     = newSentinelError("failed to copy image tar to helper pod", errx.CodeRegistry, errx.DescRegistry)
-	ErrPushImageFromHelperFailed   = newSentinelError("failed to push image from helper pod", errx.CodeRegistry, errx.DescRegistry)
+	ErrRegistryNotReady            = newSentinelError("MCP-REGISTRY-001", "registry not ready", errx.CodeRegistry, errx.DescRegistry)
+	ErrRegistryNotFound            = newSentinelError("MCP-REGISTRY-002", "registry not found", errx.CodeRegistry, errx.DescRegistry)
+	ErrBuildOperatorImageFailed    = newSentinelError("MCP-REGISTRY-003", "failed to build operator image", errx.CodeRegistry, errx.DescRegistry)
+	ErrPushOperatorImageFailed     = newSentinelError("MCP-REGISTRY-004", "failed to push operator image", errx.CodeRegistry, errx.DescRegistry)
+	ErrGenerateSBOMFailed          = newSentinelError("MCP-REGISTRY-005", "failed to generate SBOM", errx.CodeRegistry, errx.DescRegistry)
+	ErrAttachSBOMFailed            = newSentinelError("MCP-REGISTRY-006", "failed to attach SBOM", errx.CodeRegistry, errx.DescRegistry)
+	ErrSignImageFailed             = newSentinelError("MCP-REGISTRY-007", "failed to sign image", errx.CodeRegistry, errx.DescRegistry)
+	ErrRegistryUsageFailed         = newSentinelError("MCP-REGISTRY-008", "failed to read registry storage usage", errx.CodeRegistry, errx.DescRegistry)
+	ErrListRegistryTagsFailed      = newSentinelError("MCP-REGISTRY-009", "failed to list registry tags", errx.CodeRegistry, errx.DescRegistry)
+	ErrInspectImageFailed          = newSentinelError("MCP-REGISTRY-010", "failed to inspect image", errx.CodeRegistry, errx.DescRegistry)
+	ErrUnsupportedRegistryType     = newSentinelError("MCP-REGISTRY-011", "unsupported registry type", errx.CodeRegistry, errx.DescRegistry)
+	ErrEnsureNamespaceFailed       = newSentinelError("MCP-REGISTRY-012", "failed to ensure namespace", errx.CodeRegistry, errx.DescRegistry)
+	ErrReadRegistryStorageFailed   = newSentinelError("MCP-REGISTRY-013", "failed to read current registry storage size", errx.CodeRegistry, errx.DescRegistry)
+	ErrUpdateRegistryStorageFailed = newSentinelError("MCP-REGISTRY-014", "failed to update registry storage size", errx.CodeRegistry, errx.DescRegistry)
+	ErrRegistryLoginFailed         = newSentinelError("MCP-REGISTRY-015", "failed to login to registry", errx.CodeRegistry, errx.DescRegistry)
+	ErrTagImageFailed              = newSentinelError("MCP-REGISTRY-016", "failed to tag image", errx.CodeRegistry, errx.DescRegistry)
+	ErrPushImageFailed             = newSentinelError("MCP-REGISTRY-017", "failed to push image", errx.CodeRegistry, errx.DescRegistry)
+	ErrPushImagesFailed            = newSentinelError("MCP-REGISTRY-018", "failed to push one or more images", errx.CodeRegistry, errx.DescRegistry)
+	ErrHelperNamespaceNotFound     = newSentinelError("MCP-REGISTRY-019", "helper namespace not found", errx.CodeRegistry, errx.DescRegistry)
+	ErrSaveImageFailed             = newSentinelError("MCP-REGISTRY-020", "failed to save image", errx.CodeRegistry, errx.DescRegistry)
+	ErrStartHelperPodFailed        = newSentinelError("MCP-REGISTRY-021", "failed to start helper pod", errx.CodeRegistry, errx.DescRegistry)
+	ErrHelperPodNotReady           = newSentinelError("MCP-REGISTRY-022", "helper pod not ready", errx.CodeRegistry, errx.DescRegistry)
+	ErrCopyImageToHelperFailed     = newSentinelError("MCP-REGISTRY-023", "failed to copy image tar to helper pod", errx.CodeRegistry, errx.DescRegistry)
+	ErrPushImageFromHelperFailed   = newSentinelError("MCP-REGISTRY-024", "failed to push image from helper pod", errx.CodeRegistry, errx.DescRegistry)
+	ErrPruneRegistryFailed         = newSentinelError("MCP-REGISTRY-025", "failed to prune registry", errx.CodeRegistry, errx.DescRegistry)
+	ErrReadRegistryPasswordFailed  = newSentinelError("MCP-REGISTRY-026", "failed to read registry password", errx.CodeRegistry, errx.DescRegistry)
 
 	// Config errors.
-	ErrRegistryURLRequired           = newSentinelError("registry url is required", errx.CodeConfig, errx.DescConfig)
-	ErrRegistryURLMissingInConfig    = newSentinelError("registry url missing in config", errx.CodeConfig, errx.DescConfig)
-	ErrSaveRegistryConfigFailed      = newSentinelError("failed to save registry config", errx.CodeConfig, errx.DescConfig)
-	ErrReadRegistryConfigFailed      = newSentinelError("failed to read registry config", errx.CodeConfig, errx.DescConfig)
-	ErrUnmarshalRegistryConfigFailed = newSentinelError("failed to unmarshal registry config", errx.CodeConfig, errx.DescConfig)
+	ErrRegistryURLRequired           = newSentinelError("MCP-CONFIG-001", "registry url is required", errx.CodeConfig, errx.DescConfig)
+	ErrRegistryURLMissingInConfig    = newSentinelError("MCP-CONFIG-002", "registry url missing in config", errx.CodeConfig, errx.DescConfig)
+	ErrSaveRegistryConfigFailed      = newSentinelError("MCP-CONFIG-003", "failed to save registry config", errx.CodeConfig, errx.DescConfig)
+	ErrReadRegistryConfigFailed      = newSentinelError("MCP-CONFIG-004", "failed to read registry config", errx.CodeConfig, errx.DescConfig)
+	ErrUnmarshalRegistryConfigFailed = newSentinelError("MCP-CONFIG-005", "failed to unmarshal registry config", errx.CodeConfig, errx.DescConfig)
+	ErrInvalidRegistryStore          = newSentinelError("MCP-CONFIG-006", "invalid registry config store", errx.CodeConfig, errx.DescConfig)
+	ErrUnknownSettingKey             = newSentinelError("MCP-CONFIG-007", "unknown config key", errx.CodeConfig, errx.DescConfig)
+	ErrInvalidNamespace              = newSentinelError("MCP-CONFIG-008", "invalid namespace", errx.CodeConfig, errx.DescConfig)
+	ErrReadSettingsFailed            = newSentinelError("MCP-CONFIG-009", "failed to read config", errx.CodeConfig, errx.DescConfig)
+	ErrSaveSettingsFailed            = newSentinelError("MCP-CONFIG-010", "failed to save config", errx.CodeConfig, errx.DescConfig)
+	ErrInvalidExecPolicy             = newSentinelError("MCP-CONFIG-011", "invalid exec policy", errx.CodeConfig, errx.DescConfig)
 
 	// Build errors.
-	ErrBuildImageFailed         = newSentinelError("failed to build image", errx.CodeBuild, errx.DescBuild)
-	ErrMetadataFileNotFound     = newSentinelError("metadata file not found", errx.CodeBuild, errx.DescBuild)
-	ErrServerNotFoundInMetadata = newSentinelError("server not found in metadata", errx.CodeBuild, errx.DescBuild)
-	ErrMarshalMetadataFailed    = newSentinelError("failed to marshal metadata", errx.CodeBuild, errx.DescBuild)
-	ErrWriteMetadataFailed      = newSentinelError("failed to write metadata", errx.CodeBuild, errx.DescBuild)
+	ErrBuildImageFailed         = newSentinelError("MCP-BUILD-001", "failed to build image", errx.CodeBuild, errx.DescBuild)
+	ErrMetadataFileNotFound     = newSentinelError("MCP-BUILD-002", "metadata file not found", errx.CodeBuild, errx.DescBuild)
+	ErrServerNotFoundInMetadata = newSentinelError("MCP-BUILD-003", "server not found in metadata", errx.CodeBuild, errx.DescBuild)
+	ErrMarshalMetadataFailed    = newSentinelError("MCP-BUILD-004", "failed to marshal metadata", errx.CodeBuild, errx.DescBuild)
+	ErrWriteMetadataFailed      = newSentinelError("MCP-BUILD-005", "failed to write metadata", errx.CodeBuild, errx.DescBuild)
+	ErrInvalidBuilder           = newSentinelError("MCP-BUILD-006", "invalid image builder", errx.CodeBuild, errx.DescBuild)
+	ErrArchiveContextFailed     = newSentinelError("MCP-BUILD-007", "failed to archive build context", errx.CodeBuild, errx.DescBuild)
 
 	// Server errors.
-	ErrMarshalManifestFailed = newSentinelError("failed to marshal manifest", errx.CodeServer, errx.DescServer)
-	ErrWriteManifestFailed   = newSentinelError("failed to write manifest", errx.CodeServer, errx.DescServer)
-	ErrInvalidFilePath       = newSentinelError("invalid file path", errx.CodeServer, errx.DescServer)
-	ErrFileNotAccessible     = newSentinelError("cannot access file", errx.CodeServer, errx.DescServer)
-	ErrFileIsDirectory       = newSentinelError("path is a directory, not a file", errx.CodeServer, errx.DescServer)
-	ErrGetMCPServerFailed    = newSentinelError("kubectl get mcpserver failed", errx.CodeServer, errx.DescServer)
-	ErrListServersFailed     = newSentinelError("failed to list servers", errx.CodeServer, errx.DescServer)
-	ErrCreateServerFailed    = newSentinelError("failed to create server", errx.CodeServer, errx.DescServer)
-	ErrDeleteServerFailed    = newSentinelError("failed to delete server", errx.CodeServer, errx.DescServer)
-	ErrViewServerLogsFailed  = newSentinelError("failed to view server logs", errx.CodeServer, errx.DescServer)
+	ErrMarshalManifestFailed = newSentinelError("MCP-SERVER-001", "failed to marshal manifest", errx.CodeServer, errx.DescServer)
+	ErrWriteManifestFailed   = newSentinelError("MCP-SERVER-002", "failed to write manifest", errx.CodeServer, errx.DescServer)
+	ErrInvalidFilePath       = newSentinelError("MCP-SERVER-003", "invalid file path", errx.CodeServer, errx.DescServer)
+	ErrFileNotAccessible     = newSentinelError("MCP-SERVER-004", "cannot access file", errx.CodeServer, errx.DescServer)
+	ErrFileIsDirectory       = newSentinelError("MCP-SERVER-005", "path is a directory, not a file", errx.CodeServer, errx.DescServer)
+	ErrGetMCPServerFailed    = newSentinelError("MCP-SERVER-006", "kubectl get mcpserver failed", errx.CodeServer, errx.DescServer)
+	ErrListServersFailed     = newSentinelError("MCP-SERVER-007", "failed to list servers", errx.CodeServer, errx.DescServer)
+	ErrCreateServerFailed    = newSentinelError("MCP-SERVER-008", "failed to create server", errx.CodeServer, errx.DescServer)
+	ErrDeleteServerFailed    = newSentinelError("MCP-SERVER-009", "failed to delete server", errx.CodeServer, errx.DescServer)
+	ErrViewServerLogsFailed  = newSentinelError("MCP-SERVER-010", "failed to view server logs", errx.CodeServer, errx.DescServer)
+	ErrServerProtected       = newSentinelError("MCP-SERVER-011", "server is protected against deletion", errx.CodeServer, errx.DescServer)
+	ErrUnprotectServerFailed = newSentinelError("MCP-SERVER-012", "failed to remove server protection", errx.CodeServer, errx.DescServer)
+	ErrSuspendServerFailed   = newSentinelError("MCP-SERVER-013", "failed to suspend server", errx.CodeServer, errx.DescServer)
+	ErrResumeServerFailed    = newSentinelError("MCP-SERVER-014", "failed to resume server", errx.CodeServer, errx.DescServer)
+	ErrServerNotReady        = newSentinelError("MCP-SERVER-015", "server did not become ready", errx.CodeServer, errx.DescServer)
+	ErrSmokeRequestFailed    = newSentinelError("MCP-SERVER-016", "smoke test request failed", errx.CodeServer, errx.DescServer)
+	ErrScaffoldFileExists    = newSentinelError("MCP-SERVER-017", "scaffold file already exists", errx.CodeServer, errx.DescServer)
+	ErrRollbackServerFailed  = newSentinelError("MCP-SERVER-018", "failed to roll back server", errx.CodeServer, errx.DescServer)
+	ErrRevisionNotFound      = newSentinelError("MCP-SERVER-019", "revision not found", errx.CodeServer, errx.DescServer)
+	ErrCloneServerFailed     = newSentinelError("MCP-SERVER-020", "failed to clone server", errx.CodeServer, errx.DescServer)
+	ErrReadMetricsFailed     = newSentinelError("MCP-SERVER-021", "failed to read server metrics", errx.CodeServer, errx.DescServer)
+	ErrInvalidManifest       = newSentinelError("MCP-SERVER-022", "invalid manifest", errx.CodeServer, errx.DescServer)
+	ErrApplyServersFailed    = newSentinelError("MCP-SERVER-023", "failed to apply one or more documents", errx.CodeServer, errx.DescServer)
+	ErrDevLoopFailed         = newSentinelError("MCP-SERVER-024", "dev loop failed", errx.CodeServer, errx.DescServer)
+	ErrDevSyncFailed         = newSentinelError("MCP-SERVER-025", "failed to sync files into server pods", errx.CodeServer, errx.DescServer)
+	ErrListEventsFailed      = newSentinelError("MCP-SERVER-026", "failed to list events", errx.CodeServer, errx.DescServer)
+	ErrDiffServerFailed      = newSentinelError("MCP-SERVER-027", "failed to diff server", errx.CodeServer, errx.DescServer)
+	ErrMigrateServersFailed  = newSentinelError("MCP-SERVER-028", "failed to migrate servers", errx.CodeServer, errx.DescServer)
+	ErrPreviewServerFailed   = newSentinelError("MCP-SERVER-029", "failed to manage preview server", errx.CodeServer, errx.DescServer)
 )
 
 func specFor(base error) errorSpec {
@@ -0,0 +1,280 @@
+package cli
+
+// This file implements "events": the Kubernetes events of MCP servers, of their child
+// resources (Deployments, ReplicaSets, pods, Services, Ingresses, Jobs) and of the operator,
+// read with one "kubectl get events" per namespace and grouped by server, then by object,
+// so what happened to a server reads top to bottom.
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// eventKindOrder ranks involved object kinds from the MCPServer down to its pods; other
+// kinds sort after them.
+var eventKindOrder = []string{"mcpserver", "deployment", "replicaset", "pod", "service", "ingress", "job"}
+
+// serverEvent is one event in the events command output.
+type serverEvent struct {
+	Time    time.Time
+	Type    string
+	Reason  string
+	Message string
+	Count   int32
+}
+
+// eventGroup holds the events of one server, or of the operator, by object.
+type eventGroup struct {
+	Title   string
+	Server  serverRef // zero for the operator
+	Objects []string
+	Events  map[string][]serverEvent
+}
+
+// serverRef names an MCPServer.
+type serverRef struct {
+	Namespace string
+	Name      string
+}
+
+// NewEventsCmd returns the events command.
+func NewEventsCmd(logger *zap.Logger) *cobra.Command {
+	mgr := DefaultServerManager(logger)
+	var server string
+	var since time.Duration
+	var allNamespaces bool
+	var warningsOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Show Kubernetes events of MCP servers and the operator",
+		Long: `Show the Kubernetes events of MCP servers, of their child resources (Deployment,
+ReplicaSets, pods, Service, Ingress, Job) and of the operator, grouped by server and by
+object, oldest first. Kubernetes keeps events for an hour by default.`,
+		Example: `  mcp-runtime events
+  mcp-runtime events --server weather --since 30m
+  mcp-runtime events -A --warnings`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := serverNamespace()
+			if allNamespaces {
+				namespace = ""
+			}
+			return mgr.ShowEvents(namespace, server, since, warningsOnly)
+		},
+	}
+
+	cmd.Flags().StringVar(&server, "server", "", "Only show events of this server (default: every server)")
+	cmd.Flags().DurationVar(&since, "since", time.Hour, "Only show events seen within this duration (0 shows all)")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Show events of servers in every namespace")
+	cmd.Flags().BoolVar(&warningsOnly, "warnings", false, "Only show Warning events")
+
+	return cmd
+}
+
+// ShowEvents prints the events of server, or of every server, in namespace (every namespace
+// when empty), followed by the operator's events.
+func (m *ServerManager) ShowEvents(namespace, server string, since time.Duration, warningsOnly bool) error {
+	// Every server is listed, even with --server, so objects of "weather-shadow" are not
+	// taken for objects of "weather".
+	list, err := m.dashboardServers(namespace)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(ErrListServersFailed, err, fmt.Sprintf("failed to list servers: %v", err), map[string]any{"namespace": namespace, "component": "events"})
+		Error("Failed to list servers")
+		logStructuredError(m.logger, wrappedErr, "Failed to list servers")
+		return wrappedErr
+	}
+	servers := make([]serverRef, 0, len(list)+1)
+	found := false
+	for _, s := range list {
+		servers = append(servers, serverRef{Namespace: s.Namespace, Name: s.Name})
+		found = found || s.Name == server
+	}
+	if server != "" && !found && namespace != "" {
+		// The server may be gone already; its events outlive it.
+		servers = append(servers, serverRef{Namespace: namespace, Name: server})
+	}
+
+	scopes := [][]string{{"-n", namespace}, {"-n", NamespaceMCPRuntime}}
+	if namespace == "" {
+		scopes = [][]string{{"--all-namespaces"}}
+	} else if namespace == NamespaceMCPRuntime {
+		scopes = scopes[:1]
+	}
+	var events []corev1.Event
+	for _, scope := range scopes {
+		list, err := m.listEvents(scope)
+		if err != nil {
+			wrappedErr := wrapWithSentinelAndContext(ErrListEventsFailed, err, fmt.Sprintf("failed to list events: %v", err), map[string]any{"scope": strings.Join(scope, " "), "component": "events"})
+			Error("Failed to list events")
+			logStructuredError(m.logger, wrappedErr, "Failed to list events")
+			return wrappedErr
+		}
+		events = append(events, list...)
+	}
+
+	now := time.Now()
+	groups := groupEvents(events, servers, since, warningsOnly, now)
+	if server != "" {
+		groups = slices.DeleteFunc(groups, func(g eventGroup) bool {
+			return g.Server.Name != "" && g.Server.Name != server
+		})
+	}
+	if len(groups) == 0 {
+		Info("No events found")
+		return nil
+	}
+	for _, group := range groups {
+		Section(group.Title)
+		Table(eventRows(group, now))
+	}
+	return nil
+}
+
+func (m *ServerManager) listEvents(scope []string) ([]corev1.Event, error) {
+	// #nosec G204 -- namespace from CLI flag; kubectl validates namespace names.
+	out, err := m.kubectl.Output(append([]string{"get", "events", "-o", "json"}, scope...))
+	if err != nil {
+		return nil, err
+	}
+	var list corev1.EventList
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse events: %w", err)
+	}
+	return list.Items, nil
+}
+
+// groupEvents assigns each event to the server whose name its object carries, or to the
+// operator, and drops events older than since. A server owns its own object and every
+// object named "<server>-..."; the longest matching name wins, so "app-shadow-x" belongs
+// to app-shadow rather than app.
+func groupEvents(events []corev1.Event, servers []serverRef, since time.Duration, warningsOnly bool, now time.Time) []eventGroup {
+	servers = slices.Clone(servers)
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].Namespace != servers[j].Namespace {
+			return servers[i].Namespace < servers[j].Namespace
+		}
+		return servers[i].Name < servers[j].Name
+	})
+	groups := make([]eventGroup, len(servers)+1)
+	for i, s := range servers {
+		groups[i].Title = fmt.Sprintf("Server %s/%s", s.Namespace, s.Name)
+		groups[i].Server = s
+	}
+	operator := len(servers)
+	groups[operator].Title = fmt.Sprintf("Operator %s/%s", NamespaceMCPRuntime, OperatorDeploymentName)
+
+	for _, e := range events {
+		when := eventTime(e)
+		if since > 0 && now.Sub(when) > since {
+			continue
+		}
+		if warningsOnly && e.Type != corev1.EventTypeWarning {
+			continue
+		}
+		name, namespace := e.InvolvedObject.Name, e.InvolvedObject.Namespace
+		if namespace == "" {
+			namespace = e.Namespace
+		}
+		index := -1
+		for i, s := range servers {
+			if s.Namespace == namespace && ownsObject(s.Name, name) && (index == -1 || len(s.Name) > len(servers[index].Name)) {
+				index = i
+			}
+		}
+		if index == -1 {
+			if namespace != NamespaceMCPRuntime || !ownsObject(OperatorDeploymentName, name) {
+				continue
+			}
+			index = operator
+		}
+		object := strings.ToLower(e.InvolvedObject.Kind) + "/" + name
+		group := &groups[index]
+		if group.Events == nil {
+			group.Events = map[string][]serverEvent{}
+		}
+		if _, seen := group.Events[object]; !seen {
+			group.Objects = append(group.Objects, object)
+		}
+		group.Events[object] = append(group.Events[object], serverEvent{
+			Time:    when,
+			Type:    e.Type,
+			Reason:  e.Reason,
+			Message: strings.TrimSpace(e.Message),
+			Count:   e.Count,
+		})
+	}
+
+	var out []eventGroup
+	for _, group := range groups {
+		if len(group.Objects) == 0 {
+			continue
+		}
+		sort.SliceStable(group.Objects, func(i, j int) bool {
+			a, b := group.Objects[i], group.Objects[j]
+			if ra, rb := eventKindRank(a), eventKindRank(b); ra != rb {
+				return ra < rb
+			}
+			return a < b
+		})
+		for _, events := range group.Events {
+			sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+		}
+		out = append(out, group)
+	}
+	return out
+}
+
+// eventRows builds the table of one group, header first; the object is only named on its
+// first row.
+func eventRows(group eventGroup, now time.Time) [][]string {
+	rows := [][]string{{"OBJECT", "LAST SEEN", "TYPE", "REASON", "COUNT", "MESSAGE"}}
+	for _, object := range group.Objects {
+		for i, e := range group.Events[object] {
+			name := ""
+			if i == 0 {
+				name = object
+			}
+			count := "1"
+			if e.Count > 1 {
+				count = strconv.Itoa(int(e.Count))
+			}
+			rows = append(rows, []string{name, humanAge(e.Time, now), orDash(e.Type), orDash(e.Reason), count, e.Message})
+		}
+	}
+	return rows
+}
+
+// eventTime returns when an event was last seen.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+func ownsObject(owner, name string) bool {
+	return name == owner || strings.HasPrefix(name, owner+"-")
+}
+
+func eventKindRank(object string) int {
+	kind, _, _ := strings.Cut(object, "/")
+	for i, k := range eventKindOrder {
+		if k == kind {
+			return i
+		}
+	}
+	return len(eventKindOrder)
+}
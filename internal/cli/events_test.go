@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	testServerEvents = `{"items":[
+  {"metadata":{"namespace":"mcp-servers"},"involvedObject":{"kind":"Pod","name":"weather-7d9f-abcde","namespace":"mcp-servers"},"type":"Warning","reason":"BackOff","message":"Back-off restarting","count":4,"lastTimestamp":"2026-01-01T00:50:00Z"},
+  {"metadata":{"namespace":"mcp-servers"},"involvedObject":{"kind":"Deployment","name":"weather","namespace":"mcp-servers"},"type":"Normal","reason":"ScalingReplicaSet","message":"Scaled up replica set weather-7d9f to 1","lastTimestamp":"2026-01-01T00:40:00Z"},
+  {"metadata":{"namespace":"mcp-servers"},"involvedObject":{"kind":"MCPServer","name":"weather","namespace":"mcp-servers"},"type":"Warning","reason":"CrashLoop","message":"crash loop detected","lastTimestamp":"2026-01-01T00:55:00Z"},
+  {"metadata":{"namespace":"mcp-servers"},"involvedObject":{"kind":"Pod","name":"weather-shadow-1","namespace":"mcp-servers"},"type":"Normal","reason":"Pulled","lastTimestamp":"2026-01-01T00:45:00Z"},
+  {"metadata":{"namespace":"mcp-servers"},"involvedObject":{"kind":"Pod","name":"weather-old-1","namespace":"mcp-servers"},"type":"Normal","reason":"Killing","lastTimestamp":"2025-12-31T20:00:00Z"},
+  {"metadata":{"namespace":"mcp-servers"},"involvedObject":{"kind":"Pod","name":"unrelated-1","namespace":"mcp-servers"},"type":"Normal","reason":"Pulled","lastTimestamp":"2026-01-01T00:45:00Z"}
+]}`
+	testOperatorEvents = `{"items":[
+  {"metadata":{"namespace":"mcp-runtime"},"involvedObject":{"kind":"Pod","name":"mcp-runtime-operator-controller-manager-5c8-x","namespace":"mcp-runtime"},"type":"Warning","reason":"OOMKilling","message":"Memory cgroup out of memory","lastTimestamp":"2026-01-01T00:30:00Z"},
+  {"metadata":{"namespace":"mcp-runtime"},"involvedObject":{"kind":"Pod","name":"registry-0","namespace":"mcp-runtime"},"type":"Normal","reason":"Pulled","lastTimestamp":"2026-01-01T00:30:00Z"}
+]}`
+)
+
+func TestGroupEvents(t *testing.T) {
+	var events []corev1.Event
+	for _, data := range []string{testServerEvents, testOperatorEvents} {
+		var list corev1.EventList
+		if err := json.Unmarshal([]byte(data), &list); err != nil {
+			t.Fatalf("parse events: %v", err)
+		}
+		events = append(events, list.Items...)
+	}
+	servers := []serverRef{{Namespace: "mcp-servers", Name: "weather-shadow"}, {Namespace: "mcp-servers", Name: "weather"}}
+	now := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	groups := groupEvents(events, servers, time.Hour, false, now)
+	if len(groups) != 3 {
+		t.Fatalf("expected weather, weather-shadow and operator groups, got %+v", groups)
+	}
+	weather := groups[0]
+	if weather.Title != "Server mcp-servers/weather" || strings.Join(weather.Objects, ",") != "mcpserver/weather,deployment/weather,pod/weather-7d9f-abcde" {
+		t.Fatalf("unexpected weather group: %s %v", weather.Title, weather.Objects)
+	}
+	if groups[1].Objects[0] != "pod/weather-shadow-1" {
+		t.Fatalf("expected the shadow pod under weather-shadow, got %v", groups[1].Objects)
+	}
+	if groups[2].Title != "Operator mcp-runtime/"+OperatorDeploymentName || len(groups[2].Objects) != 1 {
+		t.Fatalf("unexpected operator group: %s %v", groups[2].Title, groups[2].Objects)
+	}
+
+	rows := eventRows(weather, now)
+	if strings.Join(rows[3], "|") != "pod/weather-7d9f-abcde|10m|Warning|BackOff|4|Back-off restarting" {
+		t.Fatalf("unexpected pod row: %v", rows[3])
+	}
+
+	warnings := groupEvents(events, servers, 0, true, now)
+	if len(warnings) != 2 || len(warnings[0].Objects) != 2 {
+		t.Fatalf("expected only Warning events, got %+v", warnings)
+	}
+	if all := groupEvents(events, servers[1:], 0, false, now); len(all[0].Objects) != 5 {
+		t.Fatalf("expected --since 0 to keep old events, got %v", all[0].Objects)
+	}
+}
+
+func TestShowEvents(t *testing.T) {
+	var out bytes.Buffer
+	setDefaultPrinterWriter(t, &out)
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		switch args := strings.Join(spec.Args, " "); {
+		case strings.HasPrefix(args, "get mcpservers"):
+			return &MockCommand{OutputData: []byte(`{"items":[{"metadata":{"name":"weather","namespace":"mcp-servers"}},{"metadata":{"name":"weather-shadow","namespace":"mcp-servers"}}]}`)}
+		case args == "get events -o json -n mcp-servers":
+			return &MockCommand{OutputData: []byte(testServerEvents)}
+		case args == "get events -o json -n mcp-runtime":
+			return &MockCommand{OutputData: []byte(testOperatorEvents)}
+		case strings.HasPrefix(args, "get deployments"):
+			return &MockCommand{}
+		}
+		return &MockCommand{OutputErr: errors.New("unexpected call")}
+	}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	if err := mgr.ShowEvents("mcp-servers", "weather", 0, false); err != nil {
+		t.Fatalf("ShowEvents() error: %v", err)
+	}
+	for _, want := range []string{"Server mcp-servers/weather", "CrashLoop", "OOMKilling"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output misses %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "weather-shadow") || strings.Contains(out.String(), "unrelated") {
+		t.Errorf("output shows objects of other servers:\n%s", out.String())
+	}
+
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		if spec.Args[1] == "events" {
+			return &MockCommand{OutputErr: errors.New("forbidden")}
+		}
+		return &MockCommand{OutputData: []byte(`{"items":[]}`)}
+	}
+	if err := mgr.ShowEvents("mcp-servers", "weather", 0, false); !errors.Is(err, ErrListEventsFailed) {
+		t.Fatalf("expected ErrListEventsFailed, got %v", err)
+	}
+}
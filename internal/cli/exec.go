@@ -1,127 +1,48 @@
 package cli
 
-// This file defines interfaces and implementations for command execution.
-// It provides abstractions for running shell commands with validation and testability.
+// This file adapts the command execution layer in pkg/execx to the CLI.
+// The aliases keep the CLI's names; commands are bound to the command context so they
+// stop when the CLI is interrupted.
 
 import (
-	"errors"
-	"io"
+	"context"
 	"os/exec"
-	"path/filepath"
-	"strings"
+
+	"mcp-runtime/pkg/execx"
 )
 
 // execCommand is a test seam for stubbing command creation in tests.
-var execCommand = exec.Command
+var execCommand = exec.CommandContext
 
 // Command represents a command that can be executed.
-type Command interface {
-	Output() ([]byte, error)
-	CombinedOutput() ([]byte, error)
-	Run() error
-	SetStdout(w io.Writer)
-	SetStderr(w io.Writer)
-	SetStdin(r io.Reader)
-}
+type Command = execx.Command
 
 // Executor creates commands for execution.
-type Executor interface {
-	Command(name string, args []string, validators ...ExecValidator) (Command, error)
-}
-
-// execCmd wraps exec.Cmd to implement Command interface.
-type execCmd struct {
-	cmd *exec.Cmd
-}
+// The command is killed if ctx is cancelled or its deadline passes before it exits.
+type Executor = execx.Executor
 
-func (c *execCmd) Output() ([]byte, error)         { return c.cmd.Output() }
-func (c *execCmd) CombinedOutput() ([]byte, error) { return c.cmd.CombinedOutput() }
-func (c *execCmd) Run() error                      { return c.cmd.Run() }
-func (c *execCmd) SetStdout(w io.Writer)           { c.cmd.Stdout = w }
-func (c *execCmd) SetStderr(w io.Writer)           { c.cmd.Stderr = w }
-func (c *execCmd) SetStdin(r io.Reader)            { c.cmd.Stdin = r }
+// ExecSpec is the binary and arguments of a command, as seen by validators.
+type ExecSpec = execx.Spec
 
-// osExecutor is the production implementation using os/exec.
-type osExecutor struct{}
+// ExecValidator inspects a command before it is created and returns an error to refuse it.
+type ExecValidator = execx.Validator
 
-func (osExecutor) Command(name string, args []string, validators ...ExecValidator) (Command, error) {
-	spec := ExecSpec{Name: name, Args: args}
-	for _, validate := range validators {
-		if err := validate(spec); err != nil {
-			return nil, err
-		}
-	}
-	return &execCmd{cmd: execCommand(name, args...)}, nil
+var execExecutor Executor = execx.OSExecutor{
+	CommandContext: func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return execCommand(ctx, name, args...)
+	},
 }
 
-var execExecutor Executor = osExecutor{}
-
-type ExecSpec struct {
-	Name string
-	Args []string
-}
-
-type ExecValidator func(ExecSpec) error
-
+// execCommandWithValidators builds a command bound to the current command context,
+// so it is cancelled when the CLI is interrupted.
 func execCommandWithValidators(name string, args []string, validators ...ExecValidator) (Command, error) {
-	return execExecutor.Command(name, args, validators...)
+	return execExecutor.Command(commandContext(), name, args, validators...)
 }
 
-func AllowlistBins(allowed ...string) ExecValidator {
-	set := make(map[string]struct{}, len(allowed))
-	for _, name := range allowed {
-		set[name] = struct{}{}
-	}
-	return func(spec ExecSpec) error {
-		if _, ok := set[spec.Name]; !ok {
-			return errors.New("exec: binary not allowed")
-		}
-		return nil
-	}
-}
-
-func NoShellMeta() ExecValidator {
-	return func(spec ExecSpec) error {
-		for _, arg := range spec.Args {
-			if strings.ContainsAny(arg, "&|;<>()$`\\") {
-				return errors.New("exec: shell metacharacters not allowed")
-			}
-		}
-		return nil
-	}
-}
-
-func NoControlChars() ExecValidator {
-	return func(spec ExecSpec) error {
-		for _, arg := range spec.Args {
-			if strings.ContainsAny(arg, "\r\n\t") {
-				return errors.New("exec: control characters not allowed")
-			}
-		}
-		return nil
-	}
-}
-
-func PathUnder(root string) ExecValidator {
-	absRoot := root
-	if abs, err := filepath.Abs(root); err == nil {
-		absRoot = abs
-	}
-	return func(spec ExecSpec) error {
-		for _, arg := range spec.Args {
-			if arg == "-" {
-				continue
-			}
-			candidate := arg
-			if !filepath.IsAbs(candidate) {
-				candidate = filepath.Join(absRoot, candidate)
-			}
-			candidate = filepath.Clean(candidate)
-			rel, err := filepath.Rel(absRoot, candidate)
-			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
-				return errors.New("exec: path escapes root")
-			}
-		}
-		return nil
-	}
-}
+// Validators shared with pkg/execx.
+var (
+	AllowlistBins  = execx.AllowlistBins
+	NoShellMeta    = execx.NoShellMeta
+	NoControlChars = execx.NoControlChars
+	PathUnder      = execx.PathUnder
+)
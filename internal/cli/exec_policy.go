@@ -0,0 +1,133 @@
+package cli
+
+// This file implements the exec policy, an organization-wide restriction on the commands the
+// CLI may run, read from the execPolicy section of ~/.mcp-runtime/config.yaml:
+//
+//	execPolicy:
+//	  mode: enforce            # or audit: log violations but run the command
+//	  allowedBinaries: [kubectl, docker]
+//	  forbiddenFlags: [--as, --token, --insecure-skip-tls-verify]
+//	  allowedNamespaces: [mcp-runtime, mcp-servers, registry]
+//
+// The policy applies on top of the validators each call site passes, to every command the CLI
+// executes. allowedNamespaces only constrains kubectl namespace flags.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"mcp-runtime/pkg/execx"
+)
+
+// Exec policy modes.
+const (
+	ExecPolicyEnforce = "enforce"
+	ExecPolicyAudit   = "audit"
+)
+
+// execPolicy is the execPolicy section of the CLI config. Empty lists leave that aspect
+// unrestricted.
+type execPolicy struct {
+	// Mode is enforce (the default) to refuse violating commands or audit to only log them.
+	Mode              string   `yaml:"mode,omitempty"`
+	AllowedBinaries   []string `yaml:"allowedBinaries,omitempty"`
+	ForbiddenFlags    []string `yaml:"forbiddenFlags,omitempty"`
+	AllowedNamespaces []string `yaml:"allowedNamespaces,omitempty"`
+}
+
+func (p execPolicy) validate() error {
+	switch p.Mode {
+	case "", ExecPolicyEnforce, ExecPolicyAudit:
+		return nil
+	}
+	return fmt.Errorf("mode %q must be %s or %s", p.Mode, ExecPolicyEnforce, ExecPolicyAudit)
+}
+
+// validators returns the checks the policy applies to every command.
+func (p execPolicy) validators() []ExecValidator {
+	var validators []ExecValidator
+	if len(p.AllowedBinaries) > 0 {
+		allowed := AllowlistBins(p.AllowedBinaries...)
+		validators = append(validators, func(spec ExecSpec) error {
+			if err := allowed(spec); err != nil {
+				return fmt.Errorf("%w: %s", err, spec.Name)
+			}
+			return nil
+		})
+	}
+	if len(p.ForbiddenFlags) > 0 {
+		validators = append(validators, execx.ForbidFlags(p.ForbiddenFlags...))
+	}
+	if len(p.AllowedNamespaces) > 0 {
+		allowed := execx.AllowNamespaces(p.AllowedNamespaces...)
+		validators = append(validators, func(spec ExecSpec) error {
+			if spec.Name != "kubectl" {
+				return nil
+			}
+			return allowed(spec)
+		})
+	}
+	return validators
+}
+
+// policyExecutor checks commands against the exec policy before handing them to next.
+type policyExecutor struct {
+	next       Executor
+	validators []ExecValidator
+	audit      bool
+	logger     *zap.Logger
+}
+
+func (e *policyExecutor) Command(ctx context.Context, name string, args []string, validators ...ExecValidator) (Command, error) {
+	if err := execx.Validate(ExecSpec{Name: name, Args: args}, e.validators...); err != nil {
+		// Arguments are left out of the logs; they may carry credentials.
+		if e.audit {
+			Warn(fmt.Sprintf("Exec policy (audit mode) would refuse %s: %v", name, err))
+			e.logger.Warn("Exec policy violation (audit mode, command allowed)", zap.String("binary", name), zap.Error(err))
+			return e.next.Command(ctx, name, args, validators...)
+		}
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrExecPolicyViolation,
+			err,
+			fmt.Sprintf("%s refused by the exec policy in the CLI config: %v", name, err),
+			map[string]any{"binary": name, "component": "exec"},
+		)
+		logStructuredError(e.logger, wrappedErr, "Command refused by exec policy")
+		return nil, wrappedErr
+	}
+	return e.next.Command(ctx, name, args, validators...)
+}
+
+// ConfigureExecPolicy loads the exec policy from the CLI config and applies it to the
+// commands the CLI runs. It must run before the commands are created. A config that cannot
+// be read or holds an invalid policy is an error, so the CLI does not run unrestricted.
+func ConfigureExecPolicy(logger *zap.Logger) error {
+	settings, err := loadCLISettings()
+	if err != nil {
+		return wrapWithSentinel(ErrInvalidExecPolicy, err, fmt.Sprintf("failed to read the exec policy: %v", err))
+	}
+	if settings.ExecPolicy == nil {
+		return nil
+	}
+	policy := *settings.ExecPolicy
+	if err := policy.validate(); err != nil {
+		return wrapWithSentinel(ErrInvalidExecPolicy, err, fmt.Sprintf("invalid exec policy: %v", err))
+	}
+	validators := policy.validators()
+	if len(validators) == 0 {
+		return nil
+	}
+
+	execExecutor = &policyExecutor{next: execExecutor, validators: validators, audit: policy.Mode == ExecPolicyAudit, logger: logger}
+	kubectlClient.exec = execExecutor
+	logger.Debug("Exec policy loaded",
+		zap.String("mode", valueOrDefault(policy.Mode, ExecPolicyEnforce)),
+		zap.String("allowedBinaries", strings.Join(policy.AllowedBinaries, ",")),
+		zap.String("forbiddenFlags", strings.Join(policy.ForbiddenFlags, ",")),
+		zap.String("allowedNamespaces", strings.Join(policy.AllowedNamespaces, ",")),
+	)
+	return nil
+}
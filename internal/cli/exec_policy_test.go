@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"mcp-runtime/pkg/execx"
+)
+
+func writeExecPolicy(t *testing.T, policy string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".mcp-runtime"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".mcp-runtime", "config.yaml"), []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	origExecutor, origKubectlExec := execExecutor, kubectlClient.exec
+	t.Cleanup(func() {
+		execExecutor = origExecutor
+		kubectlClient.exec = origKubectlExec
+	})
+	execExecutor = &MockExecutor{}
+}
+
+func TestConfigureExecPolicy(t *testing.T) {
+	writeExecPolicy(t, `namespace: team-a
+execPolicy:
+  allowedBinaries: [kubectl, docker]
+  forbiddenFlags: [--as]
+  allowedNamespaces: [team-a, mcp-runtime]
+`)
+	if err := ConfigureExecPolicy(zap.NewNop()); err != nil {
+		t.Fatalf("ConfigureExecPolicy() error: %v", err)
+	}
+	if kubectlClient.exec != execExecutor {
+		t.Fatal("expected the kubectl client to use the policy executor")
+	}
+
+	tests := []struct {
+		name string
+		bin  string
+		args []string
+		want error
+	}{
+		{"allowed", "kubectl", []string{"get", "pods", "-n", "team-a"}, nil},
+		{"binary", "eksctl", []string{"delete", "cluster"}, execx.ErrBinaryNotAllowed},
+		{"flag", "kubectl", []string{"get", "secrets", "--as=admin"}, execx.ErrFlagForbidden},
+		{"namespace", "kubectl", []string{"get", "secrets", "-n", "kube-system"}, execx.ErrNamespaceDenied},
+		{"namespace of other binaries", "docker", []string{"run", "-n", "kube-system"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := execExecutor.Command(context.Background(), tt.bin, tt.args)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if tt.want != nil && !errors.Is(err, ErrExecPolicyViolation) {
+				t.Fatalf("expected ErrExecPolicyViolation, got %v", err)
+			}
+		})
+	}
+
+	if _, err := kubectlClient.Output([]string{"get", "pods", "-A"}); !errors.Is(err, ErrExecPolicyViolation) {
+		t.Fatalf("expected kubectl -A to be refused, got %v", err)
+	}
+}
+
+func TestConfigureExecPolicyAudit(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	writeExecPolicy(t, "execPolicy:\n  mode: audit\n  allowedBinaries: [kubectl]\n")
+	if err := ConfigureExecPolicy(zap.NewNop()); err != nil {
+		t.Fatalf("ConfigureExecPolicy() error: %v", err)
+	}
+	if _, err := execExecutor.Command(context.Background(), "eksctl", nil); err != nil {
+		t.Fatalf("expected audit mode to allow the command, got %v", err)
+	}
+
+	writeExecPolicy(t, "execPolicy:\n  mode: warn\n")
+	if err := ConfigureExecPolicy(zap.NewNop()); !errors.Is(err, ErrInvalidExecPolicy) {
+		t.Fatalf("expected ErrInvalidExecPolicy, got %v", err)
+	}
+}
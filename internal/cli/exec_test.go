@@ -1,12 +1,13 @@
 package cli
 
 import (
+	"context"
 	"os"
 	"testing"
 )
 
 func TestExecCommand(t *testing.T) {
-	cmd := execCommand("echo", "hello")
+	cmd := execCommand(context.Background(), "echo", "hello")
 	out, err := cmd.Output()
 	if err != nil {
 		t.Fatalf("failed to execute command: %v", err)
@@ -165,8 +166,8 @@ func TestMockExecutorReset(t *testing.T) {
 	mock := &MockExecutor{}
 
 	// Execute some commands
-	_, _ = mock.Command("kubectl", []string{"get", "pods"})
-	_, _ = mock.Command("docker", []string{"build", "."})
+	_, _ = mock.Command(context.Background(), "kubectl", []string{"get", "pods"})
+	_, _ = mock.Command(context.Background(), "docker", []string{"build", "."})
 
 	if len(mock.Commands) != 2 {
 		t.Fatalf("expected 2 commands, got %d", len(mock.Commands))
@@ -206,8 +207,8 @@ func TestMockExecutorLastCommand(t *testing.T) {
 
 	t.Run("returns_last_command", func(t *testing.T) {
 		mock := &MockExecutor{}
-		_, _ = mock.Command("first", []string{"arg1"})
-		_, _ = mock.Command("second", []string{"arg2"})
+		_, _ = mock.Command(context.Background(), "first", []string{"arg1"})
+		_, _ = mock.Command(context.Background(), "second", []string{"arg2"})
 
 		last := mock.LastCommand()
 		if last.Name != "second" {
@@ -218,7 +219,7 @@ func TestMockExecutorLastCommand(t *testing.T) {
 
 func TestMockExecutorHasCommand(t *testing.T) {
 	mock := &MockExecutor{}
-	_, _ = mock.Command("kubectl", []string{"get", "pods"})
+	_, _ = mock.Command(context.Background(), "kubectl", []string{"get", "pods"})
 
 	if !mock.HasCommand("kubectl") {
 		t.Error("expected HasCommand('kubectl') to be true")
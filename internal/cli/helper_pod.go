@@ -0,0 +1,98 @@
+package cli
+
+// This file implements the configurable pod template for the in-cluster push helper.
+// Restricted clusters (Pod Security "restricted", quota-enforced namespaces, tainted nodes)
+// reject the default unconstrained skopeo pod; a template adds resources, scheduling
+// constraints, pull secrets and security contexts to it.
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// HelperPodTemplate constrains the in-cluster push helper pod.
+type HelperPodTemplate struct {
+	// Resources applies to the skopeo container.
+	Resources        corev1.ResourceRequirements   `json:"resources,omitempty"`
+	NodeSelector     map[string]string             `json:"nodeSelector,omitempty"`
+	Tolerations      []corev1.Toleration           `json:"tolerations,omitempty"`
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// PodSecurityContext applies to the pod.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// SecurityContext applies to the skopeo container.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// LoadHelperPodTemplate reads a helper pod template from a YAML or JSON file.
+// An empty path returns nil (no template).
+func LoadHelperPodTemplate(path string) (*HelperPodTemplate, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path from CLI flag or MCP_HELPER_POD_TEMPLATE.
+	if err != nil {
+		return nil, wrapWithSentinel(ErrInvalidHelperPodTemplate, err, fmt.Sprintf("read helper pod template %q: %v", path, err))
+	}
+	var tmpl HelperPodTemplate
+	if err := yaml.UnmarshalStrict(data, &tmpl); err != nil {
+		return nil, wrapWithSentinel(ErrInvalidHelperPodTemplate, err, fmt.Sprintf("parse helper pod template %q: %v", path, err))
+	}
+	return &tmpl, nil
+}
+
+// helperPodOverrides renders tmpl as a strategic-merge override for "kubectl run", loading
+// the environment from proxySecret when set. kubectl names the single container after the
+// pod, which is how container-level fields are matched.
+func helperPodOverrides(podName string, tmpl *HelperPodTemplate, proxySecret string) (string, error) {
+	if tmpl == nil {
+		tmpl = &HelperPodTemplate{}
+	}
+	container := map[string]any{"name": podName}
+	if proxySecret != "" {
+		container["envFrom"] = proxyEnvFrom(proxySecret)
+	}
+	if len(tmpl.Resources.Limits) > 0 || len(tmpl.Resources.Requests) > 0 {
+		container["resources"] = tmpl.Resources
+	}
+	if tmpl.SecurityContext != nil {
+		container["securityContext"] = tmpl.SecurityContext
+	}
+
+	spec := map[string]any{"containers": []any{container}}
+	if len(tmpl.NodeSelector) > 0 {
+		spec["nodeSelector"] = tmpl.NodeSelector
+	}
+	if len(tmpl.Tolerations) > 0 {
+		spec["tolerations"] = tmpl.Tolerations
+	}
+	if len(tmpl.ImagePullSecrets) > 0 {
+		spec["imagePullSecrets"] = tmpl.ImagePullSecrets
+	}
+	if tmpl.PodSecurityContext != nil {
+		spec["securityContext"] = tmpl.PodSecurityContext
+	}
+
+	out, err := json.Marshal(map[string]any{"apiVersion": "v1", "spec": spec})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// helperRunArgs returns the "kubectl run" arguments that start the helper pod. With a proxy,
+// the pod loads it from the proxy Secret, which ensureProxyPodSecret creates.
+func helperRunArgs(podName, namespace, image string, tmpl *HelperPodTemplate) ([]string, error) {
+	args := []string{"run", podName, "-n", namespace, "--image=" + image, "--restart=Never"}
+	if proxySecret := proxyPodSecretName(); tmpl != nil || proxySecret != "" {
+		overrides, err := helperPodOverrides(podName, tmpl, proxySecret)
+		if err != nil {
+			return nil, wrapWithSentinel(ErrInvalidHelperPodTemplate, err, fmt.Sprintf("render helper pod overrides: %v", err))
+		}
+		args = append(args, "--override-type=strategic", "--overrides="+overrides)
+	}
+	return append(args, "--command", "--", "sh", "-c", "while true; do sleep 3600; done"), nil
+}
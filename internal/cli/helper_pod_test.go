@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const testHelperPodTemplate = `resources:
+  requests:
+    cpu: 100m
+    memory: 128Mi
+  limits:
+    memory: 512Mi
+nodeSelector:
+  kubernetes.io/os: linux
+tolerations:
+- key: dedicated
+  operator: Equal
+  value: build
+  effect: NoSchedule
+imagePullSecrets:
+- name: mirror-creds
+podSecurityContext:
+  runAsNonRoot: true
+  runAsUser: 1000
+  seccompProfile:
+    type: RuntimeDefault
+securityContext:
+  allowPrivilegeEscalation: false
+  capabilities:
+    drop: ["ALL"]
+`
+
+func writeHelperPodTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "helper-pod.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	return path
+}
+
+func TestLoadHelperPodTemplate(t *testing.T) {
+	t.Run("empty path means no template", func(t *testing.T) {
+		tmpl, err := LoadHelperPodTemplate("")
+		if err != nil || tmpl != nil {
+			t.Fatalf("expected nil template, got %v %v", tmpl, err)
+		}
+	})
+
+	t.Run("parses all fields", func(t *testing.T) {
+		tmpl, err := LoadHelperPodTemplate(writeHelperPodTemplate(t, testHelperPodTemplate))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tmpl.Resources.Requests.Cpu().String() != "100m" || tmpl.NodeSelector["kubernetes.io/os"] != "linux" {
+			t.Fatalf("unexpected template %+v", tmpl)
+		}
+		if len(tmpl.Tolerations) != 1 || tmpl.ImagePullSecrets[0].Name != "mirror-creds" {
+			t.Fatalf("unexpected scheduling fields %+v", tmpl)
+		}
+		if tmpl.PodSecurityContext == nil || tmpl.SecurityContext == nil {
+			t.Fatal("expected security contexts")
+		}
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := LoadHelperPodTemplate(writeHelperPodTemplate(t, "nodeSelectr:\n  a: b\n"))
+		if !errors.Is(err, ErrInvalidHelperPodTemplate) {
+			t.Fatalf("expected ErrInvalidHelperPodTemplate, got %v", err)
+		}
+	})
+}
+
+func TestHelperRunArgs(t *testing.T) {
+	clearProxyEnv(t)
+	args, err := helperRunArgs("pusher", "registry", "skopeo:v1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(strings.Join(args, " "), "--overrides") {
+		t.Fatalf("expected no overrides without template, got %v", args)
+	}
+
+	tmpl, err := LoadHelperPodTemplate(writeHelperPodTemplate(t, testHelperPodTemplate))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args, err = helperRunArgs("pusher", "registry", "skopeo:v1", tmpl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !contains(args, "--override-type=strategic") {
+		t.Fatalf("expected strategic override type, got %v", args)
+	}
+
+	var overrides struct {
+		Spec struct {
+			Containers []struct {
+				Name            string         `json:"name"`
+				Resources       map[string]any `json:"resources"`
+				SecurityContext map[string]any `json:"securityContext"`
+			} `json:"containers"`
+			NodeSelector     map[string]string `json:"nodeSelector"`
+			ImagePullSecrets []map[string]any  `json:"imagePullSecrets"`
+			SecurityContext  map[string]any    `json:"securityContext"`
+		} `json:"spec"`
+	}
+	for _, arg := range args {
+		if raw, ok := strings.CutPrefix(arg, "--overrides="); ok {
+			if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+				t.Fatalf("invalid overrides JSON: %v", err)
+			}
+		}
+	}
+	if len(overrides.Spec.Containers) != 1 || overrides.Spec.Containers[0].Name != "pusher" {
+		t.Fatalf("expected container override named after the pod, got %+v", overrides.Spec.Containers)
+	}
+	if overrides.Spec.Containers[0].Resources == nil || overrides.Spec.Containers[0].SecurityContext == nil {
+		t.Fatalf("expected container resources and securityContext, got %+v", overrides.Spec.Containers[0])
+	}
+	if overrides.Spec.NodeSelector["kubernetes.io/os"] != "linux" || len(overrides.Spec.ImagePullSecrets) != 1 || overrides.Spec.SecurityContext == nil {
+		t.Fatalf("unexpected pod overrides %+v", overrides.Spec)
+	}
+}
+
+func TestRegistryPushCmdHelperPodTemplate(t *testing.T) {
+	t.Run("passes the template to kubectl run", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		cmd := mgr.newRegistryPushCmd()
+		_ = cmd.Flags().Set("image", "my-image:v1")
+		_ = cmd.Flags().Set("registry", "registry.example.com")
+		_ = cmd.Flags().Set("helper-pod-template", writeHelperPodTemplate(t, testHelperPodTemplate))
+
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		found := false
+		for _, c := range mock.Commands {
+			if c.Name == "kubectl" && contains(c.Args, "run") && contains(c.Args, "--override-type=strategic") {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected kubectl run with overrides, got %v", mock.Commands)
+		}
+	})
+
+	t.Run("fails before starting a pod on an invalid template", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		err := mgr.pushInCluster("my-image:v1", "registry.example.com/my-image:v1", "registry", filepath.Join(t.TempDir(), "missing.yaml"))
+		if !errors.Is(err, ErrInvalidHelperPodTemplate) {
+			t.Fatalf("expected ErrInvalidHelperPodTemplate, got %v", err)
+		}
+		if mock.HasCommand("kubectl") || mock.HasCommand("docker") {
+			t.Fatalf("expected no commands, got %v", mock.Commands)
+		}
+	})
+}
@@ -0,0 +1,310 @@
+package cli
+
+// This file implements the "ingress" command for local ingress host helpers.
+// It detects the ingress controller address and maps made-up dev hosts (e.g. mcp.local) in /etc/hosts.
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// lookupHost is a test seam for DNS resolution.
+var lookupHost = net.LookupHost
+
+// hostsFileMarker tags lines appended by "ingress hosts --apply".
+const hostsFileMarker = "# added by mcp-runtime"
+
+// IngressManager handles ingress helper operations with injected dependencies.
+type IngressManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewIngressManager creates an IngressManager with the given dependencies.
+func NewIngressManager(kubectl *KubectlClient, logger *zap.Logger) *IngressManager {
+	return &IngressManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultIngressManager returns an IngressManager using the default kubectl client.
+func DefaultIngressManager(logger *zap.Logger) *IngressManager {
+	return NewIngressManager(kubectlClient, logger)
+}
+
+// NewIngressCmd returns the ingress subcommand.
+func NewIngressCmd(logger *zap.Logger) *cobra.Command {
+	return NewIngressCmdWithManager(DefaultIngressManager(logger))
+}
+
+// NewIngressCmdWithManager returns the ingress subcommand using the provided manager.
+func NewIngressCmdWithManager(mgr *IngressManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingress",
+		Short: "Ingress helpers",
+		Long:  "Helpers for reaching MCP servers through the ingress controller",
+	}
+
+	cmd.AddCommand(mgr.newIngressHostsCmd())
+
+	return cmd
+}
+
+func (m *IngressManager) newIngressHostsCmd() *cobra.Command {
+	var apply bool
+	var hostsFile string
+	var namespace string
+	var service string
+
+	cmd := &cobra.Command{
+		Use:   "hosts",
+		Short: "Print or apply /etc/hosts entries for ingress hosts",
+		Long: `Detect the ingress controller's address and print /etc/hosts entries for
+MCPServer ingress hosts that do not resolve (e.g. made-up dev domains like mcp.local).
+
+Use --apply to append the missing entries to the hosts file (usually requires sudo).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Hosts(namespace, service, hostsFile, apply)
+		},
+	}
+
+	cmd.Flags().BoolVar(&apply, "apply", false, "Append missing entries to the hosts file")
+	cmd.Flags().StringVar(&hostsFile, "hosts-file", "/etc/hosts", "Hosts file to update with --apply")
+	cmd.Flags().StringVar(&namespace, "ingress-namespace", "traefik", "Namespace of the ingress controller service")
+	cmd.Flags().StringVar(&service, "ingress-service", "traefik", "Name of the ingress controller service")
+
+	return cmd
+}
+
+// ingressAddress is where the ingress controller can be reached from this machine.
+type ingressAddress struct {
+	IP string
+	// NodePort is set when the controller is only reachable through a NodePort.
+	NodePort string
+}
+
+// Hosts prints (and optionally appends) hosts-file entries for unresolvable ingress hosts.
+func (m *IngressManager) Hosts(namespace, service, hostsFile string, apply bool) error {
+	hosts, err := m.ingressHosts()
+	if err != nil {
+		wrappedErr := wrapWithSentinel(ErrListIngressHostsFailed, err, fmt.Sprintf("failed to list ingress hosts: %v", err))
+		Error("Failed to list ingress hosts")
+		logStructuredError(m.logger, wrappedErr, "Failed to list ingress hosts")
+		return wrappedErr
+	}
+	unresolved := unresolvedHosts(hosts)
+	if len(unresolved) == 0 {
+		Success("All ingress hosts resolve; no hosts entries needed")
+		return nil
+	}
+
+	addr, err := m.detectIngressAddress(namespace, service)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrDetectIngressAddressFailed,
+			err,
+			fmt.Sprintf("failed to detect ingress address for service %s/%s: %v", namespace, service, err),
+			map[string]any{"namespace": namespace, "service": service, "component": "ingress"},
+		)
+		Error("Failed to detect ingress address")
+		logStructuredError(m.logger, wrappedErr, "Failed to detect ingress address")
+		return wrappedErr
+	}
+
+	lines := hostsLines(addr.IP, unresolved)
+	Section("Hosts entries")
+	for _, line := range lines {
+		DefaultPrinter.Println(line)
+	}
+	if addr.NodePort != "" {
+		Warn(fmt.Sprintf("Ingress is exposed via NodePort; use http://<host>:%s/...", addr.NodePort))
+	}
+
+	if !apply {
+		Info(fmt.Sprintf("Re-run with --apply to append these entries to %s", hostsFile))
+		return nil
+	}
+
+	added, err := appendHostsEntries(hostsFile, lines)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrUpdateHostsFileFailed,
+			err,
+			fmt.Sprintf("failed to update %s (try sudo): %v", hostsFile, err),
+			map[string]any{"hosts_file": hostsFile, "component": "ingress"},
+		)
+		Error("Failed to update hosts file")
+		logStructuredError(m.logger, wrappedErr, "Failed to update hosts file")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("Added %d entr%s to %s", added, pluralSuffix(added, "y", "ies"), hostsFile))
+	return nil
+}
+
+// effectiveIngressValue returns the value the operator reported in status, falling back to
+// spec for servers it has not reconciled yet. Defaulted ingress hosts, paths and classes are
+// only recorded in status.
+func effectiveIngressValue(status, spec string) string {
+	if status != "" {
+		return status
+	}
+	return spec
+}
+
+// ingressHosts returns the distinct ingress hosts of all MCPServers.
+func (m *IngressManager) ingressHosts() ([]string, error) {
+	return listIngressHostsWithKubectl(m.kubectl)
+}
+
+func listIngressHostsWithKubectl(kubectl *KubectlClient) ([]string, error) {
+	// #nosec G204 -- fixed kubectl command.
+	out, err := kubectl.Output([]string{"get", "mcpserver", "--all-namespaces", "-o", `jsonpath={range .items[*]}{.status.ingressHost}|{.spec.ingressHost}{"\n"}{end}`})
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var hosts []string
+	for _, line := range strings.Split(string(out), "\n") {
+		status, spec, _ := strings.Cut(strings.TrimSpace(line), "|")
+		host := effectiveIngressValue(status, spec)
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts, nil
+}
+
+// unresolvedHosts returns hosts that are neither IP literals nor resolvable.
+func unresolvedHosts(hosts []string) []string {
+	var out []string
+	for _, host := range hosts {
+		if net.ParseIP(host) != nil {
+			continue
+		}
+		if _, err := lookupHost(host); err != nil {
+			out = append(out, host)
+		}
+	}
+	return out
+}
+
+// detectIngressAddress finds the external IP of the ingress controller service,
+// falling back to a node InternalIP plus NodePort when no load balancer is assigned.
+func (m *IngressManager) detectIngressAddress(namespace, service string) (ingressAddress, error) {
+	// #nosec G204 -- namespace/service from CLI flags, kubectl validates names.
+	out, err := m.kubectl.Output([]string{"get", "service", service, "-n", namespace, "-o", "jsonpath={.status.loadBalancer.ingress[0].ip} {.status.loadBalancer.ingress[0].hostname}"})
+	if err != nil {
+		return ingressAddress{}, err
+	}
+	fields := strings.Fields(string(out))
+	for _, field := range fields {
+		if net.ParseIP(field) != nil {
+			return ingressAddress{IP: field}, nil
+		}
+		if addrs, lookupErr := lookupHost(field); lookupErr == nil && len(addrs) > 0 {
+			return ingressAddress{IP: addrs[0]}, nil
+		}
+	}
+
+	// #nosec G204 -- fixed kubectl command.
+	nodeIP, err := m.kubectl.Output([]string{"get", "nodes", "-o", `jsonpath={.items[0].status.addresses[?(@.type=="InternalIP")].address}`})
+	if err != nil {
+		return ingressAddress{}, err
+	}
+	ip := strings.TrimSpace(string(nodeIP))
+	if ip == "" {
+		return ingressAddress{}, fmt.Errorf("no load balancer address and no node InternalIP found")
+	}
+	// #nosec G204 -- namespace/service from CLI flags, kubectl validates names.
+	port, err := m.kubectl.Output([]string{"get", "service", service, "-n", namespace, "-o", `jsonpath={.spec.ports[?(@.port==80)].nodePort}`})
+	if err != nil {
+		return ingressAddress{}, err
+	}
+	return ingressAddress{IP: ip, NodePort: strings.TrimSpace(string(port))}, nil
+}
+
+func hostsLines(ip string, hosts []string) []string {
+	lines := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		lines = append(lines, fmt.Sprintf("%s\t%s", ip, host))
+	}
+	return lines
+}
+
+// appendHostsEntries appends lines whose host is not already mapped in path.
+// It returns the number of lines added.
+func appendHostsEntries(path string, lines []string) (int, error) {
+	existing := map[string]bool{}
+	// #nosec G304 -- hosts file path from CLI flag.
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(strings.SplitN(scanner.Text(), "#", 2)[0])
+			for _, host := range fieldsAfterFirst(fields) {
+				existing[host] = true
+			}
+		}
+		_ = f.Close()
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	var toAdd []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && !existing[fields[1]] {
+			toAdd = append(toAdd, line)
+		}
+	}
+	if len(toAdd) == 0 {
+		return 0, nil
+	}
+
+	// #nosec G302 G304 -- hosts file must stay world-readable; path from CLI flag.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	for _, line := range toAdd {
+		if _, err := fmt.Fprintf(f, "%s %s\n", line, hostsFileMarker); err != nil {
+			return 0, err
+		}
+	}
+	return len(toAdd), nil
+}
+
+func fieldsAfterFirst(fields []string) []string {
+	if len(fields) < 2 {
+		return nil
+	}
+	return fields[1:]
+}
+
+func pluralSuffix(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// checkIngressHostsResolve warns about MCPServer ingress hosts that do not resolve.
+func checkIngressHostsResolve(kubectl *KubectlClient) {
+	hosts, err := listIngressHostsWithKubectl(kubectl)
+	if err != nil {
+		return
+	}
+	for _, host := range unresolvedHosts(hosts) {
+		Warn(fmt.Sprintf("Ingress host %q does not resolve; run 'mcp-runtime ingress hosts --apply'", host))
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func stubLookupHost(t *testing.T, resolvable map[string][]string) {
+	t.Helper()
+	original := lookupHost
+	lookupHost = func(host string) ([]string, error) {
+		if addrs, ok := resolvable[host]; ok {
+			return addrs, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	t.Cleanup(func() { lookupHost = original })
+}
+
+func TestUnresolvedHosts(t *testing.T) {
+	stubLookupHost(t, map[string][]string{"example.com": {"93.184.216.34"}})
+
+	got := unresolvedHosts([]string{"10.0.0.1", "example.com", "mcp.local", "demo.local"})
+	want := []string{"mcp.local", "demo.local"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAppendHostsEntries(t *testing.T) {
+	t.Run("appends_only_missing_hosts", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hosts")
+		if err := os.WriteFile(path, []byte("127.0.0.1\tlocalhost\n10.0.0.1 mcp.local # existing\n"), 0o600); err != nil {
+			t.Fatalf("write hosts: %v", err)
+		}
+
+		added, err := appendHostsEntries(path, hostsLines("10.0.0.2", []string{"mcp.local", "demo.local"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if added != 1 {
+			t.Fatalf("expected 1 entry added, got %d", added)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read hosts: %v", err)
+		}
+		if !strings.HasSuffix(string(data), "10.0.0.2\tdemo.local "+hostsFileMarker+"\n") {
+			t.Fatalf("unexpected hosts file:\n%s", data)
+		}
+	})
+
+	t.Run("is_idempotent", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hosts")
+		lines := hostsLines("10.0.0.2", []string{"mcp.local"})
+		if _, err := appendHostsEntries(path, lines); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		added, err := appendHostsEntries(path, lines)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if added != 0 {
+			t.Fatalf("expected no entries on second run, got %d", added)
+		}
+	})
+}
+
+func TestDetectIngressAddress(t *testing.T) {
+	t.Run("uses_load_balancer_ip", func(t *testing.T) {
+		mock := &MockExecutor{DefaultOutput: []byte("192.168.1.50 ")}
+		mgr := NewIngressManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+
+		addr, err := mgr.detectIngressAddress("traefik", "traefik")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if addr.IP != "192.168.1.50" || addr.NodePort != "" {
+			t.Fatalf("unexpected address %+v", addr)
+		}
+	})
+
+	t.Run("falls_back_to_node_port", func(t *testing.T) {
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				args := strings.Join(spec.Args, " ")
+				switch {
+				case strings.Contains(args, "get nodes"):
+					return &MockCommand{OutputData: []byte("172.18.0.2")}
+				case strings.Contains(args, "nodePort"):
+					return &MockCommand{OutputData: []byte("30080")}
+				default:
+					return &MockCommand{OutputData: []byte(" ")}
+				}
+			},
+		}
+		mgr := NewIngressManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+
+		addr, err := mgr.detectIngressAddress("traefik", "traefik")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if addr.IP != "172.18.0.2" || addr.NodePort != "30080" {
+			t.Fatalf("unexpected address %+v", addr)
+		}
+	})
+}
+
+func TestIngressHostsAllResolve(t *testing.T) {
+	stubLookupHost(t, map[string][]string{"mcp.example.com": {"10.0.0.1"}})
+	mock := &MockExecutor{DefaultOutput: []byte("mcp.example.com\nmcp.example.com\n")}
+	mgr := NewIngressManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+
+	if err := mgr.Hosts("traefik", "traefik", filepath.Join(t.TempDir(), "hosts"), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Commands) != 1 {
+		t.Fatalf("expected only the host listing command, got %d commands", len(mock.Commands))
+	}
+}
+
+func TestListIngressHostsPrefersStatus(t *testing.T) {
+	// The first server relies on a defaulted host, which the operator records in status only.
+	mock := &MockExecutor{DefaultOutput: []byte("mcp.local|\n|spec.example.com\nstatus.example.com|spec.example.com\n|\n")}
+	hosts, err := listIngressHostsWithKubectl(&KubectlClient{exec: mock, validators: nil})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"mcp.local", "spec.example.com", "status.example.com"}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("listIngressHostsWithKubectl() = %v, want %v", hosts, want)
+	}
+}
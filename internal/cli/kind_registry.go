@@ -0,0 +1,169 @@
+package cli
+
+// This file wires a local registry into kind clusters, following kind's local registry guide
+// (https://kind.sigs.k8s.io/docs/user/local-registry/): a registry:2 container published on
+// localhost:5001, a containerd hosts.toml on every node that resolves localhost:5001 to that
+// container, and the kube-public/local-registry-hosting ConfigMap (KEP-1755) that tells tools
+// where to push. Images pushed with "docker push localhost:5001/..." are then pullable by pods
+// under the same name, without the in-cluster skopeo helper.
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kindRegistryName         = "kind-registry"
+	kindRegistryImage        = "registry:2"
+	kindRegistryHost         = "localhost:5001"
+	kindRegistryNetwork      = "kind"
+	kindRegistryCertsDir     = "/etc/containerd/certs.d/" + kindRegistryHost
+	localRegistryHostingNS   = "kube-public"
+	localRegistryHostingName = "local-registry-hosting"
+)
+
+// kindRegistryContainerdPatch makes containerd on kind nodes read per-registry hosts.toml files.
+const kindRegistryContainerdPatch = `containerdConfigPatches:
+- |-
+  [plugins."io.containerd.grpc.v1.cri".registry]
+    config_path = "/etc/containerd/certs.d"
+`
+
+// kindRegistryHostsTOML points localhost:5001 inside a node at the registry container.
+var kindRegistryHostsTOML = fmt.Sprintf("[host.\"http://%s:5000\"]\n", kindRegistryName)
+
+// localRegistryHostingManifest is the KEP-1755 ConfigMap documenting the local registry.
+var localRegistryHostingManifest = fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: %s
+data:
+  localRegistryHosting.v1: |
+    host: "%s"
+    help: "https://kind.sigs.k8s.io/docs/user/local-registry/"
+`, localRegistryHostingName, localRegistryHostingNS, kindRegistryHost)
+
+// ensureKindRegistry starts the registry container with the container CLI bin, creating it
+// on first use. The container outlives clusters so pushed images survive a re-provision.
+func (m *ClusterManager) ensureKindRegistry(bin string) error {
+	// #nosec G204 -- bin is the detected container runtime; arguments are fixed.
+	inspect, err := m.exec.Command(commandContext(), bin, []string{"inspect", "-f", "{{.State.Running}}", kindRegistryName})
+	if err != nil {
+		return err
+	}
+	state, inspectErr := inspect.Output()
+	switch {
+	case inspectErr == nil && strings.TrimSpace(string(state)) == "true":
+		Info(fmt.Sprintf("Local registry %s already running on %s", kindRegistryName, kindRegistryHost))
+		return nil
+	case inspectErr == nil && strings.TrimSpace(string(state)) == "false":
+		return m.runContainerCLI(bin, "start", kindRegistryName)
+	}
+	Info(fmt.Sprintf("Starting local registry %s on %s", kindRegistryName, kindRegistryHost))
+	return m.runContainerCLI(bin, "run", "-d", "--restart=always", "-p", "127.0.0.1:5001:5000",
+		"--network", "bridge", "--name", kindRegistryName, kindRegistryImage)
+}
+
+// wireKindRegistry connects the registry to the kind cluster clusterName: it writes the
+// hosts.toml on every node, joins the registry to the kind network and applies the
+// local-registry-hosting ConfigMap.
+func (m *ClusterManager) wireKindRegistry(bin, clusterName string) error {
+	// #nosec G204 -- clusterName comes from the --name flag and is passed as a single argument.
+	nodesCmd, err := m.exec.Command(commandContext(), "kind", []string{"get", "nodes", "--name", clusterName})
+	if err != nil {
+		return err
+	}
+	out, err := nodesCmd.Output()
+	if err != nil {
+		return fmt.Errorf("list kind nodes: %w", err)
+	}
+	for _, node := range strings.Fields(string(out)) {
+		if err := m.runContainerCLI(bin, "exec", node, "mkdir", "-p", kindRegistryCertsDir); err != nil {
+			return fmt.Errorf("configure node %s: %w", node, err)
+		}
+		// #nosec G204 -- node names come from kind; the target path is fixed.
+		cp, err := m.exec.Command(commandContext(), bin, []string{"exec", "-i", node, "cp", "/dev/stdin", kindRegistryCertsDir + "/hosts.toml"})
+		if err != nil {
+			return err
+		}
+		cp.SetStdin(strings.NewReader(kindRegistryHostsTOML))
+		cp.SetStdout(DefaultPrinter.Stdout())
+		cp.SetStderr(DefaultPrinter.Stderr())
+		if err := cp.Run(); err != nil {
+			return fmt.Errorf("configure node %s: %w", node, err)
+		}
+	}
+
+	// #nosec G204 -- bin is the detected container runtime; arguments are fixed.
+	networks, err := m.exec.Command(commandContext(), bin, []string{"inspect", "-f", "{{json .NetworkSettings.Networks." + kindRegistryNetwork + "}}", kindRegistryName})
+	if err != nil {
+		return err
+	}
+	if network, err := networks.Output(); err != nil || strings.TrimSpace(string(network)) == "null" || len(bytes.TrimSpace(network)) == 0 {
+		if err := m.runContainerCLI(bin, "network", "connect", kindRegistryNetwork, kindRegistryName); err != nil {
+			return fmt.Errorf("connect %s to the %s network: %w", kindRegistryName, kindRegistryNetwork, err)
+		}
+	}
+
+	// #nosec G204 -- fixed arguments; the context name is derived from the cluster name.
+	apply, err := m.kubectl.CommandArgs([]string{"--context", "kind-" + clusterName, "apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	apply.SetStdin(strings.NewReader(localRegistryHostingManifest))
+	apply.SetStdout(DefaultPrinter.Stdout())
+	apply.SetStderr(DefaultPrinter.Stderr())
+	if err := apply.Run(); err != nil {
+		return fmt.Errorf("apply %s/%s: %w", localRegistryHostingNS, localRegistryHostingName, err)
+	}
+	return nil
+}
+
+func (m *ClusterManager) runContainerCLI(bin string, args ...string) error {
+	// #nosec G204 -- bin is the detected container runtime; arguments are fixed or come from kind.
+	cmd, err := m.exec.Command(commandContext(), bin, args)
+	if err != nil {
+		return err
+	}
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	return cmd.Run()
+}
+
+// localRegistryHost returns the host of the local registry advertised by the cluster's
+// local-registry-hosting ConfigMap (kind, k3d, minikube and others publish it), or ""
+// when there is none.
+func localRegistryHost(kubectl *KubectlClient, logger *zap.Logger) string {
+	// #nosec G204 -- fixed arguments, no user input.
+	cmd, err := kubectl.CommandArgs([]string{"get", "configmap", localRegistryHostingName, "-n", localRegistryHostingNS,
+		"--ignore-not-found", "-o", `jsonpath={.data.localRegistryHosting\.v1}`})
+	if err != nil {
+		return ""
+	}
+	out, err := cmd.Output()
+	if err != nil || len(bytes.TrimSpace(out)) == 0 {
+		return ""
+	}
+	var hosting struct {
+		Host string `yaml:"host"`
+	}
+	if err := yaml.Unmarshal(out, &hosting); err != nil {
+		if logger != nil {
+			logger.Warn("Ignoring unreadable local-registry-hosting ConfigMap", zap.Error(err))
+		}
+		return ""
+	}
+	return strings.TrimSpace(hosting.Host)
+}
+
+// isLocalRegistryImage reports whether image is in the cluster's local registry, which
+// this machine reaches directly.
+func isLocalRegistryImage(kubectl *KubectlClient, image string) bool {
+	host := localRegistryHost(kubectl, nil)
+	return host != "" && strings.HasPrefix(image, host+"/")
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestProvisionKindClusterLocalRegistry(t *testing.T) {
+	var kindConfig, hostsTOML, applied string
+	mock := &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			args := strings.Join(spec.Args, " ")
+			cmd := &MockCommand{Args: spec.Args}
+			switch {
+			case spec.Name == "docker" && strings.HasPrefix(args, "inspect -f {{.State.Running}}"):
+				cmd.OutputErr = errors.New("no such object: kind-registry")
+			case spec.Name == "docker" && strings.HasPrefix(args, "inspect -f {{json .NetworkSettings.Networks.kind}}"):
+				cmd.OutputData = []byte("null\n")
+			case spec.Name == "kind" && spec.Args[0] == "get":
+				cmd.OutputData = []byte("mcp-runtime-control-plane\nmcp-runtime-worker\n")
+			case spec.Name == "kind" && spec.Args[0] == "create":
+				cmd.RunFunc = func() error {
+					data, err := os.ReadFile(spec.Args[3])
+					kindConfig = string(data)
+					return err
+				}
+			case spec.Name == "docker" && strings.Contains(args, "cp /dev/stdin"):
+				cmd.RunFunc = func() error {
+					data, _ := io.ReadAll(cmd.StdinR)
+					hostsTOML = string(data)
+					return nil
+				}
+			case spec.Name == "kubectl":
+				cmd.RunFunc = func() error {
+					data, _ := io.ReadAll(cmd.StdinR)
+					applied = string(data)
+					return nil
+				}
+			}
+			return cmd
+		},
+	}
+	mgr := NewClusterManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+
+	if err := mgr.provisionKindCluster(2, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(kindConfig, `config_path = "/etc/containerd/certs.d"`) {
+		t.Errorf("kind config lacks the containerd patch:\n%s", kindConfig)
+	}
+	if !strings.Contains(hostsTOML, `[host."http://kind-registry:5000"]`) {
+		t.Errorf("unexpected hosts.toml: %q", hostsTOML)
+	}
+	if !strings.Contains(applied, "name: local-registry-hosting") || !strings.Contains(applied, `host: "localhost:5001"`) {
+		t.Errorf("unexpected local-registry-hosting manifest:\n%s", applied)
+	}
+
+	var ran, connected, configuredNodes []string
+	for _, c := range mock.Commands {
+		args := strings.Join(c.Args, " ")
+		switch {
+		case c.Name == "docker" && c.Args[0] == "run":
+			ran = append(ran, args)
+		case c.Name == "docker" && strings.HasPrefix(args, "network connect"):
+			connected = append(connected, args)
+		case c.Name == "docker" && strings.Contains(args, "mkdir -p /etc/containerd/certs.d/localhost:5001"):
+			configuredNodes = append(configuredNodes, c.Args[1])
+		}
+	}
+	if len(ran) != 1 || !strings.Contains(ran[0], "-p 127.0.0.1:5001:5000") || !strings.HasSuffix(ran[0], "registry:2") {
+		t.Errorf("expected the registry container to be started once, got %v", ran)
+	}
+	if len(connected) != 1 || connected[0] != "network connect kind kind-registry" {
+		t.Errorf("expected the registry to join the kind network, got %v", connected)
+	}
+	if strings.Join(configuredNodes, ",") != "mcp-runtime-control-plane,mcp-runtime-worker" {
+		t.Errorf("expected every node to be configured, got %v", configuredNodes)
+	}
+}
+
+func TestEnsureKindRegistryReusesContainer(t *testing.T) {
+	for state, want := range map[string]string{"true": "", "false": "start kind-registry"} {
+		t.Run("running="+state, func(t *testing.T) {
+			mock := &MockExecutor{
+				CommandFunc: func(spec ExecSpec) *MockCommand {
+					return &MockCommand{Args: spec.Args, OutputData: []byte(state + "\n")}
+				},
+			}
+			mgr := NewClusterManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+			if err := mgr.ensureKindRegistry("docker"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := ""
+			if len(mock.Commands) > 1 {
+				got = strings.Join(mock.LastCommand().Args, " ")
+			}
+			if got != want {
+				t.Errorf("follow-up command = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestGetPlatformRegistryURLPrefersLocalRegistry(t *testing.T) {
+	originalKubectl := kubectlClient
+	defer func() { kubectlClient = originalKubectl }()
+
+	mock := &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			if contains(spec.Args, localRegistryHostingName) {
+				return &MockCommand{OutputData: []byte("host: \"localhost:5001\"\nhelp: \"https://kind.sigs.k8s.io/docs/user/local-registry/\"\n")}
+			}
+			return &MockCommand{OutputData: []byte("10.96.0.100")}
+		},
+	}
+	kubectlClient = &KubectlClient{exec: mock}
+
+	if url := getPlatformRegistryURL(zap.NewNop()); url != "localhost:5001" {
+		t.Errorf("expected the local registry, got %q", url)
+	}
+	if !isLocalRegistryImage(kubectlClient, "localhost:5001/mcp-runtime-operator:latest") {
+		t.Error("expected image in the local registry to be detected")
+	}
+	if isLocalRegistryImage(kubectlClient, "10.96.0.100:5000/mcp-runtime-operator:latest") {
+		t.Error("expected in-cluster registry image not to be treated as local")
+	}
+}
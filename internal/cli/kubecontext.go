@@ -0,0 +1,279 @@
+package cli
+
+// This file implements the "context" command for switching between clusters.
+// The selected kubeconfig context is stored in ~/.mcp-runtime/context.yaml and passed to every
+// kubectl invocation as --context, so the user's kubeconfig current-context is never changed.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// kubeContextEnv overrides the saved context for a single invocation.
+const kubeContextEnv = "MCP_KUBE_CONTEXT"
+
+// contextProbeTimeout bounds each per-context platform probe so unreachable clusters do not stall "context list".
+const contextProbeTimeout = "5s"
+
+// kubeContextConfig is the on-disk format of ~/.mcp-runtime/context.yaml.
+type kubeContextConfig struct {
+	Context string `yaml:"context"`
+}
+
+// ContextManager handles kubeconfig context operations with injected dependencies.
+type ContextManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewContextManager creates a ContextManager with the given dependencies.
+func NewContextManager(kubectl *KubectlClient, logger *zap.Logger) *ContextManager {
+	return &ContextManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultContextManager returns a ContextManager using the default kubectl client.
+func DefaultContextManager(logger *zap.Logger) *ContextManager {
+	return NewContextManager(kubectlClient, logger)
+}
+
+// NewContextCmd returns the context subcommand.
+func NewContextCmd(logger *zap.Logger) *cobra.Command {
+	return NewContextCmdWithManager(DefaultContextManager(logger))
+}
+
+// NewContextCmdWithManager returns the context subcommand using the provided manager.
+func NewContextCmdWithManager(mgr *ContextManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "List and switch Kubernetes contexts",
+		Long: `List kubeconfig contexts and select the one mcp-runtime commands run against.
+
+The selection is stored in ~/.mcp-runtime/context.yaml and does not change the
+kubeconfig current-context used by kubectl and other tools. MCP_KUBE_CONTEXT
+overrides it for a single invocation.`,
+	}
+
+	cmd.AddCommand(mgr.newContextListCmd())
+	cmd.AddCommand(mgr.newContextUseCmd())
+
+	return cmd
+}
+
+func (m *ContextManager) newContextListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List contexts and whether the MCP platform is installed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.List()
+		},
+	}
+}
+
+func (m *ContextManager) newContextUseCmd() *cobra.Command {
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Select the context for subsequent mcp-runtime commands",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clear {
+				return m.Use("")
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("accepts 1 arg(s), received %d", len(args))
+			}
+			return m.Use(args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Forget the selection and follow the kubeconfig current-context again")
+
+	return cmd
+}
+
+// List prints all kubeconfig contexts, marking the active one and probing each for the platform.
+func (m *ContextManager) List() error {
+	contexts, err := m.contexts()
+	if err != nil {
+		wrappedErr := wrapWithSentinel(ErrListKubeContextsFailed, err, fmt.Sprintf("failed to list kubeconfig contexts: %v", err))
+		Error("Failed to list kubeconfig contexts")
+		logStructuredError(m.logger, wrappedErr, "Failed to list kubeconfig contexts")
+		return wrappedErr
+	}
+	if len(contexts) == 0 {
+		Warn("No contexts found in kubeconfig")
+		return nil
+	}
+
+	active := m.activeContext()
+	rows := [][]string{{"", "Context", "Platform", "Operator"}}
+	for _, name := range contexts {
+		marker := ""
+		if name == active {
+			marker = "*"
+		}
+		platform, operator := m.probePlatform(name)
+		rows = append(rows, []string{marker, name, platform, operator})
+	}
+
+	Header("Contexts")
+	DefaultPrinter.Println()
+	Table(rows)
+	if saved := loadKubeContext(); saved != "" {
+		Info(fmt.Sprintf("mcp-runtime uses %q (clear with: mcp-runtime context use --clear)", saved))
+	}
+	return nil
+}
+
+// Use validates and saves the context for subsequent commands; an empty name clears it.
+func (m *ContextManager) Use(name string) error {
+	if name != "" {
+		contexts, err := m.contexts()
+		if err != nil {
+			wrappedErr := wrapWithSentinel(ErrListKubeContextsFailed, err, fmt.Sprintf("failed to list kubeconfig contexts: %v", err))
+			Error("Failed to list kubeconfig contexts")
+			logStructuredError(m.logger, wrappedErr, "Failed to list kubeconfig contexts")
+			return wrappedErr
+		}
+		if !slices.Contains(contexts, name) {
+			err := newWithSentinel(ErrKubeContextNotFound, fmt.Sprintf("context %q not found in kubeconfig (see: mcp-runtime context list)", name))
+			Error("Context not found")
+			logStructuredError(m.logger, err, "Context not found")
+			return err
+		}
+	}
+
+	if err := saveKubeContext(name); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrSaveKubeContextFailed,
+			err,
+			fmt.Sprintf("failed to save context selection: %v", err),
+			map[string]any{"context": name, "component": "context"},
+		)
+		Error("Failed to save context selection")
+		logStructuredError(m.logger, wrappedErr, "Failed to save context selection")
+		return wrappedErr
+	}
+
+	if name == "" {
+		Success("Cleared context selection; using the kubeconfig current-context")
+		return nil
+	}
+	Success(fmt.Sprintf("mcp-runtime now uses context %q", name))
+	return nil
+}
+
+// contexts returns the context names from the kubeconfig.
+func (m *ContextManager) contexts() ([]string, error) {
+	// #nosec G204 -- fixed kubectl command.
+	out, err := m.kubectl.Output([]string{"config", "get-contexts", "-o", "name"})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// activeContext returns the context mcp-runtime commands run against.
+func (m *ContextManager) activeContext() string {
+	if m.kubectl.kubeContext != "" {
+		return m.kubectl.kubeContext
+	}
+	// #nosec G204 -- fixed kubectl command.
+	out, err := m.kubectl.Output([]string{"config", "current-context"})
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// probePlatform reports whether the MCP platform (CRD and operator) is installed in a context.
+func (m *ContextManager) probePlatform(name string) (platform, operator string) {
+	// #nosec G204 -- context name comes from the kubeconfig.
+	if _, err := m.kubectl.Output([]string{"--context", name, "--request-timeout=" + contextProbeTimeout, "get", "crd", "mcpservers.mcpruntime.org", "-o", "name"}); err != nil {
+		// #nosec G204 -- context name comes from the kubeconfig.
+		if _, reachErr := m.kubectl.Output([]string{"--context", name, "--request-timeout=" + contextProbeTimeout, "get", "namespace", "default", "-o", "name"}); reachErr != nil {
+			return Yellow("Unreachable"), "-"
+		}
+		return "Not installed", "-"
+	}
+
+	// #nosec G204 -- context name comes from the kubeconfig.
+	out, err := m.kubectl.Output([]string{"--context", name, "--request-timeout=" + contextProbeTimeout, "get", "deployment", OperatorDeploymentName, "-n", NamespaceMCPRuntime, "-o", "jsonpath={.status.readyReplicas}/{.spec.replicas}"})
+	if err != nil {
+		return Yellow("CRD only"), "-"
+	}
+	replicas := strings.TrimSpace(string(out))
+	if strings.HasPrefix(replicas, "/") || strings.HasPrefix(replicas, "0/") {
+		return Green("Installed"), Yellow(replicas + " ready")
+	}
+	return Green("Installed"), Green(replicas + " ready")
+}
+
+func kubeContextConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".mcp-runtime", "context.yaml"), nil
+}
+
+// loadKubeContext returns the context selected for mcp-runtime, empty if none.
+// MCP_KUBE_CONTEXT takes precedence over the saved selection.
+func loadKubeContext() string {
+	if name := os.Getenv(kubeContextEnv); name != "" {
+		return name
+	}
+	path, err := kubeContextConfigPath()
+	if err != nil {
+		return ""
+	}
+	// #nosec G304 -- path is scoped to the user's config directory.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var cfg kubeContextConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ""
+	}
+	return cfg.Context
+}
+
+// saveKubeContext stores the selected context; an empty name removes the selection.
+func saveKubeContext(name string) error {
+	path, err := kubeContextConfigPath()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(kubeContextConfig{Context: name})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
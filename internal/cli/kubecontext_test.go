@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestKubectlClientWithContext(t *testing.T) {
+	c := &KubectlClient{kubeContext: "prod"}
+	if got := strings.Join(c.withContext([]string{"get", "pods"}), " "); got != "--context prod get pods" {
+		t.Fatalf("unexpected args %q", got)
+	}
+	if got := strings.Join(c.withContext([]string{"--context", "dev", "get", "pods"}), " "); got != "--context dev get pods" {
+		t.Fatalf("explicit context should win, got %q", got)
+	}
+	if got := strings.Join((&KubectlClient{}).withContext([]string{"get", "pods"}), " "); got != "get pods" {
+		t.Fatalf("unexpected args without selection %q", got)
+	}
+}
+
+func TestKubeContextPersistence(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(kubeContextEnv, "")
+
+	if got := loadKubeContext(); got != "" {
+		t.Fatalf("expected no selection, got %q", got)
+	}
+	if err := saveKubeContext("staging"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if got := loadKubeContext(); got != "staging" {
+		t.Fatalf("expected staging, got %q", got)
+	}
+
+	t.Setenv(kubeContextEnv, "prod")
+	if got := loadKubeContext(); got != "prod" {
+		t.Fatalf("expected env override, got %q", got)
+	}
+
+	t.Setenv(kubeContextEnv, "")
+	if err := saveKubeContext(""); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if got := loadKubeContext(); got != "" {
+		t.Fatalf("expected cleared selection, got %q", got)
+	}
+}
+
+func contextTestExecutor() *MockExecutor {
+	return &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			args := strings.Join(spec.Args, " ")
+			switch {
+			case strings.Contains(args, "get-contexts"):
+				return &MockCommand{Args: spec.Args, OutputData: []byte("kind-dev\nprod\noffline\n")}
+			case strings.Contains(args, "current-context"):
+				return &MockCommand{Args: spec.Args, OutputData: []byte("kind-dev\n")}
+			case strings.Contains(args, "--context offline"):
+				return &MockCommand{Args: spec.Args, OutputErr: errors.New("connection refused")}
+			case strings.Contains(args, "--context prod") && strings.Contains(args, "crd"):
+				return &MockCommand{Args: spec.Args, OutputErr: errors.New("not found")}
+			case strings.Contains(args, "deployment"):
+				return &MockCommand{Args: spec.Args, OutputData: []byte("2/2")}
+			}
+			return &MockCommand{Args: spec.Args}
+		},
+	}
+}
+
+func TestContextManager_List(t *testing.T) {
+	mgr := NewContextManager(&KubectlClient{exec: contextTestExecutor()}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(kubeContextEnv, "")
+
+	if err := mgr.List(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"kind-dev", "Installed", "2/2 ready", "prod", "Not installed", "offline", "Unreachable"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestContextManager_Use(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(kubeContextEnv, "")
+	mgr := NewContextManager(&KubectlClient{exec: contextTestExecutor()}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.Use("prod"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := loadKubeContext(); got != "prod" {
+		t.Fatalf("expected saved context prod, got %q", got)
+	}
+
+	if err := mgr.Use("missing"); !errors.Is(err, ErrKubeContextNotFound) {
+		t.Fatalf("expected ErrKubeContextNotFound, got %v", err)
+	}
+
+	cmd := mgr.newContextUseCmd()
+	_ = cmd.Flags().Set("clear", "true")
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if got := loadKubeContext(); got != "" {
+		t.Fatalf("expected cleared selection, got %q", got)
+	}
+}
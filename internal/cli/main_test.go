@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain keeps the run logs of tool output written by tests out of the user's home, and
+// registry provisioning from probing hosts over the network.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "mcp-runtime-logs-")
+	if err != nil {
+		panic(err)
+	}
+	runLogDir = func() (string, error) { return dir, nil }
+	probeRegistry = func(string) error { return nil }
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,319 @@
+package cli
+
+// This file validates MCPServer manifests against the openAPI schema of the MCPServer CRD,
+// which is embedded in the CLI, so "server create --file" and "server apply" report typos,
+// missing fields, bad enum values and malformed quantities with their line before kubectl
+// is run, and without cluster access. The CEL rules of the schema are left to the API server.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"mcp-runtime/config/crd"
+)
+
+// openAPISchema is the subset of a structural openAPI v3 schema the CLI checks.
+type openAPISchema struct {
+	Type                 string                    `yaml:"type"`
+	Format               string                    `yaml:"format"`
+	Properties           map[string]*openAPISchema `yaml:"properties"`
+	AdditionalProperties *openAPISchema            `yaml:"additionalProperties"`
+	Items                *openAPISchema            `yaml:"items"`
+	Required             []string                  `yaml:"required"`
+	Enum                 []string                  `yaml:"enum"`
+	Minimum              *float64                  `yaml:"minimum"`
+	Maximum              *float64                  `yaml:"maximum"`
+	PreserveUnknown      bool                      `yaml:"x-kubernetes-preserve-unknown-fields"`
+}
+
+// quantityFields are the string fields holding Kubernetes resource quantities.
+var quantityFields = []string{
+	"spec.resources.limits.cpu",
+	"spec.resources.limits.memory",
+	"spec.resources.requests.cpu",
+	"spec.resources.requests.memory",
+}
+
+// manifestFieldError is one schema violation in a manifest.
+type manifestFieldError struct {
+	Line    int
+	Column  int
+	Field   string
+	Message string
+}
+
+// format renders the error as file:line:column: field: message.
+func (e manifestFieldError) format(file string) string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", file, e.Line, e.Column, e.Field, e.Message)
+}
+
+// mcpServerSchema parses the schema of the embedded CRD's storage version once.
+var mcpServerSchema = sync.OnceValues(func() (*openAPISchema, error) {
+	var def struct {
+		Spec struct {
+			Versions []struct {
+				Name    string `yaml:"name"`
+				Storage bool   `yaml:"storage"`
+				Schema  struct {
+					OpenAPIV3Schema *openAPISchema `yaml:"openAPIV3Schema"`
+				} `yaml:"schema"`
+			} `yaml:"versions"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(crd.MCPServer, &def); err != nil {
+		return nil, fmt.Errorf("parse embedded MCPServer CRD: %w", err)
+	}
+	for _, version := range def.Spec.Versions {
+		if version.Storage && version.Schema.OpenAPIV3Schema != nil {
+			return version.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return nil, fmt.Errorf("embedded MCPServer CRD has no storage version schema")
+})
+
+// validateManifestSchema checks every MCPServer document of a manifest file against the
+// embedded schema and reports all violations at once.
+func validateManifestSchema(file string, data []byte) error {
+	var all []string
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for index := 1; ; index++ {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("%s: document %d: %w", file, index, err)
+		}
+		var header manifestHeader
+		if len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode || node.Decode(&header) != nil {
+			continue
+		}
+		errs, err := schemaErrors(file, header.Kind, &node)
+		if err != nil {
+			return err
+		}
+		all = append(all, errs...)
+	}
+	if len(all) > 0 {
+		return schemaError(all)
+	}
+	return nil
+}
+
+// schemaErrors returns the schema violations of one decoded document of kind, formatted
+// with file; only MCPServers are checked.
+func schemaErrors(file, kind string, doc *yaml.Node) ([]string, error) {
+	if kind != "MCPServer" {
+		return nil, nil
+	}
+	fieldErrs, err := validateMCPServerDocument(doc.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	formatted := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		formatted = append(formatted, fieldErr.format(file))
+	}
+	return formatted, nil
+}
+
+func schemaError(errs []string) error {
+	return fmt.Errorf("%d schema error(s):\n  %s", len(errs), strings.Join(errs, "\n  "))
+}
+
+// validateMCPServerDocument checks one MCPServer document against the embedded schema.
+func validateMCPServerDocument(doc *yaml.Node) ([]manifestFieldError, error) {
+	schema, err := mcpServerSchema()
+	if err != nil {
+		return nil, err
+	}
+	var errs []manifestFieldError
+	validateSchemaNode(schema, doc, "", &errs)
+	for _, field := range quantityFields {
+		if node := lookupField(doc, field); node != nil && node.Tag == "!!str" {
+			if _, err := resource.ParseQuantity(node.Value); err != nil {
+				errs = append(errs, fieldError(node, field, fmt.Sprintf("%q is not a valid quantity (e.g. 500m, 1, 256Mi)", node.Value)))
+			}
+		}
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	return errs, nil
+}
+
+func validateSchemaNode(schema *openAPISchema, node *yaml.Node, path string, errs *[]manifestFieldError) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	// The API server drops null values, as if the field were not set.
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			*errs = append(*errs, fieldError(node, path, "must be an object"))
+			return
+		}
+		validateObject(schema, node, path, errs)
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			*errs = append(*errs, fieldError(node, path, "must be a list"))
+			return
+		}
+		if schema.Items != nil {
+			for i, item := range node.Content {
+				validateSchemaNode(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case "string", "integer", "number", "boolean":
+		validateScalar(schema, node, path, errs)
+	}
+}
+
+func validateObject(schema *openAPISchema, node *yaml.Node, path string, errs *[]manifestFieldError) {
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		seen[key.Value] = true
+		field := joinFieldPath(path, key.Value)
+		if child, ok := schema.Properties[key.Value]; ok {
+			validateSchemaNode(child, value, field, errs)
+			continue
+		}
+		switch {
+		case schema.AdditionalProperties != nil:
+			validateSchemaNode(schema.AdditionalProperties, value, field, errs)
+		case len(schema.Properties) > 0 && !schema.PreserveUnknown:
+			*errs = append(*errs, fieldError(key, field, "unknown field"+suggestField(key.Value, schema.Properties)))
+		}
+	}
+	for _, name := range schema.Required {
+		if !seen[name] {
+			*errs = append(*errs, fieldError(node, joinFieldPath(path, name), "required field is missing"))
+		}
+	}
+}
+
+func validateScalar(schema *openAPISchema, node *yaml.Node, path string, errs *[]manifestFieldError) {
+	if node.Kind != yaml.ScalarNode {
+		*errs = append(*errs, fieldError(node, path, "must be a "+schema.Type))
+		return
+	}
+	switch schema.Type {
+	case "string":
+		if node.Tag != "!!str" && node.Tag != "!!timestamp" {
+			*errs = append(*errs, fieldError(node, path, fmt.Sprintf("must be a string; quote %s", node.Value)))
+			return
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, node.Value) {
+			*errs = append(*errs, fieldError(node, path, fmt.Sprintf("%q is not one of %s", node.Value, strings.Join(schema.Enum, ", "))))
+		}
+	case "boolean":
+		if node.Tag != "!!bool" {
+			*errs = append(*errs, fieldError(node, path, "must be true or false"))
+		}
+	case "integer", "number":
+		if node.Tag != "!!int" && (schema.Type == "integer" || node.Tag != "!!float") {
+			*errs = append(*errs, fieldError(node, path, "must be "+article(schema.Type)))
+			return
+		}
+		value, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil {
+			*errs = append(*errs, fieldError(node, path, "must be "+article(schema.Type)))
+			return
+		}
+		switch {
+		case schema.Minimum != nil && value < *schema.Minimum:
+			*errs = append(*errs, fieldError(node, path, fmt.Sprintf("must be at least %v", *schema.Minimum)))
+		case schema.Maximum != nil && value > *schema.Maximum:
+			*errs = append(*errs, fieldError(node, path, fmt.Sprintf("must be at most %v", *schema.Maximum)))
+		case schema.Format == "int32" && (value < math.MinInt32 || value > math.MaxInt32):
+			*errs = append(*errs, fieldError(node, path, "does not fit a 32-bit integer"))
+		}
+	}
+}
+
+func article(typ string) string {
+	if typ == "integer" {
+		return "an integer"
+	}
+	return "a " + typ
+}
+
+// suggestField returns a hint naming the known field closest to name, if any is close.
+func suggestField(name string, properties map[string]*openAPISchema) string {
+	best, bestDistance := "", 3
+	for candidate := range properties {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(candidate)); d < bestDistance || d == bestDistance && candidate < best {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// lookupField returns the value node at a dotted path of mapping keys, or nil.
+func lookupField(node *yaml.Node, path string) *yaml.Node {
+	for _, key := range strings.Split(path, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func fieldError(node *yaml.Node, field, message string) manifestFieldError {
+	return manifestFieldError{Line: node.Line, Column: node.Column, Field: field, Message: message}
+}
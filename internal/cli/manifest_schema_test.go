@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const testInvalidServerManifest = `apiVersion: mcpruntime.org/v1alpha1
+kind: MCPServer
+metadata:
+  name: weather
+  labels:
+    team: a
+spec:
+  image: registry.local/weather
+  replica: 2
+  servicePort: "80"
+  canary:
+    weight: 150
+  resources:
+    limits:
+      cpu: half
+      memory: 256Mi
+  sessionAffinity: sticky
+  ingressAnnotations:
+    example.com/timeout: 30
+  mirror: {}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: weather-config
+data:
+  unchecked: 1
+`
+
+func TestValidateManifestSchema(t *testing.T) {
+	err := validateManifestSchema("weather.yaml", []byte(testInvalidServerManifest))
+	if err == nil {
+		t.Fatal("expected schema errors")
+	}
+	want := []string{
+		"7 schema error(s):",
+		`weather.yaml:9:3: spec.replica: unknown field (did you mean "replicas"?)`,
+		"weather.yaml:10:16: spec.servicePort: must be an integer",
+		"weather.yaml:12:13: spec.canary.weight: must be at most 100",
+		`weather.yaml:15:12: spec.resources.limits.cpu: "half" is not a valid quantity (e.g. 500m, 1, 256Mi)`,
+		`weather.yaml:17:20: spec.sessionAffinity: "sticky" is not one of clientIP, cookie`,
+		"weather.yaml:19:26: spec.ingressAnnotations.example.com/timeout: must be a string; quote 30",
+		"weather.yaml:20:11: spec.mirror.targetServer: required field is missing",
+	}
+	for _, line := range want {
+		if !strings.Contains(err.Error(), line) {
+			t.Errorf("errors miss %q:\n%v", line, err)
+		}
+	}
+	if strings.Contains(err.Error(), "unchecked") {
+		t.Errorf("only MCPServers are checked:\n%v", err)
+	}
+}
+
+func TestValidateManifestSchemaAcceptsValidServer(t *testing.T) {
+	manifest := `apiVersion: mcpruntime.org/v1alpha1
+kind: MCPServer
+metadata:
+  name: weather
+spec:
+  image: registry.local/weather
+  imageTag: "1.2"
+  replicas: 2
+  tlsOnly: true
+  envVars:
+    - name: LOG_LEVEL
+      value: debug
+  resources:
+    requests:
+      cpu: 500m
+      memory: 128Mi
+  registryOverride: ~
+`
+	if err := validateManifestSchema("weather.yaml", []byte(manifest)); err != nil {
+		t.Fatalf("validateManifestSchema() error: %v", err)
+	}
+}
+
+func TestCreateServerFromFileValidatesBeforeKubectl(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	path := writeApplyFile(t, t.TempDir(), "weather.yaml", "apiVersion: mcpruntime.org/v1alpha1\nkind: MCPServer\nmetadata:\n  name: weather\nspec:\n  replicas: 1\n")
+	mock := &MockExecutor{}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	err := mgr.CreateServerFromFile(path)
+	if !errors.Is(err, ErrInvalidManifest) || !strings.Contains(err.Error(), "spec.image: required field is missing") {
+		t.Fatalf("expected a missing spec.image error, got %v", err)
+	}
+	if len(mock.Commands) != 0 {
+		t.Fatalf("expected no kubectl call, got %v", mock.Commands)
+	}
+}
@@ -0,0 +1,202 @@
+package cli
+
+// This file implements the --all-contexts fan-out of "server list" and "status".
+// Every kubeconfig context where the MCPServer CRD is installed is queried concurrently and the
+// results are merged into one table with a CONTEXT column, for teams running dev/stage/prod clusters.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// forKubeContext returns a copy of the client bound to the named kubeconfig context.
+func (c *KubectlClient) forKubeContext(name string) *KubectlClient {
+	bound := *c
+	bound.kubeContext = name
+	return &bound
+}
+
+// fanOutContexts calls fn for each context concurrently and returns the results in context order.
+func fanOutContexts[T any](contexts []string, fn func(name string) T) []T {
+	results := make([]T, len(contexts))
+	var wg sync.WaitGroup
+	for i, name := range contexts {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i] = fn(name)
+		}(i, name)
+	}
+	wg.Wait()
+	return results
+}
+
+// platformContexts returns the kubeconfig contexts where the MCPServer CRD is installed.
+func platformContexts(kubectl *KubectlClient, logger *zap.Logger) ([]string, error) {
+	contexts, err := NewContextManager(kubectl, logger).contexts()
+	if err != nil {
+		return nil, err
+	}
+	installed := fanOutContexts(contexts, func(name string) bool {
+		// #nosec G204 -- context name comes from the kubeconfig.
+		_, err := kubectl.forKubeContext(name).Output([]string{"--request-timeout=" + contextProbeTimeout, "get", "crd", MCPServerCRDName, "-o", "name"})
+		return err == nil
+	})
+	var names []string
+	for i, name := range contexts {
+		if installed[i] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// listPlatformContexts lists platform contexts, printing the error or a warning when there are none.
+func listPlatformContexts(kubectl *KubectlClient, logger *zap.Logger) ([]string, error) {
+	contexts, err := platformContexts(kubectl, logger)
+	if err != nil {
+		wrappedErr := wrapWithSentinel(ErrListKubeContextsFailed, err, fmt.Sprintf("failed to list kubeconfig contexts: %v", err))
+		Error("Failed to list kubeconfig contexts")
+		logStructuredError(logger, wrappedErr, "Failed to list kubeconfig contexts")
+		return nil, wrappedErr
+	}
+	if len(contexts) == 0 {
+		Warn("No kubeconfig context has the MCP platform installed")
+	}
+	return contexts, nil
+}
+
+// contextServers is the server list of one context.
+type contextServers struct {
+	rows [][]string
+	err  error
+}
+
+// ListServersAllContexts lists the servers in namespace across all platform contexts.
+func (m *ServerManager) ListServersAllContexts(namespace string) error {
+	namespace, err := validateManifestValue("namespace", namespace)
+	if err != nil {
+		return err
+	}
+	contexts, err := listPlatformContexts(m.kubectl, m.logger)
+	if err != nil || len(contexts) == 0 {
+		return err
+	}
+
+	results := fanOutContexts(contexts, func(name string) contextServers {
+		// #nosec G204 -- namespace validated above; context name comes from the kubeconfig.
+		out, err := m.kubectl.forKubeContext(name).Output([]string{
+			"get", "mcpserver", "-n", namespace, "--no-headers",
+			"-o", "custom-columns=NAME:.metadata.name,PHASE:.status.phase,REPLICAS:.spec.replicas,URL:.status.url",
+		})
+		if err != nil {
+			return contextServers{err: err}
+		}
+		var rows [][]string
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if fields := strings.Fields(line); len(fields) > 0 {
+				rows = append(rows, append([]string{name}, fields...))
+			}
+		}
+		return contextServers{rows: rows}
+	})
+
+	table := [][]string{{"CONTEXT", "NAME", "PHASE", "REPLICAS", "URL"}}
+	var failed int
+	for i, result := range results {
+		if result.err != nil {
+			failed++
+			Warn(fmt.Sprintf("Context %s: failed to list servers: %v", contexts[i], result.err))
+			continue
+		}
+		table = append(table, result.rows...)
+	}
+	if len(table) == 1 {
+		Info(fmt.Sprintf("No MCP servers in namespace %s in %d context(s)", namespace, len(contexts)-failed))
+	} else {
+		Table(table)
+	}
+	if failed == len(contexts) {
+		wrappedErr := newWithSentinel(ErrListServersFailed, fmt.Sprintf("failed to list servers in all %d context(s)", failed))
+		Error("Failed to list servers")
+		logStructuredError(m.logger, wrappedErr, "Failed to list servers")
+		return wrappedErr
+	}
+	return nil
+}
+
+// contextStatus is the platform health of one context.
+type contextStatus struct {
+	Registry string
+	Operator string
+	Servers  string
+}
+
+// probeContextStatus reads registry, operator and server counts from one context.
+func probeContextStatus(kubectl *KubectlClient) contextStatus {
+	status := contextStatus{Registry: Red("ERROR"), Operator: Red("ERROR"), Servers: "-"}
+
+	if replicas, err := deploymentReplicas(kubectl, RegistryDeploymentName, NamespaceRegistry); err == nil {
+		status.Registry = replicaStatus(replicas)
+	}
+	if replicas, err := deploymentReplicas(kubectl, OperatorDeploymentName, NamespaceMCPRuntime); err == nil {
+		status.Operator = replicaStatus(replicas)
+	}
+	// #nosec G204 -- fixed kubectl command.
+	if out, err := kubectl.Output([]string{"get", "mcpserver", "--all-namespaces", "-o", "name"}); err == nil {
+		status.Servers = strconv.Itoa(len(strings.Fields(string(out))))
+	}
+	return status
+}
+
+// deploymentReplicas returns the "ready/desired" replica count of a deployment.
+func deploymentReplicas(kubectl *KubectlClient, name, namespace string) (string, error) {
+	// #nosec G204 -- fixed kubectl command with hardcoded deployment names.
+	out, err := kubectl.Output([]string{"get", "deployment", name, "-n", namespace, "-o", "jsonpath={.status.readyReplicas}/{.spec.replicas}"})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// replicasReady reports whether a "ready/desired" replica count has a ready replica.
+func replicasReady(replicas string) bool {
+	return replicas != "" && !strings.HasPrefix(replicas, "/") && !strings.HasPrefix(replicas, "0/")
+}
+
+// replicaStatus colors a "ready/desired" replica count.
+func replicaStatus(replicas string) string {
+	if !replicasReady(replicas) {
+		return Yellow("PENDING " + replicas)
+	}
+	return Green("OK " + replicas)
+}
+
+// showPlatformStatusAllContexts prints a status row per platform context.
+func showPlatformStatusAllContexts(logger *zap.Logger) error {
+	return showPlatformStatusAllContextsWithKubectl(kubectlClient, logger)
+}
+
+func showPlatformStatusAllContextsWithKubectl(kubectl *KubectlClient, logger *zap.Logger) error {
+	Header("MCP Platform Status")
+	DefaultPrinter.Println()
+
+	contexts, err := listPlatformContexts(kubectl, logger)
+	if err != nil || len(contexts) == 0 {
+		return err
+	}
+	statuses := fanOutContexts(contexts, func(name string) contextStatus {
+		return probeContextStatus(kubectl.forKubeContext(name))
+	})
+
+	table := [][]string{{"CONTEXT", "REGISTRY", "OPERATOR", "SERVERS"}}
+	for i, s := range statuses {
+		table = append(table, []string{contexts[i], s.Registry, s.Operator, s.Servers})
+	}
+	TableBoxed(table)
+	return nil
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// multiClusterExecutor serves three contexts: dev and prod with the platform, lab without.
+func multiClusterExecutor(prodListErr error) *MockExecutor {
+	return &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case args == "config get-contexts -o name":
+			cmd.OutputData = []byte("dev\nlab\nprod\n")
+		case strings.Contains(args, "get crd"):
+			if strings.HasPrefix(args, "--context lab ") {
+				cmd.OutputErr = errors.New("not found")
+			}
+		case strings.HasPrefix(args, "--context dev get mcpserver -n mcp-servers"):
+			cmd.OutputData = []byte("weather   Ready   1   http://dev.example.com/weather\n")
+		case strings.HasPrefix(args, "--context prod get mcpserver -n mcp-servers"):
+			cmd.OutputData = []byte("weather   Ready   3   http://prod.example.com/weather\nsearch   Pending   1   <none>\n")
+			cmd.OutputErr = prodListErr
+		case strings.Contains(args, "get deployment"):
+			cmd.OutputData = []byte("1/1")
+		case strings.Contains(args, "get mcpserver --all-namespaces -o name"):
+			cmd.OutputData = []byte("mcpserver.mcpruntime.org/a\nmcpserver.mcpruntime.org/b\n")
+		}
+		return cmd
+	}}
+}
+
+func TestListServersAllContexts(t *testing.T) {
+	t.Run("merges contexts with the platform", func(t *testing.T) {
+		mgr := NewServerManager(&KubectlClient{exec: multiClusterExecutor(nil)}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ListServersAllContexts(NamespaceMCPServers); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{"CONTEXT", "http://dev.example.com/weather", "http://prod.example.com/weather", "search"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("expected %q in output:\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "lab") {
+			t.Fatalf("expected context without the platform to be skipped:\n%s", out)
+		}
+	})
+
+	t.Run("reports unreachable contexts and keeps the rest", func(t *testing.T) {
+		mgr := NewServerManager(&KubectlClient{exec: multiClusterExecutor(errors.New("connection refused"))}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ListServersAllContexts(NamespaceMCPServers); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, "Context prod: failed to list servers") || !strings.Contains(out, "dev.example.com") {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+	})
+}
+
+func TestShowPlatformStatusAllContexts(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := showPlatformStatusAllContextsWithKubectl(&KubectlClient{exec: multiClusterExecutor(nil)}, zap.NewNop()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"CONTEXT", "dev", "prod", "OK 1/1"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFanOutContextsKeepsOrder(t *testing.T) {
+	got := fanOutContexts([]string{"a", "b", "c"}, strings.ToUpper)
+	if strings.Join(got, "") != "ABC" {
+		t.Fatalf("fanOutContexts = %v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+// This file implements --notify for long-running commands (setup, smoke-test, pipeline run
+// and pipeline deploy).
+// When the command finishes it posts a Slack-compatible {"text": ...} message to the webhook
+// with the outcome, the duration and, on failure, the step that was running.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// notifyTimeout bounds the webhook request so a slow endpoint cannot hang the command.
+const notifyTimeout = 10 * time.Second
+
+// addNotifyFlag registers --notify on cmd.
+func addNotifyFlag(cmd *cobra.Command, webhookURL *string) {
+	cmd.Flags().StringVar(webhookURL, "notify", "", "Webhook URL (Slack-compatible) to post a summary to when the command finishes")
+}
+
+// validateNotifyURL accepts an empty URL (notifications off) or an absolute http(s) URL.
+func validateNotifyURL(webhookURL string) error {
+	if webhookURL == "" {
+		return nil
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return newWithSentinel(ErrInvalidNotifyURL, "--notify must be an http(s) webhook URL")
+	}
+	return nil
+}
+
+// runWithNotify runs fn and, when webhookURL is set, posts a summary of its outcome.
+// A failed notification is only a warning; fn's error is returned unchanged.
+func runWithNotify(logger *zap.Logger, webhookURL, operation string, fn func() error) error {
+	if webhookURL == "" {
+		return fn()
+	}
+	start := time.Now()
+	err := fn()
+	text := notifySummary(operation, time.Since(start), DefaultPrinter.LastStep(), err)
+	if notifyErr := postNotification(&http.Client{Timeout: notifyTimeout}, webhookURL, text); notifyErr != nil {
+		Warn(fmt.Sprintf("Could not send notification: %v", notifyErr))
+		logger.Warn("Failed to send notification", zap.String("operation", operation), zap.Error(notifyErr))
+	}
+	return err
+}
+
+// notifySummary formats the message posted for an operation.
+func notifySummary(operation string, duration time.Duration, step string, err error) string {
+	duration = duration.Round(time.Second)
+	if err == nil {
+		return fmt.Sprintf("mcp-runtime %s succeeded in %s", operation, duration)
+	}
+	if step != "" {
+		return fmt.Sprintf("mcp-runtime %s failed after %s at %q: %v", operation, duration, step, err)
+	}
+	return fmt.Sprintf("mcp-runtime %s failed after %s: %v", operation, duration, err)
+}
+
+// postNotification sends text as a Slack-compatible webhook payload.
+func postNotification(client *http.Client, webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	// Not tied to the command context: an interrupted run should still be reported.
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestValidateNotifyURL(t *testing.T) {
+	for _, valid := range []string{"", "https://hooks.slack.com/services/T/B/X", "http://localhost:8080/hook"} {
+		if err := validateNotifyURL(valid); err != nil {
+			t.Fatalf("validateNotifyURL(%q) unexpected error: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"hooks.slack.com/services", "ftp://example.com/hook", "https://"} {
+		if err := validateNotifyURL(invalid); !errors.Is(err, ErrInvalidNotifyURL) {
+			t.Fatalf("validateNotifyURL(%q) expected ErrInvalidNotifyURL, got %v", invalid, err)
+		}
+	}
+}
+
+func TestNotifySummary(t *testing.T) {
+	if got := notifySummary("setup", 21*time.Minute+400*time.Millisecond, "", nil); got != "mcp-runtime setup succeeded in 21m0s" {
+		t.Fatalf("unexpected success summary %q", got)
+	}
+	got := notifySummary("setup", 90*time.Second, "Step 4: Configure registry", errors.New("registry not ready"))
+	if got != `mcp-runtime setup failed after 1m30s at "Step 4: Configure registry": registry not ready` {
+		t.Fatalf("unexpected failure summary %q", got)
+	}
+}
+
+func TestRunWithNotify(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	var payload map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	t.Run("posts the failed step", func(t *testing.T) {
+		wantErr := errors.New("timed out")
+		err := runWithNotify(zap.NewNop(), srv.URL, "smoke-test", func() error {
+			Step("Step 3: Wait for the server to become Ready")
+			return wantErr
+		})
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("expected the command error, got %v", err)
+		}
+		if !strings.Contains(payload["text"], "smoke-test failed") || !strings.Contains(payload["text"], "Step 3: Wait for the server to become Ready") {
+			t.Fatalf("unexpected payload %v", payload)
+		}
+	})
+
+	t.Run("warns when the webhook fails", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		if err := runWithNotify(zap.NewNop(), failing.URL, "setup", func() error { return nil }); err != nil {
+			t.Fatalf("notification failures must not fail the command: %v", err)
+		}
+		if !strings.Contains(buf.String(), "Could not send notification") {
+			t.Fatalf("expected a warning, got:\n%s", buf.String())
+		}
+	})
+}
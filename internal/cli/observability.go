@@ -0,0 +1,251 @@
+package cli
+
+// This file implements the "observability" command. "observability export-dashboards" hands
+// the Grafana dashboards and Prometheus alert rules bundled in config/observability to
+// clusters that run their own monitoring: it writes them to a directory, or applies them
+// next to the stack installed by "setup --with-observability".
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// grafanaDashboardsConfigMap is the ConfigMap Grafana provisions dashboards from.
+	grafanaDashboardsConfigMap = "grafana-dashboards"
+
+	// prometheusRuleCRDName is the prometheus-operator CRD the alert rules are written for.
+	prometheusRuleCRDName = "prometheusrules.monitoring.coreos.com"
+)
+
+// ObservabilityManager exports the bundled dashboards and alert rules with injected dependencies.
+type ObservabilityManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewObservabilityManager creates an ObservabilityManager with the given dependencies.
+func NewObservabilityManager(kubectl *KubectlClient, logger *zap.Logger) *ObservabilityManager {
+	return &ObservabilityManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultObservabilityManager returns an ObservabilityManager using default clients.
+func DefaultObservabilityManager(logger *zap.Logger) *ObservabilityManager {
+	return NewObservabilityManager(kubectlClient, logger)
+}
+
+// NewObservabilityCmd returns the observability subcommand.
+func NewObservabilityCmd(logger *zap.Logger) *cobra.Command {
+	return NewObservabilityCmdWithManager(DefaultObservabilityManager(logger))
+}
+
+// NewObservabilityCmdWithManager returns the observability subcommand using the provided manager.
+func NewObservabilityCmdWithManager(mgr *ObservabilityManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "observability",
+		Short: "Manage MCP runtime dashboards and alerts",
+		Long:  "Export the Grafana dashboards and Prometheus alert rules bundled with MCP runtime",
+	}
+
+	cmd.AddCommand(mgr.newExportDashboardsCmd())
+
+	return cmd
+}
+
+func (m *ObservabilityManager) newExportDashboardsCmd() *cobra.Command {
+	var output string
+	var apply bool
+
+	cmd := &cobra.Command{
+		Use:   "export-dashboards",
+		Short: "Export Grafana dashboards and alert rules",
+		Long: `Write the bundled Grafana dashboards (operator metrics, per-server health) and the
+PrometheusRule alerts (MCPServerNotReady, RegistryPVCAlmostFull, OperatorDown) to a directory,
+or with --apply install them in the mcp-monitoring namespace. Alert rules are only applied
+when the PrometheusRule CRD from prometheus-operator is installed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if apply {
+				return m.ApplyDashboards()
+			}
+			return m.ExportDashboards(output)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "observability", "Directory to write dashboards and alert rules to")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Apply to the cluster instead of writing files")
+	cmd.MarkFlagsMutuallyExclusive("output", "apply")
+
+	return cmd
+}
+
+// observabilityBundle is the set of files bundled in config/observability.
+type observabilityBundle struct {
+	// dashboards maps file names to Grafana dashboard JSON.
+	dashboards map[string][]byte
+	// alertsPath is the PrometheusRule manifest and alerts its contents.
+	alertsPath string
+	alerts     []byte
+}
+
+// loadObservabilityBundle reads the dashboards and alert rules under source.
+func loadObservabilityBundle(source string) (observabilityBundle, error) {
+	bundle := observabilityBundle{
+		dashboards: map[string][]byte{},
+		alertsPath: filepath.Join(source, "alerts", "mcp-runtime-rules.yaml"),
+	}
+	paths, err := filepath.Glob(filepath.Join(source, "dashboards", "*.json"))
+	if err != nil {
+		return bundle, err
+	}
+	if len(paths) == 0 {
+		return bundle, fmt.Errorf("no dashboards found in %s", filepath.Join(source, "dashboards"))
+	}
+	for _, path := range paths {
+		// #nosec G304 -- path comes from the bundled observability directory.
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return bundle, err
+		}
+		bundle.dashboards[filepath.Base(path)] = data
+	}
+	// #nosec G304 -- path comes from the bundled observability directory.
+	bundle.alerts, err = os.ReadFile(bundle.alertsPath)
+	return bundle, err
+}
+
+// dashboardNames returns the dashboard file names in order.
+func (b observabilityBundle) dashboardNames() []string {
+	names := make([]string, 0, len(b.dashboards))
+	for name := range b.dashboards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExportDashboards writes the bundled dashboards to output/dashboards and the alert
+// rules to output/alerts, overwriting earlier exports.
+func (m *ObservabilityManager) ExportDashboards(output string) error {
+	return m.exportDashboards(observabilityManifest, output)
+}
+
+func (m *ObservabilityManager) exportDashboards(source, output string) error {
+	output, err := validateManifestValue("output", output)
+	if err != nil {
+		return err
+	}
+	bundle, err := loadObservabilityBundle(source)
+	if err != nil {
+		return m.observabilityError(ErrExportDashboardsFailed, err, source, "Failed to read bundled dashboards")
+	}
+
+	m.logger.Info("Exporting dashboards", zap.String("output", output))
+	files := map[string][]byte{}
+	for name, data := range bundle.dashboards {
+		files[filepath.Join("dashboards", name)] = data
+	}
+	files[filepath.Join("alerts", filepath.Base(bundle.alertsPath))] = bundle.alerts
+
+	rels := make([]string, 0, len(files))
+	for rel := range files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+	for _, rel := range rels {
+		path := filepath.Join(output, rel)
+		if err := writeScaffoldFile(path, files[rel]); err != nil {
+			return m.observabilityError(ErrExportDashboardsFailed, err, path, "Failed to write dashboards")
+		}
+		Info(fmt.Sprintf("Wrote %s", path))
+	}
+	Success(fmt.Sprintf("Exported %d dashboards and alert rules to %s", len(bundle.dashboards), output))
+	return nil
+}
+
+// ApplyDashboards installs the bundled dashboards in Grafana's dashboard ConfigMap and,
+// when prometheus-operator is installed, the alert rules as a PrometheusRule.
+func (m *ObservabilityManager) ApplyDashboards() error {
+	return m.applyDashboards(observabilityManifest)
+}
+
+func (m *ObservabilityManager) applyDashboards(source string) error {
+	bundle, err := loadObservabilityBundle(source)
+	if err != nil {
+		return m.observabilityError(ErrExportDashboardsFailed, err, source, "Failed to read bundled dashboards")
+	}
+	manifest, err := renderDashboardsConfigMap(bundle)
+	if err != nil {
+		return m.observabilityError(ErrApplyDashboardsFailed, err, grafanaDashboardsConfigMap, "Failed to apply dashboards")
+	}
+
+	m.logger.Info("Applying dashboards", zap.String("namespace", NamespaceMonitoring))
+	// #nosec G204 -- fixed kubectl command, manifest generated from bundled files via stdin.
+	cmd, err := m.kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return m.observabilityError(ErrApplyDashboardsFailed, err, grafanaDashboardsConfigMap, "Failed to apply dashboards")
+	}
+	cmd.SetStdin(strings.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	if err := cmd.Run(); err != nil {
+		return m.observabilityError(ErrApplyDashboardsFailed, err, grafanaDashboardsConfigMap, "Failed to apply dashboards")
+	}
+	Success(fmt.Sprintf("Applied %d dashboards to %s/%s", len(bundle.dashboards), NamespaceMonitoring, grafanaDashboardsConfigMap))
+
+	// #nosec G204 -- fixed kubectl command with constant CRD name.
+	if err := m.kubectl.Run([]string{"get", "crd", prometheusRuleCRDName}); err != nil {
+		Warn(fmt.Sprintf("%s not installed; skipping alert rules (export them with --output instead)", prometheusRuleCRDName))
+		return nil
+	}
+	// #nosec G204 -- fixed path of the bundled alert rules.
+	if err := m.kubectl.RunWithOutput([]string{"apply", "-f", bundle.alertsPath}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return m.observabilityError(ErrApplyDashboardsFailed, err, bundle.alertsPath, "Failed to apply alert rules")
+	}
+	Success("Applied alert rules")
+	return nil
+}
+
+// renderDashboardsConfigMap renders the ConfigMap mounted by the bundled Grafana, holding
+// every dashboard keyed by file name.
+func renderDashboardsConfigMap(bundle observabilityBundle) (string, error) {
+	data := map[string]string{}
+	for _, name := range bundle.dashboardNames() {
+		data[name] = string(bundle.dashboards[name])
+	}
+	out, err := yaml.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      grafanaDashboardsConfigMap,
+			"namespace": NamespaceMonitoring,
+		},
+		"data": data,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (m *ObservabilityManager) observabilityError(base, err error, target, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		base,
+		err,
+		fmt.Sprintf("%s: %v", strings.ToLower(msg), err),
+		map[string]any{"target": target, "component": "observability"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
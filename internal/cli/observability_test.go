@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const testObservabilitySource = "../../config/observability"
+
+func TestExportDashboards(t *testing.T) {
+	mgr := NewObservabilityManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+	output := t.TempDir()
+
+	if err := mgr.exportDashboards(testObservabilitySource, output); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	for _, name := range []string{"mcp-runtime.json", "mcp-runtime-servers.json"} {
+		data, err := os.ReadFile(filepath.Join(output, "dashboards", name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		var dashboard struct {
+			UID string `json:"uid"`
+		}
+		if err := json.Unmarshal(data, &dashboard); err != nil || dashboard.UID == "" {
+			t.Fatalf("%s is not a dashboard: %v", name, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(output, "alerts", "mcp-runtime-rules.yaml"))
+	if err != nil {
+		t.Fatalf("read alerts: %v", err)
+	}
+	var rule struct {
+		Kind string `yaml:"kind"`
+		Spec struct {
+			Groups []struct {
+				Rules []struct {
+					Alert string `yaml:"alert"`
+				} `yaml:"rules"`
+			} `yaml:"groups"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &rule); err != nil {
+		t.Fatalf("parse alerts: %v", err)
+	}
+	if rule.Kind != "PrometheusRule" || len(rule.Spec.Groups) != 1 {
+		t.Fatalf("unexpected alert manifest: %+v", rule)
+	}
+	var alerts []string
+	for _, r := range rule.Spec.Groups[0].Rules {
+		alerts = append(alerts, r.Alert)
+	}
+	if got := strings.Join(alerts, ","); got != "MCPServerNotReady,RegistryPVCAlmostFull,OperatorDown" {
+		t.Fatalf("alerts = %s", got)
+	}
+}
+
+func TestExportDashboardsMissingSource(t *testing.T) {
+	mgr := NewObservabilityManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	err := mgr.exportDashboards(t.TempDir(), t.TempDir())
+	if !errors.Is(err, ErrExportDashboardsFailed) {
+		t.Fatalf("expected ErrExportDashboardsFailed, got %v", err)
+	}
+}
+
+func TestApplyDashboards(t *testing.T) {
+	run := func(t *testing.T, crdErr error) (string, []string) {
+		var applied string
+		var commands []string
+		mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			args := strings.Join(spec.Args, " ")
+			commands = append(commands, args)
+			switch {
+			case args == "apply -f -":
+				cmd.RunFunc = func() error {
+					data, _ := io.ReadAll(cmd.StdinR)
+					applied = string(data)
+					return nil
+				}
+			case args == "get crd "+prometheusRuleCRDName:
+				cmd.RunFunc = func() error { return crdErr }
+			}
+			return cmd
+		}}
+		mgr := NewObservabilityManager(&KubectlClient{exec: mock}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		if err := mgr.applyDashboards(testObservabilitySource); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+		return applied, commands
+	}
+
+	t.Run("applies dashboards and alert rules", func(t *testing.T) {
+		applied, commands := run(t, nil)
+		var cm struct {
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+			Data map[string]string `yaml:"data"`
+		}
+		if err := yaml.Unmarshal([]byte(applied), &cm); err != nil {
+			t.Fatalf("parse configmap: %v", err)
+		}
+		if cm.Metadata.Name != grafanaDashboardsConfigMap || cm.Metadata.Namespace != NamespaceMonitoring {
+			t.Fatalf("configmap targets %s/%s", cm.Metadata.Namespace, cm.Metadata.Name)
+		}
+		if _, ok := cm.Data["mcp-runtime-servers.json"]; !ok {
+			t.Fatalf("per-server dashboard missing from %v", cm.Data)
+		}
+		last := commands[len(commands)-1]
+		if last != "apply -f "+filepath.Join(testObservabilitySource, "alerts", "mcp-runtime-rules.yaml") {
+			t.Fatalf("last command = %q", last)
+		}
+	})
+
+	t.Run("skips alert rules without prometheus-operator", func(t *testing.T) {
+		_, commands := run(t, errors.New("not found"))
+		if last := commands[len(commands)-1]; last != "get crd "+prometheusRuleCRDName {
+			t.Fatalf("last command = %q", last)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package cli
+
+// This file implements the "operator" command, which toggles operator-wide maintenance mode.
+// While paused, the operator keeps reporting MCPServer status but stops changing their
+// Deployments, Services and Ingresses, so cluster upgrades and migrations don't race it.
+// The switch is the mcp-runtime-maintenance ConfigMap in the operator namespace.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Keys of the maintenance ConfigMap, shared with the operator.
+const (
+	maintenancePausedKey = "paused"
+	maintenanceReasonKey = "reason"
+)
+
+// OperatorManager manages operator maintenance mode with injected dependencies.
+type OperatorManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewOperatorManager creates an OperatorManager with the given dependencies.
+func NewOperatorManager(kubectl *KubectlClient, logger *zap.Logger) *OperatorManager {
+	return &OperatorManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultOperatorManager returns an OperatorManager using default clients.
+func DefaultOperatorManager(logger *zap.Logger) *OperatorManager {
+	return NewOperatorManager(kubectlClient, logger)
+}
+
+// NewOperatorCmd returns the operator subcommand.
+func NewOperatorCmd(logger *zap.Logger) *cobra.Command {
+	return NewOperatorCmdWithManager(DefaultOperatorManager(logger))
+}
+
+// NewOperatorCmdWithManager returns the operator subcommand using the provided manager.
+func NewOperatorCmdWithManager(mgr *OperatorManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operator",
+		Short: "Control the MCP runtime operator",
+		Long:  "Pause and resume the operator's changes to MCPServer resources for maintenance windows, and profile the operator",
+	}
+
+	cmd.AddCommand(mgr.newOperatorPauseCmd())
+	cmd.AddCommand(mgr.newOperatorResumeCmd())
+	cmd.AddCommand(mgr.newOperatorStatusCmd())
+	cmd.AddCommand(mgr.newOperatorProfileCmd())
+
+	return cmd
+}
+
+func (m *OperatorManager) newOperatorPauseCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "pause",
+		Short: "Enter maintenance mode",
+		Long: `Stop the operator from creating, updating or deleting MCPServer child resources.
+MCPServer status keeps being reported, with a Paused condition. Running servers are not affected.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Pause(reason)
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Note shown in MCPServer status while paused")
+
+	return cmd
+}
+
+func (m *OperatorManager) newOperatorResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume",
+		Short: "Leave maintenance mode",
+		Long:  "Let the operator apply MCPServer changes again; every MCPServer is reconciled right away",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Resume()
+		},
+	}
+}
+
+func (m *OperatorManager) newOperatorStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether maintenance mode is on",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Status()
+		},
+	}
+}
+
+// Pause turns maintenance mode on.
+func (m *OperatorManager) Pause(reason string) error {
+	if err := m.setMaintenance(true, reason); err != nil {
+		return m.operatorError(ErrSetMaintenanceModeFailed, err, "Failed to pause the operator")
+	}
+	Success("Operator paused: MCPServer changes are not applied until \"mcp-runtime operator resume\"")
+	return nil
+}
+
+// Resume turns maintenance mode off.
+func (m *OperatorManager) Resume() error {
+	if err := m.setMaintenance(false, ""); err != nil {
+		return m.operatorError(ErrSetMaintenanceModeFailed, err, "Failed to resume the operator")
+	}
+	Success("Operator resumed: pending MCPServer changes are being applied")
+	return nil
+}
+
+// Status prints the maintenance mode state.
+func (m *OperatorManager) Status() error {
+	paused, reason, err := m.maintenance()
+	if err != nil {
+		return m.operatorError(ErrGetMaintenanceModeFailed, err, "Failed to read maintenance mode")
+	}
+	state := "off"
+	if paused {
+		state = "on (paused)"
+	}
+	if reason == "" {
+		reason = "-"
+	}
+	TableBoxed([][]string{
+		{"Maintenance mode", "Reason"},
+		{state, reason},
+	})
+	return nil
+}
+
+// maintenanceConfigMap renders the maintenance ConfigMap.
+func maintenanceConfigMap(paused bool, reason string) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": OperatorMaintenanceConfigMapName, "namespace": NamespaceMCPRuntime},
+		"data": map[string]string{
+			maintenancePausedKey: fmt.Sprint(paused),
+			maintenanceReasonKey: reason,
+		},
+	})
+}
+
+// setMaintenance applies the maintenance ConfigMap.
+func (m *OperatorManager) setMaintenance(paused bool, reason string) error {
+	manifest, err := maintenanceConfigMap(paused, reason)
+	if err != nil {
+		return err
+	}
+	// #nosec G204 -- fixed kubectl command; manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(bytes.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	return cmd.Run()
+}
+
+// maintenance reads the maintenance ConfigMap; a missing ConfigMap means not paused.
+func (m *OperatorManager) maintenance() (bool, string, error) {
+	// #nosec G204 -- fixed ConfigMap name and namespace.
+	cmd, err := m.kubectl.CommandArgs([]string{
+		"get", "configmap", OperatorMaintenanceConfigMapName, "-n", NamespaceMCPRuntime,
+		"--ignore-not-found", "-o", "json",
+	})
+	if err != nil {
+		return false, "", err
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return false, "", err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return false, "", nil
+	}
+	var cm struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(out, &cm); err != nil {
+		return false, "", err
+	}
+	return cm.Data[maintenancePausedKey] == "true", cm.Data[maintenanceReasonKey], nil
+}
+
+// operatorError wraps err with sentinel, prints msg and logs the structured error.
+func (m *OperatorManager) operatorError(sentinel, err error, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		sentinel,
+		err,
+		fmt.Sprintf("%s: %v", strings.ToLower(msg), err),
+		map[string]any{"configMap": OperatorMaintenanceConfigMapName, "namespace": NamespaceMCPRuntime, "component": "operator"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
@@ -0,0 +1,247 @@
+package cli
+
+// This file implements "operator profile", which fetches a pprof profile from the operator
+// to debug memory growth and CPU use in large installations. The operator serves
+// net/http/pprof on loopback (--pprof-bind-address in manager.yaml), so the profile is
+// fetched through a kubectl port-forward to the leader pod, the one doing the reconciling.
+//
+// Example usage:
+//   mcp-runtime operator profile --type heap
+//   mcp-runtime operator profile --type cpu --duration 60s -o cpu.pprof
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// operatorPprofPort is the port of the operator's pprof endpoint in manager.yaml.
+	operatorPprofPort = 6060
+
+	profileTypeCPU  = "cpu"
+	profileTypeHeap = "heap"
+)
+
+// profileTypes are the accepted --type values; all but cpu are snapshots.
+var profileTypes = []string{profileTypeHeap, profileTypeCPU, "goroutine", "allocs", "block", "mutex"}
+
+var (
+	// profileForwardTimeout bounds how long the port-forward may take to come up; a test seam.
+	profileForwardTimeout = 30 * time.Second
+	// profileLocalPort picks the local end of the port-forward; a test seam.
+	profileLocalPort = freeLocalPort
+)
+
+// OperatorProfileOptions selects the profile to fetch.
+type OperatorProfileOptions struct {
+	Type     string
+	Duration time.Duration
+	Output   string
+	Pod      string
+	Port     int
+}
+
+func (m *OperatorManager) newOperatorProfileCmd() *cobra.Command {
+	opts := OperatorProfileOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Fetch a pprof profile from the operator",
+		Long: `Port-forward to the operator and save a pprof profile, by default from the leader pod.
+A cpu profile samples for --duration; the other types are snapshots. Inspect the file with
+"go tool pprof". The operator must run with --pprof-bind-address, as manager.yaml sets.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Profile(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Type, "type", profileTypeHeap, "Profile type ("+strings.Join(profileTypes, "|")+")")
+	cmd.Flags().DurationVar(&opts.Duration, "duration", 30*time.Second, "How long a cpu profile samples")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "File to write (default: operator-<type>-<time>.pprof)")
+	cmd.Flags().StringVar(&opts.Pod, "pod", "", "Operator pod to profile (default: the leader)")
+	cmd.Flags().IntVar(&opts.Port, "port", operatorPprofPort, "Port of the operator's pprof endpoint")
+
+	return cmd
+}
+
+// Profile fetches the profile described by opts and writes it to opts.Output.
+func (m *OperatorManager) Profile(opts OperatorProfileOptions) error {
+	var invalid string
+	switch {
+	case !slices.Contains(profileTypes, opts.Type):
+		invalid = fmt.Sprintf("unknown --type %q (use %s)", opts.Type, strings.Join(profileTypes, "|"))
+	case opts.Type == profileTypeCPU && opts.Duration < time.Second:
+		invalid = fmt.Sprintf("--duration must be at least 1s, got %s", opts.Duration)
+	}
+	if invalid != "" {
+		err := newWithSentinel(ErrOperatorProfileFailed, invalid)
+		Error("Invalid profile options")
+		logStructuredError(m.logger, err, "Invalid profile options")
+		return err
+	}
+	if opts.Output == "" {
+		opts.Output = fmt.Sprintf("operator-%s-%s.pprof", opts.Type, time.Now().Format("20060102-150405"))
+	}
+
+	target := m.profileTarget(opts.Pod)
+	localPort, err := profileLocalPort()
+	if err != nil {
+		return m.profileError(err, opts, "Failed to profile the operator")
+	}
+	ctx, cancel := context.WithCancel(commandContext())
+	defer cancel()
+	if err := m.startPortForward(ctx, target, localPort, opts.Port); err != nil {
+		return m.profileError(err, opts, "Failed to reach the operator")
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/debug/pprof/%s", localPort, opts.Type)
+	timeout := 30 * time.Second
+	if opts.Type == profileTypeCPU {
+		url = fmt.Sprintf("http://127.0.0.1:%d/debug/pprof/profile?seconds=%d", localPort, int(opts.Duration.Seconds()))
+		timeout += opts.Duration
+		Info(fmt.Sprintf("Sampling CPU of %s for %s", target, opts.Duration))
+	}
+	m.logger.Info("Fetching operator profile", zap.String("target", target), zap.String("type", opts.Type))
+	profile, err := fetchProfile(ctx, &http.Client{Timeout: timeout}, url)
+	if err != nil {
+		return m.profileError(err, opts, "Failed to profile the operator")
+	}
+	if err := os.WriteFile(opts.Output, profile, 0o600); err != nil {
+		return m.profileError(err, opts, "Failed to write the profile")
+	}
+	Success(fmt.Sprintf("Wrote %s profile of %s to %s (inspect with: go tool pprof %s)", opts.Type, target, opts.Output, opts.Output))
+	return nil
+}
+
+// profileTarget returns the port-forward target: pod when set, else the leader pod, else
+// any pod of the operator deployment.
+func (m *OperatorManager) profileTarget(pod string) string {
+	if pod != "" {
+		return "pod/" + pod
+	}
+	// #nosec G204 -- fixed kubectl command with constant names.
+	out, err := m.kubectl.Output([]string{"get", "lease", OperatorLeaseName, "-n", NamespaceMCPRuntime, "-o", "jsonpath={.spec.holderIdentity}"})
+	if err == nil {
+		if leader := leaderPodName(string(out)); leader != "" {
+			return "pod/" + leader
+		}
+	}
+	return "deployment/" + OperatorDeploymentName
+}
+
+// startPortForward runs kubectl port-forward until ctx is done and returns once it forwards.
+func (m *OperatorManager) startPortForward(ctx context.Context, target string, localPort, remotePort int) error {
+	// #nosec G204 -- target is a pod or deployment name; ports are integers.
+	cmd, err := m.kubectl.CommandArgsContext(ctx, []string{"port-forward", "-n", NamespaceMCPRuntime, target, fmt.Sprintf("%d:%d", localPort, remotePort)})
+	if err != nil {
+		return err
+	}
+	ready := &forwardReadyWriter{ready: make(chan struct{})}
+	var stderr lockedBuffer
+	cmd.SetStdout(ready)
+	cmd.SetStderr(&stderr)
+	done := make(chan error, 1)
+	go func() { done <- cmd.Run() }()
+
+	timer := time.NewTimer(profileForwardTimeout)
+	defer timer.Stop()
+	select {
+	case <-ready.ready:
+		return nil
+	case err := <-done:
+		if err == nil {
+			err = fmt.Errorf("port-forward exited")
+		}
+		return fmt.Errorf("port-forward to %s: %w: %s", target, err, strings.TrimSpace(stderr.String()))
+	case <-timer.C:
+		return fmt.Errorf("port-forward to %s did not start within %s", target, profileForwardTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchProfile GETs url and returns the body of a 200 response.
+func fetchProfile(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+func (m *OperatorManager) profileError(err error, opts OperatorProfileOptions, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrOperatorProfileFailed,
+		err,
+		fmt.Sprintf("%s: %v", strings.ToLower(msg), err),
+		map[string]any{"type": opts.Type, "pod": opts.Pod, "namespace": NamespaceMCPRuntime, "component": "operator"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
+
+// freeLocalPort asks the kernel for an unused local port.
+func freeLocalPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// forwardReadyWriter closes ready once kubectl port-forward reports it is forwarding.
+type forwardReadyWriter struct {
+	ready chan struct{}
+	once  sync.Once
+}
+
+func (w *forwardReadyWriter) Write(p []byte) (int, error) {
+	if bytes.Contains(p, []byte("Forwarding from")) {
+		w.once.Do(func() { close(w.ready) })
+	}
+	return len(p), nil
+}
+
+// lockedBuffer is a bytes.Buffer safe to write from a command while it is read.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
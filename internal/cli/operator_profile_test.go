@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// profileMock answers the lease lookup with leader and runs port-forwards that report
+// forwarding until the test ends; forwardErr makes them fail instead.
+func profileMock(t *testing.T, leader string, forwardErr error) *MockExecutor {
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		switch spec.Args[0] {
+		case "get":
+			if leader == "" {
+				return &MockCommand{OutputErr: errors.New("not found")}
+			}
+			return &MockCommand{OutputData: []byte(leader + "_6f1c")}
+		case "port-forward":
+			cmd := &MockCommand{}
+			cmd.RunFunc = func() error {
+				if forwardErr != nil {
+					_, _ = cmd.StderrW.Write([]byte("error: pod not found"))
+					return forwardErr
+				}
+				_, _ = cmd.StdoutW.Write([]byte("Forwarding from 127.0.0.1:1 -> 6060\n"))
+				<-stop
+				return nil
+			}
+			return cmd
+		}
+		return &MockCommand{}
+	}
+	return mock
+}
+
+// servePprof serves path on a local port that profileLocalPort returns.
+func servePprof(t *testing.T, path string) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("profile:" + r.URL.RawQuery))
+	}))
+	t.Cleanup(server.Close)
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	original := profileLocalPort
+	t.Cleanup(func() { profileLocalPort = original })
+	profileLocalPort = func() (int, error) { return strconv.Atoi(port) }
+}
+
+func TestOperatorProfile(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+	t.Run("heap from the leader", func(t *testing.T) {
+		servePprof(t, "/debug/pprof/heap")
+		mock := profileMock(t, "mcp-runtime-operator-controller-manager-abc", nil)
+		mgr := NewOperatorManager(&KubectlClient{exec: mock}, zap.NewNop())
+		output := filepath.Join(t.TempDir(), "heap.pprof")
+
+		if err := mgr.Profile(OperatorProfileOptions{Type: "heap", Output: output, Port: 6060}); err != nil {
+			t.Fatalf("Profile() error: %v", err)
+		}
+		forward := mock.Commands[1].Args
+		want := []string{"port-forward", "-n", NamespaceMCPRuntime, "pod/mcp-runtime-operator-controller-manager-abc", forward[4]}
+		if !reflect.DeepEqual(forward, want) || forward[4][len(forward[4])-5:] != ":6060" {
+			t.Fatalf("unexpected port-forward %v", forward)
+		}
+		if data, err := os.ReadFile(output); err != nil || string(data) != "profile:" {
+			t.Fatalf("unexpected profile %q, %v", data, err)
+		}
+	})
+
+	t.Run("cpu samples for the duration", func(t *testing.T) {
+		servePprof(t, "/debug/pprof/profile")
+		mock := profileMock(t, "", nil)
+		mgr := NewOperatorManager(&KubectlClient{exec: mock}, zap.NewNop())
+		output := filepath.Join(t.TempDir(), "cpu.pprof")
+
+		if err := mgr.Profile(OperatorProfileOptions{Type: "cpu", Duration: 5 * time.Second, Output: output, Port: 6060}); err != nil {
+			t.Fatalf("Profile() error: %v", err)
+		}
+		if target := mock.Commands[1].Args[3]; target != "deployment/"+OperatorDeploymentName {
+			t.Fatalf("expected the deployment without a lease, got %s", target)
+		}
+		if data, _ := os.ReadFile(output); string(data) != "profile:seconds=5" {
+			t.Fatalf("unexpected profile %q", data)
+		}
+	})
+
+	t.Run("port-forward failure", func(t *testing.T) {
+		mock := profileMock(t, "", errors.New("exit status 1"))
+		mgr := NewOperatorManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+		err := mgr.Profile(OperatorProfileOptions{Type: "heap", Pod: "op-1", Output: filepath.Join(t.TempDir(), "p"), Port: 6060})
+		if !errors.Is(err, ErrOperatorProfileFailed) {
+			t.Fatalf("expected ErrOperatorProfileFailed, got %v", err)
+		}
+		if mock.Commands[0].Args[3] != "pod/op-1" {
+			t.Fatalf("expected --pod to skip the lease lookup, got %v", mock.Commands[0].Args)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		mgr := NewOperatorManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+		for _, opts := range []OperatorProfileOptions{{Type: "threads"}, {Type: "cpu", Duration: 0}} {
+			if err := mgr.Profile(opts); !errors.Is(err, ErrOperatorProfileFailed) {
+				t.Fatalf("%+v: expected ErrOperatorProfileFailed, got %v", opts, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func operatorTestExecutor(configMap string, applied *[]string) *MockExecutor {
+	return &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		switch args := strings.Join(spec.Args, " "); {
+		case strings.HasPrefix(args, "get configmap "+OperatorMaintenanceConfigMapName):
+			cmd.OutputData = []byte(configMap)
+		case args == "apply -f -":
+			cmd.RunFunc = func() error {
+				data, _ := io.ReadAll(cmd.StdinR)
+				*applied = append(*applied, string(data))
+				return nil
+			}
+		}
+		return cmd
+	}}
+}
+
+func TestOperatorPauseAndResume(t *testing.T) {
+	var applied []string
+	mgr := NewOperatorManager(&KubectlClient{exec: operatorTestExecutor("", &applied)}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.Pause("node upgrade"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if err := mgr.Resume(); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("expected 2 applies, got %d", len(applied))
+	}
+
+	want := []map[string]string{
+		{maintenancePausedKey: "true", maintenanceReasonKey: "node upgrade"},
+		{maintenancePausedKey: "false", maintenanceReasonKey: ""},
+	}
+	for i, manifest := range applied {
+		var cm struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Data map[string]string `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(manifest), &cm); err != nil {
+			t.Fatalf("manifest %d: %v", i, err)
+		}
+		if cm.Metadata.Name != OperatorMaintenanceConfigMapName || cm.Metadata.Namespace != NamespaceMCPRuntime {
+			t.Fatalf("manifest %d targets %s/%s", i, cm.Metadata.Namespace, cm.Metadata.Name)
+		}
+		for k, v := range want[i] {
+			if cm.Data[k] != v {
+				t.Fatalf("manifest %d: data[%s] = %q, want %q", i, k, cm.Data[k], v)
+			}
+		}
+	}
+}
+
+func TestOperatorStatus(t *testing.T) {
+	t.Run("paused", func(t *testing.T) {
+		configMap := `{"data":{"paused":"true","reason":"migration"}}`
+		mgr := NewOperatorManager(&KubectlClient{exec: operatorTestExecutor(configMap, nil)}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Status(); err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if !strings.Contains(buf.String(), "on (paused)") || !strings.Contains(buf.String(), "migration") {
+			t.Fatalf("unexpected output: %s", buf.String())
+		}
+	})
+
+	t.Run("missing configmap means off", func(t *testing.T) {
+		mgr := NewOperatorManager(&KubectlClient{exec: operatorTestExecutor("", nil)}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Status(); err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		if !strings.Contains(buf.String(), "off") {
+			t.Fatalf("unexpected output: %s", buf.String())
+		}
+	})
+}
+
+func TestOperatorPauseFailure(t *testing.T) {
+	mock := &MockExecutor{DefaultRunErr: errors.New("forbidden")}
+	mgr := NewOperatorManager(&KubectlClient{exec: mock}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.Pause(""); !errors.Is(err, ErrSetMaintenanceModeFailed) {
+		t.Fatalf("expected ErrSetMaintenanceModeFailed, got %v", err)
+	}
+}
@@ -5,7 +5,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -52,6 +51,7 @@ func NewPipelineCmdWithManager(mgr *PipelineManager) *cobra.Command {
 
 	cmd.AddCommand(mgr.newPipelineGenerateCmd())
 	cmd.AddCommand(mgr.newPipelineDeployCmd())
+	cmd.AddCommand(mgr.newPipelineRunCmd())
 
 	return cmd
 }
@@ -81,7 +81,7 @@ the operator will use to deploy MCP servers.`,
 
 func (m *PipelineManager) newPipelineDeployCmd() *cobra.Command {
 	var manifestsDir string
-	var namespace string
+	var notifyURL string
 
 	cmd := &cobra.Command{
 		Use:   "deploy",
@@ -90,12 +90,55 @@ func (m *PipelineManager) newPipelineDeployCmd() *cobra.Command {
 This applies all CRD manifests to the cluster, which triggers
 the operator to create the necessary Kubernetes resources.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.DeployCRDs(manifestsDir, namespace)
+			if err := validateNotifyURL(notifyURL); err != nil {
+				Error("Invalid notify URL")
+				logStructuredError(m.logger, err, "Invalid notify URL")
+				return err
+			}
+			return runWithNotify(m.logger, notifyURL, "pipeline deploy", func() error {
+				return m.DeployCRDs(manifestsDir, getNamespaceOverride())
+			})
 		},
 	}
 
 	cmd.Flags().StringVar(&manifestsDir, "dir", "manifests", "Directory containing CRD files")
-	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace to deploy to (overrides metadata)")
+	addNotifyFlag(cmd, &notifyURL)
+
+	return cmd
+}
+
+func (m *PipelineManager) newPipelineRunCmd() *cobra.Command {
+	var metadataFile string
+	var metadataDir string
+	var outputDir string
+	var notifyURL string
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Generate and deploy CRD files",
+		Long: `Run the whole pipeline: generate CRD files from metadata and deploy them
+to the Kubernetes cluster, as pipeline generate followed by pipeline deploy.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateNotifyURL(notifyURL); err != nil {
+				Error("Invalid notify URL")
+				logStructuredError(m.logger, err, "Invalid notify URL")
+				return err
+			}
+			return runWithNotify(m.logger, notifyURL, "pipeline run", func() error {
+				Step("Generate manifests")
+				if err := m.GenerateCRDsFromMetadata(metadataFile, metadataDir, outputDir); err != nil {
+					return err
+				}
+				Step("Deploy manifests")
+				return m.DeployCRDs(outputDir, getNamespaceOverride())
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&metadataFile, "file", "", "Path to metadata file (YAML)")
+	cmd.Flags().StringVar(&metadataDir, "dir", ".mcp", "Directory containing metadata files")
+	cmd.Flags().StringVar(&outputDir, "output", "manifests", "Output directory for CRD files")
+	addNotifyFlag(cmd, &notifyURL)
 
 	return cmd
 }
@@ -202,7 +245,7 @@ func (m *PipelineManager) DeployCRDs(manifestsDir, namespace string) error {
 		}
 
 		// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-		if err := m.kubectl.RunWithOutput(args, os.Stdout, os.Stderr); err != nil {
+		if err := m.kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 			wrappedErr := wrapWithSentinelAndContext(
 				ErrApplyManifestFailed,
 				err,
@@ -29,7 +29,7 @@ func TestNewPipelineCmd(t *testing.T) {
 			t.Errorf("expected at least 2 subcommands (generate, deploy), got %d", len(subcommands))
 		}
 
-		expectedSubs := map[string]bool{"generate": false, "deploy": false}
+		expectedSubs := map[string]bool{"generate": false, "deploy": false, "run": false}
 		for _, sub := range subcommands {
 			if _, ok := expectedSubs[sub.Use]; ok {
 				expectedSubs[sub.Use] = true
@@ -258,8 +258,8 @@ func TestNewPipelineCmdWithManager(t *testing.T) {
 
 	t.Run("has_subcommands", func(t *testing.T) {
 		subcommands := cmd.Commands()
-		if len(subcommands) != 2 {
-			t.Errorf("expected 2 subcommands (generate, deploy), got %d", len(subcommands))
+		if len(subcommands) != 3 {
+			t.Errorf("expected 3 subcommands (generate, deploy, run), got %d", len(subcommands))
 		}
 	})
 }
@@ -331,7 +331,7 @@ func TestPipelineDeployCmd(t *testing.T) {
 
 	t.Run("has_flags", func(t *testing.T) {
 		flags := cmd.Flags()
-		expectedFlags := []string{"dir", "namespace"}
+		expectedFlags := []string{"dir", "notify"}
 		for _, name := range expectedFlags {
 			if flags.Lookup(name) == nil {
 				t.Errorf("expected flag %q not found", name)
@@ -352,6 +352,43 @@ func TestPipelineDeployCmd(t *testing.T) {
 	})
 }
 
+func TestPipelineRunCmd(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	mock := &MockExecutor{}
+	kubectl := &KubectlClient{exec: mock, validators: nil}
+	mgr := NewPipelineManager(kubectl, zap.NewNop())
+
+	cmd := mgr.newPipelineRunCmd()
+	for _, name := range []string{"file", "dir", "output", "notify"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected flag %q not found", name)
+		}
+	}
+
+	tmpDir := t.TempDir()
+	metadataFile := filepath.Join(tmpDir, "test.yaml")
+	content := `version: "1"
+servers:
+  - name: run-test
+    image: test:v1
+`
+	if err := os.WriteFile(metadataFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outputDir := filepath.Join(tmpDir, "out")
+	cmd.SetArgs([]string{"--file", metadataFile, "--output", outputDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	last := mock.LastCommand()
+	if last.Name != "kubectl" || !contains(last.Args, "apply") || !contains(last.Args, filepath.Join(outputDir, "run-test.yaml")) {
+		t.Fatalf("expected the generated manifest to be applied, got %s %v", last.Name, last.Args)
+	}
+}
+
 func TestPipelineManager_DeployCRDs_WithoutNamespace(t *testing.T) {
 	mock := &MockExecutor{}
 	kubectl := &KubectlClient{exec: mock, validators: nil}
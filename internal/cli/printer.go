@@ -8,13 +8,16 @@ package cli
 //   - Status messages (Info, Success, Warn, Error)
 //   - Tables (regular and boxed)
 //   - Colors and formatting
-//   - Spinners for long-running operations
+//   - Spinners for long-running operations; other output interrupts an animated spinner
+//   - Plain output (no spinners or colors) for CI logs
 //
 // Package-level convenience functions delegate to DefaultPrinter for easy usage.
 
 import (
 	"io"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/pterm/pterm"
 	"golang.org/x/term"
@@ -25,8 +28,17 @@ import (
 type Printer struct {
 	// Quiet suppresses non-essential output
 	Quiet bool
+	// Plain replaces spinners with plain lines, for logs that are not a terminal.
+	Plain bool
 	// Writer overrides the output destination when set.
 	Writer io.Writer
+
+	// lastStep is the most recent Step title, reported by --notify when a command fails.
+	lastStep string
+
+	// spinnerMu guards spinner, the animated spinner of the running step.
+	spinnerMu sync.Mutex
+	spinner   *activeSpinner
 }
 
 // DefaultPrinter is the default printer instance used by package-level functions.
@@ -39,6 +51,7 @@ func (p *Printer) Section(title string) {
 	if p.Quiet {
 		return
 	}
+	p.interruptSpinner()
 	writer := p.Writer
 	if writer != nil {
 		pterm.Fprintln(writer)
@@ -51,9 +64,11 @@ func (p *Printer) Section(title string) {
 
 // Step prints a step indicator (e.g., "Step 1: Initialize").
 func (p *Printer) Step(title string) {
+	p.lastStep = title
 	if p.Quiet {
 		return
 	}
+	p.interruptSpinner()
 	writer := p.Writer
 	if writer != nil {
 		pterm.Fprintln(writer)
@@ -64,6 +79,11 @@ func (p *Printer) Step(title string) {
 	pterm.DefaultSection.WithLevel(2).Println(title)
 }
 
+// LastStep returns the title of the most recent Step, or "" before the first one.
+func (p *Printer) LastStep() string {
+	return p.lastStep
+}
+
 // --- Status Messages ---
 
 // Info prints an informational message.
@@ -71,6 +91,7 @@ func (p *Printer) Info(msg string) {
 	if p.Quiet {
 		return
 	}
+	p.interruptSpinner()
 	if p.Writer != nil {
 		pterm.Info.WithWriter(p.Writer).Println(msg)
 		return
@@ -83,6 +104,7 @@ func (p *Printer) Success(msg string) {
 	if p.Quiet {
 		return
 	}
+	p.interruptSpinner()
 	if p.Writer != nil {
 		pterm.Success.WithWriter(p.Writer).Println(msg)
 		return
@@ -94,6 +116,7 @@ func (p *Printer) Success(msg string) {
 // Note: Warnings are intentionally not suppressed in quiet mode to ensure
 // important notices are visible even when non-essential output is disabled.
 func (p *Printer) Warn(msg string) {
+	p.interruptSpinner()
 	if p.Writer != nil {
 		pterm.Warning.WithWriter(p.Writer).Println(msg)
 		return
@@ -105,6 +128,7 @@ func (p *Printer) Warn(msg string) {
 // Note: Errors are intentionally not suppressed in quiet mode to ensure
 // critical issues are always visible, even when non-essential output is disabled.
 func (p *Printer) Error(msg string) {
+	p.interruptSpinner()
 	if p.Writer != nil {
 		pterm.Error.WithWriter(p.Writer).Println(msg)
 		return
@@ -119,6 +143,7 @@ func (p *Printer) Table(data [][]string) {
 	if len(data) == 0 {
 		return
 	}
+	p.interruptSpinner()
 	table := pterm.DefaultTable.WithHasHeader().WithData(data)
 	if p.Writer != nil {
 		s, err := table.Srender()
@@ -141,6 +166,7 @@ func (p *Printer) TableBoxed(data [][]string) {
 	if len(data) == 0 {
 		return
 	}
+	p.interruptSpinner()
 	table := pterm.DefaultTable.WithHasHeader().WithBoxed().WithData(data)
 	if p.Writer != nil {
 		s, err := table.Srender()
@@ -162,6 +188,7 @@ func (p *Printer) TableBoxed(data [][]string) {
 
 // Header prints a full-width header banner.
 func (p *Printer) Header(title string) {
+	p.interruptSpinner()
 	header := pterm.DefaultHeader.WithFullWidth().WithBackgroundStyle(pterm.NewStyle(pterm.BgCyan))
 	if p.Writer != nil {
 		header = header.WithWriter(p.Writer)
@@ -193,7 +220,8 @@ func (p *Printer) Cyan(msg string) string {
 
 // --- Spinners ---
 
-func isTerminalWriter(writer io.Writer) bool {
+// isTerminalWriter reports whether writer (stdout when nil) is a terminal; a test seam.
+var isTerminalWriter = func(writer io.Writer) bool {
 	if writer == nil {
 		return term.IsTerminal(int(os.Stdout.Fd()))
 	}
@@ -203,12 +231,49 @@ func isTerminalWriter(writer io.Writer) bool {
 	return false
 }
 
+// activeSpinner is an animated spinner. Its frames are drawn by one goroutine that holds
+// spinnerMu while writing, so every field here is guarded by spinnerMu.
+type activeSpinner struct {
+	out   io.Writer
+	msg   string
+	text  string // shown next to the frame; a nested spinner swaps it
+	start time.Time
+	// interrupted is set once other output stopped the animation.
+	interrupted bool
+	// stopped ends the animation goroutine.
+	stopped bool
+}
+
 // SpinnerStart starts a spinner with the given message. Returns a stop function.
+//
+// While the spinner animates, any other output stops it first: the message is left as a
+// plain line and the stop function prints the final message as a plain line. A spinner
+// started while another one animates reuses it, showing its message until stopped.
 func (p *Printer) SpinnerStart(msg string) func(success bool, finalMsg string) {
 	if p.Quiet {
 		return func(bool, string) {}
 	}
-	if !isTerminalWriter(p.Writer) {
+	p.spinnerMu.Lock()
+	outer := p.spinner
+	if outer != nil && !outer.interrupted {
+		outerText := outer.text
+		outer.text = msg
+		p.spinnerMu.Unlock()
+		return func(success bool, finalMsg string) {
+			if !success && finalMsg != "" {
+				p.Error(finalMsg)
+				return
+			}
+			p.spinnerMu.Lock()
+			defer p.spinnerMu.Unlock()
+			if p.spinner == outer && !outer.interrupted {
+				outer.text = outerText
+			}
+		}
+	}
+	p.spinnerMu.Unlock()
+
+	if outer != nil || p.Plain || !isTerminalWriter(p.Writer) {
 		p.Println(msg)
 		return func(success bool, finalMsg string) {
 			if finalMsg == "" {
@@ -221,24 +286,127 @@ func (p *Printer) SpinnerStart(msg string) func(success bool, finalMsg string) {
 			}
 		}
 	}
-	spinner := pterm.DefaultSpinner
-	if p.Writer != nil {
-		spinner = *spinner.WithWriter(p.Writer)
+	out := p.Writer
+	if out == nil {
+		out = os.Stderr
 	}
-	s, _ := spinner.Start(msg)
+	active := &activeSpinner{out: out, msg: msg, text: msg, start: time.Now()}
+	p.spinnerMu.Lock()
+	p.spinner = active
+	p.spinnerMu.Unlock()
+	go p.animate(active)
 	return func(success bool, finalMsg string) {
-		if success {
-			s.Success(finalMsg)
-		} else {
-			s.Fail(finalMsg)
+		p.spinnerMu.Lock()
+		if p.spinner == active {
+			p.spinner = nil
+		}
+		interrupted := active.interrupted
+		active.stopped = true
+		if !interrupted {
+			if finalMsg == "" {
+				finalMsg = active.text
+			}
+			printer := pterm.Success
+			if !success {
+				printer = pterm.Error
+			}
+			pterm.Fprint(active.out, "\r\033[K")
+			pterm.Fprintln(active.out, printer.Sprint(finalMsg))
+		}
+		p.spinnerMu.Unlock()
+		if interrupted && finalMsg != "" {
+			if success {
+				p.Success(finalMsg)
+			} else {
+				p.Error(finalMsg)
+			}
 		}
 	}
 }
 
+// animate draws the frames of active until it is stopped or interrupted.
+func (p *Printer) animate(active *activeSpinner) {
+	style := pterm.DefaultSpinner
+	ticker := time.NewTicker(style.Delay)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		p.spinnerMu.Lock()
+		if active.stopped || active.interrupted {
+			p.spinnerMu.Unlock()
+			return
+		}
+		elapsed := time.Since(active.start).Round(style.TimerRoundingFactor)
+		pterm.Fprint(active.out, "\r\033[K"+style.Style.Sprint(style.Sequence[frame%len(style.Sequence)])+" "+
+			style.MessageStyle.Sprint(active.text)+style.TimerStyle.Sprint(" ("+elapsed.String()+")"))
+		p.spinnerMu.Unlock()
+		<-ticker.C
+	}
+}
+
+// interruptSpinner stops the animated spinner before other output is printed, so the output
+// does not mix with spinner frames. The spinner's message is kept as a plain line.
+func (p *Printer) interruptSpinner() {
+	p.spinnerMu.Lock()
+	defer p.spinnerMu.Unlock()
+	active := p.spinner
+	if active == nil || active.interrupted {
+		return
+	}
+	active.interrupted = true
+	pterm.Fprint(active.out, "\r\033[K")
+	if p.Writer != nil {
+		pterm.Fprintln(p.Writer, active.msg)
+		return
+	}
+	pterm.Println(active.msg)
+}
+
+// Stdout returns the writer for the standard output of tools the CLI runs (kubectl, docker)
+// and of step output. While a spinner animates it stops the spinner before the first write;
+// otherwise it is the destination itself, so tools still see a terminal.
+func (p *Printer) Stdout() io.Writer {
+	if p.Writer != nil {
+		return p.toolWriter(p.Writer)
+	}
+	return p.toolWriter(os.Stdout)
+}
+
+// Stderr is Stdout for the standard error of tools.
+func (p *Printer) Stderr() io.Writer {
+	return p.toolWriter(os.Stderr)
+}
+
+func (p *Printer) toolWriter(out io.Writer) io.Writer {
+	p.spinnerMu.Lock()
+	defer p.spinnerMu.Unlock()
+	if p.spinner == nil || p.spinner.interrupted {
+		return out
+	}
+	return &spinnerSafeWriter{printer: p, out: out}
+}
+
+// spinnerSafeWriter stops the printer's spinner before writing to out.
+type spinnerSafeWriter struct {
+	printer *Printer
+	out     io.Writer
+}
+
+func (w *spinnerSafeWriter) Write(b []byte) (int, error) {
+	w.printer.interruptSpinner()
+	return w.out.Write(b)
+}
+
+// SetPlainOutput switches DefaultPrinter to plain output and turns off colors and styling.
+func SetPlainOutput() {
+	DefaultPrinter.Plain = true
+	pterm.DisableStyling()
+}
+
 // --- Plain Output ---
 
 // Println prints a plain line.
 func (p *Printer) Println(a ...interface{}) {
+	p.interruptSpinner()
 	if p.Writer != nil {
 		pterm.Fprintln(p.Writer, a...)
 		return
@@ -248,6 +416,7 @@ func (p *Printer) Println(a ...interface{}) {
 
 // Printf prints formatted text.
 func (p *Printer) Printf(format string, a ...interface{}) {
+	p.interruptSpinner()
 	if p.Writer != nil {
 		pterm.Fprint(p.Writer, pterm.Sprintf(format, a...))
 		return
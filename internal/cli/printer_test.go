@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 )
@@ -178,3 +179,99 @@ func TestPrinterPackageLevelHelpers(t *testing.T) {
 	stop := SpinnerStart("working")
 	stop(true, "done")
 }
+
+func TestPrinterSpinnerInterruptedByOutput(t *testing.T) {
+	orig := isTerminalWriter
+	isTerminalWriter = func(io.Writer) bool { return true }
+	t.Cleanup(func() { isTerminalWriter = orig })
+
+	var buf lockedBuffer
+	p := &Printer{Writer: &buf}
+	stop := p.SpinnerStart("Running step registry")
+	active := p.spinner
+	if active == nil {
+		t.Fatal("expected an animated spinner")
+	}
+
+	stopNested := p.SpinnerStart("Running docker build")
+	if p.spinner != active {
+		t.Fatal("expected the nested spinner to reuse the running one")
+	}
+	if got := spinnerText(p, active); got != "Running docker build" {
+		t.Fatalf("spinner text = %q, want the nested message", got)
+	}
+	stopNested(true, "docker build done")
+	if got := spinnerText(p, active); got != "Running step registry" {
+		t.Fatalf("spinner text = %q, want the outer message back", got)
+	}
+
+	p.Info("nested info")
+	if p.spinner != active || !spinnerInterrupted(p, active) {
+		t.Fatal("expected output to stop the spinner")
+	}
+	stop(true, "Step registry done in 1s")
+	if p.spinner != nil {
+		t.Fatal("expected the spinner to be cleared")
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Running step registry\n", "nested info", "Step registry done in 1s"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "docker build done") {
+		t.Fatalf("nested success should not print a line:\n%s", out)
+	}
+	if i, j := strings.Index(out, "nested info"), strings.LastIndex(out, "Running step registry"); j > i {
+		t.Fatalf("spinner frames printed after nested output:\n%s", out)
+	}
+}
+
+func TestPrinterToolOutputInterruptsSpinner(t *testing.T) {
+	orig := isTerminalWriter
+	isTerminalWriter = func(io.Writer) bool { return true }
+	t.Cleanup(func() { isTerminalWriter = orig })
+
+	var buf lockedBuffer
+	p := &Printer{Writer: &buf}
+	if w := p.Stdout(); w != io.Writer(&buf) {
+		t.Fatalf("expected the plain writer without a spinner, got %T", w)
+	}
+
+	stop := p.SpinnerStart("Running step operator")
+	active := p.spinner
+	out := p.Stdout()
+	if spinnerInterrupted(p, active) {
+		t.Fatal("taking the writer should not stop the spinner")
+	}
+	// Tools write from the goroutines os/exec copies their output with.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = io.WriteString(out, "deployment.apps/operator configured\n")
+	}()
+	<-done
+	if !spinnerInterrupted(p, active) {
+		t.Fatal("expected tool output to stop the spinner")
+	}
+	stop(true, "Step operator done in 2s")
+
+	text := buf.String()
+	i, j := strings.Index(text, "configured"), strings.Index(text, "Step operator done")
+	if i < 0 || j < i {
+		t.Fatalf("expected tool output before the final message:\n%s", text)
+	}
+}
+
+func spinnerText(p *Printer, active *activeSpinner) string {
+	p.spinnerMu.Lock()
+	defer p.spinnerMu.Unlock()
+	return active.text
+}
+
+func spinnerInterrupted(p *Printer, active *activeSpinner) bool {
+	p.spinnerMu.Lock()
+	defer p.spinnerMu.Unlock()
+	return active.interrupted
+}
@@ -0,0 +1,369 @@
+package cli
+
+// This file implements proxy support. "setup --proxy" exports HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY to the tools setup runs, sets them on the operator deployment and checks during
+// pre-flight that NO_PROXY keeps cluster-internal traffic off the proxy. Whatever proxy the
+// CLI runs with, from --proxy or the environment, is also passed to the in-cluster push
+// helper and builder pods and to image builds as build arguments. A proxy URL may carry
+// credentials, so pods and the operator read it from a Secret and builds from the
+// environment, never from the command line.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// proxySecretName is the Secret holding the proxy variables for the operator and for helper
+// and builder pods.
+const proxySecretName = "mcp-runtime-proxy"
+
+// requiredNoProxy are the NO_PROXY entries that keep cluster-internal traffic (Service DNS
+// names and local clusters such as kind) off the proxy.
+var requiredNoProxy = []string{"localhost", "127.0.0.1", ".svc", ".cluster.local"}
+
+// ProxyOptions are the --proxy and --no-proxy settings of setup.
+type ProxyOptions struct {
+	// URL is the proxy for HTTP and HTTPS requests; empty leaves proxies unconfigured.
+	URL     string
+	NoProxy []string
+}
+
+func addProxyFlags(cmd *cobra.Command, o *ProxyOptions) {
+	cmd.Flags().StringVar(&o.URL, "proxy", "", "HTTP(S) proxy URL for the operator, helper pods, image builds and the tools setup runs, e.g. http://proxy.corp:3128")
+	cmd.Flags().StringSliceVar(&o.NoProxy, "no-proxy", requiredNoProxy, "Hosts, domains (.example.com) and CIDRs reached without --proxy; must cover cluster-internal addresses")
+}
+
+// Validate checks the proxy URL and that NO_PROXY covers the cluster-internal names.
+func (o ProxyOptions) Validate() error {
+	if o.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(o.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return newWithSentinel(ErrInvalidProxy, fmt.Sprintf("invalid --proxy %q: use http://host:port or https://host:port", o.URL))
+	}
+	for _, value := range append([]string{o.URL}, o.NoProxy...) {
+		if strings.ContainsAny(value, " \t\r\n;|&$`\\\"'") {
+			return newWithSentinel(ErrInvalidProxy, fmt.Sprintf("invalid proxy setting %q: unexpected whitespace or shell characters", value))
+		}
+	}
+	var missing []string
+	for _, entry := range requiredNoProxy {
+		if !slices.Contains(o.NoProxy, entry) {
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) > 0 {
+		return newWithSentinel(ErrInvalidProxy, fmt.Sprintf("--no-proxy must cover cluster-internal addresses; add %s", strings.Join(missing, ",")))
+	}
+	return nil
+}
+
+// settings returns the proxy variables the options stand for.
+func (o ProxyOptions) settings() proxySettings {
+	if o.URL == "" {
+		return proxySettings{}
+	}
+	return proxySettings{HTTPProxy: o.URL, HTTPSProxy: o.URL, NoProxy: strings.Join(o.NoProxy, ",")}
+}
+
+// proxySettings are the values of HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+type proxySettings struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// currentProxySettings returns the proxy variables of this process, preferring the upper
+// case names as Go and most tools do.
+func currentProxySettings() proxySettings {
+	get := func(name string) string {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+		return os.Getenv(strings.ToLower(name))
+	}
+	return proxySettings{HTTPProxy: get("HTTP_PROXY"), HTTPSProxy: get("HTTPS_PROXY"), NoProxy: get("NO_PROXY")}
+}
+
+func (p proxySettings) enabled() bool {
+	return p.HTTPProxy != "" || p.HTTPSProxy != ""
+}
+
+// env returns the variables as NAME=value pairs, each in upper and lower case since curl
+// and some base images only read the lower case names. Nothing is returned without a proxy.
+func (p proxySettings) env() []string {
+	if !p.enabled() {
+		return nil
+	}
+	var out []string
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", p.HTTPProxy},
+		{"HTTPS_PROXY", p.HTTPSProxy},
+		{"NO_PROXY", p.NoProxy},
+	} {
+		if v.value != "" {
+			out = append(out, v.name+"="+v.value, strings.ToLower(v.name)+"="+v.value)
+		}
+	}
+	return out
+}
+
+// export sets the variables in this process, so the docker, kubectl and HTTP calls that
+// follow use the proxy too.
+func (p proxySettings) export() {
+	for _, kv := range p.env() {
+		name, value, _ := strings.Cut(kv, "=")
+		_ = os.Setenv(name, value)
+	}
+}
+
+// applyProxySecret writes settings to the proxy Secret in namespace. The manifest goes
+// through stdin to keep proxy credentials off the command line.
+func applyProxySecret(kubectl KubectlRunner, namespace string, settings proxySettings) error {
+	data := map[string]string{}
+	for _, kv := range settings.env() {
+		name, value, _ := strings.Cut(kv, "=")
+		data[name] = value
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]string{"name": proxySecretName, "namespace": namespace},
+		"stringData": data,
+	})
+	if err != nil {
+		return err
+	}
+	// #nosec G204 -- fixed kubectl command, manifest via stdin.
+	cmd, err := kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(bytes.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	return cmd.Run()
+}
+
+// proxyPodSecretName returns the Secret helper and builder pods load the CLI's proxy
+// settings from, or "" without a proxy.
+func proxyPodSecretName() string {
+	if !currentProxySettings().enabled() {
+		return ""
+	}
+	return proxySecretName
+}
+
+// ensureProxyPodSecret writes the CLI's proxy settings to the proxy Secret in namespace,
+// before a helper or builder pod that loads it starts there. Without a proxy it does nothing.
+func ensureProxyPodSecret(kubectl KubectlRunner, namespace string) error {
+	settings := currentProxySettings()
+	if !settings.enabled() {
+		return nil
+	}
+	return applyProxySecret(kubectl, namespace, settings)
+}
+
+// proxyEnvFrom is the container envFrom entry loading secret.
+func proxyEnvFrom(secret string) []any {
+	return []any{map[string]any{"secretRef": map[string]string{"name": secret}}}
+}
+
+// proxyBuildArgs returns build flags passing the CLI's proxy settings to RUN steps; the
+// proxy variables are predefined build arguments, so Dockerfiles need no ARG lines. Only the
+// names are passed and the builder reads the values from the environment, so they are
+// exported first.
+func proxyBuildArgs() []string {
+	settings := currentProxySettings()
+	settings.export()
+	var args []string
+	for _, kv := range settings.env() {
+		name, _, _ := strings.Cut(kv, "=")
+		args = append(args, "--build-arg", name)
+	}
+	return args
+}
+
+// noProxyCovers reports whether the comma-separated noProxy list exempts host from the
+// proxy, following Go's rules: "*" matches everything, CIDRs match the IPs in them, and a
+// domain matches itself and its subdomains.
+func noProxyCovers(noProxy, host string) bool {
+	host = strings.ToLower(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	ip := net.ParseIP(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil {
+			if ip != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		domain := strings.TrimPrefix(entry, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNoProxy verifies NO_PROXY covers the API server's Service address, which the
+// operator connects to and would otherwise send through the proxy.
+func checkNoProxy(kubectl KubectlRunner, noProxy []string) preflightCheck {
+	check := preflightCheck{name: "Proxy exemptions"}
+	// #nosec G204 -- fixed kubectl arguments.
+	out, err := kubectlOutput(kubectl, []string{"get", "service", "kubernetes", "-n", "default", "-o", "jsonpath={.spec.clusterIP}"})
+	apiServerIP := strings.TrimSpace(string(out))
+	if err != nil || apiServerIP == "" {
+		check.result = preflightWarn
+		check.detail = "could not read the API server Service address; make sure --no-proxy covers the Service CIDR"
+		return check
+	}
+	if !noProxyCovers(strings.Join(noProxy, ","), apiServerIP) {
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("--no-proxy does not cover the API server Service address %s; add the Service CIDR (e.g. 10.96.0.0/12) or the address", apiServerIP)
+		return check
+	}
+	check.result = preflightPass
+	check.detail = fmt.Sprintf("API server %s and cluster DNS names bypass the proxy", apiServerIP)
+	return check
+}
+
+type proxyStep struct{}
+
+func (s proxyStep) Name() string { return "proxy" }
+func (s proxyStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupProxyStep(logger, ctx.Plan.Proxy.settings(), deps)
+}
+
+func setupProxyStep(logger *zap.Logger, settings proxySettings, deps SetupDeps) error {
+	// Step 5f: Route the operator's outbound traffic through the proxy
+	Step("Step 5f: Configure the operator proxy")
+	if err := deps.ConfigureProxy(settings); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrConfigureProxyFailed,
+			err,
+			fmt.Sprintf("failed to set the proxy on the operator: %v", err),
+			map[string]any{"proxy": redactProxyURL(settings.HTTPSProxy), "deployment": OperatorDeploymentName, "namespace": NamespaceMCPRuntime, "component": "setup"},
+		)
+		Error("Failed to configure the operator proxy")
+		logStructuredError(logger, wrappedErr, "Failed to configure the operator proxy")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("Operator uses proxy %s; NO_PROXY=%s", redactProxyURL(settings.HTTPSProxy), settings.NoProxy))
+	return nil
+}
+
+func configureProxy(settings proxySettings) error {
+	return configureProxyWithKubectl(kubectlClient, settings)
+}
+
+// configureProxyWithKubectl stores the proxy variables in the proxy Secret of the operator
+// namespace and points the operator deployment's environment at it.
+func configureProxyWithKubectl(kubectl KubectlRunner, settings proxySettings) error {
+	if err := applyProxySecret(kubectl, NamespaceMCPRuntime, settings); err != nil {
+		return err
+	}
+	args := []string{"set", "env", "deployment/" + OperatorDeploymentName, "-n", NamespaceMCPRuntime, "--from=secret/" + proxySecretName}
+	// #nosec G204 -- fixed kubectl verbs and names.
+	return kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+}
+
+// redactProxyURL hides the password of a proxy URL for output and logs.
+func redactProxyURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	return u.Redacted()
+}
+
+// registryProbeTimeout bounds the reachability check of a provisioned registry.
+const registryProbeTimeout = 10 * time.Second
+
+// probeRegistry checks a provisioned registry before its config is saved; a test seam.
+var probeRegistry = probeRegistryHTTP
+
+// probeRegistryHTTP checks that registryURL answers on the registry API from this machine;
+// any HTTP status, including 401, means it is reachable.
+func probeRegistryHTTP(registryURL string) error {
+	client := &http.Client{
+		Timeout:   registryProbeTimeout,
+		Transport: &http.Transport{Proxy: proxyForRequest},
+	}
+	baseURL, _ := splitRegistryURL(registryURL)
+	req, err := http.NewRequestWithContext(commandContext(), http.MethodGet, baseURL+"/v2/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// proxyForRequest picks the proxy for req from the current environment. Unlike
+// http.ProxyFromEnvironment it reads the environment on every call, so proxies exported by
+// setup after startup apply.
+func proxyForRequest(req *http.Request) (*url.URL, error) {
+	settings := currentProxySettings()
+	if noProxyCovers(settings.NoProxy, req.URL.Host) {
+		return nil, nil
+	}
+	proxy := settings.HTTPSProxy
+	if req.URL.Scheme == "http" {
+		proxy = settings.HTTPProxy
+	}
+	if proxy == "" {
+		return nil, nil
+	}
+	return url.Parse(proxy)
+}
+
+// registryProbeHint explains how the probe of registryURL was routed, for the warning
+// shown when the registry cannot be reached.
+func registryProbeHint(registryURL string) string {
+	settings := currentProxySettings()
+	baseURL, _ := splitRegistryURL(registryURL)
+	u, err := url.Parse(baseURL)
+	switch {
+	case err != nil:
+		return ""
+	case noProxyCovers(settings.NoProxy, u.Host):
+		return "NO_PROXY exempts it from the proxy"
+	case settings.HTTPSProxy != "":
+		return "the request went through HTTPS_PROXY " + redactProxyURL(settings.HTTPSProxy) + "; check the proxy allows the registry or add it to NO_PROXY"
+	default:
+		return "no proxy is configured; behind a corporate proxy set HTTPS_PROXY"
+	}
+}
@@ -0,0 +1,134 @@
+package cli
+
+// This file implements namespace quota bootstrapping for MCP server namespaces.
+// It renders a ResourceQuota and LimitRange so a single team's servers cannot consume the whole cluster.
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// QuotaOptions configures the ResourceQuota and LimitRange applied to a namespace.
+type QuotaOptions struct {
+	RequestsCPU    string
+	RequestsMemory string
+	LimitsCPU      string
+	LimitsMemory   string
+	Pods           int
+	MaxCPU         string
+	MaxMemory      string
+}
+
+// DefaultQuotaOptions returns the quota totals used when no flags are given.
+func DefaultQuotaOptions() QuotaOptions {
+	return QuotaOptions{
+		RequestsCPU:    DefaultQuotaRequestsCPU,
+		RequestsMemory: DefaultQuotaRequestsMemory,
+		LimitsCPU:      DefaultQuotaLimitsCPU,
+		LimitsMemory:   DefaultQuotaLimitsMemory,
+		Pods:           DefaultQuotaPods,
+		MaxCPU:         DefaultLimitRangeMaxCPU,
+		MaxMemory:      DefaultLimitRangeMaxMemory,
+	}
+}
+
+// Validate checks that all quantities parse and the pod count is positive.
+func (o QuotaOptions) Validate() error {
+	quantities := map[string]string{
+		"requests.cpu":    o.RequestsCPU,
+		"requests.memory": o.RequestsMemory,
+		"limits.cpu":      o.LimitsCPU,
+		"limits.memory":   o.LimitsMemory,
+		"max.cpu":         o.MaxCPU,
+		"max.memory":      o.MaxMemory,
+	}
+	for field, value := range quantities {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return newWithSentinel(ErrInvalidQuota, fmt.Sprintf("invalid quota %s %q: %v", field, value, err))
+		}
+	}
+	if o.Pods <= 0 {
+		return newWithSentinel(ErrInvalidQuota, fmt.Sprintf("invalid quota pods %d: must be positive", o.Pods))
+	}
+	return nil
+}
+
+// renderQuotaManifest renders the ResourceQuota and LimitRange for namespace.
+// LimitRange defaults match the operator's container defaults so pods created
+// outside the operator still satisfy the quota.
+func renderQuotaManifest(namespace string, opts QuotaOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `apiVersion: v1
+kind: ResourceQuota
+metadata:
+  name: %s
+  namespace: %s
+  labels:
+    %s: %s
+spec:
+  hard:
+    requests.cpu: %q
+    requests.memory: %q
+    limits.cpu: %q
+    limits.memory: %q
+    pods: "%d"
+---
+`, QuotaName, namespace, LabelManagedBy, LabelManagedByValue,
+		opts.RequestsCPU, opts.RequestsMemory, opts.LimitsCPU, opts.LimitsMemory, opts.Pods)
+	fmt.Fprintf(&b, `apiVersion: v1
+kind: LimitRange
+metadata:
+  name: %s
+  namespace: %s
+  labels:
+    %s: %s
+spec:
+  limits:
+  - type: Container
+    default:
+      cpu: %q
+      memory: %q
+    defaultRequest:
+      cpu: %q
+      memory: %q
+    max:
+      cpu: %q
+      memory: %q
+`, LimitRangeName, namespace, LabelManagedBy, LabelManagedByValue,
+		DefaultContainerLimitCPU, DefaultContainerLimitMemory,
+		DefaultContainerRequestCPU, DefaultContainerRequestMemory,
+		opts.MaxCPU, opts.MaxMemory)
+	return b.String()
+}
+
+// ApplyNamespaceQuotas creates or updates the ResourceQuota and LimitRange in namespace.
+func (m *ClusterManager) ApplyNamespaceQuotas(namespace string, opts QuotaOptions) error {
+	if err := opts.Validate(); err != nil {
+		Error("Invalid quota settings")
+		logStructuredError(m.logger, err, "Invalid quota settings")
+		return err
+	}
+
+	// #nosec G204 -- fixed kubectl command, manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(strings.NewReader(renderQuotaManifest(namespace, opts)))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	if err := cmd.Run(); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrApplyQuotaFailed,
+			err,
+			fmt.Sprintf("failed to apply quota in namespace %q: %v", namespace, err),
+			map[string]any{"namespace": namespace, "component": "cluster"},
+		)
+		Error("Failed to apply namespace quota")
+		logStructuredError(m.logger, wrappedErr, "Failed to apply namespace quota")
+		return wrappedErr
+	}
+	return nil
+}
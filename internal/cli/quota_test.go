@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestQuotaOptionsValidate(t *testing.T) {
+	t.Run("accepts defaults", func(t *testing.T) {
+		if err := DefaultQuotaOptions().Validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("rejects invalid quantity", func(t *testing.T) {
+		opts := DefaultQuotaOptions()
+		opts.RequestsMemory = "lots"
+		err := opts.Validate()
+		if !errors.Is(err, ErrInvalidQuota) {
+			t.Fatalf("expected ErrInvalidQuota, got %v", err)
+		}
+	})
+
+	t.Run("rejects non-positive pods", func(t *testing.T) {
+		opts := DefaultQuotaOptions()
+		opts.Pods = 0
+		if err := opts.Validate(); !errors.Is(err, ErrInvalidQuota) {
+			t.Fatalf("expected ErrInvalidQuota, got %v", err)
+		}
+	})
+}
+
+func TestRenderQuotaManifest(t *testing.T) {
+	opts := DefaultQuotaOptions()
+	opts.RequestsCPU = "4"
+	opts.Pods = 10
+	manifest := renderQuotaManifest("team-a", opts)
+
+	for _, want := range []string{
+		"kind: ResourceQuota",
+		"name: " + QuotaName,
+		"namespace: team-a",
+		`requests.cpu: "4"`,
+		`pods: "10"`,
+		"kind: LimitRange",
+		"name: " + LimitRangeName,
+		`cpu: "` + DefaultContainerLimitCPU + `"`,
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("manifest missing %q:\n%s", want, manifest)
+		}
+	}
+}
+
+func TestClusterManager_ApplyNamespaceQuotas(t *testing.T) {
+	t.Run("applies quota manifest via stdin", func(t *testing.T) {
+		var stdin string
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			cmd.RunFunc = func() error {
+				if cmd.StdinR != nil {
+					data, _ := io.ReadAll(cmd.StdinR)
+					stdin = string(data)
+				}
+				return nil
+			}
+			return cmd
+		}
+		kubectl := &KubectlClient{exec: mock, validators: nil}
+		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
+
+		if err := mgr.ApplyNamespaceQuotas(NamespaceMCPServers, DefaultQuotaOptions()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := strings.Join(mock.LastCommand().Args, " "); got != "apply -f -" {
+			t.Fatalf("expected 'apply -f -', got %q", got)
+		}
+		if !strings.Contains(stdin, "namespace: "+NamespaceMCPServers) {
+			t.Fatalf("expected manifest for %s, got:\n%s", NamespaceMCPServers, stdin)
+		}
+	})
+
+	t.Run("wraps apply failure", func(t *testing.T) {
+		mock := &MockExecutor{DefaultRunErr: errors.New("forbidden")}
+		kubectl := &KubectlClient{exec: mock, validators: nil}
+		mgr := NewClusterManager(kubectl, mock, zap.NewNop())
+
+		err := mgr.ApplyNamespaceQuotas(NamespaceMCPServers, DefaultQuotaOptions())
+		if !errors.Is(err, ErrApplyQuotaFailed) {
+			t.Fatalf("expected ErrApplyQuotaFailed, got %v", err)
+		}
+	})
+}
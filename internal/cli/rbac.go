@@ -0,0 +1,252 @@
+package cli
+
+// This file implements the "rbac report" command, which shows the RBAC bindings of the
+// operator and checks (via kubectl auth can-i, i.e. SelfSubjectAccessReview) whether the
+// current user may perform each platform operation before it fails mid-way with Forbidden.
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// permission is a single access check for the current user.
+// An empty Namespace checks a cluster-scoped resource.
+type permission struct {
+	Verb      string
+	Resource  string
+	Namespace string
+}
+
+func (p permission) String() string {
+	if p.Namespace == "" {
+		return p.Verb + " " + p.Resource
+	}
+	return fmt.Sprintf("%s %s -n %s", p.Verb, p.Resource, p.Namespace)
+}
+
+// platformOperation is a CLI operation and the permissions it needs.
+type platformOperation struct {
+	Name        string
+	Permissions []permission
+}
+
+// platformOperations returns the operations checked by "rbac report".
+func platformOperations(serverNamespace, registryNamespace string) []platformOperation {
+	return []platformOperation{
+		{
+			Name: "setup",
+			Permissions: []permission{
+				{Verb: "create", Resource: "namespaces"},
+				{Verb: "create", Resource: "customresourcedefinitions.apiextensions.k8s.io"},
+				{Verb: "create", Resource: "clusterroles.rbac.authorization.k8s.io"},
+				{Verb: "create", Resource: "clusterrolebindings.rbac.authorization.k8s.io"},
+				{Verb: "create", Resource: "deployments.apps", Namespace: NamespaceMCPRuntime},
+				{Verb: "create", Resource: "deployments.apps", Namespace: registryNamespace},
+			},
+		},
+		{
+			Name: "server create",
+			Permissions: []permission{
+				{Verb: "create", Resource: "mcpservers.mcpruntime.org", Namespace: serverNamespace},
+				{Verb: "get", Resource: "mcpservers.mcpruntime.org", Namespace: serverNamespace},
+			},
+		},
+		{
+			Name: "registry push (in-cluster)",
+			Permissions: []permission{
+				{Verb: "create", Resource: "pods", Namespace: registryNamespace},
+				{Verb: "create", Resource: "pods/exec", Namespace: registryNamespace},
+				{Verb: "delete", Resource: "pods", Namespace: registryNamespace},
+			},
+		},
+	}
+}
+
+// roleBinding is a (Cluster)RoleBinding that grants the operator a role.
+type roleBinding struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Role      string
+}
+
+// RBACManager inspects RBAC with injected dependencies.
+type RBACManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewRBACManager creates an RBACManager with the given dependencies.
+func NewRBACManager(kubectl *KubectlClient, logger *zap.Logger) *RBACManager {
+	return &RBACManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultRBACManager returns an RBACManager using default clients.
+func DefaultRBACManager(logger *zap.Logger) *RBACManager {
+	return NewRBACManager(kubectlClient, logger)
+}
+
+// NewRBACCmd returns the rbac subcommand.
+func NewRBACCmd(logger *zap.Logger) *cobra.Command {
+	return NewRBACCmdWithManager(DefaultRBACManager(logger))
+}
+
+// NewRBACCmdWithManager returns the rbac subcommand using the provided manager.
+func NewRBACCmdWithManager(mgr *RBACManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rbac",
+		Short: "Inspect platform RBAC",
+		Long:  "Inspect the operator's RBAC bindings and the current user's permissions",
+	}
+
+	cmd.AddCommand(mgr.newRBACReportCmd())
+
+	return cmd
+}
+
+func (m *RBACManager) newRBACReportCmd() *cobra.Command {
+	var registryNamespace string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report which platform operations you are allowed to run",
+		Long: `List the ClusterRoleBindings and RoleBindings of the operator service account,
+then check your own permissions for setup, server create and in-cluster registry push
+so missing access shows up before an operation fails with Forbidden.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.Report(serverNamespace(), registryNamespace)
+		},
+	}
+
+	cmd.Flags().StringVar(&registryNamespace, "registry-namespace", NamespaceRegistry, "Namespace to check registry permissions in")
+
+	return cmd
+}
+
+// Report prints the operator bindings and which platform operations the current user can run.
+func (m *RBACManager) Report(namespace, registryNamespace string) error {
+	bindings, err := m.operatorBindings()
+	if err != nil {
+		return err
+	}
+	Header("Operator bindings")
+	DefaultPrinter.Println()
+	if len(bindings) == 0 {
+		Warn(fmt.Sprintf("No bindings found for service account %s/%s (is the operator installed?)", NamespaceMCPRuntime, OperatorServiceAccountName))
+	} else {
+		rows := [][]string{{"Binding", "Kind", "Role"}}
+		for _, b := range bindings {
+			kind := b.Kind
+			if b.Namespace != "" {
+				kind = fmt.Sprintf("%s (%s)", b.Kind, b.Namespace)
+			}
+			rows = append(rows, []string{b.Name, kind, b.Role})
+		}
+		TableBoxed(rows)
+	}
+
+	Header("Your permissions")
+	DefaultPrinter.Println()
+	rows := [][]string{{"Operation", "Status", "Missing"}}
+	var denied []string
+	for _, op := range platformOperations(namespace, registryNamespace) {
+		var missing []string
+		for _, p := range op.Permissions {
+			allowed, err := m.canI(p)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				missing = append(missing, p.String())
+			}
+		}
+		status := Green("allowed")
+		if len(missing) > 0 {
+			status = Red("denied")
+			denied = append(denied, op.Name)
+		}
+		rows = append(rows, []string{op.Name, status, strings.Join(missing, ", ")})
+	}
+	TableBoxed(rows)
+
+	if len(denied) > 0 {
+		Warn(fmt.Sprintf("Will fail with Forbidden: %s; ask a cluster admin for the missing permissions", strings.Join(denied, ", ")))
+		return nil
+	}
+	Success("All platform operations are allowed")
+	return nil
+}
+
+// operatorBindings returns the bindings whose subjects include the operator service account.
+func (m *RBACManager) operatorBindings() ([]roleBinding, error) {
+	// #nosec G204 -- fixed kubectl command.
+	out, err := m.kubectl.Output([]string{
+		"get", "clusterrolebindings,rolebindings", "--all-namespaces", "-o",
+		`jsonpath={range .items[*]}{.kind}|{.metadata.namespace}|{.metadata.name}|{.roleRef.kind}/{.roleRef.name}|{range .subjects[*]}{.kind}:{.namespace}:{.name},{end}{"\n"}{end}`,
+	})
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrListRoleBindingsFailed,
+			err,
+			fmt.Sprintf("failed to list role bindings: %v", err),
+			map[string]any{"component": "rbac"},
+		)
+		Error("Failed to list role bindings")
+		logStructuredError(m.logger, wrappedErr, "Failed to list role bindings")
+		return nil, wrappedErr
+	}
+	return parseOperatorBindings(string(out)), nil
+}
+
+// parseOperatorBindings parses the binding jsonpath output of operatorBindings.
+func parseOperatorBindings(out string) []roleBinding {
+	subject := fmt.Sprintf("ServiceAccount:%s:%s", NamespaceMCPRuntime, OperatorServiceAccountName)
+	var bindings []roleBinding
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "|")
+		if len(fields) != 5 {
+			continue
+		}
+		if !slices.Contains(strings.Split(fields[4], ","), subject) {
+			continue
+		}
+		bindings = append(bindings, roleBinding{Kind: fields[0], Namespace: fields[1], Name: fields[2], Role: fields[3]})
+	}
+	return bindings
+}
+
+// canI reports whether the current user holds p. kubectl auth can-i prints
+// "no" and exits non-zero for a denied request.
+func (m *RBACManager) canI(p permission) (bool, error) {
+	args := []string{"auth", "can-i", p.Verb, p.Resource}
+	if p.Namespace != "" {
+		args = append(args, "-n", p.Namespace)
+	}
+	// #nosec G204 -- verbs and resources are fixed; namespaces from CLI flags.
+	out, err := m.kubectl.Output(args)
+	switch strings.TrimSpace(string(out)) {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("unexpected output %q", strings.TrimSpace(string(out)))
+	}
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrCheckPermissionsFailed,
+		err,
+		fmt.Sprintf("failed to check %q: %v", p.String(), err),
+		map[string]any{"verb": p.Verb, "resource": p.Resource, "namespace": p.Namespace, "component": "rbac"},
+	)
+	Error("Failed to check permissions")
+	logStructuredError(m.logger, wrappedErr, "Failed to check permissions")
+	return false, wrappedErr
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestParseOperatorBindings(t *testing.T) {
+	out := "ClusterRoleBinding||mcp-runtime-operator-rolebinding|ClusterRole/mcp-runtime-operator-role|ServiceAccount:mcp-runtime:mcp-runtime-operator-controller-manager,\n" +
+		"ClusterRoleBinding||cluster-admin|ClusterRole/cluster-admin|Group::system:masters,\n" +
+		"RoleBinding|mcp-runtime|leader-election|Role/leader-election|ServiceAccount:mcp-runtime:mcp-runtime-operator-controller-manager,User::alice,\n"
+
+	bindings := parseOperatorBindings(out)
+	if len(bindings) != 2 {
+		t.Fatalf("expected 2 operator bindings, got %+v", bindings)
+	}
+	if bindings[0].Name != "mcp-runtime-operator-rolebinding" || bindings[0].Role != "ClusterRole/mcp-runtime-operator-role" {
+		t.Fatalf("unexpected cluster binding %+v", bindings[0])
+	}
+	if bindings[1].Kind != "RoleBinding" || bindings[1].Namespace != "mcp-runtime" {
+		t.Fatalf("unexpected role binding %+v", bindings[1])
+	}
+}
+
+func TestRBACManager_Report(t *testing.T) {
+	canI := func(denied string) *MockExecutor {
+		return &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+			if len(spec.Args) > 1 && spec.Args[0] == "auth" {
+				if strings.Join(spec.Args[2:4], " ") == denied {
+					return &MockCommand{Args: spec.Args, OutputData: []byte("no\n"), OutputErr: errors.New("exit status 1")}
+				}
+				return &MockCommand{Args: spec.Args, OutputData: []byte("yes\n")}
+			}
+			return &MockCommand{Args: spec.Args}
+		}}
+	}
+
+	t.Run("all operations allowed", func(t *testing.T) {
+		mock := canI("")
+		mgr := NewRBACManager(&KubectlClient{exec: mock}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Report(NamespaceMCPServers, NamespaceRegistry); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "All platform operations are allowed") {
+			t.Fatalf("expected success in output:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "No bindings found") {
+			t.Fatalf("expected missing operator bindings warning:\n%s", buf.String())
+		}
+	})
+
+	t.Run("reports the missing permission", func(t *testing.T) {
+		mock := canI("create pods/exec")
+		mgr := NewRBACManager(&KubectlClient{exec: mock}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Report(NamespaceMCPServers, "registry"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, "create pods/exec -n registry") || !strings.Contains(out, "Will fail with Forbidden: registry push (in-cluster)") {
+			t.Fatalf("expected denied registry push in output:\n%s", out)
+		}
+	})
+
+	t.Run("fails when the cluster is unreachable", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("connection refused")}
+		mgr := NewRBACManager(&KubectlClient{exec: mock}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.Report(NamespaceMCPServers, NamespaceRegistry); !errors.Is(err, ErrListRoleBindingsFailed) {
+			t.Fatalf("expected ErrListRoleBindingsFailed, got %v", err)
+		}
+	})
+}
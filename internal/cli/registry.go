@@ -5,13 +5,16 @@ package cli
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
@@ -55,24 +58,25 @@ func NewRegistryCmdWithManager(mgr *RegistryManager) *cobra.Command {
 	cmd.AddCommand(mgr.newRegistryInfoCmd())
 	cmd.AddCommand(mgr.newRegistryProvisionCmd())
 	cmd.AddCommand(mgr.newRegistryPushCmd())
+	cmd.AddCommand(mgr.newRegistryDfCmd())
+	cmd.AddCommand(mgr.newRegistryTagsCmd())
+	cmd.AddCommand(mgr.newRegistryInspectCmd())
+	cmd.AddCommand(mgr.newRegistryShowConfigCmd())
+	cmd.AddCommand(mgr.newRegistryPruneCmd())
 
 	return cmd
 }
 
 func (m *RegistryManager) newRegistryStatusCmd() *cobra.Command {
-	var namespace string
-
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Check registry status",
 		Long:  "Check the status of the container registry",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.CheckRegistryStatus(namespace)
+			return m.CheckRegistryStatus(registryNamespace())
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceRegistry, "Registry namespace")
-
 	return cmd
 }
 
@@ -93,17 +97,54 @@ func (m *RegistryManager) newRegistryProvisionCmd() *cobra.Command {
 	var url string
 	var username string
 	var password string
+	var passwordStdin bool
+	var passwordFile string
+	var pathPrefix string
 	var operatorImage string
+	var store string
+	var sbom SBOMOptions
 
 	cmd := &cobra.Command{
 		Use:   "provision",
 		Short: "Configure an external registry",
-		Long:  "Configure an external registry to be used for operator/runtime images",
+		Long: `Configure an external registry to be used for operator/runtime images.
+
+Pass the password with --password-stdin or --password-file rather than --password,
+which leaks it into shell history and process lists:
+
+  echo "$REGISTRY_PASSWORD" | mcp-runtime registry provision --url registry.example.com --username robot --password-stdin`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if passwordStdin || passwordFile != "" {
+				secret, err := readRegistryPassword(cmd.InOrStdin(), passwordFile)
+				if err != nil {
+					wrappedErr := wrapWithSentinelAndContext(
+						ErrReadRegistryPasswordFailed,
+						err,
+						fmt.Sprintf("failed to read registry password: %v", err),
+						map[string]any{"file": passwordFile, "component": "registry"},
+					)
+					Error("Failed to read registry password")
+					logStructuredError(m.logger, wrappedErr, "Failed to read registry password")
+					return wrappedErr
+				}
+				password = secret
+			}
 			flagCfg := &ExternalRegistryConfig{
-				URL:      url,
-				Username: username,
-				Password: password,
+				URL:        url,
+				Username:   username,
+				Password:   password,
+				PathPrefix: pathPrefix,
+			}
+			if err := validateRegistryStore(store); err != nil {
+				Error("Invalid registry config store")
+				logStructuredError(m.logger, err, "Invalid registry config store")
+				return err
+			}
+			sbom = sbom.normalized()
+			if err := sbom.Validate(); err != nil {
+				Error("Invalid SBOM settings")
+				logStructuredError(m.logger, err, "Invalid SBOM settings")
+				return err
 			}
 			cfg, err := resolveExternalRegistryConfig(flagCfg)
 			if err != nil {
@@ -115,14 +156,22 @@ func (m *RegistryManager) newRegistryProvisionCmd() *cobra.Command {
 				logStructuredError(m.logger, err, "Registry URL required")
 				return err
 			}
-			if err := saveExternalRegistryConfig(cfg); err != nil {
+			if err := probeRegistry(cfg.URL); err != nil {
+				m.logger.Warn("Registry not reachable", zap.String("url", cfg.URL), zap.Error(err))
+				Warn(fmt.Sprintf("%s is not reachable from this machine (%v); %s", cfg.URL, err, registryProbeHint(cfg.URL)))
+			}
+			save := saveExternalRegistryConfig
+			if store == registryStoreCluster {
+				save = func(cfg *ExternalRegistryConfig) error { return saveClusterRegistryConfig(m.kubectl, cfg) }
+			}
+			if err := save(cfg); err != nil {
 				wrappedErr := wrapWithSentinel(ErrSaveRegistryConfigFailed, err, fmt.Sprintf("failed to save registry config: %v", err))
 				Error("Failed to save registry config")
 				logStructuredError(m.logger, wrappedErr, "Failed to save registry config")
 				return wrappedErr
 			}
 			if cfg.Username != "" && cfg.Password != "" {
-				m.logger.Info("Performing docker login to external registry", zap.String("url", cfg.URL))
+				m.logger.Info("Performing registry login to external registry", zap.String("url", cfg.URL))
 				if err := m.LoginRegistry(cfg.URL, cfg.Username, cfg.Password); err != nil {
 					return err
 				}
@@ -140,6 +189,11 @@ func (m *RegistryManager) newRegistryProvisionCmd() *cobra.Command {
 					logStructuredError(m.logger, wrappedErr, "Failed to build operator image")
 					return wrappedErr
 				}
+				if sbom.Enabled {
+					if err := produceSBOM(m.logger, operatorImage, sbom, generateSBOM); err != nil {
+						return err
+					}
+				}
 				if err := pushOperatorImage(operatorImage); err != nil {
 					wrappedErr := wrapWithSentinelAndContext(
 						ErrPushOperatorImageFailed,
@@ -151,9 +205,14 @@ func (m *RegistryManager) newRegistryProvisionCmd() *cobra.Command {
 					logStructuredError(m.logger, wrappedErr, "Failed to push operator image")
 					return wrappedErr
 				}
+				if sbom.Attach {
+					if err := publishSBOM(m.logger, operatorImage, sbom, attachSBOM); err != nil {
+						return err
+					}
+				}
 			}
-			m.logger.Info("External registry configured", zap.String("url", cfg.URL))
-			fmt.Printf("External registry configured: %s\n", cfg.URL)
+			m.logger.Info("External registry configured", zap.String("url", cfg.URL), zap.String("path_prefix", cfg.PathPrefix))
+			fmt.Printf("External registry configured: %s\n", cfg.Repository())
 			return nil
 		},
 	}
@@ -161,78 +220,167 @@ func (m *RegistryManager) newRegistryProvisionCmd() *cobra.Command {
 	cmd.Flags().StringVar(&url, "url", "", "External registry URL (e.g., registry.example.com)")
 	cmd.Flags().StringVar(&username, "username", "", "Registry username (optional)")
 	cmd.Flags().StringVar(&password, "password", "", "Registry password (optional)")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "Read the registry password from stdin")
+	cmd.Flags().StringVar(&passwordFile, "password-file", "", "Read the registry password from a file")
+	_ = cmd.Flags().MarkDeprecated("password", "it exposes the password in shell history and process lists; use --password-stdin or --password-file")
+	cmd.MarkFlagsMutuallyExclusive("password", "password-stdin", "password-file")
+	cmd.Flags().StringVar(&pathPrefix, "path-prefix", "", "Project or org images are pushed under (e.g., mcp for harbor.example.com/mcp/<image>)")
+	cmd.Flags().StringVar(&store, "store", registryStoreFile, "Where to save the config: file (~/.mcp-runtime/registry.yaml) or cluster (Secret "+RegistryConfigSecretName+" in "+NamespaceMCPRuntime+")")
 	cmd.Flags().StringVar(&operatorImage, "operator-image", "", "Optional: build and push operator image to this external registry (e.g., <registry>/mcp-runtime-operator:latest)")
+	addSBOMFlags(cmd, &sbom)
 
 	return cmd
 }
 
 func (m *RegistryManager) newRegistryPushCmd() *cobra.Command {
-	var image string
+	var images []string
+	var imageFile string
+	var parallel int
 	var registryURL string
 	var name string
 	var mode string
-	var helperNamespace string
+	var helperTemplate string
+	var sign bool
+	var signKey string
 
 	cmd := &cobra.Command{
 		Use:   "push",
-		Short: "Retag and push an image to the platform or provisioned registry",
+		Short: "Retag and push images to the platform or provisioned registry",
+		Long: `Retag and push images to the platform or provisioned registry.
+
+Repeat --image or pass --image-file (one image per line) to push several images
+concurrently; --parallel sets the number of pushes in flight and a summary table
+reports the result of each image.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if image == "" {
-				err := newWithSentinel(ErrImageRequired, "image is required (use --image)")
+			if imageFile != "" {
+				listed, err := readImageList(imageFile)
+				if err != nil {
+					wrappedErr := wrapWithSentinelAndContext(
+						ErrReadImageListFailed,
+						err,
+						fmt.Sprintf("failed to read image list %q: %v", imageFile, err),
+						map[string]any{"file": imageFile, "component": "registry"},
+					)
+					Error("Failed to read image list")
+					logStructuredError(m.logger, wrappedErr, "Failed to read image list")
+					return wrappedErr
+				}
+				images = append(images, listed...)
+			}
+			if len(images) == 0 {
+				err := newWithSentinel(ErrImageRequired, "image is required (use --image or --image-file)")
 				Error("Image required")
 				logStructuredError(m.logger, err, "Image required")
 				return err
 			}
+			if len(images) > 1 && name != "" {
+				err := newWithSentinel(ErrInvalidPushImages, "--name can only be used when pushing a single image")
+				Error("Invalid push options")
+				logStructuredError(m.logger, err, "Invalid push options")
+				return err
+			}
+			if mode != "direct" && mode != "in-cluster" {
+				err := newWithSentinel(ErrUnknownRegistryMode, fmt.Sprintf("unknown mode %q (use direct|in-cluster)", mode))
+				Error("Unknown registry mode")
+				logStructuredError(m.logger, err, "Unknown registry mode")
+				return err
+			}
+			if mode == "in-cluster" && helperTemplate == "" {
+				helperTemplate = GetHelperPodTemplate()
+			}
+
 			targetRegistry := registryURL
 			if targetRegistry == "" {
 				if ext, err := resolveExternalRegistryConfig(nil); err == nil && ext != nil && ext.URL != "" {
-					targetRegistry = strings.TrimSuffix(ext.URL, "/")
+					targetRegistry = ext.Repository()
 				}
 			}
 			if targetRegistry == "" {
 				targetRegistry = getPlatformRegistryURL(m.logger)
 			}
-
-			repo, tag := splitImage(image)
-			if name != "" {
-				repo = name
-			} else {
-				repo = dropRegistryPrefix(repo)
-			}
-			target := targetRegistry + "/" + repo
-			if tag != "" {
-				target = target + ":" + tag
+			if !cmd.Flags().Changed("mode") && targetRegistry == localRegistryHost(m.kubectl, m.logger) {
+				// The local registry is published on this machine; no helper pod needed.
+				mode = "direct"
 			}
 
-			m.logger.Info("Pushing image", zap.String("source", image), zap.String("target", target))
+			push := func(image string) (string, error) {
+				target := pushTarget(image, targetRegistry, name)
+				m.logger.Info("Pushing image", zap.String("source", image), zap.String("target", target))
 
-			switch mode {
-			case "direct":
-				return m.PushDirect(image, target)
-			case "in-cluster":
-				return m.PushInCluster(image, target, helperNamespace)
-			default:
-				err := newWithSentinel(ErrUnknownRegistryMode, fmt.Sprintf("unknown mode %q (use direct|in-cluster)", mode))
-				Error("Unknown registry mode")
-				logStructuredError(m.logger, err, "Unknown registry mode")
+				var err error
+				if mode == "direct" {
+					err = m.PushDirect(image, target)
+				} else {
+					err = m.pushInCluster(image, target, registryNamespace(), helperTemplate)
+				}
+				if err != nil {
+					return target, err
+				}
+				if sign || signKey != "" {
+					return target, m.SignImage(target, signKey)
+				}
+				return target, nil
+			}
+
+			if len(images) == 1 {
+				_, err := push(images[0])
 				return err
 			}
+			return m.pushImages(images, parallel, push)
 		},
 	}
 
-	cmd.Flags().StringVar(&image, "image", "", "Local image to push (required)")
+	cmd.Flags().StringArrayVar(&images, "image", nil, "Local image to push (repeat for multiple images)")
+	cmd.Flags().StringVar(&imageFile, "image-file", "", "File listing images to push, one per line (# starts a comment)")
+	cmd.Flags().IntVar(&parallel, "parallel", defaultPushParallelism, "Number of images pushed concurrently when pushing multiple images")
 	cmd.Flags().StringVar(&registryURL, "registry", "", "Target registry (defaults to provisioned or internal)")
-	cmd.Flags().StringVar(&name, "name", "", "Override target repo/name (default: source name without registry)")
+	cmd.Flags().StringVar(&name, "name", "", "Override target repo/name (default: source name without registry; single image only)")
 	cmd.Flags().StringVar(&mode, "mode", "in-cluster", "Push mode: in-cluster (default, uses skopeo helper) or direct (docker push)")
-	cmd.Flags().StringVar(&helperNamespace, "namespace", NamespaceRegistry, "Namespace to run the in-cluster helper pod")
+	cmd.Flags().StringVar(&helperTemplate, "helper-pod-template", "", "YAML file with resources, nodeSelector, tolerations, imagePullSecrets and security contexts for the helper pod (default: MCP_HELPER_POD_TEMPLATE)")
+	cmd.Flags().BoolVar(&sign, "sign", false, "Sign the pushed image with cosign (keyless unless --sign-key is set)")
+	cmd.Flags().StringVar(&signKey, "sign-key", "", "Cosign private key path or KMS URI for key-based signing (implies --sign)")
 
 	return cmd
 }
 
+// pushTarget returns the registry reference image is pushed to, using name as the repository when set.
+func pushTarget(image, targetRegistry, name string) string {
+	repo, tag := splitImage(image)
+	if name != "" {
+		repo = name
+	} else {
+		repo = dropRegistryPrefix(repo)
+	}
+	target := targetRegistry + "/" + repo
+	if tag != "" {
+		target = target + ":" + tag
+	}
+	return target
+}
+
 type ExternalRegistryConfig struct {
 	URL      string `yaml:"url"`
 	Username string `yaml:"username,omitempty"`
 	Password string `yaml:"password,omitempty"`
+	// PathPrefix is the project or org images go under, such as "mcp" for
+	// harbor.example.com/mcp/<image>.
+	PathPrefix string `yaml:"pathPrefix,omitempty"`
+}
+
+// Repository returns the URL images are pushed under: the registry URL followed by the path
+// prefix, if any.
+func (c *ExternalRegistryConfig) Repository() string {
+	return joinRegistryPath(c.URL, c.PathPrefix)
+}
+
+// joinRegistryPath appends prefix to registry unless registry already ends with it.
+func joinRegistryPath(registry, prefix string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" || strings.HasSuffix(registry, "/"+prefix) {
+		return registry
+	}
+	return registry + "/" + prefix
 }
 
 func registryConfigPath() (string, error) {
@@ -288,49 +436,64 @@ func loadExternalRegistryConfig() (*ExternalRegistryConfig, error) {
 }
 
 // resolveExternalRegistryConfig returns the external registry config using precedence:
-// CLI flags > environment variables (PROVISIONED_REGISTRY_*) > config file.
+// CLI flags > environment variables (PROVISIONED_REGISTRY_*) > cluster Secret > config file.
 // Returns (nil, nil) if no source provides a URL.
 func resolveExternalRegistryConfig(flagCfg *ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
+	cfg, _, err := resolveExternalRegistryConfigWithSources(flagCfg)
+	return cfg, err
+}
+
+// resolveExternalRegistryConfigWithSources resolves the config like resolveExternalRegistryConfig
+// and also reports the source of each value.
+func resolveExternalRegistryConfigWithSources(flagCfg *ExternalRegistryConfig) (*ExternalRegistryConfig, registryConfigSources, error) {
 	var cfg ExternalRegistryConfig
+	var sources registryConfigSources
 	sourceFound := false
 
-	if fileCfg, err := loadExternalRegistryConfig(); err == nil && fileCfg != nil {
-		cfg = *fileCfg
-		if cfg.URL != "" {
+	layer := func(c ExternalRegistryConfig, source string) {
+		if c.URL != "" {
+			cfg.URL, sources.URL = c.URL, source
+			sourceFound = true
+		}
+		if c.Username != "" {
+			cfg.Username, sources.Username = c.Username, source
+			sourceFound = true
+		}
+		if c.Password != "" {
+			cfg.Password, sources.Password = c.Password, source
+			sourceFound = true
+		}
+		if c.PathPrefix != "" {
+			cfg.PathPrefix, sources.PathPrefix = c.PathPrefix, source
 			sourceFound = true
 		}
+	}
+
+	if fileCfg, err := loadExternalRegistryConfig(); err == nil && fileCfg != nil {
+		layer(*fileCfg, registrySourceFile)
 	} else if err != nil {
 		// os.IsNotExist is already handled in loadExternalRegistryConfig
-		return nil, err
+		return nil, sources, err
 	}
 
-	// Load from CLIConfig (which reads from env vars at startup)
-	if DefaultCLIConfig.ProvisionedRegistryURL != "" {
-		cfg.URL = DefaultCLIConfig.ProvisionedRegistryURL
-		sourceFound = true
-	}
-	if DefaultCLIConfig.ProvisionedRegistryUsername != "" {
-		cfg.Username = DefaultCLIConfig.ProvisionedRegistryUsername
-		sourceFound = true
-	}
-	if DefaultCLIConfig.ProvisionedRegistryPassword != "" {
-		cfg.Password = DefaultCLIConfig.ProvisionedRegistryPassword
-		sourceFound = true
+	// The cluster is best effort: before setup, or without cluster access, only the
+	// local sources apply.
+	if clusterCfg, err := loadClusterRegistryConfig(); err == nil && clusterCfg != nil {
+		// The Secret is written as a whole, so its fields replace the file's.
+		cfg, sources = ExternalRegistryConfig{}, registryConfigSources{}
+		layer(*clusterCfg, registrySourceCluster)
 	}
 
+	// Load from CLIConfig (which reads from env vars at startup)
+	layer(ExternalRegistryConfig{
+		URL:        DefaultCLIConfig.ProvisionedRegistryURL,
+		Username:   DefaultCLIConfig.ProvisionedRegistryUsername,
+		Password:   DefaultCLIConfig.ProvisionedRegistryPassword,
+		PathPrefix: DefaultCLIConfig.ProvisionedRegistryPathPrefix,
+	}, registrySourceEnv)
+
 	if flagCfg != nil {
-		if flagCfg.URL != "" {
-			cfg.URL = flagCfg.URL
-			sourceFound = true
-		}
-		if flagCfg.Username != "" {
-			cfg.Username = flagCfg.Username
-			sourceFound = true
-		}
-		if flagCfg.Password != "" {
-			cfg.Password = flagCfg.Password
-			sourceFound = true
-		}
+		layer(*flagCfg, registrySourceFlag)
 	}
 
 	if cfg.URL == "" {
@@ -338,15 +501,15 @@ func resolveExternalRegistryConfig(flagCfg *ExternalRegistryConfig) (*ExternalRe
 			err := newWithSentinel(ErrRegistryURLRequired, "registry url is required")
 			Error("Registry URL required")
 			// Note: No logger available in this helper function
-			return nil, err
+			return nil, sources, err
 		}
-		return nil, nil
+		return nil, sources, nil
 	}
 
-	return &cfg, nil
+	return &cfg, sources, nil
 }
 
-func deployRegistry(logger *zap.Logger, namespace string, port int, registryType, registryStorageSize, manifestPath string) error {
+func deployRegistry(logger *zap.Logger, namespace string, port int, registryType, registryStorageSize, manifestPath string, offlineImages []offlineImage) error {
 	logger.Info("Deploying container registry", zap.String("namespace", namespace), zap.String("type", registryType))
 
 	if registryType == "" {
@@ -381,8 +544,13 @@ func deployRegistry(logger *zap.Logger, namespace string, port int, registryType
 	}
 	// Apply registry manifests via kustomize with namespace override
 	logger.Info("Applying registry manifests")
+	args, cleanup, err := platformApplyArgs(manifestPath, true, offlineImages)
+	if err != nil {
+		return wrapWithSentinel(ErrDeployRegistryFailed, err, fmt.Sprintf("failed to prepare registry manifests: %v", err))
+	}
+	defer cleanup()
 	// #nosec G204 -- manifestPath from internal config, namespace from setup flags.
-	if err := kubectlClient.RunWithOutput([]string{"apply", "-k", manifestPath, "-n", namespace}, os.Stdout, os.Stderr); err != nil {
+	if err := kubectlClient.RunWithOutput(append(args, "-n", namespace), DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrDeployRegistryFailed,
 			err,
@@ -444,7 +612,7 @@ func ensureRegistryStorageSize(logger *zap.Logger, namespace, registryStorageSiz
 	logger.Info("Updating registry storage size", zap.String("from", currentSize), zap.String("to", storageSize))
 	patchPayload := fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":"%s"}}}}`, storageSize)
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	if err := kubectlClient.RunWithOutput([]string{"patch", "pvc", RegistryPVCName, "-n", namespace, "-p", patchPayload}, os.Stdout, os.Stderr); err != nil {
+	if err := kubectlClient.RunWithOutput([]string{"patch", "pvc", RegistryPVCName, "-n", namespace, "-p", patchPayload}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrUpdateRegistryStorageFailed,
 			err,
@@ -502,18 +670,19 @@ func (m *RegistryManager) CheckRegistryStatus(namespace string) error {
 	return nil
 }
 
-// LoginRegistry logs into a container registry.
+// LoginRegistry logs into a container registry. The password is passed to the container
+// tool on stdin, never on its command line.
 func (m *RegistryManager) LoginRegistry(registryURL, username, password string) error {
 	m.logger.Info("Logging into registry", zap.String("url", registryURL))
 
 	// #nosec G204 -- credentials from validated config; password via stdin (not command line).
-	cmd, err := m.exec.Command("docker", []string{"login", "-u", username, "--password-stdin", registryURL})
+	cmd, err := m.exec.Command(commandContext(), containerTool(), []string{"login", "-u", username, "--password-stdin", registryURL})
 	if err != nil {
 		return err
 	}
 	cmd.SetStdin(strings.NewReader(password))
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
 
 	if err := cmd.Run(); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
@@ -533,27 +702,10 @@ func (m *RegistryManager) LoginRegistry(registryURL, username, password string)
 
 // ShowRegistryInfo displays registry connection information.
 func (m *RegistryManager) ShowRegistryInfo() error {
-	ns := NamespaceRegistry
-	// Get registry service
-	// #nosec G204 -- fixed kubectl command with hardcoded namespace.
-	clusterIP, err := m.kubectl.Output([]string{"get", "service", RegistryServiceName, "-n", ns, "-o", "jsonpath={.spec.clusterIP}"})
-	if err != nil {
-		m.logger.Debug("Failed to get registry cluster IP", zap.Error(err))
-	}
-
-	// #nosec G204 -- fixed kubectl command with hardcoded namespace.
-	port, err := m.kubectl.Output([]string{"get", "service", RegistryServiceName, "-n", ns, "-o", "jsonpath={.spec.ports[0].port}"})
-	if err != nil {
-		m.logger.Debug("Failed to get registry port", zap.Error(err))
-	}
-
-	if len(clusterIP) > 0 && len(port) > 0 {
+	if ip, p := m.registryService(); ip != "" && p != "" {
 		Header("Registry Information")
 		DefaultPrinter.Println()
 
-		ip := strings.TrimSpace(string(clusterIP))
-		p := strings.TrimSpace(string(port))
-
 		tableData := [][]string{
 			{"Property", "Value"},
 			{"Internal URL", fmt.Sprintf("%s:%s", ip, p)},
@@ -576,6 +728,44 @@ func (m *RegistryManager) ShowRegistryInfo() error {
 	return nil
 }
 
+// registryService returns the cluster IP and port of the in-cluster registry service; both
+// are empty when the registry is not deployed.
+func (m *RegistryManager) registryService() (string, string) {
+	// #nosec G204 -- fixed kubectl command with hardcoded namespace.
+	clusterIP, err := m.kubectl.Output([]string{"get", "service", RegistryServiceName, "-n", NamespaceRegistry, "-o", "jsonpath={.spec.clusterIP}"})
+	if err != nil {
+		m.logger.Debug("Failed to get registry cluster IP", zap.Error(err))
+	}
+
+	// #nosec G204 -- fixed kubectl command with hardcoded namespace.
+	port, err := m.kubectl.Output([]string{"get", "service", RegistryServiceName, "-n", NamespaceRegistry, "-o", "jsonpath={.spec.ports[0].port}"})
+	if err != nil {
+		m.logger.Debug("Failed to get registry port", zap.Error(err))
+	}
+	return strings.TrimSpace(string(clusterIP)), strings.TrimSpace(string(port))
+}
+
+// readRegistryPassword reads a registry password from file, or from stdin when file is
+// empty, dropping the line ending an echo or editor adds, like docker login does.
+func readRegistryPassword(stdin io.Reader, file string) (string, error) {
+	var data []byte
+	var err error
+	if file != "" {
+		// #nosec G304 -- file comes from the --password-file flag.
+		data, err = os.ReadFile(file)
+	} else {
+		data, err = io.ReadAll(stdin)
+	}
+	if err != nil {
+		return "", err
+	}
+	password := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if password == "" {
+		return "", errors.New("password is empty")
+	}
+	return password, nil
+}
+
 // loginRegistry is a package-level helper for backward compatibility.
 func loginRegistry(logger *zap.Logger, registryURL, username, password string) error {
 	mgr := DefaultRegistryManager(logger)
@@ -606,16 +796,29 @@ func dropRegistryPrefix(repo string) string {
 	return repo
 }
 
-// PushDirect pushes an image directly using docker.
+// imageRegistry returns the registry host of image, the part before the first slash.
+func imageRegistry(image string) string {
+	host, _, _ := strings.Cut(image, "/")
+	return host
+}
+
+// PushDirect pushes an image directly with the container tool. Pushes to the internal
+// registry published on this machine log in first when the registry requires authentication.
 func (m *RegistryManager) PushDirect(source, target string) error {
+	if isLocalRegistryImage(m.kubectl, target) {
+		if creds, ok := internalRegistryCredentials(m.kubectl); ok {
+			if err := m.LoginRegistry(imageRegistry(target), creds.Username, creds.Password); err != nil {
+				return err
+			}
+		}
+	}
+
 	// #nosec G204 -- source/target are image references from internal push logic.
-	tagCmd, err := m.exec.Command("docker", []string{"tag", source, target})
+	tagCmd, err := m.exec.Command(commandContext(), containerTool(), []string{"tag", source, target})
 	if err != nil {
 		return err
 	}
-	tagCmd.SetStdout(os.Stdout)
-	tagCmd.SetStderr(os.Stderr)
-	if err := tagCmd.Run(); err != nil {
+	if err := traceStage("push.tag", func() error { return runStep("tag", tagCmd) }, attribute.String("image.target", target)); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrTagImageFailed,
 			err,
@@ -628,13 +831,11 @@ func (m *RegistryManager) PushDirect(source, target string) error {
 	}
 
 	// #nosec G204 -- target is image reference from internal push logic.
-	pushCmd, err := m.exec.Command("docker", []string{"push", target})
+	pushCmd, err := m.exec.Command(commandContext(), containerTool(), []string{"push", target})
 	if err != nil {
 		return err
 	}
-	pushCmd.SetStdout(os.Stdout)
-	pushCmd.SetStderr(os.Stderr)
-	if err := pushCmd.Run(); err != nil {
+	if err := traceStage("push.upload", func() error { return runStep("push", pushCmd) }, attribute.String("image.target", target)); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrPushImageFailed,
 			err,
@@ -651,8 +852,26 @@ func (m *RegistryManager) PushDirect(source, target string) error {
 }
 
 // PushInCluster pushes an image using an in-cluster helper pod.
+// The helper pod uses the template configured by MCP_HELPER_POD_TEMPLATE, if any.
 func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
-	helperName := fmt.Sprintf("registry-pusher-%d", time.Now().UnixNano())
+	return m.pushInCluster(source, target, helperNS, GetHelperPodTemplate())
+}
+
+func (m *RegistryManager) pushInCluster(source, target, helperNS, templatePath string) error {
+	helperName := fmt.Sprintf("registry-pusher-%d-%d", time.Now().UnixNano(), helperPodSeq.Add(1))
+
+	tmpl, err := LoadHelperPodTemplate(templatePath)
+	if err != nil {
+		Error("Invalid helper pod template")
+		logStructuredError(m.logger, err, "Invalid helper pod template")
+		return err
+	}
+	runArgs, err := helperRunArgs(helperName, helperNS, GetSkopeoImage(), tmpl)
+	if err != nil {
+		Error("Invalid helper pod template")
+		logStructuredError(m.logger, err, "Invalid helper pod template")
+		return err
+	}
 
 	// #nosec G204 -- helperNS from CLI flag, kubectl validates namespace names.
 	if err := m.kubectl.Run([]string{"get", "namespace", helperNS}); err != nil {
@@ -668,7 +887,7 @@ func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
 	}
 
 	// Ensure source is saved to tar; use CWD to satisfy kubectl path validation.
-	tmpFile, err := os.CreateTemp(".", "mcp-img-*.tar")
+	tmpFile, err := tempImageArchive.create()
 	if err != nil {
 		wrappedErr := wrapWithSentinel(ErrCreateTempFileFailed, err, fmt.Sprintf("failed to create temp file: %v", err))
 		Error("Failed to create temp file")
@@ -682,16 +901,14 @@ func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
 		logStructuredError(m.logger, wrappedErr, "Failed to close temp file")
 		return wrappedErr
 	}
-	defer os.Remove(tmpPath)
+	defer removeTempFile(tmpPath)
 
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	saveCmd, err := m.exec.Command("docker", []string{"save", "-o", tmpPath, source})
+	saveCmd, err := m.exec.Command(commandContext(), containerTool(), []string{"save", "-o", tmpPath, source})
 	if err != nil {
 		return err
 	}
-	saveCmd.SetStdout(os.Stdout)
-	saveCmd.SetStderr(os.Stderr)
-	if err := saveCmd.Run(); err != nil {
+	if err := traceStage("push.save", func() error { return runStep("save", saveCmd) }, attribute.String("image.source", source)); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrSaveImageFailed,
 			err,
@@ -705,7 +922,12 @@ func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
 
 	// Start helper pod with skopeo
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	if err := m.kubectl.RunWithOutput([]string{"run", helperName, "-n", helperNS, "--image=" + GetSkopeoImage(), "--restart=Never", "--command", "--", "sh", "-c", "while true; do sleep 3600; done"}, os.Stdout, os.Stderr); err != nil {
+	if err := traceStage("push.helper-start", func() error {
+		if err := ensureProxyPodSecret(m.kubectl, helperNS); err != nil {
+			return err
+		}
+		return m.kubectl.RunWithOutput(runArgs, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+	}); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrStartHelperPodFailed,
 			err,
@@ -722,7 +944,9 @@ func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
 	}()
 
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	if err := m.kubectl.RunWithOutput([]string{"wait", "--for=condition=Ready", "pod/" + helperName, "-n", helperNS, "--timeout=60s"}, os.Stdout, os.Stderr); err != nil {
+	if err := traceStage("push.helper-wait", func() error {
+		return m.kubectl.RunWithOutput([]string{"wait", "--for=condition=Ready", "pod/" + helperName, "-n", helperNS, "--timeout=60s"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+	}); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrHelperPodNotReady,
 			err,
@@ -736,7 +960,9 @@ func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
 
 	// Copy tar into pod
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	if err := m.kubectl.RunWithOutput([]string{"cp", tmpPath, fmt.Sprintf("%s/%s:%s", helperNS, helperName, "/tmp/image.tar")}, os.Stdout, os.Stderr); err != nil {
+	if err := traceStage("push.copy", func() error {
+		return m.kubectl.RunWithOutput([]string{"cp", tmpPath, fmt.Sprintf("%s/%s:%s", helperNS, helperName, "/tmp/image.tar")}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+	}); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrCopyImageToHelperFailed,
 			err,
@@ -748,10 +974,31 @@ func (m *RegistryManager) PushInCluster(source, target, helperNS string) error {
 		return wrappedErr
 	}
 
-	// Push using skopeo from inside cluster (registry is http, so disable tls verify)
+	// Push using skopeo from inside cluster (registry is http, so disable tls verify). An
+	// authenticated registry gets an auth file written from stdin, keeping credentials off argv.
+	copyArgs := []string{"exec", "-n", helperNS, helperName, "--", "skopeo", "copy", "--dest-tls-verify=false"}
+	var authFile []byte
+	if creds, ok := internalRegistryCredentials(m.kubectl); ok {
+		if authFile, err = registryAuthFile(imageRegistry(target), creds); err != nil {
+			return err
+		}
+		copyArgs = []string{"exec", "-i", "-n", helperNS, helperName, "--", "sh", "-c",
+			`cat > /tmp/auth.json && exec skopeo copy --dest-tls-verify=false --dest-authfile=/tmp/auth.json "$@"`, "skopeo"}
+	}
+	copyArgs = append(copyArgs, "docker-archive:/tmp/image.tar", "docker://"+target)
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	if err := m.kubectl.RunWithOutput([]string{"exec", "-n", helperNS, helperName, "--",
-		"skopeo", "copy", "--dest-tls-verify=false", "docker-archive:/tmp/image.tar", "docker://" + target}, os.Stdout, os.Stderr); err != nil {
+	if err := traceStage("push.upload", func() error {
+		cmd, err := m.kubectl.CommandArgs(copyArgs)
+		if err != nil {
+			return err
+		}
+		if authFile != nil {
+			cmd.SetStdin(bytes.NewReader(authFile))
+		}
+		cmd.SetStdout(DefaultPrinter.Stdout())
+		cmd.SetStderr(DefaultPrinter.Stderr())
+		return cmd.Run()
+	}, attribute.String("image.target", target)); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrPushImageFromHelperFailed,
 			err,
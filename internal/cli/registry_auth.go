@@ -0,0 +1,307 @@
+package cli
+
+// This file implements htpasswd authentication for the internal registry.
+// "setup --registry-auth htpasswd" generates credentials, stores them in the registry namespace,
+// switches the registry to htpasswd auth and hands pull secrets to the operator and server pods.
+// Push helpers read the same credentials, so pushes keep working once the registry is locked.
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	registryAuthNone     = "none"
+	registryAuthHtpasswd = "htpasswd"
+
+	// registryAuthSecretName holds the htpasswd file and the generated credentials in the registry namespace.
+	registryAuthSecretName = "registry-auth" // #nosec G101 -- secret name, not a credential.
+
+	// registryPullSecretName is the dockerconfigjson secret for the internal registry.
+	registryPullSecretName = "registry-pull-creds" // #nosec G101 -- secret name, not a credential.
+
+	// registryAuthUsername is the user generated for the internal registry.
+	registryAuthUsername = "mcp-runtime"
+
+	// registryAuthMountPath is where the registry container reads the htpasswd file.
+	registryAuthMountPath = "/auth"
+)
+
+// registryAuthModes lists the supported --registry-auth values.
+var registryAuthModes = []string{registryAuthNone, registryAuthHtpasswd}
+
+// registryPullSecretNamespaces returns the namespaces that need the internal registry pull secret:
+// the registry namespace for push helpers and builders, the operator namespace and the servers namespace.
+func registryPullSecretNamespaces() []string {
+	return []string{NamespaceRegistry, NamespaceMCPRuntime, serverNamespace()}
+}
+
+// registryCredentials are the internal registry username and password.
+type registryCredentials struct {
+	Username string
+	Password string
+}
+
+// validateRegistryAuth checks a --registry-auth value.
+func validateRegistryAuth(mode string) error {
+	if !slices.Contains(registryAuthModes, mode) {
+		return newWithSentinel(ErrInvalidRegistryAuth, fmt.Sprintf("unsupported registry auth %q (use one of: %s)", mode, strings.Join(registryAuthModes, ", ")))
+	}
+	return nil
+}
+
+// generateRegistryCredentials returns the registry user with a random password.
+func generateRegistryCredentials() (registryCredentials, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return registryCredentials{}, err
+	}
+	return registryCredentials{Username: registryAuthUsername, Password: hex.EncodeToString(buf)}, nil
+}
+
+// htpasswdLine returns the bcrypt htpasswd entry for creds; the registry accepts only bcrypt.
+func htpasswdLine(creds registryCredentials) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return creds.Username + ":" + string(hash) + "\n", nil
+}
+
+// internalRegistryCredentials reads the generated registry credentials. It reports false
+// when the registry runs without authentication or the secret cannot be read.
+func internalRegistryCredentials(kubectl KubectlRunner) (registryCredentials, bool) {
+	// #nosec G204 -- fixed secret name and namespace.
+	cmd, err := kubectl.CommandArgs([]string{
+		"get", "secret", registryAuthSecretName, "-n", NamespaceRegistry, "--ignore-not-found",
+		"-o", "jsonpath={.data.username} {.data.password}",
+	})
+	if err != nil {
+		return registryCredentials{}, false
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return registryCredentials{}, false
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return registryCredentials{}, false
+	}
+	username, userErr := base64.StdEncoding.DecodeString(fields[0])
+	password, passErr := base64.StdEncoding.DecodeString(fields[1])
+	if userErr != nil || passErr != nil || len(username) == 0 || len(password) == 0 {
+		return registryCredentials{}, false
+	}
+	return registryCredentials{Username: string(username), Password: string(password)}, true
+}
+
+// registryAPIScript runs wget against the registry API inside the registry pod. The basic
+// auth token of an authenticated registry is read from stdin, keeping it off the kubectl
+// command line.
+const registryAPIScript = `IFS= read -r auth; if [ -n "$auth" ]; then set -- --header "Authorization: Basic $auth" "$@"; fi; exec wget -qO- "$@"`
+
+// registryAPIGetter reads a path of the internal registry API, sending extra headers such
+// as "Accept: ..." with the request.
+type registryAPIGetter func(path string, headers ...string) ([]byte, error)
+
+// internalRegistryAPI returns a registryAPIGetter that queries the registry from inside its
+// pod in namespace, authenticating with the generated credentials when the registry has them.
+func internalRegistryAPI(kubectl KubectlRunner, namespace string) registryAPIGetter {
+	var auth string
+	if creds, ok := internalRegistryCredentials(kubectl); ok {
+		auth = base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))
+	}
+	return func(path string, headers ...string) ([]byte, error) {
+		args := []string{"exec", "-i", "-n", namespace, "deploy/" + RegistryDeploymentName, "--", "sh", "-c", registryAPIScript, "registry-api"}
+		for _, header := range headers {
+			args = append(args, "--header", header)
+		}
+		// #nosec G204 -- fixed kubectl exec; path is built from registry catalog and validated values.
+		cmd, err := kubectl.CommandArgs(append(args, registryLocalAPI+path))
+		if err != nil {
+			return nil, err
+		}
+		cmd.SetStdin(strings.NewReader(auth + "\n"))
+		return cmd.Output()
+	}
+}
+
+// registryAuthFile renders a containers auth file granting creds on registry, for tools such
+// as skopeo that read credentials from a file instead of the command line.
+func registryAuthFile(registry string, creds registryCredentials) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"auths": map[string]any{
+			registry: map[string]string{"auth": base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))},
+		},
+	})
+}
+
+// registryAuthSecretManifest renders the registry-auth secret.
+func registryAuthSecretManifest(creds registryCredentials, htpasswd string) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": registryAuthSecretName, "namespace": NamespaceRegistry},
+		"type":       "Opaque",
+		"stringData": map[string]string{
+			"htpasswd": htpasswd,
+			"username": creds.Username,
+			"password": creds.Password,
+		},
+	})
+}
+
+// registryAuthPatch is the strategic-merge patch that mounts the htpasswd file into the
+// registry container and turns on htpasswd authentication.
+func registryAuthPatch() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+			"containers": []any{map[string]any{
+				"name": RegistryDeploymentName,
+				"env": []any{
+					map[string]string{"name": "REGISTRY_AUTH", "value": registryAuthHtpasswd},
+					map[string]string{"name": "REGISTRY_AUTH_HTPASSWD_REALM", "value": "mcp-runtime registry"},
+					map[string]string{"name": "REGISTRY_AUTH_HTPASSWD_PATH", "value": registryAuthMountPath + "/htpasswd"},
+				},
+				"volumeMounts": []any{map[string]any{"name": registryAuthSecretName, "mountPath": registryAuthMountPath, "readOnly": true}},
+			}},
+			"volumes": []any{map[string]any{
+				"name":   registryAuthSecretName,
+				"secret": map[string]any{"secretName": registryAuthSecretName, "items": []any{map[string]string{"key": "htpasswd", "path": "htpasswd"}}},
+			}},
+		}}},
+	})
+}
+
+// operatorPullSecretPatch adds the registry pull secret to the operator pods, whose image
+// is served by the internal registry.
+func operatorPullSecretPatch() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+			"imagePullSecrets": []any{map[string]string{"name": registryPullSecretName}},
+		}}},
+	})
+}
+
+type registryAuthStep struct{}
+
+func (s registryAuthStep) Name() string { return "registry-auth" }
+func (s registryAuthStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupRegistryAuthStep(logger, ctx.UsingExternalRegistry, deps)
+}
+
+func setupRegistryAuthStep(logger *zap.Logger, usingExternalRegistry bool, deps SetupDeps) error {
+	// Step 5b: Enable registry authentication (if requested)
+	Step("Step 5b: Enable registry authentication")
+	if usingExternalRegistry {
+		Info("Skipped (external registry manages its own authentication)")
+		return nil
+	}
+
+	for _, namespace := range registryPullSecretNamespaces() {
+		if err := deps.EnsureNamespace(namespace); err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrEnableRegistryAuthFailed,
+				err,
+				fmt.Sprintf("failed to ensure namespace %q for the registry pull secret: %v", namespace, err),
+				map[string]any{"namespace": namespace, "component": "setup"},
+			)
+			Error("Failed to enable registry authentication")
+			logStructuredError(logger, wrappedErr, "Failed to enable registry authentication")
+			return wrappedErr
+		}
+	}
+
+	registryURL := deps.GetPlatformRegistryURL(logger)
+	if err := deps.EnableRegistryAuth(logger, registryURL); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrEnableRegistryAuthFailed,
+			err,
+			fmt.Sprintf("failed to enable registry authentication: %v", err),
+			map[string]any{"registry": registryURL, "namespace": NamespaceRegistry, "component": "setup"},
+		)
+		Error("Failed to enable registry authentication")
+		logStructuredError(logger, wrappedErr, "Failed to enable registry authentication")
+		return wrappedErr
+	}
+
+	Success(fmt.Sprintf("Registry requires htpasswd authentication; credentials are in secret %s/%s", NamespaceRegistry, registryAuthSecretName))
+	Info(fmt.Sprintf("Servers outside %s need a copy of pull secret %s in their namespace", serverNamespace(), registryPullSecretName))
+	return nil
+}
+
+func enableRegistryAuth(logger *zap.Logger, registryURL string) error {
+	return enableRegistryAuthWithKubectl(kubectlClient, registryURL)
+}
+
+// enableRegistryAuthWithKubectl stores the registry credentials (reusing existing ones so
+// re-running setup keeps pushed credentials valid), creates pull secrets, switches the
+// registry to htpasswd and points the operator at the pull secret.
+func enableRegistryAuthWithKubectl(kubectl KubectlRunner, registryURL string) error {
+	creds, ok := internalRegistryCredentials(kubectl)
+	if !ok {
+		Info("Generating registry credentials")
+		var err error
+		if creds, err = generateRegistryCredentials(); err != nil {
+			return err
+		}
+	}
+	htpasswd, err := htpasswdLine(creds)
+	if err != nil {
+		return err
+	}
+	manifest, err := registryAuthSecretManifest(creds, htpasswd)
+	if err != nil {
+		return err
+	}
+	// #nosec G204 -- fixed kubectl apply; manifest passed on stdin.
+	applyCmd, err := kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	applyCmd.SetStdin(strings.NewReader(string(manifest)))
+	applyCmd.SetStdout(DefaultPrinter.Stdout())
+	applyCmd.SetStderr(DefaultPrinter.Stderr())
+	if err := applyCmd.Run(); err != nil {
+		return fmt.Errorf("apply secret %s: %w", registryAuthSecretName, err)
+	}
+
+	for _, namespace := range registryPullSecretNamespaces() {
+		if err := ensureImagePullSecretWithKubectl(kubectl, namespace, registryPullSecretName, registryURL, creds.Username, creds.Password); err != nil {
+			return err
+		}
+	}
+
+	patch, err := registryAuthPatch()
+	if err != nil {
+		return err
+	}
+	Info("Enabling htpasswd authentication on the registry")
+	// #nosec G204 -- fixed deployment; patch built from constants.
+	if err := kubectl.RunWithOutput([]string{"patch", "deployment", RegistryDeploymentName, "-n", NamespaceRegistry, "--type=strategic", "-p", string(patch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return fmt.Errorf("patch registry deployment: %w", err)
+	}
+
+	operatorPatch, err := operatorPullSecretPatch()
+	if err != nil {
+		return err
+	}
+	Info("Configuring operator pull secret")
+	// #nosec G204 -- fixed deployment; patch built from constants.
+	if err := kubectl.RunWithOutput([]string{"patch", "deployment", OperatorDeploymentName, "-n", NamespaceMCPRuntime, "--type=strategic", "-p", string(operatorPatch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return fmt.Errorf("patch operator deployment: %w", err)
+	}
+	// #nosec G204 -- fixed deployment and env var.
+	if err := kubectl.RunWithOutput([]string{"set", "env", "deployment/" + OperatorDeploymentName, "-n", NamespaceMCPRuntime, "MCP_REGISTRY_PULL_SECRET=" + registryPullSecretName}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return fmt.Errorf("set operator env: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// registryAuthSecretOutput is the jsonpath output of the registry-auth secret.
+func registryAuthSecretOutput(username, password string) []byte {
+	return []byte(base64.StdEncoding.EncodeToString([]byte(username)) + " " + base64.StdEncoding.EncodeToString([]byte(password)))
+}
+
+func TestValidateRegistryAuth(t *testing.T) {
+	for _, mode := range []string{"none", "htpasswd"} {
+		if err := validateRegistryAuth(mode); err != nil {
+			t.Fatalf("unexpected error for %s: %v", mode, err)
+		}
+	}
+	if err := validateRegistryAuth("token"); !errors.Is(err, ErrInvalidRegistryAuth) {
+		t.Fatalf("expected ErrInvalidRegistryAuth, got %v", err)
+	}
+}
+
+func TestHtpasswdLine(t *testing.T) {
+	creds, err := generateRegistryCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creds.Username != registryAuthUsername || len(creds.Password) != 48 {
+		t.Fatalf("unexpected credentials %+v", creds)
+	}
+
+	line, err := htpasswdLine(creds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	user, hash, ok := strings.Cut(strings.TrimSuffix(line, "\n"), ":")
+	if !ok || user != creds.Username {
+		t.Fatalf("unexpected htpasswd line %q", line)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(creds.Password)); err != nil {
+		t.Fatalf("hash does not match password: %v", err)
+	}
+}
+
+func TestInternalRegistryCredentials(t *testing.T) {
+	t.Run("reads the secret", func(t *testing.T) {
+		mock := &MockExecutor{DefaultOutput: registryAuthSecretOutput("mcp-runtime", "s3cret")}
+		creds, ok := internalRegistryCredentials(&KubectlClient{exec: mock})
+		if !ok || creds.Username != "mcp-runtime" || creds.Password != "s3cret" {
+			t.Fatalf("unexpected credentials %+v (ok=%v)", creds, ok)
+		}
+		if got := strings.Join(mock.LastCommand().Args[:5], " "); got != "get secret registry-auth -n registry" {
+			t.Fatalf("unexpected command %q", got)
+		}
+	})
+
+	t.Run("reports missing secret", func(t *testing.T) {
+		mock := &MockExecutor{}
+		if _, ok := internalRegistryCredentials(&KubectlClient{exec: mock}); ok {
+			t.Fatal("expected no credentials for empty output")
+		}
+	})
+
+	t.Run("reports unreadable secret", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("forbidden")}
+		if _, ok := internalRegistryCredentials(&KubectlClient{exec: mock}); ok {
+			t.Fatal("expected no credentials when the secret cannot be read")
+		}
+	})
+}
+
+func TestInternalRegistryAPI(t *testing.T) {
+	var last *MockCommand
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		last = &MockCommand{Args: spec.Args}
+		if contains(spec.Args, "secret") {
+			last.OutputData = registryAuthSecretOutput("mcp-runtime", "s3cret")
+		}
+		return last
+	}
+	get := internalRegistryAPI(&KubectlClient{exec: mock}, NamespaceRegistry)
+	if _, err := get("/v2/_catalog", "Accept: application/json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := strings.Join(last.Args, " ")
+	if strings.Contains(args, "s3cret") {
+		t.Fatalf("expected no credentials on the command line, got %q", args)
+	}
+	if !strings.HasSuffix(args, "--header Accept: application/json http://localhost:5000/v2/_catalog") {
+		t.Fatalf("unexpected command %q", args)
+	}
+	stdin, _ := io.ReadAll(last.StdinR)
+	if want := base64.StdEncoding.EncodeToString([]byte("mcp-runtime:s3cret")) + "\n"; string(stdin) != want {
+		t.Fatalf("expected the basic auth token on stdin, got %q", stdin)
+	}
+}
+
+func TestRegistryAuthFile(t *testing.T) {
+	data, err := registryAuthFile("registry.local:5000", registryCredentials{Username: "mcp-runtime", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"auths":{"registry.local:5000":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("mcp-runtime:s3cret")) + `"}}}`
+	if string(data) != want {
+		t.Fatalf("got %s, want %s", data, want)
+	}
+}
+
+func TestBuildSetupStepsWithRegistryAuth(t *testing.T) {
+	steps := buildSetupSteps(&SetupContext{Plan: SetupPlan{RegistryAuth: registryAuthHtpasswd}})
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Name())
+	}
+	if got := strings.Join(names, ","); !strings.Contains(got, "operator-deploy,registry-auth,verify") {
+		t.Fatalf("expected registry-auth between operator deploy and verify, got %s", got)
+	}
+
+	for _, step := range buildSetupSteps(&SetupContext{Plan: SetupPlan{RegistryAuth: registryAuthNone}}) {
+		if step.Name() == "registry-auth" {
+			t.Fatal("registry-auth step should be opt-in")
+		}
+	}
+}
+
+func TestRegistryAuthStep(t *testing.T) {
+	var ensured []string
+	var enabledURL string
+	deps := SetupDeps{
+		EnsureNamespace:        func(ns string) error { ensured = append(ensured, ns); return nil },
+		GetPlatformRegistryURL: func(*zap.Logger) string { return "10.0.0.1:5000" },
+		EnableRegistryAuth:     func(_ *zap.Logger, url string) error { enabledURL = url; return nil },
+	}
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := (registryAuthStep{}).Run(zap.NewNop(), deps, &SetupContext{}); err != nil {
+		t.Fatalf("registry-auth step failed: %v", err)
+	}
+	if enabledURL != "10.0.0.1:5000" || len(ensured) != len(registryPullSecretNamespaces()) {
+		t.Fatalf("unexpected calls: url=%q namespaces=%v", enabledURL, ensured)
+	}
+
+	deps.EnableRegistryAuth = func(*zap.Logger, string) error { return errors.New("patch failed") }
+	if err := (registryAuthStep{}).Run(zap.NewNop(), deps, &SetupContext{}); !errors.Is(err, ErrEnableRegistryAuthFailed) {
+		t.Fatalf("expected ErrEnableRegistryAuthFailed, got %v", err)
+	}
+
+	enabledURL = ""
+	if err := (registryAuthStep{}).Run(zap.NewNop(), deps, &SetupContext{UsingExternalRegistry: true}); err != nil {
+		t.Fatalf("external registry should be skipped, got %v", err)
+	}
+}
+
+func TestEnableRegistryAuthWithKubectl(t *testing.T) {
+	run := func(t *testing.T, existing []byte) (*MockExecutor, map[string]string) {
+		t.Helper()
+		secret := map[string]string{}
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			switch {
+			case contains(spec.Args, "secret") && contains(spec.Args, registryAuthSecretName):
+				cmd.OutputData = existing
+			case spec.Args[0] == "apply":
+				cmd.RunFunc = func() error {
+					data, _ := io.ReadAll(cmd.StdinR)
+					var obj struct {
+						Metadata   struct{ Name string }
+						StringData map[string]string
+					}
+					if json.Unmarshal(data, &obj) == nil && obj.Metadata.Name == registryAuthSecretName {
+						secret = obj.StringData
+					}
+					return nil
+				}
+			}
+			return cmd
+		}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := enableRegistryAuthWithKubectl(&KubectlClient{exec: mock}, "10.0.0.1:5000"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return mock, secret
+	}
+
+	t.Run("generates credentials and configures registry and operator", func(t *testing.T) {
+		mock, secret := run(t, nil)
+		if secret["username"] != registryAuthUsername || secret["password"] == "" || !strings.HasPrefix(secret["htpasswd"], registryAuthUsername+":$2") {
+			t.Fatalf("unexpected registry-auth secret %v", secret)
+		}
+
+		var applies int
+		var registryPatch, operatorPatch, setEnv string
+		for _, cmd := range mock.Commands {
+			args := strings.Join(cmd.Args, " ")
+			switch {
+			case cmd.Args[0] == "apply":
+				applies++
+			case strings.HasPrefix(args, "patch deployment registry "):
+				registryPatch = args
+			case strings.HasPrefix(args, "patch deployment "+OperatorDeploymentName):
+				operatorPatch = args
+			case strings.HasPrefix(args, "set env"):
+				setEnv = args
+			}
+		}
+		// registry-auth plus one pull secret per namespace.
+		if applies != 1+len(registryPullSecretNamespaces()) {
+			t.Fatalf("expected %d applies, got %d", 1+len(registryPullSecretNamespaces()), applies)
+		}
+		if !strings.Contains(registryPatch, `"REGISTRY_AUTH_HTPASSWD_PATH","value":"/auth/htpasswd"`) {
+			t.Fatalf("unexpected registry patch %q", registryPatch)
+		}
+		if !strings.Contains(operatorPatch, `"imagePullSecrets":[{"name":"registry-pull-creds"}]`) {
+			t.Fatalf("unexpected operator patch %q", operatorPatch)
+		}
+		if !strings.HasSuffix(setEnv, "MCP_REGISTRY_PULL_SECRET=registry-pull-creds") {
+			t.Fatalf("unexpected operator env %q", setEnv)
+		}
+	})
+
+	t.Run("reuses existing credentials", func(t *testing.T) {
+		_, secret := run(t, registryAuthSecretOutput("mcp-runtime", "existing"))
+		if secret["password"] != "existing" {
+			t.Fatalf("expected existing password to be kept, got %v", secret)
+		}
+	})
+}
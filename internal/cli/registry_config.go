@@ -0,0 +1,187 @@
+package cli
+
+// This file keeps the external registry config in the cluster. "registry provision --store cluster"
+// writes it to the mcp-runtime-registry-config Secret, which then ranks above the local
+// ~/.mcp-runtime/registry.yaml so every machine resolves the same registry; flags and
+// PROVISIONED_REGISTRY_* variables still override both. "registry show-config --source"
+// reports which of these supplied each value.
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// Where "registry provision" stores the config.
+const (
+	registryStoreFile    = "file"
+	registryStoreCluster = "cluster"
+)
+
+var registryStores = []string{registryStoreFile, registryStoreCluster}
+
+// Sources of external registry config values, from lowest to highest precedence.
+const (
+	registrySourceFile    = "file"
+	registrySourceCluster = "cluster"
+	registrySourceEnv     = "env"
+	registrySourceFlag    = "flag"
+)
+
+// registryConfigSources records which source supplied each field of the resolved config.
+type registryConfigSources struct {
+	URL        string
+	Username   string
+	Password   string
+	PathPrefix string
+}
+
+// loadClusterRegistryConfig reads the config Secret; a variable so tests can stub the cluster.
+var loadClusterRegistryConfig = func() (*ExternalRegistryConfig, error) {
+	return loadClusterRegistryConfigWithKubectl(kubectlClient)
+}
+
+// loadClusterRegistryConfigWithKubectl returns the config stored in the cluster, or nil
+// when the Secret does not exist.
+func loadClusterRegistryConfigWithKubectl(kubectl *KubectlClient) (*ExternalRegistryConfig, error) {
+	// #nosec G204 -- fixed kubectl command with constant names.
+	out, err := kubectl.Output([]string{"get", "secret", RegistryConfigSecretName, "-n", NamespaceMCPRuntime,
+		"-o", "json", "--ignore-not-found", "--request-timeout=5s"})
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(out))) == 0 {
+		return nil, nil
+	}
+	var secret struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(out, &secret); err != nil {
+		return nil, fmt.Errorf("parse secret %s: %w", RegistryConfigSecretName, err)
+	}
+	var cfg ExternalRegistryConfig
+	fields := map[string]*string{"url": &cfg.URL, "username": &cfg.Username, "password": &cfg.Password, "pathPrefix": &cfg.PathPrefix}
+	for key, target := range fields {
+		value, err := base64.StdEncoding.DecodeString(secret.Data[key])
+		if err != nil {
+			return nil, fmt.Errorf("decode secret %s key %s: %w", RegistryConfigSecretName, key, err)
+		}
+		*target = string(value)
+	}
+	if cfg.URL == "" {
+		return nil, newWithSentinel(ErrRegistryURLMissingInConfig, fmt.Sprintf("registry url missing in secret %s/%s", NamespaceMCPRuntime, RegistryConfigSecretName))
+	}
+	return &cfg, nil
+}
+
+// saveClusterRegistryConfig writes cfg to the config Secret, creating the operator
+// namespace if setup has not run yet. The manifest goes through stdin to keep the
+// password off the command line.
+func saveClusterRegistryConfig(kubectl *KubectlClient, cfg *ExternalRegistryConfig) error {
+	if cfg == nil || cfg.URL == "" {
+		return newWithSentinel(ErrRegistryURLRequired, "registry url is required")
+	}
+	// #nosec G204 -- fixed kubectl command, manifest via stdin.
+	cmd, err := kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(strings.NewReader(renderRegistryConfigSecret(cfg)))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	return cmd.Run()
+}
+
+func renderRegistryConfigSecret(cfg *ExternalRegistryConfig) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: %[1]s
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: %[2]s
+  namespace: %[1]s
+  labels:
+    %[3]s: %[4]s
+type: Opaque
+stringData:
+  url: %[5]q
+  username: %[6]q
+  password: %[7]q
+  pathPrefix: %[8]q
+`, NamespaceMCPRuntime, RegistryConfigSecretName, LabelManagedBy, LabelManagedByValue, cfg.URL, cfg.Username, cfg.Password, cfg.PathPrefix)
+}
+
+func validateRegistryStore(store string) error {
+	if !slices.Contains(registryStores, store) {
+		return newWithSentinel(ErrInvalidRegistryStore, fmt.Sprintf("unsupported registry config store %q (use one of: %s)", store, strings.Join(registryStores, ", ")))
+	}
+	return nil
+}
+
+func (m *RegistryManager) newRegistryShowConfigCmd() *cobra.Command {
+	var showSource bool
+
+	cmd := &cobra.Command{
+		Use:   "show-config",
+		Short: "Show the external registry config",
+		Long: `Show the external registry config the CLI resolves. Values come from, in increasing
+precedence: ~/.mcp-runtime/registry.yaml, the mcp-runtime-registry-config Secret in the
+cluster, and the PROVISIONED_REGISTRY_URL/USERNAME/PASSWORD/PATH_PREFIX environment variables.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ShowRegistryConfig(showSource)
+		},
+	}
+
+	cmd.Flags().BoolVar(&showSource, "source", false, "Show where each value came from")
+
+	return cmd
+}
+
+// ShowRegistryConfig prints the resolved external registry config with the password masked.
+func (m *RegistryManager) ShowRegistryConfig(showSource bool) error {
+	cfg, sources, err := resolveExternalRegistryConfigWithSources(nil)
+	if err != nil {
+		Error("Failed to resolve registry config")
+		logStructuredError(m.logger, err, "Failed to resolve registry config")
+		return err
+	}
+	if cfg == nil {
+		Info("No external registry configured; the internal registry is used")
+		return nil
+	}
+	m.logger.Debug("Resolved registry config", zap.String("url", cfg.URL), zap.String("source", sources.URL))
+
+	password := ""
+	if cfg.Password != "" {
+		password = "********"
+	}
+	values := []struct{ key, value, source string }{
+		{"url", cfg.URL, sources.URL},
+		{"username", cfg.Username, sources.Username},
+		{"password", password, sources.Password},
+		{"pathPrefix", cfg.PathPrefix, sources.PathPrefix},
+	}
+	header := []string{"Key", "Value"}
+	if showSource {
+		header = append(header, "Source")
+	}
+	rows := [][]string{header}
+	for _, v := range values {
+		row := []string{v.key, valueOrDefault(v.value, "-")}
+		if showSource {
+			row = append(row, valueOrDefault(v.source, "-"))
+		}
+		rows = append(rows, row)
+	}
+	Table(rows)
+	return nil
+}
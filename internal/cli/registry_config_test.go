@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// stubClusterRegistryConfig makes the cluster store return cfg for the rest of the test.
+func stubClusterRegistryConfig(t *testing.T, cfg *ExternalRegistryConfig) {
+	t.Helper()
+	orig := loadClusterRegistryConfig
+	t.Cleanup(func() { loadClusterRegistryConfig = orig })
+	loadClusterRegistryConfig = func() (*ExternalRegistryConfig, error) { return cfg, nil }
+}
+
+func TestLoadClusterRegistryConfigWithKubectl(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	t.Run("decodes secret", func(t *testing.T) {
+		secret := fmt.Sprintf(`{"data":{"url":%q,"username":%q,"password":%q,"pathPrefix":%q}}`, b64("registry.example.com"), b64("ci"), b64("s3cret"), b64("mcp"))
+		mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+			return &MockCommand{Args: spec.Args, OutputData: []byte(secret)}
+		}}
+		cfg, err := loadClusterRegistryConfigWithKubectl(&KubectlClient{exec: mock})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := ExternalRegistryConfig{URL: "registry.example.com", Username: "ci", Password: "s3cret", PathPrefix: "mcp"}
+		if cfg == nil || *cfg != want {
+			t.Fatalf("got %#v, want %#v", cfg, want)
+		}
+		if !contains(mock.LastCommand().Args, RegistryConfigSecretName) || !contains(mock.LastCommand().Args, "--ignore-not-found") {
+			t.Fatalf("unexpected command %v", mock.LastCommand().Args)
+		}
+	})
+
+	t.Run("returns nil without secret", func(t *testing.T) {
+		mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+			return &MockCommand{Args: spec.Args}
+		}}
+		cfg, err := loadClusterRegistryConfigWithKubectl(&KubectlClient{exec: mock})
+		if err != nil || cfg != nil {
+			t.Fatalf("expected nil config, got %#v, %v", cfg, err)
+		}
+	})
+
+	t.Run("requires url", func(t *testing.T) {
+		mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+			return &MockCommand{Args: spec.Args, OutputData: []byte(fmt.Sprintf(`{"data":{"username":%q}}`, b64("ci")))}
+		}}
+		if _, err := loadClusterRegistryConfigWithKubectl(&KubectlClient{exec: mock}); !errors.Is(err, ErrRegistryURLMissingInConfig) {
+			t.Fatalf("expected ErrRegistryURLMissingInConfig, got %v", err)
+		}
+	})
+}
+
+func TestSaveClusterRegistryConfig(t *testing.T) {
+	var applied string
+	mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		cmd.RunFunc = func() error {
+			data, _ := io.ReadAll(cmd.StdinR)
+			applied = string(data)
+			return nil
+		}
+		return cmd
+	}}
+	cfg := &ExternalRegistryConfig{URL: "registry.example.com", Username: "ci", Password: `p"ss`}
+	if err := saveClusterRegistryConfig(&KubectlClient{exec: mock}, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(mock.LastCommand().Args, " ") != "apply -f -" {
+		t.Fatalf("unexpected command %v", mock.LastCommand().Args)
+	}
+
+	docs := strings.Split(applied, "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("expected namespace and secret, got:\n%s", applied)
+	}
+	var secret struct {
+		Metadata struct {
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"metadata"`
+		StringData map[string]string `yaml:"stringData"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[1]), &secret); err != nil {
+		t.Fatalf("parse secret: %v", err)
+	}
+	if secret.Metadata.Name != RegistryConfigSecretName || secret.Metadata.Namespace != NamespaceMCPRuntime {
+		t.Fatalf("secret targets %s/%s", secret.Metadata.Namespace, secret.Metadata.Name)
+	}
+	if secret.StringData["url"] != cfg.URL || secret.StringData["username"] != cfg.Username || secret.StringData["password"] != cfg.Password {
+		t.Fatalf("unexpected stringData %v", secret.StringData)
+	}
+
+	if err := saveClusterRegistryConfig(&KubectlClient{exec: mock}, &ExternalRegistryConfig{}); !errors.Is(err, ErrRegistryURLRequired) {
+		t.Fatalf("expected ErrRegistryURLRequired, got %v", err)
+	}
+}
+
+func TestShowRegistryConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	origConfig := DefaultCLIConfig
+	t.Cleanup(func() { DefaultCLIConfig = origConfig })
+	DefaultCLIConfig = &CLIConfig{ProvisionedRegistryPassword: "env-pass"}
+	if err := saveExternalRegistryConfig(&ExternalRegistryConfig{URL: "file.example.com", Username: "file-user"}); err != nil {
+		t.Fatalf("save file config: %v", err)
+	}
+	stubClusterRegistryConfig(t, nil)
+
+	mgr := NewRegistryManager(&KubectlClient{exec: &MockExecutor{}}, &MockExecutor{}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+	if err := mgr.ShowRegistryConfig(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"file.example.com", "file-user", "********", registrySourceFile, registrySourceEnv} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "env-pass") {
+		t.Fatalf("password leaked in output:\n%s", out)
+	}
+}
+
+func TestValidateRegistryStore(t *testing.T) {
+	for _, store := range registryStores {
+		if err := validateRegistryStore(store); err != nil {
+			t.Fatalf("expected %q to be valid: %v", store, err)
+		}
+	}
+	if err := validateRegistryStore("vault"); !errors.Is(err, ErrInvalidRegistryStore) {
+		t.Fatalf("expected ErrInvalidRegistryStore, got %v", err)
+	}
+}
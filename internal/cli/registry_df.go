@@ -0,0 +1,270 @@
+package cli
+
+// This file implements "registry df", a storage usage report for the internal registry.
+// Repositories come from the registry's v2 catalog API; tag counts and sizes are estimated by
+// scanning the registry's filesystem layout on the PVC, counting each shared blob once in the
+// total so operators can see what garbage collection or a larger PVC would buy them.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// registryLocalAPI is the registry API address as seen from inside the registry pod.
+	registryLocalAPI = "http://localhost:5000"
+
+	// registryStorageScanScript lists tag links, layer links and blob sizes under the
+	// registry's filesystem root, plus the volume's df line.
+	registryStorageScanScript = `cd /var/lib/registry || exit 1
+df -Pk . | tail -n 1
+cd docker/registry/v2 2>/dev/null || exit 0
+find repositories -path '*/_manifests/tags/*/current/link' -o -path '*/_layers/sha256/*/link'
+find blobs -type f -name data -exec stat -c '%s %n' {} +`
+
+	// registryUsageWarnPercent is the filesystem usage above which df suggests action.
+	registryUsageWarnPercent = 80
+)
+
+// repoUsage is the storage estimate for one repository.
+type repoUsage struct {
+	Name string
+	Tags int
+	// Size is the total size of blobs the repository references.
+	Size int64
+	// Unique is the size of blobs no other repository references.
+	Unique int64
+}
+
+// registryStorage is the parsed result of registryStorageScanScript.
+type registryStorage struct {
+	// FSSize and FSUsed are the volume size and usage in bytes.
+	FSSize int64
+	FSUsed int64
+	tags   map[string]int
+	layers map[string][]string
+	blobs  map[string]int64
+}
+
+func (m *RegistryManager) newRegistryDfCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "df",
+		Short: "Show registry storage usage",
+		Long: `Show storage usage of the internal registry: PVC capacity and usage, number of
+repositories and tags, and an approximate size per repository.
+
+Size counts every blob a repository references; Unique counts only blobs no other
+repository shares, i.e. roughly what deleting the repository and running garbage
+collection would free.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ShowRegistryUsage(registryNamespace())
+		},
+	}
+
+	return cmd
+}
+
+// ShowRegistryUsage prints the storage usage report of the internal registry.
+func (m *RegistryManager) ShowRegistryUsage(namespace string) error {
+	repos, storage, err := m.registryUsage(namespace)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrRegistryUsageFailed,
+			err,
+			fmt.Sprintf("failed to read registry storage usage: %v", err),
+			map[string]any{"namespace": namespace, "component": "registry"},
+		)
+		Error("Failed to read registry storage usage")
+		logStructuredError(m.logger, wrappedErr, "Failed to read registry storage usage")
+		return wrappedErr
+	}
+
+	// #nosec G204 -- fixed kubectl command, namespace from CLI flag.
+	capacity, _ := m.kubectl.Output([]string{"get", "pvc", RegistryPVCName, "-n", namespace, "-o", "jsonpath={.status.capacity.storage}"})
+
+	usage := storage.repoUsage(repos)
+	totalTags := 0
+	for _, r := range usage {
+		totalTags += r.Tags
+	}
+
+	Header("Registry Storage")
+	DefaultPrinter.Println()
+	TableBoxed([][]string{
+		{"Property", "Value"},
+		{"PVC Capacity", valueOrDash(strings.TrimSpace(string(capacity)))},
+		{"Volume Used", fmt.Sprintf("%s of %s (%d%%)", formatBytes(storage.FSUsed), formatBytes(storage.FSSize), storage.usedPercent())},
+		{"Repositories", strconv.Itoa(len(usage))},
+		{"Tags", strconv.Itoa(totalTags)},
+		{"Blobs (deduplicated)", formatBytes(storage.blobTotal())},
+	})
+
+	if len(usage) > 0 {
+		DefaultPrinter.Println()
+		rows := [][]string{{"Repository", "Tags", "Size", "Unique"}}
+		for _, r := range usage {
+			rows = append(rows, []string{r.Name, strconv.Itoa(r.Tags), formatBytes(r.Size), formatBytes(r.Unique)})
+		}
+		Table(rows)
+	}
+
+	if storage.usedPercent() >= registryUsageWarnPercent {
+		DefaultPrinter.Println()
+		Warn(fmt.Sprintf("Registry volume is %d%% full; delete unused tags and run garbage collection, or grow the %s PVC", storage.usedPercent(), RegistryPVCName))
+	}
+	return nil
+}
+
+// registryUsage reads the catalog and scans registry storage inside the registry pod.
+func (m *RegistryManager) registryUsage(namespace string) ([]string, *registryStorage, error) {
+	target := "deploy/" + RegistryDeploymentName
+
+	catalogOut, err := internalRegistryAPI(m.kubectl, namespace)("/v2/_catalog?n=10000")
+	if err != nil {
+		return nil, nil, fmt.Errorf("read catalog: %w", err)
+	}
+	repos, err := parseRegistryCatalog(catalogOut)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// #nosec G204 -- fixed kubectl exec running a constant script.
+	scanOut, err := m.kubectl.Output([]string{"exec", "-n", namespace, target, "--", "sh", "-c", registryStorageScanScript})
+	if err != nil {
+		return nil, nil, fmt.Errorf("scan storage: %w", err)
+	}
+	return repos, parseRegistryStorage(string(scanOut)), nil
+}
+
+func parseRegistryCatalog(out []byte) ([]string, error) {
+	var catalog struct {
+		Repositories []string `json:"repositories"`
+	}
+	if err := json.Unmarshal(out, &catalog); err != nil {
+		return nil, fmt.Errorf("parse catalog: %w", err)
+	}
+	return catalog.Repositories, nil
+}
+
+// parseRegistryStorage parses the output of registryStorageScanScript.
+func parseRegistryStorage(out string) *registryStorage {
+	s := &registryStorage{tags: map[string]int{}, layers: map[string][]string{}, blobs: map[string]int64{}}
+	for i, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if i == 0 {
+			// Filesystem 1024-blocks Used Available Capacity Mounted-on
+			if fields := strings.Fields(line); len(fields) >= 4 {
+				size, _ := strconv.ParseInt(fields[1], 10, 64)
+				used, _ := strconv.ParseInt(fields[2], 10, 64)
+				s.FSSize, s.FSUsed = size*1024, used*1024
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "repositories/"):
+			s.addLink(strings.TrimPrefix(line, "repositories/"))
+		default:
+			sizeField, path, ok := strings.Cut(line, " ")
+			if !ok || !strings.HasPrefix(path, "blobs/") {
+				continue
+			}
+			size, err := strconv.ParseInt(sizeField, 10, 64)
+			if err != nil {
+				continue
+			}
+			// blobs/sha256/<xx>/<digest>/data
+			parts := strings.Split(path, "/")
+			if len(parts) == 5 {
+				s.blobs[parts[3]] = size
+			}
+		}
+	}
+	return s
+}
+
+// addLink records a tag or layer link path relative to the repositories directory.
+func (s *registryStorage) addLink(path string) {
+	if repo, rest, ok := strings.Cut(path, "/_manifests/tags/"); ok && strings.HasSuffix(rest, "/current/link") {
+		s.tags[repo]++
+		return
+	}
+	if repo, rest, ok := strings.Cut(path, "/_layers/sha256/"); ok {
+		if digest, _, ok := strings.Cut(rest, "/"); ok {
+			s.layers[repo] = append(s.layers[repo], digest)
+		}
+	}
+}
+
+// repoUsage returns per-repository estimates sorted by size, largest first.
+func (s *registryStorage) repoUsage(repos []string) []repoUsage {
+	refs := map[string]int{}
+	for _, digests := range s.layers {
+		for _, d := range digests {
+			refs[d]++
+		}
+	}
+
+	usage := make([]repoUsage, 0, len(repos))
+	for _, name := range repos {
+		r := repoUsage{Name: name, Tags: s.tags[name]}
+		for _, d := range s.layers[name] {
+			r.Size += s.blobs[d]
+			if refs[d] == 1 {
+				r.Unique += s.blobs[d]
+			}
+		}
+		usage = append(usage, r)
+	}
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].Size != usage[j].Size {
+			return usage[i].Size > usage[j].Size
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	return usage
+}
+
+// blobTotal is the size of all stored blobs, each counted once.
+func (s *registryStorage) blobTotal() int64 {
+	var total int64
+	for _, size := range s.blobs {
+		total += size
+	}
+	return total
+}
+
+func (s *registryStorage) usedPercent() int {
+	if s.FSSize == 0 {
+		return 0
+	}
+	return int(s.FSUsed * 100 / s.FSSize)
+}
+
+// formatBytes renders n using binary units (KiB, MiB, ...).
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
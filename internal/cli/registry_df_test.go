@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const testRegistryScan = `/dev/sdb 10485760 9437184 1048576 90% /var/lib/registry
+repositories/team/api/_manifests/tags/v1/current/link
+repositories/team/api/_manifests/tags/v2/current/link
+repositories/team/api/_layers/sha256/aaa/link
+repositories/team/api/_layers/sha256/bbb/link
+repositories/web/_manifests/tags/latest/current/link
+repositories/web/_layers/sha256/aaa/link
+repositories/web/_layers/sha256/ccc/link
+100 blobs/sha256/aa/aaa/data
+2048 blobs/sha256/bb/bbb/data
+512 blobs/sha256/cc/ccc/data
+`
+
+func TestParseRegistryStorage(t *testing.T) {
+	s := parseRegistryStorage(testRegistryScan)
+	if s.FSSize != 10*1024*1024*1024 || s.usedPercent() != 90 {
+		t.Fatalf("unexpected filesystem usage %d/%d", s.FSUsed, s.FSSize)
+	}
+	if s.blobTotal() != 2660 {
+		t.Fatalf("expected deduplicated blob total 2660, got %d", s.blobTotal())
+	}
+
+	usage := s.repoUsage([]string{"web", "team/api"})
+	want := []repoUsage{
+		{Name: "team/api", Tags: 2, Size: 2148, Unique: 2048},
+		{Name: "web", Tags: 1, Size: 612, Unique: 512},
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("expected %d repositories, got %v", len(want), usage)
+	}
+	for i := range want {
+		if usage[i] != want[i] {
+			t.Fatalf("repo %d: expected %+v, got %+v", i, want[i], usage[i])
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Fatalf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestRegistryManager_ShowRegistryUsage(t *testing.T) {
+	t.Run("prints report and warns when nearly full", func(t *testing.T) {
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				args := strings.Join(spec.Args, " ")
+				switch {
+				case strings.Contains(args, "_catalog"):
+					return &MockCommand{OutputData: []byte(`{"repositories":["team/api","web"]}`)}
+				case strings.Contains(args, "sh -c"):
+					return &MockCommand{OutputData: []byte(testRegistryScan)}
+				case strings.Contains(args, "pvc"):
+					return &MockCommand{OutputData: []byte("10Gi")}
+				}
+				return &MockCommand{}
+			},
+		}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ShowRegistryUsage(NamespaceRegistry); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{"10Gi", "team/api", "2.1 KiB", "90% full"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("expected %q in output:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("wraps exec failures", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("pod not found")}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ShowRegistryUsage(NamespaceRegistry); !errors.Is(err, ErrRegistryUsageFailed) {
+			t.Fatalf("expected ErrRegistryUsageFailed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,279 @@
+package cli
+
+// This file implements "registry inspect", which reads an image's manifest and config from the
+// provisioned or internal registry (the same way "registry tags" does) and prints its digest,
+// platform, creation time, labels and layers. For a multi-platform index one platform image is
+// shown, picked with --platform.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// imageLayer is one layer of an image manifest.
+type imageLayer struct {
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	MediaType string `json:"mediaType"`
+}
+
+// imageManifest is the part of an image manifest or index that inspect reads.
+type imageManifest struct {
+	MediaType string `json:"mediaType"`
+	Config    struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Layers    []imageLayer `json:"layers"`
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+			Variant      string `json:"variant"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+// imageInspection is what "registry inspect" reports about an image.
+type imageInspection struct {
+	Digest      string
+	MediaType   string
+	IndexDigest string
+	Platforms   []string
+	Platform    string
+	Created     time.Time
+	Labels      map[string]string
+	Layers      []imageLayer
+}
+
+func (m *RegistryManager) newRegistryInspectCmd() *cobra.Command {
+	var internal bool
+	var platform string
+
+	cmd := &cobra.Command{
+		Use:   "inspect [repository:tag|repository@digest]",
+		Short: "Show the manifest and config of an image",
+		Long: `Show the digest, platform, creation time, labels and layers of an image in the registry.
+
+The provisioned registry is queried when one is configured, otherwise the internal registry.
+For a multi-platform image the --platform image is shown (default: the first one).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.InspectImage(args[0], registryNamespace(), platform, internal)
+		},
+	}
+
+	cmd.Flags().BoolVar(&internal, "internal", false, "Query the internal registry even when a provisioned registry is configured")
+	cmd.Flags().StringVar(&platform, "platform", "", "Platform to show for a multi-platform image, such as linux/arm64")
+
+	return cmd
+}
+
+// InspectImage prints the manifest and config details of image in the provisioned or internal registry.
+func (m *RegistryManager) InspectImage(image, namespace, platform string, internal bool) error {
+	repository, ref, err := parseImageReference(image)
+	if err != nil {
+		wrappedErr := newWithSentinel(ErrInvalidImageReference, err.Error())
+		Error("Invalid image reference")
+		logStructuredError(m.logger, wrappedErr, "Invalid image reference")
+		return wrappedErr
+	}
+
+	get, repository, registry := m.registryGetterFor(repository, namespace, internal)
+	m.logger.Info("Inspecting image", zap.String("registry", registry), zap.String("repository", repository), zap.String("reference", ref))
+	inspection, err := inspectImage(get, repository, ref, platform)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrInspectImageFailed,
+			err,
+			fmt.Sprintf("failed to inspect %s in %s: %v", image, registry, err),
+			map[string]any{"repository": repository, "reference": ref, "registry": registry, "component": "registry"},
+		)
+		Error("Failed to inspect image")
+		logStructuredError(m.logger, wrappedErr, "Failed to inspect image")
+		return wrappedErr
+	}
+
+	printImageInspection(repository, ref, inspection)
+	return nil
+}
+
+// parseImageReference splits repository:tag or repository@digest; the tag defaults to latest.
+func parseImageReference(image string) (string, string, error) {
+	image = strings.TrimSpace(image)
+	repository, ref := image, "latest"
+	if r, digest, ok := strings.Cut(image, "@"); ok {
+		repository, ref = r, digest
+		if !imageDigestRe.MatchString(ref) {
+			return "", "", fmt.Errorf("invalid digest %q in image reference %q", ref, image)
+		}
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repository, ref = image[:i], image[i+1:]
+		if !imageTagRe.MatchString(ref) {
+			return "", "", fmt.Errorf("invalid tag %q in image reference %q", ref, image)
+		}
+	}
+	repository = strings.Trim(repository, "/")
+	if !repositoryNameRe.MatchString(repository) {
+		return "", "", fmt.Errorf("invalid repository name %q in image reference %q", repository, image)
+	}
+	return repository, ref, nil
+}
+
+// inspectImage reads the manifest of repository:ref and its image config. When ref names an
+// index, the image for platform (os/arch[/variant]) is read, or the first one when platform is empty.
+func inspectImage(get registryGetter, repository, ref, platform string) (imageInspection, error) {
+	var inspection imageInspection
+	raw, manifest, err := fetchImageManifest(get, repository, ref)
+	if err != nil {
+		return inspection, err
+	}
+
+	if len(manifest.Manifests) > 0 {
+		inspection.IndexDigest = contentDigest(raw)
+		next := ""
+		for _, entry := range manifest.Manifests {
+			p := entry.Platform
+			// Attestation manifests are listed with platform unknown/unknown.
+			if p.OS == "unknown" || !imageDigestRe.MatchString(entry.Digest) {
+				continue
+			}
+			name := p.OS + "/" + p.Architecture
+			if p.Variant != "" {
+				name += "/" + p.Variant
+			}
+			inspection.Platforms = append(inspection.Platforms, name)
+			if next == "" && (platform == "" || platform == name || platform == p.OS+"/"+p.Architecture) {
+				next = entry.Digest
+			}
+		}
+		if next == "" {
+			if platform != "" {
+				return inspection, fmt.Errorf("no %s image in index (available: %s)", platform, strings.Join(inspection.Platforms, ", "))
+			}
+			return inspection, fmt.Errorf("index has no platform images")
+		}
+		if raw, manifest, err = fetchImageManifest(get, repository, next); err != nil {
+			return inspection, err
+		}
+		if len(manifest.Manifests) > 0 {
+			return inspection, fmt.Errorf("nested index %s is not supported", next)
+		}
+	}
+
+	inspection.Digest = contentDigest(raw)
+	inspection.MediaType = manifest.MediaType
+	inspection.Layers = manifest.Layers
+	if !imageDigestRe.MatchString(manifest.Config.Digest) {
+		return inspection, fmt.Errorf("invalid config digest %q", manifest.Config.Digest)
+	}
+	blob, err := get("/v2/"+repository+"/blobs/"+manifest.Config.Digest, "application/json")
+	if err != nil {
+		return inspection, err
+	}
+	var config struct {
+		Created      time.Time `json:"created"`
+		OS           string    `json:"os"`
+		Architecture string    `json:"architecture"`
+		Variant      string    `json:"variant"`
+		Config       struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(blob, &config); err != nil {
+		return inspection, fmt.Errorf("parse image config: %w", err)
+	}
+	inspection.Created = config.Created
+	inspection.Labels = config.Config.Labels
+	if config.OS != "" {
+		inspection.Platform = config.OS + "/" + config.Architecture
+		if config.Variant != "" {
+			inspection.Platform += "/" + config.Variant
+		}
+	}
+	return inspection, nil
+}
+
+func fetchImageManifest(get registryGetter, repository, ref string) ([]byte, imageManifest, error) {
+	var manifest imageManifest
+	raw, err := get("/v2/"+repository+"/manifests/"+ref, registryManifestAccept)
+	if err != nil {
+		return nil, manifest, err
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, manifest, fmt.Errorf("parse manifest: %w", err)
+	}
+	return raw, manifest, nil
+}
+
+// contentDigest returns the sha256 digest of a manifest as the registry computes it.
+func contentDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func printImageInspection(repository, ref string, inspection imageInspection) {
+	var size int64
+	for _, layer := range inspection.Layers {
+		size += layer.Size
+	}
+	created := "-"
+	if !inspection.Created.IsZero() {
+		created = inspection.Created.UTC().Format(time.RFC3339)
+	}
+	rows := [][]string{
+		{"Key", "Value"},
+		{"image", repository + refSeparator(ref) + ref},
+		{"digest", inspection.Digest},
+	}
+	if inspection.IndexDigest != "" {
+		rows = append(rows,
+			[]string{"index digest", inspection.IndexDigest},
+			[]string{"platforms", strings.Join(inspection.Platforms, ", ")},
+		)
+	}
+	rows = append(rows,
+		[]string{"media type", orDash(inspection.MediaType)},
+		[]string{"platform", orDash(inspection.Platform)},
+		[]string{"created", created},
+		[]string{"size", formatBytes(size)},
+	)
+	Table(rows)
+
+	if len(inspection.Labels) > 0 {
+		Section("Labels")
+		keys := make([]string, 0, len(inspection.Labels))
+		for k := range inspection.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		labels := [][]string{{"Label", "Value"}}
+		for _, k := range keys {
+			labels = append(labels, []string{k, inspection.Labels[k]})
+		}
+		Table(labels)
+	}
+
+	Section("Layers")
+	layers := [][]string{{"Digest", "Size"}}
+	for _, layer := range inspection.Layers {
+		layers = append(layers, []string{layer.Digest, formatBytes(layer.Size)})
+	}
+	Table(layers)
+}
+
+// refSeparator returns the separator between a repository and ref: @ for digests, : for tags.
+func refSeparator(ref string) string {
+	if imageDigestRe.MatchString(ref) {
+		return "@"
+	}
+	return ":"
+}
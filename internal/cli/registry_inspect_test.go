@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const (
+	testAMD64Digest  = "sha256:5555555555555555555555555555555555555555555555555555555555555555"
+	testARM64Digest  = "sha256:6666666666666666666666666666666666666666666666666666666666666666"
+	testARM64Config  = "sha256:7777777777777777777777777777777777777777777777777777777777777777"
+	testLayerDigest1 = "sha256:8888888888888888888888888888888888888888888888888888888888888888"
+)
+
+// testInspectAPI serves team/web:v1 as a two-platform index.
+var testInspectAPI = map[string]string{
+	"/v2/team/web/manifests/v1": `{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[` +
+		`{"digest":"` + testAMD64Digest + `","platform":{"os":"linux","architecture":"amd64"}},` +
+		`{"digest":"` + testARM64Digest + `","platform":{"os":"linux","architecture":"arm64","variant":"v8"}},` +
+		`{"digest":"` + testAttestDigest + `","platform":{"os":"unknown","architecture":"unknown"}}]}`,
+	"/v2/team/web/manifests/" + testAMD64Digest: `{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"digest":"` + testConfigDigestV1 + `"},` +
+		`"layers":[{"digest":"` + testLayerDigest1 + `","size":2048}]}`,
+	"/v2/team/web/manifests/" + testARM64Digest: `{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"digest":"` + testARM64Config + `"},"layers":[]}`,
+	"/v2/team/web/blobs/" + testConfigDigestV1: `{"created":"2026-01-02T03:04:05Z","os":"linux","architecture":"amd64",` +
+		`"config":{"Labels":{"org.opencontainers.image.source":"https://example.com/web"}}}`,
+	"/v2/team/web/blobs/" + testARM64Config: `{"created":"2026-01-02T03:04:05Z","os":"linux","architecture":"arm64","variant":"v8"}`,
+}
+
+func testInspectGetter(path, accept string) ([]byte, error) {
+	if body, ok := testInspectAPI[path]; ok {
+		return []byte(body), nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestInspectImage(t *testing.T) {
+	inspection, err := inspectImage(testInspectGetter, "team/web", "v1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if inspection.IndexDigest != contentDigest([]byte(testInspectAPI["/v2/team/web/manifests/v1"])) {
+		t.Fatalf("unexpected index digest %s", inspection.IndexDigest)
+	}
+	if inspection.Digest != contentDigest([]byte(testInspectAPI["/v2/team/web/manifests/"+testAMD64Digest])) {
+		t.Fatalf("unexpected digest %s", inspection.Digest)
+	}
+	if inspection.Platform != "linux/amd64" || strings.Join(inspection.Platforms, ",") != "linux/amd64,linux/arm64/v8" {
+		t.Fatalf("unexpected platforms %q %v", inspection.Platform, inspection.Platforms)
+	}
+	if len(inspection.Layers) != 1 || inspection.Layers[0].Size != 2048 {
+		t.Fatalf("unexpected layers %+v", inspection.Layers)
+	}
+	if inspection.Labels["org.opencontainers.image.source"] != "https://example.com/web" {
+		t.Fatalf("unexpected labels %v", inspection.Labels)
+	}
+
+	arm, err := inspectImage(testInspectGetter, "team/web", "v1", "linux/arm64")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if arm.Platform != "linux/arm64/v8" {
+		t.Fatalf("expected the arm64 image, got %q", arm.Platform)
+	}
+
+	if _, err := inspectImage(testInspectGetter, "team/web", "v1", "windows/amd64"); err == nil || !strings.Contains(err.Error(), "linux/arm64/v8") {
+		t.Fatalf("expected an error listing the available platforms, got %v", err)
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image, repository, ref string
+		wantErr                bool
+	}{
+		{image: "team/web:v1", repository: "team/web", ref: "v1"},
+		{image: "web", repository: "web", ref: "latest"},
+		{image: "team/web@" + testAMD64Digest, repository: "team/web", ref: testAMD64Digest},
+		{image: "team/web@sha256:abc", wantErr: true},
+		{image: "Team/Web:v1", wantErr: true},
+		{image: "team/web:", wantErr: true},
+	}
+	for _, tt := range tests {
+		repository, ref, err := parseImageReference(tt.image)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: unexpected error %v", tt.image, err)
+		}
+		if !tt.wantErr && (repository != tt.repository || ref != tt.ref) {
+			t.Fatalf("%s: got %s %s, want %s %s", tt.image, repository, ref, tt.repository, tt.ref)
+		}
+	}
+}
+
+func TestRegistryManager_InspectImage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mock := &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			last := spec.Args[len(spec.Args)-1]
+			for path, body := range testInspectAPI {
+				if strings.HasSuffix(last, path) {
+					return &MockCommand{OutputData: []byte(body)}
+				}
+			}
+			return &MockCommand{OutputErr: errors.New("not found")}
+		},
+	}
+	mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.InspectImage("team/web:v1", NamespaceRegistry, "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"2026-01-02T03:04:05Z", "linux/amd64", testLayerDigest1, "org.opencontainers.image.source"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	if err := mgr.InspectImage("team/web:v2", NamespaceRegistry, "", true); !errors.Is(err, ErrInspectImageFailed) {
+		t.Fatalf("expected ErrInspectImageFailed, got %v", err)
+	}
+	if err := mgr.InspectImage("team/web@latest", NamespaceRegistry, "", true); !errors.Is(err, ErrInvalidImageReference) {
+		t.Fatalf("expected ErrInvalidImageReference, got %v", err)
+	}
+}
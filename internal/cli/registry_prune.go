@@ -0,0 +1,425 @@
+package cli
+
+// This file implements "registry prune-unreferenced". It lists the images MCPServers use
+// (spec.image and the rollback history in status.history) and the images of every workload in
+// the cluster, then deletes the tags of the internal registry nobody references and runs the
+// registry's garbage collector to free their blobs. Tags are removed from the registry's
+// filesystem layout, the same layout "registry df" scans, since the registry API cannot
+// delete through the exec'd wget.
+//
+// Garbage collection is only safe while nothing is pushed, so the registry is switched to
+// read-only mode for it. It runs without --delete-untagged: that would also delete manifests
+// that running pods pin by digest after their tag moved. Instead the manifest of a pruned tag
+// is deleted explicitly when no kept tag and no pod uses its digest.
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// registryRepositoriesDir is where the registry keeps repository metadata on the PVC.
+	registryRepositoriesDir = "/var/lib/registry/docker/registry/v2/repositories"
+
+	// registryServerConfigPath is the registry's configuration file inside the registry image.
+	registryServerConfigPath = "/etc/docker/registry/config.yml"
+
+	// defaultPruneGracePeriod keeps recently pushed tags that no server references yet.
+	defaultPruneGracePeriod = 24 * time.Hour
+
+	// pruneWorkloadKinds are the workload kinds whose images are kept, so images such as the
+	// operator's are never pruned even though no MCPServer names them.
+	pruneWorkloadKinds = "deployments,statefulsets,daemonsets,jobs,cronjobs"
+
+	// registryReadOnlyEnv switches the registry's storage to read-only mode: pulls keep
+	// working, pushes are refused.
+	registryReadOnlyEnv = "REGISTRY_STORAGE_MAINTENANCE_READONLY"
+
+	// registryRolloutTimeout bounds the registry restarts around garbage collection.
+	registryRolloutTimeout = 5 * time.Minute
+)
+
+// imageReferences is the set of images in use, keyed by repository path without registry host.
+type imageReferences struct {
+	// tags holds "repository:tag" entries.
+	tags map[string]bool
+	// repositories holds repositories referenced by digest; all their tags are kept.
+	repositories map[string]bool
+	// digests holds the manifest digests referenced by workloads and running pods; their
+	// manifests are never deleted.
+	digests map[string]bool
+}
+
+func newImageReferences() imageReferences {
+	return imageReferences{tags: map[string]bool{}, repositories: map[string]bool{}, digests: map[string]bool{}}
+}
+
+// add records image, with tag applied when the image names no tag or digest.
+func (r imageReferences) add(image, tag string) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return
+	}
+	if repo, digest, ok := strings.Cut(image, "@"); ok {
+		r.repositories[imageRepositoryPath(repo)] = true
+		r.digests[digest] = true
+		return
+	}
+	repo := image
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repo, tag = image[:i], image[i+1:]
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	r.tags[imageRepositoryPath(repo)+":"+tag] = true
+}
+
+// keeps reports whether repository:tag is referenced.
+func (r imageReferences) keeps(repository, tag string) bool {
+	return r.repositories[repository] || r.tags[repository+":"+tag]
+}
+
+// imageRepositoryPath drops the registry host from an image repository, as the operator does
+// when it rewrites an image to another registry.
+func imageRepositoryPath(repo string) string {
+	first, rest, ok := strings.Cut(repo, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return rest
+	}
+	return repo
+}
+
+// registryPrunePlan lists what prune-unreferenced deletes.
+type registryPrunePlan struct {
+	// Tags are the unreferenced tags older than the grace period.
+	Tags []pruneCandidate
+	// Repositories are pruned as a whole because none of their tags is kept.
+	Repositories []string
+	// Manifests are the "repository@digest" manifests only pruned tags used.
+	Manifests []string
+	// Kept counts the tags that stay.
+	Kept int
+}
+
+// pruneCandidate is a tag to delete.
+type pruneCandidate struct {
+	Repository string
+	Tag        string
+	Created    time.Time
+}
+
+func (m *RegistryManager) newRegistryPruneCmd() *cobra.Command {
+	var dryRun bool
+	var gracePeriod time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "prune-unreferenced",
+		Short: "Delete internal registry tags no MCPServer references",
+		Long: `Delete the tags of the internal registry that no MCPServer references, then run the
+registry's garbage collector to free their storage.
+
+An image is referenced when an MCPServer uses it in spec.image or keeps it in
+status.history for rollbacks, or when any Deployment, StatefulSet, DaemonSet, Job or
+CronJob in the cluster runs it (such as the operator). Images referenced by digest keep
+their whole repository, and manifests a running pod uses are kept. Tags pushed within
+--grace-period, or whose age cannot be read, are kept. Repositories left without tags
+are removed.
+
+The registry is restarted in read-only mode for garbage collection, so pushes are
+refused until it is restarted again in read-write mode at the end.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.PruneUnreferenced(registryNamespace(), gracePeriod, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print what would be deleted without deleting anything")
+	cmd.Flags().DurationVar(&gracePeriod, "grace-period", defaultPruneGracePeriod, "Keep unreferenced tags created within this period")
+
+	return cmd
+}
+
+// PruneUnreferenced deletes the unreferenced tags of the internal registry in namespace.
+func (m *RegistryManager) PruneUnreferenced(namespace string, gracePeriod time.Duration, dryRun bool) error {
+	if gracePeriod < 0 {
+		err := newWithSentinel(ErrPruneRegistryFailed, fmt.Sprintf("--grace-period must not be negative, got %s", gracePeriod))
+		Error("Invalid grace period")
+		logStructuredError(m.logger, err, "Invalid grace period")
+		return err
+	}
+
+	plan, err := m.planRegistryPrune(namespace, gracePeriod, time.Now())
+	if err != nil {
+		return m.pruneError(err, "Failed to plan registry prune", namespace)
+	}
+
+	if len(plan.Tags) == 0 {
+		Info(fmt.Sprintf("Nothing to prune; %d tag(s) are referenced or within the %s grace period", plan.Kept, gracePeriod))
+		return nil
+	}
+	now := time.Now()
+	rows := [][]string{{"Repository", "Tag", "Age"}}
+	for _, c := range plan.Tags {
+		rows = append(rows, []string{c.Repository, c.Tag, humanAge(c.Created, now)})
+	}
+	Table(rows)
+	summary := fmt.Sprintf("%d unreferenced tag(s), %d repository(ies) left empty, %d tag(s) kept", len(plan.Tags), len(plan.Repositories), plan.Kept)
+	if dryRun {
+		Info("Dry run: would delete " + summary)
+		return nil
+	}
+
+	m.logger.Info("Pruning registry", zap.String("namespace", namespace), zap.Int("tags", len(plan.Tags)), zap.Int("repositories", len(plan.Repositories)))
+	if err := m.deleteRegistryPaths(namespace, plan.paths()); err != nil {
+		return m.pruneError(err, "Failed to delete registry tags", namespace)
+	}
+	Info("Deleted " + summary)
+
+	if err := m.collectRegistryGarbage(namespace); err != nil {
+		return err
+	}
+	Success("Registry pruned; run 'mcp-runtime registry df' to see the freed storage")
+	return nil
+}
+
+// collectRegistryGarbage runs the registry's garbage collector with the registry in read-only
+// mode, so concurrent uploads cannot lose blobs, and switches it back afterwards.
+func (m *RegistryManager) collectRegistryGarbage(namespace string) (err error) {
+	Info("Switching the registry to read-only mode for garbage collection")
+	if err := m.setRegistryReadOnly(namespace, true); err != nil {
+		_ = m.setRegistryReadOnly(namespace, false)
+		return m.pruneError(err, "Failed to switch the registry to read-only mode; tags were deleted, rerun to free their storage", namespace)
+	}
+	defer func() {
+		if restoreErr := m.setRegistryReadOnly(namespace, false); restoreErr != nil && err == nil {
+			err = m.pruneError(restoreErr, fmt.Sprintf("Failed to switch the registry back to read-write mode; run 'kubectl set env deploy/%s -n %s %s-'", RegistryDeploymentName, namespace, registryReadOnlyEnv), namespace)
+		}
+	}()
+
+	// #nosec G204 -- fixed kubectl exec of the registry's garbage collector.
+	if _, err := m.kubectl.Output([]string{"exec", "-n", namespace, "deploy/" + RegistryDeploymentName, "--", "registry", "garbage-collect", registryServerConfigPath}); err != nil {
+		return m.pruneError(err, "Registry garbage collection failed; tags were deleted, rerun to free their storage", namespace)
+	}
+	return nil
+}
+
+// setRegistryReadOnly restarts the registry with its storage in read-only or read-write mode
+// and waits for the rollout.
+func (m *RegistryManager) setRegistryReadOnly(namespace string, readOnly bool) error {
+	env := registryReadOnlyEnv + "-"
+	if readOnly {
+		env = registryReadOnlyEnv + `={"enabled":true}`
+	}
+	target := "deploy/" + RegistryDeploymentName
+	// #nosec G204 -- fixed deployment and env var, namespace from CLI flag.
+	if _, err := m.kubectl.Output([]string{"set", "env", target, "-n", namespace, env}); err != nil {
+		return err
+	}
+	// #nosec G204 -- fixed deployment, namespace from CLI flag.
+	_, err := m.kubectl.Output([]string{"rollout", "status", target, "-n", namespace, "--timeout=" + registryRolloutTimeout.String()})
+	return err
+}
+
+func (m *RegistryManager) pruneError(err error, msg, namespace string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrPruneRegistryFailed,
+		err,
+		fmt.Sprintf("%s: %v", strings.ToLower(msg), err),
+		map[string]any{"namespace": namespace, "component": "registry"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
+
+// planRegistryPrune compares the internal registry's tags with the images in use.
+func (m *RegistryManager) planRegistryPrune(namespace string, gracePeriod time.Duration, now time.Time) (*registryPrunePlan, error) {
+	refs, err := m.referencedImages()
+	if err != nil {
+		return nil, err
+	}
+
+	catalogOut, err := internalRegistryAPI(m.kubectl, namespace)("/v2/_catalog?n=10000")
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	repos, err := parseRegistryCatalog(catalogOut)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(repos)
+
+	get := m.internalRegistryGetter(namespace)
+	plan := &registryPrunePlan{}
+	for _, repo := range repos {
+		if !repositoryNameRe.MatchString(repo) || refs.repositories[repo] {
+			continue
+		}
+		tags, err := listRegistryTags(get, repo)
+		if err != nil {
+			return nil, fmt.Errorf("list tags of %s: %w", repo, err)
+		}
+		var keptTags, prunedTags []string
+		for _, tag := range tags {
+			old := !tag.Created.IsZero() && now.Sub(tag.Created) >= gracePeriod
+			if refs.keeps(repo, tag.Name) || !old || !imageTagRe.MatchString(tag.Name) {
+				keptTags = append(keptTags, tag.Name)
+				continue
+			}
+			prunedTags = append(prunedTags, tag.Name)
+			plan.Tags = append(plan.Tags, pruneCandidate{Repository: repo, Tag: tag.Name, Created: tag.Created})
+		}
+		plan.Kept += len(keptTags)
+		if len(keptTags) == 0 && len(tags) > 0 {
+			plan.Repositories = append(plan.Repositories, repo)
+			continue
+		}
+		if len(prunedTags) == 0 {
+			continue
+		}
+		digests, err := m.registryTagDigests(namespace, repo)
+		if err != nil {
+			return nil, fmt.Errorf("read tag digests of %s: %w", repo, err)
+		}
+		plan.Manifests = append(plan.Manifests, unusedManifests(repo, digests, prunedTags, keptTags, refs)...)
+	}
+	return plan, nil
+}
+
+// unusedManifests returns the "repo@digest" manifests of prunedTags that no kept tag and no
+// referenced digest uses.
+func unusedManifests(repo string, digests map[string]string, prunedTags, keptTags []string, refs imageReferences) []string {
+	used := map[string]bool{}
+	for _, tag := range keptTags {
+		used[digests[tag]] = true
+	}
+	var manifests []string
+	for _, tag := range prunedTags {
+		digest := digests[tag]
+		if digest == "" || used[digest] || refs.digests[digest] {
+			continue
+		}
+		used[digest] = true
+		manifests = append(manifests, repo+"@"+digest)
+	}
+	return manifests
+}
+
+// registryTagDigests maps the tags of repo to their manifest digests, read from the tag links
+// in the registry's filesystem layout.
+func (m *RegistryManager) registryTagDigests(namespace, repo string) (map[string]string, error) {
+	dir := registryRepositoriesDir + "/" + repo + "/_manifests/tags/"
+	// #nosec G204 -- repo validated against repositoryNameRe, no shell.
+	out, err := m.kubectl.Output([]string{"exec", "-n", namespace, "deploy/" + RegistryDeploymentName, "--", "grep", "-r", "sha256:", dir})
+	if err != nil {
+		return nil, err
+	}
+	digests := map[string]string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		path, digest, ok := strings.Cut(strings.TrimPrefix(line, dir), ":")
+		tag, ok2 := strings.CutSuffix(path, "/current/link")
+		if ok && ok2 && strings.HasPrefix(digest, "sha256:") && imageDigestRe.MatchString(digest) {
+			digests[tag] = digest
+		}
+	}
+	return digests, nil
+}
+
+// referencedImages collects the images of MCPServers, including their rollback history,
+// and of the cluster's workloads.
+func (m *RegistryManager) referencedImages() (imageReferences, error) {
+	refs := newImageReferences()
+
+	// #nosec G204 -- fixed kubectl get.
+	out, err := m.kubectl.Output([]string{"get", "mcpservers", "--all-namespaces", "-o", "json"})
+	if err != nil {
+		return refs, fmt.Errorf("list MCPServers: %w", err)
+	}
+	var servers struct {
+		Items []struct {
+			Spec struct {
+				Image    string `json:"image"`
+				ImageTag string `json:"imageTag"`
+			} `json:"spec"`
+			Status struct {
+				History []struct {
+					Image    string `json:"image"`
+					ImageTag string `json:"imageTag"`
+				} `json:"history"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(out, &servers); err != nil {
+		return refs, fmt.Errorf("parse MCPServers: %w", err)
+	}
+	for _, s := range servers.Items {
+		refs.add(s.Spec.Image, s.Spec.ImageTag)
+		for _, rev := range s.Status.History {
+			refs.add(rev.Image, rev.ImageTag)
+		}
+	}
+
+	// #nosec G204 -- fixed kubectl get.
+	out, err = m.kubectl.Output([]string{"get", pruneWorkloadKinds, "--all-namespaces", "-o", "jsonpath={..image}"})
+	if err != nil {
+		return refs, fmt.Errorf("list workload images: %w", err)
+	}
+	for _, image := range strings.Fields(string(out)) {
+		refs.add(image, "")
+	}
+
+	// Pods may run a digest that no tag points at anymore.
+	// #nosec G204 -- fixed kubectl get.
+	out, err = m.kubectl.Output([]string{"get", "pods", "--all-namespaces", "-o", "jsonpath={..imageID}"})
+	if err != nil {
+		return refs, fmt.Errorf("list pod image digests: %w", err)
+	}
+	for _, imageID := range strings.Fields(string(out)) {
+		if _, digest, ok := strings.Cut(imageID, "@"); ok {
+			refs.digests[digest] = true
+		}
+	}
+	return refs, nil
+}
+
+// paths returns the registry directories to remove, relative to registryRepositoriesDir.
+// Repositories pruned as a whole lose their metadata directories only, so nested
+// repositories under the same path are untouched.
+func (p *registryPrunePlan) paths() []string {
+	whole := map[string]bool{}
+	var paths []string
+	for _, repo := range p.Repositories {
+		whole[repo] = true
+		for _, dir := range []string{"_manifests", "_layers", "_uploads"} {
+			paths = append(paths, repo+"/"+dir)
+		}
+	}
+	for _, c := range p.Tags {
+		if !whole[c.Repository] {
+			paths = append(paths, c.Repository+"/_manifests/tags/"+c.Tag)
+		}
+	}
+	for _, manifest := range p.Manifests {
+		repo, digest, _ := strings.Cut(manifest, "@")
+		paths = append(paths, repo+"/_manifests/revisions/sha256/"+strings.TrimPrefix(digest, "sha256:"))
+	}
+	return paths
+}
+
+// deleteRegistryPaths removes paths under registryRepositoriesDir inside the registry pod.
+func (m *RegistryManager) deleteRegistryPaths(namespace string, paths []string) error {
+	args := []string{"exec", "-n", namespace, "deploy/" + RegistryDeploymentName, "--", "rm", "-rf"}
+	for _, path := range paths {
+		args = append(args, registryRepositoriesDir+"/"+path)
+	}
+	// #nosec G204 -- rm arguments are built from catalog names validated against repositoryNameRe and imageTagRe, without a shell.
+	_, err := m.kubectl.Output(args)
+	return err
+}
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testPruneServers = `{"items":[
+  {"spec":{"image":"registry.registry.svc.cluster.local:5000/team/api","imageTag":"v2"},
+   "status":{"history":[{"image":"registry.registry.svc.cluster.local:5000/team/api","imageTag":"v3"}]}},
+  {"spec":{"image":"localhost:5001/pinned@sha256:5555555555555555555555555555555555555555555555555555555555555555"}}
+]}`
+
+// testPruneTagLinks is the tag links of team/api in the registry's filesystem layout: v1 has a
+// manifest of its own, v2 and v3 share one.
+var testPruneTagLinks = strings.Join([]string{
+	registryRepositoriesDir + "/team/api/_manifests/tags/v1/current/link:sha256:" + strings.Repeat("a", 64),
+	registryRepositoriesDir + "/team/api/_manifests/tags/v1/index/sha256/" + strings.Repeat("a", 64) + "/link:sha256:" + strings.Repeat("a", 64),
+	registryRepositoriesDir + "/team/api/_manifests/tags/v2/current/link:sha256:" + strings.Repeat("b", 64),
+	registryRepositoriesDir + "/team/api/_manifests/tags/v3/current/link:sha256:" + strings.Repeat("b", 64),
+	registryRepositoriesDir + "/team/api/_manifests/tags/new/current/link:sha256:" + strings.Repeat("c", 64),
+}, "\n")
+
+// pruneRegistryMock serves an internal registry with team/api (v1..v3 old, fresh new), old/tool
+// (v1 old), mcp-runtime-operator (latest old, run by a workload) and pinned (old, referenced
+// by digest). Created times are 2026-01-01 for old tags and now for fresh ones.
+func pruneRegistryMock() *MockExecutor {
+	fresh := time.Now().UTC().Format(time.RFC3339)
+	api := map[string]string{
+		"/v2/_catalog?n=10000":                                 `{"repositories":["mcp-runtime-operator","old/tool","pinned","team/api"]}`,
+		"/v2/team/api/tags/list":                               `{"tags":["new","v1","v2","v3"]}`,
+		"/v2/old/tool/tags/list":                               `{"tags":["v1"]}`,
+		"/v2/mcp-runtime-operator/tags/list":                   `{"tags":["latest"]}`,
+		"/v2/pinned/tags/list":                                 `{"tags":["v1"]}`,
+		"/v2/team/api/manifests/new":                           `{"config":{"digest":"` + testConfigDigestV2 + `"}}`,
+		"/v2/team/api/blobs/" + testConfigDigestV2:             `{"created":"` + fresh + `"}`,
+		"/v2/team/api/blobs/" + testConfigDigestV1:             `{"created":"2026-01-01T00:00:00Z"}`,
+		"/v2/old/tool/blobs/" + testConfigDigestV1:             `{"created":"2026-01-01T00:00:00Z"}`,
+		"/v2/mcp-runtime-operator/blobs/" + testConfigDigestV1: `{"created":"2026-01-01T00:00:00Z"}`,
+	}
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "get mcpservers"):
+			return &MockCommand{OutputData: []byte(testPruneServers)}
+		case strings.HasPrefix(args, "get "+pruneWorkloadKinds):
+			return &MockCommand{OutputData: []byte("registry:2.8.3 registry.registry.svc.cluster.local:5000/mcp-runtime-operator:latest")}
+		case strings.Contains(args, "grep -r sha256: "+registryRepositoriesDir+"/team/api/"):
+			return &MockCommand{OutputData: []byte(testPruneTagLinks)}
+		case strings.Contains(args, "wget"):
+			url := spec.Args[len(spec.Args)-1]
+			path := url[strings.Index(url, "/v2/"):]
+			if body, ok := api[path]; ok {
+				return &MockCommand{OutputData: []byte(body)}
+			}
+			if strings.Contains(path, "/manifests/") {
+				return &MockCommand{OutputData: []byte(`{"config":{"digest":"` + testConfigDigestV1 + `"}}`)}
+			}
+			return &MockCommand{OutputErr: errors.New("not found")}
+		}
+		return &MockCommand{}
+	}
+	return mock
+}
+
+func TestPlanRegistryPrune(t *testing.T) {
+	mgr := NewRegistryManager(&KubectlClient{exec: pruneRegistryMock()}, nil, zap.NewNop())
+	plan, err := mgr.planRegistryPrune(NamespaceRegistry, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("planRegistryPrune() error: %v", err)
+	}
+
+	var got []string
+	for _, c := range plan.Tags {
+		got = append(got, c.Repository+":"+c.Tag)
+	}
+	if strings.Join(got, ",") != "old/tool:v1,team/api:v1" {
+		t.Fatalf("unexpected prune candidates %v", got)
+	}
+	if strings.Join(plan.Repositories, ",") != "old/tool" || plan.Kept != 4 {
+		t.Fatalf("unexpected plan: repositories %v, kept %d", plan.Repositories, plan.Kept)
+	}
+	if strings.Join(plan.Manifests, ",") != "team/api@sha256:"+strings.Repeat("a", 64) {
+		t.Fatalf("unexpected manifests %v", plan.Manifests)
+	}
+	want := "old/tool/_manifests,old/tool/_layers,old/tool/_uploads,team/api/_manifests/tags/v1,team/api/_manifests/revisions/sha256/" + strings.Repeat("a", 64)
+	if paths := strings.Join(plan.paths(), ","); paths != want {
+		t.Fatalf("paths = %s, want %s", paths, want)
+	}
+}
+
+func TestPruneUnreferenced(t *testing.T) {
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		mock := pruneRegistryMock()
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.PruneUnreferenced(NamespaceRegistry, time.Hour, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, cmd := range mock.Commands {
+			if contains(cmd.Args, "rm") || contains(cmd.Args, "garbage-collect") {
+				t.Fatalf("dry run ran %v", cmd.Args)
+			}
+		}
+		if !strings.Contains(buf.String(), "would delete 2 unreferenced tag(s)") {
+			t.Fatalf("unexpected output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("deletes tags and collects garbage", func(t *testing.T) {
+		mock := pruneRegistryMock()
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+		if err := mgr.PruneUnreferenced(NamespaceRegistry, time.Hour, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var rm string
+		for _, cmd := range mock.Commands {
+			if contains(cmd.Args, "rm") {
+				rm = strings.Join(cmd.Args, " ")
+			}
+		}
+		if !strings.Contains(rm, registryRepositoriesDir+"/team/api/_manifests/tags/v1") || strings.Contains(rm, "tags/v2") {
+			t.Fatalf("unexpected rm command %q", rm)
+		}
+		var steps []string
+		for _, cmd := range mock.Commands {
+			switch {
+			case contains(cmd.Args, "set"):
+				steps = append(steps, cmd.Args[len(cmd.Args)-1])
+			case contains(cmd.Args, "garbage-collect"):
+				if contains(cmd.Args, "--delete-untagged") {
+					t.Fatalf("garbage collection must keep untagged manifests, got %v", cmd.Args)
+				}
+				steps = append(steps, "garbage-collect")
+			}
+		}
+		want := registryReadOnlyEnv + `={"enabled":true},garbage-collect,` + registryReadOnlyEnv + "-"
+		if got := strings.Join(steps, ","); got != want {
+			t.Fatalf("steps = %s, want %s", got, want)
+		}
+		if !contains(mock.LastCommand().Args, "rollout") {
+			t.Fatalf("expected to wait for the read-write rollout last, got %v", mock.LastCommand().Args)
+		}
+	})
+
+	t.Run("restores read-write mode when garbage collection fails", func(t *testing.T) {
+		mock := pruneRegistryMock()
+		registry := mock.CommandFunc
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			if contains(spec.Args, "garbage-collect") {
+				return &MockCommand{OutputErr: errors.New("gc failed")}
+			}
+			return registry(spec)
+		}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+		if err := mgr.PruneUnreferenced(NamespaceRegistry, time.Hour, false); !errors.Is(err, ErrPruneRegistryFailed) {
+			t.Fatalf("expected ErrPruneRegistryFailed, got %v", err)
+		}
+		var restored bool
+		for _, cmd := range mock.Commands {
+			restored = restored || contains(cmd.Args, registryReadOnlyEnv+"-")
+		}
+		if !restored {
+			t.Fatal("expected the registry to be switched back to read-write mode")
+		}
+	})
+
+	t.Run("grace period keeps everything", func(t *testing.T) {
+		mock := pruneRegistryMock()
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.PruneUnreferenced(NamespaceRegistry, 100*365*24*time.Hour, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "Nothing to prune") {
+			t.Fatalf("unexpected output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("wraps failures", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("forbidden")}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+		if err := mgr.PruneUnreferenced(NamespaceRegistry, time.Hour, true); !errors.Is(err, ErrPruneRegistryFailed) {
+			t.Fatalf("expected ErrPruneRegistryFailed, got %v", err)
+		}
+	})
+}
+
+func TestUnusedManifests(t *testing.T) {
+	refs := newImageReferences()
+	refs.add("registry.local/team/other@sha256:pinned", "")
+	digests := map[string]string{"v1": "sha256:old", "v2": "sha256:shared", "v3": "sha256:shared", "v4": "sha256:pinned"}
+
+	got := unusedManifests("team/api", digests, []string{"v1", "v2", "v4"}, []string{"v3"}, refs)
+	if strings.Join(got, ",") != "team/api@sha256:old" {
+		t.Fatalf("unusedManifests() = %v, want only the manifest no kept tag or pod uses", got)
+	}
+}
+
+func TestImageReferences(t *testing.T) {
+	refs := newImageReferences()
+	refs.add("registry.example.com:5000/team/api", "v1")
+	refs.add("localhost:5001/web:2.0", "ignored")
+	refs.add("tool", "")
+	refs.add("team/pinned@sha256:abc", "")
+
+	for _, tt := range []struct {
+		repo, tag string
+		want      bool
+	}{
+		{"team/api", "v1", true},
+		{"team/api", "v2", false},
+		{"web", "2.0", true},
+		{"tool", "latest", true},
+		{"team/pinned", "anything", true},
+	} {
+		if got := refs.keeps(tt.repo, tt.tag); got != tt.want {
+			t.Errorf("keeps(%s, %s) = %v, want %v", tt.repo, tt.tag, got, tt.want)
+		}
+	}
+}
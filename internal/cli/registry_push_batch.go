@@ -0,0 +1,115 @@
+package cli
+
+// This file implements pushing several images in one "registry push" invocation.
+// Images are pushed by a bounded worker pool and summarized in a single result table.
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultPushParallelism is the default number of concurrent pushes.
+const defaultPushParallelism = 4
+
+// helperPodSeq keeps helper pod names unique when pushes run concurrently.
+var helperPodSeq atomic.Uint64
+
+// pushResult is the outcome of pushing one image.
+type pushResult struct {
+	Source   string
+	Target   string
+	Err      error
+	Duration time.Duration
+}
+
+// readImageList reads image references from path, one per line, skipping blank lines and # comments.
+func readImageList(path string) ([]string, error) {
+	// #nosec G304 -- path comes from the --image-file flag.
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var images []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		images = append(images, line)
+	}
+	return images, scanner.Err()
+}
+
+// runPushes pushes images with at most workers pushes in flight, returning results in input order.
+func runPushes(images []string, workers int, push func(image string) (string, error)) []pushResult {
+	results := make([]pushResult, len(images))
+	runBounded(len(images), workers, func(i int) {
+		start := time.Now()
+		target, err := push(images[i])
+		results[i] = pushResult{Source: images[i], Target: target, Err: err, Duration: time.Since(start)}
+	})
+	return results
+}
+
+// runBounded calls fn for every index below n with at most workers calls in flight.
+func runBounded(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// pushImages pushes images concurrently and prints a summary table, failing if any push failed.
+func (m *RegistryManager) pushImages(images []string, workers int, push func(image string) (string, error)) error {
+	Info(fmt.Sprintf("Pushing %d images (%d in parallel)", len(images), max(1, min(workers, len(images)))))
+	results := runPushes(images, workers, push)
+
+	rows := [][]string{{"Image", "Target", "Status", "Duration"}}
+	var failed []string
+	for _, r := range results {
+		status := Green("pushed")
+		if r.Err != nil {
+			status = Red("failed: " + r.Err.Error())
+			failed = append(failed, r.Source)
+		}
+		rows = append(rows, []string{r.Source, r.Target, status, r.Duration.Round(time.Second).String()})
+	}
+	DefaultPrinter.Println()
+	TableBoxed(rows)
+
+	if len(failed) > 0 {
+		err := newWithSentinel(ErrPushImagesFailed, fmt.Sprintf("%d of %d images failed to push: %s", len(failed), len(results), strings.Join(failed, ", ")))
+		Error("Failed to push images")
+		logStructuredError(m.logger, err, "Failed to push images")
+		return err
+	}
+	Success(fmt.Sprintf("Pushed %d images", len(results)))
+	return nil
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestReadImageList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "images.txt")
+	content := "# MCP servers\nweather:v1\n\n  search:v2  \n# trailing comment\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := readImageList(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(images, ","); got != "weather:v1,search:v2" {
+		t.Fatalf("unexpected images %q", got)
+	}
+}
+
+func TestRunPushes(t *testing.T) {
+	images := []string{"a:v1", "b:v1", "c:v1", "d:v1", "e:v1"}
+	var inFlight, peak atomic.Int32
+	var mu sync.Mutex
+	pushed := map[string]bool{}
+
+	results := runPushes(images, 2, func(image string) (string, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+
+		mu.Lock()
+		pushed[image] = true
+		mu.Unlock()
+		if image == "c:v1" {
+			return "registry.local/" + image, errors.New("denied")
+		}
+		return "registry.local/" + image, nil
+	})
+
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("expected at most 2 concurrent pushes, got %d", p)
+	}
+	if len(pushed) != len(images) {
+		t.Fatalf("expected every image pushed, got %v", pushed)
+	}
+	for i, r := range results {
+		if r.Source != images[i] || r.Target != "registry.local/"+images[i] {
+			t.Fatalf("result %d out of order: %+v", i, r)
+		}
+		if (r.Err != nil) != (r.Source == "c:v1") {
+			t.Fatalf("unexpected error for %s: %v", r.Source, r.Err)
+		}
+	}
+}
+
+func TestRegistryManager_PushImages(t *testing.T) {
+	mgr := NewRegistryManager(&KubectlClient{exec: &MockExecutor{}, validators: nil}, &MockExecutor{}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	err := mgr.pushImages([]string{"ok:v1", "bad:v1"}, 2, func(image string) (string, error) {
+		if image == "bad:v1" {
+			return "registry.local/bad:v1", errors.New("unauthorized")
+		}
+		return "registry.local/ok:v1", nil
+	})
+	if !errors.Is(err, ErrPushImagesFailed) {
+		t.Fatalf("expected ErrPushImagesFailed, got %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"registry.local/ok:v1", "pushed", "failed: unauthorized"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in summary:\n%s", want, out)
+		}
+	}
+}
+
+func TestRegistryPushCmdMultipleImages(t *testing.T) {
+	t.Run("pushes every image", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock, validators: nil}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		listPath := filepath.Join(t.TempDir(), "images.txt")
+		if err := os.WriteFile(listPath, []byte("search:v2\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := mgr.newRegistryPushCmd()
+		_ = cmd.Flags().Set("image", "weather:v1")
+		_ = cmd.Flags().Set("image-file", listPath)
+		_ = cmd.Flags().Set("registry", "registry.example.com")
+		_ = cmd.Flags().Set("mode", "direct")
+		_ = cmd.Flags().Set("parallel", "1")
+
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var pushed []string
+		for _, c := range mock.Commands {
+			if c.Name == "docker" && contains(c.Args, "push") {
+				pushed = append(pushed, c.Args[len(c.Args)-1])
+			}
+		}
+		if got := strings.Join(pushed, ","); got != "registry.example.com/weather:v1,registry.example.com/search:v2" {
+			t.Fatalf("unexpected pushes %q", got)
+		}
+	})
+
+	t.Run("rejects name override with multiple images", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock, validators: nil}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		cmd := mgr.newRegistryPushCmd()
+		_ = cmd.Flags().Set("image", "weather:v1")
+		_ = cmd.Flags().Set("image", "search:v2")
+		_ = cmd.Flags().Set("name", "custom")
+
+		if err := cmd.RunE(cmd, nil); !errors.Is(err, ErrInvalidPushImages) {
+			t.Fatalf("expected ErrInvalidPushImages, got %v", err)
+		}
+		if len(mock.Commands) != 0 {
+			t.Fatalf("expected no commands, got %v", mock.Commands)
+		}
+	})
+}
@@ -0,0 +1,359 @@
+package cli
+
+// This file implements "registry tags", which lists the tags of a repository with the time
+// each image was created. The provisioned registry is queried over its HTTP API with the stored
+// credentials; the internal registry is queried from inside the registry pod, as "registry df" does.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// registryManifestAccept lists the manifest media types tags understands, indexes first.
+	registryManifestAccept = "application/vnd.oci.image.index.v1+json, " +
+		"application/vnd.docker.distribution.manifest.list.v2+json, " +
+		"application/vnd.oci.image.manifest.v1+json, " +
+		"application/vnd.docker.distribution.manifest.v2+json"
+
+	// registryTagsParallelism is the number of tags whose creation time is read concurrently.
+	registryTagsParallelism = 4
+
+	// registryResponseLimit caps the size of a registry API response.
+	registryResponseLimit = 16 << 20
+)
+
+var (
+	// repositoryNameRe matches a repository path as accepted by the distribution spec.
+	repositoryNameRe = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	// imageTagRe matches a valid image tag.
+	imageTagRe = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	// imageDigestRe matches a content digest such as sha256:<hex>.
+	imageDigestRe = regexp.MustCompile(`^[a-z0-9]+:[a-f0-9]{32,}$`)
+	// authParamRe matches one key="value" parameter of a WWW-Authenticate challenge.
+	authParamRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// registryGetter fetches path from a registry's v2 API, sending accept as the Accept header.
+type registryGetter func(path, accept string) ([]byte, error)
+
+// registryTag is one tag of a repository; Created is zero when it could not be read.
+type registryTag struct {
+	Name    string
+	Created time.Time
+}
+
+func (m *RegistryManager) newRegistryTagsCmd() *cobra.Command {
+	var internal bool
+
+	cmd := &cobra.Command{
+		Use:   "tags [repository]",
+		Short: "List the tags of a repository",
+		Long: `List the tags of a repository, newest first, with the time each image was created.
+
+The provisioned registry is queried when one is configured, otherwise the internal registry.
+Pass the chosen tag to 'server create --tag'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ListRegistryTags(args[0], registryNamespace(), internal)
+		},
+	}
+
+	cmd.Flags().BoolVar(&internal, "internal", false, "Query the internal registry even when a provisioned registry is configured")
+
+	return cmd
+}
+
+// ListRegistryTags prints the tags of repository in the provisioned or internal registry.
+func (m *RegistryManager) ListRegistryTags(repository, namespace string, internal bool) error {
+	repository = strings.Trim(strings.TrimSpace(repository), "/")
+	if !repositoryNameRe.MatchString(repository) {
+		err := newWithSentinel(ErrInvalidRepository, fmt.Sprintf("invalid repository name %q", repository))
+		Error("Invalid repository name")
+		logStructuredError(m.logger, err, "Invalid repository name")
+		return err
+	}
+
+	get, repository, registry := m.registryGetterFor(repository, namespace, internal)
+	m.logger.Info("Listing registry tags", zap.String("registry", registry), zap.String("repository", repository))
+	tags, err := listRegistryTags(get, repository)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrListRegistryTagsFailed,
+			err,
+			fmt.Sprintf("failed to list tags of %s in %s: %v", repository, registry, err),
+			map[string]any{"repository": repository, "registry": registry, "component": "registry"},
+		)
+		Error("Failed to list registry tags")
+		logStructuredError(m.logger, wrappedErr, "Failed to list registry tags")
+		return wrappedErr
+	}
+
+	if len(tags) == 0 {
+		Info(fmt.Sprintf("No tags found for %s in %s", repository, registry))
+		return nil
+	}
+	now := time.Now()
+	rows := [][]string{{"Tag", "Created", "Age"}}
+	for _, tag := range tags {
+		created := "-"
+		if !tag.Created.IsZero() {
+			created = tag.Created.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []string{tag.Name, created, humanAge(tag.Created, now)})
+	}
+	Table(rows)
+	return nil
+}
+
+// registryGetterFor returns a getter for the provisioned registry when one is configured and
+// internal is false, otherwise for the internal registry, along with repository qualified by
+// the provisioned registry's prefix and a name for the registry used in messages.
+func (m *RegistryManager) registryGetterFor(repository, namespace string, internal bool) (registryGetter, string, string) {
+	if !internal {
+		if ext, err := resolveExternalRegistryConfig(nil); err == nil && ext != nil && ext.URL != "" {
+			baseURL, prefix := splitRegistryURL(ext.Repository())
+			if prefix != "" && !strings.HasPrefix(repository, prefix+"/") {
+				repository = prefix + "/" + repository
+			}
+			get := provisionedRegistryGetter(&http.Client{Timeout: 30 * time.Second}, baseURL, ext)
+			return get, repository, ext.Repository()
+		}
+	}
+	return m.internalRegistryGetter(namespace), repository, "internal registry"
+}
+
+// internalRegistryGetter reads the internal registry API from inside the registry pod.
+func (m *RegistryManager) internalRegistryGetter(namespace string) registryGetter {
+	get := internalRegistryAPI(m.kubectl, namespace)
+	return func(path, accept string) ([]byte, error) {
+		return get(path, "Accept: "+accept)
+	}
+}
+
+// splitRegistryURL splits a provisioned registry URL such as registry.example.com/team into
+// its API base URL (https unless a scheme is given) and repository prefix.
+func splitRegistryURL(registryURL string) (string, string) {
+	scheme := "https"
+	rest := strings.TrimSuffix(registryURL, "/")
+	if s, r, ok := strings.Cut(rest, "://"); ok {
+		scheme, rest = s, r
+	}
+	host, prefix, _ := strings.Cut(rest, "/")
+	return scheme + "://" + host, prefix
+}
+
+// provisionedRegistryGetter reads a registry API over HTTP. Requests use basic auth; when the
+// registry answers with a bearer challenge the credentials are exchanged for a token instead.
+func provisionedRegistryGetter(client *http.Client, baseURL string, cfg *ExternalRegistryConfig) registryGetter {
+	var mu sync.Mutex
+	var token string
+
+	do := func(path, accept string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(commandContext(), http.MethodGet, baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", accept)
+		mu.Lock()
+		bearer := token
+		mu.Unlock()
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		} else if cfg.Username != "" {
+			req.SetBasicAuth(cfg.Username, cfg.Password)
+		}
+		return client.Do(req)
+	}
+
+	return func(path, accept string) ([]byte, error) {
+		resp, err := do(path, accept)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			_ = resp.Body.Close()
+			bearer, err := fetchRegistryToken(client, challenge, cfg)
+			if err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			token = bearer
+			mu.Unlock()
+			if resp, err = do(path, accept); err != nil {
+				return nil, err
+			}
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: %s", path, resp.Status)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, registryResponseLimit))
+	}
+}
+
+// fetchRegistryToken answers a WWW-Authenticate bearer challenge with the registry credentials.
+func fetchRegistryToken(client *http.Client, challenge string, cfg *ExternalRegistryConfig) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("registry requires authentication (%s)", strings.TrimSpace(challenge))
+	}
+	values := map[string]string{}
+	for _, m := range authParamRe.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("bearer challenge without realm")
+	}
+	query := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	tokenURL := values["realm"]
+	if len(query) > 0 {
+		tokenURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(commandContext(), http.MethodGet, tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Username != "" {
+		req.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("request registry token: %s", resp.Status)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, registryResponseLimit)).Decode(&body); err != nil {
+		return "", fmt.Errorf("parse registry token: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("registry token response has no token")
+}
+
+// listRegistryTags returns the tags of repository, newest first. Creation times are read
+// on a best-effort basis; tags whose image cannot be read are listed last.
+func listRegistryTags(get registryGetter, repository string) ([]registryTag, error) {
+	out, err := get("/v2/"+repository+"/tags/list", "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse tag list: %w", err)
+	}
+
+	tags := make([]registryTag, len(list.Tags))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(registryTagsParallelism, len(tags)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				tags[i] = registryTag{Name: list.Tags[i]}
+				if imageTagRe.MatchString(list.Tags[i]) {
+					tags[i].Created, _ = imageCreated(get, repository, list.Tags[i])
+				}
+			}
+		}()
+	}
+	for i := range tags {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		if !tags[i].Created.Equal(tags[j].Created) {
+			return tags[i].Created.After(tags[j].Created)
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+// imageCreated returns the creation time recorded in the image config of repository:ref.
+// For a multi-platform index the first platform image is used.
+func imageCreated(get registryGetter, repository, ref string) (time.Time, error) {
+	for range 2 {
+		out, err := get("/v2/"+repository+"/manifests/"+ref, registryManifestAccept)
+		if err != nil {
+			return time.Time{}, err
+		}
+		var manifest struct {
+			Config struct {
+				Digest string `json:"digest"`
+			} `json:"config"`
+			Manifests []struct {
+				Digest   string `json:"digest"`
+				Platform struct {
+					OS string `json:"os"`
+				} `json:"platform"`
+			} `json:"manifests"`
+		}
+		if err := json.Unmarshal(out, &manifest); err != nil {
+			return time.Time{}, fmt.Errorf("parse manifest: %w", err)
+		}
+
+		if digest := manifest.Config.Digest; digest != "" {
+			if !imageDigestRe.MatchString(digest) {
+				return time.Time{}, fmt.Errorf("invalid config digest %q", digest)
+			}
+			blob, err := get("/v2/"+repository+"/blobs/"+digest, "application/json")
+			if err != nil {
+				return time.Time{}, err
+			}
+			var config struct {
+				Created time.Time `json:"created"`
+			}
+			if err := json.Unmarshal(blob, &config); err != nil {
+				return time.Time{}, fmt.Errorf("parse image config: %w", err)
+			}
+			return config.Created, nil
+		}
+
+		ref = ""
+		for _, entry := range manifest.Manifests {
+			// Attestation manifests are listed with platform unknown/unknown.
+			if entry.Platform.OS != "unknown" && imageDigestRe.MatchString(entry.Digest) {
+				ref = entry.Digest
+				break
+			}
+		}
+		if ref == "" {
+			break
+		}
+	}
+	return time.Time{}, fmt.Errorf("no image config found")
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	testConfigDigestV1 = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	testConfigDigestV2 = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	testImageDigestV2  = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+	testAttestDigest   = "sha256:4444444444444444444444444444444444444444444444444444444444444444"
+)
+
+// testRegistryAPI serves team/api with v1 (image manifest), v2 (index) and broken (unreadable).
+var testRegistryAPI = map[string]string{
+	"/v2/team/api/tags/list":                      `{"name":"team/api","tags":["broken","v1","v2"]}`,
+	"/v2/team/api/manifests/v1":                   `{"config":{"digest":"` + testConfigDigestV1 + `"}}`,
+	"/v2/team/api/blobs/" + testConfigDigestV1:    `{"created":"2026-01-02T03:04:05Z"}`,
+	"/v2/team/api/manifests/v2":                   `{"manifests":[{"digest":"` + testAttestDigest + `","platform":{"os":"unknown"}},{"digest":"` + testImageDigestV2 + `","platform":{"os":"linux"}}]}`,
+	"/v2/team/api/manifests/" + testImageDigestV2: `{"config":{"digest":"` + testConfigDigestV2 + `"}}`,
+	"/v2/team/api/blobs/" + testConfigDigestV2:    `{"created":"2026-03-04T05:06:07Z"}`,
+}
+
+func TestListRegistryTags(t *testing.T) {
+	get := func(path, accept string) ([]byte, error) {
+		if body, ok := testRegistryAPI[path]; ok {
+			return []byte(body), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	tags, err := listRegistryTags(get, "team/api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []registryTag{
+		{Name: "v2", Created: time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{Name: "v1", Created: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Name: "broken"},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("expected %d tags, got %v", len(want), tags)
+	}
+	for i := range want {
+		if tags[i].Name != want[i].Name || !tags[i].Created.Equal(want[i].Created) {
+			t.Fatalf("tag %d: expected %+v, got %+v", i, want[i], tags[i])
+		}
+	}
+}
+
+func TestSplitRegistryURL(t *testing.T) {
+	tests := []struct {
+		in, base, prefix string
+	}{
+		{"registry.example.com", "https://registry.example.com", ""},
+		{"registry.example.com/team/", "https://registry.example.com", "team"},
+		{"http://localhost:5000", "http://localhost:5000", ""},
+	}
+	for _, tt := range tests {
+		base, prefix := splitRegistryURL(tt.in)
+		if base != tt.base || prefix != tt.prefix {
+			t.Fatalf("splitRegistryURL(%q) = %q, %q; want %q, %q", tt.in, base, prefix, tt.base, tt.prefix)
+		}
+	}
+}
+
+func TestProvisionedRegistryGetterBearerToken(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			user, pass, _ := r.BasicAuth()
+			if user != "alice" || pass != "secret" || r.URL.Query().Get("scope") != "repository:team/api:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"abc"}`))
+		default:
+			if r.Header.Get("Authorization") != "Bearer abc" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="registry",scope="repository:team/api:pull"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"tags":["v1"]}`))
+		}
+	}))
+	defer srv.Close()
+
+	get := provisionedRegistryGetter(srv.Client(), srv.URL, &ExternalRegistryConfig{Username: "alice", Password: "secret"})
+	out, err := get("/v2/team/api/tags/list", "application/json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"tags":["v1"]}` {
+		t.Fatalf("unexpected body %q", out)
+	}
+}
+
+func TestRegistryManager_ListRegistryTags(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	t.Run("lists internal registry tags through the registry pod", func(t *testing.T) {
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				last := spec.Args[len(spec.Args)-1]
+				for path, body := range testRegistryAPI {
+					if strings.HasSuffix(last, path) {
+						return &MockCommand{OutputData: []byte(body)}
+					}
+				}
+				return &MockCommand{OutputErr: errors.New("not found")}
+			},
+		}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ListRegistryTags("team/api", NamespaceRegistry, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{"2026-03-04T05:06:07Z", "2026-01-02T03:04:05Z", "broken"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("expected %q in output:\n%s", want, out)
+			}
+		}
+		if strings.Index(out, "v2") > strings.Index(out, "v1") {
+			t.Fatalf("expected newest tag first:\n%s", out)
+		}
+	})
+
+	t.Run("rejects invalid repository names", func(t *testing.T) {
+		mgr := NewRegistryManager(&KubectlClient{exec: &MockExecutor{}}, &MockExecutor{}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ListRegistryTags("Team/API", NamespaceRegistry, false); !errors.Is(err, ErrInvalidRepository) {
+			t.Fatalf("expected ErrInvalidRepository, got %v", err)
+		}
+	})
+
+	t.Run("wraps tag list failures", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("pod not found")}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ListRegistryTags("team/api", NamespaceRegistry, true); !errors.Is(err, ErrListRegistryTagsFailed) {
+			t.Fatalf("expected ErrListRegistryTagsFailed, got %v", err)
+		}
+	})
+}
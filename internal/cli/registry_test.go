@@ -2,7 +2,9 @@ package cli
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -261,6 +263,7 @@ func TestLoadExternalRegistryConfigInvalid(t *testing.T) {
 func TestResolveExternalRegistryConfigPrecedence(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	stubClusterRegistryConfig(t, nil)
 
 	origConfig := DefaultCLIConfig
 	t.Cleanup(func() { DefaultCLIConfig = origConfig })
@@ -313,6 +316,22 @@ func TestResolveExternalRegistryConfigPrecedence(t *testing.T) {
 			t.Fatalf("expected flag config, got %#v", cfg)
 		}
 	})
+
+	t.Run("cluster config overrides file", func(t *testing.T) {
+		DefaultCLIConfig = &CLIConfig{ProvisionedRegistryPassword: "env-pass"}
+		stubClusterRegistryConfig(t, &ExternalRegistryConfig{URL: "cluster.example.com", Username: "cluster-user"})
+		cfg, sources, err := resolveExternalRegistryConfigWithSources(nil)
+		if err != nil {
+			t.Fatalf("resolveExternalRegistryConfigWithSources returned error: %v", err)
+		}
+		if cfg == nil || cfg.URL != "cluster.example.com" || cfg.Username != "cluster-user" || cfg.Password != "env-pass" {
+			t.Fatalf("expected cluster config, got %#v", cfg)
+		}
+		want := registryConfigSources{URL: registrySourceCluster, Username: registrySourceCluster, Password: registrySourceEnv}
+		if sources != want {
+			t.Fatalf("sources = %+v, want %+v", sources, want)
+		}
+	})
 }
 
 func TestEnsureRegistryStorageSize(t *testing.T) {
@@ -553,6 +572,55 @@ func TestRegistryProvisionCmdRunE(t *testing.T) {
 			t.Fatal("expected error when login fails")
 		}
 	})
+
+	t.Run("reads the password from stdin or a file", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+
+		origConfig := DefaultCLIConfig
+		t.Cleanup(func() { DefaultCLIConfig = origConfig })
+		DefaultCLIConfig = &CLIConfig{}
+
+		passwordFile := filepath.Join(home, "password")
+		if err := os.WriteFile(passwordFile, []byte("from-file\r\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		for flag, value := range map[string]string{"password-stdin": "true", "password-file": passwordFile} {
+			var login *MockCommand
+			mock := &MockExecutor{}
+			mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+				login = &MockCommand{Args: spec.Args}
+				return login
+			}
+			mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+
+			cmd := mgr.newRegistryProvisionCmd()
+			cmd.SetIn(strings.NewReader("from-stdin\n"))
+			_ = cmd.Flags().Set("url", "registry.example.com")
+			_ = cmd.Flags().Set("username", "user")
+			_ = cmd.Flags().Set(flag, value)
+			if err := cmd.RunE(cmd, nil); err != nil {
+				t.Fatalf("--%s: unexpected error: %v", flag, err)
+			}
+			if login == nil || !contains(login.Args, "--password-stdin") {
+				t.Fatalf("--%s: expected docker login --password-stdin, got %v", flag, login)
+			}
+			sent, _ := io.ReadAll(login.StdinR)
+			want := map[string]string{"password-stdin": "from-stdin", "password-file": "from-file"}[flag]
+			if string(sent) != want {
+				t.Fatalf("--%s: expected password %q on stdin, got %q", flag, want, sent)
+			}
+		}
+
+		mgr := NewRegistryManager(&KubectlClient{exec: &MockExecutor{}}, &MockExecutor{}, zap.NewNop())
+		cmd := mgr.newRegistryProvisionCmd()
+		cmd.SetIn(strings.NewReader("\n"))
+		_ = cmd.Flags().Set("url", "registry.example.com")
+		_ = cmd.Flags().Set("password-stdin", "true")
+		if err := cmd.RunE(cmd, nil); !errors.Is(err, ErrReadRegistryPasswordFailed) {
+			t.Fatalf("expected ErrReadRegistryPasswordFailed for an empty password, got %v", err)
+		}
+	})
 }
 
 func TestRegistryPushCmdRunE(t *testing.T) {
@@ -597,6 +665,33 @@ func TestRegistryPushCmdRunE(t *testing.T) {
 		}
 	})
 
+	t.Run("pushes under the external registry path prefix", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		stubClusterRegistryConfig(t, nil)
+
+		origConfig := DefaultCLIConfig
+		t.Cleanup(func() { DefaultCLIConfig = origConfig })
+		DefaultCLIConfig = &CLIConfig{ProvisionedRegistryPathPrefix: "mcp"}
+
+		if err := saveExternalRegistryConfig(&ExternalRegistryConfig{URL: "harbor.example.com"}); err != nil {
+			t.Fatal(err)
+		}
+
+		mock := &MockExecutor{}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+		setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+		cmd := mgr.newRegistryPushCmd()
+		_ = cmd.Flags().Set("image", "docker.io/team/my-image:latest")
+		_ = cmd.Flags().Set("mode", "direct")
+		if err := cmd.RunE(cmd, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !contains(mock.LastCommand().Args, "harbor.example.com/mcp/team/my-image:latest") {
+			t.Fatalf("expected the push under the path prefix, got %v", mock.LastCommand().Args)
+		}
+	})
+
 	t.Run("uses name override", func(t *testing.T) {
 		mock := &MockExecutor{}
 		kubectl := &KubectlClient{exec: mock, validators: nil}
@@ -758,6 +853,44 @@ func TestLoginRegistryError(t *testing.T) {
 	}
 }
 
+func TestPushDirectLogsInToLocalRegistry(t *testing.T) {
+	var login *MockCommand
+	var order []string
+	mock := &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			switch {
+			case contains(spec.Args, localRegistryHostingName):
+				cmd.OutputData = []byte(`host: "localhost:5001"`)
+			case contains(spec.Args, registryAuthSecretName):
+				cmd.OutputData = registryAuthSecretOutput("mcp-runtime", "s3cret")
+			case spec.Name == "docker":
+				order = append(order, spec.Args[0])
+				if spec.Args[0] == "login" {
+					login = cmd
+				}
+			}
+			return cmd
+		},
+	}
+	mgr := NewRegistryManager(&KubectlClient{exec: mock}, mock, zap.NewNop())
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+	if err := mgr.PushDirect("app:v1", "localhost:5001/app:v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(order, ",") != "login,tag,push" {
+		t.Fatalf("expected login before tag and push, got %v", order)
+	}
+	if strings.Contains(strings.Join(login.Args, " "), "s3cret") || !contains(login.Args, "localhost:5001") {
+		t.Fatalf("unexpected login args %v", login.Args)
+	}
+	password, _ := io.ReadAll(login.StdinR)
+	if string(password) != "s3cret" {
+		t.Fatalf("expected the password on stdin, got %q", password)
+	}
+}
+
 func TestPushDirectErrors(t *testing.T) {
 	t.Run("returns error when tag fails", func(t *testing.T) {
 		mock := &MockExecutor{DefaultRunErr: errors.New("tag failed")}
@@ -929,6 +1062,38 @@ func TestPushInCluster(t *testing.T) {
 			t.Error("expected delete to be called for cleanup")
 		}
 	})
+
+	t.Run("authenticates when the registry requires auth", func(t *testing.T) {
+		var copyCmd *MockCommand
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				cmd := &MockCommand{Args: spec.Args}
+				if contains(spec.Args, registryAuthSecretName) {
+					cmd.OutputData = registryAuthSecretOutput("mcp-runtime", "s3cret")
+				}
+				if contains(spec.Args, "skopeo") {
+					copyCmd = cmd
+				}
+				return cmd
+			},
+		}
+		kubectl := &KubectlClient{exec: mock, validators: nil}
+		mgr := NewRegistryManager(kubectl, mock, zap.NewNop())
+
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.PushInCluster("source:tag", "target:tag", "registry"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if copyCmd == nil || strings.Contains(strings.Join(copyCmd.Args, " "), "s3cret") {
+			t.Fatalf("expected skopeo without credentials on argv, got %v", copyCmd)
+		}
+		authFile, _ := io.ReadAll(copyCmd.StdinR)
+		if want := base64.StdEncoding.EncodeToString([]byte("mcp-runtime:s3cret")); !strings.Contains(string(authFile), want) {
+			t.Fatalf("expected the auth file on stdin, got %q", authFile)
+		}
+	})
 }
 
 func TestSaveExternalRegistryConfigErrors(t *testing.T) {
@@ -973,6 +1138,7 @@ func TestResolveExternalRegistryConfigErrors(t *testing.T) {
 	t.Run("returns nil when no source found", func(t *testing.T) {
 		home := t.TempDir()
 		t.Setenv("HOME", home)
+		stubClusterRegistryConfig(t, nil)
 
 		origConfig := DefaultCLIConfig
 		t.Cleanup(func() { DefaultCLIConfig = origConfig })
@@ -990,6 +1156,7 @@ func TestResolveExternalRegistryConfigErrors(t *testing.T) {
 	t.Run("returns error when source found but no url", func(t *testing.T) {
 		home := t.TempDir()
 		t.Setenv("HOME", home)
+		stubClusterRegistryConfig(t, nil)
 
 		origConfig := DefaultCLIConfig
 		t.Cleanup(func() { DefaultCLIConfig = origConfig })
@@ -1035,7 +1202,7 @@ func TestDeployRegistry(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		err := deployRegistry(zap.NewNop(), "registry", 5000, "", "", manifestPath)
+		err := deployRegistry(zap.NewNop(), "registry", 5000, "", "", manifestPath, nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -1048,7 +1215,7 @@ func TestDeployRegistry(t *testing.T) {
 		mock := &MockExecutor{}
 		kubectlClient = &KubectlClient{exec: mock, validators: nil}
 
-		err := deployRegistry(zap.NewNop(), "registry", 5000, "harbor", "", "")
+		err := deployRegistry(zap.NewNop(), "registry", 5000, "harbor", "", "", nil)
 		if err == nil {
 			t.Fatal("expected error for unsupported registry type")
 		}
@@ -1064,7 +1231,7 @@ func TestDeployRegistry(t *testing.T) {
 		mock := &MockExecutor{DefaultRunErr: errors.New("namespace failed")}
 		kubectlClient = &KubectlClient{exec: mock, validators: nil}
 
-		err := deployRegistry(zap.NewNop(), "registry", 5000, "docker", "", "config/registry")
+		err := deployRegistry(zap.NewNop(), "registry", 5000, "docker", "", "config/registry", nil)
 		if err == nil {
 			t.Fatal("expected error when namespace fails")
 		}
@@ -1087,7 +1254,7 @@ func TestDeployRegistry(t *testing.T) {
 		}
 		kubectlClient = &KubectlClient{exec: mock, validators: nil}
 
-		err := deployRegistry(zap.NewNop(), "registry", 5000, "docker", "", "config/registry")
+		err := deployRegistry(zap.NewNop(), "registry", 5000, "docker", "", "config/registry", nil)
 		if err == nil {
 			t.Fatal("expected error when apply fails")
 		}
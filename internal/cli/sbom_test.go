@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestSBOMOptionsValidate(t *testing.T) {
+	if err := (SBOMOptions{}).Validate(); err != nil {
+		t.Fatalf("disabled options should validate, got %v", err)
+	}
+	for _, format := range []string{SBOMFormatSPDX, SBOMFormatCycloneDX} {
+		if err := (SBOMOptions{Enabled: true, Format: format}).Validate(); err != nil {
+			t.Fatalf("format %s should validate, got %v", format, err)
+		}
+	}
+	err := (SBOMOptions{Enabled: true, Format: "xml"}).Validate()
+	if !errors.Is(err, ErrInvalidSBOMFormat) {
+		t.Fatalf("expected ErrInvalidSBOMFormat, got %v", err)
+	}
+}
+
+func TestBuildSetupPlanSBOMAttachImpliesEnabled(t *testing.T) {
+	plan := BuildSetupPlan(SetupPlanInput{SBOM: SBOMOptions{Attach: true, Format: SBOMFormatSPDX}})
+	if !plan.SBOM.Enabled {
+		t.Fatal("expected --sbom-attach to imply --sbom")
+	}
+}
+
+func TestSBOMCommands(t *testing.T) {
+	originalExecutor := execExecutor
+	t.Cleanup(func() { execExecutor = originalExecutor })
+	mock := &MockExecutor{}
+	execExecutor = mock
+
+	if err := generateSBOM("example/operator:v1", SBOMFormatCycloneDX, "out.json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := mock.LastCommand()
+	if got.Name != "syft" || strings.Join(got.Args, " ") != "docker:example/operator:v1 -o cyclonedx-json=out.json" {
+		t.Fatalf("unexpected syft command: %s %v", got.Name, got.Args)
+	}
+
+	if err := attachSBOM("registry.example.com/operator:v1", "out.json", SBOMFormatCycloneDX); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got = mock.LastCommand()
+	if got.Name != "cosign" || strings.Join(got.Args, " ") != "attach sbom --sbom out.json --type cyclonedx registry.example.com/operator:v1" {
+		t.Fatalf("unexpected cosign command: %s %v", got.Name, got.Args)
+	}
+}
+
+func TestOperatorImageStepGeneratesSBOM(t *testing.T) {
+	const image = "registry.example.com/mcp-runtime-operator:latest"
+	var calls []string
+	ctx := &SetupContext{
+		Plan:                  SetupPlan{SBOM: SBOMOptions{Enabled: true, Attach: true, Format: SBOMFormatSPDX, Output: "sbom.json"}},
+		ExternalRegistry:      &ExternalRegistryConfig{URL: "registry.example.com"},
+		UsingExternalRegistry: true,
+	}
+	deps := SetupDeps{
+		OperatorImageFor:   func(*ExternalRegistryConfig) string { return image },
+		BuildOperatorImage: func(string) error { calls = append(calls, "build"); return nil },
+		PushOperatorImage:  func(string) error { calls = append(calls, "push"); return nil },
+		GenerateSBOM: func(img, format, output string) error {
+			if img != image || format != SBOMFormatSPDX || output != "sbom.json" {
+				t.Fatalf("unexpected SBOM request %q %q %q", img, format, output)
+			}
+			calls = append(calls, "sbom")
+			return nil
+		},
+		AttachSBOM: func(string, string, string) error { calls = append(calls, "attach"); return nil },
+	}
+
+	if err := (operatorImageStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("operator image step failed: %v", err)
+	}
+	if strings.Join(calls, ",") != "build,sbom,push,attach" {
+		t.Fatalf("unexpected call order %v", calls)
+	}
+
+	deps.GenerateSBOM = func(string, string, string) error { return errors.New("syft not found") }
+	err := (operatorImageStep{}).Run(zap.NewNop(), deps, ctx)
+	if !errors.Is(err, ErrGenerateSBOMFailed) {
+		t.Fatalf("expected ErrGenerateSBOMFailed, got %v", err)
+	}
+}
@@ -0,0 +1,361 @@
+package cli
+
+// This file implements the "serve-api" command, an authenticated HTTP API over the same
+// managers the CLI uses, so portals can list, create and delete servers and read platform
+// and registry status without shelling out to mcp-runtime.
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// apiTokenEnv is the environment variable holding the API token when --token-file is not set.
+const apiTokenEnv = "MCP_API_TOKEN"
+
+// apiMaxBodyBytes caps the size of request bodies.
+const apiMaxBodyBytes = 1 << 20
+
+// apiShutdownTimeout is how long in-flight requests get to finish after an interrupt.
+const apiShutdownTimeout = 10 * time.Second
+
+// apiServer serves the management API.
+type apiServer struct {
+	servers  *ServerManager
+	registry *RegistryManager
+	kubectl  *KubectlClient
+	logger   *zap.Logger
+	token    string
+}
+
+// apiServerInfo is one MCP server in API responses.
+type apiServerInfo struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase,omitempty"`
+	Ready     string `json:"ready,omitempty"`
+	Replicas  string `json:"replicas,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// apiCreateServerRequest is the body of POST /api/v1/servers.
+type apiCreateServerRequest struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+	Tag       string `json:"tag"`
+}
+
+// apiComponentStatus is the health of one platform component.
+type apiComponentStatus struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"` // ok, pending or error
+	Replicas string `json:"replicas,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// apiPlatformStatus is the body of GET /api/v1/status.
+type apiPlatformStatus struct {
+	Healthy    bool                 `json:"healthy"`
+	Components []apiComponentStatus `json:"components"`
+	Servers    int                  `json:"servers"`
+}
+
+// apiRegistryInfo is the body of GET /api/v1/registry.
+type apiRegistryInfo struct {
+	InternalURL string `json:"internalURL,omitempty"`
+	ServiceDNS  string `json:"serviceDNS,omitempty"`
+	External    string `json:"external,omitempty"`
+}
+
+// NewServeAPICmd returns the serve-api command.
+func NewServeAPICmd(logger *zap.Logger) *cobra.Command {
+	var addr string
+	var tokenFile string
+	var tlsCert string
+	var tlsKey string
+
+	cmd := &cobra.Command{
+		Use:   "serve-api",
+		Short: "Serve the management HTTP API",
+		Long: `Serve an authenticated HTTP API for the core platform operations, backed by the
+same code as the CLI:
+
+  GET    /healthz                                 liveness, no token needed
+  GET    /api/v1/servers[?namespace=NS]           list servers (all namespaces by default)
+  POST   /api/v1/servers                          create a server: {"name","namespace","image","tag"}
+  DELETE /api/v1/servers/{namespace}/{name}       delete a server (?force=true for protected ones)
+  GET    /api/v1/status                           platform status
+  GET    /api/v1/registry                         registry endpoints
+
+Every /api request needs "Authorization: Bearer <token>", where the token is read from
+--token-file or the ` + apiTokenEnv + ` environment variable. Errors are returned as the JSON
+error report of --error-format json.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := loadAPIToken(tokenFile)
+			if err != nil {
+				return err
+			}
+			if (tlsCert == "") != (tlsKey == "") {
+				return newWithSentinel(ErrServeAPIFailed, "--tls-cert and --tls-key must be set together")
+			}
+			api := &apiServer{
+				servers:  DefaultServerManager(logger),
+				registry: DefaultRegistryManager(logger),
+				kubectl:  kubectlClient,
+				logger:   logger,
+				token:    token,
+			}
+			return api.serve(commandContext(), addr, tlsCert, tlsKey)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8090", "Address to listen on")
+	cmd.Flags().StringVar(&tokenFile, "token-file", "", "File holding the bearer token (default: $"+apiTokenEnv+")")
+	cmd.Flags().StringVar(&tlsCert, "tls-cert", "", "TLS certificate file; serves HTTPS together with --tls-key")
+	cmd.Flags().StringVar(&tlsKey, "tls-key", "", "TLS private key file")
+
+	return cmd
+}
+
+// loadAPIToken reads the token from tokenFile, or from the environment when it is empty.
+func loadAPIToken(tokenFile string) (string, error) {
+	token := os.Getenv(apiTokenEnv)
+	if tokenFile != "" {
+		// #nosec G304 -- token file path comes from the operator's CLI flag.
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", wrapWithSentinel(ErrAPITokenRequired, err, fmt.Sprintf("read token file %s: %v", tokenFile, err))
+		}
+		token = string(data)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", newWithSentinel(ErrAPITokenRequired, fmt.Sprintf("an API token is required: pass --token-file or set %s", apiTokenEnv))
+	}
+	return token, nil
+}
+
+// serve listens on addr until ctx is done, then drains in-flight requests.
+func (a *apiServer) serve(ctx context.Context, addr, tlsCert, tlsKey string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(ErrServeAPIFailed, err, fmt.Sprintf("listen on %s: %v", addr, err), map[string]any{"addr": addr, "component": "serve-api"})
+		Error("Failed to start the API server")
+		logStructuredError(a.logger, wrappedErr, "Failed to start the API server")
+		return wrappedErr
+	}
+	if tlsCert == "" && !isLoopbackAddr(listener.Addr()) {
+		Warn("Serving the API without TLS on a non-loopback address; the token is sent in clear text")
+	}
+
+	server := &http.Server{Handler: a.handler(), ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() {
+		if tlsCert != "" {
+			errCh <- server.ServeTLS(listener, tlsCert, tlsKey)
+			return
+		}
+		errCh <- server.Serve(listener)
+	}()
+	scheme := "http"
+	if tlsCert != "" {
+		scheme = "https"
+	}
+	Success(fmt.Sprintf("Serving the management API on %s://%s", scheme, listener.Addr()))
+
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		Info("Shutting down the API server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), apiShutdownTimeout)
+		defer cancel()
+		err = server.Shutdown(shutdownCtx)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		wrappedErr := wrapWithSentinelAndContext(ErrServeAPIFailed, err, fmt.Sprintf("API server: %v", err), map[string]any{"addr": addr, "component": "serve-api"})
+		Error("API server failed")
+		logStructuredError(a.logger, wrappedErr, "API server failed")
+		return wrappedErr
+	}
+	return nil
+}
+
+func isLoopbackAddr(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// handler routes the API.
+func (a *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("GET /api/v1/servers", a.authorize(a.listServers))
+	mux.Handle("POST /api/v1/servers", a.authorize(a.createServer))
+	mux.Handle("DELETE /api/v1/servers/{namespace}/{name}", a.authorize(a.deleteServer))
+	mux.Handle("GET /api/v1/status", a.authorize(a.platformStatus))
+	mux.Handle("GET /api/v1/registry", a.authorize(a.registryInfo))
+	return mux
+}
+
+// authorize rejects requests without the bearer token and logs the ones it lets through.
+func (a *apiServer) authorize(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp-runtime"`)
+			writeAPIError(w, newWithSentinel(ErrAPIUnauthorized, "missing or invalid bearer token"))
+			return
+		}
+		a.logger.Info("API request", zap.String("method", r.Method), zap.String("path", r.URL.Path), zap.String("remote", r.RemoteAddr))
+		next(w, r)
+	})
+}
+
+func (a *apiServer) listServers(w http.ResponseWriter, r *http.Request) {
+	list, err := a.servers.dashboardServers(r.URL.Query().Get("namespace"))
+	if err != nil {
+		writeAPIError(w, wrapWithSentinel(ErrListServersFailed, err, fmt.Sprintf("failed to list servers: %v", err)))
+		return
+	}
+	servers := make([]apiServerInfo, 0, len(list))
+	for _, s := range list {
+		servers = append(servers, apiServerInfo(s))
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]any{"servers": servers})
+}
+
+func (a *apiServer) createServer(w http.ResponseWriter, r *http.Request) {
+	var req apiCreateServerRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeAPIError(w, wrapWithSentinel(ErrInvalidAPIRequest, err, fmt.Sprintf("invalid request body: %v", err)))
+		return
+	}
+	if req.Namespace == "" {
+		req.Namespace = serverNamespace()
+	}
+	if req.Tag == "" {
+		req.Tag = "latest"
+	}
+	if err := a.servers.CreateServer(req.Name, req.Namespace, req.Image, req.Tag); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusCreated, apiServerInfo{Namespace: req.Namespace, Name: req.Name})
+}
+
+func (a *apiServer) deleteServer(w http.ResponseWriter, r *http.Request) {
+	force := r.URL.Query().Get("force") == "true"
+	if err := a.servers.DeleteServer(r.PathValue("name"), r.PathValue("namespace"), force); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *apiServer) platformStatus(w http.ResponseWriter, r *http.Request) {
+	status := apiPlatformStatus{Healthy: true}
+
+	cluster := apiComponentStatus{Name: "cluster", Status: "ok"}
+	// #nosec G204 -- fixed kubectl command.
+	if out, err := a.kubectl.CombinedOutput([]string{"cluster-info"}); err != nil {
+		cluster.Status, cluster.Message = "error", strings.TrimSpace(string(out))
+		if cluster.Message == "" {
+			cluster.Message = err.Error()
+		}
+	}
+	status.Components = append(status.Components, cluster,
+		deploymentComponentStatus(a.kubectl, "registry", RegistryDeploymentName, NamespaceRegistry),
+		deploymentComponentStatus(a.kubectl, "operator", OperatorDeploymentName, NamespaceMCPRuntime),
+	)
+	for _, c := range status.Components {
+		if c.Status != "ok" {
+			status.Healthy = false
+		}
+	}
+
+	servers, err := a.servers.dashboardServers("")
+	if err != nil {
+		writeAPIError(w, wrapWithSentinel(ErrListServersFailed, err, fmt.Sprintf("failed to list servers: %v", err)))
+		return
+	}
+	status.Servers = len(servers)
+	writeAPIJSON(w, http.StatusOK, status)
+}
+
+// deploymentComponentStatus reports a platform deployment as ok, pending or error.
+func deploymentComponentStatus(kubectl *KubectlClient, component, name, namespace string) apiComponentStatus {
+	replicas, err := deploymentReplicas(kubectl, name, namespace)
+	switch {
+	case err != nil:
+		return apiComponentStatus{Name: component, Status: "error", Message: "deployment not found"}
+	case !replicasReady(replicas):
+		return apiComponentStatus{Name: component, Status: "pending", Replicas: replicas}
+	}
+	return apiComponentStatus{Name: component, Status: "ok", Replicas: replicas}
+}
+
+func (a *apiServer) registryInfo(w http.ResponseWriter, r *http.Request) {
+	var info apiRegistryInfo
+	if ip, port := a.registry.registryService(); ip != "" && port != "" {
+		info.InternalURL = ip + ":" + port
+		info.ServiceDNS = fmt.Sprintf("%s.%s.svc.cluster.local:%s", RegistryServiceName, NamespaceRegistry, port)
+	}
+	ext, err := resolveExternalRegistryConfig(nil)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if ext != nil {
+		info.External = ext.Repository()
+	}
+	if info.InternalURL == "" && info.External == "" {
+		writeAPIError(w, newWithSentinel(ErrRegistryNotFound, "no registry is deployed or configured"))
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, info)
+}
+
+// apiStatusCode maps an error to the HTTP status of its response.
+func apiStatusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrAPIUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrRegistryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrServerProtected):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidAPIRequest), errors.Is(err, ErrInvalidServerName), errors.Is(err, ErrFieldRequired),
+		errors.Is(err, ErrControlCharsNotAllowed), errors.Is(err, ErrImageRequired):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// writeAPIError writes err as the JSON error report with its HTTP status.
+func writeAPIError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiStatusCode(err))
+	WriteError(w, err, ErrorFormatJSON)
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
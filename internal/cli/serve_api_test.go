@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const testAPIToken = "s3cret"
+
+func newTestAPIServer(t *testing.T, mock *MockExecutor) http.Handler {
+	t.Helper()
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	kubectl := &KubectlClient{exec: mock}
+	api := &apiServer{
+		servers:  NewServerManager(kubectl, zap.NewNop()),
+		registry: NewRegistryManager(kubectl, mock, zap.NewNop()),
+		kubectl:  kubectl,
+		logger:   zap.NewNop(),
+		token:    testAPIToken,
+	}
+	return api.handler()
+}
+
+func apiRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAPIToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeAPIAuthorization(t *testing.T) {
+	handler := newTestAPIServer(t, dashboardMock(""))
+
+	for _, header := range []string{"", "Bearer wrong", "Basic " + testAPIToken} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/servers", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("Authorization %q: expected 401, got %d", header, rec.Code)
+		}
+		var report errorReport
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || report.Code != "MCP-CLI-029" {
+			t.Fatalf("unexpected error body %s (%v)", rec.Body.String(), err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected /healthz without a token to answer 200, got %d", rec.Code)
+	}
+}
+
+func TestServeAPIListServers(t *testing.T) {
+	mock := dashboardMock("")
+	handler := newTestAPIServer(t, mock)
+
+	rec := apiRequest(t, handler, http.MethodGet, "/api/v1/servers?namespace=mcp-servers", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Servers []apiServerInfo `json:"servers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Servers) != 2 || body.Servers[1].Name != "weather" || body.Servers[1].Ready != "2" {
+		t.Fatalf("unexpected servers %+v", body.Servers)
+	}
+	if got := strings.Join(mock.Commands[0].Args, " "); got != "get mcpservers -o json -n mcp-servers" {
+		t.Fatalf("expected a namespaced list, got %q", got)
+	}
+}
+
+func TestServeAPICreateServer(t *testing.T) {
+	t.Run("creates with the default tag", func(t *testing.T) {
+		mock := dashboardMock("")
+		handler := newTestAPIServer(t, mock)
+
+		rec := apiRequest(t, handler, http.MethodPost, "/api/v1/servers", `{"name":"weather","namespace":"team-a","image":"registry.local/weather"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.HasPrefix(strings.Join(mock.LastCommand().Args, " "), "apply -f ") {
+			t.Fatalf("expected kubectl apply, got %v", mock.LastCommand().Args)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		body string
+		code string
+	}{
+		"unknown field": {`{"name":"weather","image":"x","replicas":3}`, "MCP-CLI-030"},
+		"bad name":      {`{"name":"Weather","image":"x"}`, "MCP-CLI-002"},
+		"no image":      {`{"name":"weather"}`, "MCP-CLI-001"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			handler := newTestAPIServer(t, dashboardMock(""))
+			rec := apiRequest(t, handler, http.MethodPost, "/api/v1/servers", tc.body)
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tc.code) {
+				t.Fatalf("expected 400 with %s, got %d: %s", tc.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServeAPIDeleteServer(t *testing.T) {
+	mock := dashboardMock("weather")
+	handler := newTestAPIServer(t, mock)
+
+	rec := apiRequest(t, handler, http.MethodDelete, "/api/v1/servers/mcp-servers/weather", "")
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "MCP-SERVER-011") {
+		t.Fatalf("expected 409 for a protected server, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = apiRequest(t, handler, http.MethodDelete, "/api/v1/servers/mcp-servers/weather?force=true", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := strings.Join(mock.LastCommand().Args, " "); got != "delete mcpserver weather -n mcp-servers" {
+		t.Fatalf("expected kubectl delete, got %q", got)
+	}
+}
+
+func TestServeAPIStatus(t *testing.T) {
+	mock := dashboardMock("")
+	base := mock.CommandFunc
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "get deployment "+RegistryDeploymentName):
+			return &MockCommand{OutputData: []byte("1/1")}
+		case strings.HasPrefix(args, "get deployment "+OperatorDeploymentName):
+			return &MockCommand{OutputData: []byte("0/1")}
+		}
+		return base(spec)
+	}
+	handler := newTestAPIServer(t, mock)
+
+	rec := apiRequest(t, handler, http.MethodGet, "/api/v1/status", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var status apiPlatformStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	want := []apiComponentStatus{
+		{Name: "cluster", Status: "ok"},
+		{Name: "registry", Status: "ok", Replicas: "1/1"},
+		{Name: "operator", Status: "pending", Replicas: "0/1"},
+	}
+	if status.Healthy || status.Servers != 2 || len(status.Components) != len(want) {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	for i, c := range want {
+		if status.Components[i] != c {
+			t.Fatalf("component %d = %+v, want %+v", i, status.Components[i], c)
+		}
+	}
+}
+
+func TestServeAPIRegistry(t *testing.T) {
+	stubClusterRegistryConfig(t, nil)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PROVISIONED_REGISTRY_URL", "")
+
+	mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		switch {
+		case contains(spec.Args, "jsonpath={.spec.clusterIP}"):
+			return &MockCommand{OutputData: []byte("10.96.0.10")}
+		case contains(spec.Args, "jsonpath={.spec.ports[0].port}"):
+			return &MockCommand{OutputData: []byte("5000")}
+		}
+		return &MockCommand{}
+	}}
+	handler := newTestAPIServer(t, mock)
+
+	rec := apiRequest(t, handler, http.MethodGet, "/api/v1/registry", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var info apiRegistryInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.InternalURL != "10.96.0.10:5000" || info.ServiceDNS != "registry.registry.svc.cluster.local:5000" {
+		t.Fatalf("unexpected registry info %+v", info)
+	}
+
+	handler = newTestAPIServer(t, &MockExecutor{})
+	if rec := apiRequest(t, handler, http.MethodGet, "/api/v1/registry", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without a registry, got %d", rec.Code)
+	}
+}
+
+func TestLoadAPIToken(t *testing.T) {
+	t.Setenv(apiTokenEnv, "")
+	if _, err := loadAPIToken(""); !errors.Is(err, ErrAPITokenRequired) {
+		t.Fatalf("expected ErrAPITokenRequired, got %v", err)
+	}
+
+	t.Setenv(apiTokenEnv, "from-env")
+	if token, err := loadAPIToken(""); err != nil || token != "from-env" {
+		t.Fatalf("loadAPIToken() = %q, %v", token, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := loadAPIToken(path); err != nil || token != "from-file" {
+		t.Fatalf("loadAPIToken() = %q, %v", token, err)
+	}
+}
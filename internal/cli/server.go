@@ -77,9 +77,20 @@ For pushing images, use 'registry push'.`,
 	cmd.AddCommand(mgr.newServerListCmd())
 	cmd.AddCommand(mgr.newServerGetCmd())
 	cmd.AddCommand(mgr.newServerCreateCmd())
+	cmd.AddCommand(mgr.newServerApplyCmd())
+	cmd.AddCommand(mgr.newServerDiffCmd())
 	cmd.AddCommand(mgr.newServerDeleteCmd())
 	cmd.AddCommand(mgr.newServerLogsCmd())
 	cmd.AddCommand(mgr.newServerStatusCmd())
+	cmd.AddCommand(mgr.newServerSuspendCmd())
+	cmd.AddCommand(mgr.newServerResumeCmd())
+	cmd.AddCommand(mgr.newServerRollbackCmd())
+	cmd.AddCommand(mgr.newServerScaffoldCmd())
+	cmd.AddCommand(mgr.newServerCloneCmd())
+	cmd.AddCommand(mgr.newServerMigrateCmd())
+	cmd.AddCommand(mgr.newServerPreviewCmd())
+	cmd.AddCommand(mgr.newServerTopCmd())
+	cmd.AddCommand(mgr.newServerDevCmd())
 	cmd.AddCommand(newServerBuildCmd(mgr.logger))
 
 	return cmd
@@ -98,42 +109,40 @@ func newServerBuildCmd(logger *zap.Logger) *cobra.Command {
 }
 
 func (m *ServerManager) newServerListCmd() *cobra.Command {
-	var namespace string
+	var allContexts bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List MCP servers",
 		Long:  "List all MCP server deployments",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.ListServers(namespace)
+			if allContexts {
+				return m.ListServersAllContexts(serverNamespace())
+			}
+			return m.ListServers(serverNamespace())
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceMCPServers, "Namespace to list servers from")
+	cmd.Flags().BoolVar(&allContexts, "all-contexts", false, "List servers in every kubeconfig context with the platform installed")
 
 	return cmd
 }
 
 func (m *ServerManager) newServerGetCmd() *cobra.Command {
-	var namespace string
-
 	cmd := &cobra.Command{
 		Use:   "get [name]",
 		Short: "Get MCP server details",
 		Long:  "Get detailed information about an MCP server",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.GetServer(args[0], namespace)
+			return m.GetServer(args[0], serverNamespace())
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceMCPServers, "Namespace")
-
 	return cmd
 }
 
 func (m *ServerManager) newServerCreateCmd() *cobra.Command {
-	var namespace string
 	var image string
 	var imageTag string
 	var file string
@@ -147,38 +156,39 @@ func (m *ServerManager) newServerCreateCmd() *cobra.Command {
 			if file != "" {
 				return m.CreateServerFromFile(file)
 			}
-			return m.CreateServer(args[0], namespace, image, imageTag)
+			return m.CreateServer(args[0], serverNamespace(), image, imageTag)
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceMCPServers, "Namespace")
 	cmd.Flags().StringVar(&image, "image", "", "Container image")
 	cmd.Flags().StringVar(&imageTag, "tag", "latest", "Image tag")
-	cmd.Flags().StringVar(&file, "file", "", "YAML file with server spec")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file with server spec, validated against the MCPServer schema before it is applied")
 
 	return cmd
 }
 
 func (m *ServerManager) newServerDeleteCmd() *cobra.Command {
-	var namespace string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "delete [name]",
 		Short: "Delete an MCP server",
-		Long:  "Delete an MCP server deployment",
-		Args:  cobra.ExactArgs(1),
+		Long: `Delete an MCP server deployment.
+
+Servers annotated mcpruntime.org/protected="true" are refused unless --force is
+given, which removes the annotation before deleting.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.DeleteServer(args[0], namespace)
+			return m.DeleteServer(args[0], serverNamespace(), force)
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceMCPServers, "Namespace")
+	cmd.Flags().BoolVar(&force, "force", false, "Delete even if the server is protected")
 
 	return cmd
 }
 
 func (m *ServerManager) newServerLogsCmd() *cobra.Command {
-	var namespace string
 	var follow bool
 
 	cmd := &cobra.Command{
@@ -187,30 +197,25 @@ func (m *ServerManager) newServerLogsCmd() *cobra.Command {
 		Long:  "View logs from an MCP server",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.ViewServerLogs(args[0], namespace, follow)
+			return m.ViewServerLogs(args[0], serverNamespace(), follow)
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceMCPServers, "Namespace")
 	cmd.Flags().BoolVar(&follow, "follow", false, "Follow log output")
 
 	return cmd
 }
 
 func (m *ServerManager) newServerStatusCmd() *cobra.Command {
-	var namespace string
-
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show MCP server runtime status (pods, images, pull secrets)",
 		Long:  "List MCPServer resources with their Deployment/pod status, image, and pull secrets.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return m.ServerStatus(namespace)
+			return m.ServerStatus(serverNamespace())
 		},
 	}
 
-	cmd.Flags().StringVar(&namespace, "namespace", NamespaceMCPServers, "Namespace to inspect")
-
 	return cmd
 }
 
@@ -222,7 +227,7 @@ func (m *ServerManager) ListServers(namespace string) error {
 	}
 
 	// #nosec G204 -- namespace validated above; kubectl validates resource names.
-	if err := m.kubectl.RunWithOutput([]string{"get", "mcpserver", "-n", namespace}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"get", "mcpserver", "-n", namespace}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrListServersFailed,
 			err,
@@ -244,7 +249,7 @@ func (m *ServerManager) GetServer(name, namespace string) error {
 	}
 
 	// #nosec G204 -- name/namespace validated via validateServerInput.
-	if err := m.kubectl.RunWithOutput([]string{"get", "mcpserver", name, "-n", namespace, "-o", "yaml"}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"get", "mcpserver", name, "-n", namespace, "-o", "yaml"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrGetMCPServerFailed,
 			err,
@@ -307,8 +312,8 @@ func (m *ServerManager) CreateServer(name, namespace, image, imageTag string) er
 		return wrappedErr
 	}
 
-	// Use os.CreateTemp for secure temp file creation (random suffix, no race conditions)
-	tmpFile, err := os.CreateTemp("", "mcpserver-*.yaml")
+	// Tracked temp file from os.CreateTemp (random suffix, no race conditions), removed on interrupt too
+	tmpFile, err := tempServerManifest.create()
 	if err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrCreateTempFileFailed,
@@ -321,7 +326,7 @@ func (m *ServerManager) CreateServer(name, namespace, image, imageTag string) er
 		return wrappedErr
 	}
 	tmpPath := tmpFile.Name()
-	defer os.Remove(tmpPath)
+	defer removeTempFile(tmpPath)
 
 	if _, err := tmpFile.Write(manifestBytes); err != nil {
 		closeErr := tmpFile.Close()
@@ -359,7 +364,7 @@ func (m *ServerManager) CreateServer(name, namespace, image, imageTag string) er
 	}
 
 	// #nosec G204 -- tmpPath is from os.CreateTemp, kubectl is a fixed command.
-	if err := m.kubectl.RunWithOutput([]string{"apply", "-f", tmpPath}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"apply", "-f", tmpPath}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrCreateServerFailed,
 			err,
@@ -398,9 +403,29 @@ func (m *ServerManager) CreateServerFromFile(file string) error {
 		return err
 	}
 
+	// #nosec G304 -- file comes from the --file flag and was checked to be a regular file.
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		wrappedErr := wrapWithSentinel(ErrFileNotAccessible, err, fmt.Sprintf("cannot read file %q: %v", file, err))
+		Error("Cannot access file")
+		logStructuredError(m.logger, wrappedErr, "Cannot access file")
+		return wrappedErr
+	}
+	if err := validateManifestSchema(file, data); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrInvalidManifest,
+			err,
+			fmt.Sprintf("invalid manifest: %v", err),
+			map[string]any{"file": file, "component": "server"},
+		)
+		Error("Invalid manifest")
+		logStructuredError(m.logger, wrappedErr, "Invalid manifest")
+		return wrappedErr
+	}
+
 	// #nosec G204 -- execCommand passes arguments directly without shell interpretation;
-	// file path validated above (exists, is regular file); kubectl validates manifest contents.
-	if err := m.kubectl.RunWithOutput([]string{"apply", "-f", absPath}, os.Stdout, os.Stderr); err != nil {
+	// file path validated above (exists, is regular file) and its MCPServers against the CRD schema.
+	if err := m.kubectl.RunWithOutput([]string{"apply", "-f", absPath}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrCreateServerFailed,
 			err,
@@ -414,17 +439,21 @@ func (m *ServerManager) CreateServerFromFile(file string) error {
 	return nil
 }
 
-// DeleteServer deletes an MCP server.
-func (m *ServerManager) DeleteServer(name, namespace string) error {
+// DeleteServer deletes an MCP server. Protected servers are only deleted when force is set.
+func (m *ServerManager) DeleteServer(name, namespace string, force bool) error {
 	name, namespace, err := validateServerInput(name, namespace)
 	if err != nil {
 		return err
 	}
 
+	if err := m.checkDeleteProtection(name, namespace, force); err != nil {
+		return err
+	}
+
 	m.logger.Info("Deleting MCP server", zap.String("name", name))
 
 	// #nosec G204 -- name/namespace validated via validateServerInput.
-	if err := m.kubectl.RunWithOutput([]string{"delete", "mcpserver", name, "-n", namespace}, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput([]string{"delete", "mcpserver", name, "-n", namespace}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrDeleteServerFailed,
 			err,
@@ -451,7 +480,7 @@ func (m *ServerManager) ViewServerLogs(name, namespace string, follow bool) erro
 	}
 
 	// #nosec G204 -- name/namespace validated via validateServerInput.
-	if err := m.kubectl.RunWithOutput(args, os.Stdout, os.Stderr); err != nil {
+	if err := m.kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrViewServerLogsFailed,
 			err,
@@ -472,7 +501,7 @@ func (m *ServerManager) ServerStatus(namespace string) error {
 
 	// Get MCPServer details
 	// #nosec G204 -- namespace from CLI flag; kubectl validates namespace names.
-	getServersCmd, err := m.kubectl.CommandArgs([]string{"get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.spec.ingressPath}|{.spec.useProvisionedRegistry}{\"\\n\"}{end}"})
+	getServersCmd, err := m.kubectl.CommandArgs([]string{"get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}|{.spec.useProvisionedRegistry}|{.status.url}{\"\\n\"}{end}"})
 	if err != nil {
 		return err
 	}
@@ -513,7 +542,7 @@ func (m *ServerManager) ServerStatus(namespace string) error {
 
 	// Build table
 	tableData := [][]string{
-		{"Name", "Image", "Replicas", "Path", "Registry"},
+		{"Name", "Image", "Replicas", "URL", "Class", "Registry"},
 	}
 
 	for _, line := range lines {
@@ -521,19 +550,27 @@ func (m *ServerManager) ServerStatus(namespace string) error {
 			continue
 		}
 		parts := strings.Split(line, "|")
-		if len(parts) >= 5 {
+		if len(parts) >= 8 {
 			name := parts[0]
 			image := parts[1]
 			replicas := parts[2]
-			path := parts[3]
-			useProv := parts[4]
+			// The operator records defaulted paths and classes in status only.
+			path := effectiveIngressValue(parts[3], parts[4])
+			class := effectiveIngressValue(parts[5], parts[6])
+			useProv := parts[7]
+
+			// Fall back to the path until the operator has reported a URL.
+			url := path
+			if len(parts) >= 9 && parts[8] != "" {
+				url = parts[8]
+			}
 
 			registry := "custom"
 			if useProv == "true" {
 				registry = "provisioned"
 			}
 
-			tableData = append(tableData, []string{name, image, replicas, path, registry})
+			tableData = append(tableData, []string{name, image, replicas, url, orDash(class), registry})
 		}
 	}
 
@@ -545,38 +582,7 @@ func (m *ServerManager) ServerStatus(namespace string) error {
 	DefaultPrinter.Println()
 	Section("Pod Status")
 
-	// #nosec G204 -- namespace from CLI flag; fixed label selector.
-	podCmd, err := m.kubectl.CommandArgs([]string{"get", "pods", "-n", namespace, "-l", SelectorManagedBy, "-o", "custom-columns=NAME:.metadata.name,READY:.status.containerStatuses[0].ready,STATUS:.status.phase,RESTARTS:.status.containerStatuses[0].restartCount"})
-	if err != nil {
-		return err
-	}
-	podOut, err := podCmd.Output()
-	if err != nil {
-		Warn("Failed to list pods: " + err.Error())
-		return nil
-	}
-	trimmedPods := strings.TrimSpace(string(podOut))
-	if trimmedPods == "" {
-		return nil
-	}
-	rawPodLines := strings.Split(trimmedPods, "\n")
-	podLines := make([]string, 0, len(rawPodLines))
-	for _, line := range rawPodLines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-		podLines = append(podLines, line)
-	}
-	if len(podLines) > 1 {
-		podData := [][]string{}
-		for _, pl := range podLines {
-			podData = append(podData, strings.Fields(pl))
-		}
-		Table(podData)
-	} else {
-		Info("No pods found")
-	}
-
+	m.printPodStatus(namespace)
 	return nil
 }
 
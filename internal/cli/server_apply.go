@@ -0,0 +1,383 @@
+package cli
+
+// This file implements "server apply", which applies a bundle of manifests (files or
+// directories, each possibly holding several YAML documents). With --parallel the
+// documents are applied by a bounded worker pool, and with --wait every applied MCPServer
+// is then polled together until all are Ready instead of applying and waiting per server.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// applyPollInterval is how often "server apply --wait" checks readiness; a test seam.
+var applyPollInterval = 2 * time.Second
+
+// applyDocument is one YAML document of a manifest bundle.
+type applyDocument struct {
+	Source    string
+	Kind      string
+	Name      string
+	Namespace string
+	Data      []byte
+	// defaultNamespace is set when the document names no namespace and is applied
+	// into the CLI's namespace.
+	defaultNamespace bool
+}
+
+// applyResult is the outcome of applying one document.
+type applyResult struct {
+	Doc      applyDocument
+	Result   string
+	Err      error
+	Duration time.Duration
+}
+
+// manifestHeader holds the fields of a document that apply needs to know about.
+type manifestHeader struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+// serverPhase is the readiness of one MCPServer as seen by "server apply --wait".
+type serverPhase struct {
+	Phase   string
+	Message string
+}
+
+// serverReadiness is the part of an MCPServer list that the consolidated wait reads.
+type serverReadiness struct {
+	Items []struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Generation int64  `json:"generation"`
+		} `json:"metadata"`
+		Status struct {
+			ObservedGeneration int64  `json:"observedGeneration"`
+			Phase              string `json:"phase"`
+			Message            string `json:"message"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+func (m *ServerManager) newServerApplyCmd() *cobra.Command {
+	var files []string
+	var parallel int
+	var wait bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a bundle of MCP server manifests",
+		Long: `Apply MCPServer manifests (and any other resources they ship with) from files or
+directories of *.yaml and *.yml files; a file may hold several documents separated by ---.
+
+--parallel applies that many documents concurrently. --wait then waits once for every
+applied MCPServer to become Ready, so a bundle of many servers rolls out in roughly the
+time of the slowest one.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ApplyServers(files, serverNamespace(), parallel, wait, timeout)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&files, "filename", "f", nil, "Manifest file or directory (repeatable)")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of documents applied concurrently")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for every applied MCPServer to become Ready")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long --wait waits for all servers")
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// ApplyServers applies every document found in paths, at most parallel at a time, and
+// with wait set waits up to timeout for all applied MCPServers to become Ready.
+// Documents without a namespace are applied into namespace.
+func (m *ServerManager) ApplyServers(paths []string, namespace string, parallel int, wait bool, timeout time.Duration) error {
+	namespace, err := validateManifestValue("namespace", namespace)
+	if err != nil {
+		return err
+	}
+
+	docs, err := loadApplyDocuments(paths, namespace)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrInvalidManifest,
+			err,
+			fmt.Sprintf("invalid manifest: %v", err),
+			map[string]any{"paths": strings.Join(paths, ","), "component": "server"},
+		)
+		Error("Invalid manifest")
+		logStructuredError(m.logger, wrappedErr, "Invalid manifest")
+		return wrappedErr
+	}
+
+	workers := max(1, min(parallel, len(docs)))
+	m.logger.Info("Applying manifests", zap.Int("documents", len(docs)), zap.Int("parallel", workers))
+	Info(fmt.Sprintf("Applying %d documents (%d in parallel)", len(docs), workers))
+
+	results := make([]applyResult, len(docs))
+	runBounded(len(docs), workers, func(i int) {
+		start := time.Now()
+		result, err := m.applyDocument(docs[i])
+		results[i] = applyResult{Doc: docs[i], Result: result, Err: err, Duration: time.Since(start)}
+	})
+
+	rows := [][]string{{"Kind", "Name", "Namespace", "Result", "Duration"}}
+	var failed []string
+	var servers []applyDocument
+	for _, r := range results {
+		status := Green(r.Result)
+		if r.Err != nil {
+			status = Red("failed: " + r.Err.Error())
+			failed = append(failed, r.Doc.Source)
+		} else if r.Doc.Kind == "MCPServer" {
+			servers = append(servers, r.Doc)
+		}
+		rows = append(rows, []string{r.Doc.Kind, r.Doc.Name, orDash(r.Doc.Namespace), status, r.Duration.Round(time.Millisecond).String()})
+	}
+	DefaultPrinter.Println()
+	TableBoxed(rows)
+
+	if len(failed) > 0 {
+		err := newWithSentinel(ErrApplyServersFailed, fmt.Sprintf("%d of %d documents failed to apply: %s", len(failed), len(results), strings.Join(failed, ", ")))
+		Error("Failed to apply manifests")
+		logStructuredError(m.logger, err, "Failed to apply manifests")
+		return err
+	}
+	Success(fmt.Sprintf("Applied %d documents", len(results)))
+
+	if !wait || len(servers) == 0 {
+		return nil
+	}
+	if err := m.waitForServersReady(servers, time.Now().Add(timeout)); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrServerNotReady,
+			err,
+			fmt.Sprintf("servers did not become ready: %v", err),
+			map[string]any{"servers": len(servers), "component": "server"},
+		)
+		Error("Servers did not become ready")
+		logStructuredError(m.logger, wrappedErr, "Servers did not become ready")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("All %d servers are Ready", len(servers)))
+	return nil
+}
+
+// applyDocument applies one document through stdin and returns kubectl's verdict
+// (created, configured or unchanged).
+func (m *ServerManager) applyDocument(doc applyDocument) (string, error) {
+	args := []string{"apply", "-f", "-"}
+	if doc.defaultNamespace {
+		args = append(args, "-n", doc.Namespace)
+	}
+	// #nosec G204 -- fixed kubectl command; namespace validated, manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs(args)
+	if err != nil {
+		return "", err
+	}
+	cmd.SetStdin(bytes.NewReader(doc.Data))
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if err != nil {
+		if output != "" {
+			return "", fmt.Errorf("%s: %w", output, err)
+		}
+		return "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "applied", nil
+	}
+	return fields[len(fields)-1], nil
+}
+
+// waitForServersReady polls the namespaces of servers until every server has been
+// observed at its latest generation and reports Ready, or deadline passes.
+func (m *ServerManager) waitForServersReady(servers []applyDocument, deadline time.Time) error {
+	byNamespace := map[string][]string{}
+	for _, s := range servers {
+		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s.Name)
+	}
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	Info(fmt.Sprintf("Waiting for %d servers to become Ready", len(servers)))
+	lastReady := -1
+	for {
+		var pending [][]string
+		for _, ns := range namespaces {
+			states, err := m.serverReadiness(ns)
+			for _, name := range byNamespace[ns] {
+				state, ok := states[name]
+				switch {
+				case err != nil:
+					pending = append(pending, []string{name, ns, "unknown", err.Error()})
+				case !ok:
+					pending = append(pending, []string{name, ns, "unknown", "not found"})
+				case state.Phase != "Ready":
+					pending = append(pending, []string{name, ns, orDash(state.Phase), orDash(state.Message)})
+				}
+			}
+		}
+		if ready := len(servers) - len(pending); ready != lastReady {
+			Info(fmt.Sprintf("%d/%d servers Ready", ready, len(servers)))
+			lastReady = ready
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			DefaultPrinter.Println()
+			Table(append([][]string{{"Server", "Namespace", "Phase", "Message"}}, pending...))
+			names := make([]string, 0, len(pending))
+			for _, p := range pending {
+				names = append(names, p[1]+"/"+p[0])
+			}
+			return fmt.Errorf("%d of %d servers not Ready: %s", len(pending), len(servers), strings.Join(names, ", "))
+		}
+		if err := sleepContext(commandContext(), applyPollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// serverReadiness returns the phase and message of every MCPServer in namespace, with
+// the phase reported as Progressing until the operator has seen the latest generation.
+func (m *ServerManager) serverReadiness(namespace string) (map[string]serverPhase, error) {
+	// #nosec G204 -- namespace is the CLI namespace or read from a manifest; exec validators reject shell metacharacters.
+	out, err := m.kubectl.Output([]string{"get", "mcpserver", "-n", namespace, "-o", "json"})
+	if err != nil {
+		return nil, err
+	}
+	var list serverReadiness
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse mcpserver list: %w", err)
+	}
+	states := make(map[string]serverPhase, len(list.Items))
+	for _, item := range list.Items {
+		phase := item.Status.Phase
+		if item.Status.ObservedGeneration != item.Metadata.Generation {
+			phase = "Progressing"
+		}
+		states[item.Metadata.Name] = serverPhase{Phase: phase, Message: item.Status.Message}
+	}
+	return states, nil
+}
+
+// loadApplyDocuments reads every document from paths in order. Directories contribute
+// their *.yaml and *.yml files sorted by name.
+func loadApplyDocuments(paths []string, namespace string) ([]applyDocument, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		var dirFiles []string
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(p, pattern))
+			if err != nil {
+				return nil, err
+			}
+			dirFiles = append(dirFiles, matches...)
+		}
+		sort.Strings(dirFiles)
+		files = append(files, dirFiles...)
+	}
+
+	var docs []applyDocument
+	for _, file := range files {
+		// #nosec G304 -- file comes from the --filename flag.
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileDocs, err := splitManifest(file, data, namespace)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, fileDocs...)
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no documents found in %s", strings.Join(paths, ", "))
+	}
+	return docs, nil
+}
+
+// splitManifest splits data into its documents, skipping empty ones. Documents without
+// a namespace are assigned namespace; kubectl ignores it for cluster-scoped kinds.
+// MCPServer documents are checked against the embedded CRD schema, and every violation
+// in the file is reported at once.
+func splitManifest(file string, data []byte, namespace string) ([]applyDocument, error) {
+	var docs []applyDocument
+	var schemaErrs []string
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for index := 1; ; index++ {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: document %d: %w", file, index, err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Kind == yaml.ScalarNode && node.Content[0].Tag == "!!null" {
+			continue
+		}
+		var header manifestHeader
+		if err := node.Decode(&header); err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", file, index, err)
+		}
+		if header.Kind == "" || header.Metadata.Name == "" {
+			return nil, fmt.Errorf("%s: document %d has no kind or metadata.name", file, index)
+		}
+		docErrs, err := schemaErrors(file, header.Kind, &node)
+		if err != nil {
+			return nil, err
+		}
+		schemaErrs = append(schemaErrs, docErrs...)
+		out, err := yaml.Marshal(&node)
+		if err != nil {
+			return nil, fmt.Errorf("%s: document %d: %w", file, index, err)
+		}
+		doc := applyDocument{
+			Source:    fmt.Sprintf("%s#%d", file, index),
+			Kind:      header.Kind,
+			Name:      header.Metadata.Name,
+			Namespace: header.Metadata.Namespace,
+			Data:      out,
+		}
+		if doc.Namespace == "" {
+			doc.Namespace = namespace
+			doc.defaultNamespace = true
+		}
+		docs = append(docs, doc)
+	}
+	if len(schemaErrs) > 0 {
+		return nil, schemaError(schemaErrs)
+	}
+	return docs, nil
+}
@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testApplyBundle = `apiVersion: mcpruntime.org/v1alpha1
+kind: MCPServer
+metadata:
+  name: weather
+spec:
+  image: weather
+---
+# comments only
+---
+apiVersion: mcpruntime.org/v1alpha1
+kind: MCPServer
+metadata:
+  name: search
+  namespace: team-a
+spec:
+  image: search
+`
+
+func writeApplyFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSplitManifest(t *testing.T) {
+	docs, err := splitManifest("bundle.yaml", []byte(testApplyBundle), "mcp-servers")
+	if err != nil {
+		t.Fatalf("splitManifest() error: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+	if docs[0].Name != "weather" || docs[0].Namespace != "mcp-servers" || !docs[0].defaultNamespace {
+		t.Fatalf("first document = %+v", docs[0])
+	}
+	if docs[1].Name != "search" || docs[1].Namespace != "team-a" || docs[1].defaultNamespace || docs[1].Source != "bundle.yaml#3" {
+		t.Fatalf("second document = %+v", docs[1])
+	}
+	if !strings.Contains(string(docs[1].Data), "image: search") || strings.Contains(string(docs[1].Data), "weather") {
+		t.Fatalf("second document data = %s", docs[1].Data)
+	}
+
+	if _, err := splitManifest("bad.yaml", []byte("metadata:\n  name: x\n"), "ns"); err == nil || !strings.Contains(err.Error(), "bad.yaml: document 1 has no kind") {
+		t.Fatalf("expected missing kind error, got %v", err)
+	}
+}
+
+func TestApplyServersParallelWithConsolidatedWait(t *testing.T) {
+	dir := t.TempDir()
+	writeApplyFile(t, dir, "a.yaml", testApplyBundle)
+	writeApplyFile(t, dir, "b.yml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared\n")
+	writeApplyFile(t, dir, "notes.txt", "ignored")
+
+	prev := applyPollInterval
+	applyPollInterval = 0
+	t.Cleanup(func() { applyPollInterval = prev })
+
+	var gets atomic.Int32
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "apply"):
+			return &MockCommand{OutputData: []byte("configmap/shared created\n")}
+		case strings.HasPrefix(args, "get mcpserver -n mcp-servers"):
+			n := gets.Add(1)
+			if n == 1 {
+				return &MockCommand{OutputData: []byte(`{"items":[{"metadata":{"name":"weather","generation":1},"status":{"observedGeneration":0}}]}`)}
+			}
+			return &MockCommand{OutputData: []byte(`{"items":[{"metadata":{"name":"weather","generation":1},"status":{"observedGeneration":1,"phase":"Ready"}}]}`)}
+		case strings.HasPrefix(args, "get mcpserver -n team-a"):
+			return &MockCommand{OutputData: []byte(`{"items":[{"metadata":{"name":"search","generation":2},"status":{"observedGeneration":2,"phase":"Ready"}}]}`)}
+		}
+		t.Errorf("unexpected command %s", args)
+		return &MockCommand{}
+	}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.ApplyServers([]string{dir}, "mcp-servers", 4, true, time.Minute); err != nil {
+		t.Fatalf("ApplyServers() error: %v\n%s", err, buf.String())
+	}
+
+	var applies []string
+	for _, c := range mock.Commands {
+		if c.Args[0] == "apply" {
+			applies = append(applies, strings.Join(c.Args, " "))
+		}
+	}
+	if len(applies) != 3 {
+		t.Fatalf("expected 3 applies, got %v", applies)
+	}
+	var withNamespace int
+	for _, a := range applies {
+		if a == "apply -f - -n mcp-servers" {
+			withNamespace++
+		} else if a != "apply -f -" {
+			t.Fatalf("unexpected apply args %q", a)
+		}
+	}
+	// weather and the ConfigMap name no namespace; search names team-a.
+	if withNamespace != 2 {
+		t.Fatalf("expected 2 applies into the CLI namespace, got %v", applies)
+	}
+	if got := gets.Load(); got != 2 {
+		t.Fatalf("expected mcp-servers to be polled twice, got %d", got)
+	}
+	out := buf.String()
+	for _, want := range []string{"Applying 3 documents (3 in parallel)", "shared", "created", "All 2 servers are Ready"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestApplyServersSendsDocumentsOnStdin(t *testing.T) {
+	dir := t.TempDir()
+	file := writeApplyFile(t, dir, "bundle.yaml", testApplyBundle)
+
+	var cmds []*MockCommand
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args, OutputData: []byte("mcpserver.mcpruntime.org/x unchanged")}
+		cmds = append(cmds, cmd)
+		return cmd
+	}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+	if err := mgr.ApplyServers([]string{file}, "mcp-servers", 1, false, 0); err != nil {
+		t.Fatalf("ApplyServers() error: %v", err)
+	}
+	var manifests []string
+	for _, cmd := range cmds {
+		data, err := io.ReadAll(cmd.StdinR)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, string(data))
+	}
+	if len(manifests) != 2 || !strings.Contains(manifests[0], "name: weather") || !strings.Contains(manifests[1], "name: search") {
+		t.Fatalf("unexpected manifests in order: %q", manifests)
+	}
+}
+
+func TestApplyServersErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := writeApplyFile(t, dir, "bundle.yaml", testApplyBundle)
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		bad := writeApplyFile(t, dir, "bad.yaml", "kind: [")
+		mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+		if err := mgr.ApplyServers([]string{bad}, "mcp-servers", 1, false, 0); !errors.Is(err, ErrInvalidManifest) {
+			t.Fatalf("expected ErrInvalidManifest, got %v", err)
+		}
+	})
+
+	t.Run("apply failure skips the wait", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			if spec.Args[0] == "get" {
+				t.Errorf("waited after a failed apply")
+			}
+			if strings.Contains(strings.Join(spec.Args, " "), "-n mcp-servers") {
+				return &MockCommand{OutputData: []byte("admission webhook denied"), OutputErr: errors.New("exit status 1")}
+			}
+			return &MockCommand{OutputData: []byte("created")}
+		}
+		mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+		err := mgr.ApplyServers([]string{file}, "mcp-servers", 2, true, time.Minute)
+		if !errors.Is(err, ErrApplyServersFailed) || !strings.Contains(err.Error(), "1 of 2 documents failed to apply: "+file+"#1") {
+			t.Fatalf("expected ErrApplyServersFailed for the first document, got %v", err)
+		}
+	})
+
+	t.Run("wait timeout", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			if spec.Args[0] == "get" {
+				return &MockCommand{OutputData: []byte(`{"items":[{"metadata":{"name":"weather","generation":1},"status":{"observedGeneration":1,"phase":"Pending","message":"image pull backoff"}},{"metadata":{"name":"search","generation":1},"status":{"observedGeneration":1,"phase":"Ready"}}]}`)}
+			}
+			return &MockCommand{OutputData: []byte("created")}
+		}
+		mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+		err := mgr.ApplyServers([]string{file}, "mcp-servers", 2, true, 0)
+		if !errors.Is(err, ErrServerNotReady) || !strings.Contains(err.Error(), "1 of 2 servers not Ready: mcp-servers/weather") {
+			t.Fatalf("expected ErrServerNotReady, got %v", err)
+		}
+	})
+}
+
+func TestRunBoundedLimitsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	var done atomic.Int32
+	runBounded(20, 3, func(int) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		inFlight.Add(-1)
+		done.Add(1)
+	})
+	if done.Load() != 20 {
+		t.Fatalf("ran %d of 20 jobs", done.Load())
+	}
+	if peak.Load() > 3 {
+		t.Fatalf("peak concurrency %d exceeds 3", peak.Load())
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+// This file implements "server clone", which copies an existing MCPServer under a new name,
+// for example to give a developer a personal copy of a shared server. The spec is copied as is
+// apart from the ingress path, which moves to /<target>/mcp, the canary settings, which belong to
+// the source's route, and any --image override. Status and annotations are not copied.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// ServerCloneOptions holds the overrides applied to the copy.
+type ServerCloneOptions struct {
+	// TargetNamespace is the namespace of the copy; empty means the source's namespace.
+	TargetNamespace string
+	// Image replaces the image (and tag, when the reference has one).
+	Image string
+}
+
+func (m *ServerManager) newServerCloneCmd() *cobra.Command {
+	var opts ServerCloneOptions
+
+	cmd := &cobra.Command{
+		Use:   "clone <source> <target>",
+		Short: "Copy an MCP server under a new name",
+		Long: `Create a new MCPServer from the spec of an existing one. The copy is served at
+/<target>/mcp on the same ingress host. --namespace selects the source's namespace and
+--to-namespace the copy's (default: the same namespace).`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.CloneServer(args[0], serverNamespace(), args[1], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.TargetNamespace, "to-namespace", "", "Namespace for the copy (defaults to the source namespace)")
+	cmd.Flags().StringVar(&opts.Image, "image", "", "Image for the copy, optionally with a tag (defaults to the source image)")
+
+	return cmd
+}
+
+// CloneServer creates target from the spec of source in namespace.
+func (m *ServerManager) CloneServer(source, namespace, target string, opts ServerCloneOptions) error {
+	source, namespace, err := validateServerInput(source, namespace)
+	if err != nil {
+		return err
+	}
+	targetNamespace := opts.TargetNamespace
+	if targetNamespace == "" {
+		targetNamespace = namespace
+	}
+	if target, targetNamespace, err = validateServerInput(target, targetNamespace); err != nil {
+		return err
+	}
+	if opts.Image != "" {
+		if opts.Image, err = validateManifestValue("image", opts.Image); err != nil {
+			return err
+		}
+	}
+
+	// #nosec G204 -- source/namespace validated via validateServerInput.
+	out, err := m.kubectl.Output([]string{"get", "mcpserver", source, "-n", namespace, "-o", "json"})
+	if err != nil {
+		return m.cloneError(err, source, namespace, target, "Failed to read source server")
+	}
+	manifest, err := cloneServerManifest(out, target, targetNamespace, opts.Image)
+	if err != nil {
+		return m.cloneError(err, source, namespace, target, "Failed to read source server")
+	}
+
+	m.logger.Info("Cloning MCP server", zap.String("source", source), zap.String("target", target), zap.String("namespace", targetNamespace))
+	// #nosec G204 -- fixed kubectl command; manifest via stdin. create fails if target already exists.
+	cmd, err := m.kubectl.CommandArgs([]string{"create", "-f", "-"})
+	if err != nil {
+		return m.cloneError(err, source, namespace, target, "Failed to create server")
+	}
+	cmd.SetStdin(bytes.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	if err := cmd.Run(); err != nil {
+		return m.cloneError(err, source, namespace, target, "Failed to create server")
+	}
+
+	Success(fmt.Sprintf("Cloned %s/%s to %s/%s", namespace, source, targetNamespace, target))
+	return nil
+}
+
+// cloneServerManifest turns the JSON of a source MCPServer into the manifest of its copy.
+func cloneServerManifest(source []byte, target, namespace, image string) ([]byte, error) {
+	var server struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Metadata   struct {
+			Labels map[string]string `json:"labels,omitempty"`
+		} `json:"metadata"`
+		Spec map[string]any `json:"spec"`
+	}
+	if err := json.Unmarshal(source, &server); err != nil {
+		return nil, fmt.Errorf("parse mcpserver: %w", err)
+	}
+	if server.Spec == nil {
+		server.Spec = map[string]any{}
+	}
+
+	server.Spec["ingressPath"] = "/" + target + "/mcp"
+	delete(server.Spec, "canary")
+	if image != "" {
+		repo, tag := splitImage(image)
+		server.Spec["image"] = repo
+		delete(server.Spec, "imageTag")
+		if tag != "" {
+			server.Spec["imageTag"] = tag
+		}
+	}
+
+	metadata := map[string]any{"name": target, "namespace": namespace}
+	if len(server.Metadata.Labels) > 0 {
+		metadata["labels"] = server.Metadata.Labels
+	}
+	return json.Marshal(map[string]any{
+		"apiVersion": server.APIVersion,
+		"kind":       server.Kind,
+		"metadata":   metadata,
+		"spec":       server.Spec,
+	})
+}
+
+func (m *ServerManager) cloneError(err error, source, namespace, target, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrCloneServerFailed,
+		err,
+		fmt.Sprintf("failed to clone server %q in namespace %q to %q: %v", source, namespace, target, err),
+		map[string]any{"server": source, "namespace": namespace, "target": target, "component": "server"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
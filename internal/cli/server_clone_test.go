@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const cloneSourceJSON = `{
+  "apiVersion": "mcpruntime.org/v1alpha1",
+  "kind": "MCPServer",
+  "metadata": {
+    "name": "shared",
+    "namespace": "mcp-servers",
+    "labels": {"team": "search"},
+    "annotations": {"mcpruntime.org/delete-protection": "true"},
+    "resourceVersion": "42"
+  },
+  "spec": {
+    "image": "registry.local/shared",
+    "imageTag": "v3",
+    "ingressPath": "/shared/mcp",
+    "ingressHost": "mcp.example.com",
+    "canary": {"stableServer": "stable", "weight": 10},
+    "envVars": [{"name": "MODE", "value": "test"}]
+  },
+  "status": {"phase": "Ready"}
+}`
+
+func TestCloneServerManifest(t *testing.T) {
+	out, err := cloneServerManifest([]byte(cloneSourceJSON), "alice-shared", "dev", "registry.local/shared-wip:pr-7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		Metadata map[string]any `json:"metadata"`
+		Spec     map[string]any `json:"spec"`
+		Status   any            `json:"status"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if got.Metadata["name"] != "alice-shared" || got.Metadata["namespace"] != "dev" {
+		t.Fatalf("unexpected metadata %v", got.Metadata)
+	}
+	if _, ok := got.Metadata["annotations"]; ok {
+		t.Fatalf("annotations must not be copied: %v", got.Metadata)
+	}
+	if got.Spec["ingressPath"] != "/alice-shared/mcp" || got.Spec["ingressHost"] != "mcp.example.com" {
+		t.Fatalf("unexpected ingress settings %v", got.Spec)
+	}
+	if got.Spec["image"] != "registry.local/shared-wip" || got.Spec["imageTag"] != "pr-7" {
+		t.Fatalf("unexpected image %v:%v", got.Spec["image"], got.Spec["imageTag"])
+	}
+	if _, ok := got.Spec["canary"]; ok || got.Status != nil {
+		t.Fatalf("canary and status must not be copied: %s", out)
+	}
+	if _, ok := got.Spec["envVars"]; !ok {
+		t.Fatalf("expected env vars to be copied: %s", out)
+	}
+}
+
+func TestServerManager_CloneServer(t *testing.T) {
+	t.Run("creates the copy", func(t *testing.T) {
+		var created []byte
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				cmd := &MockCommand{Args: spec.Args}
+				switch {
+				case contains(spec.Args, "get"):
+					cmd.OutputData = []byte(cloneSourceJSON)
+				case contains(spec.Args, "create"):
+					cmd.RunFunc = func() error {
+						created, _ = io.ReadAll(cmd.StdinR)
+						return nil
+					}
+				}
+				return cmd
+			},
+		}
+		mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.CloneServer("shared", "mcp-servers", "alice-shared", ServerCloneOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Contains(created, []byte(`"name":"alice-shared"`)) || !bytes.Contains(created, []byte(`"namespace":"mcp-servers"`)) {
+			t.Fatalf("unexpected manifest %s", created)
+		}
+	})
+
+	t.Run("rejects invalid target names", func(t *testing.T) {
+		mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+		if err := mgr.CloneServer("shared", "mcp-servers", "Alice", ServerCloneOptions{}); !errors.Is(err, ErrInvalidServerName) {
+			t.Fatalf("expected ErrInvalidServerName, got %v", err)
+		}
+	})
+
+	t.Run("wraps a missing source", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("NotFound")}
+		mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.CloneServer("shared", "mcp-servers", "alice-shared", ServerCloneOptions{}); !errors.Is(err, ErrCloneServerFailed) {
+			t.Fatalf("expected ErrCloneServerFailed, got %v", err)
+		}
+	})
+}
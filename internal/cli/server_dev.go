@@ -0,0 +1,373 @@
+package cli
+
+// This file implements "server dev", an inner development loop for one MCP server. It
+// watches a local build context and, whenever a file changes, rebuilds the image, pushes it
+// to the platform registry under a fresh dev tag and patches the MCPServer so the operator
+// rolls it out. With --sync-to, changes after the first deploy are copied straight into the
+// running pods instead, for servers that reload their sources without a rebuild.
+//
+// Example usage:
+//   mcp-runtime server dev my-server --context ./src --image dev/my-server
+//   mcp-runtime server dev my-server --context ./src --in-cluster
+//   mcp-runtime server dev my-server --context ./src --sync-to /app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// devTagFormat timestamps dev tags so every build rolls the Deployment.
+const devTagFormat = "dev-20060102-150405"
+
+// serverDevOptions configures a dev loop.
+type serverDevOptions struct {
+	Name       string
+	Namespace  string
+	Context    string
+	Dockerfile string
+	Image      string
+	Registry   string
+	InCluster  bool
+	Builder    string
+	SyncTo     string
+	Interval   time.Duration
+}
+
+// fileStamp is what the dev loop compares to notice a changed file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// contextSnapshot maps the slash-separated paths of the files in a build context to their stamps.
+type contextSnapshot map[string]fileStamp
+
+func (m *ServerManager) newServerDevCmd() *cobra.Command {
+	opts := serverDevOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "dev [name]",
+		Short: "Rebuild and redeploy an MCP server whenever its sources change",
+		Long: `Watch a local build context and redeploy the server on every change: the image is
+rebuilt, pushed to the platform registry with a dev-<timestamp> tag, and the MCPServer is
+patched to that tag so the operator rolls it out. The server is created if it does not exist.
+
+With --sync-to, an image is built only on start; later changes are copied into the running
+pods under that directory (kubectl cp, so the image needs tar), for servers that reload their
+sources. Press Ctrl-C to stop.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			opts.Namespace = serverNamespace()
+			return m.RunDevLoop(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Context, "context", ".", "Build context directory to watch")
+	cmd.Flags().StringVar(&opts.Dockerfile, "dockerfile", "Dockerfile", "Path to Dockerfile")
+	cmd.Flags().StringVar(&opts.Image, "image", "", "Image repository in the registry (default: the server name)")
+	cmd.Flags().StringVar(&opts.Registry, "registry", "", "Registry URL (defaults to platform registry)")
+	cmd.Flags().BoolVar(&opts.InCluster, "in-cluster", false, "Build inside the cluster and push to the platform registry (no local docker needed)")
+	cmd.Flags().StringVar(&opts.Builder, "builder", builderKaniko, "In-cluster builder ("+strings.Join(imageBuilders, "|")+")")
+	cmd.Flags().StringVar(&opts.SyncTo, "sync-to", "", "Copy changed files into the running pods under this directory instead of rebuilding")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", time.Second, "How often to check the context for changes")
+
+	return cmd
+}
+
+// RunDevLoop deploys the build context once and then redeploys or syncs it on every change
+// until the command is interrupted. A failed iteration is reported and retried on the
+// next change.
+func (m *ServerManager) RunDevLoop(opts serverDevOptions) error {
+	name, namespace, err := validateServerInput(opts.Name, opts.Namespace)
+	if err != nil {
+		return err
+	}
+	opts.Name, opts.Namespace = name, namespace
+	if opts.Interval <= 0 {
+		err := newWithSentinel(ErrDevLoopFailed, fmt.Sprintf("--interval must be positive, got %s", opts.Interval))
+		Error("Invalid dev options")
+		logStructuredError(m.logger, err, "Invalid dev options")
+		return err
+	}
+	if opts.SyncTo != "" && !path.IsAbs(opts.SyncTo) {
+		err := newWithSentinel(ErrDevLoopFailed, fmt.Sprintf("--sync-to must be an absolute path in the container, got %q", opts.SyncTo))
+		Error("Invalid dev options")
+		logStructuredError(m.logger, err, "Invalid dev options")
+		return err
+	}
+	if opts.InCluster {
+		if err := validateImageBuilder(opts.Builder); err != nil {
+			Error("Invalid builder")
+			logStructuredError(m.logger, err, "Invalid builder")
+			return err
+		}
+	}
+	if opts.Registry == "" {
+		opts.Registry = getPlatformRegistryURL(m.logger)
+	}
+	if opts.Image == "" {
+		opts.Image = opts.Name
+	}
+
+	snapshot, err := snapshotContext(opts.Context)
+	if err != nil {
+		return m.devError(err, opts, "Failed to read build context")
+	}
+	if err := m.devDeploy(opts, time.Now()); err != nil {
+		return err
+	}
+	Info(fmt.Sprintf("Watching %s for changes (Ctrl-C to stop)", opts.Context))
+
+	ctx := commandContext()
+	for {
+		if err := sleepContext(ctx, opts.Interval); err != nil {
+			// Interrupting the loop is the normal way to stop it.
+			return nil
+		}
+		next, err := snapshotContext(opts.Context)
+		if err != nil {
+			Warn(fmt.Sprintf("Failed to read build context: %v", err))
+			continue
+		}
+		changed, removed := snapshot.diff(next)
+		if len(changed) == 0 && len(removed) == 0 {
+			continue
+		}
+		snapshot = next
+		Info(fmt.Sprintf("%d file(s) changed, %d removed", len(changed), len(removed)))
+		if opts.SyncTo != "" {
+			_ = m.devSync(opts, changed, removed)
+		} else {
+			_ = m.devDeploy(opts, time.Now())
+		}
+	}
+}
+
+// devImage returns the image repository dev builds are pushed to. An image that already
+// names a registry host is used as is.
+func devImage(opts serverDevOptions) string {
+	if dropRegistryPrefix(opts.Image) != opts.Image {
+		return opts.Image
+	}
+	return opts.Registry + "/" + opts.Image
+}
+
+// devDeploy builds and pushes the context under a tag derived from now, then points the
+// MCPServer at it.
+func (m *ServerManager) devDeploy(opts serverDevOptions, now time.Time) error {
+	image := devImage(opts)
+	tag := now.UTC().Format(devTagFormat)
+	ref := image + ":" + tag
+	m.logger.Info("Deploying dev build", zap.String("server", opts.Name), zap.String("image", ref))
+
+	if opts.InCluster {
+		relDockerfile, err := dockerfileInContext(opts.Context, opts.Dockerfile)
+		if err != nil {
+			Error("Invalid dockerfile")
+			logStructuredError(m.logger, err, "Invalid dockerfile")
+			return err
+		}
+		if err := buildImageInCluster(m.kubectl, m.logger, inClusterBuild{
+			Builder:     opts.Builder,
+			Namespace:   registryNamespace(),
+			Context:     opts.Context,
+			Dockerfile:  relDockerfile,
+			Destination: ref,
+		}); err != nil {
+			return err
+		}
+	} else if err := m.devDockerBuild(opts, ref); err != nil {
+		return err
+	}
+
+	if err := m.devUpdateServer(opts, image, tag); err != nil {
+		return m.devError(err, opts, "Failed to deploy dev build")
+	}
+	Success(fmt.Sprintf("Deployed %s to %s/%s", ref, opts.Namespace, opts.Name))
+	return nil
+}
+
+// devDockerBuild builds ref with the local docker daemon and pushes it.
+func (m *ServerManager) devDockerBuild(opts serverDevOptions, ref string) error {
+	steps := []struct {
+		args     []string
+		sentinel error
+		msg      string
+	}{
+		{append(append([]string{"build", "-f", opts.Dockerfile, "-t", ref}, proxyBuildArgs()...), opts.Context), ErrBuildImageFailed, "Failed to build image"},
+		{[]string{"push", ref}, ErrPushImageFailed, "Failed to push image"},
+	}
+	for _, step := range steps {
+		// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
+		cmd, err := execCommandWithValidators(containerTool(), step.args)
+		if err == nil {
+			cmd.SetStdout(DefaultPrinter.Stdout())
+			cmd.SetStderr(DefaultPrinter.Stderr())
+			err = cmd.Run()
+		}
+		if err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				step.sentinel,
+				err,
+				fmt.Sprintf("%s %s: %v", strings.ToLower(step.msg), ref, err),
+				map[string]any{"server": opts.Name, "image": ref, "component": "server"},
+			)
+			Error(step.msg)
+			logStructuredError(m.logger, wrappedErr, step.msg)
+			return wrappedErr
+		}
+	}
+	return nil
+}
+
+// devUpdateServer patches the server's image and tag, creating the server when it does
+// not exist yet.
+func (m *ServerManager) devUpdateServer(opts serverDevOptions, image, tag string) error {
+	// #nosec G204 -- name/namespace validated via validateServerInput.
+	if _, err := m.kubectl.Output([]string{"get", "mcpserver", opts.Name, "-n", opts.Namespace, "-o", "name"}); err != nil {
+		return m.CreateServer(opts.Name, opts.Namespace, image, tag)
+	}
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"image": image, "imageTag": tag},
+	})
+	if err != nil {
+		return err
+	}
+	// #nosec G204 -- name/namespace validated via validateServerInput; patch is generated JSON.
+	return m.kubectl.Run([]string{"patch", "mcpserver", opts.Name, "-n", opts.Namespace, "--type=merge", "-p", string(patch)})
+}
+
+// devSync copies the changed files into every running pod of the server under
+// opts.SyncTo and deletes the removed ones there.
+func (m *ServerManager) devSync(opts serverDevOptions, changed, removed []string) error {
+	// #nosec G204 -- name/namespace validated via validateServerInput.
+	out, err := m.kubectl.Output([]string{"get", "pods", "-n", opts.Namespace, "-l", "app=" + opts.Name,
+		"--field-selector=status.phase=Running", "-o", "jsonpath={.items[*].metadata.name}"})
+	if err != nil {
+		return m.devSyncError(err, opts)
+	}
+	pods := strings.Fields(string(out))
+	if len(pods) == 0 {
+		Warn(fmt.Sprintf("No running pods of %s/%s to sync into", opts.Namespace, opts.Name))
+		return nil
+	}
+
+	dirs := map[string]bool{}
+	for _, file := range changed {
+		dirs[path.Dir(path.Join(opts.SyncTo, file))] = true
+	}
+	for _, pod := range pods {
+		var args [][]string
+		if len(dirs) > 0 {
+			args = append(args, append([]string{"exec", pod, "-n", opts.Namespace, "--", "mkdir", "-p"}, sortedSetKeys(dirs)...))
+		}
+		for _, file := range changed {
+			args = append(args, []string{"cp", filepath.Join(opts.Context, filepath.FromSlash(file)),
+				fmt.Sprintf("%s/%s:%s", opts.Namespace, pod, path.Join(opts.SyncTo, file))})
+		}
+		if len(removed) > 0 {
+			targets := make([]string, 0, len(removed))
+			for _, file := range removed {
+				targets = append(targets, path.Join(opts.SyncTo, file))
+			}
+			args = append(args, append([]string{"exec", pod, "-n", opts.Namespace, "--", "rm", "-f"}, targets...))
+		}
+		for _, a := range args {
+			// #nosec G204 -- pod names come from kubectl; paths are relative to the watched context.
+			if out, err := m.kubectl.CombinedOutput(a); err != nil {
+				return m.devSyncError(fmt.Errorf("%s: %w: %s", pod, err, strings.TrimSpace(string(out))), opts)
+			}
+		}
+	}
+	Success(fmt.Sprintf("Synced %d file(s) into %d pod(s)", len(changed)+len(removed), len(pods)))
+	return nil
+}
+
+func (m *ServerManager) devSyncError(err error, opts serverDevOptions) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrDevSyncFailed,
+		err,
+		fmt.Sprintf("failed to sync files into %s/%s: %v", opts.Namespace, opts.Name, err),
+		map[string]any{"server": opts.Name, "namespace": opts.Namespace, "component": "server"},
+	)
+	Error("Failed to sync files")
+	logStructuredError(m.logger, wrappedErr, "Failed to sync files")
+	return wrappedErr
+}
+
+func (m *ServerManager) devError(err error, opts serverDevOptions, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrDevLoopFailed,
+		err,
+		fmt.Sprintf("%s %q in namespace %q: %v", strings.ToLower(msg), opts.Name, opts.Namespace, err),
+		map[string]any{"server": opts.Name, "namespace": opts.Namespace, "context": opts.Context, "component": "server"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
+
+// snapshotContext stamps every file under dir, skipping .git directories.
+func snapshotContext(dir string) (contextSnapshot, error) {
+	snapshot := contextSnapshot{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(rel)] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return snapshot, err
+}
+
+// diff returns the files of next that are new or changed since s, and the files of s that
+// next no longer has, both sorted.
+func (s contextSnapshot) diff(next contextSnapshot) (changed, removed []string) {
+	for file, stamp := range next {
+		if old, ok := s[file]; !ok || old != stamp {
+			changed = append(changed, file)
+		}
+	}
+	for file := range s {
+		if _, ok := next[file]; !ok {
+			removed = append(removed, file)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return changed, removed
+}
+
+// sortedSetKeys returns the members of set in order.
+func sortedSetKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
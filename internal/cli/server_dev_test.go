@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestContextSnapshotDiff(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.py", "print(1)")
+	write("lib/util.py", "x = 1")
+	write(".git/HEAD", "ref")
+
+	before, err := snapshotContext(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := before[".git/HEAD"]; ok || len(before) != 2 {
+		t.Fatalf("unexpected snapshot %v", before)
+	}
+
+	write("lib/util.py", "x = 22")
+	write("lib/new.py", "")
+	if err := os.Remove(filepath.Join(dir, "main.py")); err != nil {
+		t.Fatal(err)
+	}
+	after, err := snapshotContext(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, removed := before.diff(after)
+	if !reflect.DeepEqual(changed, []string{"lib/new.py", "lib/util.py"}) || !reflect.DeepEqual(removed, []string{"main.py"}) {
+		t.Fatalf("diff() = %v, %v", changed, removed)
+	}
+}
+
+func TestDevImage(t *testing.T) {
+	if got := devImage(serverDevOptions{Registry: "registry.local", Image: "dev/my-server"}); got != "registry.local/dev/my-server" {
+		t.Fatalf("devImage() = %q", got)
+	}
+	if got := devImage(serverDevOptions{Registry: "registry.local", Image: "ghcr.io/acme/my-server"}); got != "ghcr.io/acme/my-server" {
+		t.Fatalf("devImage() = %q", got)
+	}
+}
+
+func TestDevDeploy(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	opts := serverDevOptions{Name: "my-server", Namespace: "mcp-servers", Context: "./src", Dockerfile: "Dockerfile", Image: "dev/my-server", Registry: "registry.local"}
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	t.Run("builds pushes and patches", func(t *testing.T) {
+		originalExecutor := execExecutor
+		defer func() { execExecutor = originalExecutor }()
+		docker := &MockExecutor{}
+		execExecutor = docker
+		kubectl := &MockExecutor{}
+		mgr := NewServerManager(&KubectlClient{exec: kubectl}, zap.NewNop())
+
+		if err := mgr.devDeploy(opts, now); err != nil {
+			t.Fatalf("devDeploy() error: %v", err)
+		}
+		ref := "registry.local/dev/my-server:dev-20260304-050607"
+		if len(docker.Commands) != 2 || !reflect.DeepEqual(docker.Commands[0].Args, []string{"build", "-f", "Dockerfile", "-t", ref, "./src"}) ||
+			!reflect.DeepEqual(docker.Commands[1].Args, []string{"push", ref}) {
+			t.Fatalf("unexpected docker commands %+v", docker.Commands)
+		}
+		want := []string{"patch", "mcpserver", "my-server", "-n", "mcp-servers", "--type=merge", "-p", `{"spec":{"image":"registry.local/dev/my-server","imageTag":"dev-20260304-050607"}}`}
+		if got := kubectl.LastCommand().Args; !reflect.DeepEqual(got, want) {
+			t.Fatalf("kubectl args = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("creates a missing server", func(t *testing.T) {
+		originalExecutor := execExecutor
+		defer func() { execExecutor = originalExecutor }()
+		execExecutor = &MockExecutor{}
+		kubectl := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+			if spec.Args[0] == "get" {
+				return &MockCommand{OutputErr: errors.New("not found")}
+			}
+			return &MockCommand{}
+		}}
+		mgr := NewServerManager(&KubectlClient{exec: kubectl}, zap.NewNop())
+
+		if err := mgr.devDeploy(opts, now); err != nil {
+			t.Fatalf("devDeploy() error: %v", err)
+		}
+		if args := kubectl.LastCommand().Args; args[0] != "apply" {
+			t.Fatalf("expected the server to be applied, got %v", args)
+		}
+	})
+
+	t.Run("stops when the build fails", func(t *testing.T) {
+		originalExecutor := execExecutor
+		defer func() { execExecutor = originalExecutor }()
+		execExecutor = &MockExecutor{DefaultRunErr: errors.New("build failed")}
+		kubectl := &MockExecutor{}
+		mgr := NewServerManager(&KubectlClient{exec: kubectl}, zap.NewNop())
+
+		if err := mgr.devDeploy(opts, now); !errors.Is(err, ErrBuildImageFailed) {
+			t.Fatalf("expected ErrBuildImageFailed, got %v", err)
+		}
+		if len(kubectl.Commands) != 0 {
+			t.Fatalf("expected no kubectl calls, got %+v", kubectl.Commands)
+		}
+	})
+}
+
+func TestDevSync(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	opts := serverDevOptions{Name: "my-server", Namespace: "mcp-servers", Context: "src", SyncTo: "/app"}
+	kubectl := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		if spec.Args[0] == "get" {
+			return &MockCommand{OutputData: []byte("pod-a pod-b")}
+		}
+		return &MockCommand{}
+	}}
+	mgr := NewServerManager(&KubectlClient{exec: kubectl}, zap.NewNop())
+
+	if err := mgr.devSync(opts, []string{"lib/util.py"}, []string{"old.py"}); err != nil {
+		t.Fatalf("devSync() error: %v", err)
+	}
+	var got []string
+	for _, cmd := range kubectl.Commands[1:] {
+		got = append(got, strings.Join(cmd.Args, " "))
+	}
+	want := []string{
+		"exec pod-a -n mcp-servers -- mkdir -p /app/lib",
+		"cp " + filepath.Join("src", "lib", "util.py") + " mcp-servers/pod-a:/app/lib/util.py",
+		"exec pod-a -n mcp-servers -- rm -f /app/old.py",
+		"exec pod-b -n mcp-servers -- mkdir -p /app/lib",
+		"cp " + filepath.Join("src", "lib", "util.py") + " mcp-servers/pod-b:/app/lib/util.py",
+		"exec pod-b -n mcp-servers -- rm -f /app/old.py",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("kubectl calls:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	kubectl.CommandFunc = func(spec ExecSpec) *MockCommand {
+		if spec.Args[0] == "get" {
+			return &MockCommand{OutputData: []byte("pod-a")}
+		}
+		return &MockCommand{OutputErr: errors.New("tar: not found")}
+	}
+	if err := mgr.devSync(opts, []string{"main.py"}, nil); !errors.Is(err, ErrDevSyncFailed) {
+		t.Fatalf("expected ErrDevSyncFailed, got %v", err)
+	}
+}
+
+func TestRunDevLoopValidatesOptions(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	for _, opts := range []serverDevOptions{
+		{Name: "my-server", Namespace: "mcp-servers", Interval: 0},
+		{Name: "my-server", Namespace: "mcp-servers", Interval: time.Second, SyncTo: "app"},
+	} {
+		if err := mgr.RunDevLoop(opts); !errors.Is(err, ErrDevLoopFailed) {
+			t.Fatalf("%+v: expected ErrDevLoopFailed, got %v", opts, err)
+		}
+	}
+}
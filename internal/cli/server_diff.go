@@ -0,0 +1,261 @@
+package cli
+
+// This file implements "server diff", which shows what applying a manifest would change
+// on the live MCPServers. Each document is applied with --dry-run=server, so defaults,
+// admission and the fields the operator fills in are taken into account, and the spec of
+// the result is compared field by field with the live object's.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// specChange is one differing spec field; Old is empty for added fields and New for
+// removed ones.
+type specChange struct {
+	Path string
+	Old  string
+	New  string
+}
+
+func (m *ServerManager) newServerDiffCmd() *cobra.Command {
+	var files []string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show what applying MCP server manifests would change",
+		Long: `Compare MCPServer manifests with the live servers before applying them. Every
+MCPServer document is applied with a server-side dry run and the spec of the result is
+compared field by field with the live server, so only real changes are listed:
+  + field added   - field removed   ~ field changed
+Other kinds in the manifests are skipped.`,
+		Example: `  mcp-runtime server diff -f weather.yaml
+  mcp-runtime server diff -f manifests/ --namespace team-a`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.DiffServers(files, serverNamespace())
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&files, "filename", "f", nil, "Manifest file or directory (repeatable)")
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
+
+// DiffServers prints the spec changes applying the MCPServer documents in paths would
+// make. Documents without a namespace are compared in namespace.
+func (m *ServerManager) DiffServers(paths []string, namespace string) error {
+	namespace, err := validateManifestValue("namespace", namespace)
+	if err != nil {
+		return err
+	}
+
+	docs, err := loadApplyDocuments(paths, namespace)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrInvalidManifest,
+			err,
+			fmt.Sprintf("invalid manifest: %v", err),
+			map[string]any{"paths": strings.Join(paths, ","), "component": "server"},
+		)
+		Error("Invalid manifest")
+		logStructuredError(m.logger, wrappedErr, "Invalid manifest")
+		return wrappedErr
+	}
+
+	var servers, changed int
+	for _, doc := range docs {
+		if doc.Kind != "MCPServer" {
+			continue
+		}
+		servers++
+		live, err := m.liveServerSpec(doc)
+		var desired map[string]any
+		if err == nil {
+			desired, err = m.dryRunServerSpec(doc)
+		}
+		if err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrDiffServerFailed,
+				err,
+				fmt.Sprintf("failed to diff %s: %v", doc.Source, err),
+				map[string]any{"server": doc.Name, "namespace": doc.Namespace, "source": doc.Source, "component": "server"},
+			)
+			Error("Failed to diff server")
+			logStructuredError(m.logger, wrappedErr, "Failed to diff server")
+			return wrappedErr
+		}
+
+		title := fmt.Sprintf("MCPServer %s/%s", doc.Namespace, doc.Name)
+		changes := diffSpecs(live, desired)
+		switch {
+		case live == nil:
+			DefaultPrinter.Println(Cyan(title) + " (new)")
+		case len(changes) == 0:
+			DefaultPrinter.Println(Cyan(title) + " unchanged")
+			continue
+		default:
+			DefaultPrinter.Println(Cyan(title))
+		}
+		changed++
+		for _, c := range changes {
+			DefaultPrinter.Println("  " + formatSpecChange(c))
+		}
+	}
+
+	switch {
+	case servers == 0:
+		Warn("No MCPServer documents found")
+	case changed == 0:
+		Success(fmt.Sprintf("No changes to %d servers", servers))
+	default:
+		Info(fmt.Sprintf("%d of %d servers would change", changed, servers))
+	}
+	return nil
+}
+
+// liveServerSpec returns the spec of the live server doc describes, or nil when the
+// server does not exist yet.
+func (m *ServerManager) liveServerSpec(doc applyDocument) (map[string]any, error) {
+	// #nosec G204 -- name and namespace come from a schema-checked manifest; exec validators reject shell metacharacters.
+	out, err := m.kubectl.Output([]string{"get", "mcpserver", doc.Name, "-n", doc.Namespace, "-o", "json", "--ignore-not-found"})
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	return objectSpec(out)
+}
+
+// dryRunServerSpec returns the spec the server would have after applying doc.
+func (m *ServerManager) dryRunServerSpec(doc applyDocument) (map[string]any, error) {
+	args := []string{"apply", "-f", "-", "--dry-run=server", "-o", "json"}
+	if doc.defaultNamespace {
+		args = append(args, "-n", doc.Namespace)
+	}
+	// #nosec G204 -- fixed kubectl command; namespace validated, manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	// stderr is kept apart so kubectl warnings do not end up in the JSON.
+	var stderr bytes.Buffer
+	cmd.SetStdin(bytes.NewReader(doc.Data))
+	cmd.SetStderr(&stderr)
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w", msg, err)
+		}
+		return nil, err
+	}
+	return objectSpec(out)
+}
+
+// objectSpec decodes the spec of a JSON object.
+func objectSpec(data []byte) (map[string]any, error) {
+	var obj struct {
+		Spec map[string]any `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("parse mcpserver: %w", err)
+	}
+	if obj.Spec == nil {
+		return map[string]any{}, nil
+	}
+	return obj.Spec, nil
+}
+
+// diffSpecs compares two specs leaf by leaf and returns the differing fields in path
+// order. A nil live spec reports every desired field as added.
+func diffSpecs(live, desired map[string]any) []specChange {
+	oldFields := map[string]string{}
+	newFields := map[string]string{}
+	if live != nil {
+		flattenSpec("spec", live, oldFields)
+	}
+	flattenSpec("spec", desired, newFields)
+
+	var changes []specChange
+	for path, value := range newFields {
+		if old, ok := oldFields[path]; !ok || old != value {
+			changes = append(changes, specChange{Path: path, Old: old, New: value})
+		}
+	}
+	for path, old := range oldFields {
+		if _, ok := newFields[path]; !ok {
+			changes = append(changes, specChange{Path: path, Old: old})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return lessSpecPath(changes[i].Path, changes[j].Path) })
+	return changes
+}
+
+// flattenSpec records every leaf of value under its dotted path, with list items as
+// path[i]. Empty objects and lists are leaves, so clearing a field still shows up.
+func flattenSpec(path string, value any, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			out[path] = "{}"
+		}
+		for key, item := range v {
+			flattenSpec(path+"."+key, item, out)
+		}
+	case []any:
+		if len(v) == 0 {
+			out[path] = "[]"
+		}
+		for i, item := range v {
+			flattenSpec(fmt.Sprintf("%s[%d]", path, i), item, out)
+		}
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			data = []byte(fmt.Sprint(v))
+		}
+		out[path] = string(data)
+	}
+}
+
+// lessSpecPath orders paths by segment, with list indexes compared as numbers so that
+// env[2] sorts before env[10].
+func lessSpecPath(a, b string) bool {
+	as, bs := splitSpecPath(a), splitSpecPath(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		ai, aerr := strconv.Atoi(as[i])
+		bi, berr := strconv.Atoi(bs[i])
+		if aerr == nil && berr == nil {
+			return ai < bi
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
+
+func splitSpecPath(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' || r == ']' })
+}
+
+// formatSpecChange renders a change as a colored +, - or ~ line.
+func formatSpecChange(c specChange) string {
+	switch {
+	case c.Old == "":
+		return Green(fmt.Sprintf("+ %s: %s", c.Path, c.New))
+	case c.New == "":
+		return Red(fmt.Sprintf("- %s: %s", c.Path, c.Old))
+	default:
+		return Yellow(fmt.Sprintf("~ %s: %s -> %s", c.Path, c.Old, c.New))
+	}
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestDiffSpecs(t *testing.T) {
+	live := map[string]any{
+		"image":    "weather",
+		"replicas": float64(1),
+		"tlsOnly":  true,
+		"envVars":  []any{map[string]any{"name": "A", "value": "1"}},
+	}
+	desired := map[string]any{
+		"image":    "weather",
+		"replicas": float64(3),
+		"envVars":  []any{map[string]any{"name": "A", "value": "1"}, map[string]any{"name": "B", "value": "2"}},
+		"labels":   map[string]any{},
+	}
+	var got []string
+	for _, c := range diffSpecs(live, desired) {
+		got = append(got, c.Path+"|"+c.Old+"|"+c.New)
+	}
+	want := []string{
+		`spec.envVars[1].name||"B"`,
+		`spec.envVars[1].value||"2"`,
+		`spec.labels||{}`,
+		`spec.replicas|1|3`,
+		`spec.tlsOnly|true|`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("diffSpecs() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if changes := diffSpecs(nil, map[string]any{"image": "weather"}); len(changes) != 1 || changes[0].Old != "" {
+		t.Fatalf("expected every field added for a new server, got %+v", changes)
+	}
+}
+
+func TestLessSpecPath(t *testing.T) {
+	if !lessSpecPath("spec.env[2].name", "spec.env[10].name") {
+		t.Fatal("expected list indexes to sort numerically")
+	}
+	if !lessSpecPath("spec.env", "spec.env[0].name") || lessSpecPath("spec.image", "spec.env[0]") {
+		t.Fatal("unexpected path order")
+	}
+}
+
+func TestDiffServers(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+	dir := t.TempDir()
+	path := writeApplyFile(t, dir, "bundle.yaml", testApplyBundle+"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shared\n")
+
+	var dryRuns []ExecSpec
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "get mcpserver weather"):
+			return &MockCommand{Args: spec.Args, OutputData: []byte(`{"spec":{"image":"weather","replicas":1}}`)}
+		case strings.HasPrefix(args, "get mcpserver search"):
+			return &MockCommand{Args: spec.Args}
+		case strings.HasPrefix(args, "apply"):
+			dryRuns = append(dryRuns, spec)
+			return &MockCommand{Args: spec.Args, OutputData: []byte(`{"spec":{"image":"weather","replicas":2}}`)}
+		}
+		t.Fatalf("unexpected command %v", spec.Args)
+		return nil
+	}
+	m := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	if err := m.DiffServers([]string{path}, "mcp-servers"); err != nil {
+		t.Fatalf("DiffServers() error: %v", err)
+	}
+	if len(dryRuns) != 2 {
+		t.Fatalf("expected a dry run per MCPServer, got %d", len(dryRuns))
+	}
+	if !commandHasArgs(dryRuns[0], "--dry-run=server", "-n", "mcp-servers") || commandHasArgs(dryRuns[1], "-n") {
+		t.Fatalf("unexpected dry runs %v %v", dryRuns[0].Args, dryRuns[1].Args)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"MCPServer mcp-servers/weather",
+		"~ spec.replicas: 1 -> 2",
+		"MCPServer team-a/search", "(new)",
+		"+ spec.replicas: 2",
+		"2 of 2 servers would change",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output misses %q:\n%s", want, out)
+		}
+	}
+
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		if spec.Args[0] == "apply" {
+			return &MockCommand{OutputErr: errors.New("exit status 1")}
+		}
+		return &MockCommand{}
+	}
+	if err := m.DiffServers([]string{path}, "mcp-servers"); !errors.Is(err, ErrDiffServerFailed) {
+		t.Fatalf("expected ErrDiffServerFailed, got %v", err)
+	}
+	if err := m.DiffServers([]string{filepath.Join(dir, "missing.yaml")}, "mcp-servers"); !errors.Is(err, ErrInvalidManifest) {
+		t.Fatalf("expected ErrInvalidManifest, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package cli
+
+// This file implements "server migrate", which moves MCPServers to another namespace for
+// namespace reorganizations. The selected servers are recreated in the target namespace with
+// their labels, annotations and spec; ingress paths gain the target namespace so the copies do
+// not collide with the originals on a shared host, and the image pull secrets they reference
+// are copied along. Once every copy is Ready the originals can be deleted with --delete-source.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// ServerMigrateOptions selects the servers to migrate and what happens afterwards.
+type ServerMigrateOptions struct {
+	FromNamespace string
+	ToNamespace   string
+	// Selector is a label selector limiting the servers; empty migrates all of them.
+	Selector string
+	// DeleteSource deletes the originals once every copy is Ready.
+	DeleteSource bool
+	// Force deletes protected originals too.
+	Force   bool
+	Timeout time.Duration
+}
+
+func (m *ServerManager) newServerMigrateCmd() *cobra.Command {
+	var opts ServerMigrateOptions
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move MCP servers to another namespace",
+		Long: `Recreate the MCPServers of one namespace in another, for namespace reorganizations.
+Each copy keeps its name, labels, annotations and spec, with two rewrites:
+  - the ingress path gains the target namespace (/weather/mcp becomes /<to-ns>/weather/mcp,
+    and a /<from-ns>/ segment is replaced), so copies and originals can share a host
+  - the image pull secrets the servers reference are copied to the target namespace
+The command then waits until every copy is Ready. With --delete-source the originals are
+deleted afterwards; protected servers need --force.`,
+		Example: `  mcp-runtime server migrate --from-ns team-a --to-ns search
+  mcp-runtime server migrate --from-ns team-a --to-ns search --selector app=weather --delete-source`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.MigrateServers(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.FromNamespace, "from-ns", "", "Namespace the servers are in")
+	cmd.Flags().StringVar(&opts.ToNamespace, "to-ns", "", "Namespace to move the servers to")
+	cmd.Flags().StringVarP(&opts.Selector, "selector", "l", "", "Label selector limiting the servers, e.g. app=weather")
+	cmd.Flags().BoolVar(&opts.DeleteSource, "delete-source", false, "Delete the original servers once the copies are Ready")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "With --delete-source, also delete protected servers")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 10*time.Minute, "How long to wait for the copies to become Ready")
+	_ = cmd.MarkFlagRequired("from-ns")
+	_ = cmd.MarkFlagRequired("to-ns")
+
+	return cmd
+}
+
+// MigrateServers recreates the selected servers of opts.FromNamespace in opts.ToNamespace,
+// waits for them to become Ready and, with opts.DeleteSource, deletes the originals.
+func (m *ServerManager) MigrateServers(opts ServerMigrateOptions) error {
+	from, err := validateManifestValue("from-ns", opts.FromNamespace)
+	if err != nil {
+		return err
+	}
+	to, err := validateManifestValue("to-ns", opts.ToNamespace)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return newWithSentinel(ErrMigrateServersFailed, "--from-ns and --to-ns must differ")
+	}
+	selector := opts.Selector
+	if selector != "" {
+		if selector, err = validateManifestValue("selector", selector); err != nil {
+			return err
+		}
+	}
+
+	args := []string{"get", "mcpservers", "-n", from, "-o", "json"}
+	if selector != "" {
+		args = append(args, "-l", selector)
+	}
+	// #nosec G204 -- namespaces and selector validated; kubectl parses the selector.
+	out, err := m.kubectl.Output(args)
+	if err != nil {
+		return m.migrateError(err, from, to, "Failed to list servers")
+	}
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return m.migrateError(fmt.Errorf("parse mcpserver list: %w", err), from, to, "Failed to list servers")
+	}
+	if len(list.Items) == 0 {
+		Warn(fmt.Sprintf("No MCPServers to migrate in namespace %s", from))
+		return nil
+	}
+
+	var servers []applyDocument
+	rows := [][]string{{"SERVER", "FROM PATH", "TO PATH"}}
+	pullSecrets := map[string]bool{}
+	for _, item := range list.Items {
+		manifest, name, paths, err := migrateServerManifest(item, from, to)
+		if err != nil {
+			return m.migrateError(err, from, to, "Failed to read server")
+		}
+		for _, secret := range serverPullSecrets(item) {
+			pullSecrets[secret] = true
+		}
+		servers = append(servers, applyDocument{Kind: "MCPServer", Name: name, Namespace: to, Data: manifest})
+		rows = append(rows, []string{name, orDash(paths[0]), orDash(paths[1])})
+	}
+
+	for secret := range pullSecrets {
+		if err := m.copySecret(secret, from, to); err != nil {
+			return m.migrateError(err, from, to, "Failed to copy image pull secret")
+		}
+	}
+
+	m.logger.Info("Migrating MCP servers", zap.String("from", from), zap.String("to", to), zap.Int("servers", len(servers)))
+	for _, server := range servers {
+		if err := m.createFromManifest(server.Data); err != nil {
+			return m.migrateError(fmt.Errorf("create %s/%s: %w", to, server.Name, err), from, to, "Failed to create server")
+		}
+	}
+	Table(rows)
+
+	if err := m.waitForServersReady(servers, time.Now().Add(opts.Timeout)); err != nil {
+		return m.migrateError(err, from, to, "Migrated servers not Ready; the originals were kept")
+	}
+	if !opts.DeleteSource {
+		Success(fmt.Sprintf("Migrated %d servers from %s to %s; delete the originals with --delete-source once clients use the new paths", len(servers), from, to))
+		return nil
+	}
+
+	for _, server := range servers {
+		if err := m.DeleteServer(server.Name, from, opts.Force); err != nil {
+			return err
+		}
+	}
+	Success(fmt.Sprintf("Moved %d servers from %s to %s", len(servers), from, to))
+	return nil
+}
+
+// migrateServerManifest turns a live MCPServer into the manifest of its copy in namespace
+// to, returning the server's name and its old and new ingress paths.
+func migrateServerManifest(server map[string]any, from, to string) ([]byte, string, [2]string, error) {
+	var paths [2]string
+	server = sanitizeObject(server)
+	metadata, _ := server["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	if name == "" {
+		return nil, "", paths, fmt.Errorf("mcpserver without a name")
+	}
+	metadata["namespace"] = to
+	delete(metadata, "ownerReferences")
+	delete(metadata, "finalizers")
+
+	if spec, ok := server["spec"].(map[string]any); ok {
+		if path, _ := spec["ingressPath"].(string); path != "" {
+			paths = [2]string{path, migratedIngressPath(path, from, to)}
+			spec["ingressPath"] = paths[1]
+		}
+	}
+	data, err := json.Marshal(server)
+	return data, name, paths, err
+}
+
+// migratedIngressPath rewrites the first /<from>/ segment of path to /<to>/, or puts
+// /<to> in front of paths without one.
+func migratedIngressPath(path, from, to string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == from {
+			segments[i] = to
+			return strings.Join(segments, "/")
+		}
+	}
+	return "/" + to + path
+}
+
+// serverPullSecrets returns the image pull secrets a live MCPServer references.
+func serverPullSecrets(server map[string]any) []string {
+	spec, _ := server["spec"].(map[string]any)
+	refs, _ := spec["imagePullSecrets"].([]any)
+	var names []string
+	for _, ref := range refs {
+		if name, ok := ref.(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// copySecret copies Secret name from namespace from to namespace to, keeping a Secret of
+// that name already in the target.
+func (m *ServerManager) copySecret(name, from, to string) error {
+	// #nosec G204 -- secret names from MCPServer specs; kubectl validates names.
+	existing, err := m.kubectl.Output([]string{"get", "secret", name, "-n", to, "-o", "name", "--ignore-not-found"})
+	if err != nil {
+		return fmt.Errorf("get secret %s/%s: %w", to, name, err)
+	}
+	if len(bytes.TrimSpace(existing)) > 0 {
+		return nil
+	}
+	// #nosec G204 -- secret names from MCPServer specs; kubectl validates names.
+	out, err := m.kubectl.Output([]string{"get", "secret", name, "-n", from, "-o", "json", "--ignore-not-found"})
+	if err != nil {
+		return fmt.Errorf("get secret %s/%s: %w", from, name, err)
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		Warn(fmt.Sprintf("Image pull secret %s/%s not found; create it in %s before the servers pull images", from, name, to))
+		return nil
+	}
+	var secret map[string]any
+	if err := json.Unmarshal(out, &secret); err != nil {
+		return fmt.Errorf("parse secret %s/%s: %w", from, name, err)
+	}
+	secret = sanitizeObject(secret)
+	if metadata, ok := secret["metadata"].(map[string]any); ok {
+		metadata["namespace"] = to
+		delete(metadata, "ownerReferences")
+	}
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+	if err := m.createFromManifest(data); err != nil {
+		return fmt.Errorf("create secret %s/%s: %w", to, name, err)
+	}
+	Info(fmt.Sprintf("Copied image pull secret %s to %s", name, to))
+	return nil
+}
+
+// createFromManifest creates the object in manifest; it fails if the object exists.
+func (m *ServerManager) createFromManifest(manifest []byte) error {
+	// #nosec G204 -- fixed kubectl command; manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs([]string{"create", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(bytes.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	return cmd.Run()
+}
+
+func (m *ServerManager) migrateError(err error, from, to, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrMigrateServersFailed,
+		err,
+		fmt.Sprintf("failed to migrate servers from %q to %q: %v", from, to, err),
+		map[string]any{"from_namespace": from, "to_namespace": to, "component": "server"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
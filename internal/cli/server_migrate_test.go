@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const migrateListJSON = `{"items": [
+  {"apiVersion": "mcpruntime.org/v1alpha1", "kind": "MCPServer",
+   "metadata": {"name": "weather", "namespace": "team-a", "uid": "1", "resourceVersion": "7", "labels": {"app": "weather"}},
+   "spec": {"image": "weather", "ingressPath": "/weather/mcp", "imagePullSecrets": ["pull"]},
+   "status": {"phase": "Ready"}},
+  {"apiVersion": "mcpruntime.org/v1alpha1", "kind": "MCPServer",
+   "metadata": {"name": "search", "namespace": "team-a"},
+   "spec": {"image": "search", "ingressPath": "/team-a/search/mcp"}}
+]}`
+
+func TestMigratedIngressPath(t *testing.T) {
+	tests := map[string]string{
+		"/weather/mcp":        "/search/weather/mcp",
+		"/team-a/weather/mcp": "/search/weather/mcp",
+		"/v1/team-a/mcp":      "/v1/search/mcp",
+	}
+	for path, want := range tests {
+		if got := migratedIngressPath(path, "team-a", "search"); got != want {
+			t.Errorf("migratedIngressPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestMigrateServers(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	var created []map[string]any
+	var deleted []string
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		args := strings.Join(spec.Args, " ")
+		switch {
+		case strings.HasPrefix(args, "get mcpservers -n team-a"):
+			cmd.OutputData = []byte(migrateListJSON)
+		case strings.HasPrefix(args, "get secret pull -n search"):
+		case strings.HasPrefix(args, "get secret pull -n team-a"):
+			cmd.OutputData = []byte(`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "pull", "namespace": "team-a", "uid": "2"}, "data": {".dockerconfigjson": "e30="}}`)
+		case strings.HasPrefix(args, "create"):
+			cmd.RunFunc = func() error {
+				data, _ := io.ReadAll(cmd.StdinR)
+				var obj map[string]any
+				_ = json.Unmarshal(data, &obj)
+				created = append(created, obj)
+				return nil
+			}
+		case strings.HasPrefix(args, "get mcpserver -n search"):
+			cmd.OutputData = []byte(`{"items": [{"metadata": {"name": "weather"}, "status": {"phase": "Ready"}}, {"metadata": {"name": "search"}, "status": {"phase": "Ready"}}]}`)
+		case strings.HasPrefix(args, "get mcpserver"):
+		case strings.HasPrefix(args, "delete"):
+			deleted = append(deleted, spec.Args[2]+"/"+spec.Args[4])
+		default:
+			t.Fatalf("unexpected command %v", spec.Args)
+		}
+		return cmd
+	}
+	m := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	opts := ServerMigrateOptions{FromNamespace: "team-a", ToNamespace: "search", Selector: "tier=web", DeleteSource: true, Timeout: time.Minute}
+	if err := m.MigrateServers(opts); err != nil {
+		t.Fatalf("MigrateServers() error: %v", err)
+	}
+	if !commandHasArgs(mock.Commands[0], "-l", "tier=web") {
+		t.Fatalf("expected the selector to be passed, got %v", mock.Commands[0].Args)
+	}
+	if len(created) != 3 {
+		t.Fatalf("expected the secret and two servers to be created, got %d", len(created))
+	}
+	secretMeta := created[0]["metadata"].(map[string]any)
+	if created[0]["kind"] != "Secret" || secretMeta["namespace"] != "search" || secretMeta["uid"] != nil {
+		t.Fatalf("unexpected secret copy %v", created[0])
+	}
+	weather := created[1]
+	metadata := weather["metadata"].(map[string]any)
+	spec := weather["spec"].(map[string]any)
+	if metadata["namespace"] != "search" || metadata["resourceVersion"] != nil || weather["status"] != nil {
+		t.Fatalf("unexpected copy %v", weather)
+	}
+	if spec["ingressPath"] != "/search/weather/mcp" || created[2]["spec"].(map[string]any)["ingressPath"] != "/search/search/mcp" {
+		t.Fatalf("unexpected ingress paths %v %v", spec["ingressPath"], created[2]["spec"])
+	}
+	if strings.Join(deleted, ",") != "weather/team-a,search/team-a" {
+		t.Fatalf("expected the originals to be deleted, got %v", deleted)
+	}
+
+	if err := m.MigrateServers(ServerMigrateOptions{FromNamespace: "team-a", ToNamespace: "team-a"}); !errors.Is(err, ErrMigrateServersFailed) {
+		t.Fatalf("expected ErrMigrateServersFailed for the same namespace, got %v", err)
+	}
+}
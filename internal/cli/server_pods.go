@@ -0,0 +1,95 @@
+package cli
+
+// This file renders the "Pod Status" section of "server status": one row per server pod
+// with its placement (node, pod IP), restarts, age and the reason its container last
+// terminated, all read from a single "kubectl get pods -o json" call.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// printPodStatus prints the pods of MCP servers in namespace.
+func (m *ServerManager) printPodStatus(namespace string) {
+	// #nosec G204 -- namespace from CLI flag; fixed label selector.
+	podCmd, err := m.kubectl.CommandArgs([]string{"get", "pods", "-n", namespace, "-l", SelectorManagedBy, "-o", "json"})
+	if err != nil {
+		Warn("Failed to list pods: " + err.Error())
+		return
+	}
+	out, err := podCmd.Output()
+	if err != nil {
+		Warn("Failed to list pods: " + err.Error())
+		return
+	}
+	var pods corev1.PodList
+	if err := json.Unmarshal(out, &pods); err != nil {
+		Warn("Failed to parse pods: " + err.Error())
+		return
+	}
+	if len(pods.Items) == 0 {
+		Info("No pods found")
+		return
+	}
+	Table(podStatusRows(pods.Items, time.Now()))
+}
+
+// podStatusRows builds the pod table, header first.
+func podStatusRows(pods []corev1.Pod, now time.Time) [][]string {
+	rows := [][]string{{"NAME", "SERVER", "READY", "STATUS", "RESTARTS", "NODE", "IP", "AGE", "LAST TERMINATION"}}
+	for _, pod := range pods {
+		var ready int
+		var restarts int32
+		status := string(pod.Status.Phase)
+		lastTermination := "-"
+		var lastFinished time.Time
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+			restarts += cs.RestartCount
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+				status = cs.State.Waiting.Reason
+			}
+			if t := cs.LastTerminationState.Terminated; t != nil && !t.FinishedAt.Time.Before(lastFinished) {
+				lastFinished = t.FinishedAt.Time
+				lastTermination = fmt.Sprintf("%s (exit %d) %s ago", t.Reason, t.ExitCode, humanAge(t.FinishedAt.Time, now))
+			}
+		}
+		if pod.DeletionTimestamp != nil {
+			status = "Terminating"
+		}
+		rows = append(rows, []string{
+			pod.Name,
+			orDash(pod.Labels["app"]),
+			fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers)),
+			orDash(status),
+			strconv.Itoa(int(restarts)),
+			orDash(pod.Spec.NodeName),
+			orDash(pod.Status.PodIP),
+			humanAge(pod.CreationTimestamp.Time, now),
+			lastTermination,
+		})
+	}
+	return rows
+}
+
+// humanAge formats the time since t like kubectl's AGE column.
+func humanAge(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return duration.HumanDuration(now.Sub(t))
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodStatusRows(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "weather-abc",
+			Labels:            map[string]string{"app": "weather"},
+			CreationTimestamp: metav1.NewTime(now.Add(-3 * time.Hour)),
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "weather"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "10.0.0.7",
+			ContainerStatuses: []corev1.ContainerStatus{{
+				RestartCount: 4,
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason:     "OOMKilled",
+					ExitCode:   137,
+					FinishedAt: metav1.NewTime(now.Add(-5 * time.Minute)),
+				}},
+			}},
+		},
+	}
+
+	rows := podStatusRows([]corev1.Pod{pod}, now)
+	want := []string{"weather-abc", "weather", "0/1", "CrashLoopBackOff", "4", "node-1", "10.0.0.7", "3h", "OOMKilled (exit 137) 5m ago"}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Fatalf("row = %v, want %v", rows[1], want)
+	}
+
+	pending := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "weather-new"}, Status: corev1.PodStatus{Phase: corev1.PodPending}}
+	rows = podStatusRows([]corev1.Pod{pending}, now)
+	if got := strings.Join(rows[1], "|"); got != "weather-new|-|0/0|Pending|0|-|-|-|-" {
+		t.Fatalf("pending row = %s", got)
+	}
+}
+
+func TestPrintPodStatusQueriesOnce(t *testing.T) {
+	mock := &MockExecutor{DefaultOutput: []byte(`{"items":[{"metadata":{"name":"pod-1"},"spec":{"nodeName":"node-a"},"status":{"phase":"Running","podIP":"10.1.2.3"}}]}`)}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	mgr.printPodStatus("mcp-servers")
+	if len(mock.Commands) != 1 || !contains(mock.Commands[0].Args, "json") {
+		t.Fatalf("expected one get pods -o json call, got %v", mock.Commands)
+	}
+	for _, want := range []string{"NODE", "node-a", "10.1.2.3"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package cli
+
+// This file implements "server preview", short-lived MCPServers built from Git branches, in
+// the spirit of pull request preview environments. "preview create" applies a server named
+// <name>-<branch> carrying the mcpruntime.org/ttl annotation; the operator's TTL controller
+// deletes it once that lifetime, counted from the server's creation, has passed. Re-running
+// create for the same branch rolls the preview to the new image without resetting its TTL.
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// ServerPreviewOptions describes the preview server to create.
+type ServerPreviewOptions struct {
+	// Name is the base name of the server; empty uses the image's repository name.
+	Name   string
+	Branch string
+	// Image is the image reference, usually tagged with the commit, e.g. weather:3f2a91c.
+	Image string
+	// TTL is how long the preview lives after it is first created.
+	TTL time.Duration
+}
+
+// DefaultPreviewTTL is the lifetime of previews created without --ttl.
+const DefaultPreviewTTL = 72 * time.Hour
+
+func (m *ServerManager) newServerPreviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Manage short-lived MCP servers for Git branches",
+		Long: `Preview servers are MCPServers for a Git branch that delete themselves after a TTL,
+like pull request preview environments. The operator removes a preview once its
+` + AnnotationTTL + ` annotation, counted from the server's creation, has passed.`,
+	}
+	cmd.AddCommand(m.newServerPreviewCreateCmd())
+	cmd.AddCommand(m.newServerPreviewListCmd())
+	return cmd
+}
+
+func (m *ServerManager) newServerPreviewCreateCmd() *cobra.Command {
+	opts := ServerPreviewOptions{TTL: DefaultPreviewTTL}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create or update the preview server of a branch",
+		Long: `Apply an MCPServer named <name>-<branch> running --image, labeled with the branch and
+annotated with its TTL. Running the command again for the same branch updates the
+image of the existing preview; its lifetime still counts from the first creation.`,
+		Example: `  mcp-runtime server preview create --branch feature-x --image registry.local/weather:3f2a91c
+  mcp-runtime server preview create --branch fix/login --image weather:9bc01de --name weather --ttl 24h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.CreatePreviewServer(serverNamespace(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Branch, "branch", "", "Git branch the preview is built from")
+	cmd.Flags().StringVar(&opts.Image, "image", "", "Image to run, including its tag")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Base name of the server (defaults to the image's repository name)")
+	cmd.Flags().DurationVar(&opts.TTL, "ttl", DefaultPreviewTTL, "How long the preview lives before the operator deletes it")
+	_ = cmd.MarkFlagRequired("branch")
+	_ = cmd.MarkFlagRequired("image")
+
+	return cmd
+}
+
+func (m *ServerManager) newServerPreviewListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List preview servers and their remaining lifetime",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ListPreviewServers(serverNamespace(), time.Now())
+		},
+	}
+}
+
+// CreatePreviewServer applies the preview server of opts.Branch in namespace.
+func (m *ServerManager) CreatePreviewServer(namespace string, opts ServerPreviewOptions) error {
+	if opts.Image == "" {
+		return ErrImageRequired
+	}
+	image, err := validateManifestValue("image", opts.Image)
+	if err != nil {
+		return err
+	}
+	branch := previewBranchSlug(opts.Branch)
+	if branch == "" {
+		return newWithSentinel(ErrPreviewServerFailed, fmt.Sprintf("branch %q has no letters or digits to name the preview after", opts.Branch))
+	}
+	if opts.TTL <= 0 {
+		return newWithSentinel(ErrPreviewServerFailed, "--ttl must be positive")
+	}
+	repo, tag := splitImage(image)
+	base := opts.Name
+	if base == "" {
+		base = previewBranchSlug(repo[strings.LastIndex(repo, "/")+1:])
+	}
+	name, namespace, err := validateServerInput(previewServerName(base, branch), namespace)
+	if err != nil {
+		return err
+	}
+
+	spec := map[string]any{
+		"image":       repo,
+		"replicas":    1,
+		"port":        GetDefaultServerPort(),
+		"servicePort": 80,
+	}
+	if tag != "" {
+		spec["imageTag"] = tag
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"apiVersion": "mcpruntime.org/v1alpha1",
+		"kind":       "MCPServer",
+		"metadata": map[string]any{
+			"name":        name,
+			"namespace":   namespace,
+			"labels":      map[string]string{LabelPreviewBranch: branch},
+			"annotations": map[string]string{AnnotationTTL: opts.TTL.String()},
+		},
+		"spec": spec,
+	})
+	if err != nil {
+		return m.previewError(err, name, namespace, "Failed to build preview manifest")
+	}
+
+	m.logger.Info("Applying preview server", zap.String("name", name), zap.String("namespace", namespace), zap.String("branch", opts.Branch), zap.String("image", image))
+	verdict, err := m.applyDocument(applyDocument{Kind: "MCPServer", Name: name, Namespace: namespace, Data: manifest})
+	if err != nil {
+		return m.previewError(err, name, namespace, "Failed to apply preview server")
+	}
+	Success(fmt.Sprintf("Preview %s/%s %s for branch %s (TTL %s)", namespace, name, verdict, opts.Branch, duration.HumanDuration(opts.TTL)))
+	return nil
+}
+
+// ListPreviewServers prints the preview servers in namespace with the time left until the
+// operator deletes them.
+func (m *ServerManager) ListPreviewServers(namespace string, now time.Time) error {
+	namespace, err := validateManifestValue("namespace", namespace)
+	if err != nil {
+		return err
+	}
+	// #nosec G204 -- namespace validated above.
+	out, err := m.kubectl.Output([]string{"get", "mcpservers", "-n", namespace, "-l", LabelPreviewBranch, "-o", "json"})
+	if err != nil {
+		return m.previewError(err, "", namespace, "Failed to list preview servers")
+	}
+	var list struct {
+		Items []previewServer `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return m.previewError(fmt.Errorf("parse mcpserver list: %w", err), "", namespace, "Failed to list preview servers")
+	}
+	if len(list.Items) == 0 {
+		Info(fmt.Sprintf("No preview servers in namespace %s", namespace))
+		return nil
+	}
+
+	rows := [][]string{{"NAME", "BRANCH", "IMAGE", "PHASE", "AGE", "EXPIRES IN"}}
+	for _, server := range list.Items {
+		image := server.Spec.Image
+		if server.Spec.ImageTag != "" {
+			image += ":" + server.Spec.ImageTag
+		}
+		rows = append(rows, []string{
+			server.Metadata.Name,
+			orDash(server.Metadata.Labels[LabelPreviewBranch]),
+			orDash(image),
+			orDash(server.Status.Phase),
+			humanAge(server.Metadata.CreationTimestamp, now),
+			previewRemaining(server, now),
+		})
+	}
+	Table(rows)
+	return nil
+}
+
+// previewServer holds the fields of a live preview MCPServer that "preview list" shows.
+type previewServer struct {
+	Metadata struct {
+		Name              string            `json:"name"`
+		Labels            map[string]string `json:"labels"`
+		Annotations       map[string]string `json:"annotations"`
+		CreationTimestamp time.Time         `json:"creationTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		Image    string `json:"image"`
+		ImageTag string `json:"imageTag"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// previewRemaining formats the time left before the operator deletes server.
+func previewRemaining(server previewServer, now time.Time) string {
+	value, ok := server.Metadata.Annotations[AnnotationTTL]
+	if !ok {
+		return "never"
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return "invalid TTL " + value
+	}
+	remaining := server.Metadata.CreationTimestamp.Add(ttl).Sub(now)
+	if remaining <= 0 {
+		return "expired"
+	}
+	return duration.HumanDuration(remaining)
+}
+
+var previewSlugInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// previewBranchSlug turns a branch name such as feature/Login-UI into a name segment and
+// label value (feature-login-ui).
+func previewBranchSlug(branch string) string {
+	slug := strings.Trim(previewSlugInvalid.ReplaceAllString(strings.ToLower(branch), "-"), "-")
+	if len(slug) > 40 {
+		slug = strings.TrimRight(slug[:40], "-")
+	}
+	return slug
+}
+
+// previewServerName joins base and the branch slug, keeping the result a valid name.
+func previewServerName(base, branch string) string {
+	name := base + "-" + branch
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
+
+func (m *ServerManager) previewError(err error, name, namespace, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrPreviewServerFailed,
+		err,
+		fmt.Sprintf("preview server %q in namespace %q: %v", name, namespace, err),
+		map[string]any{"server": name, "namespace": namespace, "component": "server"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
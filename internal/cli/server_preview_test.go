@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestPreviewNames(t *testing.T) {
+	tests := map[string]string{
+		"feature-x":        "feature-x",
+		"fix/Login_UI":     "fix-login-ui",
+		"--release/v1.2--": "release-v1-2",
+		"///":              "",
+	}
+	for branch, want := range tests {
+		if got := previewBranchSlug(branch); got != want {
+			t.Errorf("previewBranchSlug(%q) = %q, want %q", branch, got, want)
+		}
+	}
+	if got := previewServerName(strings.Repeat("a", 40), strings.Repeat("b", 22)+"-c"); len(got) > 63 || strings.HasSuffix(got, "-") {
+		t.Errorf("previewServerName() = %q, want at most 63 characters without a trailing hyphen", got)
+	}
+}
+
+func TestCreatePreviewServer(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	var apply *MockCommand
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		apply = &MockCommand{Args: spec.Args, OutputData: []byte("mcpserver.mcpruntime.org/weather-fix-login created")}
+		return apply
+	}
+	m := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	opts := ServerPreviewOptions{Branch: "fix/login", Image: "registry.local/team/weather:3f2a91c", TTL: 24 * time.Hour}
+	if err := m.CreatePreviewServer("previews", opts); err != nil {
+		t.Fatalf("CreatePreviewServer() error: %v", err)
+	}
+	if len(mock.Commands) != 1 || !commandHasArgs(mock.Commands[0], "apply", "-f", "-") {
+		t.Fatalf("expected one kubectl apply, got %v", mock.Commands)
+	}
+	data, _ := io.ReadAll(apply.StdinR)
+	var server struct {
+		Metadata struct {
+			Name        string            `json:"name"`
+			Namespace   string            `json:"namespace"`
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec map[string]any `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &server); err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	if server.Metadata.Name != "weather-fix-login" || server.Metadata.Namespace != "previews" {
+		t.Fatalf("unexpected server %s/%s", server.Metadata.Namespace, server.Metadata.Name)
+	}
+	if server.Metadata.Labels[LabelPreviewBranch] != "fix-login" || server.Metadata.Annotations[AnnotationTTL] != "24h0m0s" {
+		t.Fatalf("unexpected labels %v and annotations %v", server.Metadata.Labels, server.Metadata.Annotations)
+	}
+	if server.Spec["image"] != "registry.local/team/weather" || server.Spec["imageTag"] != "3f2a91c" {
+		t.Fatalf("unexpected image in spec %v", server.Spec)
+	}
+
+	if err := m.CreatePreviewServer("previews", ServerPreviewOptions{Branch: "main", Image: "weather:1", TTL: -time.Hour}); !errors.Is(err, ErrPreviewServerFailed) {
+		t.Fatalf("expected ErrPreviewServerFailed for a negative TTL, got %v", err)
+	}
+	if err := m.CreatePreviewServer("previews", ServerPreviewOptions{Branch: "main"}); !errors.Is(err, ErrImageRequired) {
+		t.Fatalf("expected ErrImageRequired, got %v", err)
+	}
+}
+
+func TestListPreviewServers(t *testing.T) {
+	var out bytes.Buffer
+	setDefaultPrinterWriter(t, &out)
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		return &MockCommand{Args: spec.Args, OutputData: []byte(`{"items": [
+  {"metadata": {"name": "weather-feature-x", "creationTimestamp": "2026-01-01T12:00:00Z",
+    "labels": {"mcpruntime.org/preview-branch": "feature-x"}, "annotations": {"mcpruntime.org/ttl": "72h"}},
+   "spec": {"image": "weather", "imageTag": "3f2a91c"}, "status": {"phase": "Ready"}},
+  {"metadata": {"name": "weather-old", "creationTimestamp": "2025-12-20T12:00:00Z",
+    "labels": {"mcpruntime.org/preview-branch": "old"}, "annotations": {"mcpruntime.org/ttl": "24h"}},
+   "spec": {"image": "weather"}}
+]}`)}
+	}
+	m := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	if err := m.ListPreviewServers("previews", now); err != nil {
+		t.Fatalf("ListPreviewServers() error: %v", err)
+	}
+	if !commandHasArgs(mock.Commands[0], "-l", LabelPreviewBranch) {
+		t.Fatalf("expected the preview label selector, got %v", mock.Commands[0].Args)
+	}
+	output := out.String()
+	for _, want := range []string{"weather-feature-x", "weather:3f2a91c", "2d", "expired"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+// This file implements delete protection for MCPServers annotated mcpruntime.org/protected="true".
+// The CLI refuses to delete them without --force, and a ValidatingAdmissionPolicy applied during
+// setup rejects deletes made with kubectl or any other client.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// deleteProtectionPolicyManifest is the ValidatingAdmissionPolicy that blocks deleting protected servers.
+const deleteProtectionPolicyManifest = "config/admission/delete-protection.yaml"
+
+// checkDeleteProtection refuses to delete a protected server unless force is set, in which case
+// the annotation is removed first so the admission policy lets the delete through.
+func (m *ServerManager) checkDeleteProtection(name, namespace string, force bool) error {
+	if !m.isServerProtected(name, namespace) {
+		return nil
+	}
+
+	if !force {
+		err := newWithSentinel(ErrServerProtected, fmt.Sprintf("server %q in namespace %q is protected (%s=true); use --force to delete it", name, namespace, AnnotationProtected))
+		Error("Server is protected against deletion")
+		logStructuredError(m.logger, err, "Server is protected against deletion")
+		return err
+	}
+
+	Warn(fmt.Sprintf("Removing delete protection from %s/%s", namespace, name))
+	// #nosec G204 -- name/namespace validated via validateServerInput; annotation key is a constant.
+	if err := m.kubectl.RunWithOutput([]string{"annotate", "mcpserver", name, "-n", namespace, AnnotationProtected + "-"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrUnprotectServerFailed,
+			err,
+			fmt.Sprintf("failed to remove %s from server %q: %v", AnnotationProtected, name, err),
+			map[string]any{"server": name, "namespace": namespace, "component": "server"},
+		)
+		Error("Failed to remove server protection")
+		logStructuredError(m.logger, wrappedErr, "Failed to remove server protection")
+		return wrappedErr
+	}
+	return nil
+}
+
+// isServerProtected reports whether the server carries the protected annotation. Lookup failures
+// are treated as unprotected so the delete itself reports a missing server.
+func (m *ServerManager) isServerProtected(name, namespace string) bool {
+	jsonPath := "jsonpath={.metadata.annotations." + strings.ReplaceAll(AnnotationProtected, ".", `\.`) + "}"
+	// #nosec G204 -- name/namespace validated via validateServerInput.
+	out, err := m.kubectl.Output([]string{"get", "mcpserver", name, "-n", namespace, "-o", jsonPath})
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "true"
+}
+
+// admissionPolicyAPI is the group version serving ValidatingAdmissionPolicy from Kubernetes 1.30.
+const admissionPolicyAPI = "admissionregistration.k8s.io/v1"
+
+// admissionPoliciesServed reports whether the API server serves ValidatingAdmissionPolicy
+// at admissionPolicyAPI.
+func admissionPoliciesServed(kubectl KubectlRunner) (bool, error) {
+	out, err := kubectlOutput(kubectl, []string{"get", "--raw", "/apis/" + admissionPolicyAPI})
+	if err != nil {
+		return false, err
+	}
+	var list struct {
+		Resources []struct {
+			Name string `json:"name"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return false, fmt.Errorf("parse %s resources: %w", admissionPolicyAPI, err)
+	}
+	for _, resource := range list.Resources {
+		if resource.Name == "validatingadmissionpolicies" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applyDeleteProtectionPolicy installs the admission policy that blocks deleting protected servers.
+// Clusters without ValidatingAdmissionPolicy (before Kubernetes 1.30) only get the CLI check.
+func applyDeleteProtectionPolicy(kubectl KubectlRunner, logger *zap.Logger) {
+	served, err := admissionPoliciesServed(kubectl)
+	if !served {
+		if err == nil {
+			err = fmt.Errorf("%s does not serve ValidatingAdmissionPolicy", admissionPolicyAPI)
+		}
+		Warn(fmt.Sprintf("Delete protection policy skipped (needs Kubernetes 1.30+): %v", err))
+		if logger != nil {
+			logger.Warn("Delete protection policy skipped", zap.Error(err))
+		}
+		return
+	}
+	Info("Applying MCPServer delete protection policy")
+	// #nosec G204 -- fixed file path from repository.
+	if err := kubectl.Run([]string{"apply", "-f", deleteProtectionPolicyManifest}); err != nil {
+		Warn(fmt.Sprintf("Delete protection policy not applied (needs Kubernetes 1.30+): %v", err))
+		if logger != nil {
+			logger.Warn("Delete protection policy not applied", zap.Error(err))
+		}
+	}
+}
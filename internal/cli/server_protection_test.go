@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func protectedServerExecutor(annotation string) *MockExecutor {
+	return &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			if contains(spec.Args, "get") && strings.Contains(strings.Join(spec.Args, " "), "annotations") {
+				return &MockCommand{Args: spec.Args, OutputData: []byte(annotation)}
+			}
+			return &MockCommand{Args: spec.Args}
+		},
+	}
+}
+
+func TestServerManager_DeleteProtectedServer(t *testing.T) {
+	t.Run("refuses without force", func(t *testing.T) {
+		mock := protectedServerExecutor("true")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		err := mgr.DeleteServer("prod-server", "test-ns", false)
+		if !errors.Is(err, ErrServerProtected) {
+			t.Fatalf("expected ErrServerProtected, got %v", err)
+		}
+		for _, cmd := range mock.Commands {
+			if contains(cmd.Args, "delete") {
+				t.Fatalf("protected server must not be deleted, got %v", cmd.Args)
+			}
+		}
+	})
+
+	t.Run("removes the annotation and deletes with force", func(t *testing.T) {
+		mock := protectedServerExecutor("true")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.DeleteServer("prod-server", "test-ns", true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var verbs []string
+		for _, cmd := range mock.Commands {
+			verbs = append(verbs, cmd.Args[0])
+		}
+		if got := strings.Join(verbs, ","); got != "get,annotate,delete" {
+			t.Fatalf("unexpected command order %s", got)
+		}
+		if !contains(mock.Commands[1].Args, AnnotationProtected+"-") {
+			t.Fatalf("expected annotation removal, got %v", mock.Commands[1].Args)
+		}
+	})
+
+	t.Run("deletes unprotected server", func(t *testing.T) {
+		mock := protectedServerExecutor("false")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+
+		if err := mgr.DeleteServer("dev-server", "test-ns", false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !contains(mock.LastCommand().Args, "delete") {
+			t.Fatalf("expected delete, got %v", mock.LastCommand().Args)
+		}
+	})
+}
+
+func TestApplyDeleteProtectionPolicy(t *testing.T) {
+	t.Run("applies the policy when the API is served", func(t *testing.T) {
+		mock := &MockExecutor{
+			DefaultOutput: []byte(`{"resources":[{"name":"validatingadmissionpolicies"}]}`),
+			DefaultRunErr: errors.New("connection refused"),
+		}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		applyDeleteProtectionPolicy(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+
+		if !contains(mock.LastCommand().Args, deleteProtectionPolicyManifest) {
+			t.Fatalf("expected policy manifest to be applied, got %v", mock.LastCommand().Args)
+		}
+		if !strings.Contains(buf.String(), "Delete protection policy not applied") {
+			t.Fatalf("expected warning, got %q", buf.String())
+		}
+	})
+
+	t.Run("skips the policy before Kubernetes 1.30", func(t *testing.T) {
+		mock := &MockExecutor{DefaultOutput: []byte(`{"resources":[{"name":"validatingwebhookconfigurations"}]}`)}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		applyDeleteProtectionPolicy(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+
+		for _, cmd := range mock.Commands {
+			if contains(cmd.Args, deleteProtectionPolicyManifest) {
+				t.Fatalf("expected no policy apply, got %v", cmd.Args)
+			}
+		}
+		if !strings.Contains(buf.String(), "Delete protection policy skipped") {
+			t.Fatalf("expected warning, got %q", buf.String())
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package cli
+
+// This file implements "server rollback". The operator records every image that became
+// ready and fully rolled out in the MCPServer's status.history; rollback patches
+// spec.image/imageTag back to one of those revisions and waits until the operator reports
+// the server Ready and rolled out again.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// rollbackPollInterval is how often rollback checks readiness; a test seam.
+var rollbackPollInterval = 2 * time.Second
+
+// serverRevision is one entry of an MCPServer's status.history.
+type serverRevision struct {
+	Revision int64  `json:"revision"`
+	Image    string `json:"image"`
+	ImageTag string `json:"imageTag"`
+}
+
+// imageRef joins image and tag the way the operator does.
+func (r serverRevision) imageRef() string {
+	if r.ImageTag == "" {
+		return r.Image
+	}
+	return r.Image + ":" + r.ImageTag
+}
+
+// serverRevisionState is the part of an MCPServer that rollback reads.
+type serverRevisionState struct {
+	Spec struct {
+		Image    string `json:"image"`
+		ImageTag string `json:"imageTag"`
+	} `json:"spec"`
+	Status struct {
+		History []serverRevision `json:"history"`
+	} `json:"status"`
+}
+
+func (m *ServerManager) newServerRollbackCmd() *cobra.Command {
+	var toRevision int64
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "rollback [name]",
+		Short: "Roll an MCP server back to an earlier image",
+		Long: `Restore the image and tag of an earlier revision and wait for the server to become Ready
+with every pod running it. Revisions are the images the server previously ran fully rolled out
+and Ready, as recorded by the operator in status.history. Without --to-revision the most recent image other than the current one is used.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.RollbackServer(args[0], serverNamespace(), toRevision, timeout)
+		},
+	}
+
+	cmd.Flags().Int64Var(&toRevision, "to-revision", 0, "Revision to restore (default: the previous image)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the server to become Ready")
+
+	return cmd
+}
+
+// RollbackServer restores the image of revision toRevision (0 for the previous image) and
+// waits up to timeout for the server to become Ready.
+func (m *ServerManager) RollbackServer(name, namespace string, toRevision int64, timeout time.Duration) error {
+	name, namespace, err := validateServerInput(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	state, err := m.serverRevisionState(name, namespace)
+	if err != nil {
+		return m.rollbackError(ErrRollbackServerFailed, err, name, namespace, "Failed to roll back server")
+	}
+	current := serverRevision{Image: state.Spec.Image, ImageTag: state.Spec.ImageTag}
+	target, err := rollbackTarget(state.Status.History, current, toRevision)
+	if err != nil {
+		return m.rollbackError(ErrRevisionNotFound, err, name, namespace, "Revision not found")
+	}
+	if target.imageRef() == current.imageRef() {
+		Warn(fmt.Sprintf("Server %s already runs revision %d (%s)", name, target.Revision, target.imageRef()))
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"image": target.Image, "imageTag": target.ImageTag},
+	})
+	if err != nil {
+		return m.rollbackError(ErrRollbackServerFailed, err, name, namespace, "Failed to roll back server")
+	}
+	m.logger.Info("Rolling back MCP server", zap.String("name", name), zap.Int64("revision", target.Revision), zap.String("image", target.imageRef()))
+	Info(fmt.Sprintf("Rolling back %s from %s to revision %d (%s)", name, current.imageRef(), target.Revision, target.imageRef()))
+	// #nosec G204 -- name/namespace validated via validateServerInput; patch is generated JSON.
+	if err := m.kubectl.RunWithOutput([]string{"patch", "mcpserver", name, "-n", namespace, "--type=merge", "-p", string(patch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return m.rollbackError(ErrRollbackServerFailed, err, name, namespace, "Failed to roll back server")
+	}
+
+	if err := m.waitForRollout(name, namespace, time.Now().Add(timeout)); err != nil {
+		return m.rollbackError(ErrServerNotReady, err, name, namespace, "Server did not become ready")
+	}
+	Success(fmt.Sprintf("Rolled back %s to revision %d (%s)", name, target.Revision, target.imageRef()))
+	return nil
+}
+
+// serverRevisionState reads the server's current image and revision history.
+func (m *ServerManager) serverRevisionState(name, namespace string) (serverRevisionState, error) {
+	var state serverRevisionState
+	// #nosec G204 -- name/namespace validated via validateServerInput.
+	out, err := m.kubectl.Output([]string{"get", "mcpserver", name, "-n", namespace, "-o", "json"})
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(out, &state); err != nil {
+		return state, fmt.Errorf("parse mcpserver: %w", err)
+	}
+	return state, nil
+}
+
+// rollbackTarget picks the revision to restore: toRevision when set, otherwise the
+// most recent revision whose image differs from current.
+func rollbackTarget(history []serverRevision, current serverRevision, toRevision int64) (serverRevision, error) {
+	if len(history) == 0 {
+		return serverRevision{}, fmt.Errorf("no revision history recorded yet")
+	}
+	if toRevision > 0 {
+		available := make([]string, 0, len(history))
+		for _, rev := range history {
+			if rev.Revision == toRevision {
+				return rev, nil
+			}
+			available = append(available, fmt.Sprint(rev.Revision))
+		}
+		return serverRevision{}, fmt.Errorf("revision %d not in history (available: %s)", toRevision, strings.Join(available, ", "))
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].imageRef() != current.imageRef() {
+			return history[i], nil
+		}
+	}
+	return serverRevision{}, fmt.Errorf("no earlier revision than %s", current.imageRef())
+}
+
+// waitForRollout polls until the operator has observed the latest spec, reports Ready and
+// the Deployment has fully rolled out. Ready alone can still be served by the old pods.
+func (m *ServerManager) waitForRollout(name, namespace string, deadline time.Time) error {
+	var phase, message string
+	for {
+		// #nosec G204 -- name/namespace validated via validateServerInput.
+		out, err := m.kubectl.Output([]string{"get", "mcpserver", name, "-n", namespace, "-o",
+			`jsonpath={.metadata.generation}{"\t"}{.status.observedGeneration}{"\t"}{.status.rolloutComplete}{"\t"}{.status.phase}{"\t"}{.status.message}`})
+		if err == nil {
+			fields := strings.SplitN(string(out), "\t", 5)
+			for len(fields) < 5 {
+				fields = append(fields, "")
+			}
+			rolledOut := fields[2] == "true"
+			phase, message = fields[3], fields[4]
+			if fields[0] == fields[1] && phase == "Ready" {
+				if rolledOut {
+					return nil
+				}
+				message = "rollout incomplete, old pods are still serving"
+			}
+		}
+		if time.Now().After(deadline) {
+			if phase == "" {
+				phase = "unknown"
+			}
+			return fmt.Errorf("timed out in phase %s: %s", phase, message)
+		}
+		time.Sleep(rollbackPollInterval)
+	}
+}
+
+func (m *ServerManager) rollbackError(base, err error, name, namespace, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		base,
+		err,
+		fmt.Sprintf("%s %q in namespace %q: %v", strings.ToLower(msg), name, namespace, err),
+		map[string]any{"server": name, "namespace": namespace, "component": "server"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const rollbackTestServer = `{
+  "spec": {"image": "registry.local/weather", "imageTag": "v3"},
+  "status": {"history": [
+    {"revision": 1, "image": "registry.local/weather", "imageTag": "v1"},
+    {"revision": 2, "image": "registry.local/weather", "imageTag": "v2"},
+    {"revision": 3, "image": "registry.local/weather", "imageTag": "v3"}
+  ]}
+}`
+
+func rollbackTestExecutor(rollout string) *MockExecutor {
+	return &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			switch {
+			case contains(spec.Args, "json"):
+				return &MockCommand{Args: spec.Args, OutputData: []byte(rollbackTestServer)}
+			case contains(spec.Args, "get"):
+				return &MockCommand{Args: spec.Args, OutputData: []byte(rollout)}
+			}
+			return &MockCommand{Args: spec.Args}
+		},
+	}
+}
+
+// rollbackPatch returns the payload of the patch command run by the mock.
+func rollbackPatch(t *testing.T, mock *MockExecutor) string {
+	t.Helper()
+	for _, cmd := range mock.Commands {
+		if contains(cmd.Args, "patch") {
+			return cmd.Args[len(cmd.Args)-1]
+		}
+	}
+	t.Fatal("expected a patch command")
+	return ""
+}
+
+func TestServerManager_RollbackServer(t *testing.T) {
+	prev := rollbackPollInterval
+	rollbackPollInterval = 0
+	t.Cleanup(func() { rollbackPollInterval = prev })
+
+	t.Run("restores the previous image and waits for ready", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		mock := rollbackTestExecutor("4\t4\ttrue\tReady\tAll resources reconciled")
+		mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+		if err := mgr.RollbackServer("weather", NamespaceMCPServers, 0, time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payload := rollbackPatch(t, mock); !strings.Contains(payload, `"imageTag":"v2"`) {
+			t.Fatalf("unexpected patch %s", payload)
+		}
+		if !strings.Contains(buf.String(), "Rolled back weather to revision 2") {
+			t.Fatalf("unexpected output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("restores an explicit revision", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		mock := rollbackTestExecutor("4\t4\ttrue\tReady\t")
+		mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+		if err := mgr.RollbackServer("weather", NamespaceMCPServers, 1, time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if payload := rollbackPatch(t, mock); !strings.Contains(payload, `"imageTag":"v1"`) {
+			t.Fatalf("unexpected patch %s", payload)
+		}
+	})
+
+	t.Run("fails for an unknown revision", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		mgr := NewServerManager(&KubectlClient{exec: rollbackTestExecutor("")}, zap.NewNop())
+
+		err := mgr.RollbackServer("weather", NamespaceMCPServers, 9, time.Minute)
+		if !errors.Is(err, ErrRevisionNotFound) {
+			t.Fatalf("expected ErrRevisionNotFound, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "available: 1, 2, 3") {
+			t.Fatalf("expected available revisions in error, got %v", err)
+		}
+	})
+
+	t.Run("times out when the server does not become ready", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		mgr := NewServerManager(&KubectlClient{exec: rollbackTestExecutor("4\t4\tfalse\tPending\tWaiting for Deployment")}, zap.NewNop())
+
+		err := mgr.RollbackServer("weather", NamespaceMCPServers, 0, 0)
+		if !errors.Is(err, ErrServerNotReady) {
+			t.Fatalf("expected ErrServerNotReady, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "timed out in phase Pending") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("waits for the rollout to complete", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		mgr := NewServerManager(&KubectlClient{exec: rollbackTestExecutor("4\t4\tfalse\tReady\tAll resources reconciled")}, zap.NewNop())
+
+		err := mgr.RollbackServer("weather", NamespaceMCPServers, 0, 0)
+		if !errors.Is(err, ErrServerNotReady) {
+			t.Fatalf("expected ErrServerNotReady, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "rollout incomplete") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package cli
+
+// This file implements "server scaffold", which writes a GitOps-ready kustomize layout
+// for one MCP server: a base holding the MCPServer manifest and dev/stage/prod overlays
+// whose patches show how to override replicas, env vars and the ingress host.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// scaffoldOverlay holds the example values patched in by one environment overlay.
+type scaffoldOverlay struct {
+	Name     string
+	Replicas int
+	LogLevel string
+}
+
+// scaffoldOverlays are the environments generated by "server scaffold".
+var scaffoldOverlays = []scaffoldOverlay{
+	{Name: "dev", Replicas: 1, LogLevel: "debug"},
+	{Name: "stage", Replicas: 2, LogLevel: "info"},
+	{Name: "prod", Replicas: 3, LogLevel: "warn"},
+}
+
+const scaffoldBaseKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - mcpserver.yaml
+`
+
+const scaffoldOverlayKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - ../../base
+patches:
+  - path: mcpserver-patch.yaml
+`
+
+const scaffoldOverlayPatch = `# %[1]s overrides for the %[2]s MCPServer.
+# MCPServer is a custom resource, so lists such as envVars replace the base list
+# instead of being merged into it.
+apiVersion: mcpruntime.org/v1alpha1
+kind: MCPServer
+metadata:
+  name: %[2]s
+  namespace: %[3]s
+spec:
+  replicas: %[4]d
+  ingressHost: %[2]s.%[1]s.example.com
+  envVars:
+    - name: LOG_LEVEL
+      value: %[5]s
+`
+
+func (m *ServerManager) newServerScaffoldCmd() *cobra.Command {
+	var image string
+	var imageTag string
+	var output string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "scaffold [name]",
+		Short: "Generate kustomize base and overlays for an MCP server",
+		Long: `Generate a kustomize directory for an MCP server: base/ holds the MCPServer manifest and
+overlays/dev, overlays/stage and overlays/prod patch replicas, env vars and the ingress host.
+Apply an environment with 'kubectl apply -k <output>/overlays/<env>'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				output = filepath.Join("deploy", args[0])
+			}
+			return m.ScaffoldServer(args[0], serverNamespace(), image, imageTag, output, force)
+		},
+	}
+
+	cmd.Flags().StringVar(&image, "image", "", "Container image (defaults to the server name)")
+	cmd.Flags().StringVar(&imageTag, "tag", "latest", "Image tag")
+	cmd.Flags().StringVar(&output, "output", "", "Output directory (defaults to deploy/<name>)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing files")
+
+	return cmd
+}
+
+// ScaffoldServer writes the kustomize base and overlays for server name into output.
+// Existing files are left untouched unless force is set.
+func (m *ServerManager) ScaffoldServer(name, namespace, image, imageTag, output string, force bool) error {
+	name, err := validateManifestValue("name", name)
+	if err != nil {
+		return err
+	}
+	if namespace, err = validateManifestValue("namespace", namespace); err != nil {
+		return err
+	}
+	if image == "" {
+		image = name
+	}
+	if image, err = validateManifestValue("image", image); err != nil {
+		return err
+	}
+	if imageTag, err = validateManifestValue("tag", imageTag); err != nil {
+		return err
+	}
+	if output, err = validateManifestValue("output", output); err != nil {
+		return err
+	}
+
+	files, err := scaffoldFiles(name, namespace, image, imageTag)
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrMarshalManifestFailed,
+			err,
+			fmt.Sprintf("failed to marshal manifest: %v", err),
+			map[string]any{"server": name, "namespace": namespace, "component": "server"},
+		)
+		Error("Failed to marshal manifest")
+		logStructuredError(m.logger, wrappedErr, "Failed to marshal manifest")
+		return wrappedErr
+	}
+
+	if !force {
+		for _, rel := range scaffoldFileOrder {
+			path := filepath.Join(output, rel)
+			if _, err := os.Stat(path); err == nil {
+				wrappedErr := newWithSentinel(ErrScaffoldFileExists, fmt.Sprintf("%s already exists; use --force to overwrite", path))
+				Error("Scaffold file already exists")
+				logStructuredError(m.logger, wrappedErr, "Scaffold file already exists")
+				return wrappedErr
+			}
+		}
+	}
+
+	m.logger.Info("Scaffolding MCP server", zap.String("name", name), zap.String("output", output))
+	for _, rel := range scaffoldFileOrder {
+		path := filepath.Join(output, rel)
+		if err := writeScaffoldFile(path, files[rel]); err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrWriteManifestFailed,
+				err,
+				fmt.Sprintf("failed to write %s: %v", path, err),
+				map[string]any{"server": name, "path": path, "component": "server"},
+			)
+			Error("Failed to write manifest")
+			logStructuredError(m.logger, wrappedErr, "Failed to write manifest")
+			return wrappedErr
+		}
+	}
+
+	Success(fmt.Sprintf("Scaffolded %s in %s", name, output))
+	for _, overlay := range scaffoldOverlays {
+		Info(fmt.Sprintf("Deploy %s with: kubectl apply -k %s", overlay.Name, filepath.Join(output, "overlays", overlay.Name)))
+	}
+	return nil
+}
+
+// scaffoldFileOrder lists the generated files relative to the output directory.
+var scaffoldFileOrder = func() []string {
+	files := []string{filepath.Join("base", "kustomization.yaml"), filepath.Join("base", "mcpserver.yaml")}
+	for _, overlay := range scaffoldOverlays {
+		files = append(files,
+			filepath.Join("overlays", overlay.Name, "kustomization.yaml"),
+			filepath.Join("overlays", overlay.Name, "mcpserver-patch.yaml"),
+		)
+	}
+	return files
+}()
+
+// scaffoldFiles renders the contents of every scaffold file keyed by relative path.
+func scaffoldFiles(name, namespace, image, imageTag string) (map[string][]byte, error) {
+	manifest := mcpServerManifest{
+		APIVersion: "mcpruntime.org/v1alpha1",
+		Kind:       "MCPServer",
+		Metadata: manifestMetadata{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: manifestSpec{
+			Image:       image,
+			ImageTag:    imageTag,
+			Replicas:    1,
+			Port:        GetDefaultServerPort(),
+			ServicePort: 80,
+			IngressPath: "/" + name,
+		},
+	}
+	manifestBytes, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		filepath.Join("base", "kustomization.yaml"): []byte(scaffoldBaseKustomization),
+		filepath.Join("base", "mcpserver.yaml"):     manifestBytes,
+	}
+	for _, overlay := range scaffoldOverlays {
+		dir := filepath.Join("overlays", overlay.Name)
+		files[filepath.Join(dir, "kustomization.yaml")] = []byte(scaffoldOverlayKustomization)
+		files[filepath.Join(dir, "mcpserver-patch.yaml")] = []byte(fmt.Sprintf(scaffoldOverlayPatch, overlay.Name, name, namespace, overlay.Replicas, overlay.LogLevel))
+	}
+	return files, nil
+}
+
+func writeScaffoldFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
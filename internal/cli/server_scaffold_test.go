@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestScaffoldServer(t *testing.T) {
+	t.Run("writes base and overlays", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		out := filepath.Join(t.TempDir(), "deploy", "weather")
+
+		mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+		if err := mgr.ScaffoldServer("weather", NamespaceMCPServers, "", "v1", out, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, rel := range scaffoldFileOrder {
+			if _, err := os.Stat(filepath.Join(out, rel)); err != nil {
+				t.Fatalf("expected %s: %v", rel, err)
+			}
+		}
+
+		base, err := os.ReadFile(filepath.Join(out, "base", "mcpserver.yaml"))
+		if err != nil {
+			t.Fatalf("read base: %v", err)
+		}
+		for _, want := range []string{"name: weather", "image: weather", "imageTag: v1"} {
+			if !strings.Contains(string(base), want) {
+				t.Fatalf("expected %q in base manifest:\n%s", want, base)
+			}
+		}
+
+		patch, err := os.ReadFile(filepath.Join(out, "overlays", "prod", "mcpserver-patch.yaml"))
+		if err != nil {
+			t.Fatalf("read prod patch: %v", err)
+		}
+		for _, want := range []string{"replicas: 3", "ingressHost: weather.prod.example.com", "value: warn"} {
+			if !strings.Contains(string(patch), want) {
+				t.Fatalf("expected %q in prod patch:\n%s", want, patch)
+			}
+		}
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+		out := t.TempDir()
+		basePath := filepath.Join(out, "base", "mcpserver.yaml")
+		if err := os.MkdirAll(filepath.Dir(basePath), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(basePath, []byte("custom"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+		if err := mgr.ScaffoldServer("weather", NamespaceMCPServers, "", "latest", out, false); !errors.Is(err, ErrScaffoldFileExists) {
+			t.Fatalf("expected ErrScaffoldFileExists, got %v", err)
+		}
+		if data, _ := os.ReadFile(basePath); string(data) != "custom" {
+			t.Fatalf("existing file was modified: %s", data)
+		}
+
+		if err := mgr.ScaffoldServer("weather", NamespaceMCPServers, "", "latest", out, true); err != nil {
+			t.Fatalf("unexpected error with force: %v", err)
+		}
+		if data, _ := os.ReadFile(basePath); !strings.Contains(string(data), "kind: MCPServer") {
+			t.Fatalf("expected base manifest to be overwritten: %s", data)
+		}
+	})
+}
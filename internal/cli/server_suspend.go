@@ -0,0 +1,137 @@
+package cli
+
+// This file implements "server suspend" and "server resume".
+// Suspending scales an MCPServer to zero replicas and records the previous count in the
+// mcpruntime.org/suspended-replicas annotation; resuming restores it and removes the annotation.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func (m *ServerManager) newServerSuspendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suspend [name]",
+		Short: "Scale an MCP server to zero replicas",
+		Long:  "Scale an MCP server to zero replicas, remembering its replica count so 'server resume' can restore it.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.SuspendServer(args[0], serverNamespace())
+		},
+	}
+
+	return cmd
+}
+
+func (m *ServerManager) newServerResumeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume [name]",
+		Short: "Restore a suspended MCP server",
+		Long:  "Restore the replica count a suspended MCP server had before 'server suspend'.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ResumeServer(args[0], serverNamespace())
+		},
+	}
+
+	return cmd
+}
+
+// SuspendServer scales an MCP server to zero, saving its replica count in an annotation.
+func (m *ServerManager) SuspendServer(name, namespace string) error {
+	name, namespace, err := validateServerInput(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	replicas, suspended, err := m.serverReplicas(name, namespace)
+	if err != nil {
+		return m.suspendError(ErrSuspendServerFailed, err, name, namespace, "Failed to suspend server")
+	}
+	if suspended != "" {
+		Warn(fmt.Sprintf("Server %s is already suspended (resume with: mcp-runtime server resume %s -n %s)", name, name, namespace))
+		return nil
+	}
+	if replicas == "" {
+		replicas = "1"
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]string{AnnotationSuspendedReplicas: replicas}},
+		"spec":     map[string]any{"replicas": 0},
+	})
+	if err != nil {
+		return m.suspendError(ErrSuspendServerFailed, err, name, namespace, "Failed to suspend server")
+	}
+	// #nosec G204 -- name/namespace validated via validateServerInput; patch is generated JSON.
+	if err := m.kubectl.RunWithOutput([]string{"patch", "mcpserver", name, "-n", namespace, "--type=merge", "-p", string(patch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return m.suspendError(ErrSuspendServerFailed, err, name, namespace, "Failed to suspend server")
+	}
+
+	Success(fmt.Sprintf("Suspended %s (was %s replicas)", name, replicas))
+	return nil
+}
+
+// ResumeServer restores the replica count saved by SuspendServer.
+func (m *ServerManager) ResumeServer(name, namespace string) error {
+	name, namespace, err := validateServerInput(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	_, suspended, err := m.serverReplicas(name, namespace)
+	if err != nil {
+		return m.suspendError(ErrResumeServerFailed, err, name, namespace, "Failed to resume server")
+	}
+	if suspended == "" {
+		Warn(fmt.Sprintf("Server %s is not suspended", name))
+		return nil
+	}
+	replicas, err := strconv.Atoi(suspended)
+	if err != nil || replicas < 1 {
+		replicas = 1
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"annotations": map[string]any{AnnotationSuspendedReplicas: nil}},
+		"spec":     map[string]any{"replicas": replicas},
+	})
+	if err != nil {
+		return m.suspendError(ErrResumeServerFailed, err, name, namespace, "Failed to resume server")
+	}
+	// #nosec G204 -- name/namespace validated via validateServerInput; patch is generated JSON.
+	if err := m.kubectl.RunWithOutput([]string{"patch", "mcpserver", name, "-n", namespace, "--type=merge", "-p", string(patch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return m.suspendError(ErrResumeServerFailed, err, name, namespace, "Failed to resume server")
+	}
+
+	Success(fmt.Sprintf("Resumed %s with %d replicas", name, replicas))
+	return nil
+}
+
+// serverReplicas returns the server's spec.replicas and its suspended-replicas annotation.
+func (m *ServerManager) serverReplicas(name, namespace string) (replicas, suspended string, err error) {
+	annotation := strings.ReplaceAll(AnnotationSuspendedReplicas, ".", `\.`)
+	// #nosec G204 -- name/namespace validated via validateServerInput.
+	out, err := m.kubectl.Output([]string{"get", "mcpserver", name, "-n", namespace, "-o", "jsonpath={.spec.replicas}|{.metadata.annotations." + annotation + "}"})
+	if err != nil {
+		return "", "", err
+	}
+	replicas, suspended, _ = strings.Cut(strings.TrimSpace(string(out)), "|")
+	return replicas, suspended, nil
+}
+
+func (m *ServerManager) suspendError(base, err error, name, namespace, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		base,
+		err,
+		fmt.Sprintf("%s %q in namespace %q: %v", strings.ToLower(msg), name, namespace, err),
+		map[string]any{"server": name, "namespace": namespace, "component": "server"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func suspendTestExecutor(getOutput string) *MockExecutor {
+	return &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			if contains(spec.Args, "get") {
+				return &MockCommand{Args: spec.Args, OutputData: []byte(getOutput)}
+			}
+			return &MockCommand{Args: spec.Args}
+		},
+	}
+}
+
+func patchPayload(t *testing.T, mock *MockExecutor) string {
+	t.Helper()
+	last := mock.LastCommand()
+	if !contains(last.Args, "patch") {
+		t.Fatalf("expected patch, got %v", last.Args)
+	}
+	return last.Args[len(last.Args)-1]
+}
+
+func TestServerManager_SuspendServer(t *testing.T) {
+	t.Run("scales to zero and saves replicas", func(t *testing.T) {
+		mock := suspendTestExecutor("3|")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.SuspendServer("dev-server", "test-ns"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		payload := patchPayload(t, mock)
+		if !strings.Contains(payload, `"mcpruntime.org/suspended-replicas":"3"`) || !strings.Contains(payload, `"replicas":0`) {
+			t.Fatalf("unexpected patch %s", payload)
+		}
+	})
+
+	t.Run("leaves an already suspended server alone", func(t *testing.T) {
+		mock := suspendTestExecutor("0|2")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.SuspendServer("dev-server", "test-ns"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if contains(mock.LastCommand().Args, "patch") {
+			t.Fatal("expected no patch for an already suspended server")
+		}
+	})
+
+	t.Run("wraps lookup failures", func(t *testing.T) {
+		mock := &MockExecutor{DefaultErr: errors.New("not found")}
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.SuspendServer("dev-server", "test-ns"); !errors.Is(err, ErrSuspendServerFailed) {
+			t.Fatalf("expected ErrSuspendServerFailed, got %v", err)
+		}
+	})
+}
+
+func TestServerManager_ResumeServer(t *testing.T) {
+	t.Run("restores saved replicas", func(t *testing.T) {
+		mock := suspendTestExecutor("0|3")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ResumeServer("dev-server", "test-ns"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		payload := patchPayload(t, mock)
+		if !strings.Contains(payload, `"mcpruntime.org/suspended-replicas":null`) || !strings.Contains(payload, `"replicas":3`) {
+			t.Fatalf("unexpected patch %s", payload)
+		}
+	})
+
+	t.Run("warns when not suspended", func(t *testing.T) {
+		mock := suspendTestExecutor("2|")
+		mgr := NewServerManager(&KubectlClient{exec: mock, validators: nil}, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.ResumeServer("dev-server", "test-ns"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "not suspended") {
+			t.Fatalf("expected warning, got %q", buf.String())
+		}
+	})
+}
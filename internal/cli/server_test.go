@@ -110,7 +110,7 @@ func TestServerManager_DeleteServer(t *testing.T) {
 		mgr := NewServerManager(kubectl, zap.NewNop())
 
 		// Invalid name with special chars
-		err := mgr.DeleteServer("bad;name", "test-ns")
+		err := mgr.DeleteServer("bad;name", "test-ns", false)
 		if err == nil {
 			t.Fatal("expected error for invalid server name")
 		}
@@ -126,7 +126,7 @@ func TestServerManager_DeleteServer(t *testing.T) {
 		kubectl := &KubectlClient{exec: mock, validators: nil}
 		mgr := NewServerManager(kubectl, zap.NewNop())
 
-		err := mgr.DeleteServer("my-server", "test-ns")
+		err := mgr.DeleteServer("my-server", "test-ns", false)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -621,9 +621,9 @@ func TestServerManager_ServerStatus(t *testing.T) {
 			CommandFunc: func(spec ExecSpec) *MockCommand {
 				cmd := &MockCommand{Args: spec.Args}
 				if contains(spec.Args, "mcpserver") {
-					cmd.OutputData = []byte("server1|image:tag|1|/path|true\n")
+					cmd.OutputData = []byte("server1|image:tag|1||/path|||true\n")
 				} else if contains(spec.Args, "pods") {
-					cmd.OutputData = []byte("NAME READY STATUS RESTARTS\npod-1 true Running 0\n")
+					cmd.OutputData = []byte(`{"items":[{"metadata":{"name":"pod-1"},"status":{"phase":"Running"}}]}`)
 				}
 				return cmd
 			},
@@ -714,7 +714,7 @@ func TestServerManager_ServerStatus(t *testing.T) {
 				if contains(spec.Args, "mcpserver") {
 					cmd.OutputData = []byte("server1|image:tag|1|/path|false\n")
 				} else if contains(spec.Args, "pods") {
-					cmd.OutputData = []byte("NAME READY STATUS RESTARTS\n")
+					cmd.OutputData = []byte(`{"items":[]}`)
 				}
 				return cmd
 			},
@@ -0,0 +1,214 @@
+package cli
+
+// This file implements "server top", which sums the CPU and memory usage the metrics API
+// reports for each MCP server's pods. With --watch it redraws the table every --interval
+// until interrupted.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	topSortCPU    = "cpu"
+	topSortMemory = "memory"
+)
+
+// podMetricsList is the part of a metrics.k8s.io PodMetricsList that "server top" reads.
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string            `json:"name"`
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Containers []struct {
+			Usage map[string]resource.Quantity `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// serverUsage is the summed usage of one server's pods.
+type serverUsage struct {
+	Namespace   string
+	Name        string
+	Pods        int
+	MilliCPU    int64
+	MemoryBytes int64
+}
+
+func (m *ServerManager) newServerTopCmd() *cobra.Command {
+	var sortBy string
+	var allNamespaces bool
+	var watch bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show CPU and memory usage per MCP server",
+		Long: `Show the CPU and memory usage of each MCP server, summed over its pods.
+Usage comes from the metrics API, so metrics-server (or another metrics.k8s.io provider)
+must be installed in the cluster. Use --watch to refresh every --interval.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := serverNamespace()
+			if allNamespaces {
+				namespace = ""
+			}
+			return m.TopServers(namespace, sortBy, watch, interval)
+		},
+	}
+
+	cmd.Flags().StringVar(&sortBy, "sort-by", topSortCPU, "Sort servers by cpu or memory")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Show servers in every namespace")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Refresh the table until interrupted")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Refresh interval for --watch")
+
+	return cmd
+}
+
+// TopServers prints the usage of the servers in namespace, or in every namespace when it
+// is empty. With watch the table is redrawn every interval until the command is interrupted.
+func (m *ServerManager) TopServers(namespace, sortBy string, watch bool, interval time.Duration) error {
+	if sortBy != topSortCPU && sortBy != topSortMemory {
+		err := newWithSentinel(ErrInvalidTopOptions, fmt.Sprintf("invalid --sort-by %q (use %s or %s)", sortBy, topSortCPU, topSortMemory))
+		Error("Invalid top options")
+		logStructuredError(m.logger, err, "Invalid top options")
+		return err
+	}
+	if watch && interval <= 0 {
+		err := newWithSentinel(ErrInvalidTopOptions, fmt.Sprintf("--interval must be positive, got %s", interval))
+		Error("Invalid top options")
+		logStructuredError(m.logger, err, "Invalid top options")
+		return err
+	}
+
+	for {
+		usage, err := m.serverUsage(namespace)
+		if err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrReadMetricsFailed,
+				err,
+				fmt.Sprintf("failed to read server metrics: %v (is metrics-server installed?)", err),
+				map[string]any{"namespace": namespace, "component": "server"},
+			)
+			if !watch {
+				Error("Failed to read server metrics")
+				logStructuredError(m.logger, wrappedErr, "Failed to read server metrics")
+				return wrappedErr
+			}
+			// Metrics lag behind new pods, so a failed refresh is retried.
+			Warn(wrappedErr.Error())
+		} else {
+			if watch && isTerminalWriter(DefaultPrinter.Writer) {
+				// Clear the screen so the table redraws in place.
+				DefaultPrinter.Printf("\033[H\033[2J")
+			}
+			sortServerUsage(usage, sortBy)
+			if len(usage) == 0 {
+				Info("No MCP server pods reporting metrics")
+			} else {
+				Table(serverUsageRows(usage, namespace == ""))
+			}
+		}
+		if !watch {
+			return nil
+		}
+		if err := sleepContext(commandContext(), interval); err != nil {
+			// Interrupting a watch is the normal way to stop it.
+			return nil
+		}
+	}
+}
+
+// serverUsage reads pod metrics for MCP servers and sums them per server.
+func (m *ServerManager) serverUsage(namespace string) ([]serverUsage, error) {
+	path := "/apis/metrics.k8s.io/v1beta1/pods"
+	if namespace != "" {
+		path = "/apis/metrics.k8s.io/v1beta1/namespaces/" + url.PathEscape(namespace) + "/pods"
+	}
+	path += "?labelSelector=" + url.QueryEscape(SelectorManagedBy)
+	// #nosec G204 -- fixed API path; namespace is escaped.
+	out, err := m.kubectl.Output([]string{"get", "--raw", path})
+	if err != nil {
+		return nil, err
+	}
+	var metrics podMetricsList
+	if err := json.Unmarshal(out, &metrics); err != nil {
+		return nil, fmt.Errorf("parse pod metrics: %w", err)
+	}
+	return sumServerUsage(metrics), nil
+}
+
+// sumServerUsage groups pod metrics by the server named in the pod's app label.
+func sumServerUsage(metrics podMetricsList) []serverUsage {
+	byServer := map[string]*serverUsage{}
+	var usage []serverUsage
+	var order []string
+	for _, pod := range metrics.Items {
+		name := pod.Metadata.Labels["app"]
+		if name == "" {
+			name = pod.Metadata.Name
+		}
+		key := pod.Metadata.Namespace + "/" + name
+		u, ok := byServer[key]
+		if !ok {
+			u = &serverUsage{Namespace: pod.Metadata.Namespace, Name: name}
+			byServer[key] = u
+			order = append(order, key)
+		}
+		u.Pods++
+		for _, c := range pod.Containers {
+			if cpu, ok := c.Usage["cpu"]; ok {
+				u.MilliCPU += cpu.MilliValue()
+			}
+			if memory, ok := c.Usage["memory"]; ok {
+				u.MemoryBytes += memory.Value()
+			}
+		}
+	}
+	for _, key := range order {
+		usage = append(usage, *byServer[key])
+	}
+	return usage
+}
+
+// sortServerUsage orders servers by descending cpu or memory, then by namespace and name.
+func sortServerUsage(usage []serverUsage, sortBy string) {
+	sort.SliceStable(usage, func(i, j int) bool {
+		a, b := usage[i], usage[j]
+		switch {
+		case sortBy == topSortMemory && a.MemoryBytes != b.MemoryBytes:
+			return a.MemoryBytes > b.MemoryBytes
+		case sortBy == topSortCPU && a.MilliCPU != b.MilliCPU:
+			return a.MilliCPU > b.MilliCPU
+		case a.Namespace != b.Namespace:
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// serverUsageRows builds the top table, header first, in kubectl top's units.
+func serverUsageRows(usage []serverUsage, withNamespace bool) [][]string {
+	header := []string{"NAME", "PODS", "CPU(cores)", "MEMORY(bytes)"}
+	if withNamespace {
+		header = append([]string{"NAMESPACE"}, header...)
+	}
+	rows := [][]string{header}
+	for _, u := range usage {
+		row := []string{u.Name, strconv.Itoa(u.Pods), fmt.Sprintf("%dm", u.MilliCPU), fmt.Sprintf("%dMi", u.MemoryBytes/(1024*1024))}
+		if withNamespace {
+			row = append([]string{u.Namespace}, row...)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
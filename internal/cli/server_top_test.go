@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testPodMetrics = `{"items":[
+ {"metadata":{"name":"weather-a","namespace":"mcp-servers","labels":{"app":"weather"}},
+  "containers":[{"usage":{"cpu":"150m","memory":"64Mi"}},{"usage":{"cpu":"50m","memory":"16Mi"}}]},
+ {"metadata":{"name":"weather-b","namespace":"mcp-servers","labels":{"app":"weather"}},
+  "containers":[{"usage":{"cpu":"100m","memory":"80Mi"}}]},
+ {"metadata":{"name":"search-a","namespace":"mcp-servers","labels":{"app":"search"}},
+  "containers":[{"usage":{"cpu":"1","memory":"32Mi"}}]}
+]}`
+
+func TestTopServersSumsPodsPerServer(t *testing.T) {
+	mock := &MockExecutor{DefaultOutput: []byte(testPodMetrics)}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.TopServers("mcp-servers", topSortMemory, false, 0); err != nil {
+		t.Fatalf("TopServers() error: %v", err)
+	}
+	if len(mock.Commands) != 1 {
+		t.Fatalf("expected one metrics call, got %v", mock.Commands)
+	}
+	path := mock.Commands[0].Args[len(mock.Commands[0].Args)-1]
+	if !strings.HasPrefix(path, "/apis/metrics.k8s.io/v1beta1/namespaces/mcp-servers/pods?labelSelector=") {
+		t.Fatalf("unexpected metrics path %q", path)
+	}
+
+	out := buf.String()
+	weather, search := strings.Index(out, "weather"), strings.Index(out, "search")
+	if weather < 0 || search < 0 || weather > search {
+		t.Fatalf("expected weather (160Mi) before search (32Mi) when sorted by memory:\n%s", out)
+	}
+	for _, want := range []string{"300m", "160Mi", "1000m", "32Mi"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSortServerUsage(t *testing.T) {
+	usage := []serverUsage{
+		{Namespace: "b", Name: "low", MilliCPU: 10, MemoryBytes: 300},
+		{Namespace: "a", Name: "high", MilliCPU: 500, MemoryBytes: 100},
+		{Namespace: "a", Name: "mid", MilliCPU: 10, MemoryBytes: 200},
+	}
+
+	sortServerUsage(usage, topSortCPU)
+	if got := usage[0].Name + "," + usage[1].Name + "," + usage[2].Name; got != "high,mid,low" {
+		t.Fatalf("cpu order = %s", got)
+	}
+	sortServerUsage(usage, topSortMemory)
+	if got := usage[0].Name + "," + usage[1].Name + "," + usage[2].Name; got != "low,mid,high" {
+		t.Fatalf("memory order = %s", got)
+	}
+
+	rows := serverUsageRows(usage[:1], true)
+	if got := strings.Join(rows[1], "|"); got != "b|low|0|10m|0Mi" {
+		t.Fatalf("row = %s", got)
+	}
+}
+
+func TestTopServersErrors(t *testing.T) {
+	mgr := NewServerManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+	if err := mgr.TopServers("mcp-servers", "disk", false, 0); !errors.Is(err, ErrInvalidTopOptions) {
+		t.Fatalf("expected ErrInvalidTopOptions for --sort-by disk, got %v", err)
+	}
+	if err := mgr.TopServers("mcp-servers", topSortCPU, true, 0); !errors.Is(err, ErrInvalidTopOptions) {
+		t.Fatalf("expected ErrInvalidTopOptions for a zero interval, got %v", err)
+	}
+
+	mgr = NewServerManager(&KubectlClient{exec: &MockExecutor{DefaultErr: errors.New("the server could not find the requested resource")}}, zap.NewNop())
+	if err := mgr.TopServers("", topSortCPU, false, 0); !errors.Is(err, ErrReadMetricsFailed) {
+		t.Fatalf("expected ErrReadMetricsFailed, got %v", err)
+	}
+}
+
+func TestTopServersWatchStopsOnInterrupt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	SetCommandContext(ctx)
+	t.Cleanup(func() { SetCommandContext(context.Background()) })
+
+	calls := 0
+	mock := &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return &MockCommand{Args: spec.Args, OutputData: []byte(testPodMetrics)}
+	}}
+	mgr := NewServerManager(&KubectlClient{exec: mock}, zap.NewNop())
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+
+	if err := mgr.TopServers("", topSortCPU, true, time.Millisecond); err != nil {
+		t.Fatalf("TopServers() error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the watch to stop after the interrupted refresh, got %d calls", calls)
+	}
+}
@@ -0,0 +1,275 @@
+package cli
+
+// This file implements the "config" command, which saves CLI defaults in ~/.mcp-runtime/config.yaml,
+// and the namespace resolution every command shares: the global --namespace flag wins, then the
+// namespace saved with "config set namespace", then mcp-servers. Registry commands fall back to
+// the registry namespace instead, since the registry does not move with the servers.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const settingNamespace = "namespace"
+
+// cliSettings is the on-disk format of ~/.mcp-runtime/config.yaml.
+type cliSettings struct {
+	Namespace string `yaml:"namespace,omitempty"`
+	// ExecPolicy restricts the commands the CLI may run; it is edited in the file directly.
+	ExecPolicy *execPolicy `yaml:"execPolicy,omitempty"`
+}
+
+// settingKeys lists the keys accepted by "config set/get/unset" with their built-in defaults.
+var settingKeys = map[string]string{
+	settingNamespace: NamespaceMCPServers,
+}
+
+var (
+	namespaceOverride   string
+	namespaceOverrideMu sync.RWMutex
+)
+
+// SetNamespaceOverride records the global --namespace flag; empty means not set.
+func SetNamespaceOverride(namespace string) {
+	namespaceOverrideMu.Lock()
+	defer namespaceOverrideMu.Unlock()
+	namespaceOverride = strings.TrimSpace(namespace)
+}
+
+func getNamespaceOverride() string {
+	namespaceOverrideMu.RLock()
+	defer namespaceOverrideMu.RUnlock()
+	return namespaceOverride
+}
+
+// serverNamespace returns the namespace MCP servers live in: --namespace, then the saved
+// namespace, then mcp-servers.
+func serverNamespace() string {
+	if ns := getNamespaceOverride(); ns != "" {
+		return ns
+	}
+	if settings, err := loadCLISettings(); err == nil && settings.Namespace != "" {
+		return settings.Namespace
+	}
+	return NamespaceMCPServers
+}
+
+// registryNamespace returns --namespace when given, otherwise the registry namespace.
+func registryNamespace() string {
+	if ns := getNamespaceOverride(); ns != "" {
+		return ns
+	}
+	return NamespaceRegistry
+}
+
+// NewConfigCmd returns the config subcommand.
+func NewConfigCmd(logger *zap.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage mcp-runtime CLI defaults",
+		Long: `Save defaults for mcp-runtime commands in ~/.mcp-runtime/config.yaml.
+
+Keys:
+  namespace   Namespace for MCP servers used by server, rbac, smoketest and setup
+              commands (default "mcp-servers"). The global --namespace flag overrides it.
+
+The execPolicy section of the file, edited by hand, restricts what the CLI may execute:
+allowedBinaries, forbiddenFlags, allowedNamespaces (kubectl namespace flags) and mode
+(enforce, the default, or audit to only log violations).`,
+	}
+
+	cmd.AddCommand(newConfigSetCmd(logger))
+	cmd.AddCommand(newConfigGetCmd(logger))
+	cmd.AddCommand(newConfigUnsetCmd(logger))
+
+	return cmd
+}
+
+func newConfigSetCmd(logger *zap.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Save a default",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setSetting(logger, args[0], args[1])
+		},
+	}
+}
+
+func newConfigGetCmd(logger *zap.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Show saved defaults",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := ""
+			if len(args) == 1 {
+				key = args[0]
+			}
+			return showSettings(logger, key)
+		},
+	}
+}
+
+func newConfigUnsetCmd(logger *zap.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a saved default",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setSetting(logger, args[0], "")
+		},
+	}
+}
+
+// setSetting validates and saves key; an empty value removes it.
+func setSetting(logger *zap.Logger, key, value string) error {
+	if err := validateSettingKey(key); err != nil {
+		Error("Unknown config key")
+		logStructuredError(logger, err, "Unknown config key")
+		return err
+	}
+	value = strings.TrimSpace(value)
+	if value != "" {
+		if errs := validation.IsDNS1123Label(value); len(errs) > 0 {
+			err := newWithSentinel(ErrInvalidNamespace, fmt.Sprintf("invalid namespace %q: %s", value, strings.Join(errs, "; ")))
+			Error("Invalid namespace")
+			logStructuredError(logger, err, "Invalid namespace")
+			return err
+		}
+	}
+
+	settings, err := loadCLISettings()
+	if err == nil {
+		settings.Namespace = value
+		err = saveCLISettings(settings)
+	}
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrSaveSettingsFailed,
+			err,
+			fmt.Sprintf("failed to save config: %v", err),
+			map[string]any{"key": key, "component": "config"},
+		)
+		Error("Failed to save config")
+		logStructuredError(logger, wrappedErr, "Failed to save config")
+		return wrappedErr
+	}
+
+	if value == "" {
+		Success(fmt.Sprintf("Removed %s; using the default %q", key, settingKeys[key]))
+		return nil
+	}
+	Success(fmt.Sprintf("Set %s to %q", key, value))
+	return nil
+}
+
+// showSettings prints the effective value of key, or of every key when key is empty.
+func showSettings(logger *zap.Logger, key string) error {
+	if key != "" {
+		if err := validateSettingKey(key); err != nil {
+			Error("Unknown config key")
+			logStructuredError(logger, err, "Unknown config key")
+			return err
+		}
+	}
+	settings, err := loadCLISettings()
+	if err != nil {
+		wrappedErr := wrapWithSentinel(ErrReadSettingsFailed, err, fmt.Sprintf("failed to read config: %v", err))
+		Error("Failed to read config")
+		logStructuredError(logger, wrappedErr, "Failed to read config")
+		return wrappedErr
+	}
+
+	saved := map[string]string{settingNamespace: settings.Namespace}
+	if key != "" {
+		DefaultPrinter.Println(valueOrDefault(saved[key], settingKeys[key]))
+		return nil
+	}
+	rows := [][]string{{"Key", "Value", "Source"}}
+	for _, k := range sortedSettingKeys() {
+		source := "saved"
+		if saved[k] == "" {
+			source = "default"
+		}
+		rows = append(rows, []string{k, valueOrDefault(saved[k], settingKeys[k]), source})
+	}
+	Table(rows)
+	return nil
+}
+
+func validateSettingKey(key string) error {
+	if _, ok := settingKeys[key]; !ok {
+		return newWithSentinel(ErrUnknownSettingKey, fmt.Sprintf("unknown config key %q (use one of: %s)", key, strings.Join(sortedSettingKeys(), ", ")))
+	}
+	return nil
+}
+
+func sortedSettingKeys() []string {
+	keys := make([]string, 0, len(settingKeys))
+	for k := range settingKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+func cliSettingsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".mcp-runtime", "config.yaml"), nil
+}
+
+// loadCLISettings reads the saved defaults; a missing file yields empty settings.
+func loadCLISettings() (cliSettings, error) {
+	var settings cliSettings
+	path, err := cliSettingsPath()
+	if err != nil {
+		return settings, err
+	}
+	// #nosec G304 -- path is scoped to the user's config directory.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return settings, err
+	}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return settings, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+func saveCLISettings(settings cliSettings) error {
+	path, err := cliSettingsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestServerNamespace(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { SetNamespaceOverride("") })
+
+	if got := serverNamespace(); got != NamespaceMCPServers {
+		t.Fatalf("expected built-in default, got %q", got)
+	}
+	if err := saveCLISettings(cliSettings{Namespace: "team-a"}); err != nil {
+		t.Fatalf("saveCLISettings: %v", err)
+	}
+	if got := serverNamespace(); got != "team-a" {
+		t.Fatalf("expected saved namespace, got %q", got)
+	}
+	if got := registryNamespace(); got != NamespaceRegistry {
+		t.Fatalf("expected registry namespace to ignore the saved namespace, got %q", got)
+	}
+
+	SetNamespaceOverride("team-b")
+	if got := serverNamespace(); got != "team-b" {
+		t.Fatalf("expected --namespace to win, got %q", got)
+	}
+	if got := registryNamespace(); got != "team-b" {
+		t.Fatalf("expected --namespace for registry commands, got %q", got)
+	}
+}
+
+func TestSetSetting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+	logger := zap.NewNop()
+
+	if err := setSetting(logger, "namespace", "team-a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings, _ := loadCLISettings(); settings.Namespace != "team-a" {
+		t.Fatalf("expected namespace to be saved, got %+v", settings)
+	}
+
+	buf.Reset()
+	if err := showSettings(logger, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "team-a") || !strings.Contains(out, "saved") {
+		t.Fatalf("unexpected config output:\n%s", out)
+	}
+
+	if err := setSetting(logger, "namespace", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf.Reset()
+	if err := showSettings(logger, "namespace"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out := strings.TrimSpace(buf.String()); out != NamespaceMCPServers {
+		t.Fatalf("expected default after unset, got %q", out)
+	}
+
+	if err := setSetting(logger, "namespace", "Team_A"); !errors.Is(err, ErrInvalidNamespace) {
+		t.Fatalf("expected ErrInvalidNamespace, got %v", err)
+	}
+	if err := setSetting(logger, "registry", "x"); !errors.Is(err, ErrUnknownSettingKey) {
+		t.Fatalf("expected ErrUnknownSettingKey, got %v", err)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -37,7 +36,7 @@ type SetupDeps struct {
 	ClusterManager                  ClusterManagerAPI
 	RegistryManager                 RegistryManagerAPI
 	LoginRegistry                   func(logger *zap.Logger, registryURL, username, password string) error
-	DeployRegistry                  func(logger *zap.Logger, namespace string, port int, registryType, registryStorageSize, manifestPath string) error
+	DeployRegistry                  func(logger *zap.Logger, namespace string, port int, registryType, registryStorageSize, manifestPath string, offlineImages []offlineImage) error
 	WaitForDeploymentAvailable      func(logger *zap.Logger, name, namespace, selector string, timeout time.Duration) error
 	PrintDeploymentDiagnostics      func(deploy, namespace, selector string)
 	SetupTLS                        func(logger *zap.Logger) error
@@ -46,13 +45,30 @@ type SetupDeps struct {
 	EnsureNamespace                 func(namespace string) error
 	GetPlatformRegistryURL          func(logger *zap.Logger) string
 	PushOperatorImageToInternal     func(logger *zap.Logger, sourceImage, targetImage, helperNamespace string) error
-	DeployOperatorManifests         func(logger *zap.Logger, operatorImage string) error
+	DeployOperatorManifests         func(logger *zap.Logger, operatorImage string, replicas int, options OperatorDeployOptions) error
 	ConfigureProvisionedRegistryEnv func(ext *ExternalRegistryConfig, secretName string) error
 	RestartDeployment               func(name, namespace string) error
 	CheckCRDInstalled               func(name string) error
 	GetDeploymentTimeout            func() time.Duration
+	GetCertTimeout                  func() time.Duration
 	GetRegistryPort                 func() int
 	OperatorImageFor                func(ext *ExternalRegistryConfig) string
+	LoadImageArchive                func(path string) (string, error)
+	PushImageDirect                 func(source, target string) error
+	CheckRegistryImage              func(logger *zap.Logger, image string, external bool) error
+	CheckInternalRegistryReady      func(logger *zap.Logger) error
+	GenerateSBOM                    func(image, format, output string) error
+	AttachSBOM                      func(image, sbomPath, format string) error
+	DeployObservability             func(logger *zap.Logger, offlineImages []offlineImage) error
+	DeployExternalDNS               func(logger *zap.Logger, opts ExternalDNSOptions, offlineImages []offlineImage) error
+	EnableRegistryAuth              func(logger *zap.Logger, registryURL string) error
+	VerifyOperatorFailover          func(logger *zap.Logger, timeout time.Duration) error
+	ConfigureDualIngress            func() error
+	ConfigureServiceIPFamilies      func(families []string) error
+	ConfigureNamespaceQuotas        func() error
+	ConfigureProxy                  func(settings proxySettings) error
+	Preflight                       func(logger *zap.Logger, opts preflightOptions) error
+	DetectIngressAddress            func() (ingressAddress, error)
 }
 
 func (d SetupDeps) withDefaults(logger *zap.Logger) SetupDeps {
@@ -77,8 +93,14 @@ func (d SetupDeps) withDefaults(logger *zap.Logger) SetupDeps {
 	if d.PrintDeploymentDiagnostics == nil {
 		d.PrintDeploymentDiagnostics = printDeploymentDiagnostics
 	}
+	if d.GetCertTimeout == nil {
+		d.GetCertTimeout = GetCertTimeout
+	}
 	if d.SetupTLS == nil {
-		d.SetupTLS = setupTLS
+		getCertTimeout := d.GetCertTimeout
+		d.SetupTLS = func(logger *zap.Logger) error {
+			return setupTLSWithKubectl(kubectlClient, logger, getCertTimeout())
+		}
 	}
 	if d.BuildOperatorImage == nil {
 		d.BuildOperatorImage = buildOperatorImage
@@ -116,6 +138,56 @@ func (d SetupDeps) withDefaults(logger *zap.Logger) SetupDeps {
 	if d.OperatorImageFor == nil {
 		d.OperatorImageFor = getOperatorImage
 	}
+	if d.LoadImageArchive == nil {
+		d.LoadImageArchive = loadImageArchive
+	}
+	if d.PushImageDirect == nil {
+		d.PushImageDirect = pushImageDirect
+	}
+	if d.CheckRegistryImage == nil {
+		d.CheckRegistryImage = checkRegistryImage
+	}
+	if d.CheckInternalRegistryReady == nil {
+		d.CheckInternalRegistryReady = func(logger *zap.Logger) error {
+			return checkRegistryStatusQuiet(logger, NamespaceRegistry)
+		}
+	}
+	if d.GenerateSBOM == nil {
+		d.GenerateSBOM = generateSBOM
+	}
+	if d.AttachSBOM == nil {
+		d.AttachSBOM = attachSBOM
+	}
+	if d.DeployObservability == nil {
+		d.DeployObservability = deployObservability
+	}
+	if d.DeployExternalDNS == nil {
+		d.DeployExternalDNS = deployExternalDNS
+	}
+	if d.EnableRegistryAuth == nil {
+		d.EnableRegistryAuth = enableRegistryAuth
+	}
+	if d.VerifyOperatorFailover == nil {
+		d.VerifyOperatorFailover = verifyOperatorFailover
+	}
+	if d.ConfigureDualIngress == nil {
+		d.ConfigureDualIngress = configureDualIngress
+	}
+	if d.ConfigureServiceIPFamilies == nil {
+		d.ConfigureServiceIPFamilies = configureServiceIPFamilies
+	}
+	if d.ConfigureNamespaceQuotas == nil {
+		d.ConfigureNamespaceQuotas = configureNamespaceQuotas
+	}
+	if d.ConfigureProxy == nil {
+		d.ConfigureProxy = configureProxy
+	}
+	if d.Preflight == nil {
+		d.Preflight = runSetupPreflight
+	}
+	if d.DetectIngressAddress == nil {
+		d.DetectIngressAddress = detectSetupIngressAddress
+	}
 	return d
 }
 
@@ -127,18 +199,120 @@ func NewSetupCmd(logger *zap.Logger) *cobra.Command {
 	var ingressManifest string
 	var forceIngressInstall bool
 	var tlsEnabled bool
+	var dualIngress bool
+	var offline bool
+	var imagesDir string
+	var imageMirror string
+	var sbom SBOMOptions
+	var observability bool
+	var externalDNS ExternalDNSOptions
+	var registryAuth string
+	var operatorReplicas int
+	var plain bool
+	var skipPreflight bool
+	var serviceIPFamilies []string
+	var namespaceQuotas bool
+	var proxy ProxyOptions
+	var profile string
+	var timeouts SetupTimeouts
+	var notifyURL string
+	var operatorOptions OperatorDeployOptions
+	var configFile string
+	var containerToolFlag string
+	var summaryFile string
 	cmd := &cobra.Command{
 		Use:   "setup",
 		Short: "Setup the complete MCP platform",
 		Long: `Setup the complete MCP platform including:
 - Kubernetes cluster initialization
-- Internal container registry deployment (Docker Registry)
+- Internal container registry deployment (Docker Registry), optionally with htpasswd auth
 - Operator deployment
 - Ingress controller configuration
+- Optional Prometheus and Grafana stack (--with-observability)
+- Optional external-dns for MCPServer ingress hosts (--with-external-dns)
 
 The platform deploys an internal Docker registry by default, which teams
-will use to push and pull container images.`,
+will use to push and pull container images.
+
+--profile presets the flags of a common installation; flags given explicitly and
+the setup config file (-f) override it:
+` + setupProfileHelp(),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if plain {
+				SetPlainOutput()
+			}
+			if profile != "" {
+				applied, err := applySetupProfile(cmd, profile)
+				if err != nil {
+					Error("Invalid setup profile")
+					logStructuredError(logger, err, "Invalid setup profile")
+					return err
+				}
+				logger.Info("Using setup profile", zap.String("profile", profile), zap.Strings("flags", applied))
+				Info(fmt.Sprintf("Using the %s profile: %s", profile, orDash(strings.Join(applied, " "))))
+			}
+			if configFile != "" {
+				if err := applySetupConfigFile(cmd, configFile, &operatorReplicas, &operatorOptions); err != nil {
+					Error("Invalid setup config")
+					logStructuredError(logger, err, "Invalid setup config")
+					return err
+				}
+			}
+			if operatorReplicas < 1 {
+				err := newWithSentinel(ErrInvalidOperatorReplicas, fmt.Sprintf("--operator-replicas must be at least 1, got %d", operatorReplicas))
+				Error("Invalid operator replicas")
+				logStructuredError(logger, err, "Invalid operator replicas")
+				return err
+			}
+			if err := operatorOptions.Validate(); err != nil {
+				Error("Invalid operator options")
+				logStructuredError(logger, err, "Invalid operator options")
+				return err
+			}
+			if err := sbom.normalized().Validate(); err != nil {
+				Error("Invalid SBOM settings")
+				logStructuredError(logger, err, "Invalid SBOM settings")
+				return err
+			}
+			if err := externalDNS.Validate(); err != nil {
+				Error("Invalid external-dns settings")
+				logStructuredError(logger, err, "Invalid external-dns settings")
+				return err
+			}
+			if err := validateRegistryAuth(registryAuth); err != nil {
+				Error("Invalid registry auth")
+				logStructuredError(logger, err, "Invalid registry auth")
+				return err
+			}
+			if err := validateServiceIPFamilies(serviceIPFamilies); err != nil {
+				Error("Invalid Service IP families")
+				logStructuredError(logger, err, "Invalid Service IP families")
+				return err
+			}
+			if err := proxy.Validate(); err != nil {
+				Error("Invalid proxy settings")
+				logStructuredError(logger, err, "Invalid proxy settings")
+				return err
+			}
+			proxy.settings().export()
+			if err := timeouts.Validate(); err != nil {
+				Error("Invalid setup timeout")
+				logStructuredError(logger, err, "Invalid setup timeout")
+				return err
+			}
+			if err := validateNotifyURL(notifyURL); err != nil {
+				Error("Invalid notify URL")
+				logStructuredError(logger, err, "Invalid notify URL")
+				return err
+			}
+			tool, err := resolveContainerTool(execExecutor, containerToolFlag)
+			if err != nil {
+				Error("Invalid container tool")
+				logStructuredError(logger, err, "Invalid container tool")
+				return err
+			}
+			selectedContainerTool = tool
+			logger.Info("Using container tool", zap.String("tool", tool))
 			plan := BuildSetupPlan(SetupPlanInput{
 				RegistryType:           registryType,
 				RegistryStorageSize:    registryStorageSize,
@@ -147,25 +321,60 @@ will use to push and pull container images.`,
 				IngressManifestChanged: cmd.Flags().Changed("ingress-manifest"),
 				ForceIngressInstall:    forceIngressInstall,
 				TLSEnabled:             tlsEnabled,
+				DualIngress:            dualIngress,
+				Offline:                offline,
+				ImagesDir:              imagesDir,
+				ImageMirror:            imageMirror,
+				SBOM:                   sbom,
+				Observability:          observability,
+				ExternalDNS:            externalDNS,
+				RegistryAuth:           registryAuth,
+				OperatorReplicas:       operatorReplicas,
+				Operator:               operatorOptions,
+				ServiceIPFamilies:      serviceIPFamilies,
+				NamespaceQuotas:        namespaceQuotas,
+				Proxy:                  proxy,
+				SkipPreflight:          skipPreflight,
+				SummaryFile:            summaryFile,
 			})
 
-			return setupPlatform(logger, plan)
+			kubectlClient.timeout = timeouts.Kubectl
+			return runWithNotify(logger, notifyURL, "setup", func() error {
+				return setupPlatformWithDeps(logger, plan, timeouts.deps())
+			})
 		},
 	}
 
 	cmd.Flags().StringVar(&registryType, "registry-type", "docker", "Registry type (docker; harbor coming soon)")
 	cmd.Flags().StringVar(&registryStorageSize, "registry-storage", "20Gi", "Registry storage size (default: 20Gi)")
+	cmd.Flags().StringVar(&registryAuth, "registry-auth", registryAuthNone, "Internal registry authentication ("+strings.Join(registryAuthModes, "|")+"); htpasswd generates credentials and pull secrets")
 	cmd.Flags().StringVar(&ingressMode, "ingress", "traefik", "Ingress controller to install automatically during setup (traefik|none)")
-	cmd.Flags().StringVar(&ingressManifest, "ingress-manifest", "config/ingress/overlays/http", "Manifest to apply when installing the ingress controller")
+	cmd.Flags().StringVar(&ingressManifest, "ingress-manifest", ingressManifestHTTP, "Manifest to apply when installing the ingress controller")
 	cmd.Flags().BoolVar(&forceIngressInstall, "force-ingress-install", false, "Force ingress install even if an ingress class already exists")
 	cmd.Flags().BoolVar(&tlsEnabled, "with-tls", false, "Enable TLS overlays (ingress/registry); default is HTTP for dev")
+	cmd.Flags().BoolVar(&dualIngress, "dual-ingress", false, "Serve MCP servers over HTTP and HTTPS side by side (implies --with-tls); spec.tlsOnly limits a server to HTTPS")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Skip image builds and external pulls; require images to be preloaded in the registry")
+	cmd.Flags().StringVar(&imagesDir, "images-dir", "", "Directory of image archives (<name>.tar) to load and push in offline mode (implies --offline)")
+	cmd.Flags().StringVar(&imageMirror, "image-mirror", "", "Registry every platform image is pulled from in offline mode (default: the external registry; implies --offline)")
+	addSBOMFlags(cmd, &sbom)
+	cmd.Flags().StringVar(&containerToolFlag, "container-tool", containerToolAuto, "Image CLI to build, push, save and load images with ("+containerToolAuto+"|"+strings.Join(containerTools, "|")+"); auto detects a running one")
+	cmd.Flags().StringSliceVar(&serviceIPFamilies, "service-ip-families", nil, "IP families of MCP server Services, primary first (IPv4, IPv6 or IPv4,IPv6 for dual-stack); checked during pre-flight (default: the cluster's)")
+	cmd.Flags().BoolVar(&namespaceQuotas, "with-namespace-quotas", false, "Have the operator enforce a ResourceQuota and LimitRange in every namespace with MCP servers")
+	cmd.Flags().BoolVar(&skipPreflight, "skip-preflight", false, "Skip the pre-flight checks of the Kubernetes version, API groups and default StorageClass")
+	cmd.Flags().StringVar(&summaryFile, "summary-file", "", "Also write the post-install summary of endpoints and next commands to this file")
+	cmd.Flags().BoolVar(&plain, "plain", false, "Plain log output without spinners or colors (for CI logs)")
+	cmd.Flags().IntVar(&operatorReplicas, "operator-replicas", DefaultOperatorReplicas, "Operator replicas; 2 or more run with leader election and a PodDisruptionBudget")
+	addOperatorDeployFlags(cmd, &operatorOptions)
+	cmd.Flags().StringVar(&profile, "profile", "", "Preset flags for a kind of installation ("+strings.Join(setupProfileNames(), "|")+"); explicit flags override it")
+	cmd.Flags().StringVarP(&configFile, "config", "f", "", "Setup config file (YAML) with operator settings; flags override it")
+	cmd.Flags().BoolVar(&observability, "with-observability", false, "Install a bundled Prometheus and Grafana stack with an MCP Runtime dashboard")
+	addExternalDNSFlags(cmd, &externalDNS)
+	addProxyFlags(cmd, &proxy)
+	addSetupTimeoutFlags(cmd, &timeouts)
+	addNotifyFlag(cmd, &notifyURL)
 	return cmd
 }
 
-func setupPlatform(logger *zap.Logger, plan SetupPlan) error {
-	return setupPlatformWithDeps(logger, plan, SetupDeps{}.withDefaults(logger))
-}
-
 func setupPlatformWithDeps(logger *zap.Logger, plan SetupPlan, deps SetupDeps) error {
 	deps = deps.withDefaults(logger)
 	Section("MCP Runtime Setup")
@@ -182,6 +391,7 @@ func setupPlatformWithDeps(logger *zap.Logger, plan SetupPlan, deps SetupDeps) e
 	}
 
 	Success("Platform setup complete")
+	printSetupSummary(buildSetupSummary(logger, deps, ctx), plan.SummaryFile)
 	fmt.Println(Green("\nPlatform is ready. Use 'mcp-runtime status' to check everything."))
 	return nil
 }
@@ -237,7 +447,7 @@ func setupTLSStep(logger *zap.Logger, tlsEnabled bool, deps SetupDeps) error {
 	return nil
 }
 
-func setupRegistryStep(logger *zap.Logger, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, registryType, registryStorageSize, registryManifest string, tlsEnabled bool, deps SetupDeps) error {
+func setupRegistryStep(logger *zap.Logger, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, registryType, registryStorageSize, registryManifest string, tlsEnabled bool, offlineImages []offlineImage, deps SetupDeps) error {
 	// Step 4: Deploy internal container registry
 	Step("Step 4: Configure registry")
 	if usingExternalRegistry {
@@ -260,7 +470,7 @@ func setupRegistryStep(logger *zap.Logger, extRegistry *ExternalRegistryConfig,
 	} else {
 		Info("TLS: disabled (dev HTTP mode)")
 	}
-	if err := deps.DeployRegistry(logger, "registry", deps.GetRegistryPort(), registryType, registryStorageSize, registryManifest); err != nil {
+	if err := deps.DeployRegistry(logger, "registry", deps.GetRegistryPort(), registryType, registryStorageSize, registryManifest, offlineImages); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrDeployRegistryFailed,
 			err,
@@ -303,7 +513,7 @@ func setupRegistryStep(logger *zap.Logger, extRegistry *ExternalRegistryConfig,
 	return nil
 }
 
-func prepareOperatorImage(logger *zap.Logger, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, deps SetupDeps) (string, error) {
+func prepareOperatorImage(logger *zap.Logger, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, sbom SBOMOptions, deps SetupDeps) (string, error) {
 	// Step 5: Deploy operator
 	Step("Step 5: Deploy operator")
 
@@ -326,14 +536,33 @@ func prepareOperatorImage(logger *zap.Logger, extRegistry *ExternalRegistryConfi
 		return "", wrappedErr
 	}
 
+	if sbom.Enabled {
+		if err := produceSBOM(logger, operatorImage, sbom, deps.GenerateSBOM); err != nil {
+			return "", err
+		}
+	}
+
 	if usingExternalRegistry {
 		Info("Pushing operator image to external registry")
 		if err := deps.PushOperatorImage(operatorImage); err != nil {
 			Warn(fmt.Sprintf("Could not push image to external registry: %v", err))
+			if sbom.Attach {
+				Warn("Skipping SBOM attach because the image was not pushed")
+			}
+			return operatorImage, nil
+		}
+		if sbom.Attach {
+			if err := publishSBOM(logger, operatorImage, sbom, deps.AttachSBOM); err != nil {
+				return "", err
+			}
 		}
 		return operatorImage, nil
 	}
 
+	if sbom.Attach {
+		Warn(fmt.Sprintf("SBOM attach needs a registry reachable from this machine; SBOM kept at %s", sbom.Output))
+	}
+
 	Info("Pushing operator image to internal registry")
 	internalRegistryURL := deps.GetPlatformRegistryURL(logger)
 	internalOperatorImage := internalRegistryURL + "/mcp-runtime-operator:latest"
@@ -370,9 +599,9 @@ func prepareOperatorImage(logger *zap.Logger, extRegistry *ExternalRegistryConfi
 	return internalOperatorImage, nil
 }
 
-func deployOperatorStep(logger *zap.Logger, operatorImage string, extRegistry *ExternalRegistryConfig, registrySecretName string, usingExternalRegistry bool, deps SetupDeps) error {
+func deployOperatorStep(logger *zap.Logger, operatorImage string, replicas int, options OperatorDeployOptions, extRegistry *ExternalRegistryConfig, registrySecretName string, usingExternalRegistry bool, deps SetupDeps) error {
 	Info("Deploying operator manifests")
-	if err := deps.DeployOperatorManifests(logger, operatorImage); err != nil {
+	if err := deps.DeployOperatorManifests(logger, operatorImage, replicas, options); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrOperatorDeploymentFailed,
 			err,
@@ -419,7 +648,7 @@ func deployOperatorStep(logger *zap.Logger, operatorImage string, extRegistry *E
 	return nil
 }
 
-func verifySetup(usingExternalRegistry bool, deps SetupDeps) error {
+func verifySetup(usingExternalRegistry bool, operatorReplicas int, deps SetupDeps) error {
 	Step("Step 6: Verify platform components")
 
 	if usingExternalRegistry {
@@ -454,6 +683,14 @@ func verifySetup(usingExternalRegistry bool, deps SetupDeps) error {
 		return wrappedErr
 	}
 
+	if operatorReplicas > 1 {
+		Info("Verifying operator leader failover")
+		if err := deps.VerifyOperatorFailover(nil, deps.GetDeploymentTimeout()); err != nil {
+			// Note: logger not available in verifySetup, but error will be logged by caller
+			return err
+		}
+	}
+
 	Info("Checking MCPServer CRD presence")
 	if err := deps.CheckCRDInstalled("mcpservers.mcpruntime.org"); err != nil {
 		wrappedErr := wrapWithSentinel(ErrCRDCheckFailed, err, fmt.Sprintf("CRD check failed: %v", err))
@@ -473,7 +710,7 @@ func getOperatorImage(ext *ExternalRegistryConfig) string {
 	}
 
 	if ext != nil && ext.URL != "" {
-		return strings.TrimSuffix(ext.URL, "/") + "/mcp-runtime-operator:latest"
+		return ext.Repository() + "/mcp-runtime-operator:latest"
 	}
 	// Fallback to an internal-cluster reachable URL (resolved via ClusterIP).
 	return fmt.Sprintf("%s/mcp-runtime-operator:latest", getPlatformRegistryURL(nil))
@@ -495,13 +732,15 @@ func configureProvisionedRegistryEnvWithKubectl(kubectl KubectlRunner, ext *Exte
 		"set", "env", "deployment/mcp-runtime-operator-controller-manager",
 		"-n", "mcp-runtime",
 		"PROVISIONED_REGISTRY_URL=" + ext.URL,
+		// Always set so removing the prefix from the config also clears it on the operator.
+		"PROVISIONED_REGISTRY_PATH_PREFIX=" + ext.PathPrefix,
 	}
 	if hasCreds {
 		if err := ensureProvisionedRegistrySecretWithKubectl(kubectl, secretName, ext.Username, ext.Password); err != nil {
 			return err
 		}
-		// Create imagePullSecret in mcp-servers namespace for pod image pulls.
-		if err := ensureImagePullSecretWithKubectl(kubectl, NamespaceMCPServers, secretName, ext.URL, ext.Username, ext.Password); err != nil {
+		// Create imagePullSecret in the servers namespace for pod image pulls.
+		if err := ensureImagePullSecretWithKubectl(kubectl, serverNamespace(), secretName, ext.URL, ext.Username, ext.Password); err != nil {
 			return err
 		}
 		args = append(args, "PROVISIONED_REGISTRY_SECRET_NAME="+secretName)
@@ -509,7 +748,7 @@ func configureProvisionedRegistryEnvWithKubectl(kubectl KubectlRunner, ext *Exte
 		args = append(args, "--from=secret/"+secretName)
 	}
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	return kubectl.RunWithOutput(args, os.Stdout, os.Stderr)
+	return kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
 
 func ensureProvisionedRegistrySecretWithKubectl(kubectl KubectlRunner, name, username, password string) error {
@@ -542,7 +781,7 @@ func ensureProvisionedRegistrySecretWithKubectl(kubectl KubectlRunner, name, use
 	createCmd.SetStdin(strings.NewReader(envData.String()))
 	var rendered bytes.Buffer
 	createCmd.SetStdout(&rendered)
-	createCmd.SetStderr(os.Stderr)
+	createCmd.SetStderr(DefaultPrinter.Stderr())
 	if err := createCmd.Run(); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrRenderSecretManifestFailed,
@@ -561,8 +800,8 @@ func ensureProvisionedRegistrySecretWithKubectl(kubectl KubectlRunner, name, use
 		return err
 	}
 	applyCmd.SetStdin(&rendered)
-	applyCmd.SetStdout(os.Stdout)
-	applyCmd.SetStderr(os.Stderr)
+	applyCmd.SetStdout(DefaultPrinter.Stdout())
+	applyCmd.SetStderr(DefaultPrinter.Stderr())
 	if err := applyCmd.Run(); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrApplySecretManifestFailed,
@@ -622,8 +861,8 @@ data:
 		return err
 	}
 	applyCmd.SetStdin(strings.NewReader(secretManifest))
-	applyCmd.SetStdout(os.Stdout)
-	applyCmd.SetStderr(os.Stderr)
+	applyCmd.SetStdout(DefaultPrinter.Stdout())
+	applyCmd.SetStderr(DefaultPrinter.Stderr())
 	if err := applyCmd.Run(); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrApplyImagePullSecretFailed,
@@ -641,13 +880,15 @@ data:
 
 func buildOperatorImage(image string) error {
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	cmd, err := execCommandWithValidators("make", []string{"-f", "Makefile.operator", "docker-build-operator", "IMG=" + image})
+	args := []string{"-f", "Makefile.operator", "docker-build-operator", "IMG=" + image, "CONTAINER_TOOL=" + containerTool()}
+	if buildArgs := proxyBuildArgs(); len(buildArgs) > 0 {
+		args = append(args, "BUILD_ARGS="+strings.Join(buildArgs, " "))
+	}
+	cmd, err := execCommandWithValidators("make", args)
 	if err != nil {
 		return err
 	}
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
-	return cmd.Run()
+	return runStep("build", cmd)
 }
 
 func restartDeployment(name, namespace string) error {
@@ -657,23 +898,25 @@ func restartDeployment(name, namespace string) error {
 
 func restartDeploymentWithKubectl(kubectl KubectlRunner, name, namespace string) error {
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	return kubectl.RunWithOutput([]string{"rollout", "restart", "deployment/" + name, "-n", namespace}, os.Stdout, os.Stderr)
+	return kubectl.RunWithOutput([]string{"rollout", "restart", "deployment/" + name, "-n", namespace}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
 
 func pushOperatorImage(image string) error {
 	// #nosec G204 -- image from internal build process or validated config.
-	cmd, err := execCommandWithValidators("docker", []string{"push", image})
+	cmd, err := execCommandWithValidators(containerTool(), []string{"push", image})
 	if err != nil {
 		return err
 	}
-	cmd.SetStdout(os.Stdout)
-	cmd.SetStderr(os.Stderr)
-	return cmd.Run()
+	return runStep("push", cmd)
 }
 
 func pushOperatorImageToInternalRegistry(logger *zap.Logger, sourceImage, targetImage, helperNamespace string) error {
 	mgr := DefaultRegistryManager(logger)
-	if err := mgr.PushInCluster(sourceImage, targetImage, helperNamespace); err != nil {
+	push := mgr.PushInCluster
+	if isLocalRegistryImage(kubectlClient, targetImage) {
+		push = func(source, target, _ string) error { return mgr.PushDirect(source, target) }
+	}
+	if err := push(sourceImage, targetImage, helperNamespace); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrPushImageInClusterFailed,
 			err,
@@ -694,7 +937,7 @@ func checkCRDInstalled(name string) error {
 
 func checkCRDInstalledWithKubectl(kubectl KubectlRunner, name string) error {
 	// #nosec G204 -- name is hardcoded CRD identifier from internal code.
-	return kubectl.RunWithOutput([]string{"get", "crd", name}, os.Stdout, os.Stderr)
+	return kubectl.RunWithOutput([]string{"get", "crd", name}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
 
 // waitForDeploymentAvailable polls a deployment until it has at least one available replica or times out.
@@ -733,7 +976,9 @@ func waitForDeploymentAvailableWithKubectl(kubectl KubectlRunner, logger *zap.Lo
 			}
 			return err
 		}
-		time.Sleep(5 * time.Second)
+		if err := sleepContext(commandContext(), 5*time.Second); err != nil {
+			return wrapWithSentinel(ErrCommandCanceled, err, fmt.Sprintf("interrupted while waiting for deployment %s in namespace %s", name, namespace))
+		}
 	}
 }
 
@@ -746,22 +991,22 @@ func printDeploymentDiagnostics(deploy, namespace, selector string) {
 func printDeploymentDiagnosticsWithKubectl(kubectl KubectlRunner, deploy, namespace, selector string) {
 	Warn(fmt.Sprintf("Deployment %s in %s is not ready. Showing pod statuses:", deploy, namespace))
 	// #nosec G204 -- namespace/selector from internal diagnostics, not user input.
-	_ = kubectl.RunWithOutput([]string{"get", "pods", "-n", namespace, "-l", selector, "-o", "wide"}, os.Stdout, os.Stderr)
+	_ = kubectl.RunWithOutput([]string{"get", "pods", "-n", namespace, "-l", selector, "-o", "wide"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
 }
 
 // deployOperatorManifests deploys operator manifests without requiring kustomize or controller-gen.
 // It applies CRD, RBAC, and manager manifests directly, replacing the image name in the process.
-func deployOperatorManifests(logger *zap.Logger, operatorImage string) error {
-	return deployOperatorManifestsWithKubectl(kubectlClient, logger, operatorImage)
+func deployOperatorManifests(logger *zap.Logger, operatorImage string, replicas int, options OperatorDeployOptions) error {
+	return deployOperatorManifestsWithKubectl(kubectlClient, logger, operatorImage, replicas, options)
 }
 
 // deployOperatorManifestsWithKubectl deploys operator manifests without requiring kustomize or controller-gen.
 // It applies CRD, RBAC, and manager manifests directly, replacing the image name in the process.
-func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logger, operatorImage string) error {
+func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logger, operatorImage string, replicas int, options OperatorDeployOptions) error {
 	// Step 1: Apply CRD
 	Info("Applying CRD manifests")
 	// #nosec G204 -- fixed file path from repository.
-	if err := kubectl.RunWithOutput([]string{"apply", "--validate=false", "-f", "config/crd/bases/mcpruntime.org_mcpservers.yaml"}, os.Stdout, os.Stderr); err != nil {
+	if err := kubectl.RunWithOutput([]string{"apply", "--validate=false", "-f", "config/crd/bases"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinel(ErrApplyCRDFailed, err, fmt.Sprintf("failed to apply CRD: %v", err))
 		Error("Failed to apply CRD")
 		if logger != nil {
@@ -769,6 +1014,7 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 		}
 		return wrappedErr
 	}
+	applyDeleteProtectionPolicy(kubectl, logger)
 
 	// Step 2: Apply RBAC (ServiceAccount, Role, RoleBinding)
 	Info("Applying RBAC manifests")
@@ -787,7 +1033,7 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 	}
 
 	// #nosec G204 -- fixed kustomize path from repository.
-	if err := kubectl.RunWithOutput([]string{"apply", "-k", "config/rbac/"}, os.Stdout, os.Stderr); err != nil {
+	if err := kubectl.RunWithOutput([]string{"apply", "-k", "config/rbac/"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinel(ErrApplyRBACFailed, err, fmt.Sprintf("failed to apply RBAC: %v", err))
 		Error("Failed to apply RBAC")
 		if logger != nil {
@@ -798,6 +1044,16 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 
 	// Step 3: Apply manager deployment with image replacement
 	Info("Applying operator deployment")
+	if options.Manifest != "" {
+		if err := applyOperatorKustomization(kubectl, logger, operatorImage, replicas, options); err != nil {
+			return err
+		}
+		if err := applyOperatorPDB(kubectl, logger, replicas); err != nil {
+			return err
+		}
+		Success("Operator manifests deployed successfully")
+		return nil
+	}
 	// Read manager.yaml, replace image, and apply
 	managerYAML, err := os.ReadFile("config/manager/manager.yaml")
 	if err != nil {
@@ -809,13 +1065,12 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 		return wrappedErr
 	}
 
-	// Replace image name using a broad regex with captured indentation to handle registry-customized image values.
-	// This targets the first image field in the file (the manager container).
-	re := regexp.MustCompile(`(?m)^(\s*)image:\s*\S+`)
-	managerYAMLStr := re.ReplaceAllString(string(managerYAML), fmt.Sprintf("${1}image: %s", operatorImage))
+	// Set image and replicas; leader election stays on for HA deployments.
+	managerYAMLStr := renderManagerManifest(string(managerYAML), operatorImage, replicas)
+	managerYAMLStr = renderManagerOptions(managerYAMLStr, options)
 
 	// Write to temp file under the working directory so kubectl path validation passes.
-	tmpFile, err := os.CreateTemp(".", "manager-*.yaml")
+	tmpFile, err := tempManagerManifest.create()
 	if err != nil {
 		wrappedErr := wrapWithSentinel(ErrCreateTempFileFailed, err, fmt.Sprintf("failed to create temp file: %v", err))
 		Error("Failed to create temp file")
@@ -824,7 +1079,7 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 		}
 		return wrappedErr
 	}
-	defer os.Remove(tmpFile.Name())
+	defer removeTempFile(tmpFile.Name())
 
 	if _, err := tmpFile.WriteString(managerYAMLStr); err != nil {
 		if closeErr := tmpFile.Close(); closeErr != nil {
@@ -856,7 +1111,7 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 	_ = kubectl.Run([]string{"delete", "deployment/" + OperatorDeploymentName, "-n", NamespaceMCPRuntime, "--ignore-not-found"})
 
 	// #nosec G204 -- command arguments are built from trusted inputs and fixed verbs.
-	if err := kubectl.RunWithOutput([]string{"apply", "-f", tmpFile.Name()}, os.Stdout, os.Stderr); err != nil {
+	if err := kubectl.RunWithOutput([]string{"apply", "-f", tmpFile.Name()}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
 		wrappedErr := wrapWithSentinelAndContext(
 			ErrApplyManagerDeploymentFailed,
 			err,
@@ -870,19 +1125,18 @@ func deployOperatorManifestsWithKubectl(kubectl KubectlRunner, logger *zap.Logge
 		return wrappedErr
 	}
 
+	if err := applyOperatorPDB(kubectl, logger, replicas); err != nil {
+		return err
+	}
+
 	Success("Operator manifests deployed successfully")
 	return nil
 }
 
-// setupTLS configures TLS by applying cert-manager resources.
-// Prerequisites: cert-manager must be installed and CA secret must exist.
-func setupTLS(logger *zap.Logger) error {
-	return setupTLSWithKubectl(kubectlClient, logger)
-}
-
-// setupTLSWithKubectl configures TLS by applying cert-manager resources.
+// setupTLSWithKubectl configures TLS by applying cert-manager resources and waits up to
+// certTimeout for the registry certificate.
 // Prerequisites: cert-manager must be installed and CA secret must exist.
-func setupTLSWithKubectl(kubectl KubectlRunner, logger *zap.Logger) error {
+func setupTLSWithKubectl(kubectl KubectlRunner, logger *zap.Logger, certTimeout time.Duration) error {
 	// Check if cert-manager CRDs are installed
 	Info("Checking cert-manager installation")
 	if err := checkCertManagerInstalledWithKubectl(kubectl); err != nil {
@@ -950,7 +1204,6 @@ func setupTLSWithKubectl(kubectl KubectlRunner, logger *zap.Logger) error {
 	}
 
 	// Wait for certificate to be ready using kubectl wait
-	certTimeout := GetCertTimeout()
 	Info(fmt.Sprintf("Waiting for certificate to be issued (timeout: %s)", certTimeout))
 	if err := waitForCertificateReadyWithKubectl(kubectl, registryCertificateName, NamespaceRegistry, certTimeout); err != nil {
 		err := newWithSentinel(ErrCertificateNotReady, fmt.Sprintf("certificate not ready after %s. Check cert-manager logs: kubectl logs -n cert-manager deployment/cert-manager", certTimeout))
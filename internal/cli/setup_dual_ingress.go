@@ -0,0 +1,68 @@
+package cli
+
+// This file implements dual ingress for setup. "setup --dual-ingress" installs Traefik with
+// plain HTTP on the web entrypoint and TLS on websecure side by side, and tells the operator to
+// route MCPServers through both unless they set spec.tlsOnly.
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Ingress overlays chosen by setup when --ingress-manifest is not given.
+const (
+	ingressManifestHTTP = "config/ingress/overlays/http"
+	ingressManifestTLS  = "config/ingress/overlays/prod"
+	ingressManifestDual = "config/ingress/overlays/dual"
+)
+
+// resolveIngressManifest picks the ingress overlay by priority: an explicit
+// --ingress-manifest, then dual ingress, then TLS, then plain HTTP.
+func resolveIngressManifest(input SetupPlanInput) string {
+	switch {
+	case input.IngressManifestChanged:
+		return input.IngressManifest
+	case input.DualIngress:
+		return ingressManifestDual
+	case input.TLSEnabled:
+		return ingressManifestTLS
+	default:
+		return ingressManifestHTTP
+	}
+}
+
+type dualIngressStep struct{}
+
+func (s dualIngressStep) Name() string { return "dual-ingress" }
+func (s dualIngressStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupDualIngressStep(logger, deps)
+}
+
+func setupDualIngressStep(logger *zap.Logger, deps SetupDeps) error {
+	// Step 5c: Route MCP servers through both ingress entrypoints
+	Step("Step 5c: Enable dual HTTP and TLS ingress")
+	if err := deps.ConfigureDualIngress(); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrConfigureDualIngressFailed,
+			err,
+			fmt.Sprintf("failed to enable dual ingress on the operator: %v", err),
+			map[string]any{"deployment": OperatorDeploymentName, "namespace": NamespaceMCPRuntime, "component": "setup"},
+		)
+		Error("Failed to enable dual ingress")
+		logStructuredError(logger, wrappedErr, "Failed to enable dual ingress")
+		return wrappedErr
+	}
+	Success("MCP servers are exposed over HTTP and HTTPS; set spec.tlsOnly to serve a server over HTTPS only")
+	return nil
+}
+
+func configureDualIngress() error {
+	return configureDualIngressWithKubectl(kubectlClient)
+}
+
+// configureDualIngressWithKubectl switches the operator to dual entrypoints; status URLs use https.
+func configureDualIngressWithKubectl(kubectl KubectlRunner) error {
+	// #nosec G204 -- fixed kubectl set env arguments.
+	return kubectl.RunWithOutput([]string{"set", "env", "deployment/" + OperatorDeploymentName, "-n", NamespaceMCPRuntime, "MCP_INGRESS_DUAL=true", "MCP_INGRESS_TLS=true"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+}
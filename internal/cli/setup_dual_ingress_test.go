@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestBuildSetupStepsDualIngress(t *testing.T) {
+	var names []string
+	for _, step := range buildSetupSteps(&SetupContext{Plan: SetupPlan{DualIngress: true}}) {
+		names = append(names, step.Name())
+	}
+	if got := strings.Join(names, ","); !strings.Contains(got, "operator-deploy,dual-ingress,verify") {
+		t.Fatalf("expected dual-ingress between operator deploy and verify, got %s", got)
+	}
+
+	for _, step := range buildSetupSteps(&SetupContext{}) {
+		if step.Name() == "dual-ingress" {
+			t.Fatal("dual-ingress step should be opt-in")
+		}
+	}
+}
+
+func TestDualIngressStep(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	called := false
+	deps := SetupDeps{ConfigureDualIngress: func() error { called = true; return nil }}
+	if err := (dualIngressStep{}).Run(zap.NewNop(), deps, &SetupContext{}); err != nil || !called {
+		t.Fatalf("expected dual ingress to be configured, called=%v err=%v", called, err)
+	}
+
+	deps.ConfigureDualIngress = func() error { return errors.New("forbidden") }
+	if err := (dualIngressStep{}).Run(zap.NewNop(), deps, &SetupContext{}); !errors.Is(err, ErrConfigureDualIngressFailed) {
+		t.Fatalf("expected ErrConfigureDualIngressFailed, got %v", err)
+	}
+}
+
+func TestConfigureDualIngressWithKubectl(t *testing.T) {
+	mock := &MockExecutor{}
+	if err := configureDualIngressWithKubectl(&KubectlClient{exec: mock}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Commands) != 1 {
+		t.Fatalf("expected one kubectl call, got %d", len(mock.Commands))
+	}
+	args := strings.Join(mock.Commands[0].Args, " ")
+	for _, want := range []string{"set env deployment/" + OperatorDeploymentName, "MCP_INGRESS_DUAL=true", "MCP_INGRESS_TLS=true"} {
+		if !strings.Contains(args, want) {
+			t.Fatalf("expected %q in %q", want, args)
+		}
+	}
+}
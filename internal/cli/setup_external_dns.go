@@ -0,0 +1,148 @@
+package cli
+
+// This file implements the optional external-dns step of setup.
+// "setup --with-external-dns" applies config/external-dns and points it at the selected
+// DNS provider, so MCPServers with spec.externalDNS.enabled get records for their ingress host.
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// externalDNSManifest is the kustomize directory of the external-dns deployment.
+	externalDNSManifest = "config/external-dns"
+
+	// externalDNSDeploymentName is the external-dns Deployment (and its app label).
+	externalDNSDeploymentName = "external-dns"
+
+	// externalDNSAnnotationFilter limits the ingress source to the Ingresses the operator
+	// marks for servers with spec.externalDNS enabled (operator.AnnotationExternalDNS).
+	externalDNSAnnotationFilter = "mcpruntime.org/external-dns=true"
+)
+
+// externalDNSProviders lists the external-dns providers setup can configure.
+var externalDNSProviders = []string{
+	"aws", "azure", "azure-private-dns", "cloudflare", "digitalocean",
+	"google", "linode", "oci", "ovh", "pdns", "rfc2136",
+}
+
+// ExternalDNSOptions configures the optional external-dns deployment.
+type ExternalDNSOptions struct {
+	Enabled  bool
+	Provider string
+	// DomainFilter limits external-dns to records under these domains.
+	DomainFilter []string
+}
+
+// Validate checks the provider when external-dns is enabled.
+func (o ExternalDNSOptions) Validate() error {
+	if !o.Enabled {
+		return nil
+	}
+	if !slices.Contains(externalDNSProviders, o.Provider) {
+		return newWithSentinel(ErrInvalidExternalDNS, fmt.Sprintf("unsupported external-dns provider %q (use one of: %s)", o.Provider, strings.Join(externalDNSProviders, ", ")))
+	}
+	return nil
+}
+
+// addExternalDNSFlags registers the --with-external-dns flags on the setup command.
+func addExternalDNSFlags(cmd *cobra.Command, opts *ExternalDNSOptions) {
+	cmd.Flags().BoolVar(&opts.Enabled, "with-external-dns", false, "Deploy external-dns so ingress hosts of MCPServers with spec.externalDNS get DNS records")
+	cmd.Flags().StringVar(&opts.Provider, "external-dns-provider", "aws", "DNS provider for external-dns ("+strings.Join(externalDNSProviders, "|")+")")
+	cmd.Flags().StringSliceVar(&opts.DomainFilter, "external-dns-domain", nil, "Limit external-dns to these domains (repeatable)")
+}
+
+// externalDNSArgs returns the external-dns container arguments for opts.
+func externalDNSArgs(opts ExternalDNSOptions) []string {
+	args := []string{
+		"--source=ingress",
+		"--annotation-filter=" + externalDNSAnnotationFilter,
+		"--provider=" + opts.Provider,
+		"--policy=upsert-only",
+		"--registry=txt",
+		"--txt-owner-id=mcp-runtime",
+	}
+	for _, domain := range opts.DomainFilter {
+		args = append(args, "--domain-filter="+domain)
+	}
+	return args
+}
+
+type externalDNSStep struct{}
+
+func (s externalDNSStep) Name() string { return "external-dns" }
+func (s externalDNSStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupExternalDNSStep(logger, deps, ctx.Plan.ExternalDNS, ctx.OfflineImages)
+}
+
+func setupExternalDNSStep(logger *zap.Logger, deps SetupDeps, opts ExternalDNSOptions, offlineImages []offlineImage) error {
+	// Step 8: Install external-dns (if enabled)
+	Step("Step 8: Install external-dns")
+	if err := deps.DeployExternalDNS(logger, opts, offlineImages); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrDeployExternalDNSFailed,
+			err,
+			fmt.Sprintf("failed to deploy external-dns: %v", err),
+			map[string]any{"manifest": externalDNSManifest, "provider": opts.Provider, "component": "setup"},
+		)
+		Error("external-dns deployment failed")
+		logStructuredError(logger, wrappedErr, "external-dns deployment failed")
+		return wrappedErr
+	}
+
+	Info("Waiting for external-dns deployment to be available")
+	selector := "app=" + externalDNSDeploymentName
+	if err := deps.WaitForDeploymentAvailable(logger, externalDNSDeploymentName, NamespaceExternalDNS, selector, deps.GetDeploymentTimeout()); err != nil {
+		deps.PrintDeploymentDiagnostics(externalDNSDeploymentName, NamespaceExternalDNS, selector)
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrExternalDNSNotReady,
+			err,
+			fmt.Sprintf("external-dns not ready: %v", err),
+			map[string]any{"deployment": externalDNSDeploymentName, "namespace": NamespaceExternalDNS, "component": "setup"},
+		)
+		Error("external-dns not ready")
+		logStructuredError(logger, wrappedErr, "external-dns not ready")
+		return wrappedErr
+	}
+
+	Success(fmt.Sprintf("external-dns installed (provider %s)", opts.Provider))
+	Info(fmt.Sprintf("Provider credentials are read from the optional secret external-dns-credentials in namespace %s", NamespaceExternalDNS))
+	return nil
+}
+
+func deployExternalDNS(logger *zap.Logger, opts ExternalDNSOptions, offlineImages []offlineImage) error {
+	return deployExternalDNSWithKubectl(kubectlClient, opts, offlineImages)
+}
+
+// deployExternalDNSWithKubectl applies the external-dns manifests and replaces the
+// container arguments with the ones for the selected provider.
+func deployExternalDNSWithKubectl(kubectl KubectlRunner, opts ExternalDNSOptions, offlineImages []offlineImage) error {
+	Info("Applying external-dns manifests")
+	args, cleanup, err := platformApplyArgs(externalDNSManifest, true, offlineImages)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	// #nosec G204 -- fixed kustomize path from repository.
+	if err := kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal([]map[string]any{{
+		"op":    "replace",
+		"path":  "/spec/template/spec/containers/0/args",
+		"value": externalDNSArgs(opts),
+	}})
+	if err != nil {
+		return err
+	}
+	Info(fmt.Sprintf("Configuring external-dns for provider %s", opts.Provider))
+	// #nosec G204 -- fixed deployment; patch built from validated provider and domain flags.
+	return kubectl.RunWithOutput([]string{"patch", "deployment", externalDNSDeploymentName, "-n", NamespaceExternalDNS, "--type=json", "-p", string(patch)}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+}
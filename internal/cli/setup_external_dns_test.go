@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestExternalDNSOptionsValidate(t *testing.T) {
+	if err := (ExternalDNSOptions{Provider: "bogus"}).Validate(); err != nil {
+		t.Fatalf("disabled options should validate, got %v", err)
+	}
+	if err := (ExternalDNSOptions{Enabled: true, Provider: "cloudflare"}).Validate(); err != nil {
+		t.Fatalf("cloudflare should validate, got %v", err)
+	}
+	if err := (ExternalDNSOptions{Enabled: true, Provider: "bogus"}).Validate(); !errors.Is(err, ErrInvalidExternalDNS) {
+		t.Fatalf("expected ErrInvalidExternalDNS, got %v", err)
+	}
+}
+
+func TestBuildSetupStepsWithExternalDNS(t *testing.T) {
+	steps := buildSetupSteps(&SetupContext{Plan: SetupPlan{ExternalDNS: ExternalDNSOptions{Enabled: true}}})
+	if got := steps[len(steps)-1].Name(); got != "external-dns" {
+		t.Fatalf("expected external-dns to run last, got %q", got)
+	}
+
+	for _, step := range buildSetupSteps(&SetupContext{}) {
+		if step.Name() == "external-dns" {
+			t.Fatal("external-dns step should be opt-in")
+		}
+	}
+}
+
+func TestExternalDNSStep(t *testing.T) {
+	opts := ExternalDNSOptions{Enabled: true, Provider: "google"}
+	var deployed ExternalDNSOptions
+	deps := SetupDeps{
+		DeployExternalDNS: func(_ *zap.Logger, o ExternalDNSOptions, _ []offlineImage) error { deployed = o; return nil },
+		WaitForDeploymentAvailable: func(_ *zap.Logger, name, namespace, selector string, _ time.Duration) error {
+			if name != "external-dns" || namespace != NamespaceExternalDNS || selector != "app=external-dns" {
+				t.Fatalf("unexpected wait %s/%s %s", namespace, name, selector)
+			}
+			return nil
+		},
+		PrintDeploymentDiagnostics: func(_, _, _ string) {},
+		GetDeploymentTimeout:       func() time.Duration { return time.Second },
+	}
+	ctx := &SetupContext{Plan: SetupPlan{ExternalDNS: opts}}
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := (externalDNSStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("external-dns step failed: %v", err)
+	}
+	if deployed.Provider != "google" {
+		t.Fatalf("expected provider to be passed through, got %+v", deployed)
+	}
+
+	deps.DeployExternalDNS = func(*zap.Logger, ExternalDNSOptions, []offlineImage) error { return errors.New("apply failed") }
+	if err := (externalDNSStep{}).Run(zap.NewNop(), deps, ctx); !errors.Is(err, ErrDeployExternalDNSFailed) {
+		t.Fatalf("expected ErrDeployExternalDNSFailed, got %v", err)
+	}
+
+	deps.DeployExternalDNS = func(*zap.Logger, ExternalDNSOptions, []offlineImage) error { return nil }
+	deps.WaitForDeploymentAvailable = func(*zap.Logger, string, string, string, time.Duration) error { return errors.New("timed out") }
+	if err := (externalDNSStep{}).Run(zap.NewNop(), deps, ctx); !errors.Is(err, ErrExternalDNSNotReady) {
+		t.Fatalf("expected ErrExternalDNSNotReady, got %v", err)
+	}
+}
+
+func TestDeployExternalDNSWithKubectl(t *testing.T) {
+	mock := &MockExecutor{}
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	opts := ExternalDNSOptions{Enabled: true, Provider: "cloudflare", DomainFilter: []string{"example.com"}}
+	if err := deployExternalDNSWithKubectl(&KubectlClient{exec: mock}, opts, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Commands) != 2 {
+		t.Fatalf("expected apply and patch, got %v", mock.Commands)
+	}
+	if got := strings.Join(mock.Commands[0].Args, " "); got != "apply -k config/external-dns" {
+		t.Fatalf("unexpected first command %q", got)
+	}
+	patch := mock.Commands[1].Args
+	if strings.Join(patch[:6], " ") != "patch deployment external-dns -n external-dns --type=json" {
+		t.Fatalf("unexpected patch command %v", patch)
+	}
+	body := patch[len(patch)-1]
+	if !strings.Contains(body, `"--provider=cloudflare"`) || !strings.Contains(body, `"--domain-filter=example.com"`) {
+		t.Fatalf("unexpected patch body %s", body)
+	}
+}
+
+func TestExternalDNSArgsFilterServerIngresses(t *testing.T) {
+	args := externalDNSArgs(ExternalDNSOptions{Enabled: true, Provider: "aws"})
+	if args[0] != "--source=ingress" || args[1] != "--annotation-filter=mcpruntime.org/external-dns=true" {
+		t.Fatalf("expected the ingress source to be limited to MCP server Ingresses, got %v", args)
+	}
+
+	data, err := os.ReadFile("../../config/external-dns/external-dns.yaml")
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if !strings.Contains(string(data), "- --annotation-filter="+externalDNSAnnotationFilter+"\n") {
+		t.Fatal("expected config/external-dns to filter on the MCP server annotation")
+	}
+}
@@ -51,6 +51,15 @@ func TestGetOperatorImage(t *testing.T) {
 		}
 	})
 
+	t.Run("uses external registry path prefix", func(t *testing.T) {
+		DefaultCLIConfig.OperatorImage = ""
+		ext := &ExternalRegistryConfig{URL: "harbor.example.com", PathPrefix: "mcp"}
+		got := getOperatorImage(ext)
+		if got != "harbor.example.com/mcp/mcp-runtime-operator:latest" {
+			t.Fatalf("unexpected external registry image: %q", got)
+		}
+	})
+
 	t.Run("uses platform registry URL when external not set", func(t *testing.T) {
 		DefaultCLIConfig.OperatorImage = ""
 		mock := &MockExecutor{
@@ -487,7 +496,7 @@ func TestDeployOperatorManifestsWithKubectl(t *testing.T) {
 	kubectlClient = kubectl
 
 	operatorImage := "registry.example.com/mcp-runtime-operator:dev"
-	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), operatorImage); err != nil {
+	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), operatorImage, DefaultOperatorReplicas, OperatorDeployOptions{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if managerManifest == "" {
@@ -505,7 +514,7 @@ func TestDeployOperatorManifestsWithKubectl(t *testing.T) {
 		hasNamespace    bool
 	)
 	for _, cmd := range mock.Commands {
-		if commandHasArgs(cmd, "apply", "--validate=false", "-f", "config/crd/bases/mcpruntime.org_mcpservers.yaml") {
+		if commandHasArgs(cmd, "apply", "--validate=false", "-f", "config/crd/bases") {
 			hasCRD = true
 		}
 		if commandHasArgs(cmd, "apply", "-k", "config/rbac/") {
@@ -534,7 +543,7 @@ func TestDeployOperatorManifestsWithKubectlCRDError(t *testing.T) {
 	mock := &MockExecutor{
 		CommandFunc: func(spec ExecSpec) *MockCommand {
 			cmd := &MockCommand{Args: spec.Args}
-			if commandHasArgs(spec, "apply", "--validate=false", "-f", "config/crd/bases/mcpruntime.org_mcpservers.yaml") {
+			if commandHasArgs(spec, "apply", "--validate=false", "-f", "config/crd/bases") {
 				cmd.RunErr = mockErr
 			}
 			return cmd
@@ -542,7 +551,7 @@ func TestDeployOperatorManifestsWithKubectlCRDError(t *testing.T) {
 	}
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 
-	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), "example"); err == nil {
+	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), "example", DefaultOperatorReplicas, OperatorDeployOptions{}); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -564,7 +573,7 @@ func TestDeployOperatorManifestsWithKubectlRBACError(t *testing.T) {
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 	kubectlClient = kubectl
 
-	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), "example"); err == nil {
+	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), "example", DefaultOperatorReplicas, OperatorDeployOptions{}); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -601,7 +610,7 @@ func TestDeployOperatorManifestsWithKubectlManagerApplyError(t *testing.T) {
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 	kubectlClient = kubectl
 
-	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), "example"); err == nil {
+	if err := deployOperatorManifestsWithKubectl(kubectl, zap.NewNop(), "example", DefaultOperatorReplicas, OperatorDeployOptions{}); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -614,7 +623,7 @@ func TestSetupTLSWithKubectl(t *testing.T) {
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 	kubectlClient = kubectl
 
-	if err := setupTLSWithKubectl(kubectl, zap.NewNop()); err != nil {
+	if err := setupTLSWithKubectl(kubectl, zap.NewNop(), GetCertTimeout()); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -664,7 +673,7 @@ func TestSetupTLSWithKubectlMissingCRD(t *testing.T) {
 	}
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 
-	if err := setupTLSWithKubectl(kubectl, zap.NewNop()); err == nil {
+	if err := setupTLSWithKubectl(kubectl, zap.NewNop(), GetCertTimeout()); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -681,7 +690,7 @@ func TestSetupTLSWithKubectlMissingSecret(t *testing.T) {
 	}
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 
-	if err := setupTLSWithKubectl(kubectl, zap.NewNop()); err == nil {
+	if err := setupTLSWithKubectl(kubectl, zap.NewNop(), GetCertTimeout()); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -702,7 +711,7 @@ func TestSetupTLSWithKubectlWaitError(t *testing.T) {
 	kubectl := &KubectlClient{exec: mock, validators: nil}
 	kubectlClient = kubectl
 
-	if err := setupTLSWithKubectl(kubectl, zap.NewNop()); err == nil {
+	if err := setupTLSWithKubectl(kubectl, zap.NewNop(), GetCertTimeout()); err == nil {
 		t.Fatal("expected error")
 	}
 }
@@ -0,0 +1,116 @@
+package cli
+
+// This file implements --service-ip-families for setup. Dual-stack and IPv6-only clusters
+// otherwise give MCP server Services the cluster's default single family. The pre-flight
+// checks that the cluster serves the requested families with a server-side dry-run Service,
+// and setup then sets them as the operator default (MCP_SERVICE_IP_FAMILIES), which servers
+// override with spec.ipFamilies and spec.ipFamilyPolicy.
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// serviceIPFamilyValues are the IP families a Service can request.
+var serviceIPFamilyValues = []string{"IPv4", "IPv6"}
+
+// preflightServiceName is the Service created with --dry-run=server to check IP families.
+const preflightServiceName = "mcp-runtime-preflight"
+
+// validateServiceIPFamilies checks --service-ip-families: IPv4, IPv6 or both, primary first.
+func validateServiceIPFamilies(families []string) error {
+	if len(families) > len(serviceIPFamilyValues) {
+		return newWithSentinel(ErrInvalidServiceIPFamilies, fmt.Sprintf("--service-ip-families takes at most two families, got %s", strings.Join(families, ",")))
+	}
+	for i, family := range families {
+		if !slices.Contains(serviceIPFamilyValues, family) {
+			return newWithSentinel(ErrInvalidServiceIPFamilies, fmt.Sprintf("unsupported IP family %q in --service-ip-families (use %s)", family, strings.Join(serviceIPFamilyValues, " or ")))
+		}
+		if slices.Contains(families[:i], family) {
+			return newWithSentinel(ErrInvalidServiceIPFamilies, fmt.Sprintf("--service-ip-families lists %s twice", family))
+		}
+	}
+	return nil
+}
+
+// renderPreflightService returns a Service requiring exactly families, for a dry run.
+func renderPreflightService(families []string) string {
+	policy := "SingleStack"
+	if len(families) > 1 {
+		policy = "RequireDualStack"
+	}
+	return fmt.Sprintf(`apiVersion: v1
+kind: Service
+metadata:
+  name: %s
+  namespace: default
+spec:
+  ipFamilyPolicy: %s
+  ipFamilies: [%s]
+  ports:
+  - port: 80
+`, preflightServiceName, policy, strings.Join(families, ", "))
+}
+
+// checkServiceIPFamilies verifies the cluster can allocate Service addresses in families by
+// creating a Service with --dry-run=server; the API server rejects families it is not
+// configured for.
+func checkServiceIPFamilies(kubectl KubectlRunner, families []string) preflightCheck {
+	check := preflightCheck{name: "Service IP families"}
+	// #nosec G204 -- fixed kubectl arguments; the manifest is rendered from validated families.
+	cmd, err := kubectl.CommandArgs([]string{"create", "-f", "-", "--dry-run=server", "-o", "name"})
+	if err == nil {
+		cmd.SetStdin(strings.NewReader(renderPreflightService(families)))
+		var out []byte
+		out, err = cmd.CombinedOutput()
+		if err != nil && len(strings.TrimSpace(string(out))) > 0 {
+			err = fmt.Errorf("%s", strings.TrimSpace(string(out)))
+		}
+	}
+	if err != nil {
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("the cluster cannot create %s Services (%v); enable dual-stack networking or drop --service-ip-families", strings.Join(families, "/"), err)
+		return check
+	}
+	check.result = preflightPass
+	check.detail = strings.Join(families, ", ")
+	return check
+}
+
+type serviceIPFamiliesStep struct{}
+
+func (s serviceIPFamiliesStep) Name() string { return "service-ip-families" }
+func (s serviceIPFamiliesStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupServiceIPFamiliesStep(logger, ctx.Plan.ServiceIPFamilies, deps)
+}
+
+func setupServiceIPFamiliesStep(logger *zap.Logger, families []string, deps SetupDeps) error {
+	// Step 5d: Default MCP server Services to the requested IP families
+	Step("Step 5d: Configure Service IP families")
+	if err := deps.ConfigureServiceIPFamilies(families); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrConfigureServiceIPFamiliesFailed,
+			err,
+			fmt.Sprintf("failed to set the Service IP families on the operator: %v", err),
+			map[string]any{"ip_families": families, "deployment": OperatorDeploymentName, "namespace": NamespaceMCPRuntime, "component": "setup"},
+		)
+		Error("Failed to configure Service IP families")
+		logStructuredError(logger, wrappedErr, "Failed to configure Service IP families")
+		return wrappedErr
+	}
+	Success(fmt.Sprintf("MCP server Services use %s; spec.ipFamilies overrides it per server", strings.Join(families, ", ")))
+	return nil
+}
+
+func configureServiceIPFamilies(families []string) error {
+	return configureServiceIPFamiliesWithKubectl(kubectlClient, families)
+}
+
+// configureServiceIPFamiliesWithKubectl sets the operator's default Service IP families.
+func configureServiceIPFamiliesWithKubectl(kubectl KubectlRunner, families []string) error {
+	// #nosec G204 -- families are validated against a fixed list.
+	return kubectl.RunWithOutput([]string{"set", "env", "deployment/" + OperatorDeploymentName, "-n", NamespaceMCPRuntime, "MCP_SERVICE_IP_FAMILIES=" + strings.Join(families, ",")}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+}
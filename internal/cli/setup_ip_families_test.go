@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestValidateServiceIPFamilies(t *testing.T) {
+	for _, families := range [][]string{nil, {"IPv6"}, {"IPv4", "IPv6"}, {"IPv6", "IPv4"}} {
+		if err := validateServiceIPFamilies(families); err != nil {
+			t.Errorf("validateServiceIPFamilies(%v) error: %v", families, err)
+		}
+	}
+	for _, families := range [][]string{{"ipv4"}, {"IPv4", "IPv4"}, {"IPv4", "IPv6", "IPv4"}} {
+		if err := validateServiceIPFamilies(families); !errors.Is(err, ErrInvalidServiceIPFamilies) {
+			t.Errorf("validateServiceIPFamilies(%v): expected ErrInvalidServiceIPFamilies, got %v", families, err)
+		}
+	}
+}
+
+func TestCheckServiceIPFamilies(t *testing.T) {
+	var last *MockCommand
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		last = &MockCommand{Args: spec.Args, OutputData: []byte("service/mcp-runtime-preflight")}
+		return last
+	}
+	kubectl := &KubectlClient{exec: mock}
+
+	check := checkServiceIPFamilies(kubectl, []string{"IPv4", "IPv6"})
+	if check.result != preflightPass {
+		t.Fatalf("expected pass, got %+v", check)
+	}
+	if !commandHasArgs(mock.LastCommand(), "create", "--dry-run=server") {
+		t.Fatalf("expected a server-side dry run, got %v", mock.LastCommand().Args)
+	}
+	data, err := io.ReadAll(last.StdinR)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	manifest := string(data)
+	for _, want := range []string{"ipFamilyPolicy: RequireDualStack", "ipFamilies: [IPv4, IPv6]", "namespace: default"} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("dry-run Service misses %q:\n%s", want, manifest)
+		}
+	}
+
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		return &MockCommand{
+			OutputData: []byte(`The Service "mcp-runtime-preflight" is invalid: spec.ipFamilyPolicy: Invalid value: "RequireDualStack": this cluster is not configured for dual-stack services`),
+			OutputErr:  errors.New("exit status 1"),
+		}
+	}
+	check = checkServiceIPFamilies(kubectl, []string{"IPv4", "IPv6"})
+	if check.result != preflightFail || !strings.Contains(check.detail, "not configured for dual-stack") {
+		t.Fatalf("expected a failure naming the API server error, got %+v", check)
+	}
+}
+
+func TestServiceIPFamiliesStep(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	var got []string
+	deps := SetupDeps{ConfigureServiceIPFamilies: func(families []string) error {
+		got = families
+		return nil
+	}}
+	ctx := &SetupContext{Plan: SetupPlan{ServiceIPFamilies: []string{"IPv6", "IPv4"}}}
+	if err := (serviceIPFamiliesStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if strings.Join(got, ",") != "IPv6,IPv4" {
+		t.Fatalf("unexpected families %v", got)
+	}
+
+	deps.ConfigureServiceIPFamilies = func([]string) error { return errors.New("not found") }
+	if err := (serviceIPFamiliesStep{}).Run(zap.NewNop(), deps, ctx); !errors.Is(err, ErrConfigureServiceIPFamiliesFailed) {
+		t.Fatalf("expected ErrConfigureServiceIPFamiliesFailed, got %v", err)
+	}
+
+	mock := &MockExecutor{}
+	if err := configureServiceIPFamiliesWithKubectl(&KubectlClient{exec: mock}, []string{"IPv4", "IPv6"}); err != nil {
+		t.Fatalf("configureServiceIPFamiliesWithKubectl() error: %v", err)
+	}
+	if !commandHasArgs(mock.LastCommand(), "set", "env", "MCP_SERVICE_IP_FAMILIES=IPv4,IPv6") {
+		t.Fatalf("unexpected command %v", mock.LastCommand().Args)
+	}
+	steps := buildSetupSteps(ctx)
+	found := false
+	for _, step := range steps {
+		found = found || step.Name() == "service-ip-families"
+	}
+	if !found {
+		t.Fatal("expected the service-ip-families step when families are requested")
+	}
+}
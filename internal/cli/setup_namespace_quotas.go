@@ -0,0 +1,46 @@
+package cli
+
+// This file implements --with-namespace-quotas for setup. It turns on the operator's
+// namespace quota enforcement (MCP_NAMESPACE_QUOTA), so every namespace with MCPServers gets
+// a ResourceQuota and LimitRange sized by the MCP_QUOTA_* defaults.
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+type namespaceQuotasStep struct{}
+
+func (s namespaceQuotasStep) Name() string { return "namespace-quotas" }
+func (s namespaceQuotasStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupNamespaceQuotasStep(logger, deps)
+}
+
+func setupNamespaceQuotasStep(logger *zap.Logger, deps SetupDeps) error {
+	// Step 5e: Enforce quotas in MCP server namespaces
+	Step("Step 5e: Enable namespace quotas")
+	if err := deps.ConfigureNamespaceQuotas(); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrConfigureNamespaceQuotasFailed,
+			err,
+			fmt.Sprintf("failed to enable namespace quotas on the operator: %v", err),
+			map[string]any{"deployment": OperatorDeploymentName, "namespace": NamespaceMCPRuntime, "component": "setup"},
+		)
+		Error("Failed to enable namespace quotas")
+		logStructuredError(logger, wrappedErr, "Failed to enable namespace quotas")
+		return wrappedErr
+	}
+	Success("Namespaces with MCP servers get a ResourceQuota and LimitRange; tune them with the operator's MCP_QUOTA_* variables")
+	return nil
+}
+
+func configureNamespaceQuotas() error {
+	return configureNamespaceQuotasWithKubectl(kubectlClient)
+}
+
+// configureNamespaceQuotasWithKubectl turns on quota enforcement in the operator.
+func configureNamespaceQuotasWithKubectl(kubectl KubectlRunner) error {
+	// #nosec G204 -- fixed kubectl set env arguments.
+	return kubectl.RunWithOutput([]string{"set", "env", "deployment/" + OperatorDeploymentName, "-n", NamespaceMCPRuntime, "MCP_NAMESPACE_QUOTA=true"}, DefaultPrinter.Stdout(), DefaultPrinter.Stderr())
+}
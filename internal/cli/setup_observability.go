@@ -0,0 +1,149 @@
+package cli
+
+// This file implements the optional observability step of setup.
+// "setup --with-observability" applies the bundled Prometheus and Grafana overlay from
+// config/observability, which scrapes the operator and kubelet and ships a pre-provisioned
+// MCP Runtime dashboard (operator reconciles, per-server readiness, registry storage usage).
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// observabilityManifest is the kustomize directory of the observability stack.
+	observabilityManifest = "config/observability"
+
+	// grafanaAdminSecretName holds the generated Grafana admin password.
+	grafanaAdminSecretName = "grafana-admin"
+)
+
+// observabilityDeployments lists the deployments setup waits for, with their selectors.
+var observabilityDeployments = []struct{ name, selector string }{
+	{"prometheus", "app=prometheus"},
+	{"grafana", "app=grafana"},
+}
+
+type observabilityStep struct{}
+
+func (s observabilityStep) Name() string { return "observability" }
+func (s observabilityStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	return setupObservabilityStep(logger, deps, ctx.OfflineImages)
+}
+
+func setupObservabilityStep(logger *zap.Logger, deps SetupDeps, offlineImages []offlineImage) error {
+	// Step 7: Install observability stack (if enabled)
+	Step("Step 7: Install observability stack")
+	if err := deps.DeployObservability(logger, offlineImages); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrDeployObservabilityFailed,
+			err,
+			fmt.Sprintf("failed to deploy observability stack: %v", err),
+			map[string]any{"manifest": observabilityManifest, "namespace": NamespaceMonitoring, "component": "setup"},
+		)
+		Error("Observability stack deployment failed")
+		logStructuredError(logger, wrappedErr, "Observability stack deployment failed")
+		return wrappedErr
+	}
+
+	for _, d := range observabilityDeployments {
+		Info(fmt.Sprintf("Waiting for %s deployment to be available", d.name))
+		if err := deps.WaitForDeploymentAvailable(logger, d.name, NamespaceMonitoring, d.selector, deps.GetDeploymentTimeout()); err != nil {
+			deps.PrintDeploymentDiagnostics(d.name, NamespaceMonitoring, d.selector)
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrObservabilityNotReady,
+				err,
+				fmt.Sprintf("%s not ready: %v", d.name, err),
+				map[string]any{"deployment": d.name, "namespace": NamespaceMonitoring, "component": "setup"},
+			)
+			Error(fmt.Sprintf("%s not ready", d.name))
+			logStructuredError(logger, wrappedErr, "Observability stack not ready")
+			return wrappedErr
+		}
+	}
+
+	Success("Observability stack installed")
+	Info(fmt.Sprintf("Open Grafana: kubectl port-forward -n %s svc/grafana 3000:3000 (user: admin)", NamespaceMonitoring))
+	Info(fmt.Sprintf("Admin password: kubectl get secret -n %s %s -o jsonpath='{.data.admin-password}' | base64 -d", NamespaceMonitoring, grafanaAdminSecretName))
+	return nil
+}
+
+func deployObservability(logger *zap.Logger, offlineImages []offlineImage) error {
+	return deployObservabilityWithKubectl(kubectlClient, logger, offlineImages)
+}
+
+// deployObservabilityWithKubectl applies the observability overlay and makes sure
+// Grafana has an admin password.
+func deployObservabilityWithKubectl(kubectl KubectlRunner, logger *zap.Logger, offlineImages []offlineImage) error {
+	Info("Applying Prometheus and Grafana manifests")
+	args, cleanup, err := platformApplyArgs(observabilityManifest, true, offlineImages)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	// #nosec G204 -- fixed kustomize path from repository.
+	if err := kubectl.RunWithOutput(args, DefaultPrinter.Stdout(), DefaultPrinter.Stderr()); err != nil {
+		return err
+	}
+	return ensureGrafanaAdminSecret(kubectl, logger)
+}
+
+// ensureGrafanaAdminSecret creates the Grafana admin secret with a random password
+// unless it already exists, so re-running setup keeps the current password.
+func ensureGrafanaAdminSecret(kubectl KubectlRunner, logger *zap.Logger) error {
+	// #nosec G204 -- fixed kubectl command with constant names.
+	if err := kubectl.Run([]string{"get", "secret", grafanaAdminSecretName, "-n", NamespaceMonitoring}); err == nil {
+		Info("Grafana admin secret already exists")
+		return nil
+	}
+
+	password, err := randomPassword()
+	if err != nil {
+		return wrapWithSentinel(ErrGrafanaAdminSecretFailed, err, fmt.Sprintf("failed to generate Grafana password: %v", err))
+	}
+
+	// #nosec G204 -- fixed kubectl command, manifest via stdin keeps the password off the command line.
+	cmd, err := kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return err
+	}
+	cmd.SetStdin(strings.NewReader(renderGrafanaAdminSecret(password)))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	if err := cmd.Run(); err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrGrafanaAdminSecretFailed,
+			err,
+			fmt.Sprintf("failed to create Grafana admin secret: %v", err),
+			map[string]any{"secret": grafanaAdminSecretName, "namespace": NamespaceMonitoring, "component": "setup"},
+		)
+		Error("Failed to create Grafana admin secret")
+		logStructuredError(logger, wrappedErr, "Failed to create Grafana admin secret")
+		return wrappedErr
+	}
+	return nil
+}
+
+func renderGrafanaAdminSecret(password string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: %s
+  namespace: %s
+type: Opaque
+stringData:
+  admin-password: %q
+`, grafanaAdminSecretName, NamespaceMonitoring, password)
+}
+
+func randomPassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestBuildSetupStepsWithObservability(t *testing.T) {
+	steps := buildSetupSteps(&SetupContext{Plan: SetupPlan{Observability: true}})
+	if got := steps[len(steps)-1].Name(); got != "observability" {
+		t.Fatalf("expected observability to run last, got %q", got)
+	}
+
+	for _, step := range buildSetupSteps(&SetupContext{}) {
+		if step.Name() == "observability" {
+			t.Fatal("observability step should be opt-in")
+		}
+	}
+}
+
+func TestObservabilityStep(t *testing.T) {
+	var waited []string
+	deps := SetupDeps{
+		DeployObservability: func(*zap.Logger, []offlineImage) error { return nil },
+		WaitForDeploymentAvailable: func(_ *zap.Logger, name, namespace, selector string, _ time.Duration) error {
+			if namespace != NamespaceMonitoring {
+				t.Fatalf("unexpected namespace %q", namespace)
+			}
+			waited = append(waited, name+"/"+selector)
+			return nil
+		},
+		PrintDeploymentDiagnostics: func(_, _, _ string) {},
+		GetDeploymentTimeout:       func() time.Duration { return time.Second },
+	}
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := (observabilityStep{}).Run(zap.NewNop(), deps, &SetupContext{}); err != nil {
+		t.Fatalf("observability step failed: %v", err)
+	}
+	if strings.Join(waited, ",") != "prometheus/app=prometheus,grafana/app=grafana" {
+		t.Fatalf("unexpected waits %v", waited)
+	}
+
+	deps.DeployObservability = func(*zap.Logger, []offlineImage) error { return errors.New("apply failed") }
+	if err := (observabilityStep{}).Run(zap.NewNop(), deps, &SetupContext{}); !errors.Is(err, ErrDeployObservabilityFailed) {
+		t.Fatalf("expected ErrDeployObservabilityFailed, got %v", err)
+	}
+
+	deps.DeployObservability = func(*zap.Logger, []offlineImage) error { return nil }
+	deps.WaitForDeploymentAvailable = func(*zap.Logger, string, string, string, time.Duration) error { return errors.New("timed out") }
+	if err := (observabilityStep{}).Run(zap.NewNop(), deps, &SetupContext{}); !errors.Is(err, ErrObservabilityNotReady) {
+		t.Fatalf("expected ErrObservabilityNotReady, got %v", err)
+	}
+}
+
+func TestDeployObservabilityWithKubectl(t *testing.T) {
+	t.Run("creates the Grafana admin secret when missing", func(t *testing.T) {
+		var applied []*MockCommand
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				cmd := &MockCommand{Args: spec.Args}
+				switch {
+				case spec.Args[0] == "get":
+					cmd.RunErr = errors.New("NotFound")
+				case strings.Join(spec.Args, " ") == "apply -f -":
+					applied = append(applied, cmd)
+				}
+				return cmd
+			},
+		}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := deployObservabilityWithKubectl(&KubectlClient{exec: mock}, zap.NewNop(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := strings.Join(mock.Commands[0].Args, " "); got != "apply -k config/observability" {
+			t.Fatalf("unexpected first command %q", got)
+		}
+		if len(applied) != 1 {
+			t.Fatalf("expected secret to be applied once, got %d", len(applied))
+		}
+		manifest, _ := io.ReadAll(applied[0].StdinR)
+		if !strings.Contains(string(manifest), "name: grafana-admin") || !strings.Contains(string(manifest), "admin-password:") {
+			t.Fatalf("unexpected secret manifest:\n%s", manifest)
+		}
+	})
+
+	t.Run("keeps an existing secret", func(t *testing.T) {
+		mock := &MockExecutor{}
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := deployObservabilityWithKubectl(&KubectlClient{exec: mock}, zap.NewNop(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mock.Commands) != 2 {
+			t.Fatalf("expected apply and get only, got %v", mock.Commands)
+		}
+	})
+}
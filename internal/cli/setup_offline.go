@@ -0,0 +1,367 @@
+package cli
+
+// This file implements offline setup support.
+// In offline mode setup never builds images or pulls from the internet. Every image it deploys
+// (the operator, traefik, the registry and its init container, the skopeo helper and the
+// optional observability and external-dns images) comes from a mirror registry: --image-mirror,
+// the external registry when one is configured, or else an internal registry that is already
+// running with the images pushed to it. Setup loads image archives from a local directory (if
+// given), pushes them to the mirror and verifies that every image is present before it applies
+// anything; the manifests it applies afterwards are retargeted at the mirror through a
+// generated kustomization.
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// Platform images referenced by the bundled manifests. The kustomize images transformer
+// matches these names when it retargets them at the mirror, so they must stay in sync with
+// config/ingress, config/registry, config/observability and config/external-dns.
+const (
+	traefikImage       = "traefik:v2.10"
+	registryImage      = "registry:2.8.3"
+	registryInitImage  = "busybox:1.36"
+	prometheusImage    = "prom/prometheus:v2.53.0"
+	grafanaImage       = "grafana/grafana:11.1.0"
+	externalDNSImage   = "registry.k8s.io/external-dns/external-dns:v0.14.2"
+	offlineOperatorTag = "latest"
+)
+
+// offlineImage is an image setup needs when running offline.
+type offlineImage struct {
+	// Name is the archive basename looked up in the images directory (<name>.tar).
+	Name string
+	// Source is the reference in the bundled manifests; empty for the operator, whose
+	// reference setup renders itself.
+	Source string
+	// Target is the fully qualified reference the cluster will pull.
+	Target string
+}
+
+// offlineMirror is the registry an offline setup pulls its images from.
+type offlineMirror struct {
+	// Registry is the registry host and optional repository prefix.
+	Registry string
+	// Internal is set when the mirror is the platform's own, already running, registry; its
+	// images are verified through the registry API instead of from this machine.
+	Internal bool
+}
+
+// requiredOfflineImages lists every image setup deploys for plan, with its mirror reference.
+// The registry and its init container are left out when the internal registry is the
+// mirror: it already runs them.
+func requiredOfflineImages(plan SetupPlan, mirror offlineMirror, operatorImage string, usingExternalRegistry bool) []offlineImage {
+	images := []offlineImage{{Name: "mcp-runtime-operator", Target: operatorImage}}
+	add := func(name, source string) {
+		images = append(images, offlineImage{Name: name, Source: source, Target: mirrorImage(mirror.Registry, source)})
+	}
+	if strings.EqualFold(plan.Ingress.mode, "traefik") {
+		add("traefik", traefikImage)
+	}
+	if !usingExternalRegistry {
+		if !mirror.Internal {
+			add("registry", registryImage)
+			add("busybox", registryInitImage)
+		}
+		add("skopeo", GetSkopeoImage())
+	}
+	if plan.Observability {
+		add("prometheus", prometheusImage)
+		add("grafana", grafanaImage)
+	}
+	if plan.ExternalDNS.Enabled {
+		add("external-dns", externalDNSImage)
+	}
+	return images
+}
+
+// mirrorImage returns source moved under mirror, dropping the registry host of source:
+// quay.io/skopeo/stable:v1.14 becomes <mirror>/skopeo/stable:v1.14.
+func mirrorImage(mirror, source string) string {
+	return strings.TrimSuffix(mirror, "/") + "/" + dropRegistryPrefix(source)
+}
+
+// offlineImageMirror returns the registry offline setup pulls every image from. The internal
+// registry can only serve them when it is already running, since setup would otherwise deploy
+// it from one of these images.
+func offlineImageMirror(logger *zap.Logger, plan SetupPlan, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, deps SetupDeps) (offlineMirror, error) {
+	if plan.ImageMirror != "" {
+		return offlineMirror{Registry: strings.TrimSuffix(plan.ImageMirror, "/")}, nil
+	}
+	if usingExternalRegistry {
+		return offlineMirror{Registry: extRegistry.Repository()}, nil
+	}
+	if err := deps.CheckInternalRegistryReady(logger); err != nil {
+		return offlineMirror{}, newWithSentinel(ErrOfflineMirrorRequired, fmt.Sprintf("offline setup with the internal registry needs --image-mirror unless the registry is already running with the images pushed to it (%v)", err))
+	}
+	return offlineMirror{Registry: deps.GetPlatformRegistryURL(logger), Internal: true}, nil
+}
+
+// offlineOperatorImage returns the operator image reference used in offline mode.
+// Unlike online mode the image is never built, so the reference points straight at
+// the registry the operator will be pulled from.
+func offlineOperatorImage(mirror offlineMirror, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, deps SetupDeps) string {
+	if usingExternalRegistry || GetOperatorImageOverride() != "" {
+		return deps.OperatorImageFor(extRegistry)
+	}
+	return mirror.Registry + "/mcp-runtime-operator:" + offlineOperatorTag
+}
+
+type offlineImageStep struct{}
+
+func (s offlineImageStep) Name() string { return "offline-images" }
+func (s offlineImageStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	images, err := prepareOfflineImages(logger, ctx.Plan, ctx.ExternalRegistry, ctx.UsingExternalRegistry, deps)
+	if err != nil {
+		return err
+	}
+	ctx.OperatorImage = images[0].Target
+	ctx.OfflineImages = images
+	for _, img := range images {
+		if img.Name == "skopeo" && img.Target != GetSkopeoImage() {
+			Info(fmt.Sprintf("Set MCP_SKOPEO_IMAGE=%s so in-cluster pushes use the mirrored helper image", img.Target))
+		}
+	}
+	return nil
+}
+
+// prepareOfflineImages resolves the images of an offline setup, loads their archives and
+// checks that the mirror has all of them. The operator image comes first.
+func prepareOfflineImages(logger *zap.Logger, plan SetupPlan, extRegistry *ExternalRegistryConfig, usingExternalRegistry bool, deps SetupDeps) ([]offlineImage, error) {
+	Step("Step 0b: Prepare offline images")
+
+	mirror, err := offlineImageMirror(logger, plan, extRegistry, usingExternalRegistry, deps)
+	if err != nil {
+		Error("No image mirror for offline setup")
+		logStructuredError(logger, err, "No image mirror for offline setup")
+		return nil, err
+	}
+	if mirror.Internal {
+		Info(fmt.Sprintf("Mirror: internal registry %s", mirror.Registry))
+	} else {
+		Info(fmt.Sprintf("Mirror: %s", mirror.Registry))
+	}
+	if usingExternalRegistry && (extRegistry.Username != "" || extRegistry.Password != "") {
+		Info("Logging into external registry")
+		if err := deps.LoginRegistry(logger, extRegistry.URL, extRegistry.Username, extRegistry.Password); err != nil {
+			wrappedErr := wrapWithSentinel(ErrRegistryLoginFailed, err, fmt.Sprintf("failed to login to registry %q: %v", extRegistry.URL, err))
+			Error("Registry login failed")
+			logStructuredError(logger, wrappedErr, "Registry login failed")
+			return nil, wrappedErr
+		}
+	}
+
+	images := requiredOfflineImages(plan, mirror, offlineOperatorImage(mirror, extRegistry, usingExternalRegistry, deps), usingExternalRegistry)
+	if plan.ImagesDir != "" {
+		if err := loadOfflineImages(logger, images, plan.ImagesDir, deps); err != nil {
+			return nil, err
+		}
+	}
+
+	Info("Verifying required images in the mirror")
+	var missing []string
+	for _, img := range images {
+		if err := deps.CheckRegistryImage(logger, img.Target, !mirror.Internal); err != nil {
+			missing = append(missing, img.Target)
+			logger.Debug("Image not found in registry", zap.String("image", img.Target), zap.Error(err))
+		}
+	}
+	if len(missing) > 0 {
+		err := newWithSentinel(ErrOfflineImagesMissing, fmt.Sprintf("offline setup is missing %d image(s): %s", len(missing), strings.Join(missing, ", ")))
+		Error("Required images missing for offline setup")
+		for _, img := range missing {
+			Info(fmt.Sprintf("missing: %s", img))
+		}
+		if plan.ImagesDir == "" {
+			Info("Provide archives with --images-dir or push the images to the mirror first")
+		}
+		logStructuredError(logger, err, "Required images missing for offline setup")
+		return nil, err
+	}
+	Info("All required images present")
+	return images, nil
+}
+
+// imageKustomization retargets the images of a manifest; see platformApplyArgs.
+type imageKustomization struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Resources  []string         `yaml:"resources"`
+	Images     []kustomizeImage `yaml:"images"`
+}
+
+// platformApplyArgs returns the kubectl arguments applying manifest, a kustomize directory
+// when kustomize is set. With offline images the manifest is wrapped in a generated
+// kustomization whose images transformer points the platform images at the mirror; cleanup
+// removes it.
+func platformApplyArgs(manifest string, kustomize bool, offlineImages []offlineImage) ([]string, func(), error) {
+	var images []kustomizeImage
+	for _, img := range offlineImages {
+		if img.Source == "" {
+			continue
+		}
+		sourceRepo, _ := splitImage(img.Source)
+		targetRepo, _ := splitImage(img.Target)
+		images = append(images, kustomizeImage{Name: sourceRepo, NewName: targetRepo})
+	}
+	if len(images) == 0 {
+		if kustomize {
+			return []string{"apply", "-k", manifest}, func() {}, nil
+		}
+		return []string{"apply", "-f", manifest}, func() {}, nil
+	}
+
+	dir, err := tempMirrorKustomization.mkdir()
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { removeTempFile(dir) }
+	resource, err := kustomizationResource(dir, manifest)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	out, err := yaml.Marshal(imageKustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  []string{resource},
+		Images:     images,
+	})
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "kustomization.yaml"), out, 0o600)
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return []string{"apply", "-k", dir}, cleanup, nil
+}
+
+// loadOfflineImages loads each <name>.tar found in imagesDir into the local image
+// store and pushes it to the mirror. Images without an archive are left
+// for the registry check to report.
+func loadOfflineImages(logger *zap.Logger, images []offlineImage, imagesDir string, deps SetupDeps) error {
+	if info, err := os.Stat(imagesDir); err != nil || !info.IsDir() {
+		if err == nil {
+			err = errors.New("not a directory")
+		}
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrImagesDirInvalid,
+			err,
+			fmt.Sprintf("images directory %q is not usable: %v", imagesDir, err),
+			map[string]any{"images_dir": imagesDir, "component": "setup"},
+		)
+		Error("Images directory not usable")
+		logStructuredError(logger, wrappedErr, "Images directory not usable")
+		return wrappedErr
+	}
+
+	for _, img := range images {
+		archive := filepath.Join(imagesDir, img.Name+".tar")
+		if _, err := os.Stat(archive); err != nil {
+			Warn(fmt.Sprintf("No archive for %s (%s); expecting it in the registry", img.Name, archive))
+			continue
+		}
+
+		Info(fmt.Sprintf("Loading %s", archive))
+		loaded, err := deps.LoadImageArchive(archive)
+		if err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrLoadImageArchiveFailed,
+				err,
+				fmt.Sprintf("failed to load image archive %q: %v", archive, err),
+				map[string]any{"archive": archive, "component": "setup"},
+			)
+			Error("Failed to load image archive")
+			logStructuredError(logger, wrappedErr, "Failed to load image archive")
+			return wrappedErr
+		}
+
+		Info(fmt.Sprintf("Pushing %s to %s", loaded, img.Target))
+		if err := deps.PushImageDirect(loaded, img.Target); err != nil {
+			wrappedErr := wrapWithSentinelAndContext(
+				ErrPushImageFailed,
+				err,
+				fmt.Sprintf("failed to push %q to %q: %v", loaded, img.Target, err),
+				map[string]any{"source_image": loaded, "target_image": img.Target, "component": "setup"},
+			)
+			Error("Failed to push offline image")
+			logStructuredError(logger, wrappedErr, "Failed to push offline image")
+			return wrappedErr
+		}
+	}
+	return nil
+}
+
+func loadImageArchive(path string) (string, error) {
+	// #nosec G204 -- path comes from the --images-dir flag and a fixed archive name.
+	cmd, err := execCommandWithValidators(containerTool(), []string{"load", "-i", path})
+	if err != nil {
+		return "", err
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return parseLoadedImage(string(out))
+}
+
+// parseLoadedImage extracts the image reference from `docker load` output; podman and
+// nerdctl print the same line, older podman "Loaded image(s):".
+func parseLoadedImage(out string) (string, error) {
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		for _, prefix := range []string{"Loaded image:", "Loaded image(s):"} {
+			if ref, ok := strings.CutPrefix(line, prefix); ok {
+				return strings.TrimSpace(ref), nil
+			}
+		}
+	}
+	return "", errors.New("image load did not report a tagged image")
+}
+
+func pushImageDirect(source, target string) error {
+	return DefaultRegistryManager(zap.NewNop()).PushDirect(source, target)
+}
+
+func checkRegistryImage(logger *zap.Logger, image string, external bool) error {
+	if external {
+		return checkExternalRegistryImage(image)
+	}
+	return checkInternalRegistryImageWithKubectl(kubectlClient, image, GetRegistryPort())
+}
+
+// checkInternalRegistryImageWithKubectl queries the internal registry's v2 API through the
+// Kubernetes API server service proxy, so it works without the registry being exposed.
+func checkInternalRegistryImageWithKubectl(kubectl KubectlRunner, image string, port int) error {
+	repo, tag := splitImage(image)
+	repo = dropRegistryPrefix(repo)
+	if tag == "" {
+		tag = "latest"
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/services/registry:%d/proxy/v2/%s/manifests/%s", NamespaceRegistry, port, repo, tag)
+	// #nosec G204 -- path is built from an image reference produced by setup.
+	cmd, err := kubectl.CommandArgs([]string{"get", "--raw", path})
+	if err != nil {
+		return err
+	}
+	_, err = cmd.Output()
+	return err
+}
+
+func checkExternalRegistryImage(image string) error {
+	// #nosec G204 -- image reference produced by setup from registry config.
+	cmd, err := execCommandWithValidators(containerTool(), []string{"manifest", "inspect", image})
+	if err != nil {
+		return err
+	}
+	_, err = cmd.Output()
+	return err
+}
@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildSetupStepsOffline(t *testing.T) {
+	ctx := &SetupContext{Plan: SetupPlan{Offline: true}}
+	steps := buildSetupSteps(ctx)
+
+	var got []string
+	for _, step := range steps {
+		got = append(got, step.Name())
+	}
+	want := []string{"preflight", "offline-images", "cluster", "registry", "operator-deploy", "verify"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected steps %v, got %v", want, got)
+	}
+}
+
+func TestBuildSetupPlanImagesDirImpliesOffline(t *testing.T) {
+	plan := BuildSetupPlan(SetupPlanInput{ImagesDir: "/tmp/images"})
+	if !plan.Offline {
+		t.Fatal("expected --images-dir to imply offline mode")
+	}
+	if plan.ImagesDir != "/tmp/images" {
+		t.Fatalf("expected images dir to be kept, got %q", plan.ImagesDir)
+	}
+
+	plan = BuildSetupPlan(SetupPlanInput{ImageMirror: "mirror.local:5000"})
+	if !plan.Offline || plan.ImageMirror != "mirror.local:5000" {
+		t.Fatalf("expected --image-mirror to imply offline mode, got %+v", plan)
+	}
+}
+
+func TestOfflineImageStepVerifiesMirror(t *testing.T) {
+	skopeoImage := GetSkopeoImage()
+	ctx := &SetupContext{Plan: SetupPlan{Offline: true, ImageMirror: "mirror.local:5000/", Ingress: ingressOptions{mode: "traefik"}}}
+	var checked []string
+	deps := SetupDeps{
+		BuildOperatorImage: func(string) error {
+			t.Fatal("offline setup must not build images")
+			return nil
+		},
+		CheckRegistryImage: func(_ *zap.Logger, image string, external bool) error {
+			if !external {
+				t.Fatal("expected the mirror to be checked as an external registry")
+			}
+			checked = append(checked, image)
+			return nil
+		},
+	}
+
+	if err := (offlineImageStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("offline image step failed: %v", err)
+	}
+	if ctx.OperatorImage != "mirror.local:5000/mcp-runtime-operator:latest" {
+		t.Fatalf("unexpected operator image %q", ctx.OperatorImage)
+	}
+	want := []string{
+		"mirror.local:5000/mcp-runtime-operator:latest",
+		"mirror.local:5000/traefik:v2.10",
+		"mirror.local:5000/registry:2.8.3",
+		"mirror.local:5000/busybox:1.36",
+		"mirror.local:5000/skopeo/stable:v1.14",
+	}
+	if strings.Join(checked, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v to be verified, got %v", want, checked)
+	}
+	if len(ctx.OfflineImages) != len(want) {
+		t.Fatalf("expected the offline images to be recorded, got %v", ctx.OfflineImages)
+	}
+	if GetSkopeoImage() != skopeoImage {
+		t.Fatalf("expected the configured skopeo image to be left alone, got %q", GetSkopeoImage())
+	}
+}
+
+func TestOfflineImageStepUsesRunningInternalRegistry(t *testing.T) {
+	ctx := &SetupContext{Plan: SetupPlan{Offline: true, Ingress: ingressOptions{mode: "traefik"}}}
+	var checked []string
+	deps := SetupDeps{
+		CheckInternalRegistryReady: func(*zap.Logger) error { return nil },
+		GetPlatformRegistryURL:     func(*zap.Logger) string { return "10.96.0.10:5000" },
+		CheckRegistryImage: func(_ *zap.Logger, image string, external bool) error {
+			if external {
+				t.Fatal("expected the internal registry to be checked through the cluster")
+			}
+			checked = append(checked, image)
+			return nil
+		},
+	}
+
+	if err := (offlineImageStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("offline image step failed: %v", err)
+	}
+	want := []string{
+		"10.96.0.10:5000/mcp-runtime-operator:latest",
+		"10.96.0.10:5000/traefik:v2.10",
+		"10.96.0.10:5000/skopeo/stable:v1.14",
+	}
+	if strings.Join(checked, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v to be verified, got %v", want, checked)
+	}
+	if ctx.OperatorImage != want[0] {
+		t.Fatalf("unexpected operator image %q", ctx.OperatorImage)
+	}
+	if len(ctx.OfflineImages) != len(want) {
+		t.Fatalf("expected the offline images to be recorded, got %v", ctx.OfflineImages)
+	}
+}
+
+func TestOfflineImageStepDefaultsToExternalRegistry(t *testing.T) {
+	ctx := &SetupContext{
+		Plan:                  SetupPlan{Offline: true, Observability: true, ExternalDNS: ExternalDNSOptions{Enabled: true}},
+		ExternalRegistry:      &ExternalRegistryConfig{URL: "registry.example.com", PathPrefix: "mcp"},
+		UsingExternalRegistry: true,
+	}
+	var checked []string
+	deps := SetupDeps{
+		OperatorImageFor: func(*ExternalRegistryConfig) string {
+			return "registry.example.com/mcp/mcp-runtime-operator:latest"
+		},
+		CheckRegistryImage: func(_ *zap.Logger, image string, _ bool) error {
+			checked = append(checked, image)
+			return nil
+		},
+	}
+
+	if err := (offlineImageStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("offline image step failed: %v", err)
+	}
+	want := []string{
+		"registry.example.com/mcp/mcp-runtime-operator:latest",
+		"registry.example.com/mcp/prom/prometheus:v2.53.0",
+		"registry.example.com/mcp/grafana/grafana:11.1.0",
+		"registry.example.com/mcp/external-dns/external-dns:v0.14.2",
+	}
+	if strings.Join(checked, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v to be verified, got %v", want, checked)
+	}
+}
+
+func TestOfflineImageStepRequiresMirror(t *testing.T) {
+	ctx := &SetupContext{Plan: SetupPlan{Offline: true}}
+	deps := SetupDeps{
+		CheckInternalRegistryReady: func(*zap.Logger) error { return ErrRegistryNotFound },
+		CheckRegistryImage: func(*zap.Logger, string, bool) error {
+			t.Fatal("no image can be checked without a mirror")
+			return nil
+		},
+	}
+
+	err := (offlineImageStep{}).Run(zap.NewNop(), deps, ctx)
+	if !errors.Is(err, ErrOfflineMirrorRequired) {
+		t.Fatalf("expected ErrOfflineMirrorRequired, got %v", err)
+	}
+}
+
+func TestOfflineImageStepFailsWithMissingImages(t *testing.T) {
+	ctx := &SetupContext{Plan: SetupPlan{Offline: true, ImageMirror: "mirror.local:5000"}}
+	deps := SetupDeps{
+		CheckRegistryImage: func(*zap.Logger, string, bool) error {
+			return errors.New("manifest unknown")
+		},
+	}
+
+	err := (offlineImageStep{}).Run(zap.NewNop(), deps, ctx)
+	if err == nil {
+		t.Fatal("expected error for missing images")
+	}
+	if !errors.Is(err, ErrOfflineImagesMissing) {
+		t.Fatalf("expected ErrOfflineImagesMissing, got %v", err)
+	}
+	for _, image := range []string{"mirror.local:5000/mcp-runtime-operator:latest", "mirror.local:5000/registry:2.8.3"} {
+		if !strings.Contains(err.Error(), image) {
+			t.Fatalf("expected missing image %s in error, got %v", image, err)
+		}
+	}
+	if ctx.OfflineImages != nil {
+		t.Fatalf("expected no offline images after a failed check, got %v", ctx.OfflineImages)
+	}
+}
+
+func TestOfflineImageStepLoadsArchives(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "mcp-runtime-operator.tar")
+	if err := os.WriteFile(archive, []byte("tar"), 0o600); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+
+	ctx := &SetupContext{Plan: SetupPlan{Offline: true, ImagesDir: dir, ImageMirror: "mirror.local:5000"}}
+	var loaded, pushedSource, pushedTarget string
+	deps := SetupDeps{
+		LoadImageArchive: func(path string) (string, error) {
+			loaded = path
+			return "mcp-runtime-operator:dev", nil
+		},
+		PushImageDirect: func(source, target string) error {
+			pushedSource, pushedTarget = source, target
+			return nil
+		},
+		CheckRegistryImage: func(*zap.Logger, string, bool) error { return nil },
+	}
+
+	if err := (offlineImageStep{}).Run(zap.NewNop(), deps, ctx); err != nil {
+		t.Fatalf("offline image step failed: %v", err)
+	}
+	if loaded != archive {
+		t.Fatalf("expected %q to be loaded, got %q", archive, loaded)
+	}
+	if pushedSource != "mcp-runtime-operator:dev" || pushedTarget != "mirror.local:5000/mcp-runtime-operator:latest" {
+		t.Fatalf("unexpected push %q -> %q", pushedSource, pushedTarget)
+	}
+}
+
+func TestPlatformApplyArgs(t *testing.T) {
+	args, cleanup, err := platformApplyArgs(observabilityManifest, true, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cleanup()
+	if strings.Join(args, " ") != "apply -k "+observabilityManifest {
+		t.Fatalf("expected the manifest to be applied as is online, got %v", args)
+	}
+
+	root := t.TempDir()
+	manifest := filepath.Join(root, "traefik.yaml")
+	if err := os.WriteFile(manifest, []byte("kind: Deployment\n"), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(root); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	images := []offlineImage{
+		{Name: "mcp-runtime-operator", Target: "mirror.local:5000/mcp-runtime-operator:latest"},
+		{Name: "traefik", Source: traefikImage, Target: "mirror.local:5000/traefik:v2.10"},
+		{Name: "external-dns", Source: externalDNSImage, Target: "mirror.local:5000/external-dns/external-dns:v0.14.2"},
+	}
+	args, cleanup, err = platformApplyArgs(manifest, false, images)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(args) != 3 || args[0] != "apply" || args[1] != "-k" {
+		t.Fatalf("expected a generated kustomization to be applied, got %v", args)
+	}
+	data, err := os.ReadFile(filepath.Join(args[2], "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("read kustomization: %v", err)
+	}
+	var k imageKustomization
+	if err := yaml.Unmarshal(data, &k); err != nil {
+		t.Fatalf("parse kustomization: %v", err)
+	}
+	if strings.Join(k.Resources, ",") != "traefik.yaml" {
+		t.Fatalf("unexpected resources: %v", k.Resources)
+	}
+	want := []kustomizeImage{
+		{Name: "traefik", NewName: "mirror.local:5000/traefik"},
+		{Name: "registry.k8s.io/external-dns/external-dns", NewName: "mirror.local:5000/external-dns/external-dns"},
+	}
+	if len(k.Images) != len(want) || k.Images[0] != want[0] || k.Images[1] != want[1] {
+		t.Fatalf("expected images %v, got %v", want, k.Images)
+	}
+	cleanup()
+	if _, err := os.Stat(args[2]); !os.IsNotExist(err) {
+		t.Fatalf("expected the kustomization to be removed, got %v", err)
+	}
+}
+
+func TestPlatformImagesMatchManifests(t *testing.T) {
+	for file, images := range map[string][]string{
+		"../../config/ingress/base/traefik.yaml":      {traefikImage},
+		"../../config/registry/base/deployment.yaml":  {registryImage, registryInitImage},
+		"../../config/observability/prometheus.yaml":  {prometheusImage},
+		"../../config/observability/grafana.yaml":     {grafanaImage},
+		"../../config/external-dns/external-dns.yaml": {externalDNSImage},
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		for _, image := range images {
+			if !strings.Contains(string(data), "image: "+image+"\n") {
+				t.Errorf("%s does not deploy %s; update the offline image list", file, image)
+			}
+		}
+	}
+}
+
+func TestParseLoadedImage(t *testing.T) {
+	ref, err := parseLoadedImage("abc: Loading layer\nLoaded image: mcp-runtime-operator:latest\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref != "mcp-runtime-operator:latest" {
+		t.Fatalf("unexpected ref %q", ref)
+	}
+
+	if ref, _ := parseLoadedImage("Loaded image(s): localhost/mcp-runtime-operator:latest\n"); ref != "localhost/mcp-runtime-operator:latest" {
+		t.Fatalf("unexpected podman ref %q", ref)
+	}
+
+	if _, err := parseLoadedImage("Loaded image ID: sha256:abc\n"); err == nil {
+		t.Fatal("expected error for untagged archive")
+	}
+}
+
+func TestCheckInternalRegistryImageWithKubectl(t *testing.T) {
+	mock := &MockExecutor{}
+	kubectl := &KubectlClient{exec: mock, validators: nil}
+
+	if err := checkInternalRegistryImageWithKubectl(kubectl, "10.0.0.5:5000/mcp-runtime-operator:v1", 5000); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := strings.Join(mock.LastCommand().Args, " ")
+	want := "get --raw /api/v1/namespaces/registry/services/registry:5000/proxy/v2/mcp-runtime-operator/manifests/v1"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testManagerManifest = `apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - command:
+        - /manager
+        args:
+        - --metrics-bind-address=:8080
+        image: mcp-runtime-operator:latest
+`
+
+func TestRenderManagerManifest(t *testing.T) {
+	out := renderManagerManifest(testManagerManifest, "registry.local/operator:v1", 3)
+	for _, want := range []string{"replicas: 3", "image: registry.local/operator:v1", "        args:\n        - --leader-elect\n        - --metrics-bind-address=:8080"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in rendered manifest:\n%s", want, out)
+		}
+	}
+
+	single := renderManagerManifest(testManagerManifest, "registry.local/operator:v1", 1)
+	if !strings.Contains(single, "replicas: 1") || strings.Contains(single, "--leader-elect") {
+		t.Fatalf("unexpected single-replica manifest:\n%s", single)
+	}
+
+	elected := renderManagerManifest(out, "registry.local/operator:v1", 2)
+	if strings.Count(elected, "--leader-elect") != 1 {
+		t.Fatalf("expected --leader-elect once, got:\n%s", elected)
+	}
+}
+
+func TestApplyOperatorPDB(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	mock := &MockExecutor{}
+	if err := applyOperatorPDB(&KubectlClient{exec: mock}, zap.NewNop(), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(mock.LastCommand().Args, " "); got != "apply -f config/manager/pdb.yaml" {
+		t.Fatalf("unexpected command %q", got)
+	}
+
+	mock = &MockExecutor{}
+	if err := applyOperatorPDB(&KubectlClient{exec: mock}, zap.NewNop(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(mock.LastCommand().Args, " "); !strings.HasPrefix(got, "delete pdb "+OperatorPDBName) {
+		t.Fatalf("expected PDB removal for a single replica, got %q", got)
+	}
+
+	mock = &MockExecutor{DefaultRunErr: errors.New("forbidden")}
+	if err := applyOperatorPDB(&KubectlClient{exec: mock}, zap.NewNop(), 2); !errors.Is(err, ErrApplyOperatorPDBFailed) {
+		t.Fatalf("expected ErrApplyOperatorPDBFailed, got %v", err)
+	}
+}
+
+func TestVerifyOperatorFailover(t *testing.T) {
+	original := failoverPollInterval
+	failoverPollInterval = time.Millisecond
+	t.Cleanup(func() { failoverPollInterval = original })
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	t.Run("succeeds when another replica takes the lease", func(t *testing.T) {
+		deleted := ""
+		mock := &MockExecutor{
+			CommandFunc: func(spec ExecSpec) *MockCommand {
+				cmd := &MockCommand{Args: spec.Args}
+				switch spec.Args[0] {
+				case "get":
+					holder := "manager-a_0d1f"
+					if deleted != "" {
+						holder = "manager-b_9e2c"
+					}
+					cmd.OutputData = []byte(holder)
+				case "delete":
+					deleted = spec.Args[2]
+				}
+				return cmd
+			},
+		}
+		if err := verifyOperatorFailoverWithKubectl(&KubectlClient{exec: mock}, zap.NewNop(), time.Second); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if deleted != "manager-a" {
+			t.Fatalf("expected leader pod manager-a to be deleted, got %q", deleted)
+		}
+	})
+
+	t.Run("fails when leadership does not move", func(t *testing.T) {
+		mock := &MockExecutor{DefaultOutput: []byte("manager-a_0d1f")}
+		err := verifyOperatorFailoverWithKubectl(&KubectlClient{exec: mock}, zap.NewNop(), 20*time.Millisecond)
+		if !errors.Is(err, ErrOperatorFailoverFailed) {
+			t.Fatalf("expected ErrOperatorFailoverFailed, got %v", err)
+		}
+	})
+}
+
+func TestVerifyStepChecksFailoverForHA(t *testing.T) {
+	failoverCalls := 0
+	deps := SetupDeps{
+		WaitForDeploymentAvailable: func(*zap.Logger, string, string, string, time.Duration) error { return nil },
+		PrintDeploymentDiagnostics: func(_, _, _ string) {},
+		CheckCRDInstalled:          func(string) error { return nil },
+		GetDeploymentTimeout:       func() time.Duration { return time.Second },
+		VerifyOperatorFailover: func(*zap.Logger, time.Duration) error {
+			failoverCalls++
+			return nil
+		},
+	}
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := (verifyStep{}).Run(zap.NewNop(), deps, &SetupContext{Plan: SetupPlan{OperatorReplicas: 1}}); err != nil {
+		t.Fatalf("verify step failed: %v", err)
+	}
+	if err := (verifyStep{}).Run(zap.NewNop(), deps, &SetupContext{Plan: SetupPlan{OperatorReplicas: 2}}); err != nil {
+		t.Fatalf("verify step failed: %v", err)
+	}
+	if failoverCalls != 1 {
+		t.Fatalf("expected failover check only for HA, got %d calls", failoverCalls)
+	}
+}
+
+func TestBuildSetupPlanDefaultsOperatorReplicas(t *testing.T) {
+	if got := BuildSetupPlan(SetupPlanInput{}).OperatorReplicas; got != DefaultOperatorReplicas {
+		t.Fatalf("expected %d operator replicas, got %d", DefaultOperatorReplicas, got)
+	}
+	if got := BuildSetupPlan(SetupPlanInput{OperatorReplicas: 3}).OperatorReplicas; got != 3 {
+		t.Fatalf("expected 3 operator replicas, got %d", got)
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderOperatorKustomization(t *testing.T) {
+	out, err := renderOperatorKustomization("../overlays/prod", "registry.example.com/ops/operator:v1.2", 3, OperatorDeployOptions{CPU: "1", ImagePullPolicy: "Always"})
+	if err != nil {
+		t.Fatalf("renderOperatorKustomization() error: %v", err)
+	}
+	var k operatorKustomization
+	if err := yaml.Unmarshal([]byte(out), &k); err != nil {
+		t.Fatalf("parse kustomization: %v\n%s", err, out)
+	}
+	if strings.Join(k.Resources, ",") != "../overlays/prod" {
+		t.Fatalf("unexpected resources: %v", k.Resources)
+	}
+	if len(k.Images) != 1 || k.Images[0] != (kustomizeImage{Name: "mcp-runtime-operator", NewName: "registry.example.com/ops/operator", NewTag: "v1.2"}) {
+		t.Fatalf("unexpected images: %+v", k.Images)
+	}
+	if len(k.Replicas) != 1 || k.Replicas[0] != (kustomizeReplicas{Name: OperatorDeploymentName, Count: 3}) {
+		t.Fatalf("unexpected replicas: %+v", k.Replicas)
+	}
+	if len(k.Patches) != 1 {
+		t.Fatalf("expected one patch, got %+v", k.Patches)
+	}
+	for _, want := range []string{"name: manager", "imagePullPolicy: Always", `cpu: "1"`} {
+		if !strings.Contains(k.Patches[0].Patch, want) {
+			t.Fatalf("patch misses %q:\n%s", want, k.Patches[0].Patch)
+		}
+	}
+	if strings.Contains(k.Patches[0].Patch, "memory") {
+		t.Fatalf("patch sets an unset option:\n%s", k.Patches[0].Patch)
+	}
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	out, err = renderOperatorKustomization("manager.yaml", "registry.example.com/operator@"+digest, 1, OperatorDeployOptions{})
+	if err != nil {
+		t.Fatalf("renderOperatorKustomization() error: %v", err)
+	}
+	if !strings.Contains(out, "digest: "+digest) || strings.Contains(out, "newTag") || strings.Contains(out, "patches") {
+		t.Fatalf("unexpected kustomization for a digest without options:\n%s", out)
+	}
+}
+
+func TestValidateOperatorManifest(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "manager.yaml")
+	if err := os.WriteFile(file, []byte("kind: Deployment\n"), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	overlay := filepath.Join(dir, "overlay")
+	if err := os.Mkdir(overlay, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := validateOperatorManifest(overlay); !errors.Is(err, ErrInvalidOperatorOptions) {
+		t.Fatalf("expected ErrInvalidOperatorOptions for a directory without kustomization, got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(overlay, "kustomization.yaml"), []byte("resources: []\n"), 0o600); err != nil {
+		t.Fatalf("write kustomization: %v", err)
+	}
+	for _, path := range []string{file, overlay} {
+		if err := (OperatorDeployOptions{Manifest: path}).Validate(); err != nil {
+			t.Fatalf("expected %s to be valid, got %v", path, err)
+		}
+	}
+	if err := validateOperatorManifest(filepath.Join(dir, "missing.yaml")); !errors.Is(err, ErrInvalidOperatorOptions) {
+		t.Fatalf("expected ErrInvalidOperatorOptions for a missing path, got %v", err)
+	}
+}
+
+func TestApplyOperatorKustomization(t *testing.T) {
+	work := t.TempDir()
+	t.Chdir(work)
+	overlay := filepath.Join(work, "deploy", "operator")
+	if err := os.MkdirAll(overlay, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(overlay, "kustomization.yaml"), []byte("resources: []\n"), 0o600); err != nil {
+		t.Fatalf("write kustomization: %v", err)
+	}
+
+	var kustomization string
+	mock := &MockExecutor{
+		CommandFunc: func(spec ExecSpec) *MockCommand {
+			cmd := &MockCommand{Args: spec.Args}
+			if idx := argIndex(spec.Args, "-k"); idx != -1 {
+				dir := spec.Args[idx+1]
+				cmd.RunFunc = func() error {
+					data, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+					kustomization = string(data)
+					return err
+				}
+			}
+			return cmd
+		},
+	}
+	kubectl := &KubectlClient{exec: mock}
+
+	opts := OperatorDeployOptions{Manifest: overlay}
+	if err := applyOperatorKustomization(kubectl, zap.NewNop(), "registry.example.com/operator:dev", 2, opts); err != nil {
+		t.Fatalf("applyOperatorKustomization() error: %v", err)
+	}
+	if !strings.Contains(kustomization, "- ../deploy/operator") || !strings.Contains(kustomization, "newTag: dev") {
+		t.Fatalf("unexpected kustomization:\n%s", kustomization)
+	}
+	if !commandHasArgs(mock.Commands[0], "delete", "deployment/"+OperatorDeploymentName) {
+		t.Fatalf("expected the old deployment to be deleted first, got %v", mock.Commands[0].Args)
+	}
+	entries, err := os.ReadDir(work)
+	if err != nil {
+		t.Fatalf("read work dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected the kustomization directory to be removed, got %v", entries)
+	}
+
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		if argIndex(spec.Args, "-k") != -1 {
+			cmd.RunErr = errors.New("apply failed")
+		}
+		return cmd
+	}
+	if err := applyOperatorKustomization(kubectl, zap.NewNop(), "operator:dev", 1, opts); !errors.Is(err, ErrApplyManagerDeploymentFailed) {
+		t.Fatalf("expected ErrApplyManagerDeploymentFailed, got %v", err)
+	}
+}
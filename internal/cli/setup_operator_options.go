@@ -0,0 +1,135 @@
+package cli
+
+// This file implements the operator sizing options of setup. The manager deployment ships
+// with requests and limits sized for small clusters; --operator-cpu and --operator-memory
+// set both to the given value, and --operator-image-pull-policy replaces IfNotPresent.
+// --operator-manifest swaps manager.yaml for a downstream manifest (see setup_operator_manifest.go).
+// The same values can be kept in a setup config file passed with -f.
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// operatorPullPolicies are the accepted --operator-image-pull-policy values.
+var operatorPullPolicies = []string{"Always", "IfNotPresent", "Never"}
+
+var (
+	managerCPURe        = regexp.MustCompile(`(?m)^(\s*)cpu:\s*\S+`)
+	managerMemoryRe     = regexp.MustCompile(`(?m)^(\s*)memory:\s*\S+`)
+	managerPullPolicyRe = regexp.MustCompile(`(?m)^(\s*)imagePullPolicy:\s*\S+`)
+)
+
+// OperatorDeployOptions override the operator container settings in manager.yaml; empty
+// fields keep the manifest's values.
+type OperatorDeployOptions struct {
+	CPU             string
+	Memory          string
+	ImagePullPolicy string
+	// Manifest is a manager manifest file or kustomize overlay installed instead of
+	// config/manager/manager.yaml.
+	Manifest string
+}
+
+func addOperatorDeployFlags(cmd *cobra.Command, o *OperatorDeployOptions) {
+	cmd.Flags().StringVar(&o.CPU, "operator-cpu", "", "Operator CPU request and limit, e.g. 1 or 500m (default: manager.yaml values)")
+	cmd.Flags().StringVar(&o.Memory, "operator-memory", "", "Operator memory request and limit, e.g. 1Gi (default: manager.yaml values)")
+	cmd.Flags().StringVar(&o.ImagePullPolicy, "operator-image-pull-policy", "", "Operator image pull policy ("+strings.Join(operatorPullPolicies, "|")+")")
+	cmd.Flags().StringVar(&o.Manifest, "operator-manifest", "", "Operator manager manifest file or kustomize overlay directory to install instead of config/manager/manager.yaml")
+}
+
+// Validate checks the quantities and the pull policy.
+func (o OperatorDeployOptions) Validate() error {
+	quantities := []struct{ flag, value string }{
+		{"--operator-cpu", o.CPU},
+		{"--operator-memory", o.Memory},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(q.value); err != nil {
+			return newWithSentinel(ErrInvalidOperatorOptions, fmt.Sprintf("invalid %s %q: %v", q.flag, q.value, err))
+		}
+	}
+	if o.ImagePullPolicy != "" && !slices.Contains(operatorPullPolicies, o.ImagePullPolicy) {
+		return newWithSentinel(ErrInvalidOperatorOptions, fmt.Sprintf("invalid --operator-image-pull-policy %q (use one of: %s)", o.ImagePullPolicy, strings.Join(operatorPullPolicies, ", ")))
+	}
+	if o.Manifest != "" {
+		return validateOperatorManifest(o.Manifest)
+	}
+	return nil
+}
+
+// renderManagerOptions applies o to manager.yaml. The manager container is the only
+// container in the file, so its cpu, memory and imagePullPolicy lines are replaced directly.
+func renderManagerOptions(manifest string, o OperatorDeployOptions) string {
+	if o.CPU != "" {
+		manifest = managerCPURe.ReplaceAllString(manifest, fmt.Sprintf("${1}cpu: %s", o.CPU))
+	}
+	if o.Memory != "" {
+		manifest = managerMemoryRe.ReplaceAllString(manifest, fmt.Sprintf("${1}memory: %s", o.Memory))
+	}
+	if o.ImagePullPolicy != "" {
+		manifest = managerPullPolicyRe.ReplaceAllString(manifest, fmt.Sprintf("${1}imagePullPolicy: %s", o.ImagePullPolicy))
+	}
+	return manifest
+}
+
+// setupConfigFile is the format of the file passed to "setup -f".
+type setupConfigFile struct {
+	Operator struct {
+		Replicas        int    `yaml:"replicas"`
+		CPU             string `yaml:"cpu"`
+		Memory          string `yaml:"memory"`
+		ImagePullPolicy string `yaml:"imagePullPolicy"`
+		Manifest        string `yaml:"manifest"`
+	} `yaml:"operator"`
+}
+
+// applySetupConfigFile fills the operator settings from the config file at path. Flags
+// given on the command line win over the file.
+func applySetupConfigFile(cmd *cobra.Command, path string, replicas *int, o *OperatorDeployOptions) error {
+	// #nosec G304 -- path is provided explicitly by the user.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return wrapWithSentinel(ErrReadSetupConfigFailed, err, fmt.Sprintf("failed to read setup config %s: %v", path, err))
+	}
+	var file setupConfigFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return wrapWithSentinel(ErrReadSetupConfigFailed, err, fmt.Sprintf("failed to parse setup config %s: %v", path, err))
+	}
+
+	changed := cmd.Flags().Changed
+	if file.Operator.Replicas != 0 && !changed("operator-replicas") {
+		*replicas = file.Operator.Replicas
+	}
+	overrides := []struct {
+		flag   string
+		value  string
+		target *string
+	}{
+		{"operator-cpu", file.Operator.CPU, &o.CPU},
+		{"operator-memory", file.Operator.Memory, &o.Memory},
+		{"operator-image-pull-policy", file.Operator.ImagePullPolicy, &o.ImagePullPolicy},
+		{"operator-manifest", file.Operator.Manifest, &o.Manifest},
+	}
+	for _, s := range overrides {
+		if s.value != "" && !changed(s.flag) {
+			*s.target = s.value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const testManagerResources = `        image: mcp-runtime-operator:latest
+        imagePullPolicy: IfNotPresent
+        name: manager
+        resources:
+          limits:
+            cpu: 500m
+            memory: 512Mi
+          requests:
+            cpu: 100m
+            memory: 128Mi
+`
+
+func TestRenderManagerOptions(t *testing.T) {
+	if out := renderManagerOptions(testManagerResources, OperatorDeployOptions{}); out != testManagerResources {
+		t.Fatalf("expected manifest unchanged without options, got:\n%s", out)
+	}
+
+	out := renderManagerOptions(testManagerResources, OperatorDeployOptions{CPU: "2", Memory: "2Gi", ImagePullPolicy: "Always"})
+	if got := strings.Count(out, "cpu: 2\n"); got != 2 {
+		t.Fatalf("expected cpu request and limit set, got %d in:\n%s", got, out)
+	}
+	if got := strings.Count(out, "memory: 2Gi\n"); got != 2 {
+		t.Fatalf("expected memory request and limit set, got %d in:\n%s", got, out)
+	}
+	if !strings.Contains(out, "        imagePullPolicy: Always\n") {
+		t.Fatalf("expected pull policy Always in:\n%s", out)
+	}
+}
+
+func TestRenderManagerOptionsOnManagerYAML(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(repoRootForTest(t), "config", "manager", "manager.yaml"))
+	if err != nil {
+		t.Fatalf("read manager.yaml: %v", err)
+	}
+	out := renderManagerOptions(string(data), OperatorDeployOptions{CPU: "1500m", Memory: "3Gi", ImagePullPolicy: "Never"})
+	for _, want := range []string{"cpu: 1500m", "memory: 3Gi", "imagePullPolicy: Never"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in rendered manager.yaml", want)
+		}
+	}
+	for _, stale := range []string{"cpu: 500m", "cpu: 100m", "memory: 512Mi", "memory: 128Mi", "IfNotPresent"} {
+		if strings.Contains(out, stale) {
+			t.Fatalf("expected %q to be replaced in rendered manager.yaml", stale)
+		}
+	}
+}
+
+func TestOperatorDeployOptionsValidate(t *testing.T) {
+	valid := []OperatorDeployOptions{
+		{},
+		{CPU: "500m", Memory: "1Gi", ImagePullPolicy: "IfNotPresent"},
+	}
+	for _, o := range valid {
+		if err := o.Validate(); err != nil {
+			t.Fatalf("expected %+v to be valid, got %v", o, err)
+		}
+	}
+	invalid := []OperatorDeployOptions{
+		{CPU: "lots"},
+		{Memory: "1GB!"},
+		{ImagePullPolicy: "always"},
+	}
+	for _, o := range invalid {
+		if err := o.Validate(); !errors.Is(err, ErrInvalidOperatorOptions) {
+			t.Fatalf("expected ErrInvalidOperatorOptions for %+v, got %v", o, err)
+		}
+	}
+}
+
+func TestApplySetupConfigFile(t *testing.T) {
+	newCmd := func() (*cobra.Command, *int, *OperatorDeployOptions) {
+		cmd := &cobra.Command{Use: "setup"}
+		replicas := DefaultOperatorReplicas
+		var opts OperatorDeployOptions
+		cmd.Flags().IntVar(&replicas, "operator-replicas", DefaultOperatorReplicas, "")
+		addOperatorDeployFlags(cmd, &opts)
+		return cmd, &replicas, &opts
+	}
+	path := filepath.Join(t.TempDir(), "setup.yaml")
+	config := "operator:\n  replicas: 3\n  cpu: \"2\"\n  memory: 4Gi\n  imagePullPolicy: Always\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	t.Run("fills unset flags", func(t *testing.T) {
+		cmd, replicas, opts := newCmd()
+		if err := applySetupConfigFile(cmd, path, replicas, opts); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+		want := OperatorDeployOptions{CPU: "2", Memory: "4Gi", ImagePullPolicy: "Always"}
+		if *replicas != 3 || *opts != want {
+			t.Fatalf("got replicas=%d options=%+v", *replicas, *opts)
+		}
+	})
+
+	t.Run("flags win", func(t *testing.T) {
+		cmd, replicas, opts := newCmd()
+		if err := cmd.Flags().Parse([]string{"--operator-memory=8Gi", "--operator-replicas=1"}); err != nil {
+			t.Fatalf("parse flags: %v", err)
+		}
+		if err := applySetupConfigFile(cmd, path, replicas, opts); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+		if *replicas != 1 || opts.Memory != "8Gi" || opts.CPU != "2" {
+			t.Fatalf("got replicas=%d options=%+v", *replicas, *opts)
+		}
+	})
+
+	t.Run("rejects unknown keys", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad.yaml")
+		if err := os.WriteFile(bad, []byte("operator:\n  cpus: 2\n"), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		cmd, replicas, opts := newCmd()
+		if err := applySetupConfigFile(cmd, bad, replicas, opts); !errors.Is(err, ErrReadSetupConfigFailed) {
+			t.Fatalf("expected ErrReadSetupConfigFailed, got %v", err)
+		}
+	})
+}
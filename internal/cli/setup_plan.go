@@ -13,6 +13,21 @@ type SetupPlanInput struct {
 	IngressManifestChanged bool
 	ForceIngressInstall    bool
 	TLSEnabled             bool
+	DualIngress            bool
+	Offline                bool
+	ImagesDir              string
+	ImageMirror            string
+	SBOM                   SBOMOptions
+	Observability          bool
+	ExternalDNS            ExternalDNSOptions
+	RegistryAuth           string
+	OperatorReplicas       int
+	Operator               OperatorDeployOptions
+	ServiceIPFamilies      []string
+	NamespaceQuotas        bool
+	Proxy                  ProxyOptions
+	SkipPreflight          bool
+	SummaryFile            string
 }
 
 // SetupPlan captures the resolved setup decisions.
@@ -22,33 +37,67 @@ type SetupPlan struct {
 	Ingress             ingressOptions
 	RegistryManifest    string
 	TLSEnabled          bool
+	DualIngress         bool
+	Offline             bool
+	ImagesDir           string
+	ImageMirror         string
+	SBOM                SBOMOptions
+	Observability       bool
+	ExternalDNS         ExternalDNSOptions
+	RegistryAuth        string
+	OperatorReplicas    int
+	Operator            OperatorDeployOptions
+	ServiceIPFamilies   []string
+	NamespaceQuotas     bool
+	Proxy               ProxyOptions
+	SkipPreflight       bool
+	SummaryFile         string
 }
 
 // BuildSetupPlan resolves CLI inputs into a concrete setup plan.
 func BuildSetupPlan(input SetupPlanInput) SetupPlan {
-	manifestPath := input.IngressManifest
-	if !input.IngressManifestChanged {
-		if input.TLSEnabled {
-			manifestPath = "config/ingress/overlays/prod"
-		} else {
-			manifestPath = "config/ingress/overlays/http"
-		}
-	}
+	// Dual ingress serves TLS next to HTTP, so it needs everything --with-tls sets up.
+	tlsEnabled := input.TLSEnabled || input.DualIngress
 
 	registryManifest := "config/registry"
-	if input.TLSEnabled {
+	if tlsEnabled {
 		registryManifest = "config/registry/overlays/tls"
 	}
 
+	registryAuth := input.RegistryAuth
+	if registryAuth == "" {
+		registryAuth = registryAuthNone
+	}
+
+	operatorReplicas := input.OperatorReplicas
+	if operatorReplicas < 1 {
+		operatorReplicas = DefaultOperatorReplicas
+	}
+
 	return SetupPlan{
 		RegistryType:        input.RegistryType,
 		RegistryStorageSize: input.RegistryStorageSize,
 		Ingress: ingressOptions{
 			mode:     input.IngressMode,
-			manifest: manifestPath,
+			manifest: resolveIngressManifest(input),
 			force:    input.ForceIngressInstall,
 		},
-		RegistryManifest: registryManifest,
-		TLSEnabled:       input.TLSEnabled,
+		RegistryManifest:  registryManifest,
+		TLSEnabled:        tlsEnabled,
+		DualIngress:       input.DualIngress,
+		Offline:           input.Offline || input.ImagesDir != "" || input.ImageMirror != "",
+		ImagesDir:         input.ImagesDir,
+		ImageMirror:       input.ImageMirror,
+		SBOM:              input.SBOM.normalized(),
+		Observability:     input.Observability,
+		ExternalDNS:       input.ExternalDNS,
+		RegistryAuth:      registryAuth,
+		OperatorReplicas:  operatorReplicas,
+		Operator:          input.Operator,
+		ServiceIPFamilies: input.ServiceIPFamilies,
+		NamespaceQuotas:   input.NamespaceQuotas,
+		Proxy:             input.Proxy,
+		SkipPreflight:     input.SkipPreflight,
+		SummaryFile:       input.SummaryFile,
 	}
 }
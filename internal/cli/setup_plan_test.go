@@ -65,6 +65,33 @@ func TestBuildSetupPlan_CustomIngressManifest(t *testing.T) {
 	}
 }
 
+func TestBuildSetupPlan_DualIngress(t *testing.T) {
+	plan := BuildSetupPlan(SetupPlanInput{
+		IngressMode:     "traefik",
+		IngressManifest: "config/ingress/overlays/http",
+		DualIngress:     true,
+	})
+
+	if plan.Ingress.manifest != "config/ingress/overlays/dual" {
+		t.Fatalf("expected dual ingress manifest, got %q", plan.Ingress.manifest)
+	}
+	if !plan.TLSEnabled || !plan.DualIngress {
+		t.Fatalf("expected dual ingress to enable TLS, got %+v", plan)
+	}
+	if plan.RegistryManifest != "config/registry/overlays/tls" {
+		t.Fatalf("expected tls registry manifest, got %q", plan.RegistryManifest)
+	}
+
+	plan = BuildSetupPlan(SetupPlanInput{
+		IngressManifest:        "custom/manifest",
+		IngressManifestChanged: true,
+		DualIngress:            true,
+	})
+	if plan.Ingress.manifest != "custom/manifest" {
+		t.Fatalf("expected explicit ingress manifest to win, got %q", plan.Ingress.manifest)
+	}
+}
+
 type callRecorder struct {
 	calls []string
 	waits []string
@@ -127,6 +154,7 @@ func (f *fakeRegistryManager) PushInCluster(_, _, _ string) error {
 func TestSetupPlatformWithDeps_ExternalRegistry(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return &ExternalRegistryConfig{
 				URL:      "registry.example.com",
@@ -134,10 +162,13 @@ func TestSetupPlatformWithDeps_ExternalRegistry(t *testing.T) {
 				Password: "pass",
 			}, nil
 		},
-		ClusterManager:              &fakeClusterManager{rec: rec},
-		RegistryManager:             &fakeRegistryManager{rec: rec},
-		LoginRegistry:               func(*zap.Logger, string, string, string) error { rec.add("login"); return nil },
-		DeployRegistry:              func(*zap.Logger, string, int, string, string, string) error { rec.add("deploy-registry"); return nil },
+		ClusterManager:  &fakeClusterManager{rec: rec},
+		RegistryManager: &fakeRegistryManager{rec: rec},
+		LoginRegistry:   func(*zap.Logger, string, string, string) error { rec.add("login"); return nil },
+		DeployRegistry: func(*zap.Logger, string, int, string, string, string, []offlineImage) error {
+			rec.add("deploy-registry")
+			return nil
+		},
 		WaitForDeploymentAvailable:  func(_ *zap.Logger, name, _, _ string, _ time.Duration) error { rec.addWait(name); return nil },
 		PrintDeploymentDiagnostics:  func(string, string, string) { rec.add("diagnostics") },
 		SetupTLS:                    func(*zap.Logger) error { rec.add("tls"); return nil },
@@ -146,7 +177,7 @@ func TestSetupPlatformWithDeps_ExternalRegistry(t *testing.T) {
 		EnsureNamespace:             func(string) error { rec.add("ensure-ns"); return nil },
 		GetPlatformRegistryURL:      func(*zap.Logger) string { return "registry.local" },
 		PushOperatorImageToInternal: func(*zap.Logger, string, string, string) error { rec.add("push-internal"); return nil },
-		DeployOperatorManifests:     func(*zap.Logger, string) error { rec.add("deploy-operator"); return nil },
+		DeployOperatorManifests:     func(*zap.Logger, string, int, OperatorDeployOptions) error { rec.add("deploy-operator"); return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error {
 			rec.add("configure-env")
 			return nil
@@ -197,6 +228,7 @@ func TestSetupPlatformWithDeps_ExternalRegistry(t *testing.T) {
 func TestSetupPlatformWithDeps_InternalRegistryTLS(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return nil, nil
 		},
@@ -206,7 +238,10 @@ func TestSetupPlatformWithDeps_InternalRegistryTLS(t *testing.T) {
 			rec.add("login")
 			return nil
 		},
-		DeployRegistry:             func(*zap.Logger, string, int, string, string, string) error { rec.add("deploy-registry"); return nil },
+		DeployRegistry: func(*zap.Logger, string, int, string, string, string, []offlineImage) error {
+			rec.add("deploy-registry")
+			return nil
+		},
 		WaitForDeploymentAvailable: func(_ *zap.Logger, name, _, _ string, _ time.Duration) error { rec.addWait(name); return nil },
 		PrintDeploymentDiagnostics: func(string, string, string) { rec.add("diagnostics") },
 		SetupTLS:                   func(*zap.Logger) error { rec.add("tls"); return nil },
@@ -218,7 +253,7 @@ func TestSetupPlatformWithDeps_InternalRegistryTLS(t *testing.T) {
 			rec.add("push-internal")
 			return nil
 		},
-		DeployOperatorManifests: func(*zap.Logger, string) error { rec.add("deploy-operator"); return nil },
+		DeployOperatorManifests: func(*zap.Logger, string, int, OperatorDeployOptions) error { rec.add("deploy-operator"); return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error {
 			rec.add("configure-env")
 			return nil
@@ -272,6 +307,7 @@ func TestSetupPlatformWithDeps_InternalRegistryTLS(t *testing.T) {
 func TestSetupPlatformWithDeps_ExternalRegistryTLS(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return &ExternalRegistryConfig{
 				URL:      "registry.example.com",
@@ -285,7 +321,10 @@ func TestSetupPlatformWithDeps_ExternalRegistryTLS(t *testing.T) {
 			rec.add("login")
 			return nil
 		},
-		DeployRegistry:             func(*zap.Logger, string, int, string, string, string) error { rec.add("deploy-registry"); return nil },
+		DeployRegistry: func(*zap.Logger, string, int, string, string, string, []offlineImage) error {
+			rec.add("deploy-registry")
+			return nil
+		},
 		WaitForDeploymentAvailable: func(_ *zap.Logger, name, _, _ string, _ time.Duration) error { rec.addWait(name); return nil },
 		PrintDeploymentDiagnostics: func(string, string, string) { rec.add("diagnostics") },
 		SetupTLS:                   func(*zap.Logger) error { rec.add("tls"); return nil },
@@ -297,7 +336,7 @@ func TestSetupPlatformWithDeps_ExternalRegistryTLS(t *testing.T) {
 			rec.add("push-internal")
 			return nil
 		},
-		DeployOperatorManifests: func(*zap.Logger, string) error { rec.add("deploy-operator"); return nil },
+		DeployOperatorManifests: func(*zap.Logger, string, int, OperatorDeployOptions) error { rec.add("deploy-operator"); return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error {
 			rec.add("configure-env")
 			return nil
@@ -348,6 +387,7 @@ func TestSetupPlatformWithDeps_ExternalRegistryTLS(t *testing.T) {
 func TestSetupPlatformWithDeps_DiagnosticsOnRegistryWaitFailure(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return nil, nil
 		},
@@ -357,7 +397,7 @@ func TestSetupPlatformWithDeps_DiagnosticsOnRegistryWaitFailure(t *testing.T) {
 			rec.add("login")
 			return nil
 		},
-		DeployRegistry: func(*zap.Logger, string, int, string, string, string) error {
+		DeployRegistry: func(*zap.Logger, string, int, string, string, string, []offlineImage) error {
 			rec.add("deploy-registry")
 			return nil
 		},
@@ -377,7 +417,7 @@ func TestSetupPlatformWithDeps_DiagnosticsOnRegistryWaitFailure(t *testing.T) {
 		PushOperatorImageToInternal: func(*zap.Logger, string, string, string) error {
 			return nil
 		},
-		DeployOperatorManifests:         func(*zap.Logger, string) error { return nil },
+		DeployOperatorManifests:         func(*zap.Logger, string, int, OperatorDeployOptions) error { return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error { return nil },
 		RestartDeployment:               func(string, string) error { return nil },
 		CheckCRDInstalled:               func(string) error { return nil },
@@ -409,13 +449,14 @@ func TestSetupPlatformWithDeps_DiagnosticsOnRegistryWaitFailure(t *testing.T) {
 func TestSetupPlatformWithDeps_DiagnosticsOnOperatorWaitFailure(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return &ExternalRegistryConfig{URL: "registry.example.com"}, nil
 		},
 		ClusterManager:  &fakeClusterManager{rec: rec},
 		RegistryManager: &fakeRegistryManager{rec: rec},
 		LoginRegistry:   func(*zap.Logger, string, string, string) error { return nil },
-		DeployRegistry:  func(*zap.Logger, string, int, string, string, string) error { return nil },
+		DeployRegistry:  func(*zap.Logger, string, int, string, string, string, []offlineImage) error { return nil },
 		WaitForDeploymentAvailable: func(_ *zap.Logger, name, _, _ string, _ time.Duration) error {
 			rec.addWait(name)
 			if name == "mcp-runtime-operator-controller-manager" {
@@ -432,7 +473,7 @@ func TestSetupPlatformWithDeps_DiagnosticsOnOperatorWaitFailure(t *testing.T) {
 		PushOperatorImageToInternal: func(*zap.Logger, string, string, string) error {
 			return nil
 		},
-		DeployOperatorManifests:         func(*zap.Logger, string) error { return nil },
+		DeployOperatorManifests:         func(*zap.Logger, string, int, OperatorDeployOptions) error { return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error { return nil },
 		RestartDeployment:               func(string, string) error { return nil },
 		CheckCRDInstalled:               func(string) error { return nil },
@@ -467,13 +508,14 @@ func TestSetupPlatformWithDeps_DiagnosticsOnOperatorWaitFailure(t *testing.T) {
 func TestSetupPlatformWithDeps_CRDCheckFailure(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return &ExternalRegistryConfig{URL: "registry.example.com"}, nil
 		},
 		ClusterManager:  &fakeClusterManager{rec: rec},
 		RegistryManager: &fakeRegistryManager{rec: rec},
 		LoginRegistry:   func(*zap.Logger, string, string, string) error { return nil },
-		DeployRegistry:  func(*zap.Logger, string, int, string, string, string) error { return nil },
+		DeployRegistry:  func(*zap.Logger, string, int, string, string, string, []offlineImage) error { return nil },
 		WaitForDeploymentAvailable: func(_ *zap.Logger, name, _, _ string, _ time.Duration) error {
 			rec.addWait(name)
 			return nil
@@ -487,7 +529,7 @@ func TestSetupPlatformWithDeps_CRDCheckFailure(t *testing.T) {
 		PushOperatorImageToInternal: func(*zap.Logger, string, string, string) error {
 			return nil
 		},
-		DeployOperatorManifests:         func(*zap.Logger, string) error { return nil },
+		DeployOperatorManifests:         func(*zap.Logger, string, int, OperatorDeployOptions) error { return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error { return nil },
 		RestartDeployment:               func(string, string) error { return nil },
 		CheckCRDInstalled: func(string) error {
@@ -524,13 +566,14 @@ func TestSetupPlatformWithDeps_CRDCheckFailure(t *testing.T) {
 func TestSetupPlatformWithDeps_InternalRegistryPushFailure(t *testing.T) {
 	rec := &callRecorder{}
 	deps := SetupDeps{
+		Preflight: func(*zap.Logger, preflightOptions) error { return nil },
 		ResolveExternalRegistryConfig: func(*ExternalRegistryConfig) (*ExternalRegistryConfig, error) {
 			return nil, nil
 		},
 		ClusterManager:  &fakeClusterManager{rec: rec},
 		RegistryManager: &fakeRegistryManager{rec: rec},
 		LoginRegistry:   func(*zap.Logger, string, string, string) error { return nil },
-		DeployRegistry:  func(*zap.Logger, string, int, string, string, string) error { return nil },
+		DeployRegistry:  func(*zap.Logger, string, int, string, string, string, []offlineImage) error { return nil },
 		WaitForDeploymentAvailable: func(_ *zap.Logger, name, _, _ string, _ time.Duration) error {
 			rec.addWait(name)
 			return nil
@@ -545,7 +588,7 @@ func TestSetupPlatformWithDeps_InternalRegistryPushFailure(t *testing.T) {
 			rec.add("push-internal")
 			return fmt.Errorf("push failed")
 		},
-		DeployOperatorManifests:         func(*zap.Logger, string) error { rec.add("deploy-operator"); return nil },
+		DeployOperatorManifests:         func(*zap.Logger, string, int, OperatorDeployOptions) error { rec.add("deploy-operator"); return nil },
 		ConfigureProvisionedRegistryEnv: func(*ExternalRegistryConfig, string) error { return nil },
 		RestartDeployment:               func(string, string) error { return nil },
 		CheckCRDInstalled:               func(string) error { return nil },
@@ -0,0 +1,279 @@
+package cli
+
+// This file implements the setup pre-flight checks. Before setup applies anything it verifies
+// that the API server version is supported, that the API groups the platform relies on are
+// served, whether admission policies are available, that the registry PVC can be bound and that requested Service IP families are
+// available, so an unsuitable cluster fails up front with a fix instead of half-way through
+// the install.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Supported Kubernetes minor versions (1.x). Older servers fail the pre-flight; newer ones
+// only warn, since they are expected to work but have not been tested.
+const (
+	minKubernetesMinor = 26
+	maxKubernetesMinor = 34
+)
+
+// defaultStorageClassAnnotations mark the default StorageClass; the beta key is still set by
+// some provisioners.
+var defaultStorageClassAnnotations = []string{
+	"storageclass.kubernetes.io/is-default-class",
+	"storageclass.beta.kubernetes.io/is-default-class",
+}
+
+// requiredAPIVersions are the group versions of resources setup and the operator create:
+// Ingresses for MCP servers and Jobs for in-cluster image pushes and builds.
+var requiredAPIVersions = []string{"networking.k8s.io/v1", "batch/v1"}
+
+// Pre-flight check results.
+const (
+	preflightPass = "ok"
+	preflightWarn = "warn"
+	preflightFail = "fail"
+)
+
+// preflightCheck is the outcome of a single pre-flight check.
+type preflightCheck struct {
+	name   string
+	result string
+	detail string
+}
+
+// preflightOptions selects the optional pre-flight checks.
+type preflightOptions struct {
+	// RegistryStorage checks that the internal registry PVC can be bound.
+	RegistryStorage bool
+	// ServiceIPFamilies checks that the cluster serves these Service IP families.
+	ServiceIPFamilies []string
+	// NoProxy checks that these NO_PROXY entries cover the API server's Service address.
+	NoProxy []string
+}
+
+type preflightStep struct{}
+
+func (s preflightStep) Name() string { return "preflight" }
+func (s preflightStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
+	opts := preflightOptions{
+		RegistryStorage:   !ctx.UsingExternalRegistry,
+		ServiceIPFamilies: ctx.Plan.ServiceIPFamilies,
+	}
+	if ctx.Plan.Proxy.URL != "" {
+		opts.NoProxy = ctx.Plan.Proxy.NoProxy
+	}
+	return setupPreflightStep(logger, opts, deps)
+}
+
+func setupPreflightStep(logger *zap.Logger, opts preflightOptions, deps SetupDeps) error {
+	// Step 0: Check the cluster before changing it
+	Step("Step 0: Pre-flight checks")
+	if err := deps.Preflight(logger, opts); err != nil {
+		Error("Pre-flight checks failed")
+		logStructuredError(logger, err, "Pre-flight checks failed")
+		return err
+	}
+	Success("Cluster meets the setup requirements")
+	return nil
+}
+
+func runSetupPreflight(logger *zap.Logger, opts preflightOptions) error {
+	return runSetupPreflightWithKubectl(kubectlClient, opts)
+}
+
+// runSetupPreflightWithKubectl runs every check, prints the results, and fails with all
+// failing checks at once so they can be fixed in one go.
+func runSetupPreflightWithKubectl(kubectl KubectlRunner, opts preflightOptions) error {
+	checks := []preflightCheck{
+		checkServerVersion(kubectl),
+		checkAPIResources(kubectl),
+		checkAdmissionPolicies(kubectl),
+	}
+	if opts.RegistryStorage {
+		checks = append(checks, checkRegistryStorageClass(kubectl))
+	}
+	if len(opts.ServiceIPFamilies) > 0 {
+		checks = append(checks, checkServiceIPFamilies(kubectl, opts.ServiceIPFamilies))
+	}
+	if len(opts.NoProxy) > 0 {
+		checks = append(checks, checkNoProxy(kubectl, opts.NoProxy))
+	}
+
+	rows := [][]string{{"Check", "Result", "Detail"}}
+	var failures []string
+	for _, check := range checks {
+		rows = append(rows, []string{check.name, check.result, check.detail})
+		switch check.result {
+		case preflightFail:
+			failures = append(failures, check.name+": "+check.detail)
+		case preflightWarn:
+			Warn(check.name + ": " + check.detail)
+		}
+	}
+	Table(rows)
+
+	if len(failures) > 0 {
+		return newWithSentinel(
+			ErrPreflightFailed,
+			fmt.Sprintf("pre-flight checks failed (use --skip-preflight to bypass):\n  - %s", strings.Join(failures, "\n  - ")),
+		)
+	}
+	return nil
+}
+
+// checkServerVersion verifies the API server is reachable and within the supported range.
+func checkServerVersion(kubectl KubectlRunner) preflightCheck {
+	check := preflightCheck{name: "Kubernetes version"}
+	out, err := kubectlOutput(kubectl, []string{"version", "-o", "json"})
+	var version struct {
+		ServerVersion *struct {
+			Major      string `json:"major"`
+			Minor      string `json:"minor"`
+			GitVersion string `json:"gitVersion"`
+		} `json:"serverVersion"`
+	}
+	// kubectl version exits non-zero when the server is unreachable but still prints the client.
+	if jsonErr := json.Unmarshal(out, &version); jsonErr != nil || version.ServerVersion == nil {
+		check.result = preflightFail
+		check.detail = "cannot reach the API server; check the current kubectl context and credentials"
+		if err != nil {
+			check.detail += fmt.Sprintf(" (%v)", err)
+		}
+		return check
+	}
+
+	server := version.ServerVersion
+	major, majorErr := strconv.Atoi(server.Major)
+	minor, minorErr := strconv.Atoi(strings.TrimSuffix(server.Minor, "+"))
+	if majorErr != nil || minorErr != nil {
+		check.result = preflightWarn
+		check.detail = fmt.Sprintf("could not parse server version %q", server.GitVersion)
+		return check
+	}
+	check.detail = server.GitVersion
+	switch {
+	case major < 1 || (major == 1 && minor < minKubernetesMinor):
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("server %s is older than the supported minimum 1.%d; upgrade the cluster", server.GitVersion, minKubernetesMinor)
+	case major > 1 || minor > maxKubernetesMinor:
+		check.result = preflightWarn
+		check.detail = fmt.Sprintf("server %s is newer than the latest tested 1.%d", server.GitVersion, maxKubernetesMinor)
+	default:
+		check.result = preflightPass
+	}
+	return check
+}
+
+// checkAPIResources verifies the API groups used for ingress and in-cluster jobs are served.
+func checkAPIResources(kubectl KubectlRunner) preflightCheck {
+	check := preflightCheck{name: "API resources"}
+	out, err := kubectlOutput(kubectl, []string{"api-versions"})
+	if err != nil {
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("cannot list API versions: %v", err)
+		return check
+	}
+	served := make(map[string]bool)
+	for _, line := range strings.Split(string(out), "\n") {
+		served[strings.TrimSpace(line)] = true
+	}
+	var missing []string
+	for _, groupVersion := range requiredAPIVersions {
+		if !served[groupVersion] {
+			missing = append(missing, groupVersion)
+		}
+	}
+	if len(missing) > 0 {
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("the API server does not serve %s; upgrade the cluster to 1.%d or newer", strings.Join(missing, ", "), minKubernetesMinor)
+		return check
+	}
+	check.result = preflightPass
+	check.detail = strings.Join(requiredAPIVersions, ", ")
+	return check
+}
+
+// checkAdmissionPolicies reports whether setup can install the delete protection policy. It
+// only warns: without ValidatingAdmissionPolicy setup skips the policy and the CLI check applies.
+func checkAdmissionPolicies(kubectl KubectlRunner) preflightCheck {
+	check := preflightCheck{name: "Admission policies"}
+	served, err := admissionPoliciesServed(kubectl)
+	switch {
+	case err != nil:
+		check.result = preflightWarn
+		check.detail = fmt.Sprintf("cannot check %s, skipping the delete protection policy: %v", admissionPolicyAPI, err)
+	case !served:
+		check.result = preflightWarn
+		check.detail = fmt.Sprintf("%s does not serve ValidatingAdmissionPolicy (Kubernetes 1.30+), skipping the delete protection policy", admissionPolicyAPI)
+	default:
+		check.result = preflightPass
+		check.detail = "ValidatingAdmissionPolicy"
+	}
+	return check
+}
+
+// checkRegistryStorageClass verifies the registry PVC, which names no StorageClass, can be
+// bound: either it is already bound from an earlier install or a default StorageClass exists.
+func checkRegistryStorageClass(kubectl KubectlRunner) preflightCheck {
+	check := preflightCheck{name: "Registry storage"}
+	phase, err := kubectlOutput(kubectl, []string{"get", "pvc", RegistryPVCName, "-n", NamespaceRegistry, "-o", "jsonpath={.status.phase}", "--ignore-not-found"})
+	if err == nil && strings.TrimSpace(string(phase)) == "Bound" {
+		check.result = preflightPass
+		check.detail = fmt.Sprintf("PVC %s/%s is already bound", NamespaceRegistry, RegistryPVCName)
+		return check
+	}
+
+	out, err := kubectlOutput(kubectl, []string{"get", "storageclass", "-o", "json"})
+	if err != nil {
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("cannot list StorageClasses: %v", err)
+		return check
+	}
+	var classes struct {
+		Items []struct {
+			Metadata struct {
+				Name        string            `json:"name"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(out, &classes); err != nil {
+		check.result = preflightFail
+		check.detail = fmt.Sprintf("cannot parse StorageClasses: %v", err)
+		return check
+	}
+	for _, class := range classes.Items {
+		for _, key := range defaultStorageClassAnnotations {
+			if class.Metadata.Annotations[key] == "true" {
+				check.result = preflightPass
+				check.detail = "default StorageClass " + class.Metadata.Name
+				return check
+			}
+		}
+	}
+
+	check.result = preflightFail
+	check.detail = fmt.Sprintf("no default StorageClass, so the registry PVC %s would stay Pending; ", RegistryPVCName)
+	if len(classes.Items) == 0 {
+		check.detail += "install a storage provisioner or use an external registry"
+	} else {
+		check.detail += fmt.Sprintf("mark one as default: kubectl patch storageclass %s -p '{\"metadata\":{\"annotations\":{\"storageclass.kubernetes.io/is-default-class\":\"true\"}}}'", classes.Items[0].Metadata.Name)
+	}
+	return check
+}
+
+// kubectlOutput runs kubectl with args and returns its stdout.
+func kubectlOutput(kubectl KubectlRunner, args []string) ([]byte, error) {
+	// #nosec G204 -- fixed read-only kubectl arguments.
+	cmd, err := kubectl.CommandArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.Output()
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const (
+	preflightVersionJSON = `{"clientVersion":{"major":"1","minor":"34"},"serverVersion":{"major":"1","minor":"%s","gitVersion":"v1.%s.0"}}`
+	preflightAPIVersions = "apps/v1\nbatch/v1\nnetworking.k8s.io/v1\nv1\n"
+	preflightDefaultSC   = `{"items":[{"metadata":{"name":"standard","annotations":{"storageclass.kubernetes.io/is-default-class":"true"}}}]}`
+	preflightAdmission   = `{"resources":[{"name":"validatingadmissionpolicies"},{"name":"validatingwebhookconfigurations"}]}`
+)
+
+// preflightMock answers the pre-flight kubectl calls by their first argument.
+func preflightMock(minor, apiVersions, storageClasses string) *MockExecutor {
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		switch {
+		case spec.Args[0] == "version":
+			return &MockCommand{OutputData: []byte(strings.ReplaceAll(preflightVersionJSON, "%s", minor))}
+		case spec.Args[0] == "api-versions":
+			return &MockCommand{OutputData: []byte(apiVersions)}
+		case contains(spec.Args, "--raw"):
+			return &MockCommand{OutputData: []byte(preflightAdmission)}
+		case contains(spec.Args, "storageclass"):
+			return &MockCommand{OutputData: []byte(storageClasses)}
+		}
+		return &MockCommand{}
+	}
+	return mock
+}
+
+func TestRunSetupPreflight(t *testing.T) {
+	tests := []struct {
+		name            string
+		mock            *MockExecutor
+		checkStorage    bool
+		wantErr         []string
+		wantWarn        string
+		wantNoStorageSC bool
+	}{
+		{name: "supported cluster", mock: preflightMock("30", preflightAPIVersions, preflightDefaultSC), checkStorage: true},
+		{name: "newer than tested", mock: preflightMock("40+", preflightAPIVersions, preflightDefaultSC), checkStorage: true, wantWarn: "newer than the latest tested"},
+		{
+			name:         "old cluster without a default StorageClass",
+			mock:         preflightMock("24", "apps/v1\nv1\n", `{"items":[{"metadata":{"name":"gp2"}}]}`),
+			checkStorage: true,
+			wantErr:      []string{"older than the supported minimum 1.26", "networking.k8s.io/v1, batch/v1", "kubectl patch storageclass gp2"},
+		},
+		{
+			name:            "external registry skips storage",
+			mock:            preflightMock("30", preflightAPIVersions, `{"items":[]}`),
+			wantNoStorageSC: true,
+		},
+		{
+			name:    "unreachable API server",
+			mock:    &MockExecutor{DefaultOutput: []byte(`{"clientVersion":{"major":"1","minor":"34"}}`), DefaultErr: errors.New("exit status 1")},
+			wantErr: []string{"cannot reach the API server", "cannot list API versions"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			setDefaultPrinterWriter(t, &buf)
+			err := runSetupPreflightWithKubectl(&KubectlClient{exec: tt.mock}, preflightOptions{RegistryStorage: tt.checkStorage})
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tt.wantErr) > 0 && !errors.Is(err, ErrPreflightFailed) {
+				t.Fatalf("expected ErrPreflightFailed, got %v", err)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected %q in %v", want, err)
+				}
+			}
+			if tt.wantWarn != "" && !strings.Contains(buf.String(), tt.wantWarn) {
+				t.Errorf("expected warning %q in output:\n%s", tt.wantWarn, buf.String())
+			}
+			if tt.wantNoStorageSC {
+				for _, cmd := range tt.mock.Commands {
+					if contains(cmd.Args, "storageclass") || contains(cmd.Args, "pvc") {
+						t.Fatalf("unexpected storage lookup %v", cmd.Args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRunSetupPreflightBoundRegistryPVC(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	mock := preflightMock("30", preflightAPIVersions, `{"items":[]}`)
+	lookup := mock.CommandFunc
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		if contains(spec.Args, "pvc") {
+			return &MockCommand{OutputData: []byte("Bound")}
+		}
+		return lookup(spec)
+	}
+	if err := runSetupPreflightWithKubectl(&KubectlClient{exec: mock}, preflightOptions{RegistryStorage: true}); err != nil {
+		t.Fatalf("a bound registry PVC needs no default StorageClass, got %v", err)
+	}
+}
+
+func TestCheckAdmissionPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"served", preflightAdmission, preflightPass},
+		{"before 1.30", `{"resources":[{"name":"validatingwebhookconfigurations"}]}`, preflightWarn},
+		{"unparsable", "", preflightWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockExecutor{DefaultOutput: []byte(tt.output)}
+			check := checkAdmissionPolicies(&KubectlClient{exec: mock})
+			if check.result != tt.want {
+				t.Fatalf("result = %s (%s), want %s", check.result, check.detail, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSetupStepsPreflight(t *testing.T) {
+	steps := buildSetupSteps(&SetupContext{})
+	if steps[0].Name() != "preflight" {
+		t.Fatalf("expected preflight to run first, got %s", steps[0].Name())
+	}
+	for _, step := range buildSetupSteps(&SetupContext{Plan: SetupPlan{SkipPreflight: true}}) {
+		if step.Name() == "preflight" {
+			t.Fatal("--skip-preflight should drop the preflight step")
+		}
+	}
+}
+
+func TestPreflightStep(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	var checkedStorage bool
+	deps := SetupDeps{Preflight: func(_ *zap.Logger, opts preflightOptions) error {
+		checkedStorage = opts.RegistryStorage
+		return nil
+	}}
+	if err := (preflightStep{}).Run(zap.NewNop(), deps, &SetupContext{UsingExternalRegistry: true}); err != nil || checkedStorage {
+		t.Fatalf("external registry: err=%v checkedStorage=%v", err, checkedStorage)
+	}
+	if err := (preflightStep{}).Run(zap.NewNop(), deps, &SetupContext{}); err != nil || !checkedStorage {
+		t.Fatalf("internal registry: err=%v checkedStorage=%v", err, checkedStorage)
+	}
+
+	deps.Preflight = func(*zap.Logger, preflightOptions) error {
+		return newWithSentinel(ErrPreflightFailed, "no default StorageClass")
+	}
+	if err := (preflightStep{}).Run(zap.NewNop(), deps, &SetupContext{}); !errors.Is(err, ErrPreflightFailed) {
+		t.Fatalf("expected ErrPreflightFailed, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+// This file implements "setup --profile". A profile is a named bundle of setup flag values
+// for a common kind of installation. It only fills in flags that were not given, and the
+// setup config file (-f) is applied after it, so both explicit flags and the config file
+// win over the profile.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// setupProfile is a named set of setup flag values.
+type setupProfile struct {
+	name        string
+	description string
+	// flags holds flag name and value pairs, in the order they are reported.
+	flags [][2]string
+}
+
+// setupProfiles are the profiles accepted by --profile.
+var setupProfiles = []setupProfile{
+	{
+		name:        "dev",
+		description: "local kind or minikube clusters over plain HTTP",
+		flags: [][2]string{
+			{"with-tls", "false"},
+			{"registry-storage", "5Gi"},
+			{"operator-replicas", "1"},
+		},
+	},
+	{
+		name:        "staging",
+		description: "shared test clusters with TLS and monitoring",
+		flags: [][2]string{
+			{"with-tls", "true"},
+			{"registry-storage", "50Gi"},
+			{"operator-replicas", "2"},
+			{"with-observability", "true"},
+		},
+	},
+	{
+		name:        "prod",
+		description: "production clusters with TLS, quotas and monitoring",
+		flags: [][2]string{
+			{"with-tls", "true"},
+			{"registry-storage", "200Gi"},
+			{"operator-replicas", "3"},
+			{"with-namespace-quotas", "true"},
+			{"with-observability", "true"},
+		},
+	},
+}
+
+func setupProfileNames() []string {
+	names := make([]string, 0, len(setupProfiles))
+	for _, p := range setupProfiles {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+// applySetupProfile sets the flags of the named profile that were not given on the command
+// line and returns the settings it applied. The values are set without marking the flags
+// as changed, so the setup config file still overrides them.
+func applySetupProfile(cmd *cobra.Command, name string) ([]string, error) {
+	var profile *setupProfile
+	for i := range setupProfiles {
+		if setupProfiles[i].name == name {
+			profile = &setupProfiles[i]
+		}
+	}
+	if profile == nil {
+		return nil, newWithSentinel(ErrInvalidSetupProfile, fmt.Sprintf("unknown setup profile %q (use one of: %s)", name, strings.Join(setupProfileNames(), ", ")))
+	}
+	var applied []string
+	for _, f := range profile.flags {
+		flag := cmd.Flags().Lookup(f[0])
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err := flag.Value.Set(f[1]); err != nil {
+			return nil, wrapWithSentinel(ErrInvalidSetupProfile, err, fmt.Sprintf("profile %s: invalid --%s %q: %v", name, f[0], f[1], err))
+		}
+		applied = append(applied, fmt.Sprintf("--%s=%s", f[0], f[1]))
+	}
+	return applied, nil
+}
+
+// setupProfileHelp describes the profiles for the setup help text.
+func setupProfileHelp() string {
+	var b strings.Builder
+	for _, p := range setupProfiles {
+		settings := make([]string, 0, len(p.flags))
+		for _, f := range p.flags {
+			settings = append(settings, fmt.Sprintf("--%s=%s", f[0], f[1]))
+		}
+		fmt.Fprintf(&b, "  %-8s %s\n           %s\n", p.name, p.description, strings.Join(settings, " "))
+	}
+	return b.String()
+}
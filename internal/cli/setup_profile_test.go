@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestApplySetupProfile(t *testing.T) {
+	cmd := NewSetupCmd(zap.NewNop())
+	if err := cmd.ParseFlags([]string{"--profile", "prod", "--operator-replicas", "5"}); err != nil {
+		t.Fatal(err)
+	}
+	applied, err := applySetupProfile(cmd, "prod")
+	if err != nil {
+		t.Fatalf("applySetupProfile() error: %v", err)
+	}
+	if got := strings.Join(applied, " "); got != "--with-tls=true --registry-storage=200Gi --with-namespace-quotas=true --with-observability=true" {
+		t.Fatalf("unexpected applied flags %q", got)
+	}
+	flags := cmd.Flags()
+	for name, want := range map[string]string{
+		"with-tls":              "true",
+		"registry-storage":      "200Gi",
+		"operator-replicas":     "5",
+		"with-namespace-quotas": "true",
+	} {
+		if got := flags.Lookup(name).Value.String(); got != want {
+			t.Errorf("--%s = %q, want %q", name, got, want)
+		}
+	}
+	if flags.Changed("registry-storage") {
+		t.Fatal("profile values must not count as explicit flags")
+	}
+
+	if _, err := applySetupProfile(NewSetupCmd(zap.NewNop()), "qa"); !errors.Is(err, ErrInvalidSetupProfile) {
+		t.Fatalf("expected ErrInvalidSetupProfile, got %v", err)
+	}
+}
+
+func TestSetupProfileConfigFileOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "setup.yaml")
+	if err := os.WriteFile(path, []byte("operator:\n  replicas: 4\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := NewSetupCmd(zap.NewNop())
+	if err := cmd.ParseFlags([]string{"--profile", "dev"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := applySetupProfile(cmd, "dev"); err != nil {
+		t.Fatal(err)
+	}
+	replicas, err := cmd.Flags().GetInt("operator-replicas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replicas != 1 {
+		t.Fatalf("dev profile should run one operator replica, got %d", replicas)
+	}
+	var o OperatorDeployOptions
+	if err := applySetupConfigFile(cmd, path, &replicas, &o); err != nil {
+		t.Fatal(err)
+	}
+	if replicas != 4 {
+		t.Fatalf("config file should override the profile, got %d replicas", replicas)
+	}
+}
+
+func TestNamespaceQuotasStep(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	called := false
+	deps := SetupDeps{ConfigureNamespaceQuotas: func() error { called = true; return nil }}
+	if err := (namespaceQuotasStep{}).Run(zap.NewNop(), deps, &SetupContext{}); err != nil || !called {
+		t.Fatalf("Run() = %v, called = %v", err, called)
+	}
+	deps.ConfigureNamespaceQuotas = func() error { return errors.New("forbidden") }
+	if err := (namespaceQuotasStep{}).Run(zap.NewNop(), deps, &SetupContext{}); !errors.Is(err, ErrConfigureNamespaceQuotasFailed) {
+		t.Fatalf("expected ErrConfigureNamespaceQuotasFailed, got %v", err)
+	}
+
+	mock := &MockExecutor{}
+	if err := configureNamespaceQuotasWithKubectl(&KubectlClient{exec: mock}); err != nil {
+		t.Fatalf("configureNamespaceQuotasWithKubectl() error: %v", err)
+	}
+	if !commandHasArgs(mock.LastCommand(), "set", "env", "MCP_NAMESPACE_QUOTA=true") {
+		t.Fatalf("unexpected command %v", mock.LastCommand().Args)
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+// This file renders setup progress: a spinner with elapsed time while each step runs,
+// the step duration when it ends, and a summary table of all steps at the end.
+// With --plain (or when output is not a terminal) spinners become plain log lines, and any
+// output a step prints stops its spinner first so the two do not garble each other.
+
+import (
+	"fmt"
+	"time"
+)
+
+// Step outcomes shown in the setup summary.
+const (
+	stepStatusDone    = "done"
+	stepStatusFailed  = "failed"
+	stepStatusSkipped = "not run"
+)
+
+// stepResult records how one setup step ended.
+type stepResult struct {
+	Name     string
+	Status   string
+	Duration time.Duration
+}
+
+// stepProgress times setup steps and renders their progress.
+type stepProgress struct {
+	printer *Printer
+	// now is a test seam for the clock.
+	now     func() time.Time
+	results []stepResult
+}
+
+func newStepProgress(printer *Printer) *stepProgress {
+	return &stepProgress{printer: printer, now: time.Now}
+}
+
+// Run runs fn as the named step with a spinner and records its outcome.
+func (p *stepProgress) Run(name string, fn func() error) error {
+	start := p.now()
+	stop := p.printer.SpinnerStart(fmt.Sprintf("Running step %s", name))
+	err := fn()
+	duration := p.now().Sub(start)
+
+	result := stepResult{Name: name, Status: stepStatusDone, Duration: duration}
+	if err != nil {
+		result.Status = stepStatusFailed
+		stop(false, fmt.Sprintf("Step %s failed after %s", name, formatStepDuration(duration)))
+	} else {
+		stop(true, fmt.Sprintf("Step %s done in %s", name, formatStepDuration(duration)))
+	}
+	p.results = append(p.results, result)
+	return err
+}
+
+// Skip records steps that did not run because an earlier step failed.
+func (p *stepProgress) Skip(names ...string) {
+	for _, name := range names {
+		p.results = append(p.results, stepResult{Name: name, Status: stepStatusSkipped})
+	}
+}
+
+// Summary prints a table of step outcomes and durations.
+func (p *stepProgress) Summary() {
+	if len(p.results) == 0 {
+		return
+	}
+	rows := [][]string{{"Step", "Status", "Duration"}}
+	var total time.Duration
+	for _, r := range p.results {
+		duration := "-"
+		if r.Status != stepStatusSkipped {
+			duration = formatStepDuration(r.Duration)
+			total += r.Duration
+		}
+		rows = append(rows, []string{r.Name, r.Status, duration})
+	}
+	rows = append(rows, []string{"total", "", formatStepDuration(total)})
+	p.printer.Section("Setup summary")
+	p.printer.TableBoxed(rows)
+}
+
+// formatStepDuration rounds d for display: tenths of a second under a minute, whole seconds above.
+func formatStepDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeClock advances by step on every call.
+func fakeClock(step time.Duration) func() time.Time {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestStepProgress(t *testing.T) {
+	var buf bytes.Buffer
+	progress := newStepProgress(&Printer{Writer: &buf, Plain: true})
+	progress.now = fakeClock(1500 * time.Millisecond)
+
+	if err := progress.Run("cluster", func() error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	boom := errors.New("boom")
+	if err := progress.Run("registry", func() error { return boom }); !errors.Is(err, boom) {
+		t.Fatalf("expected step error, got %v", err)
+	}
+	progress.Skip("verify")
+	progress.Summary()
+
+	out := buf.String()
+	for _, want := range []string{
+		"Running step cluster",
+		"Step cluster done in 1.5s",
+		"Step registry failed after 1.5s",
+		"Setup summary",
+		"not run",
+		"3s",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatStepDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		1234 * time.Millisecond:               "1.2s",
+		90*time.Second + 400*time.Millisecond: "1m30s",
+	}
+	for d, want := range cases {
+		if got := formatStepDuration(d); got != want {
+			t.Fatalf("formatStepDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestRunSetupStepsSummarySkipsRemainingSteps(t *testing.T) {
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	steps := []SetupStep{
+		fakeSetupStep{name: "cluster"},
+		fakeSetupStep{name: "registry", err: errors.New("boom")},
+		fakeSetupStep{name: "verify"},
+	}
+	if err := runSetupSteps(zap.NewNop(), SetupDeps{}, &SetupContext{}, steps); !errors.Is(err, ErrSetupStepFailed) {
+		t.Fatalf("expected ErrSetupStepFailed, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "Setup summary") || !strings.Contains(buf.String(), stepStatusSkipped) {
+		t.Fatalf("expected summary with skipped step:\n%s", buf.String())
+	}
+}
+
+type fakeSetupStep struct {
+	name string
+	err  error
+}
+
+func (s fakeSetupStep) Name() string { return s.name }
+func (s fakeSetupStep) Run(*zap.Logger, SetupDeps, *SetupContext) error {
+	return s.err
+}
@@ -16,6 +16,9 @@ type SetupContext struct {
 	UsingExternalRegistry bool
 	RegistrySecretName    string
 	OperatorImage         string
+	// OfflineImages are the mirrored images of an offline setup; manifests applied by later
+	// steps retarget their Source references at Target. Empty outside offline mode.
+	OfflineImages []offlineImage
 }
 
 // SetupStep models a single setup phase.
@@ -53,7 +56,9 @@ type clusterStep struct{}
 
 func (s clusterStep) Name() string { return "cluster" }
 func (s clusterStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
-	return setupClusterSteps(logger, ctx.Plan.Ingress, deps)
+	ingress := ctx.Plan.Ingress
+	ingress.images = ctx.OfflineImages
+	return setupClusterSteps(logger, ingress, deps)
 }
 
 type tlsStep struct{}
@@ -75,6 +80,7 @@ func (s registryStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext)
 		ctx.Plan.RegistryStorageSize,
 		ctx.Plan.RegistryManifest,
 		ctx.Plan.TLSEnabled,
+		ctx.OfflineImages,
 		deps,
 	)
 }
@@ -87,6 +93,7 @@ func (s operatorImageStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupCon
 		logger,
 		ctx.ExternalRegistry,
 		ctx.UsingExternalRegistry,
+		ctx.Plan.SBOM,
 		deps,
 	)
 	if err != nil {
@@ -103,6 +110,8 @@ func (s deployOperatorStepCmd) Run(logger *zap.Logger, deps SetupDeps, ctx *Setu
 	return deployOperatorStep(
 		logger,
 		ctx.OperatorImage,
+		ctx.Plan.OperatorReplicas,
+		ctx.Plan.Operator,
 		ctx.ExternalRegistry,
 		ctx.RegistrySecretName,
 		ctx.UsingExternalRegistry,
@@ -114,7 +123,7 @@ type verifyStep struct{}
 
 func (s verifyStep) Name() string { return "verify" }
 func (s verifyStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) error {
-	if err := verifySetup(ctx.UsingExternalRegistry, deps); err != nil {
+	if err := verifySetup(ctx.UsingExternalRegistry, ctx.Plan.OperatorReplicas, deps); err != nil {
 		Error("Post-setup verification failed")
 		logStructuredError(logger, err, "Post-setup verification failed")
 		return err
@@ -124,18 +133,32 @@ func (s verifyStep) Run(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) e
 
 func buildSetupSteps(ctx *SetupContext) []SetupStep {
 	return NewSetupPipeline().
+		WithIf(!ctx.Plan.SkipPreflight, preflightStep{}).
+		WithIf(ctx.Plan.Offline, offlineImageStep{}).
 		With(clusterStep{}).
 		WithIf(ctx.Plan.TLSEnabled, tlsStep{}).
 		With(registryStep{}).
-		With(operatorImageStep{}).
+		WithIf(!ctx.Plan.Offline, operatorImageStep{}).
 		With(deployOperatorStepCmd{}).
+		WithIf(ctx.Plan.RegistryAuth == registryAuthHtpasswd, registryAuthStep{}).
+		WithIf(ctx.Plan.DualIngress, dualIngressStep{}).
+		WithIf(len(ctx.Plan.ServiceIPFamilies) > 0, serviceIPFamiliesStep{}).
+		WithIf(ctx.Plan.NamespaceQuotas, namespaceQuotasStep{}).
+		WithIf(ctx.Plan.Proxy.URL != "", proxyStep{}).
 		With(verifyStep{}).
+		WithIf(ctx.Plan.Observability, observabilityStep{}).
+		WithIf(ctx.Plan.ExternalDNS.Enabled, externalDNSStep{}).
 		Build()
 }
 
 func runSetupSteps(logger *zap.Logger, deps SetupDeps, ctx *SetupContext, steps []SetupStep) error {
-	for _, step := range steps {
-		if err := step.Run(logger, deps, ctx); err != nil {
+	progress := newStepProgress(DefaultPrinter)
+	for i, step := range steps {
+		if err := progress.Run(step.Name(), func() error { return step.Run(logger, deps, ctx) }); err != nil {
+			for _, pending := range steps[i+1:] {
+				progress.Skip(pending.Name())
+			}
+			progress.Summary()
 			wrappedErr := wrapWithSentinelAndContext(
 				ErrSetupStepFailed,
 				err,
@@ -147,5 +170,6 @@ func runSetupSteps(logger *zap.Logger, deps SetupDeps, ctx *SetupContext, steps
 			return wrappedErr
 		}
 	}
+	progress.Summary()
 	return nil
 }
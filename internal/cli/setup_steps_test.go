@@ -28,8 +28,8 @@ func TestBuildSetupStepsOrderWithTLS(t *testing.T) {
 		},
 	}
 	steps := buildSetupSteps(ctx)
-	if len(steps) != 6 {
-		t.Fatalf("expected 6 steps, got %d", len(steps))
+	if len(steps) != 7 {
+		t.Fatalf("expected 7 steps, got %d", len(steps))
 	}
 
 	got := []string{
@@ -39,8 +39,9 @@ func TestBuildSetupStepsOrderWithTLS(t *testing.T) {
 		steps[3].Name(),
 		steps[4].Name(),
 		steps[5].Name(),
+		steps[6].Name(),
 	}
-	want := []string{"cluster", "tls", "registry", "operator-image", "operator-deploy", "verify"}
+	want := []string{"preflight", "cluster", "tls", "registry", "operator-image", "operator-deploy", "verify"}
 	for i := range want {
 		if got[i] != want[i] {
 			t.Fatalf("step %d: expected %q, got %q", i, want[i], got[i])
@@ -55,8 +56,8 @@ func TestBuildSetupStepsOrderWithoutTLS(t *testing.T) {
 		},
 	}
 	steps := buildSetupSteps(ctx)
-	if len(steps) != 5 {
-		t.Fatalf("expected 5 steps, got %d", len(steps))
+	if len(steps) != 6 {
+		t.Fatalf("expected 6 steps, got %d", len(steps))
 	}
 
 	got := []string{
@@ -65,8 +66,9 @@ func TestBuildSetupStepsOrderWithoutTLS(t *testing.T) {
 		steps[2].Name(),
 		steps[3].Name(),
 		steps[4].Name(),
+		steps[5].Name(),
 	}
-	want := []string{"cluster", "registry", "operator-image", "operator-deploy", "verify"}
+	want := []string{"preflight", "cluster", "registry", "operator-image", "operator-deploy", "verify"}
 	for i := range want {
 		if got[i] != want[i] {
 			t.Fatalf("step %d: expected %q, got %q", i, want[i], got[i])
@@ -112,7 +114,7 @@ func TestRegistryStepDeploysInternalRegistry(t *testing.T) {
 		UsingExternalRegistry: false,
 	}
 	deps := SetupDeps{
-		DeployRegistry: func(_ *zap.Logger, namespace string, port int, registryType, registryStorageSize, manifestPath string) error {
+		DeployRegistry: func(_ *zap.Logger, namespace string, port int, registryType, registryStorageSize, manifestPath string, _ []offlineImage) error {
 			if namespace != "registry" || port != 5000 || registryType != "docker" || registryStorageSize != "1Gi" || manifestPath != "config/registry" {
 				t.Fatalf("unexpected deploy args: %s %d %s %s %s", namespace, port, registryType, registryStorageSize, manifestPath)
 			}
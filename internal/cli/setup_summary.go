@@ -0,0 +1,124 @@
+package cli
+
+// This file builds the summary printed at the end of setup: where the registry is and which
+// secret holds its credentials, the operator and servers namespaces, the ingress address, and
+// the commands to deploy a first server and check the platform. --summary-file also writes it
+// to a file, so it does not have to be dug out of the setup logs.
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// setupSummaryServerName is the server name used in the example create command.
+const setupSummaryServerName = "my-server"
+
+// SetupSummary lists the endpoints and names a user needs after setup.
+type SetupSummary struct {
+	RegistryURL       string
+	RegistrySecret    string
+	OperatorNamespace string
+	ServersNamespace  string
+	IngressAddress    string
+	CreateCommand     string
+	StatusCommand     string
+}
+
+// buildSetupSummary collects the summary of a completed setup. A failing ingress address
+// lookup is reported in the summary rather than failing setup.
+func buildSetupSummary(logger *zap.Logger, deps SetupDeps, ctx *SetupContext) SetupSummary {
+	summary := SetupSummary{
+		RegistrySecret:    "none (anonymous pushes)",
+		OperatorNamespace: NamespaceMCPRuntime,
+		ServersNamespace:  serverNamespace(),
+		StatusCommand:     "mcp-runtime status",
+	}
+
+	if ctx.UsingExternalRegistry {
+		summary.RegistryURL = ctx.ExternalRegistry.Repository()
+		if ctx.ExternalRegistry.Username != "" || ctx.ExternalRegistry.Password != "" {
+			summary.RegistrySecret = fmt.Sprintf("%s/%s (pulls: %s/%s)", NamespaceMCPRuntime, ctx.RegistrySecretName, serverNamespace(), ctx.RegistrySecretName)
+		}
+	} else {
+		summary.RegistryURL = deps.GetPlatformRegistryURL(logger)
+		if ctx.Plan.RegistryAuth == registryAuthHtpasswd {
+			summary.RegistrySecret = fmt.Sprintf("%s/%s (pulls: %s)", NamespaceRegistry, registryAuthSecretName, registryPullSecretName)
+		}
+	}
+
+	if ctx.Plan.Ingress.mode == "none" {
+		summary.IngressAddress = "not installed (--ingress none)"
+	} else if address, err := deps.DetectIngressAddress(); err != nil {
+		logger.Debug("Ingress address lookup failed", zap.Error(err))
+		summary.IngressAddress = "pending (run `mcp-runtime ingress hosts` once the load balancer is assigned)"
+	} else {
+		summary.IngressAddress = ingressEntrypoints(address, ctx.Plan.TLSEnabled, ctx.Plan.DualIngress)
+	}
+
+	image := strings.TrimSuffix(summary.RegistryURL, "/") + "/" + setupSummaryServerName
+	summary.CreateCommand = fmt.Sprintf("mcp-runtime server create %s --image %s --tag latest", setupSummaryServerName, image)
+	return summary
+}
+
+// ingressEntrypoints describes how the ingress controller at address is reached: plain HTTP,
+// HTTPS only with --with-tls, or both with --dual-ingress.
+func ingressEntrypoints(address ingressAddress, tls, dual bool) string {
+	if address.NodePort != "" {
+		return fmt.Sprintf("http://%s:%s (NodePort)", address.IP, address.NodePort)
+	}
+	switch {
+	case dual:
+		return fmt.Sprintf("http://%s, https://%s", address.IP, address.IP)
+	case tls:
+		return "https://" + address.IP
+	}
+	return "http://" + address.IP
+}
+
+// Rows returns the summary as property/value table rows.
+func (s SetupSummary) Rows() [][]string {
+	return [][]string{
+		{"Property", "Value"},
+		{"Registry", s.RegistryURL},
+		{"Registry credentials secret", s.RegistrySecret},
+		{"Operator namespace", s.OperatorNamespace},
+		{"Servers namespace", s.ServersNamespace},
+		{"Ingress", s.IngressAddress},
+	}
+}
+
+// String renders the summary as plain text for --summary-file.
+func (s SetupSummary) String() string {
+	var b strings.Builder
+	b.WriteString("MCP Runtime setup summary\n\n")
+	for _, row := range s.Rows()[1:] {
+		fmt.Fprintf(&b, "%-28s %s\n", row[0]+":", row[1])
+	}
+	fmt.Fprintf(&b, "\nDeploy a server:\n  %s\n\nCheck the platform:\n  %s\n", s.CreateCommand, s.StatusCommand)
+	return b.String()
+}
+
+// printSetupSummary prints the summary and, when path is set, writes it to path. A failed
+// write only warns: the platform is already up.
+func printSetupSummary(summary SetupSummary, path string) {
+	Section("Endpoints")
+	TableBoxed(summary.Rows())
+	Info("Deploy a server: " + summary.CreateCommand)
+	Info("Check the platform: " + summary.StatusCommand)
+	if path == "" {
+		return
+	}
+	if err := os.WriteFile(path, []byte(summary.String()), 0o600); err != nil {
+		Warn(fmt.Sprintf("Could not write setup summary to %s: %v", path, err))
+		return
+	}
+	Info(fmt.Sprintf("Setup summary written to %s", path))
+}
+
+// detectSetupIngressAddress finds the address of the ingress controller setup installs.
+func detectSetupIngressAddress() (ingressAddress, error) {
+	return DefaultIngressManager(zap.NewNop()).detectIngressAddress("traefik", "traefik")
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestBuildSetupSummary(t *testing.T) {
+	deps := SetupDeps{
+		GetPlatformRegistryURL: func(*zap.Logger) string { return "10.0.0.5:5000" },
+		DetectIngressAddress:   func() (ingressAddress, error) { return ingressAddress{IP: "203.0.113.7"}, nil },
+	}
+
+	t.Run("internal registry with htpasswd and TLS", func(t *testing.T) {
+		ctx := &SetupContext{Plan: SetupPlan{RegistryAuth: registryAuthHtpasswd, TLSEnabled: true, Ingress: ingressOptions{mode: "traefik"}}}
+		summary := buildSetupSummary(zap.NewNop(), deps, ctx)
+		if summary.RegistryURL != "10.0.0.5:5000" || summary.RegistrySecret != "registry/registry-auth (pulls: registry-pull-creds)" {
+			t.Fatalf("unexpected registry summary %+v", summary)
+		}
+		if summary.IngressAddress != "https://203.0.113.7" {
+			t.Fatalf("unexpected ingress %q", summary.IngressAddress)
+		}
+		if summary.CreateCommand != "mcp-runtime server create my-server --image 10.0.0.5:5000/my-server --tag latest" {
+			t.Fatalf("unexpected create command %q", summary.CreateCommand)
+		}
+	})
+
+	t.Run("external registry and pending ingress", func(t *testing.T) {
+		pending := deps
+		pending.DetectIngressAddress = func() (ingressAddress, error) { return ingressAddress{}, errors.New("no address") }
+		ctx := &SetupContext{
+			Plan:                  SetupPlan{Ingress: ingressOptions{mode: "traefik"}},
+			ExternalRegistry:      &ExternalRegistryConfig{URL: "ghcr.io/acme/", Username: "bot"},
+			UsingExternalRegistry: true,
+			RegistrySecretName:    defaultRegistrySecretName,
+		}
+		summary := buildSetupSummary(zap.NewNop(), pending, ctx)
+		if !strings.HasPrefix(summary.RegistrySecret, NamespaceMCPRuntime+"/"+defaultRegistrySecretName) {
+			t.Fatalf("unexpected secret %q", summary.RegistrySecret)
+		}
+		if !strings.HasPrefix(summary.IngressAddress, "pending") || !strings.Contains(summary.CreateCommand, "--image ghcr.io/acme/my-server") {
+			t.Fatalf("unexpected summary %+v", summary)
+		}
+	})
+
+	t.Run("node port", func(t *testing.T) {
+		if got := ingressEntrypoints(ingressAddress{IP: "172.18.0.2", NodePort: "30080"}, false, false); got != "http://172.18.0.2:30080 (NodePort)" {
+			t.Fatalf("ingressEntrypoints() = %q", got)
+		}
+		if got := ingressEntrypoints(ingressAddress{IP: "203.0.113.7"}, true, true); got != "http://203.0.113.7, https://203.0.113.7" {
+			t.Fatalf("ingressEntrypoints() = %q", got)
+		}
+	})
+}
+
+func TestPrintSetupSummaryWritesFile(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	path := filepath.Join(t.TempDir(), "NOTICE")
+	summary := SetupSummary{RegistryURL: "10.0.0.5:5000", RegistrySecret: "none", OperatorNamespace: "mcp-runtime", ServersNamespace: "mcp-servers", IngressAddress: "http://203.0.113.7", CreateCommand: "mcp-runtime server create my-server", StatusCommand: "mcp-runtime status"}
+
+	printSetupSummary(summary, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("summary file not written: %v", err)
+	}
+	for _, want := range []string{"Registry:                    10.0.0.5:5000", "Ingress:                     http://203.0.113.7", "  mcp-runtime server create my-server", "  mcp-runtime status"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("summary file missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+// This file defines the setup timeout flags. Their defaults come from the CLI config
+// (MCP_RUNTIME_DEPLOYMENT_TIMEOUT, MCP_RUNTIME_CERT_TIMEOUT, MCP_RUNTIME_KUBECTL_TIMEOUT),
+// so slow clusters can raise them either per run or through the environment.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// SetupTimeouts are the deadlines setup waits for.
+type SetupTimeouts struct {
+	Deployment time.Duration
+	Cert       time.Duration
+	Kubectl    time.Duration // Per-invocation kubectl limit; 0 disables
+}
+
+func addSetupTimeoutFlags(cmd *cobra.Command, t *SetupTimeouts) {
+	cmd.Flags().DurationVar(&t.Deployment, "deployment-timeout", GetDeploymentTimeout(), "How long to wait for each deployment to become available (env: MCP_RUNTIME_DEPLOYMENT_TIMEOUT)")
+	cmd.Flags().DurationVar(&t.Cert, "cert-timeout", GetCertTimeout(), "How long to wait for the registry certificate with --with-tls (env: MCP_RUNTIME_CERT_TIMEOUT)")
+	cmd.Flags().DurationVar(&t.Kubectl, "kubectl-timeout", GetKubectlTimeout(), "Limit for each kubectl call; 0 disables (env: MCP_RUNTIME_KUBECTL_TIMEOUT)")
+}
+
+// Validate requires positive wait timeouts and a non-negative kubectl timeout.
+func (t SetupTimeouts) Validate() error {
+	if t.Deployment <= 0 {
+		return newWithSentinel(ErrInvalidSetupTimeout, fmt.Sprintf("--deployment-timeout must be positive, got %s", t.Deployment))
+	}
+	if t.Cert <= 0 {
+		return newWithSentinel(ErrInvalidSetupTimeout, fmt.Sprintf("--cert-timeout must be positive, got %s", t.Cert))
+	}
+	if t.Kubectl < 0 {
+		return newWithSentinel(ErrInvalidSetupTimeout, fmt.Sprintf("--kubectl-timeout must not be negative, got %s", t.Kubectl))
+	}
+	return nil
+}
+
+// deps returns setup dependencies that wait with these timeouts.
+func (t SetupTimeouts) deps() SetupDeps {
+	return SetupDeps{
+		GetDeploymentTimeout: func() time.Duration { return t.Deployment },
+		GetCertTimeout:       func() time.Duration { return t.Cert },
+	}
+}
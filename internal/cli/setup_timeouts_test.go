@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestSetupTimeoutsValidate(t *testing.T) {
+	valid := SetupTimeouts{Deployment: time.Minute, Cert: time.Minute}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, timeouts := range map[string]SetupTimeouts{
+		"zero deployment":  {Cert: time.Minute},
+		"zero cert":        {Deployment: time.Minute},
+		"negative kubectl": {Deployment: time.Minute, Cert: time.Minute, Kubectl: -time.Second},
+	} {
+		if err := timeouts.Validate(); !errors.Is(err, ErrInvalidSetupTimeout) {
+			t.Fatalf("%s: expected ErrInvalidSetupTimeout, got %v", name, err)
+		}
+	}
+}
+
+func TestSetupTimeoutsDeps(t *testing.T) {
+	deps := SetupTimeouts{Deployment: 7 * time.Minute, Cert: 3 * time.Minute}.deps().withDefaults(zap.NewNop())
+	if got := deps.GetDeploymentTimeout(); got != 7*time.Minute {
+		t.Fatalf("deployment timeout = %s", got)
+	}
+	if got := deps.GetCertTimeout(); got != 3*time.Minute {
+		t.Fatalf("cert timeout = %s", got)
+	}
+}
+
+func TestSetupCmdTimeoutFlags(t *testing.T) {
+	t.Setenv("MCP_RUNTIME_DEPLOYMENT_TIMEOUT", "12m")
+	orig := DefaultCLIConfig
+	DefaultCLIConfig = LoadCLIConfig()
+	t.Cleanup(func() { DefaultCLIConfig = orig })
+
+	flag := NewSetupCmd(zap.NewNop()).Flags().Lookup("deployment-timeout")
+	if flag == nil || flag.DefValue != "12m0s" {
+		t.Fatalf("expected deployment-timeout default from env, got %+v", flag)
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestSignImageArgs(t *testing.T) {
+	if got := strings.Join(signImageArgs("registry.local/app:v1", ""), " "); got != "sign --yes registry.local/app:v1" {
+		t.Fatalf("unexpected keyless args %q", got)
+	}
+	if got := strings.Join(signImageArgs("registry.local/app:v1", "cosign.key"), " "); got != "sign --yes --key cosign.key registry.local/app:v1" {
+		t.Fatalf("unexpected key args %q", got)
+	}
+}
+
+func TestRegistryManager_SignImage(t *testing.T) {
+	t.Run("runs cosign sign", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock, validators: nil}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.SignImage("registry.local/app:v1", "cosign.key"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		last := mock.LastCommand()
+		if last.Name != "cosign" || !contains(last.Args, "--key") {
+			t.Fatalf("unexpected command %s %v", last.Name, last.Args)
+		}
+	})
+
+	t.Run("wraps failures", func(t *testing.T) {
+		mock := &MockExecutor{DefaultRunErr: errors.New("no identity token")}
+		mgr := NewRegistryManager(&KubectlClient{exec: mock, validators: nil}, mock, zap.NewNop())
+		var buf bytes.Buffer
+		setDefaultPrinterWriter(t, &buf)
+
+		if err := mgr.SignImage("registry.local/app:v1", ""); !errors.Is(err, ErrSignImageFailed) {
+			t.Fatalf("expected ErrSignImageFailed, got %v", err)
+		}
+	})
+}
+
+func TestRegistryPushCmdSign(t *testing.T) {
+	mock := &MockExecutor{}
+	mgr := NewRegistryManager(&KubectlClient{exec: mock, validators: nil}, mock, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	cmd := mgr.newRegistryPushCmd()
+	_ = cmd.Flags().Set("image", "my-image:v1")
+	_ = cmd.Flags().Set("registry", "registry.example.com")
+	_ = cmd.Flags().Set("mode", "direct")
+	_ = cmd.Flags().Set("sign-key", "cosign.key")
+
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := mock.LastCommand()
+	if last.Name != "cosign" || last.Args[len(last.Args)-1] != "registry.example.com/my-image:v1" {
+		t.Fatalf("expected pushed target to be signed, got %s %v", last.Name, last.Args)
+	}
+}
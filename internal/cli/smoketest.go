@@ -0,0 +1,269 @@
+package cli
+
+// This file implements the "smoke-test" command, a single check that a fresh installation works
+// end to end: it builds the bundled example app in the cluster, pushes it to the platform registry,
+// deploys it as a temporary MCPServer, waits until it is Ready, calls it through the ingress and
+// removes it again.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// smokeTestImage is the repository the example app is pushed to.
+	smokeTestImage = "mcp-smoke-test"
+
+	// smokeTestResponse is part of the example app's response body.
+	smokeTestResponse = "mcp example server is up"
+)
+
+// smokePollInterval is how often readiness and the endpoint are polled; a variable so tests can shorten it.
+var smokePollInterval = 5 * time.Second
+
+// SmokeTestOptions configures a smoke test run.
+type SmokeTestOptions struct {
+	// Context is the example app build context.
+	Context   string
+	Builder   string
+	Namespace string
+	Timeout   time.Duration
+	// Keep leaves the MCPServer in place for inspection.
+	Keep             bool
+	IngressNamespace string
+	IngressService   string
+}
+
+// SmokeTestManager runs smoke tests with injected dependencies.
+type SmokeTestManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewSmokeTestManager creates a SmokeTestManager with the given dependencies.
+func NewSmokeTestManager(kubectl *KubectlClient, logger *zap.Logger) *SmokeTestManager {
+	return &SmokeTestManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultSmokeTestManager returns a SmokeTestManager using default clients.
+func DefaultSmokeTestManager(logger *zap.Logger) *SmokeTestManager {
+	return NewSmokeTestManager(kubectlClient, logger)
+}
+
+// NewSmokeTestCmd returns the smoke-test subcommand.
+func NewSmokeTestCmd(logger *zap.Logger) *cobra.Command {
+	return NewSmokeTestCmdWithManager(DefaultSmokeTestManager(logger))
+}
+
+// NewSmokeTestCmdWithManager returns the smoke-test subcommand using the provided manager.
+func NewSmokeTestCmdWithManager(mgr *SmokeTestManager) *cobra.Command {
+	var opts SmokeTestOptions
+	var notifyURL string
+
+	cmd := &cobra.Command{
+		Use:   "smoke-test",
+		Short: "Deploy the example app end to end to validate an installation",
+		Long: `Build examples/example-app in the cluster and push it to the platform registry,
+create a temporary MCPServer, wait until it is Ready, request it through the ingress
+and delete it again. Run it from the repository root after "mcp-runtime setup".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateNotifyURL(notifyURL); err != nil {
+				Error("Invalid notify URL")
+				logStructuredError(mgr.logger, err, "Invalid notify URL")
+				return err
+			}
+			opts.Namespace = serverNamespace()
+			return runWithNotify(mgr.logger, notifyURL, "smoke-test", func() error { return mgr.Run(opts) })
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Context, "context", "examples/example-app", "Build context of the example app")
+	cmd.Flags().StringVar(&opts.Builder, "builder", builderKaniko, "In-cluster builder ("+strings.Join(imageBuilders, "|")+")")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 5*time.Minute, "How long to wait for the server to become Ready and respond")
+	cmd.Flags().BoolVar(&opts.Keep, "keep", false, "Keep the MCPServer after the test")
+	cmd.Flags().StringVar(&opts.IngressNamespace, "ingress-namespace", "traefik", "Namespace of the ingress controller service")
+	cmd.Flags().StringVar(&opts.IngressService, "ingress-service", "traefik", "Name of the ingress controller service")
+	addNotifyFlag(cmd, &notifyURL)
+
+	return cmd
+}
+
+// Run builds, deploys and calls the example app, then removes the MCPServer unless opts.Keep is set.
+func (m *SmokeTestManager) Run(opts SmokeTestOptions) error {
+	if err := validateImageBuilder(opts.Builder); err != nil {
+		Error("Invalid builder")
+		logStructuredError(m.logger, err, "Invalid builder")
+		return err
+	}
+
+	suffix := fmt.Sprint(time.Now().Unix())
+	name := "smoke-test-" + suffix
+	image := getPlatformRegistryURL(m.logger) + "/" + smokeTestImage
+
+	Step("Step 1: Build and push the example app")
+	if err := buildImageInCluster(m.kubectl, m.logger, inClusterBuild{
+		Builder:     opts.Builder,
+		Namespace:   NamespaceRegistry,
+		Context:     opts.Context,
+		Dockerfile:  "Dockerfile",
+		Destination: image + ":" + suffix,
+	}); err != nil {
+		return err
+	}
+
+	Step("Step 2: Create MCPServer " + name)
+	servers := NewServerManager(m.kubectl, m.logger)
+	if err := servers.CreateServer(name, opts.Namespace, image, suffix); err != nil {
+		return err
+	}
+	if opts.Keep {
+		defer Info(fmt.Sprintf("Keeping MCPServer %s/%s; remove it with \"mcp-runtime server delete %s -n %s\"", opts.Namespace, name, name, opts.Namespace))
+	} else {
+		defer func() {
+			Info(fmt.Sprintf("Removing MCPServer %s/%s", opts.Namespace, name))
+			if err := servers.DeleteServer(name, opts.Namespace, true); err != nil {
+				Warn(fmt.Sprintf("Cleanup failed; delete MCPServer %s/%s manually", opts.Namespace, name))
+			}
+		}()
+	}
+
+	deadline := time.Now().Add(opts.Timeout)
+
+	Step("Step 3: Wait for the server to become Ready")
+	serverURL, err := m.waitForServerReady(name, opts.Namespace, deadline)
+	if err != nil {
+		return m.smokeError(ErrServerNotReady, err, "Server did not become Ready", map[string]any{"server": name, "namespace": opts.Namespace})
+	}
+	Success(fmt.Sprintf("Server is Ready at %s", serverURL))
+
+	Step("Step 4: Request the server through the ingress")
+	if err := m.checkEndpoint(serverURL, opts, deadline); err != nil {
+		return m.smokeError(ErrSmokeRequestFailed, err, "Request through the ingress failed", map[string]any{"server": name, "url": serverURL})
+	}
+
+	Success("Smoke test passed")
+	return nil
+}
+
+// waitForServerReady polls the MCPServer status until its phase is Ready and returns its URL.
+func (m *SmokeTestManager) waitForServerReady(name, namespace string, deadline time.Time) (string, error) {
+	var phase, message string
+	for {
+		// #nosec G204 -- generated server name; namespace validated when the server was created.
+		out, err := m.kubectl.Output([]string{"get", "mcpserver", name, "-n", namespace, "-o", `jsonpath={.status.phase}{"\t"}{.status.url}{"\t"}{.status.message}`})
+		if err == nil {
+			fields := strings.SplitN(string(out), "\t", 3)
+			for len(fields) < 3 {
+				fields = append(fields, "")
+			}
+			phase, message = fields[0], fields[2]
+			if phase == "Ready" {
+				if fields[1] == "" {
+					return "", fmt.Errorf("server is Ready but has no URL; configure an ingress host (MCP_DEFAULT_INGRESS_HOST on the operator)")
+				}
+				return fields[1], nil
+			}
+		}
+		if time.Now().After(deadline) {
+			if phase == "" {
+				phase = "unknown"
+			}
+			return "", fmt.Errorf("timed out in phase %s: %s", phase, message)
+		}
+		time.Sleep(smokePollInterval)
+	}
+}
+
+// checkEndpoint requests serverURL until the example app answers or the deadline passes.
+func (m *SmokeTestManager) checkEndpoint(serverURL string, opts SmokeTestOptions, deadline time.Time) error {
+	client, err := m.smokeHTTPClient(serverURL, opts)
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for {
+		if lastErr = requestExampleApp(client, serverURL); lastErr == nil {
+			Success(fmt.Sprintf("GET %s answered: %s", serverURL, smokeTestResponse))
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return lastErr
+		}
+		time.Sleep(smokePollInterval)
+	}
+}
+
+// smokeHTTPClient returns a client for serverURL. When the URL's host does not resolve
+// (e.g. a made-up dev domain), connections go to the detected ingress address instead,
+// keeping the Host header and TLS server name of the URL.
+func (m *SmokeTestManager) smokeHTTPClient(serverURL string, opts SmokeTestOptions) (*http.Client, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(unresolvedHosts([]string{u.Hostname()})) == 0 {
+		return client, nil
+	}
+
+	ingress := NewIngressManager(m.kubectl, m.logger)
+	addr, err := ingress.detectIngressAddress(opts.IngressNamespace, opts.IngressService)
+	if err != nil {
+		return nil, fmt.Errorf("host %s does not resolve and the ingress address could not be detected: %w", u.Hostname(), err)
+	}
+	Info(fmt.Sprintf("Host %s does not resolve; connecting to the ingress at %s", u.Hostname(), addr.IP))
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		_, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if port == "80" && addr.NodePort != "" {
+			port = addr.NodePort
+		}
+		return dialer.DialContext(ctx, network, net.JoinHostPort(addr.IP, port))
+	}
+	client.Transport = transport
+	return client, nil
+}
+
+// requestExampleApp checks that serverURL is served by the example app.
+func requestExampleApp(client *http.Client, serverURL string) error {
+	resp, err := client.Get(serverURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if !strings.Contains(string(body), smokeTestResponse) {
+		return fmt.Errorf("unexpected response body: %.200s", body)
+	}
+	return nil
+}
+
+func (m *SmokeTestManager) smokeError(sentinel, err error, msg string, context map[string]any) error {
+	context["component"] = "smoke-test"
+	wrappedErr := wrapWithSentinelAndContext(sentinel, err, fmt.Sprintf("%s: %v", strings.ToLower(msg), err), context)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func newExampleAppServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"message":%q}`, smokeTestResponse)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func smokeTestExecutor(serverURL string, commands *[]string) *MockExecutor {
+	return &MockExecutor{CommandFunc: func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		args := strings.Join(spec.Args, " ")
+		*commands = append(*commands, args)
+		switch {
+		case strings.Contains(args, "jsonpath={.spec.clusterIP}"):
+			cmd.OutputData = []byte("10.0.0.5")
+		case strings.Contains(args, "jsonpath={.spec.ports[0].port}"):
+			cmd.OutputData = []byte("5000")
+		case strings.HasPrefix(args, "get mcpserver smoke-test-"):
+			cmd.OutputData = []byte("Ready\t" + serverURL + "\tAll resources reconciled")
+		}
+		return cmd
+	}}
+}
+
+func TestSmokeTestRun(t *testing.T) {
+	original := smokePollInterval
+	smokePollInterval = time.Millisecond
+	t.Cleanup(func() { smokePollInterval = original })
+	originalKubectl := kubectlClient
+	t.Cleanup(func() { kubectlClient = originalKubectl })
+
+	srv := newExampleAppServer(t)
+	var commands []string
+	kubectlClient = &KubectlClient{exec: smokeTestExecutor(srv.URL+"/smoke", &commands)}
+	mgr := NewSmokeTestManager(kubectlClient, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	opts := SmokeTestOptions{Context: t.TempDir(), Builder: builderKaniko, Namespace: NamespaceMCPServers, Timeout: time.Second}
+	if err := mgr.Run(opts); err != nil {
+		t.Fatalf("run: %v\n%s", err, buf.String())
+	}
+
+	all := strings.Join(commands, "\n")
+	for _, want := range []string{
+		"--destination=10.0.0.5:5000/" + smokeTestImage + ":",
+		"apply -f ",
+		"delete mcpserver smoke-test-",
+	} {
+		if !strings.Contains(all, want) {
+			t.Fatalf("expected command containing %q, got:\n%s", want, all)
+		}
+	}
+	if !strings.Contains(buf.String(), "Smoke test passed") {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestSmokeTestKeep(t *testing.T) {
+	original := smokePollInterval
+	smokePollInterval = time.Millisecond
+	t.Cleanup(func() { smokePollInterval = original })
+	originalKubectl := kubectlClient
+	t.Cleanup(func() { kubectlClient = originalKubectl })
+
+	srv := newExampleAppServer(t)
+	var commands []string
+	kubectlClient = &KubectlClient{exec: smokeTestExecutor(srv.URL, &commands)}
+	mgr := NewSmokeTestManager(kubectlClient, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	opts := SmokeTestOptions{Context: t.TempDir(), Builder: builderKaniko, Namespace: NamespaceMCPServers, Timeout: time.Second, Keep: true}
+	if err := mgr.Run(opts); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if strings.Contains(strings.Join(commands, "\n"), "delete mcpserver") {
+		t.Fatal("expected the MCPServer to be kept")
+	}
+}
+
+func TestSmokeTestWaitForServerReadyTimeout(t *testing.T) {
+	original := smokePollInterval
+	smokePollInterval = time.Millisecond
+	t.Cleanup(func() { smokePollInterval = original })
+
+	mock := &MockExecutor{DefaultOutput: []byte("Pending\t\tDeployment degraded (ImagePullBackOff): back-off")}
+	mgr := NewSmokeTestManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+	_, err := mgr.waitForServerReady("smoke-test-1", NamespaceMCPServers, time.Now().Add(5*time.Millisecond))
+	if err == nil || !strings.Contains(err.Error(), "ImagePullBackOff") {
+		t.Fatalf("expected timeout with status message, got %v", err)
+	}
+}
+
+func TestSmokeTestInvalidBuilder(t *testing.T) {
+	mgr := NewSmokeTestManager(&KubectlClient{exec: &MockExecutor{}}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	if err := mgr.Run(SmokeTestOptions{Builder: "docker"}); !errors.Is(err, ErrInvalidBuilder) {
+		t.Fatalf("expected ErrInvalidBuilder, got %v", err)
+	}
+}
+
+func TestRequestExampleApp(t *testing.T) {
+	srv := newExampleAppServer(t)
+	if err := requestExampleApp(srv.Client(), srv.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer other.Close()
+	if err := requestExampleApp(other.Client(), other.URL); err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+}
+
+func TestSmokeHTTPClientUsesIngressAddress(t *testing.T) {
+	original := lookupHost
+	lookupHost = func(host string) ([]string, error) { return nil, errors.New("no such host") }
+	t.Cleanup(func() { lookupHost = original })
+
+	var gotHost string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		fmt.Fprint(w, smokeTestResponse)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	mock := &MockExecutor{DefaultOutput: []byte("127.0.0.1 ")}
+	mgr := NewSmokeTestManager(&KubectlClient{exec: mock}, zap.NewNop())
+	var buf bytes.Buffer
+	setDefaultPrinterWriter(t, &buf)
+
+	serverURL := "http://mcp.test:" + u.Port() + "/smoke"
+	client, err := mgr.smokeHTTPClient(serverURL, SmokeTestOptions{IngressNamespace: "traefik", IngressService: "traefik"})
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	if err := requestExampleApp(client, serverURL); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if gotHost != "mcp.test:"+u.Port() {
+		t.Fatalf("Host header = %q", gotHost)
+	}
+}
@@ -4,6 +4,8 @@ package cli
 // It displays the status of cluster, registry, and operator components.
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,18 +14,29 @@ import (
 
 // NewStatusCmd returns the status subcommand for platform health checks.
 func NewStatusCmd(logger *zap.Logger) *cobra.Command {
+	var allContexts bool
+
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show platform status",
 		Long:  "Show the overall status of the MCP platform",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allContexts {
+				return showPlatformStatusAllContexts(logger)
+			}
 			return showPlatformStatus(logger)
 		},
 	}
 
+	cmd.Flags().BoolVar(&allContexts, "all-contexts", false, "Show a status row for every kubeconfig context with the platform installed")
+
 	return cmd
 }
 
+// mcpServerListJSONPath lists every MCPServer as namespace|name|image|replicas followed by the
+// status and spec values of its ingress path and class; defaulted values are only in status.
+const mcpServerListJSONPath = `jsonpath={range .items[*]}{.metadata.namespace}|{.metadata.name}|{.spec.image}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}{"\n"}{end}`
+
 func showPlatformStatus(logger *zap.Logger) error {
 	Header("MCP Platform Status")
 	DefaultPrinter.Println()
@@ -71,6 +84,9 @@ func showPlatformStatus(logger *zap.Logger) error {
 				operatorStatus = Yellow("PENDING")
 			}
 			operatorDetails = "Replicas: " + replicas
+			if version := operatorVersion(kubectlClient); version != "" {
+				operatorDetails += ", version " + version
+			}
 		}
 	}
 	tableData = append(tableData, []string{"Operator", operatorStatus, operatorDetails})
@@ -82,7 +98,7 @@ func showPlatformStatus(logger *zap.Logger) error {
 	Section("MCP Servers")
 
 	// #nosec G204 -- fixed kubectl command.
-	cmd, err := kubectlClient.CommandArgs([]string{"get", "mcpserver", "--all-namespaces", "-o", "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,IMAGE:.spec.image,REPLICAS:.spec.replicas,PATH:.spec.ingressPath"})
+	cmd, err := kubectlClient.CommandArgs([]string{"get", "mcpserver", "--all-namespaces", "-o", mcpServerListJSONPath})
 	if err != nil {
 		Warn("Failed to list MCP servers: " + err.Error())
 	} else {
@@ -96,20 +112,26 @@ func showPlatformStatus(logger *zap.Logger) error {
 		} else if len(strings.TrimSpace(string(output))) == 0 {
 			Warn("No MCP servers deployed")
 		} else {
-			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-			if len(lines) <= 1 {
+			serverData := [][]string{{"NAMESPACE", "NAME", "IMAGE", "REPLICAS", "PATH", "CLASS"}}
+			for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+				fields := strings.Split(strings.TrimSpace(line), "|")
+				if len(fields) < 8 {
+					continue
+				}
+				path := effectiveIngressValue(fields[4], fields[5])
+				class := effectiveIngressValue(fields[6], fields[7])
+				serverData = append(serverData, []string{fields[0], fields[1], orDash(fields[2]), orDash(fields[3]), orDash(path), orDash(class)})
+			}
+			if len(serverData) <= 1 {
 				Warn("No MCP servers deployed")
 			} else {
-				serverData := [][]string{}
-				for _, line := range lines {
-					fields := strings.Fields(line)
-					serverData = append(serverData, fields)
-				}
 				Table(serverData)
 			}
 		}
 	}
 
+	checkIngressHostsResolve(kubectlClient)
+
 	// Quick tips
 	DefaultPrinter.Println()
 	Info("Use 'mcp-runtime server list' for detailed server info")
@@ -133,3 +155,33 @@ func checkRegistryStatusQuiet(logger *zap.Logger, namespace string) error {
 	}
 	return nil
 }
+
+// operatorMetricsPort is the port of the operator's metrics endpoint.
+const operatorMetricsPort = 8080
+
+// operatorBuildInfoRe matches the version and commit labels of the operator's build_info
+// series; Prometheus sorts labels, so commit comes first.
+var operatorBuildInfoRe = regexp.MustCompile(`mcpruntime_operator_build_info\{commit="([^"]*)".*[{,]version="([^"]*)"`)
+
+// operatorVersion returns the version and commit of the operator running in the cluster,
+// read from a running pod's build_info metric through the API server proxy, or "" when it
+// cannot be read, such as with operators built before the metric existed.
+func operatorVersion(kubectl KubectlRunner) string {
+	// #nosec G204 -- fixed kubectl command.
+	out, err := kubectlOutput(kubectl, []string{"get", "pods", "-n", NamespaceMCPRuntime, "-l", SelectorOperator, "--field-selector=status.phase=Running", "-o", "jsonpath={.items[0].metadata.name}"})
+	pod := strings.TrimSpace(string(out))
+	if err != nil || pod == "" {
+		return ""
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s:%d/proxy/metrics", NamespaceMCPRuntime, pod, operatorMetricsPort)
+	// #nosec G204 -- fixed API path; the pod name comes from the API server.
+	metrics, err := kubectlOutput(kubectl, []string{"get", "--raw", path})
+	if err != nil {
+		return ""
+	}
+	match := operatorBuildInfoRe.FindSubmatch(metrics)
+	if match == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (commit %s)", match[2], match[1])
+}
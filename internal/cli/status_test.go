@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"os"
 	"os/exec"
@@ -22,13 +23,13 @@ func commandKey(name string, args ...string) string {
 	return strings.Join(append([]string{name}, args...), " ")
 }
 
-func fakeExecCommand(t *testing.T, base func(string, ...string) *exec.Cmd, responses map[string]commandResponse, calls *[]string) func(string, ...string) *exec.Cmd {
+func fakeExecCommand(t *testing.T, base func(context.Context, string, ...string) *exec.Cmd, responses map[string]commandResponse, calls *[]string) func(context.Context, string, ...string) *exec.Cmd {
 	t.Helper()
-	return func(name string, args ...string) *exec.Cmd {
+	return func(ctx context.Context, name string, args ...string) *exec.Cmd {
 		if calls != nil {
 			*calls = append(*calls, commandKey(name, args...))
 		}
-		cmd := base(os.Args[0], "-test.run=TestHelperProcess", "--", name)
+		cmd := base(ctx, os.Args[0], "-test.run=TestHelperProcess", "--", name)
 		cmd.Args = append(cmd.Args, args...)
 		payload, err := json.Marshal(responses)
 		if err != nil {
@@ -108,7 +109,7 @@ func TestShowPlatformStatus(t *testing.T) {
 			commandKey("kubectl", "get", "deployment", "mcp-runtime-operator-controller-manager", "-n", "mcp-runtime", "-o", "jsonpath={.status.readyReplicas}/{.spec.replicas}"): {
 				Stdout: "0/1",
 			},
-			commandKey("kubectl", "get", "mcpserver", "--all-namespaces", "-o", "custom-columns=NAMESPACE:.metadata.namespace,NAME:.metadata.name,IMAGE:.spec.image,REPLICAS:.spec.replicas,PATH:.spec.ingressPath"): {},
+			commandKey("kubectl", "get", "mcpserver", "--all-namespaces", "-o", mcpServerListJSONPath): {},
 		}
 
 		origExec := execCommand
@@ -136,6 +137,48 @@ func TestShowPlatformStatus(t *testing.T) {
 			t.Fatalf("expected operator replica details, got output: %s", output)
 		}
 	})
+
+	t.Run("lists-defaulted-ingress-path-and-class-from-status", func(t *testing.T) {
+		responses := map[string]commandResponse{
+			commandKey("kubectl", "cluster-info"):                            {Stdout: "cluster ok\n"},
+			commandKey("kubectl", "get", "nodes"):                            {},
+			commandKey("kubectl", "get", "crd", "mcpservers.mcpruntime.org"): {},
+			commandKey("kubectl", "get", "pods", "-n", "mcp-runtime"):        {},
+			commandKey("kubectl", "get", "deployment", "registry", "-n", "registry", "-o", "jsonpath={.status.readyReplicas}"): {
+				Stdout: "1",
+			},
+			commandKey("kubectl", "get", "deployment", "mcp-runtime-operator-controller-manager", "-n", "mcp-runtime", "-o", "jsonpath={.status.readyReplicas}/{.spec.replicas}"): {
+				Stdout: "1/1",
+			},
+			// The spec leaves path and class empty; the operator reported the defaults in status.
+			commandKey("kubectl", "get", "mcpserver", "--all-namespaces", "-o", mcpServerListJSONPath): {
+				Stdout: "mcp-servers|weather|registry.local/weather|1|/weather/mcp||traefik|\n",
+			},
+		}
+
+		origExec := execCommand
+		execCommand = fakeExecCommand(t, origExec, responses, nil)
+		t.Cleanup(func() { execCommand = origExec })
+
+		var buf bytes.Buffer
+		pterm.SetDefaultOutput(&buf)
+		pterm.DisableStyling()
+		setDefaultPrinterWriter(t, &buf)
+		t.Cleanup(func() {
+			pterm.SetDefaultOutput(os.Stdout)
+			pterm.EnableStyling()
+		})
+
+		if err := showPlatformStatus(zap.NewNop()); err != nil {
+			t.Fatalf("showPlatformStatus() unexpected error = %v", err)
+		}
+		output := buf.String()
+		for _, want := range []string{"weather", "/weather/mcp", "traefik"} {
+			if !strings.Contains(output, want) {
+				t.Fatalf("expected %q in the server table, got output: %s", want, output)
+			}
+		}
+	})
 }
 
 func TestServerStatus(t *testing.T) {
@@ -143,7 +186,7 @@ func TestServerStatus(t *testing.T) {
 		logger := zap.NewNop()
 		namespace := "mcp-servers"
 		responses := map[string]commandResponse{
-			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.spec.ingressPath}|{.spec.useProvisionedRegistry}{\"\\n\"}{end}"): {
+			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}|{.spec.useProvisionedRegistry}|{.status.url}{\"\\n\"}{end}"): {
 				Stdout:   "boom-out\n",
 				Stderr:   "boom-err\n",
 				ExitCode: 1,
@@ -179,7 +222,7 @@ func TestServerStatus(t *testing.T) {
 		logger := zap.NewNop()
 		namespace := "mcp-servers"
 		responses := map[string]commandResponse{
-			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.spec.ingressPath}|{.spec.useProvisionedRegistry}{\"\\n\"}{end}"): {},
+			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}|{.spec.useProvisionedRegistry}|{.status.url}{\"\\n\"}{end}"): {},
 		}
 
 		origExec := execCommand
@@ -212,11 +255,11 @@ func TestServerStatus(t *testing.T) {
 		var calls []string
 
 		responses := map[string]commandResponse{
-			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.spec.ingressPath}|{.spec.useProvisionedRegistry}{\"\\n\"}{end}"): {
-				Stdout: "server1|image:tag|1|/server|false\n",
+			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}|{.spec.useProvisionedRegistry}|{.status.url}{\"\\n\"}{end}"): {
+				Stdout: "server1|image:tag|1||/server|||false\n",
 			},
-			commandKey("kubectl", "get", "pods", "-n", namespace, "-l", "app.kubernetes.io/managed-by=mcp-runtime", "-o", "custom-columns=NAME:.metadata.name,READY:.status.containerStatuses[0].ready,STATUS:.status.phase,RESTARTS:.status.containerStatuses[0].restartCount"): {
-				Stdout: "NAME READY STATUS RESTARTS\npod-1 true Running 0\n",
+			commandKey("kubectl", "get", "pods", "-n", namespace, "-l", "app.kubernetes.io/managed-by=mcp-runtime", "-o", "json"): {
+				Stdout: `{"items":[{"metadata":{"name":"pod-1"},"status":{"phase":"Running"}}]}`,
 			},
 		}
 
@@ -241,15 +284,15 @@ func TestServerStatus(t *testing.T) {
 		}
 	})
 
-	t.Run("prints no pods found when only header returned", func(t *testing.T) {
+	t.Run("prints no pods found when no pods returned", func(t *testing.T) {
 		logger := zap.NewNop()
 		namespace := "mcp-servers"
 		responses := map[string]commandResponse{
-			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.spec.ingressPath}|{.spec.useProvisionedRegistry}{\"\\n\"}{end}"): {
-				Stdout: "server1|image:tag|1|/server|false\n",
+			commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}|{.spec.useProvisionedRegistry}|{.status.url}{\"\\n\"}{end}"): {
+				Stdout: "server1|image:tag|1||/server|||false|https://mcp.example.com/server/mcp\n",
 			},
-			commandKey("kubectl", "get", "pods", "-n", namespace, "-l", "app.kubernetes.io/managed-by=mcp-runtime", "-o", "custom-columns=NAME:.metadata.name,READY:.status.containerStatuses[0].ready,STATUS:.status.phase,RESTARTS:.status.containerStatuses[0].restartCount"): {
-				Stdout: "NAME READY STATUS RESTARTS\n",
+			commandKey("kubectl", "get", "pods", "-n", namespace, "-l", "app.kubernetes.io/managed-by=mcp-runtime", "-o", "json"): {
+				Stdout: `{"items":[]}`,
 			},
 		}
 
@@ -275,9 +318,48 @@ func TestServerStatus(t *testing.T) {
 		if !strings.Contains(output, "No pods found") {
 			t.Fatalf("expected no pods message, got output: %s", output)
 		}
+		if !strings.Contains(output, "https://mcp.example.com/server/mcp") {
+			t.Fatalf("expected server URL in output, got output: %s", output)
+		}
 	})
 }
 
+func TestServerStatusReadsDefaultedIngressFromStatus(t *testing.T) {
+	namespace := "mcp-servers"
+	responses := map[string]commandResponse{
+		// The spec leaves path and class empty; the operator reported the defaults in status.
+		commandKey("kubectl", "get", "mcpserver", "-n", namespace, "-o", "jsonpath={range .items[*]}{.metadata.name}|{.spec.image}:{.spec.imageTag}|{.spec.replicas}|{.status.ingressPath}|{.spec.ingressPath}|{.status.ingressClass}|{.spec.ingressClass}|{.spec.useProvisionedRegistry}|{.status.url}{\"\\n\"}{end}"): {
+			Stdout: "server1|image:tag|1|/server1-2/mcp||nginx||false|\n",
+		},
+		commandKey("kubectl", "get", "pods", "-n", namespace, "-l", "app.kubernetes.io/managed-by=mcp-runtime", "-o", "json"): {
+			Stdout: `{"items":[]}`,
+		},
+	}
+
+	origExec := execCommand
+	execCommand = fakeExecCommand(t, origExec, responses, nil)
+	t.Cleanup(func() { execCommand = origExec })
+
+	var buf bytes.Buffer
+	pterm.SetDefaultOutput(&buf)
+	pterm.DisableStyling()
+	setDefaultPrinterWriter(t, &buf)
+	t.Cleanup(func() {
+		pterm.SetDefaultOutput(os.Stdout)
+		pterm.EnableStyling()
+	})
+
+	if err := DefaultServerManager(zap.NewNop()).ServerStatus(namespace); err != nil {
+		t.Fatalf("serverStatus() unexpected error = %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{"/server1-2/mcp", "nginx"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got output: %s", want, output)
+		}
+	}
+}
+
 func TestCheckRegistryStatusQuiet(t *testing.T) {
 	t.Run("returns-error-when-registry-not-found", func(t *testing.T) {
 		logger := zap.NewNop()
@@ -318,3 +400,34 @@ func setDefaultPrinterWriter(t *testing.T, w *bytes.Buffer) {
 		DefaultPrinter.Writer = orig
 	})
 }
+
+func TestOperatorVersion(t *testing.T) {
+	metrics := `# HELP mcpruntime_operator_build_info Build of the running operator (always 1): version, commit, build date and Go version.
+# TYPE mcpruntime_operator_build_info gauge
+mcpruntime_operator_build_info{commit="abc1234",date="2026-01-02T03:04:05Z",go_version="go1.24.0",version="v1.2.3"} 1
+`
+	mock := &MockExecutor{}
+	mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+		cmd := &MockCommand{Args: spec.Args}
+		switch spec.Args[1] {
+		case "pods":
+			cmd.OutputData = []byte("operator-7d9f")
+		case "--raw":
+			cmd.OutputData = []byte(metrics)
+		}
+		return cmd
+	}
+	kubectl := &KubectlClient{exec: mock}
+
+	if got := operatorVersion(kubectl); got != "v1.2.3 (commit abc1234)" {
+		t.Fatalf("operatorVersion() = %q", got)
+	}
+	if !commandHasArgs(mock.Commands[1], "--raw", "/api/v1/namespaces/mcp-runtime/pods/operator-7d9f:8080/proxy/metrics") {
+		t.Fatalf("unexpected metrics request %v", mock.Commands[1].Args)
+	}
+
+	metrics = "go_goroutines 12\n"
+	if got := operatorVersion(kubectl); got != "" {
+		t.Fatalf("expected no version without build_info, got %q", got)
+	}
+}
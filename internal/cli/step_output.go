@@ -0,0 +1,334 @@
+package cli
+
+// This file streams the output of long-running tools (docker/podman build, push and save,
+// kind, eksctl) through one multiplexer. Every line is prefixed with the step that produced
+// it, a "still running" line is printed when a tool is silent for a while, and the full output
+// of every step is recorded in ~/.mcp-runtime/logs/<run-id>.log for later inspection.
+//
+// The global --quiet and --verbose flags pick how much reaches the terminal:
+//   - default: each line prefixed with [step]; progress redraws ('\r') collapse to their last state
+//   - --quiet: a spinner per step, or the running setup spinner showing the step; the last lines
+//     and the log path are shown only on failure
+//   - --verbose: every line including progress redraws, with the elapsed time of the step
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Output modes for tool output.
+const (
+	OutputModeNormal  = "normal"
+	OutputModeQuiet   = "quiet"
+	OutputModeVerbose = "verbose"
+)
+
+// stepTailLines is how many lines of a failed step --quiet prints.
+const stepTailLines = 20
+
+var (
+	outputModeMu sync.RWMutex
+	outputMode   = OutputModeNormal
+
+	// stepHeartbeat is how long a step may be silent before "still running" is printed; a
+	// test seam.
+	stepHeartbeat = 30 * time.Second
+)
+
+// SetOutputMode applies the global --quiet and --verbose flags.
+func SetOutputMode(quiet, verbose bool) error {
+	mode := OutputModeNormal
+	switch {
+	case quiet && verbose:
+		return newWithSentinel(ErrInvalidOutputMode, "--quiet and --verbose cannot be combined")
+	case quiet:
+		mode = OutputModeQuiet
+	case verbose:
+		mode = OutputModeVerbose
+	}
+	outputModeMu.Lock()
+	defer outputModeMu.Unlock()
+	outputMode = mode
+	return nil
+}
+
+func currentOutputMode() string {
+	outputModeMu.RLock()
+	defer outputModeMu.RUnlock()
+	return outputMode
+}
+
+// runLog is the log file of this CLI run, opened by the first step.
+var runLog = struct {
+	sync.Mutex
+	id     string
+	path   string
+	file   *os.File
+	failed bool
+}{id: newRunID()}
+
+// newRunID names the log of this run; the pid keeps concurrent runs apart.
+func newRunID() string {
+	return fmt.Sprintf("%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
+}
+
+// runLogDir returns ~/.mcp-runtime/logs; a test seam.
+var runLogDir = func() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".mcp-runtime", "logs"), nil
+}
+
+// openRunLog returns the run log, opening it on first use. It returns nil when the log cannot
+// be created; that is reported once and does not fail the command.
+func openRunLog() (io.Writer, string) {
+	runLog.Lock()
+	defer runLog.Unlock()
+	if runLog.file != nil {
+		return runLog.file, runLog.path
+	}
+	if runLog.failed {
+		return nil, ""
+	}
+	file, path, err := createRunLog(runLog.id)
+	if err != nil {
+		runLog.failed = true
+		Warn(fmt.Sprintf("Could not create the run log; tool output is not recorded: %v", err))
+		return nil, ""
+	}
+	runLog.file, runLog.path = file, path
+	return file, path
+}
+
+func createRunLog(id string) (*os.File, string, error) {
+	dir, err := runLogDir()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, "", err
+	}
+	path := filepath.Join(dir, id+".log")
+	// #nosec G304 -- path is scoped to the user's config directory.
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, "", err
+	}
+	return file, path, nil
+}
+
+// stepOutput multiplexes the stdout and stderr of one step to the terminal and the run log.
+type stepOutput struct {
+	step    string
+	mode    string
+	out     io.Writer
+	log     io.Writer
+	logPath string
+	// now is a test seam for the clock.
+	now func() time.Time
+
+	mu         sync.Mutex
+	start      time.Time
+	lastOutput time.Time
+	tail       []string
+	stdout     *stepLineWriter
+	stderr     *stepLineWriter
+	stop       func(success bool, finalMsg string)
+	done       chan struct{}
+	// heartbeatDone is closed when the heartbeat goroutine exits; nil without one.
+	heartbeatDone chan struct{}
+}
+
+// startStepOutput starts streaming a step named step.
+func startStepOutput(step string) *stepOutput {
+	s := &stepOutput{step: step, mode: currentOutputMode(), out: DefaultPrinter.Stdout(), now: time.Now, done: make(chan struct{})}
+	s.log, s.logPath = openRunLog()
+	s.start = s.now()
+	s.lastOutput = s.start
+	s.stdout = &stepLineWriter{output: s, stream: "stdout"}
+	s.stderr = &stepLineWriter{output: s, stream: "stderr"}
+	s.logf("--- %s started", step)
+
+	switch s.mode {
+	case OutputModeQuiet:
+		s.stop = DefaultPrinter.SpinnerStart(fmt.Sprintf("Running %s", step))
+	case OutputModeVerbose:
+		if s.logPath != "" {
+			fmt.Fprintf(s.out, "[%s] logging to %s\n", step, s.logPath)
+		}
+		s.startHeartbeat()
+	default:
+		s.startHeartbeat()
+	}
+	return s
+}
+
+// runStep runs cmd as step with its output streamed through a stepOutput.
+func runStep(step string, cmd Command) error {
+	s := startStepOutput(step)
+	cmd.SetStdout(s.Stdout())
+	cmd.SetStderr(s.Stderr())
+	err := cmd.Run()
+	s.Finish(err)
+	return err
+}
+
+// Stdout returns the writer for the step's standard output.
+func (s *stepOutput) Stdout() io.Writer { return s.stdout }
+
+// Stderr returns the writer for the step's standard error.
+func (s *stepOutput) Stderr() io.Writer { return s.stderr }
+
+// line records one line of output and shows it according to the output mode. A progress
+// line is a '\r' redraw, shown only with --verbose.
+func (s *stepOutput) line(stream, text string, progress bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.lastOutput = now
+	s.logf("%s | %s", stream, text)
+	if progress && s.mode != OutputModeVerbose {
+		return
+	}
+	switch s.mode {
+	case OutputModeQuiet:
+		s.tail = append(s.tail, text)
+		if len(s.tail) > stepTailLines {
+			s.tail = s.tail[1:]
+		}
+	case OutputModeVerbose:
+		fmt.Fprintf(s.out, "[%s +%s] %s\n", s.step, formatStepDuration(now.Sub(s.start)), text)
+	default:
+		fmt.Fprintf(s.out, "[%s] %s\n", s.step, text)
+	}
+}
+
+func (s *stepOutput) startHeartbeat() {
+	s.heartbeatDone = make(chan struct{})
+	go s.heartbeat(stepHeartbeat)
+}
+
+// heartbeat prints a line while the step runs without output, so a slow tool is not mistaken
+// for a hung one.
+func (s *stepOutput) heartbeat(interval time.Duration) {
+	defer close(s.heartbeatDone)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			now := s.now()
+			if now.Sub(s.lastOutput) >= interval {
+				fmt.Fprintf(s.out, "[%s] still running (%s elapsed)\n", s.step, formatStepDuration(now.Sub(s.start)))
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// Finish flushes the step's output and reports how it ended. A step killed by the command
+// deadline is reported as timed out.
+func (s *stepOutput) Finish(err error) {
+	s.stdout.flush()
+	s.stderr.flush()
+	close(s.done)
+	// No "still running" line may follow the step's final status.
+	if s.heartbeatDone != nil {
+		<-s.heartbeatDone
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	duration := s.now().Sub(s.start)
+	status := fmt.Sprintf("done in %s", formatStepDuration(duration))
+	if err != nil {
+		status = fmt.Sprintf("failed after %s: %v", formatStepDuration(duration), err)
+		if errors.Is(commandContext().Err(), context.DeadlineExceeded) {
+			status = fmt.Sprintf("timed out after %s", formatStepDuration(duration))
+		}
+	}
+	s.logf("--- %s %s", s.step, status)
+
+	switch s.mode {
+	case OutputModeQuiet:
+		s.stop(err == nil, fmt.Sprintf("%s %s", s.step, status))
+		if err != nil {
+			for _, text := range s.tail {
+				fmt.Fprintf(s.out, "[%s] %s\n", s.step, text)
+			}
+		}
+	case OutputModeVerbose:
+		fmt.Fprintf(s.out, "[%s] %s\n", s.step, status)
+	default:
+		if err != nil {
+			fmt.Fprintf(s.out, "[%s] %s\n", s.step, status)
+		}
+	}
+	if err != nil && s.logPath != "" {
+		fmt.Fprintf(s.out, "[%s] full output: %s\n", s.step, s.logPath)
+	}
+}
+
+// logf appends a timestamped line to the run log; callers hold s.mu.
+func (s *stepOutput) logf(format string, a ...any) {
+	if s.log == nil {
+		return
+	}
+	fmt.Fprintf(s.log, "%s [%s] %s\n", s.now().UTC().Format(time.RFC3339), s.step, fmt.Sprintf(format, a...))
+}
+
+// stepLineWriter splits one stream of a step into lines. A '\r' not followed by '\n' ends a
+// progress redraw.
+type stepLineWriter struct {
+	output  *stepOutput
+	stream  string
+	buf     []byte
+	pending bool // the previous byte was '\r'
+}
+
+func (w *stepLineWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if w.pending {
+			w.pending = false
+			if b != '\n' {
+				w.emit(true)
+			}
+		}
+		switch b {
+		case '\n':
+			w.emit(false)
+		case '\r':
+			w.pending = true
+		default:
+			w.buf = append(w.buf, b)
+		}
+	}
+	return len(p), nil
+}
+
+func (w *stepLineWriter) emit(progress bool) {
+	text := strings.TrimRight(string(w.buf), " \t")
+	w.buf = w.buf[:0]
+	if text == "" {
+		return
+	}
+	w.output.line(w.stream, text, progress)
+}
+
+// flush emits a final line without a newline.
+func (w *stepLineWriter) flush() {
+	w.pending = false
+	w.emit(false)
+}
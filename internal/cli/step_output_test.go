@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useStepOutput sets the output mode and gives the test its own run log, returning the
+// terminal output and the log path.
+func useStepOutput(t *testing.T, quiet, verbose bool) (*bytes.Buffer, string) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	setDefaultPrinterWriter(t, out)
+	if err := SetOutputMode(quiet, verbose); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	originalDir := runLogDir
+	runLogDir = func() (string, error) { return dir, nil }
+	runLog.Lock()
+	runLog.file, runLog.path, runLog.failed = nil, "", false
+	runLog.Unlock()
+	t.Cleanup(func() {
+		_ = SetOutputMode(false, false)
+		runLog.Lock()
+		if runLog.file != nil {
+			_ = runLog.file.Close()
+		}
+		runLog.file, runLog.path, runLog.failed = nil, "", false
+		runLog.Unlock()
+		runLogDir = originalDir
+	})
+	return out, dir + "/" + runLog.id + ".log"
+}
+
+// toolCommand writes stdout and stderr like a tool would and fails with runErr.
+func toolCommand(stdout, stderr string, runErr error) *MockCommand {
+	cmd := &MockCommand{}
+	cmd.RunFunc = func() error {
+		_, _ = cmd.StdoutW.Write([]byte(stdout))
+		_, _ = cmd.StderrW.Write([]byte(stderr))
+		return runErr
+	}
+	return cmd
+}
+
+func TestRunStep(t *testing.T) {
+	t.Run("prefixes lines and collapses progress", func(t *testing.T) {
+		out, logPath := useStepOutput(t, false, false)
+
+		cmd := toolCommand("Step 1/2 : FROM alpine\r\n 10%\r 50%\r100%\nSuccessfully built\n", "warning: no tag", nil)
+		if err := runStep("build", cmd); err != nil {
+			t.Fatalf("runStep() error: %v", err)
+		}
+		want := "[build] Step 1/2 : FROM alpine\n[build] 100%\n[build] Successfully built\n[build] warning: no tag\n"
+		if out.String() != want {
+			t.Fatalf("unexpected output:\n%s", out.String())
+		}
+		log, err := os.ReadFile(logPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range []string{"[build] --- build started", "[build] stdout |  50%", "[build] stderr | warning: no tag", "[build] --- build done in"} {
+			if !strings.Contains(string(log), line) {
+				t.Fatalf("run log misses %q:\n%s", line, log)
+			}
+		}
+	})
+
+	t.Run("verbose shows progress and timing", func(t *testing.T) {
+		out, logPath := useStepOutput(t, false, true)
+
+		if err := runStep("push", toolCommand(" 10%\r100%\n", "", nil)); err != nil {
+			t.Fatalf("runStep() error: %v", err)
+		}
+		for _, line := range []string{"[push] logging to " + logPath, "[push +0s]  10%", "[push +0s] 100%", "[push] done in 0s"} {
+			if !strings.Contains(out.String(), line) {
+				t.Fatalf("output misses %q:\n%s", line, out.String())
+			}
+		}
+	})
+
+	t.Run("quiet shows the tail only on failure", func(t *testing.T) {
+		out, logPath := useStepOutput(t, true, false)
+
+		if err := runStep("eksctl", toolCommand("creating stack\n", "", nil)); err != nil {
+			t.Fatalf("runStep() error: %v", err)
+		}
+		if strings.Contains(out.String(), "creating stack") {
+			t.Fatalf("expected no tool output on success:\n%s", out.String())
+		}
+
+		out.Reset()
+		err := runStep("eksctl", toolCommand("creating stack\n", "AlreadyExistsException\n", errors.New("exit status 1")))
+		if err == nil {
+			t.Fatal("expected the command error")
+		}
+		for _, line := range []string{"[eksctl] creating stack", "[eksctl] AlreadyExistsException", "[eksctl] full output: " + logPath} {
+			if !strings.Contains(out.String(), line) {
+				t.Fatalf("output misses %q:\n%s", line, out.String())
+			}
+		}
+	})
+
+	t.Run("silent step prints a heartbeat", func(t *testing.T) {
+		out, _ := useStepOutput(t, false, false)
+		original := stepHeartbeat
+		stepHeartbeat = 10 * time.Millisecond
+		t.Cleanup(func() { stepHeartbeat = original })
+
+		cmd := &MockCommand{RunFunc: func() error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}}
+		if err := runStep("kind", cmd); err != nil {
+			t.Fatalf("runStep() error: %v", err)
+		}
+		if !strings.Contains(out.String(), "[kind] still running (") {
+			t.Fatalf("expected a heartbeat:\n%s", out.String())
+		}
+	})
+}
+
+func TestSetOutputModeRejectsQuietAndVerbose(t *testing.T) {
+	if err := SetOutputMode(true, true); !errors.Is(err, ErrInvalidOutputMode) {
+		t.Fatalf("expected ErrInvalidOutputMode, got %v", err)
+	}
+	if mode := currentOutputMode(); mode != OutputModeNormal {
+		t.Fatalf("expected the mode to stay %s, got %s", OutputModeNormal, mode)
+	}
+}
@@ -0,0 +1,375 @@
+package cli
+
+// This file implements the "team" command, which onboards a team in one step. "team create"
+// applies the team's namespace together with a Role and RoleBindings for the team's groups,
+// a ResourceQuota and LimitRange, copies of the registry pull secrets, and the ingress host
+// prefix annotation the operator uses to put the team's servers under their own subdomain.
+// Everything is applied with kubectl apply, so rerunning it updates the team.
+//
+// Example usage:
+//   mcp-runtime team create payments --group payments-devs --host-prefix payments
+//   mcp-runtime team list
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// LabelTeam marks a namespace created by "team create" with the team name.
+	LabelTeam = "mcpruntime.org/team"
+
+	// AnnotationTeamGroups lists the groups bound to a team namespace, comma separated.
+	AnnotationTeamGroups = "mcpruntime.org/team-groups"
+
+	// AnnotationIngressHostPrefix on a namespace makes the operator put servers without
+	// spec.ingressHost under prefix.<default host>.
+	AnnotationIngressHostPrefix = "mcpruntime.org/ingress-host-prefix"
+
+	// teamRoleName is the Role granted to a team's groups in its namespace.
+	teamRoleName = "mcp-team"
+)
+
+// teamRoleRules let a team manage its MCP servers and their configuration and debug their
+// pods, without touching the resources the operator owns.
+var teamRoleRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{"mcpruntime.org"}, Resources: []string{"mcpservers"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+	{APIGroups: []string{"mcpruntime.org"}, Resources: []string{"mcpservers/status"}, Verbs: []string{"get"}},
+	{APIGroups: []string{""}, Resources: []string{"secrets", "configmaps"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+	{APIGroups: []string{""}, Resources: []string{"pods", "pods/log", "services", "events", "resourcequotas"}, Verbs: []string{"get", "list", "watch"}},
+	{APIGroups: []string{""}, Resources: []string{"pods/portforward", "pods/exec"}, Verbs: []string{"create"}},
+	{APIGroups: []string{"apps"}, Resources: []string{"deployments", "replicasets"}, Verbs: []string{"get", "list", "watch"}},
+	{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: []string{"get", "list", "watch"}},
+}
+
+// TeamOptions describes a team to create.
+type TeamOptions struct {
+	Name       string
+	Groups     []string
+	HostPrefix string
+	// PullSecretsFrom is the namespace whose docker registry secrets are copied to the team.
+	PullSecretsFrom string
+	Quota           QuotaOptions
+}
+
+// teamInfo is one row of "team list".
+type teamInfo struct {
+	Name       string
+	Namespace  string
+	Groups     string
+	HostPrefix string
+}
+
+// TeamManager onboards teams with injected dependencies.
+type TeamManager struct {
+	kubectl *KubectlClient
+	logger  *zap.Logger
+}
+
+// NewTeamManager creates a TeamManager with the given dependencies.
+func NewTeamManager(kubectl *KubectlClient, logger *zap.Logger) *TeamManager {
+	return &TeamManager{
+		kubectl: kubectl,
+		logger:  logger,
+	}
+}
+
+// DefaultTeamManager returns a TeamManager using default clients.
+func DefaultTeamManager(logger *zap.Logger) *TeamManager {
+	return NewTeamManager(kubectlClient, logger)
+}
+
+// NewTeamCmd returns the team subcommand.
+func NewTeamCmd(logger *zap.Logger) *cobra.Command {
+	return NewTeamCmdWithManager(DefaultTeamManager(logger))
+}
+
+// NewTeamCmdWithManager returns the team subcommand using the provided manager.
+func NewTeamCmdWithManager(mgr *TeamManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "team",
+		Short: "Onboard teams onto the platform",
+		Long:  "Provision a namespace per team with access for the team's groups, a quota, registry pull secrets and an ingress host prefix",
+	}
+
+	cmd.AddCommand(mgr.newTeamCreateCmd())
+	cmd.AddCommand(mgr.newTeamListCmd())
+
+	return cmd
+}
+
+func (m *TeamManager) newTeamCreateCmd() *cobra.Command {
+	opts := TeamOptions{Quota: DefaultQuotaOptions()}
+
+	cmd := &cobra.Command{
+		Use:   "create <team>",
+		Short: "Create or update a team namespace",
+		Long: `Create the namespace <team> and, in it:
+  - a Role to manage MCPServers, Secrets and ConfigMaps and read pods and logs, bound to each --group
+  - a ResourceQuota and LimitRange (--quota-* flags)
+  - copies of the docker registry pull secrets of --pull-secrets-from
+  - the ingress host prefix: servers without spec.ingressHost are served on <prefix>.<default host>
+
+Rerun it to change the groups, quota or prefix; rerun it after rotating registry credentials
+to refresh the copied pull secrets.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			if !cmd.Flags().Changed("host-prefix") {
+				opts.HostPrefix = opts.Name
+			}
+			if opts.PullSecretsFrom == "" {
+				opts.PullSecretsFrom = serverNamespace()
+			}
+			return m.CreateTeam(opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.Groups, "group", nil, "Group bound to the team Role (repeat for several groups)")
+	cmd.Flags().StringVar(&opts.HostPrefix, "host-prefix", "", "Subdomain for the team's servers (default: the team name; empty disables it)")
+	cmd.Flags().StringVar(&opts.PullSecretsFrom, "pull-secrets-from", "", "Namespace to copy registry pull secrets from (default: the servers namespace)")
+	cmd.Flags().StringVar(&opts.Quota.RequestsCPU, "quota-requests-cpu", opts.Quota.RequestsCPU, "Total CPU requests allowed in the team namespace")
+	cmd.Flags().StringVar(&opts.Quota.RequestsMemory, "quota-requests-memory", opts.Quota.RequestsMemory, "Total memory requests allowed in the team namespace")
+	cmd.Flags().StringVar(&opts.Quota.LimitsCPU, "quota-limits-cpu", opts.Quota.LimitsCPU, "Total CPU limits allowed in the team namespace")
+	cmd.Flags().StringVar(&opts.Quota.LimitsMemory, "quota-limits-memory", opts.Quota.LimitsMemory, "Total memory limits allowed in the team namespace")
+	cmd.Flags().IntVar(&opts.Quota.Pods, "quota-pods", opts.Quota.Pods, "Maximum number of pods in the team namespace")
+	cmd.Flags().StringVar(&opts.Quota.MaxCPU, "quota-max-cpu", opts.Quota.MaxCPU, "Largest CPU limit a single container may request")
+	cmd.Flags().StringVar(&opts.Quota.MaxMemory, "quota-max-memory", opts.Quota.MaxMemory, "Largest memory limit a single container may request")
+
+	return cmd
+}
+
+func (m *TeamManager) newTeamListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List teams",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.ListTeams()
+		},
+	}
+}
+
+// Validate checks the team name, groups, host prefix and quota.
+func (o TeamOptions) Validate() error {
+	if errs := validation.IsDNS1123Label(o.Name); len(errs) > 0 {
+		return newWithSentinel(ErrInvalidTeam, fmt.Sprintf("invalid team name %q: %s", o.Name, strings.Join(errs, "; ")))
+	}
+	if len(o.Groups) == 0 {
+		return newWithSentinel(ErrInvalidTeam, "at least one --group is required")
+	}
+	for _, group := range o.Groups {
+		if strings.TrimSpace(group) == "" || strings.Contains(group, ",") {
+			return newWithSentinel(ErrInvalidTeam, fmt.Sprintf("invalid group %q: must be non-empty and contain no commas", group))
+		}
+	}
+	if o.HostPrefix != "" {
+		if errs := validation.IsDNS1123Label(o.HostPrefix); len(errs) > 0 {
+			return newWithSentinel(ErrInvalidTeam, fmt.Sprintf("invalid --host-prefix %q: %s", o.HostPrefix, strings.Join(errs, "; ")))
+		}
+	}
+	return o.Quota.Validate()
+}
+
+// CreateTeam applies the team's namespace, RBAC, quota and pull secrets.
+func (m *TeamManager) CreateTeam(opts TeamOptions) error {
+	if err := opts.Validate(); err != nil {
+		Error("Invalid team")
+		logStructuredError(m.logger, err, "Invalid team")
+		return err
+	}
+
+	secrets, err := m.pullSecrets(opts.PullSecretsFrom)
+	if err != nil {
+		return m.teamError(err, opts, "Failed to read registry pull secrets")
+	}
+	manifest, err := renderTeamManifest(opts, secrets)
+	if err != nil {
+		return m.teamError(err, opts, "Failed to create team")
+	}
+
+	m.logger.Info("Creating team", zap.String("team", opts.Name), zap.Strings("groups", opts.Groups))
+	// #nosec G204 -- fixed kubectl command, manifest via stdin.
+	cmd, err := m.kubectl.CommandArgs([]string{"apply", "-f", "-"})
+	if err != nil {
+		return m.teamError(err, opts, "Failed to create team")
+	}
+	cmd.SetStdin(strings.NewReader(manifest))
+	cmd.SetStdout(DefaultPrinter.Stdout())
+	cmd.SetStderr(DefaultPrinter.Stderr())
+	if err := cmd.Run(); err != nil {
+		return m.teamError(err, opts, "Failed to create team")
+	}
+
+	Success(fmt.Sprintf("Team %s ready in namespace %s for %s", opts.Name, opts.Name, strings.Join(opts.Groups, ", ")))
+	if len(secrets) == 0 {
+		Warn(fmt.Sprintf("No registry pull secrets found in %s; servers pulling from an authenticated registry will fail", opts.PullSecretsFrom))
+	}
+	if opts.HostPrefix != "" {
+		Info(fmt.Sprintf("Servers without spec.ingressHost are served on %s.<default host>", opts.HostPrefix))
+	}
+	Info(fmt.Sprintf("Deploy into it with: mcp-runtime server create <name> -n %s --image <image>", opts.Name))
+	return nil
+}
+
+// pullSecrets returns the docker registry secrets of namespace.
+func (m *TeamManager) pullSecrets(namespace string) ([]corev1.Secret, error) {
+	// #nosec G204 -- namespace validated by kubectl; fixed field selector.
+	out, err := m.kubectl.Output([]string{"get", "secrets", "-n", namespace, "--field-selector", "type=" + string(corev1.SecretTypeDockerConfigJson), "-o", "json"})
+	if err != nil {
+		return nil, err
+	}
+	var list corev1.SecretList
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("parse secrets: %w", err)
+	}
+	return list.Items, nil
+}
+
+// renderTeamManifest renders the team's namespace, Role, RoleBindings, quota and copies of
+// secrets as one multi-document YAML manifest.
+func renderTeamManifest(opts TeamOptions, secrets []corev1.Secret) (string, error) {
+	labels := map[string]string{LabelManagedBy: LabelManagedByValue, LabelTeam: opts.Name}
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        opts.Name,
+			Labels:      labels,
+			Annotations: map[string]string{AnnotationTeamGroups: strings.Join(opts.Groups, ",")},
+		},
+	}
+	if opts.HostPrefix != "" {
+		namespace.Annotations[AnnotationIngressHostPrefix] = opts.HostPrefix
+	}
+	objects := []any{
+		namespace,
+		&rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: teamRoleName, Namespace: opts.Name, Labels: labels},
+			Rules:      teamRoleRules,
+		},
+	}
+	groups := append([]string(nil), opts.Groups...)
+	sort.Strings(groups)
+	for _, group := range groups {
+		objects = append(objects, &rbacv1.RoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: teamRoleBindingName(group), Namespace: opts.Name, Labels: labels},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: group}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: teamRoleName},
+		})
+	}
+	for _, secret := range secrets {
+		objects = append(objects, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Namespace: opts.Name, Labels: labels},
+			Type:       secret.Type,
+			Data:       secret.Data,
+		})
+	}
+
+	var b strings.Builder
+	for _, obj := range objects {
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		b.Write(doc)
+		b.WriteString("---\n")
+	}
+	b.WriteString(renderQuotaManifest(opts.Name, opts.Quota))
+	return b.String(), nil
+}
+
+// teamRoleBindingName names the RoleBinding of group; group names may hold characters
+// object names cannot, such as ':' or '@'.
+func teamRoleBindingName(group string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, group)
+	return teamRoleName + "-" + strings.Trim(name, "-.")
+}
+
+// ListTeams prints the namespaces created by "team create".
+func (m *TeamManager) ListTeams() error {
+	// #nosec G204 -- fixed kubectl command with a constant selector.
+	out, err := m.kubectl.Output([]string{"get", "namespaces", "-l", LabelTeam, "-o", "json"})
+	if err != nil {
+		wrappedErr := wrapWithSentinelAndContext(
+			ErrListTeamsFailed,
+			err,
+			fmt.Sprintf("failed to list teams: %v", err),
+			map[string]any{"component": "team"},
+		)
+		Error("Failed to list teams")
+		logStructuredError(m.logger, wrappedErr, "Failed to list teams")
+		return wrappedErr
+	}
+	teams, err := parseTeams(out)
+	if err != nil {
+		wrappedErr := wrapWithSentinel(ErrListTeamsFailed, err, fmt.Sprintf("failed to parse teams: %v", err))
+		Error("Failed to list teams")
+		logStructuredError(m.logger, wrappedErr, "Failed to list teams")
+		return wrappedErr
+	}
+	if len(teams) == 0 {
+		Info("No teams found; create one with: mcp-runtime team create <team> --group <group>")
+		return nil
+	}
+	rows := [][]string{{"Team", "Namespace", "Groups", "Host prefix"}}
+	for _, team := range teams {
+		prefix := team.HostPrefix
+		if prefix == "" {
+			prefix = "-"
+		}
+		rows = append(rows, []string{team.Name, team.Namespace, team.Groups, prefix})
+	}
+	TableBoxed(rows)
+	return nil
+}
+
+// parseTeams reads the teams from a namespace list, ordered by name.
+func parseTeams(data []byte) ([]teamInfo, error) {
+	var list corev1.NamespaceList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	teams := make([]teamInfo, 0, len(list.Items))
+	for _, ns := range list.Items {
+		teams = append(teams, teamInfo{
+			Name:       ns.Labels[LabelTeam],
+			Namespace:  ns.Name,
+			Groups:     strings.ReplaceAll(ns.Annotations[AnnotationTeamGroups], ",", ", "),
+			HostPrefix: ns.Annotations[AnnotationIngressHostPrefix],
+		})
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].Name < teams[j].Name })
+	return teams, nil
+}
+
+func (m *TeamManager) teamError(err error, opts TeamOptions, msg string) error {
+	wrappedErr := wrapWithSentinelAndContext(
+		ErrCreateTeamFailed,
+		err,
+		fmt.Sprintf("%s %q: %v", strings.ToLower(msg), opts.Name, err),
+		map[string]any{"team": opts.Name, "namespace": opts.Name, "component": "team"},
+	)
+	Error(msg)
+	logStructuredError(m.logger, wrappedErr, msg)
+	return wrappedErr
+}
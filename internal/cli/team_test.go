@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestTeamOptionsValidate(t *testing.T) {
+	valid := TeamOptions{Name: "payments", Groups: []string{"payments-devs"}, HostPrefix: "payments", Quota: DefaultQuotaOptions()}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	for name, mutate := range map[string]func(*TeamOptions){
+		"bad name":      func(o *TeamOptions) { o.Name = "Payments" },
+		"no groups":     func(o *TeamOptions) { o.Groups = nil },
+		"comma group":   func(o *TeamOptions) { o.Groups = []string{"a,b"} },
+		"bad prefix":    func(o *TeamOptions) { o.HostPrefix = "pay.ments" },
+		"invalid quota": func(o *TeamOptions) { o.Quota.Pods = -1 },
+	} {
+		opts := valid
+		mutate(&opts)
+		if err := opts.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTeamRoleBindingName(t *testing.T) {
+	if got := teamRoleBindingName("oidc:Payments@acme.io"); got != "mcp-team-oidc-payments-acme.io" {
+		t.Fatalf("teamRoleBindingName() = %q", got)
+	}
+}
+
+func TestCreateTeam(t *testing.T) {
+	setDefaultPrinterWriter(t, &bytes.Buffer{})
+	opts := TeamOptions{Name: "payments", Groups: []string{"payments-devs", "sre"}, HostPrefix: "pay", PullSecretsFrom: "mcp-servers", Quota: DefaultQuotaOptions()}
+
+	t.Run("applies the team manifest", func(t *testing.T) {
+		var applied string
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			if spec.Args[0] == "get" {
+				return &MockCommand{OutputData: []byte(`{"items":[{"metadata":{"name":"registry-pull-creds","namespace":"mcp-servers","resourceVersion":"7"},"type":"kubernetes.io/dockerconfigjson","data":{".dockerconfigjson":"e30="}}]}`)}
+			}
+			cmd := &MockCommand{}
+			cmd.RunFunc = func() error {
+				data, err := io.ReadAll(cmd.StdinR)
+				applied = string(data)
+				return err
+			}
+			return cmd
+		}
+		mgr := NewTeamManager(&KubectlClient{exec: mock}, zap.NewNop())
+
+		if err := mgr.CreateTeam(opts); err != nil {
+			t.Fatalf("CreateTeam() error: %v", err)
+		}
+		if got := strings.Join(mock.Commands[0].Args, " "); got != "get secrets -n mcp-servers --field-selector type=kubernetes.io/dockerconfigjson -o json" {
+			t.Fatalf("unexpected secrets lookup %q", got)
+		}
+		for _, want := range []string{
+			"kind: Namespace",
+			AnnotationIngressHostPrefix + ": pay",
+			AnnotationTeamGroups + ": payments-devs,sre",
+			"kind: Role\n",
+			"name: mcp-team-payments-devs",
+			"name: mcp-team-sre",
+			"name: registry-pull-creds\n  namespace: payments",
+			".dockerconfigjson: e30=",
+			"kind: ResourceQuota",
+		} {
+			if !strings.Contains(applied, want) {
+				t.Errorf("manifest missing %q:\n%s", want, applied)
+			}
+		}
+		if strings.Contains(applied, "resourceVersion") {
+			t.Errorf("copied secret kept its resourceVersion:\n%s", applied)
+		}
+	})
+
+	t.Run("apply failure", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mock.CommandFunc = func(spec ExecSpec) *MockCommand {
+			if spec.Args[0] == "get" {
+				return &MockCommand{OutputData: []byte(`{"items":[]}`)}
+			}
+			return &MockCommand{RunErr: errors.New("forbidden")}
+		}
+		mgr := NewTeamManager(&KubectlClient{exec: mock}, zap.NewNop())
+		if err := mgr.CreateTeam(opts); !errors.Is(err, ErrCreateTeamFailed) {
+			t.Fatalf("expected ErrCreateTeamFailed, got %v", err)
+		}
+	})
+
+	t.Run("invalid team", func(t *testing.T) {
+		mock := &MockExecutor{}
+		mgr := NewTeamManager(&KubectlClient{exec: mock}, zap.NewNop())
+		bad := opts
+		bad.Groups = nil
+		if err := mgr.CreateTeam(bad); !errors.Is(err, ErrInvalidTeam) {
+			t.Fatalf("expected ErrInvalidTeam, got %v", err)
+		}
+		if len(mock.Commands) != 0 {
+			t.Fatalf("expected no kubectl calls, got %+v", mock.Commands)
+		}
+	})
+}
+
+func TestParseTeams(t *testing.T) {
+	data := []byte(`{"items":[
+		{"metadata":{"name":"search","labels":{"mcpruntime.org/team":"search"},"annotations":{"mcpruntime.org/team-groups":"search-devs"}}},
+		{"metadata":{"name":"payments","labels":{"mcpruntime.org/team":"payments"},"annotations":{"mcpruntime.org/team-groups":"payments-devs,sre","mcpruntime.org/ingress-host-prefix":"pay"}}}
+	]}`)
+	teams, err := parseTeams(data)
+	if err != nil {
+		t.Fatalf("parseTeams() error: %v", err)
+	}
+	if len(teams) != 2 || teams[0] != (teamInfo{Name: "payments", Namespace: "payments", Groups: "payments-devs, sre", HostPrefix: "pay"}) || teams[1].Name != "search" {
+		t.Fatalf("unexpected teams %+v", teams)
+	}
+}
@@ -0,0 +1,212 @@
+package cli
+
+// This file tracks the temporary files the CLI writes: rendered manifests, image archives,
+// kind configs and kustomizations. Each is created through a tempArtifact and registered
+// until removed, so an interrupt can remove whatever is still on disk (see NotifyInterrupt),
+// and "mcp-runtime clean" recognizes the leftovers of runs that were killed outright.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// tempArtifact is a kind of temporary file. Dir "." keeps the file under the working
+// directory, which kubectl path validation requires for files passed to it; "" uses the
+// system temp directory.
+type tempArtifact struct {
+	dir     string
+	pattern string
+}
+
+var (
+	tempManagerManifest       = tempArtifact{dir: ".", pattern: "manager-*.yaml"}
+	tempImageArchive          = tempArtifact{dir: ".", pattern: "mcp-img-*.tar"}
+	tempOperatorKustomization = tempArtifact{dir: ".", pattern: "operator-kustomize-*"}
+	tempMirrorKustomization   = tempArtifact{dir: ".", pattern: "mirror-kustomize-*"}
+	tempKindConfig            = tempArtifact{dir: "", pattern: "mcp-kind-config-*.yaml"}
+	tempServerManifest        = tempArtifact{dir: "", pattern: "mcpserver-*.yaml"}
+)
+
+// tempArtifacts lists every kind of temporary file, for clean.
+var tempArtifacts = []tempArtifact{
+	tempManagerManifest,
+	tempImageArchive,
+	tempOperatorKustomization,
+	tempMirrorKustomization,
+	tempKindConfig,
+	tempServerManifest,
+}
+
+// liveTempFiles holds the temporary files of this process that are not removed yet.
+var liveTempFiles = struct {
+	sync.Mutex
+	paths map[string]struct{}
+}{paths: map[string]struct{}{}}
+
+// create creates a temporary file of this kind and registers it for cleanup.
+func (a tempArtifact) create() (*os.File, error) {
+	f, err := os.CreateTemp(a.dir, a.pattern)
+	if err != nil {
+		return nil, err
+	}
+	trackTempFile(f.Name())
+	return f, nil
+}
+
+// mkdir creates a temporary directory of this kind and registers it for cleanup.
+func (a tempArtifact) mkdir() (string, error) {
+	dir, err := os.MkdirTemp(a.dir, a.pattern)
+	if err != nil {
+		return "", err
+	}
+	trackTempFile(dir)
+	return dir, nil
+}
+
+// matches reports whether name was generated from the pattern: os.CreateTemp and
+// os.MkdirTemp replace "*" with digits only, so user files such as manager-prod.yaml
+// never match.
+func (a tempArtifact) matches(name string) bool {
+	prefix, suffix, _ := strings.Cut(a.pattern, "*")
+	re := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + "[0-9]+" + regexp.QuoteMeta(suffix) + "$")
+	return re.MatchString(name)
+}
+
+func trackTempFile(path string) {
+	liveTempFiles.Lock()
+	defer liveTempFiles.Unlock()
+	liveTempFiles.paths[path] = struct{}{}
+}
+
+// removeTempFile removes a temporary file or directory and stops tracking it.
+func removeTempFile(path string) {
+	liveTempFiles.Lock()
+	delete(liveTempFiles.paths, path)
+	liveTempFiles.Unlock()
+	_ = os.RemoveAll(path)
+}
+
+// CleanupTempFiles removes every temporary file of this process that is still on disk.
+// It is safe to call more than once.
+func CleanupTempFiles() {
+	liveTempFiles.Lock()
+	paths := make([]string, 0, len(liveTempFiles.paths))
+	for path := range liveTempFiles.paths {
+		paths = append(paths, path)
+	}
+	clear(liveTempFiles.paths)
+	liveTempFiles.Unlock()
+	for _, path := range paths {
+		_ = os.RemoveAll(path)
+	}
+}
+
+// interruptExitCode is the conventional exit status after SIGINT.
+const interruptExitCode = 130
+
+// NotifyInterrupt returns a context cancelled on the first SIGINT or SIGTERM, so running
+// kubectl and docker commands are stopped and their callers clean up as they return. A
+// second signal removes the temporary files right away and exits, for commands that do
+// not return promptly. stop releases the signal handlers.
+func NotifyInterrupt(parent context.Context) (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		cancel()
+		fmt.Fprintln(os.Stderr, "\nInterrupted; cleaning up (press Ctrl-C again to exit now)")
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		CleanupTempFiles()
+		os.Exit(interruptExitCode)
+	}()
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			cancel()
+		})
+	}
+}
+
+// staleTempFile is a leftover temporary file found by clean.
+type staleTempFile struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// findStaleTempFiles returns the temporary files in workDir and tempDir last modified
+// before cutoff, oldest first.
+func findStaleTempFiles(workDir, tempDir string, cutoff time.Time) ([]staleTempFile, error) {
+	entries := map[string][]fs.DirEntry{}
+	var found []staleTempFile
+	var errs []error
+	for _, a := range tempArtifacts {
+		dir := tempDir
+		if a.dir == "." {
+			dir = workDir
+		}
+		list, ok := entries[dir]
+		if !ok {
+			var err error
+			list, err = os.ReadDir(dir)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			entries[dir] = list
+		}
+		for _, entry := range list {
+			if !a.matches(entry.Name()) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || !info.ModTime().Before(cutoff) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			size := info.Size()
+			if entry.IsDir() {
+				size = dirSize(path)
+			}
+			found = append(found, staleTempFile{Path: path, Size: size, ModTime: info.ModTime()})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ModTime.Before(found[j].ModTime) })
+	return found, errors.Join(errs...)
+}
+
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && !d.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
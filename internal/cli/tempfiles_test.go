@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestTempArtifactMatches(t *testing.T) {
+	tests := []struct {
+		artifact tempArtifact
+		name     string
+		want     bool
+	}{
+		{tempManagerManifest, "manager-123456789.yaml", true},
+		{tempManagerManifest, "manager-prod.yaml", false},
+		{tempManagerManifest, "manager-.yaml", false},
+		{tempImageArchive, "mcp-img-42.tar", true},
+		{tempOperatorKustomization, "operator-kustomize-987", true},
+		{tempOperatorKustomization, "operator-kustomize-987.yaml", false},
+	}
+	for _, tc := range tests {
+		if got := tc.artifact.matches(tc.name); got != tc.want {
+			t.Errorf("%s matches %s = %v, want %v", tc.artifact.pattern, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCleanupTempFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	f, err := tempManagerManifest.create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	f.Close()
+	dir, err := tempOperatorKustomization.mkdir()
+	if err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	kept, err := tempImageArchive.create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	kept.Close()
+	removeTempFile(kept.Name())
+
+	CleanupTempFiles()
+	for _, path := range []string{f.Name(), dir, kept.Name()} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected %s removed, got %v", path, err)
+		}
+	}
+	if len(liveTempFiles.paths) != 0 {
+		t.Fatalf("expected no tracked files, got %v", liveTempFiles.paths)
+	}
+}
+
+func TestCleanTempFiles(t *testing.T) {
+	var out bytes.Buffer
+	setDefaultPrinterWriter(t, &out)
+	workDir, tempDir := t.TempDir(), t.TempDir()
+	old := time.Now().Add(-2 * time.Hour)
+	write := func(path string, aged bool) string {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if aged {
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return path
+	}
+	stale := []string{
+		write(filepath.Join(workDir, "manager-1234.yaml"), true),
+		write(filepath.Join(workDir, "mcp-img-99.tar"), true),
+		write(filepath.Join(tempDir, "mcp-kind-config-5.yaml"), true),
+	}
+	kustomize := filepath.Join(workDir, "operator-kustomize-7")
+	if err := os.Mkdir(kustomize, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	write(filepath.Join(kustomize, "kustomization.yaml"), false)
+	if err := os.Chtimes(kustomize, old, old); err != nil {
+		t.Fatal(err)
+	}
+	stale = append(stale, kustomize)
+	keep := []string{
+		write(filepath.Join(workDir, "manager-prod.yaml"), true),
+		write(filepath.Join(workDir, "manager-5678.yaml"), false),
+		write(filepath.Join(tempDir, "manager-1234.yaml"), true),
+	}
+
+	if err := cleanTempFiles(zap.NewNop(), workDir, tempDir, time.Hour, true); err != nil {
+		t.Fatalf("dry run error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Would remove 4 files") {
+		t.Fatalf("unexpected dry run output:\n%s", out.String())
+	}
+	for _, path := range stale {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("dry run removed %s", path)
+		}
+	}
+
+	if err := cleanTempFiles(zap.NewNop(), workDir, tempDir, time.Hour, false); err != nil {
+		t.Fatalf("cleanTempFiles() error: %v", err)
+	}
+	for _, path := range stale {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected %s removed, got %v", path, err)
+		}
+	}
+	for _, path := range keep {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s kept, got %v", path, err)
+		}
+	}
+}
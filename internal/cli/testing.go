@@ -3,7 +3,11 @@ package cli
 // This file provides test doubles (mocks) for testing CLI functionality.
 // It includes MockCommand and MockExecutor for testing command execution.
 
-import "io"
+import (
+	"context"
+	"io"
+	"sync"
+)
 
 // MockCommand is a test double for Command interface.
 type MockCommand struct {
@@ -43,16 +47,21 @@ type MockExecutor struct {
 	DefaultRunErr error
 	// CommandFunc allows custom behavior per command.
 	CommandFunc func(spec ExecSpec) *MockCommand
+
+	// mu guards Commands for commands created concurrently.
+	mu sync.Mutex
 }
 
-func (m *MockExecutor) Command(name string, args []string, validators ...ExecValidator) (Command, error) {
+func (m *MockExecutor) Command(_ context.Context, name string, args []string, validators ...ExecValidator) (Command, error) {
 	spec := ExecSpec{Name: name, Args: args}
 	for _, v := range validators {
 		if err := v(spec); err != nil {
 			return nil, err
 		}
 	}
+	m.mu.Lock()
 	m.Commands = append(m.Commands, spec)
+	m.mu.Unlock()
 
 	if m.CommandFunc != nil {
 		return m.CommandFunc(spec), nil
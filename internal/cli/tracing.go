@@ -0,0 +1,83 @@
+package cli
+
+// This file contains OpenTelemetry instrumentation helpers for the CLI.
+// Spans are only exported when OTEL_EXPORTER_OTLP_ENDPOINT is set (see internal/tracing).
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "mcp-runtime/cli"
+
+// startSpan starts a span as a child of the current command context.
+func startSpan(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(commandContext(), name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err (if any) on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceStage runs fn inside a span named name, e.g. one stage of an image push.
+func traceStage(name string, fn func() error, attrs ...attribute.KeyValue) error {
+	_, span := startSpan(name, attrs...)
+	err := fn()
+	endSpan(span, err)
+	return err
+}
+
+// tracedCommand wraps a Command so each execution is recorded as a span.
+type tracedCommand struct {
+	Command
+	name string
+	args []string
+}
+
+func newTracedCommand(cmd Command, name string, args []string) Command {
+	return &tracedCommand{Command: cmd, name: name, args: args}
+}
+
+func (c *tracedCommand) start() trace.Span {
+	verb := ""
+	if len(c.args) > 0 {
+		verb = c.args[0]
+	}
+	// Only the binary and verb are recorded; full args may carry credentials.
+	_, span := startSpan(strings.TrimSpace("exec "+c.name+" "+verb),
+		attribute.String("exec.binary", c.name),
+		attribute.String("exec.verb", verb),
+	)
+	return span
+}
+
+func (c *tracedCommand) Output() ([]byte, error) {
+	span := c.start()
+	out, err := c.Command.Output()
+	endSpan(span, err)
+	return out, err
+}
+
+func (c *tracedCommand) CombinedOutput() ([]byte, error) {
+	span := c.start()
+	out, err := c.Command.CombinedOutput()
+	endSpan(span, err)
+	return out, err
+}
+
+func (c *tracedCommand) Run() error {
+	span := c.start()
+	err := c.Command.Run()
+	endSpan(span, err)
+	return err
+}
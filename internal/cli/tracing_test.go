@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func installSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		SetCommandContext(context.Background())
+	})
+	return recorder
+}
+
+func TestKubectlClientTracesExecution(t *testing.T) {
+	t.Run("records a span per kubectl call", func(t *testing.T) {
+		recorder := installSpanRecorder(t)
+		mock := &MockExecutor{DefaultOutput: []byte("ok")}
+		kubectl := &KubectlClient{exec: mock, validators: nil}
+
+		if _, err := kubectl.Output([]string{"get", "pods"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		spans := recorder.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(spans))
+		}
+		if spans[0].Name() != "exec kubectl get" {
+			t.Fatalf("span name = %q, want %q", spans[0].Name(), "exec kubectl get")
+		}
+	})
+
+	t.Run("marks span as error when command fails", func(t *testing.T) {
+		recorder := installSpanRecorder(t)
+		mock := &MockExecutor{DefaultRunErr: errors.New("boom")}
+		kubectl := &KubectlClient{exec: mock, validators: nil}
+
+		if err := kubectl.Run([]string{"apply", "-f", "x.yaml"}); err == nil {
+			t.Fatal("expected error")
+		}
+
+		spans := recorder.Ended()
+		if len(spans) != 1 {
+			t.Fatalf("expected 1 span, got %d", len(spans))
+		}
+		if spans[0].Status().Code != codes.Error {
+			t.Fatalf("span status = %v, want Error", spans[0].Status().Code)
+		}
+	})
+
+	t.Run("parents spans on the command context", func(t *testing.T) {
+		recorder := installSpanRecorder(t)
+		ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+		SetCommandContext(ctx)
+
+		if err := traceStage("push.save", func() error { return nil }); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		parent.End()
+
+		spans := recorder.Ended()
+		if len(spans) != 2 {
+			t.Fatalf("expected 2 spans, got %d", len(spans))
+		}
+		if spans[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Fatal("stage span is not a child of the command context span")
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// Values of spec.auth.type.
+const (
+	AuthTypeBasic  = "basic"
+	AuthTypeBearer = "bearer"
+	AuthTypeOIDC   = "oidc"
+)
+
+// NGINX external and basic auth annotations.
+const (
+	nginxAuthTypeAnnotation            = "nginx.ingress.kubernetes.io/auth-type"
+	nginxAuthSecretAnnotation          = "nginx.ingress.kubernetes.io/auth-secret"
+	nginxAuthRealmAnnotation           = "nginx.ingress.kubernetes.io/auth-realm"
+	nginxAuthURLAnnotation             = "nginx.ingress.kubernetes.io/auth-url"
+	nginxAuthSigninAnnotation          = "nginx.ingress.kubernetes.io/auth-signin"
+	nginxAuthResponseHeadersAnnotation = "nginx.ingress.kubernetes.io/auth-response-headers"
+)
+
+// traefikMiddlewaresAnnotation attaches Middlewares from Traefik's Kubernetes CRD
+// provider to the router of an Ingress.
+const traefikMiddlewaresAnnotation = "traefik.ingress.kubernetes.io/router.middlewares"
+
+// traefikMiddlewareGVK is the Traefik Middleware kind. The operator does not depend
+// on Traefik's Go types, so Middlewares are handled as unstructured objects.
+var traefikMiddlewareGVK = schema.GroupVersionKind{Group: "traefik.io", Version: "v1alpha1", Kind: "Middleware"}
+
+// authSecretKey is the Secret key each ingress controller reads htpasswd entries from.
+var authSecretKey = map[string]string{
+	"traefik": "users",
+	"nginx":   "auth",
+}
+
+const authRealm = "MCP server"
+
+// authEnabled reports whether the Ingress of mcpServer is protected by spec.auth.
+func authEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.Auth != nil && mcpServer.Spec.Auth.Type != ""
+}
+
+// authMiddlewareName is the name of the Traefik Middleware guarding mcpServer.
+func authMiddlewareName(mcpServer *mcpv1alpha1.MCPServer) string {
+	return mcpServer.Name + "-auth"
+}
+
+// validateAuth rejects spec.auth on ingress classes the operator cannot configure, and
+// basic auth whose Secret lacks the htpasswd key of the ingress class. The Ingress is
+// not written until the auth settings are valid, so the endpoint is never exposed
+// without them. Spec problems are permanent; a missing or incomplete Secret is retried,
+// since fixing it does not change the MCPServer.
+func (r *MCPServerReconciler) validateAuth(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	if !authEnabled(mcpServer) {
+		return nil
+	}
+	auth := mcpServer.Spec.Auth
+	key, supported := authSecretKey[mcpServer.Spec.IngressClass]
+	var message string
+	sentinel := ErrInvalidAuth
+	switch {
+	case !supported:
+		message = fmt.Sprintf("spec.auth requires ingressClass traefik or nginx, got %q", mcpServer.Spec.IngressClass)
+	case auth.Type == AuthTypeBasic && auth.SecretRef == "":
+		message = "spec.auth.secretRef is required for basic auth"
+	case auth.Type == AuthTypeBasic:
+		message = r.checkAuthSecret(ctx, mcpServer.Namespace, auth.SecretRef, key)
+		sentinel = ErrAuthSecretNotReady
+	case auth.URL == "":
+		message = fmt.Sprintf("spec.auth.url is required for %s auth", auth.Type)
+	}
+	if message == "" {
+		return nil
+	}
+	contextMap := map[string]any{
+		"mcpServer":    mcpServer.Name,
+		"namespace":    mcpServer.Namespace,
+		"ingressClass": mcpServer.Spec.IngressClass,
+		"authType":     auth.Type,
+	}
+	err := wrapOperatorError(fmt.Errorf("%w: %s", sentinel, message), "Invalid auth configuration", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Invalid auth configuration")
+	return err
+}
+
+// checkAuthSecret returns why the basic auth Secret is unusable, or "" when it holds key.
+func (r *MCPServerReconciler) checkAuthSecret(ctx context.Context, namespace, name, key string) string {
+	var secret corev1.Secret
+	if err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("auth Secret %s/%s not found", namespace, name)
+		}
+		return fmt.Sprintf("read auth Secret %s/%s: %v", namespace, name, err)
+	}
+	if len(secret.Data[key]) == 0 {
+		return fmt.Sprintf("auth Secret %s/%s has no %q key with htpasswd entries", namespace, name, key)
+	}
+	return ""
+}
+
+// authAnnotations returns the Ingress annotations enabling spec.auth for the
+// server's ingress class, or nil when auth is off.
+func authAnnotations(mcpServer *mcpv1alpha1.MCPServer, existing map[string]string) map[string]string {
+	if !authEnabled(mcpServer) {
+		return nil
+	}
+	auth := mcpServer.Spec.Auth
+	switch mcpServer.Spec.IngressClass {
+	case "traefik":
+		middleware := traefikMiddlewareRef(mcpServer.Namespace, authMiddlewareName(mcpServer))
+		if user := existing[traefikMiddlewaresAnnotation]; user != "" {
+			// Authenticate before any user middleware runs.
+			middleware += "," + user
+		}
+		return map[string]string{traefikMiddlewaresAnnotation: middleware}
+	case "nginx":
+		if auth.Type == AuthTypeBasic {
+			return map[string]string{
+				nginxAuthTypeAnnotation:   "basic",
+				nginxAuthSecretAnnotation: auth.SecretRef,
+				nginxAuthRealmAnnotation:  authRealm,
+			}
+		}
+		annotations := map[string]string{nginxAuthURLAnnotation: auth.URL}
+		if auth.Type == AuthTypeOIDC && auth.SigninURL != "" {
+			annotations[nginxAuthSigninAnnotation] = auth.SigninURL
+		}
+		if len(auth.ResponseHeaders) > 0 {
+			annotations[nginxAuthResponseHeadersAnnotation] = strings.Join(auth.ResponseHeaders, ",")
+		}
+		return annotations
+	}
+	return nil
+}
+
+// reconcileAuthMiddleware keeps the Traefik Middleware of a traefik-class server with
+// spec.auth in place, and removes it once auth is turned off or the class changes.
+func (r *MCPServerReconciler) reconcileAuthMiddleware(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	var spec map[string]any
+	if authEnabled(mcpServer) && mcpServer.Spec.IngressClass == "traefik" && r.routesThroughIngress(mcpServer) {
+		spec = traefikAuthMiddlewareSpec(mcpServer.Spec.Auth)
+	}
+	err := r.reconcileTraefikMiddleware(ctx, mcpServer, authMiddlewareName(mcpServer), spec)
+	if meta.IsNoMatchError(err) {
+		return fmt.Errorf("%w: spec.auth with ingressClass traefik needs the Traefik Middleware CRD (traefik.io/v1alpha1) and the kubernetescrd provider", ErrInvalidAuth)
+	}
+	return err
+}
+
+// reconcileTraefikMiddleware keeps the Traefik Middleware name of mcpServer in place with
+// spec, or removes it when spec is nil. A NoMatch error is returned when the Middleware
+// CRD is missing, for the caller to explain.
+func (r *MCPServerReconciler) reconcileTraefikMiddleware(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, name string, spec map[string]any) error {
+	logger := log.FromContext(ctx)
+
+	middleware := &unstructured.Unstructured{}
+	middleware.SetGroupVersionKind(traefikMiddlewareGVK)
+	middleware.SetName(name)
+	middleware.SetNamespace(mcpServer.Namespace)
+
+	if spec == nil {
+		// Only look for a Middleware to clean up when the Ingress still references one, so
+		// clusters without Traefik's CRDs never pay for the lookup.
+		var ingress networkingv1.Ingress
+		if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, &ingress); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !strings.Contains(ingress.Annotations[traefikMiddlewaresAnnotation], name+"@kubernetescrd") {
+			return nil
+		}
+		err := r.Delete(ctx, middleware)
+		if err == nil {
+			logger.Info("Traefik middleware deleted", "name", name)
+			return nil
+		}
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	op, err := ctrl.CreateOrUpdate(ctx, r.Client, middleware, func() error {
+		middleware.SetLabels(map[string]string{LabelApp: mcpServer.Name, LabelManagedBy: LabelManagedByValue})
+		if err := unstructured.SetNestedMap(middleware.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return ctrl.SetControllerReference(mcpServer, middleware, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("Traefik middleware reconciled", "operation", op, "name", name)
+	}
+	return nil
+}
+
+// traefikMiddlewareRef is how an Ingress annotation refers to Middleware name in namespace.
+func traefikMiddlewareRef(namespace, name string) string {
+	return namespace + "-" + name + "@kubernetescrd"
+}
+
+// traefikAuthMiddlewareSpec renders the Middleware spec for auth: basicAuth for basic,
+// forwardAuth to the auth service otherwise.
+func traefikAuthMiddlewareSpec(auth *mcpv1alpha1.AuthSpec) map[string]any {
+	if auth.Type == AuthTypeBasic {
+		return map[string]any{
+			"basicAuth": map[string]any{
+				"secret": auth.SecretRef,
+				"realm":  authRealm,
+			},
+		}
+	}
+	forwardAuth := map[string]any{"address": auth.URL}
+	if len(auth.ResponseHeaders) > 0 {
+		headers := make([]any, 0, len(auth.ResponseHeaders))
+		for _, h := range auth.ResponseHeaders {
+			headers = append(headers, h)
+		}
+		forwardAuth["authResponseHeaders"] = headers
+	}
+	return map[string]any{"forwardAuth": forwardAuth}
+}
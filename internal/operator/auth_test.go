@@ -0,0 +1,183 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newAuthServer(ingressClass string, auth *mcpv1alpha1.AuthSpec) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default", UID: "uid-1"},
+		Spec:       mcpv1alpha1.MCPServerSpec{IngressClass: ingressClass, Auth: auth},
+	}
+}
+
+func TestAuthAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *mcpv1alpha1.MCPServer
+		existing map[string]string
+		want     map[string]string
+	}{
+		{
+			name:   "disabled",
+			server: newAuthServer("nginx", nil),
+			want:   nil,
+		},
+		{
+			name:   "nginx basic",
+			server: newAuthServer("nginx", &mcpv1alpha1.AuthSpec{Type: AuthTypeBasic, SecretRef: "htpasswd"}),
+			want: map[string]string{
+				nginxAuthTypeAnnotation:   "basic",
+				nginxAuthSecretAnnotation: "htpasswd",
+				nginxAuthRealmAnnotation:  authRealm,
+			},
+		},
+		{
+			name: "nginx oidc",
+			server: newAuthServer("nginx", &mcpv1alpha1.AuthSpec{
+				Type: AuthTypeOIDC, URL: "https://auth.example.com/oauth2/auth", SigninURL: "https://auth.example.com/oauth2/start",
+				ResponseHeaders: []string{"X-Auth-Request-User", "X-Auth-Request-Email"},
+			}),
+			want: map[string]string{
+				nginxAuthURLAnnotation:             "https://auth.example.com/oauth2/auth",
+				nginxAuthSigninAnnotation:          "https://auth.example.com/oauth2/start",
+				nginxAuthResponseHeadersAnnotation: "X-Auth-Request-User,X-Auth-Request-Email",
+			},
+		},
+		{
+			name:   "nginx bearer ignores signin",
+			server: newAuthServer("nginx", &mcpv1alpha1.AuthSpec{Type: AuthTypeBearer, URL: "http://verifier/check", SigninURL: "http://ignored"}),
+			want:   map[string]string{nginxAuthURLAnnotation: "http://verifier/check"},
+		},
+		{
+			name:     "traefik runs auth before user middlewares",
+			server:   newAuthServer("traefik", &mcpv1alpha1.AuthSpec{Type: AuthTypeBearer, URL: "http://verifier/check"}),
+			existing: map[string]string{traefikMiddlewaresAnnotation: "default-ratelimit@kubernetescrd"},
+			want:     map[string]string{traefikMiddlewaresAnnotation: "default-test-server-auth@kubernetescrd,default-ratelimit@kubernetescrd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := authAnnotations(tt.server, tt.existing)
+			if len(got) != len(tt.want) {
+				t.Fatalf("annotations = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				assertEqual(t, k, got[k], v)
+			}
+		})
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	scheme := newServerTestScheme()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "htpasswd", Namespace: "default"},
+		Data:       map[string][]byte{"auth": []byte("user:$apr1$abc$def")},
+	}
+	tests := []struct {
+		name   string
+		server *mcpv1alpha1.MCPServer
+		want   error
+	}{
+		{"nginx basic with auth key", newAuthServer("nginx", &mcpv1alpha1.AuthSpec{Type: AuthTypeBasic, SecretRef: "htpasswd"}), nil},
+		{"traefik basic without users key", newAuthServer("traefik", &mcpv1alpha1.AuthSpec{Type: AuthTypeBasic, SecretRef: "htpasswd"}), ErrAuthSecretNotReady},
+		{"missing secret", newAuthServer("nginx", &mcpv1alpha1.AuthSpec{Type: AuthTypeBasic, SecretRef: "missing"}), ErrAuthSecretNotReady},
+		{"basic without secretRef", newAuthServer("nginx", &mcpv1alpha1.AuthSpec{Type: AuthTypeBasic}), ErrInvalidAuth},
+		{"oidc without url", newAuthServer("traefik", &mcpv1alpha1.AuthSpec{Type: AuthTypeOIDC}), ErrInvalidAuth},
+		{"unsupported class", newAuthServer("istio", &mcpv1alpha1.AuthSpec{Type: AuthTypeBearer, URL: "http://verifier"}), ErrInvalidAuth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.server, secret).
+				WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+			r := MCPServerReconciler{Client: c, Scheme: scheme}
+			err := r.validateAuth(context.Background(), tt.server, logr.Discard())
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("validateAuth: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestReconcileAuthMiddleware(t *testing.T) {
+	scheme := newServerTestScheme()
+	server := newAuthServer("traefik", &mcpv1alpha1.AuthSpec{
+		Type: AuthTypeOIDC, URL: "http://oauth2-proxy.auth/oauth2/auth", ResponseHeaders: []string{"X-Auth-Request-User"},
+	})
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	if err := r.reconcileAuthMiddleware(ctx, server); err != nil {
+		t.Fatalf("reconcileAuthMiddleware: %v", err)
+	}
+	middleware := &unstructured.Unstructured{}
+	middleware.SetGroupVersionKind(traefikMiddlewareGVK)
+	key := types.NamespacedName{Name: "test-server-auth", Namespace: "default"}
+	if err := c.Get(ctx, key, middleware); err != nil {
+		t.Fatalf("get middleware: %v", err)
+	}
+	address, _, _ := unstructured.NestedString(middleware.Object, "spec", "forwardAuth", "address")
+	assertEqual(t, "address", address, "http://oauth2-proxy.auth/oauth2/auth")
+	if refs := middleware.GetOwnerReferences(); len(refs) != 1 || refs[0].Name != "test-server" {
+		t.Errorf("expected owner reference to the MCPServer, got %+v", refs)
+	}
+
+	// Turning auth off removes the Middleware the Ingress still points at.
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name: "test-server", Namespace: "default",
+		Annotations: authAnnotations(server, nil),
+	}}
+	if err := c.Create(ctx, ingress); err != nil {
+		t.Fatalf("create ingress: %v", err)
+	}
+	server.Spec.Auth = nil
+	if err := r.reconcileAuthMiddleware(ctx, server); err != nil {
+		t.Fatalf("reconcileAuthMiddleware: %v", err)
+	}
+	if err := c.Get(ctx, key, middleware); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Fatalf("expected middleware to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileAuthMiddlewareWithoutTraefikCRDs(t *testing.T) {
+	scheme := newServerTestScheme()
+	server := newAuthServer("traefik", &mcpv1alpha1.AuthSpec{Type: AuthTypeBearer, URL: "http://verifier/check"})
+	// Without Traefik's CRDs the API server has no mapping for the Middleware kind.
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if obj.GetObjectKind().GroupVersionKind() == traefikMiddlewareGVK {
+				return &meta.NoKindMatchError{GroupKind: traefikMiddlewareGVK.GroupKind(), SearchedVersions: []string{traefikMiddlewareGVK.Version}}
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	err := r.reconcileAuthMiddleware(context.Background(), server)
+	if !errors.Is(err, ErrInvalidAuth) {
+		t.Fatalf("expected ErrInvalidAuth without the Middleware CRD, got %v", err)
+	}
+}
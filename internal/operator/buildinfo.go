@@ -0,0 +1,53 @@
+package operator
+
+import (
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// AnnotationOperatorVersion on a Deployment, Service or Ingress records the version of the
+// operator that last applied it.
+const AnnotationOperatorVersion = "mcpruntime.org/operator-version"
+
+// BuildInfo identifies the operator binary. main sets it from the values the build stamps
+// in with -ldflags "-X main.version=...".
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// buildInfo is the running operator's build; unstamped builds report "dev".
+var buildInfo = BuildInfo{Version: "dev", Commit: "none", Date: "unknown"}
+
+// buildInfoGauge is always 1, with the build in its labels, as Prometheus build_info
+// metrics are.
+var buildInfoGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "mcpruntime_operator_build_info",
+		Help: "Build of the running operator (always 1): version, commit, build date and Go version.",
+	},
+	[]string{"version", "commit", "date", "go_version"},
+)
+
+// SetBuildInfo records the operator's build for the build_info metric and the version
+// annotation of reconciled resources. Empty fields keep their defaults.
+func SetBuildInfo(info BuildInfo) {
+	if info.Version != "" {
+		buildInfo.Version = info.Version
+	}
+	if info.Commit != "" {
+		buildInfo.Commit = info.Commit
+	}
+	if info.Date != "" {
+		buildInfo.Date = info.Date
+	}
+	buildInfoGauge.Reset()
+	buildInfoGauge.WithLabelValues(buildInfo.Version, buildInfo.Commit, buildInfo.Date, runtime.Version()).Set(1)
+}
+
+// OperatorVersion returns the version of the running operator.
+func OperatorVersion() string {
+	return buildInfo.Version
+}
@@ -0,0 +1,248 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// NGINX canary annotations. A canary Ingress shares host and path with the
+// stable Ingress and receives the configured share of its traffic. Traefik
+// honors them for nginx-class Ingresses through its NGINX Ingress provider.
+const (
+	nginxCanaryAnnotation              = "nginx.ingress.kubernetes.io/canary"
+	nginxCanaryWeightAnnotation        = "nginx.ingress.kubernetes.io/canary-weight"
+	nginxCanaryByHeaderAnnotation      = "nginx.ingress.kubernetes.io/canary-by-header"
+	nginxCanaryByHeaderValueAnnotation = "nginx.ingress.kubernetes.io/canary-by-header-value"
+)
+
+// canaryEnabled reports whether mcpServer's Ingress is rendered as a canary.
+func canaryEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.Canary != nil && mcpServer.Spec.Canary.Enabled
+}
+
+// canaryAnnotations returns the Ingress annotations for an enabled canary.
+func canaryAnnotations(canary *mcpv1alpha1.CanarySpec) map[string]string {
+	annotations := map[string]string{
+		nginxCanaryAnnotation:       "true",
+		nginxCanaryWeightAnnotation: strconv.Itoa(int(canary.Weight)),
+	}
+	if match := canary.HeaderMatch; match != nil && match.Name != "" {
+		annotations[nginxCanaryByHeaderAnnotation] = match.Name
+		if match.Value != "" {
+			annotations[nginxCanaryByHeaderValueAnnotation] = match.Value
+		}
+	}
+	return annotations
+}
+
+// Traefik has no canary annotations for Ingresses. A traefik-class canary gets a weighted
+// TraefikService splitting traffic between the stable and canary Services, and an
+// IngressRoute sending the shared host and path to it. As with Middlewares, the operator
+// handles both as unstructured objects.
+var (
+	traefikServiceGVK      = schema.GroupVersionKind{Group: "traefik.io", Version: "v1alpha1", Kind: "TraefikService"}
+	traefikIngressRouteGVK = schema.GroupVersionKind{Group: "traefik.io", Version: "v1alpha1", Kind: "IngressRoute"}
+)
+
+// annotationTraefikCanary marks the Ingress of a traefik-class canary, so the canary route
+// is only looked up for cleanup on servers that had one.
+const annotationTraefikCanary = "mcpruntime.org/traefik-canary"
+
+// traefikCanaryPriority ranks the canary routes above the Ingress routers of the stable and
+// canary servers, whose priority is the length of their rule.
+const traefikCanaryPriority = 100000
+
+// canaryClassSupported reports whether the ingress class can split traffic for a canary.
+func canaryClassSupported(ingressClass string) bool {
+	return ingressClass == "nginx" || ingressClass == "traefik"
+}
+
+// traefikCanaryEnabled reports whether mcpServer's canary is routed by a Traefik IngressRoute.
+func (r *MCPServerReconciler) traefikCanaryEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return canaryEnabled(mcpServer) && mcpServer.Spec.IngressClass == "traefik" && r.routesThroughIngress(mcpServer)
+}
+
+// traefikCanaryName is the name of the TraefikService and IngressRoute of a canary.
+func traefikCanaryName(mcpServer *mcpv1alpha1.MCPServer) string {
+	return mcpServer.Name + "-canary"
+}
+
+// canaryStable returns the MCPServer a canary splits traffic with: another server in the
+// namespace with the same ingress host and path that is not a canary itself, or nil.
+func (r *MCPServerReconciler) canaryStable(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*mcpv1alpha1.MCPServer, error) {
+	var servers mcpv1alpha1.MCPServerList
+	if err := r.List(ctx, &servers, client.InNamespace(mcpServer.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range servers.Items {
+		server := &servers.Items[i]
+		if server.Name == mcpServer.Name || canaryEnabled(server) {
+			continue
+		}
+		if effectiveIngressHost(server) == effectiveIngressHost(mcpServer) && effectiveIngressPath(server) == mcpServer.Spec.IngressPath {
+			return server, nil
+		}
+	}
+	return nil, nil
+}
+
+// servicePort returns the port of mcpServer's Service.
+func servicePort(mcpServer *mcpv1alpha1.MCPServer) int64 {
+	if mcpServer.Spec.ServicePort == 0 {
+		return 80
+	}
+	return int64(mcpServer.Spec.ServicePort)
+}
+
+// traefikCanaryServiceSpec renders the weighted TraefikService sending weight percent of the
+// requests to the canary and the rest to the stable server.
+func traefikCanaryServiceSpec(canary, stable *mcpv1alpha1.MCPServer) map[string]any {
+	weight := int64(canary.Spec.Canary.Weight)
+	return map[string]any{
+		"weighted": map[string]any{
+			"services": []any{
+				map[string]any{"name": stable.Name, "port": servicePort(stable), "weight": 100 - weight},
+				map[string]any{"name": canary.Name, "port": servicePort(canary), "weight": weight},
+			},
+		},
+	}
+}
+
+// traefikCanaryRouteSpec renders the IngressRoute of a canary: requests matching the header
+// go straight to the canary, the others to the weighted TraefikService. middlewares are the
+// Ingress middleware references of the canary, kept in order.
+func traefikCanaryRouteSpec(mcpServer *mcpv1alpha1.MCPServer, middlewares []string) map[string]any {
+	match := fmt.Sprintf("Host(`%s`) && PathPrefix(`%s`)", effectiveIngressHost(mcpServer), mcpServer.Spec.IngressPath)
+	refs := make([]any, 0, len(middlewares))
+	for _, m := range middlewares {
+		refs = append(refs, map[string]any{"name": m})
+	}
+	route := func(match string, priority int64, service map[string]any) map[string]any {
+		r := map[string]any{"kind": "Rule", "match": match, "priority": priority, "services": []any{service}}
+		if len(refs) > 0 {
+			r["middlewares"] = refs
+		}
+		return r
+	}
+
+	var routes []any
+	if header := mcpServer.Spec.Canary.HeaderMatch; header != nil && header.Name != "" {
+		value := header.Value
+		if value == "" {
+			value = "always"
+		}
+		headerMatch := fmt.Sprintf("%s && Headers(`%s`, `%s`)", match, header.Name, value)
+		routes = append(routes, route(headerMatch, traefikCanaryPriority+1,
+			map[string]any{"name": mcpServer.Name, "port": servicePort(mcpServer)}))
+	}
+	routes = append(routes, route(match, traefikCanaryPriority,
+		map[string]any{"name": traefikCanaryName(mcpServer), "kind": "TraefikService"}))
+	return map[string]any{"routes": routes}
+}
+
+// reconcileTraefikCanary keeps the TraefikService and IngressRoute of a traefik-class canary
+// in place, and removes them once the canary ends or the class changes.
+func (r *MCPServerReconciler) reconcileTraefikCanary(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	logger := log.FromContext(ctx)
+	name := traefikCanaryName(mcpServer)
+	objects := func() []*unstructured.Unstructured {
+		var objs []*unstructured.Unstructured
+		for _, gvk := range []schema.GroupVersionKind{traefikIngressRouteGVK, traefikServiceGVK} {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			obj.SetName(name)
+			obj.SetNamespace(mcpServer.Namespace)
+			objs = append(objs, obj)
+		}
+		return objs
+	}
+
+	if !r.traefikCanaryEnabled(mcpServer) {
+		// Only look for a canary route to clean up when the Ingress is still marked, so
+		// clusters without Traefik's CRDs never pay for the lookup.
+		var ingress networkingv1.Ingress
+		if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, &ingress); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if ingress.Annotations[annotationTraefikCanary] == "" {
+			return nil
+		}
+		for _, obj := range objects() {
+			if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				return err
+			}
+		}
+		logger.Info("Traefik canary route deleted", "name", name)
+		return nil
+	}
+
+	stable, err := r.canaryStable(ctx, mcpServer)
+	if err != nil {
+		return err
+	}
+	if stable == nil {
+		return fmt.Errorf("no MCPServer in namespace %s serves %s%s for the canary to split traffic with", mcpServer.Namespace, effectiveIngressHost(mcpServer), mcpServer.Spec.IngressPath)
+	}
+
+	var middlewares []string
+	if refs := r.buildIngressAnnotations(mcpServer)[traefikMiddlewaresAnnotation]; refs != "" {
+		middlewares = strings.Split(refs, ",")
+	}
+	specs := []map[string]any{traefikCanaryRouteSpec(mcpServer, middlewares), traefikCanaryServiceSpec(mcpServer, stable)}
+	for i, obj := range objects() {
+		spec := specs[i]
+		op, err := ctrl.CreateOrUpdate(ctx, r.Client, obj, func() error {
+			obj.SetLabels(map[string]string{LabelApp: mcpServer.Name, LabelManagedBy: LabelManagedByValue})
+			if err := unstructured.SetNestedMap(obj.Object, spec, "spec"); err != nil {
+				return err
+			}
+			return ctrl.SetControllerReference(mcpServer, obj, r.Scheme)
+		})
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("%w: spec.canary with ingressClass traefik needs the Traefik IngressRoute and TraefikService CRDs (traefik.io/v1alpha1) and the kubernetescrd provider", ErrCanaryUnsupported)
+		}
+		if err != nil {
+			return err
+		}
+		if op != controllerutil.OperationResultNone {
+			logger.Info("Traefik canary route reconciled", "operation", op, "kind", obj.GetKind(), "name", name)
+		}
+	}
+	return nil
+}
+
+// validateCanary rejects canaries on ingress classes without canary support.
+func (r *MCPServerReconciler) validateCanary(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	if !canaryEnabled(mcpServer) {
+		return nil
+	}
+	if canaryClassSupported(mcpServer.Spec.IngressClass) {
+		return nil
+	}
+	contextMap := map[string]any{
+		"mcpServer":    mcpServer.Name,
+		"namespace":    mcpServer.Namespace,
+		"ingressClass": mcpServer.Spec.IngressClass,
+	}
+	message := fmt.Sprintf("spec.canary requires ingressClass nginx or traefik, got %q", mcpServer.Spec.IngressClass)
+	err := wrapOperatorError(fmt.Errorf("%w: %s", ErrCanaryUnsupported, message), "Unsupported canary ingress class", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Unsupported canary ingress class")
+	return err
+}
@@ -0,0 +1,192 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestCanaryAnnotations(t *testing.T) {
+	t.Run("weight only", func(t *testing.T) {
+		got := canaryAnnotations(&mcpv1alpha1.CanarySpec{Enabled: true, Weight: 20})
+		assertEqual(t, "canary", got[nginxCanaryAnnotation], "true")
+		assertEqual(t, "weight", got[nginxCanaryWeightAnnotation], "20")
+		if _, ok := got[nginxCanaryByHeaderAnnotation]; ok {
+			t.Fatal("expected no header annotation without headerMatch")
+		}
+	})
+
+	t.Run("header match", func(t *testing.T) {
+		got := canaryAnnotations(&mcpv1alpha1.CanarySpec{
+			Enabled:     true,
+			HeaderMatch: &mcpv1alpha1.CanaryHeaderMatch{Name: "X-Canary", Value: "v2"},
+		})
+		assertEqual(t, "weight", got[nginxCanaryWeightAnnotation], "0")
+		assertEqual(t, "header", got[nginxCanaryByHeaderAnnotation], "X-Canary")
+		assertEqual(t, "header value", got[nginxCanaryByHeaderValueAnnotation], "v2")
+	})
+}
+
+func TestReconcileCanaryIngress(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	newServer := func(ingressClass string) *mcpv1alpha1.MCPServer {
+		replicas := int32(1)
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-v2", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "registry.local/app",
+				ImageTag:     "v2",
+				Port:         8088,
+				ServicePort:  80,
+				Replicas:     &replicas,
+				IngressHost:  "example.com",
+				IngressPath:  "/app/mcp",
+				IngressClass: ingressClass,
+				Canary:       &mcpv1alpha1.CanarySpec{Enabled: true, Weight: 10},
+			},
+		}
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "app-v2", Namespace: "default"}}
+
+	t.Run("renders nginx canary annotations", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer("nginx")).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ingress := &networkingv1.Ingress{}
+		if err := client.Get(context.Background(), request.NamespacedName, ingress); err != nil {
+			t.Fatalf("expected Ingress: %v", err)
+		}
+		assertEqual(t, "canary", ingress.Annotations[nginxCanaryAnnotation], "true")
+		assertEqual(t, "weight", ingress.Annotations[nginxCanaryWeightAnnotation], "10")
+		assertEqual(t, "path", ingress.Spec.Rules[0].HTTP.Paths[0].Path, "/app/mcp")
+	})
+
+	t.Run("rejects ingress classes without canary support", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer("haproxy")).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+		if _, err := r.Reconcile(context.Background(), request); !errors.Is(err, ErrCanaryUnsupported) {
+			t.Fatalf("expected ErrCanaryUnsupported, got %v", err)
+		}
+		updated := &mcpv1alpha1.MCPServer{}
+		if err := client.Get(context.Background(), request.NamespacedName, updated); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		assertEqual(t, "phase", updated.Status.Phase, "Error")
+	})
+}
+
+func TestReconcileTraefikCanary(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	newServer := func(name string, canary *mcpv1alpha1.CanarySpec) *mcpv1alpha1.MCPServer {
+		replicas := int32(1)
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "registry.local/app",
+				ImageTag:     "v2",
+				Replicas:     &replicas,
+				Port:         8088,
+				ServicePort:  80,
+				IngressHost:  "example.com",
+				IngressPath:  "/app/mcp",
+				IngressClass: "traefik",
+				Canary:       canary,
+			},
+		}
+	}
+	getRoute := func(t *testing.T, c client.Client, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+		t.Helper()
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := c.Get(context.Background(), types.NamespacedName{Name: "app-v2-canary", Namespace: "default"}, obj)
+		return obj, err
+	}
+
+	t.Run("splits traffic with the stable server", func(t *testing.T) {
+		canary := newServer("app-v2", &mcpv1alpha1.CanarySpec{
+			Enabled:     true,
+			Weight:      10,
+			HeaderMatch: &mcpv1alpha1.CanaryHeaderMatch{Name: "X-Canary"},
+		})
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer("app", nil), canary).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme}
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "app-v2", Namespace: "default"}}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		service, err := getRoute(t, c, traefikServiceGVK)
+		if err != nil {
+			t.Fatalf("expected TraefikService: %v", err)
+		}
+		services, _, _ := unstructured.NestedSlice(service.Object, "spec", "weighted", "services")
+		assertEqual(t, "services", len(services), 2)
+		assertEqual(t, "stable", services[0].(map[string]any)["name"], "app")
+		assertEqual[any](t, "stable weight", services[0].(map[string]any)["weight"], int64(90))
+		assertEqual(t, "canary", services[1].(map[string]any)["name"], "app-v2")
+		assertEqual[any](t, "canary weight", services[1].(map[string]any)["weight"], int64(10))
+
+		route, err := getRoute(t, c, traefikIngressRouteGVK)
+		if err != nil {
+			t.Fatalf("expected IngressRoute: %v", err)
+		}
+		routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "routes")
+		assertEqual(t, "routes", len(routes), 2)
+		assertEqual(t, "header route", routes[0].(map[string]any)["match"], "Host(`example.com`) && PathPrefix(`/app/mcp`) && Headers(`X-Canary`, `always`)")
+		assertEqual(t, "weighted route", routes[1].(map[string]any)["match"], "Host(`example.com`) && PathPrefix(`/app/mcp`)")
+
+		ingress := &networkingv1.Ingress{}
+		if err := c.Get(context.Background(), request.NamespacedName, ingress); err != nil {
+			t.Fatalf("expected Ingress: %v", err)
+		}
+		assertEqual(t, "marker", ingress.Annotations[annotationTraefikCanary], "app-v2-canary")
+		if _, ok := ingress.Annotations[nginxCanaryAnnotation]; ok {
+			t.Fatal("expected no nginx canary annotations on a traefik Ingress")
+		}
+
+		// Ending the canary removes its route.
+		updated := &mcpv1alpha1.MCPServer{}
+		if err := c.Get(context.Background(), request.NamespacedName, updated); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		updated.Spec.Canary.Enabled = false
+		if err := r.reconcileTraefikCanary(context.Background(), updated); err != nil {
+			t.Fatalf("reconcileTraefikCanary: %v", err)
+		}
+		if _, err := getRoute(t, c, traefikIngressRouteGVK); !apierrors.IsNotFound(err) {
+			t.Fatalf("expected IngressRoute to be deleted, got %v", err)
+		}
+		if _, err := getRoute(t, c, traefikServiceGVK); !apierrors.IsNotFound(err) {
+			t.Fatalf("expected TraefikService to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("waits for a stable server", func(t *testing.T) {
+		canary := newServer("app-v2", &mcpv1alpha1.CanarySpec{Enabled: true, Weight: 10})
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(canary).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+		err := r.reconcileTraefikCanary(context.Background(), canary)
+		if err == nil || errors.Is(err, ErrCanaryUnsupported) {
+			t.Fatalf("expected a retried error without a stable server, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ChaosInjector makes a share of API calls fail the way a busy API server does, to check
+// that reconciles converge anyway. Reads fail with throttling; writes fail with a conflict,
+// with throttling, or with a timeout after the write went through, which leaves the
+// reconciler unsure whether it took effect. Only chaos builds expose it (see ChaosBuild).
+type ChaosInjector struct {
+	rate     float64
+	mu       sync.Mutex
+	rng      *rand.Rand
+	injected atomic.Int64
+}
+
+// NewChaosInjector fails calls with probability rate (0 to 1); seed makes runs repeatable.
+func NewChaosInjector(rate float64, seed int64) *ChaosInjector {
+	return &ChaosInjector{rate: rate, rng: rand.New(rand.NewSource(seed))} // #nosec G404 -- fault injection, not security.
+}
+
+// SetErrorRate changes the share of calls that fail; 0 stops injecting.
+func (i *ChaosInjector) SetErrorRate(rate float64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rate = rate
+}
+
+// Injected returns how many errors were injected so far.
+func (i *ChaosInjector) Injected() int64 {
+	return i.injected.Load()
+}
+
+// Client wraps c so its calls fail at the injector's rate.
+func (i *ChaosInjector) Client(c client.Client) client.Client {
+	return &chaosClient{Client: c, chaos: i}
+}
+
+// Reader wraps r so its calls fail at the injector's rate.
+func (i *ChaosInjector) Reader(r client.Reader) client.Reader {
+	return &chaosReader{reader: r, chaos: i}
+}
+
+// chaosFault is what happens to one intercepted call.
+type chaosFault int
+
+const (
+	faultNone chaosFault = iota
+	faultConflict
+	faultThrottle
+	faultTimeoutAfterWrite
+)
+
+func (i *ChaosInjector) roll(write bool) chaosFault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.rng.Float64() >= i.rate {
+		return faultNone
+	}
+	i.injected.Add(1)
+	if !write {
+		return faultThrottle
+	}
+	return []chaosFault{faultConflict, faultThrottle, faultTimeoutAfterWrite}[i.rng.Intn(3)]
+}
+
+func (i *ChaosInjector) read(call func() error) error {
+	if i.roll(false) == faultThrottle {
+		return apierrors.NewTooManyRequests("chaos: injected throttling", 1)
+	}
+	return call()
+}
+
+func (i *ChaosInjector) write(verb string, obj client.Object, call func() error) error {
+	resource := schema.GroupResource{Resource: obj.GetObjectKind().GroupVersionKind().Kind}
+	switch i.roll(true) {
+	case faultConflict:
+		return apierrors.NewConflict(resource, obj.GetName(), errChaosConflict)
+	case faultThrottle:
+		return apierrors.NewTooManyRequests("chaos: injected throttling", 1)
+	case faultTimeoutAfterWrite:
+		if err := call(); err != nil {
+			return err
+		}
+		return apierrors.NewServerTimeout(resource, verb, 1)
+	}
+	return call()
+}
+
+var errChaosConflict = errors.New("chaos: injected conflict")
+
+type chaosClient struct {
+	client.Client
+	chaos *ChaosInjector
+}
+
+func (c *chaosClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.chaos.read(func() error { return c.Client.Get(ctx, key, obj, opts...) })
+}
+
+func (c *chaosClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.chaos.read(func() error { return c.Client.List(ctx, list, opts...) })
+}
+
+func (c *chaosClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.chaos.write("create", obj, func() error { return c.Client.Create(ctx, obj, opts...) })
+}
+
+func (c *chaosClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.chaos.write("update", obj, func() error { return c.Client.Update(ctx, obj, opts...) })
+}
+
+func (c *chaosClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.chaos.write("patch", obj, func() error { return c.Client.Patch(ctx, obj, patch, opts...) })
+}
+
+func (c *chaosClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.chaos.write("delete", obj, func() error { return c.Client.Delete(ctx, obj, opts...) })
+}
+
+func (c *chaosClient) Status() client.SubResourceWriter {
+	return &chaosSubResourceWriter{SubResourceWriter: c.Client.Status(), chaos: c.chaos}
+}
+
+type chaosSubResourceWriter struct {
+	client.SubResourceWriter
+	chaos *ChaosInjector
+}
+
+func (w *chaosSubResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return w.chaos.write("update", obj, func() error { return w.SubResourceWriter.Update(ctx, obj, opts...) })
+}
+
+func (w *chaosSubResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return w.chaos.write("patch", obj, func() error { return w.SubResourceWriter.Patch(ctx, obj, patch, opts...) })
+}
+
+type chaosReader struct {
+	reader client.Reader
+	chaos  *ChaosInjector
+}
+
+func (r *chaosReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.chaos.read(func() error { return r.reader.Get(ctx, key, obj, opts...) })
+}
+
+func (r *chaosReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.chaos.read(func() error { return r.reader.List(ctx, list, opts...) })
+}
@@ -0,0 +1,7 @@
+//go:build chaos
+
+package operator
+
+// ChaosBuild reports whether this binary was built with the chaos tag, which enables the
+// operator's --chaos-error-rate flag.
+const ChaosBuild = true
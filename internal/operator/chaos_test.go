@@ -0,0 +1,207 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestChaosInjectorFaults(t *testing.T) {
+	scheme := newServerTestScheme()
+	ctx := context.Background()
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+
+	t.Run("rate 0 passes every call through", func(t *testing.T) {
+		injector := NewChaosInjector(0, 1)
+		c := injector.Client(fake.NewClientBuilder().WithScheme(scheme).Build())
+		for i := 0; i < 50; i++ {
+			if err := c.Get(ctx, types.NamespacedName{Name: "missing", Namespace: "default"}, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+				t.Fatalf("expected the real NotFound, got %v", err)
+			}
+		}
+		assertEqual(t, "injected", injector.Injected(), int64(0))
+	})
+
+	t.Run("rate 1 fails every call", func(t *testing.T) {
+		injector := NewChaosInjector(1, 1)
+		inner := fake.NewClientBuilder().WithScheme(scheme).Build()
+		c := injector.Client(inner)
+		if err := c.List(ctx, &corev1.ConfigMapList{}); !apierrors.IsTooManyRequests(err) {
+			t.Fatalf("reads should be throttled, got %v", err)
+		}
+		if err := injector.Reader(inner).Get(ctx, types.NamespacedName{Name: "x", Namespace: "default"}, &corev1.ConfigMap{}); !apierrors.IsTooManyRequests(err) {
+			t.Fatalf("API reader reads should be throttled, got %v", err)
+		}
+
+		seen := map[string]bool{}
+		for i := 0; i < 30; i++ {
+			cm := newConfigMap("cm")
+			err := c.Create(ctx, cm)
+			switch {
+			case apierrors.IsConflict(err):
+				seen["conflict"] = true
+			case apierrors.IsTooManyRequests(err):
+				seen["throttle"] = true
+			case apierrors.IsServerTimeout(err):
+				seen["timeout"] = true
+				// The write went through before the timeout was reported.
+				if err := inner.Get(ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{}); err != nil {
+					t.Fatalf("expected the timed-out create to have happened: %v", err)
+				}
+				_ = inner.Delete(ctx, cm)
+			case apierrors.IsAlreadyExists(err):
+				t.Fatalf("unexpected leftover object: %v", err)
+			default:
+				t.Fatalf("expected an injected error, got %v", err)
+			}
+		}
+		for _, fault := range []string{"conflict", "throttle", "timeout"} {
+			if !seen[fault] {
+				t.Errorf("fault %s never injected", fault)
+			}
+		}
+		if err := c.Status().Update(ctx, newConfigMap("cm")); err == nil {
+			t.Error("status writes should fail too")
+		}
+	})
+}
+
+// reconcileUntilStable runs Reconcile until three passes in a row succeed, failing the
+// test on any error or when that takes more than max passes.
+func reconcileUntilStable(t *testing.T, r *MCPServerReconciler, key types.NamespacedName, max int) {
+	t.Helper()
+	clean := 0
+	for i := 0; i < max && clean < 3; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("reconcile of %s failed without injected errors: %v", key, err)
+		}
+		clean++
+	}
+	if clean < 3 {
+		t.Fatalf("reconcile of %s did not settle in %d passes", key, max)
+	}
+}
+
+// reconcileUnderChaos runs passes passes of Reconcile while injector fails calls, the
+// way the controller retries, and returns how many passes succeeded.
+func reconcileUnderChaos(r *MCPServerReconciler, key types.NamespacedName, passes int) int {
+	succeeded := 0
+	for i := 0; i < passes; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err == nil {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+// convergedState is what a reconcile loop leaves behind for a server.
+type convergedState struct {
+	deployment appsv1.DeploymentSpec
+	service    []corev1.ServicePort
+	ingress    networkingv1.IngressSpec
+	annotation map[string]string
+	phase      string
+	observed   int64
+	generation int64
+}
+
+func readConvergedState(t *testing.T, c client.Client, key types.NamespacedName) convergedState {
+	t.Helper()
+	ctx := context.Background()
+	var (
+		server     mcpv1alpha1.MCPServer
+		deployment appsv1.Deployment
+		service    corev1.Service
+		ingress    networkingv1.Ingress
+	)
+	for _, obj := range []client.Object{&server, &deployment, &service, &ingress} {
+		if err := c.Get(ctx, key, obj); err != nil {
+			t.Fatalf("get %T: %v", obj, err)
+		}
+	}
+	return convergedState{
+		deployment: deployment.Spec,
+		service:    service.Spec.Ports,
+		ingress:    ingress.Spec,
+		annotation: ingress.Annotations,
+		phase:      server.Status.Phase,
+		observed:   server.Status.ObservedGeneration,
+		generation: server.Generation,
+	}
+}
+
+func TestReconcileConvergesUnderChaos(t *testing.T) {
+	scheme := newServerTestScheme()
+	key := types.NamespacedName{Name: "chaos-server", Namespace: "default"}
+	newServer := func() *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "registry.local/chaos-server",
+				IngressHost:  "example.com",
+				IngressClass: "nginx",
+				EnvVars:      []mcpv1alpha1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+			},
+		}
+	}
+	newReconciler := func() (client.Client, *MCPServerReconciler) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer()).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		return c, &MCPServerReconciler{Client: c, Scheme: scheme}
+	}
+
+	reference, r := newReconciler()
+	reconcileUntilStable(t, r, key, 10)
+	want := readConvergedState(t, reference, key)
+
+	// Whatever state the errors leave behind, the next error-free reconciles must
+	// repair it without getting stuck.
+	for _, seed := range []int64{1, 7, 42} {
+		injector := NewChaosInjector(0.1, seed)
+		c, r := newReconciler()
+		r.Client = injector.Client(c)
+		r.APIReader = injector.Reader(c)
+
+		if succeeded := reconcileUnderChaos(r, key, 100); succeeded == 0 {
+			t.Fatalf("seed %d: no reconcile succeeded under chaos", seed)
+		}
+		if injector.Injected() == 0 {
+			t.Fatalf("seed %d: no errors injected", seed)
+		}
+		injector.SetErrorRate(0)
+		reconcileUntilStable(t, r, key, 10)
+		if got := readConvergedState(t, c, key); !reflect.DeepEqual(got, want) {
+			t.Fatalf("seed %d: state after chaos differs from the reference:\ngot  %+v\nwant %+v", seed, got, want)
+		}
+
+		// A spec change made while errors keep coming is rolled out as well.
+		var server mcpv1alpha1.MCPServer
+		if err := c.Get(context.Background(), key, &server); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		server.Spec.ImageTag = "v2"
+		if err := c.Update(context.Background(), &server); err != nil {
+			t.Fatalf("update MCPServer: %v", err)
+		}
+		injector.SetErrorRate(0.1)
+		reconcileUnderChaos(r, key, 100)
+		injector.SetErrorRate(0)
+		reconcileUntilStable(t, r, key, 10)
+		got := readConvergedState(t, c, key)
+		assertEqual(t, "image", got.deployment.Template.Spec.Containers[0].Image, "registry.local/chaos-server:v2")
+		assertEqual(t, "observedGeneration", got.observed, got.generation)
+	}
+}
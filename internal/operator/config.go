@@ -25,20 +25,20 @@ type OperatorConfig struct {
 	// ProvisionedRegistrySecretName is the name of the secret for registry credentials.
 	ProvisionedRegistrySecretName string
 
-	// RequeueDelaySeconds is the delay in seconds before requeueing when resources aren't ready.
-	RequeueDelaySeconds int
+	// ProvisionedRegistryPathPrefix is the project or org images live under in the registry.
+	ProvisionedRegistryPathPrefix string
 }
 
 // LoadOperatorConfig loads operator configuration from environment variables.
 func LoadOperatorConfig() *OperatorConfig {
 	cfg := &OperatorConfig{
 		DefaultIngressHost:            getEnvOrDefault("DEFAULT_INGRESS_HOST", ""),
-		DefaultIngressClass:           getEnvOrDefault("DEFAULT_INGRESS_CLASS", DefaultIngressClass),
+		DefaultIngressClass:           getEnvOrDefault("MCP_DEFAULT_INGRESS_CLASS", DefaultIngressClass),
 		ProvisionedRegistryURL:        os.Getenv("PROVISIONED_REGISTRY_URL"),
 		ProvisionedRegistryUsername:   os.Getenv("PROVISIONED_REGISTRY_USERNAME"),
 		ProvisionedRegistryPassword:   os.Getenv("PROVISIONED_REGISTRY_PASSWORD"),
 		ProvisionedRegistrySecretName: getEnvOrDefault("PROVISIONED_REGISTRY_SECRET_NAME", DefaultRegistrySecretName),
-		RequeueDelaySeconds:           getEnvIntOrDefault("REQUEUE_DELAY_SECONDS", RequeueDelayNotReady),
+		ProvisionedRegistryPathPrefix: os.Getenv("PROVISIONED_REGISTRY_PATH_PREFIX"),
 	}
 	return cfg
 }
@@ -58,6 +58,7 @@ func (c *OperatorConfig) ToRegistryConfig() *RegistryConfig {
 		Username:   c.ProvisionedRegistryUsername,
 		Password:   c.ProvisionedRegistryPassword,
 		SecretName: c.ProvisionedRegistrySecretName,
+		PathPrefix: c.ProvisionedRegistryPathPrefix,
 	}
 }
 
@@ -1,6 +1,8 @@
 // Package operator provides the Kubernetes operator for MCPServer resources.
 package operator
 
+import "time"
+
 // Resource defaults for MCPServer deployments.
 const (
 	// DefaultRequestCPU is the default CPU request for containers.
@@ -31,6 +33,35 @@ const (
 	LabelManagedBy = "app.kubernetes.io/managed-by"
 	// LabelManagedByValue is the value for the managed-by label.
 	LabelManagedByValue = "mcp-runtime"
+	// LabelArch marks the pods of a spec.imageVariants Deployment with their architecture.
+	LabelArch = "mcpruntime.org/arch"
+)
+
+// Namespace quota resources.
+const (
+	// QuotaName is the name of the ResourceQuota managed in MCP server namespaces.
+	QuotaName = "mcp-servers-quota"
+	// LimitRangeName is the name of the LimitRange managed in MCP server namespaces.
+	LimitRangeName = "mcp-servers-limits"
+)
+
+// Namespace quota defaults, shared with "cluster init --with-quotas" and
+// "team create" so both paths size namespaces the same way.
+const (
+	// DefaultQuotaRequestsCPU is the total CPU requests allowed in the namespace.
+	DefaultQuotaRequestsCPU = "8"
+	// DefaultQuotaRequestsMemory is the total memory requests allowed in the namespace.
+	DefaultQuotaRequestsMemory = "16Gi"
+	// DefaultQuotaLimitsCPU is the total CPU limits allowed in the namespace.
+	DefaultQuotaLimitsCPU = "16"
+	// DefaultQuotaLimitsMemory is the total memory limits allowed in the namespace.
+	DefaultQuotaLimitsMemory = "32Gi"
+	// DefaultQuotaPods is the maximum number of pods in the namespace.
+	DefaultQuotaPods = 50
+	// DefaultLimitRangeMaxCPU is the largest CPU limit a single container may set.
+	DefaultLimitRangeMaxCPU = "2"
+	// DefaultLimitRangeMaxMemory is the largest memory limit a single container may set.
+	DefaultLimitRangeMaxMemory = "2Gi"
 )
 
 // Secret names.
@@ -46,10 +77,55 @@ const (
 	DefaultIngressClass = "traefik"
 	// DefaultIngressPathType is the default path type for ingress rules.
 	DefaultIngressPathType = "Prefix"
+	// WildcardDNSSuffix is appended to a LoadBalancer IP to build an
+	// auto-detected ingress host (1.2.3.4 -> 1.2.3.4.nip.io).
+	WildcardDNSSuffix = "nip.io"
 )
 
 // Requeue delays for reconciliation.
 const (
-	// RequeueDelayNotReady is the delay before requeueing when resources are not ready.
-	RequeueDelayNotReady = 10 // seconds
+	// ResyncNotReady is the delay before reconciling a server that is not ready again. Watches
+	// usually bring the next reconcile sooner; this resync covers objects that are not watched,
+	// such as an HTTPRoute whose CRD was installed after the operator started.
+	ResyncNotReady = 5 * time.Minute
+)
+
+// Status conditions and reasons.
+const (
+	// ConditionDegraded is set when the deployment is failing for a concrete
+	// reason such as an image pull error or a crash loop.
+	ConditionDegraded = "Degraded"
+	// ReasonHealthy is the Degraded=False reason when no failure is detected.
+	ReasonHealthy = "Healthy"
+	// ConditionImageVerified records the result of image signature verification.
+	ConditionImageVerified = "ImageVerified"
+	// ReasonSignatureVerified is the ImageVerified=True reason.
+	ReasonSignatureVerified = "SignatureVerified"
+	// ReasonSignatureInvalid is the ImageVerified=False reason when the image
+	// is unsigned or the signature does not match the configured key/identity.
+	ReasonSignatureInvalid = "SignatureInvalid"
+	// ConditionPaused is True while maintenance mode stops changes to the
+	// server's child resources.
+	ConditionPaused = "Paused"
+	// ReasonMaintenanceMode is the Paused=True reason.
+	ReasonMaintenanceMode = "MaintenanceMode"
+	// ReasonReconciling is the Paused=False reason once maintenance mode ends.
+	ReasonReconciling = "Reconciling"
+	// ConditionReady is True when the Deployment, Service and Ingress are all
+	// ready; while False its reason is the current phase.
+	ConditionReady = "Ready"
+	// ReasonResourcesReady is the Ready=True reason.
+	ReasonResourcesReady = "ResourcesReady"
+)
+
+// Revision history.
+const (
+	// MaxRevisionHistory is how many ready images status.history keeps.
+	MaxRevisionHistory = 10
+)
+
+// Tracing.
+const (
+	// TracerName is the OpenTelemetry instrumentation name for operator spans.
+	TracerName = "mcp-runtime/operator"
 )
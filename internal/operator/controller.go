@@ -1,13 +1,16 @@
 /*
 Let me share the flow of the code:
-1. fetch the MCPServer object
-2. apply the defaults if needed
-3. validate the ingress config
-4. reconcile the resources
-5. check the resource readiness
-6. determine the phase
-7. update the status
-8. return the result
+1. fetch the MCPServer object (in maintenance mode, only report status)
+2. apply the defaults (ingress ones in memory only; MCPRuntimeConfig "default" overrides the operator's)
+3. resolve the ingress host (auto-detected if none is configured)
+4. validate the ingress config
+5. verify the image signature (if enabled)
+6. enforce the crash loop policy (if set)
+7. reconcile the resources
+8. check the resource readiness
+9. determine the phase
+10. update the status
+11. return the result
 */
 package operator
 
@@ -16,10 +19,14 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -28,10 +35,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
 )
@@ -42,6 +54,9 @@ type RegistryConfig struct {
 	Username   string
 	Password   string
 	SecretName string
+	// PathPrefix is the project or org images live under, such as "mcp" in
+	// harbor.example.com/mcp/<image>.
+	PathPrefix string
 }
 
 // MCPServerReconciler reconciles a MCPServer object
@@ -52,9 +67,91 @@ type MCPServerReconciler struct {
 	// DefaultIngressHost is the default ingress host if not specified in the CR.
 	DefaultIngressHost string
 
+	// DefaultIngressClass is the ingress class for servers that set none;
+	// empty means the class IngressClasses detects, then DefaultIngressClass (traefik).
+	DefaultIngressClass string
+
+	// IngressClasses, when set, supplies the cluster's default IngressClass.
+	IngressClasses *IngressClassDetector
+
+	// IngressProvider exposes servers that set no spec.ingressProvider; empty picks the
+	// provider named by their ingress class.
+	IngressProvider string
+
+	// Gateway is the Gateway, as namespace/name, that the HTTPRoutes of gateway-api
+	// servers attach to.
+	Gateway string
+
+	// DefaultSafeToEvict is the cluster-autoscaler safe-to-evict policy for servers that
+	// leave spec.safeToEvict unset; nil adds no annotation.
+	DefaultSafeToEvict *bool
+
+	// DefaultResources fill in resource requests and limits a server leaves unset.
+	DefaultResources *mcpv1alpha1.ResourceRequirements
+
+	// NamespaceResources override DefaultResources for servers in the named namespace.
+	NamespaceResources map[string]mcpv1alpha1.ResourceRequirements
+
+	// ProbeDefaults tune the probes a server leaves unset.
+	ProbeDefaults *mcpv1alpha1.ProbesSpec
+
 	// ProvisionedRegistry holds the provisioned registry configuration.
 	// If nil or URL is empty, provisioned registry features are disabled.
 	ProvisionedRegistry *RegistryConfig
+
+	// RegistryPullSecret is attached to server pods that set no imagePullSecrets
+	// when the internal registry requires authentication.
+	RegistryPullSecret string
+
+	// NamespaceQuota, if set, is enforced as a ResourceQuota and LimitRange
+	// in every namespace that contains MCPServer resources.
+	NamespaceQuota *QuotaConfig
+
+	// ImageVerifier, if set, must accept an MCPServer's image signature
+	// before its Deployment is created or updated.
+	ImageVerifier ImageVerifier
+
+	// IngressTLS reports status URLs as https when the ingress controller
+	// terminates TLS for every route.
+	IngressTLS bool
+
+	// IngressDual exposes servers on both the web and websecure Traefik
+	// entrypoints unless they set spec.tlsOnly.
+	IngressDual bool
+
+	// MaintenanceNamespace holds the maintenance ConfigMap that pauses changes
+	// to child resources. Empty disables maintenance mode.
+	MaintenanceNamespace string
+
+	// SyncNamespace holds the Secrets and ConfigMaps that servers copy into their
+	// namespace with spec.syncSecrets. Empty disables syncing.
+	SyncNamespace string
+
+	// APIReader reads synced copies and envFrom sources in server namespaces, which
+	// the cache does not cover, and the servers checked for default ingress path collisions.
+	// Nil falls back to the client.
+	APIReader client.Reader
+
+	// Recorder emits events about the MCPServer, such as a crash loop policy
+	// pausing it. Nil emits none.
+	Recorder record.EventRecorder
+
+	// ImpersonatingClient builds the client that writes the child resources of
+	// servers setting spec.deployAs. Nil rejects spec.deployAs.
+	ImpersonatingClient ImpersonatingClientFunc
+
+	// NamespacedIngressPaths defaults spec.ingressPath to /<namespace>/<name>/mcp
+	// instead of /<name>/mcp, so servers of the same name in different namespaces
+	// can share a host.
+	NamespacedIngressPaths bool
+
+	// DefaultIPFamilies are the Service IP families of servers that do not set
+	// spec.ipFamilies, primary first. Empty leaves the choice to the cluster.
+	DefaultIPFamilies []corev1.IPFamily
+
+	// RequireDeployAs rejects servers that do not set spec.deployAs, so every
+	// server's resources are bounded by a ServiceAccount's RBAC.
+	RequireDeployAs bool
 }
 
 // Use constants from constants.go
@@ -67,60 +164,142 @@ const (
 
 //+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpservers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpservers/status,verbs=get;update;patch
+// Owner references set BlockOwnerDeletion, which needs update on mcpservers/finalizers; a
+// spec.deployAs ServiceAccount needs the same rule (see the README).
 //+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpservers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mcpruntime.org,resources=mcpruntimeconfigs,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=resourcequotas;limitranges,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=impersonate
+//+kubebuilder:rbac:groups=traefik.io,resources=ingressroutes;middlewares;traefikservices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch;update
+//+kubebuilder:rbac:groups="",resources=events,verbs=get;list;create;patch;update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop
-func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := startSpan(ctx, "Reconcile",
+		attribute.String("mcpserver.name", req.Name),
+		attribute.String("mcpserver.namespace", req.Namespace),
+	)
+	defer func() { endSpan(span, err) }()
+
 	logger := log.FromContext(ctx)
 
 	mcpServer, found, err := r.fetchMCPServer(ctx, req)
 	if err != nil {
-		return ctrl.Result{Requeue: false}, err
+		return requeueResult(err)
 	}
 	if !found {
+		forgetServer(req.Namespace, req.Name)
 		return ctrl.Result{Requeue: false}, nil
 	}
 
 	logger.Info("Reconciling MCPServer", "name", mcpServer.Name, "namespace", mcpServer.Namespace)
 
+	if paused, reason := r.maintenanceMode(ctx, logger); paused {
+		return r.reconcilePaused(ctx, mcpServer, reason, logger)
+	}
+	clearPausedCondition(mcpServer)
+
+	return r.withRuntimeConfig(ctx, logger).reconcileServer(ctx, mcpServer, logger)
+}
+
+// reconcileServer applies defaults, reconciles the child resources and updates the status.
+func (r *MCPServerReconciler) reconcileServer(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) (ctrl.Result, error) {
 	// Set defaults and update spec only if changed
 	requeue, err := r.applyDefaultsIfNeeded(ctx, mcpServer, logger)
 	if err != nil {
-		return ctrl.Result{Requeue: false}, err
+		return requeueResult(err)
 	}
 	if requeue {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	if jobMode(mcpServer) {
+		return r.reconcileJobServer(ctx, mcpServer, logger)
+	}
+
+	if err := r.applyIngressDefaults(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+	mcpServer.Status.IngressClass = mcpServer.Spec.IngressClass
+	mcpServer.Status.IngressPath = mcpServer.Spec.IngressPath
+	r.resolveIngressHost(ctx, mcpServer, logger)
+
 	if err := r.validateIngressConfig(ctx, mcpServer, logger); err != nil {
-		return ctrl.Result{Requeue: false}, err
+		return requeueResult(err)
+	}
+	if err := r.validateImageVariants(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+	if err := r.validateIPFamilies(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+	if err := r.validateEnvTemplates(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+
+	if err := r.verifyImageSignature(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+
+	if err := r.enforceCrashLoopPolicy(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
 	}
 
 	if err := r.reconcileResources(ctx, mcpServer, logger); err != nil {
-		return ctrl.Result{Requeue: false}, err
+		return requeueResult(err)
 	}
 
 	deploymentReady, serviceReady, ingressReady, err := r.checkResourceReadiness(ctx, mcpServer)
 	if err != nil {
-		return ctrl.Result{Requeue: false}, err
+		return requeueResult(err)
 	}
 
 	phase, allReady := determinePhase(deploymentReady, serviceReady, ingressReady)
-	r.updateStatus(ctx, mcpServer, phase, "All resources reconciled", deploymentReady, serviceReady, ingressReady)
+	message := "All resources reconciled"
+
+	var failure *deploymentFailure
+	if !deploymentReady {
+		failure, err = r.diagnoseDeployment(ctx, mcpServer)
+		if err != nil {
+			logger.Error(err, "Failed to diagnose Deployment", "name", mcpServer.Name)
+		}
+	}
+	setDegradedCondition(mcpServer, failure)
+	if failure != nil {
+		message = fmt.Sprintf("Deployment degraded (%s): %s", failure.Reason, failure.Message)
+	}
+	if mcpServer.Spec.Replicas != nil && *mcpServer.Spec.Replicas == 0 {
+		phase = "Suspended"
+		message = "Scaled to zero replicas"
+		if paused, ok := crashLoopPausedMessage(mcpServer); ok {
+			message = paused
+		}
+	}
+	recordRolledOutRevision(mcpServer, phase)
+	if err := r.recordVariants(ctx, mcpServer); err != nil {
+		logger.Error(err, "Failed to record image variants", "name", mcpServer.Name)
+	}
+	r.updateStatus(ctx, mcpServer, phase, message, deploymentReady, serviceReady, ingressReady)
 
 	logger.Info("Successfully reconciled MCPServer", "name", mcpServer.Name, "phase", phase)
 
-	// If not all resources are ready, requeue with a short delay to check again
+	// Readiness changes of a server's pods, Deployments and Ingress trigger the next reconcile
+	// through the watches in SetupWithManager; the long resync covers what is not watched.
 	if !allReady {
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: ResyncNotReady}, nil
 	}
-	return ctrl.Result{Requeue: false}, nil
+	return ctrl.Result{}, nil
 }
 
 func (r *MCPServerReconciler) fetchMCPServer(ctx context.Context, req ctrl.Request) (*mcpv1alpha1.MCPServer, bool, error) {
@@ -134,9 +313,12 @@ func (r *MCPServerReconciler) fetchMCPServer(ctx context.Context, req ctrl.Reque
 	return &mcpServer, true, nil
 }
 
+// applyDefaultsIfNeeded saves the server's own defaults (image tag, replicas and ports) in its
+// spec and requeues when that changed it. Platform defaults are not saved; see
+// applyIngressDefaults.
 func (r *MCPServerReconciler) applyDefaultsIfNeeded(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) (bool, error) {
 	original := mcpServer.DeepCopy()
-	r.setDefaults(mcpServer)
+	setServerDefaults(mcpServer)
 	if reflect.DeepEqual(original.Spec, mcpServer.Spec) {
 		return false, nil
 	}
@@ -148,16 +330,69 @@ func (r *MCPServerReconciler) applyDefaultsIfNeeded(ctx context.Context, mcpServ
 	return true, nil
 }
 
+// applyIngressDefaults fills in the ingress host, path and class the server leaves unset, in
+// memory only, so a changed MCPRuntimeConfig or operator default reaches every server that
+// relies on it. A default path is recorded in status.ingressPath, which keeps it stable and
+// lets other servers avoid it.
+func (r *MCPServerReconciler) applyIngressDefaults(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	stored := mcpServer.DeepCopy()
+	if mcpServer.Spec.IngressHost == "" && r.DefaultIngressHost != "" {
+		prefix, err := r.ingressHostPrefix(ctx, mcpServer.Namespace)
+		if err != nil {
+			logger.Error(err, "Failed to read namespace for its ingress host prefix", "namespace", mcpServer.Namespace)
+			return err
+		}
+		mcpServer.Spec.IngressHost = prefixIngressHost(prefix, r.DefaultIngressHost)
+	}
+	if normalizeIngressPath(mcpServer.Spec.IngressPath) == "" && mcpServer.Name != "" {
+		// Held until the path is recorded below, so concurrent reconciles see it.
+		ingressPathMu.Lock()
+		defer ingressPathMu.Unlock()
+		ingressPath, err := r.defaultIngressPath(ctx, mcpServer)
+		if err != nil {
+			logger.Error(err, "Failed to list MCPServers for the default ingress path")
+			return err
+		}
+		if ingressPath != mcpServer.Status.IngressPath {
+			// Saved from the stored copy: the status write returns the stored spec.
+			stored.Status.IngressPath = ingressPath
+			if err := r.Status().Update(ctx, stored); err != nil {
+				logger.Error(err, "Failed to record the default ingress path")
+				return err
+			}
+			mcpServer.ResourceVersion = stored.ResourceVersion
+			mcpServer.Status.IngressPath = ingressPath
+		}
+		mcpServer.Spec.IngressPath = ingressPath
+	}
+	r.setIngressDefaults(mcpServer)
+	return nil
+}
+
 func (r *MCPServerReconciler) validateIngressConfig(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
-	if err := r.requireSpecField(ctx, mcpServer, logger, "ingress host", mcpServer.Spec.IngressHost,
-		"ingressHost is required; set spec.ingressHost or MCP_DEFAULT_INGRESS_HOST"); err != nil {
-		return err
+	if r.ingressProvider(mcpServer).Name() != IngressProviderNone {
+		if err := r.requireSpecField(ctx, mcpServer, logger, "ingress host", effectiveIngressHost(mcpServer),
+			"ingressHost is required; set spec.ingressHost or MCP_DEFAULT_INGRESS_HOST, or expose the ingress controller through a LoadBalancer Service"); err != nil {
+			return fmt.Errorf("%w: %w", ErrMissingIngressHost, err)
+		}
 	}
 	if err := r.requireSpecField(ctx, mcpServer, logger, "ingress path", mcpServer.Spec.IngressPath,
 		"ingressPath is required; set spec.ingressPath or ensure metadata.name is set"); err != nil {
+		return fmt.Errorf("%w: %w", ErrMissingIngressPath, err)
+	}
+	if err := r.validateIngressProvider(ctx, mcpServer, logger); err != nil {
 		return err
 	}
-	return nil
+	if err := r.validateIngressPath(ctx, mcpServer, logger); err != nil {
+		return err
+	}
+	if err := r.validateCanary(ctx, mcpServer, logger); err != nil {
+		return err
+	}
+	if err := r.validateMirror(ctx, mcpServer, logger); err != nil {
+		return err
+	}
+	return r.validateAuth(ctx, mcpServer, logger)
 }
 
 func (r *MCPServerReconciler) requireSpecField(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger, field, value, message string) error {
@@ -181,25 +416,74 @@ func (r *MCPServerReconciler) reconcileResources(ctx context.Context, mcpServer
 		"namespace": mcpServer.Namespace,
 	}
 
-	if err := r.reconcileDeployment(ctx, mcpServer); err != nil {
+	if err := traceResource(ctx, "quota", func(ctx context.Context) error { return r.reconcileNamespaceQuota(ctx, mcpServer.Namespace) }); err != nil {
+		contextMap["resource"] = "quota"
+		wrappedErr := wrapOperatorError(err, "Failed to reconcile namespace quota", contextMap)
+		logOperatorError(logger, wrappedErr, "Failed to reconcile namespace quota")
+		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile namespace quota: %v", err), false, false, false)
+		return wrappedErr
+	}
+	if err := traceResource(ctx, "sync", func(ctx context.Context) error { return r.reconcileSyncedSecrets(ctx, mcpServer) }); err != nil {
+		contextMap["resource"] = "sync"
+		wrappedErr := wrapOperatorError(err, "Failed to sync secrets", contextMap)
+		logOperatorError(logger, wrappedErr, "Failed to sync secrets")
+		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to sync secrets: %v", err), false, false, false)
+		return wrappedErr
+	}
+	children, err := r.deployAsReconciler(ctx, mcpServer, logger)
+	if err != nil {
+		return err
+	}
+	if jobMode(mcpServer) {
+		if err := traceResource(ctx, "job", func(ctx context.Context) error { return children.reconcileJob(ctx, mcpServer) }); err != nil {
+			contextMap["resource"] = "job"
+			wrappedErr := wrapOperatorError(err, "Failed to reconcile Job", contextMap)
+			logOperatorError(logger, wrappedErr, "Failed to reconcile Job")
+			children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Job: %v", err), false, false, false)
+			return wrappedErr
+		}
+		return nil
+	}
+	if err := traceResource(ctx, "deployment", func(ctx context.Context) error { return children.reconcileDeployment(ctx, mcpServer) }); err != nil {
 		contextMap["resource"] = "deployment"
 		wrappedErr := wrapOperatorError(err, "Failed to reconcile Deployment", contextMap)
 		logOperatorError(logger, wrappedErr, "Failed to reconcile Deployment")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Deployment: %v", err), false, false, false)
+		children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Deployment: %v", err), false, false, false)
 		return wrappedErr
 	}
-	if err := r.reconcileService(ctx, mcpServer); err != nil {
+	if err := traceResource(ctx, "service", func(ctx context.Context) error { return children.reconcileService(ctx, mcpServer) }); err != nil {
 		contextMap["resource"] = "service"
 		wrappedErr := wrapOperatorError(err, "Failed to reconcile Service", contextMap)
 		logOperatorError(logger, wrappedErr, "Failed to reconcile Service")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Service: %v", err), false, false, false)
+		children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Service: %v", err), false, false, false)
 		return wrappedErr
 	}
-	if err := r.reconcileIngress(ctx, mcpServer); err != nil {
+	if err := traceResource(ctx, "auth", func(ctx context.Context) error { return children.reconcileAuthMiddleware(ctx, mcpServer) }); err != nil {
+		contextMap["resource"] = "auth"
+		wrappedErr := wrapOperatorError(err, "Failed to reconcile auth middleware", contextMap)
+		logOperatorError(logger, wrappedErr, "Failed to reconcile auth middleware")
+		children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile auth middleware: %v", err), false, false, false)
+		return wrappedErr
+	}
+	if err := traceResource(ctx, "strip-prefix", func(ctx context.Context) error { return children.reconcileStripPrefixMiddleware(ctx, mcpServer) }); err != nil {
+		contextMap["resource"] = "strip-prefix"
+		wrappedErr := wrapOperatorError(err, "Failed to reconcile strip-prefix middleware", contextMap)
+		logOperatorError(logger, wrappedErr, "Failed to reconcile strip-prefix middleware")
+		children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile strip-prefix middleware: %v", err), false, false, false)
+		return wrappedErr
+	}
+	if err := traceResource(ctx, "canary", func(ctx context.Context) error { return children.reconcileTraefikCanary(ctx, mcpServer) }); err != nil {
+		contextMap["resource"] = "canary"
+		wrappedErr := wrapOperatorError(err, "Failed to reconcile canary route", contextMap)
+		logOperatorError(logger, wrappedErr, "Failed to reconcile canary route")
+		children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile canary route: %v", err), false, false, false)
+		return wrappedErr
+	}
+	if err := traceResource(ctx, "ingress", func(ctx context.Context) error { return children.reconcileIngress(ctx, mcpServer) }); err != nil {
 		contextMap["resource"] = "ingress"
 		wrappedErr := wrapOperatorError(err, "Failed to reconcile Ingress", contextMap)
 		logOperatorError(logger, wrappedErr, "Failed to reconcile Ingress")
-		r.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Ingress: %v", err), false, false, false)
+		children.updateStatus(ctx, mcpServer, "Error", fmt.Sprintf("Failed to reconcile Ingress: %v", err), false, false, false)
 		return wrappedErr
 	}
 	return nil
@@ -232,7 +516,14 @@ func determinePhase(deploymentReady, serviceReady, ingressReady bool) (string, b
 	return "Pending", false
 }
 
+// setDefaults fills in all defaults of mcpServer in memory.
 func (r *MCPServerReconciler) setDefaults(mcpServer *mcpv1alpha1.MCPServer) {
+	setServerDefaults(mcpServer)
+	r.setIngressDefaults(mcpServer)
+}
+
+// setServerDefaults fills in the defaults saved in the server's spec.
+func setServerDefaults(mcpServer *mcpv1alpha1.MCPServer) {
 	// Only set a default tag if the image doesn't already contain one.
 	if mcpServer.Spec.ImageTag == "" && !strings.Contains(mcpServer.Spec.Image, ":") && !strings.Contains(mcpServer.Spec.Image, "@") {
 		mcpServer.Spec.ImageTag = "latest"
@@ -247,95 +538,150 @@ func (r *MCPServerReconciler) setDefaults(mcpServer *mcpv1alpha1.MCPServer) {
 	if mcpServer.Spec.ServicePort == 0 {
 		mcpServer.Spec.ServicePort = 80
 	}
+}
+
+// setIngressDefaults fills in the platform's ingress defaults, applied when rendering only.
+func (r *MCPServerReconciler) setIngressDefaults(mcpServer *mcpv1alpha1.MCPServer) {
+	mcpServer.Spec.IngressPath = normalizeIngressPath(mcpServer.Spec.IngressPath)
 	if mcpServer.Spec.IngressPath == "" && mcpServer.Name != "" {
-		mcpServer.Spec.IngressPath = "/" + mcpServer.Name + "/mcp"
+		mcpServer.Spec.IngressPath = r.baseIngressPath(mcpServer)
 	}
 	if mcpServer.Spec.IngressHost == "" && r.DefaultIngressHost != "" {
 		mcpServer.Spec.IngressHost = r.DefaultIngressHost
 	}
 	if mcpServer.Spec.IngressClass == "" {
-		mcpServer.Spec.IngressClass = "traefik"
+		mcpServer.Spec.IngressClass = providerIngressClass(mcpServer)
+	}
+	if mcpServer.Spec.IngressClass == "" {
+		mcpServer.Spec.IngressClass = r.DefaultIngressClass
+		if mcpServer.Spec.IngressClass == "" {
+			mcpServer.Spec.IngressClass = r.IngressClasses.Class()
+		}
+		if mcpServer.Spec.IngressClass == "" {
+			mcpServer.Spec.IngressClass = DefaultIngressClass
+		}
 	}
 }
 
 func (r *MCPServerReconciler) reconcileDeployment(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	if len(mcpServer.Spec.ImageVariants) > 0 {
+		return r.reconcileVariantDeployments(ctx, mcpServer)
+	}
+	image, err := r.resolveImage(ctx, mcpServer)
+	if err != nil {
+		return err
+	}
+	if image, err = r.pinnedImage(ctx, image); err != nil {
+		return err
+	}
+	if err := r.applyDeployment(ctx, mcpServer, mcpServer.Name, image, ""); err != nil {
+		return err
+	}
+	return r.deleteStaleDeployments(ctx, mcpServer, map[string]bool{mcpServer.Name: true})
+}
+
+// applyDeployment creates or updates the Deployment name running image. A non-empty arch
+// pins its pods to nodes of that architecture and adds it to the pod selector.
+func (r *MCPServerReconciler) applyDeployment(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, name, image, arch string) error {
 	logger := log.FromContext(ctx)
 
-	image, err := r.resolveImage(ctx, mcpServer)
+	env, err := r.buildEnvVars(mcpServer)
 	if err != nil {
 		return err
 	}
+	podAnnotations := r.podAnnotations(mcpServer)
+	checksum, err := r.envFromChecksum(ctx, mcpServer)
+	if err != nil {
+		return err
+	}
+	if checksum != "" {
+		if podAnnotations == nil {
+			podAnnotations = map[string]string{}
+		}
+		podAnnotations[AnnotationEnvChecksum] = checksum
+	}
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      mcpServer.Name,
+			Name:      name,
 			Namespace: mcpServer.Namespace,
 		},
 	}
 
-	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-		selectorLabels := map[string]string{
-			"app": mcpServer.Name,
-		}
-		templateLabels := map[string]string{
-			"app":                          mcpServer.Name,
-			"app.kubernetes.io/managed-by": "mcp-runtime",
-		}
+	selectorLabels := map[string]string{
+		"app": mcpServer.Name,
+	}
+	templateLabels := map[string]string{
+		"app":                          mcpServer.Name,
+		"app.kubernetes.io/managed-by": "mcp-runtime",
+	}
+	if arch != "" {
+		selectorLabels[LabelArch] = arch
+		templateLabels[LabelArch] = arch
+	}
 
-		deployment.Labels = map[string]string{
-			"app":                          mcpServer.Name,
-			"app.kubernetes.io/managed-by": "mcp-runtime",
-		}
+	labels := map[string]string{
+		"app":                          mcpServer.Name,
+		"app.kubernetes.io/managed-by": "mcp-runtime",
+	}
 
-		deployment.Spec = appsv1.DeploymentSpec{
-			Replicas: mcpServer.Spec.Replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: selectorLabels,
+	spec := appsv1.DeploymentSpec{
+		Replicas: mcpServer.Spec.Replicas,
+		Selector: &metav1.LabelSelector{
+			MatchLabels: selectorLabels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      templateLabels,
+				Annotations: podAnnotations,
 			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: templateLabels,
-				},
-				Spec: corev1.PodSpec{
-					ImagePullSecrets: r.buildImagePullSecrets(mcpServer),
-					Containers:       []corev1.Container{},
-				},
+			Spec: corev1.PodSpec{
+				ImagePullSecrets:          r.buildImagePullSecrets(mcpServer),
+				Containers:                []corev1.Container{},
+				PriorityClassName:         mcpServer.Spec.PriorityClassName,
+				TopologySpreadConstraints: buildTopologySpreadConstraints(mcpServer.Spec.TopologySpreadConstraints, selectorLabels),
 			},
-		}
+		},
+	}
 
-		container := corev1.Container{
-			Name:            mcpServer.Name,
-			Image:           image,
-			ImagePullPolicy: corev1.PullIfNotPresent,
-			Ports: []corev1.ContainerPort{
-				{
-					Name:          "http",
-					ContainerPort: mcpServer.Spec.Port,
-					Protocol:      corev1.ProtocolTCP,
-				},
-			},
-			Env: r.buildEnvVars(mcpServer.Spec.EnvVars),
-			LivenessProbe: &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(mcpServer.Spec.Port)},
-				},
-				InitialDelaySeconds: 5,
-				PeriodSeconds:       10,
-			},
-			ReadinessProbe: &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(mcpServer.Spec.Port)},
-				},
-				InitialDelaySeconds: 3,
-				PeriodSeconds:       5,
+	container := corev1.Container{
+		Name:            mcpServer.Name,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: mcpServer.Spec.Port,
+				Protocol:      corev1.ProtocolTCP,
 			},
-		}
+		},
+		Env:     env,
+		EnvFrom: mcpServer.Spec.EnvFrom,
+	}
+	container.LivenessProbe, container.ReadinessProbe, container.StartupProbe = buildProbes(mcpServer, r.ProbeDefaults)
 
-		if err := applyContainerResources(&container, mcpServer.Spec.Resources); err != nil {
-			return err
-		}
+	if err := applyContainerResources(&container, mergeResourceDefaults(mcpServer.Spec.Resources, r.resourceDefaultsFor(mcpServer.Namespace))); err != nil {
+		return err
+	}
 
-		deployment.Spec.Template.Spec.Containers = []corev1.Container{container}
+	spec.Template.Spec.Containers = []corev1.Container{container}
+	if arch != "" {
+		spec.Template.Spec.Affinity = archAffinity(arch)
+	}
+
+	hash, err := specHash(struct {
+		Owner  types.UID
+		Labels map[string]string
+		Spec   appsv1.DeploymentSpec
+	}{mcpServer.UID, labels, spec})
+	if err != nil {
+		return err
+	}
+
+	synced := func() bool { return inSync(labels, deployment.Labels) && inSync(spec, deployment.Spec) }
+	op, err := r.createOrUpdateHashed(ctx, deployment, hash, synced, func() error {
+		deployment.Labels = labels
+		deployment.Spec = spec
 
 		if err := ctrl.SetControllerReference(mcpServer, deployment, r.Scheme); err != nil {
 			return err
@@ -382,7 +728,7 @@ func applyContainerResources(container *corev1.Container, resources mcpv1alpha1.
 					"type":     "request",
 					"value":    resources.Requests.CPU,
 				}
-				return wrapOperatorError(err, fmt.Sprintf("invalid CPU request %q", resources.Requests.CPU), contextMap)
+				return wrapOperatorError(fmt.Errorf("%w: %w", ErrInvalidCPURequest, err), fmt.Sprintf("invalid CPU request %q", resources.Requests.CPU), contextMap)
 			}
 			container.Resources.Requests[corev1.ResourceCPU] = cpu
 		}
@@ -394,7 +740,7 @@ func applyContainerResources(container *corev1.Container, resources mcpv1alpha1.
 					"type":     "request",
 					"value":    resources.Requests.Memory,
 				}
-				return wrapOperatorError(err, fmt.Sprintf("invalid memory request %q", resources.Requests.Memory), contextMap)
+				return wrapOperatorError(fmt.Errorf("%w: %w", ErrInvalidMemoryRequest, err), fmt.Sprintf("invalid memory request %q", resources.Requests.Memory), contextMap)
 			}
 			container.Resources.Requests[corev1.ResourceMemory] = mem
 		}
@@ -409,7 +755,7 @@ func applyContainerResources(container *corev1.Container, resources mcpv1alpha1.
 					"type":     "limit",
 					"value":    resources.Limits.CPU,
 				}
-				return wrapOperatorError(err, fmt.Sprintf("invalid CPU limit %q", resources.Limits.CPU), contextMap)
+				return wrapOperatorError(fmt.Errorf("%w: %w", ErrInvalidCPULimit, err), fmt.Sprintf("invalid CPU limit %q", resources.Limits.CPU), contextMap)
 			}
 			container.Resources.Limits[corev1.ResourceCPU] = cpu
 		}
@@ -421,7 +767,7 @@ func applyContainerResources(container *corev1.Container, resources mcpv1alpha1.
 					"type":     "limit",
 					"value":    resources.Limits.Memory,
 				}
-				return wrapOperatorError(err, fmt.Sprintf("invalid memory limit %q", resources.Limits.Memory), contextMap)
+				return wrapOperatorError(fmt.Errorf("%w: %w", ErrInvalidMemoryLimit, err), fmt.Sprintf("invalid memory limit %q", resources.Limits.Memory), contextMap)
 			}
 			container.Resources.Limits[corev1.ResourceMemory] = mem
 		}
@@ -431,18 +777,24 @@ func applyContainerResources(container *corev1.Container, resources mcpv1alpha1.
 }
 
 func (r *MCPServerReconciler) resolveImage(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (string, error) {
+	return r.resolveImageRef(ctx, mcpServer, mcpServer.Spec.Image)
+}
+
+// resolveImageRef applies the server's image tag and registry override to image.
+func (r *MCPServerReconciler) resolveImageRef(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, image string) (string, error) {
 	logger := log.FromContext(ctx)
 
-	image := mcpServer.Spec.Image
 	// Append tag only if the image does not already include a tag or digest.
 	if mcpServer.Spec.ImageTag != "" && !strings.Contains(image, ":") && !strings.Contains(image, "@") {
 		image = fmt.Sprintf("%s:%s", image, mcpServer.Spec.ImageTag)
 	}
 
 	regOverride := mcpServer.Spec.RegistryOverride
+	pathPrefix := ""
 	if mcpServer.Spec.UseProvisionedRegistry {
 		if r.ProvisionedRegistry != nil && r.ProvisionedRegistry.URL != "" {
 			regOverride = r.ProvisionedRegistry.URL
+			pathPrefix = r.ProvisionedRegistry.PathPrefix
 		} else if regOverride == "" {
 			// Fallback to internal registry service if not configured
 			regOverride = "registry.registry.svc.cluster.local:5000"
@@ -450,16 +802,23 @@ func (r *MCPServerReconciler) resolveImage(ctx context.Context, mcpServer *mcpv1
 		}
 	}
 	if regOverride != "" {
-		image = rewriteRegistry(image, regOverride)
+		image = rewriteRegistry(image, regOverride, pathPrefix)
 	}
 
 	return image, nil
 }
 
-func rewriteRegistry(image, registry string) string {
+// rewriteRegistry moves image to registry, under pathPrefix when set. An image already under
+// pathPrefix keeps a single copy of it.
+func rewriteRegistry(image, registry, pathPrefix string) string {
 	if registry == "" {
 		return image
 	}
+	registry = strings.TrimSuffix(registry, "/")
+	prefix := strings.Trim(pathPrefix, "/")
+	if prefix != "" && !strings.HasSuffix(registry, "/"+prefix) {
+		registry = registry + "/" + prefix
+	}
 	parts := strings.Split(image, "/")
 	if len(parts) == 1 {
 		return fmt.Sprintf("%s/%s", registry, image)
@@ -470,7 +829,11 @@ func rewriteRegistry(image, registry string) string {
 	if strings.Contains(first, ".") || strings.Contains(first, ":") || first == "localhost" {
 		parts = parts[1:]
 	}
-	return fmt.Sprintf("%s/%s", registry, strings.Join(parts, "/"))
+	repo := strings.Join(parts, "/")
+	if prefix != "" {
+		repo = strings.TrimPrefix(repo, prefix+"/")
+	}
+	return fmt.Sprintf("%s/%s", registry, repo)
 }
 
 func (r *MCPServerReconciler) buildImagePullSecrets(mcpServer *mcpv1alpha1.MCPServer) []corev1.LocalObjectReference {
@@ -493,6 +856,10 @@ func (r *MCPServerReconciler) buildImagePullSecrets(mcpServer *mcpv1alpha1.MCPSe
 	// The secret is created during setup (mcp-runtime setup), not during reconciliation.
 	if r.ProvisionedRegistry == nil || r.ProvisionedRegistry.URL == "" ||
 		r.ProvisionedRegistry.Username == "" || r.ProvisionedRegistry.Password == "" {
+		// Fall back to the internal registry pull secret (setup --registry-auth htpasswd).
+		if r.RegistryPullSecret != "" {
+			return []corev1.LocalObjectReference{{Name: r.RegistryPullSecret}}
+		}
 		return nil
 	}
 
@@ -504,6 +871,23 @@ func (r *MCPServerReconciler) buildImagePullSecrets(mcpServer *mcpv1alpha1.MCPSe
 	return []corev1.LocalObjectReference{{Name: secretName}}
 }
 
+// buildTopologySpreadConstraints copies the user's constraints, defaulting an
+// unset labelSelector to the server's pod selector so spreading counts only
+// this server's pods.
+func buildTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, selectorLabels map[string]string) []corev1.TopologySpreadConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+	result := make([]corev1.TopologySpreadConstraint, len(constraints))
+	for i, constraint := range constraints {
+		result[i] = *constraint.DeepCopy()
+		if result[i].LabelSelector == nil {
+			result[i].LabelSelector = &metav1.LabelSelector{MatchLabels: selectorLabels}
+		}
+	}
+	return result
+}
+
 func (r *MCPServerReconciler) reconcileService(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
 	logger := log.FromContext(ctx)
 
@@ -514,91 +898,43 @@ func (r *MCPServerReconciler) reconcileService(ctx context.Context, mcpServer *m
 		},
 	}
 
-	op, err := ctrl.CreateOrUpdate(ctx, r.Client, service, func() error {
-		labels := map[string]string{
-			"app": mcpServer.Name,
-		}
-
-		service.Spec = corev1.ServiceSpec{
-			Type:     corev1.ServiceTypeClusterIP,
-			Selector: labels,
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "http",
-					Port:       mcpServer.Spec.ServicePort,
-					TargetPort: intstr.FromInt32(mcpServer.Spec.Port),
-					Protocol:   corev1.ProtocolTCP,
-				},
+	labels := map[string]string{
+		"app": mcpServer.Name,
+	}
+	families, policy := r.serviceIPFamilies(mcpServer)
+	spec := corev1.ServiceSpec{
+		Type:            corev1.ServiceTypeClusterIP,
+		Selector:        labels,
+		SessionAffinity: serviceSessionAffinity(mcpServer),
+		IPFamilies:      families,
+		IPFamilyPolicy:  policy,
+		Ports: []corev1.ServicePort{
+			{
+				Name:       "http",
+				Port:       mcpServer.Spec.ServicePort,
+				TargetPort: intstr.FromInt32(mcpServer.Spec.Port),
+				Protocol:   corev1.ProtocolTCP,
 			},
-		}
-
-		if err := ctrl.SetControllerReference(mcpServer, service, r.Scheme); err != nil {
-			return err
-		}
-
-		return nil
-	})
+		},
+	}
 
+	hash, err := specHash(struct {
+		Owner       types.UID
+		Spec        corev1.ServiceSpec
+		Annotations map[string]string
+	}{mcpServer.UID, spec, sessionAffinityServiceAnnotations(mcpServer)})
 	if err != nil {
 		return err
 	}
 
-	if op != controllerutil.OperationResultNone {
-		logger.Info("Service reconciled", "operation", op, "name", service.Name)
-	}
-
-	return nil
-}
-
-func (r *MCPServerReconciler) reconcileIngress(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
-	logger := log.FromContext(ctx)
-
-	ingress := &networkingv1.Ingress{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      mcpServer.Name,
-			Namespace: mcpServer.Namespace,
-		},
+	synced := func() bool {
+		return inSync(spec, service.Spec) && inSync(sessionAffinityServiceAnnotations(mcpServer), service.Annotations)
 	}
+	op, err := r.createOrUpdateHashed(ctx, service, hash, synced, func() error {
+		service.Spec = spec
+		applySessionAffinityServiceAnnotations(mcpServer, service)
 
-	op, err := ctrl.CreateOrUpdate(ctx, r.Client, ingress, func() error {
-		pathType := networkingv1.PathTypePrefix
-		ingressClassName := mcpServer.Spec.IngressClass
-		if ingressClassName == "" {
-			ingressClassName = "traefik" // Default to traefik
-		}
-
-		ingress.Spec = networkingv1.IngressSpec{
-			IngressClassName: &ingressClassName,
-			Rules: []networkingv1.IngressRule{
-				{
-					Host: mcpServer.Spec.IngressHost,
-					IngressRuleValue: networkingv1.IngressRuleValue{
-						HTTP: &networkingv1.HTTPIngressRuleValue{
-							Paths: []networkingv1.HTTPIngressPath{
-								{
-									Path:     mcpServer.Spec.IngressPath,
-									PathType: &pathType,
-									Backend: networkingv1.IngressBackend{
-										Service: &networkingv1.IngressServiceBackend{
-											Name: mcpServer.Name,
-											Port: networkingv1.ServiceBackendPort{
-												Number: mcpServer.Spec.ServicePort,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
-
-		// Build annotations based on ingress class
-		annotations := r.buildIngressAnnotations(mcpServer)
-		ingress.Annotations = annotations
-
-		if err := ctrl.SetControllerReference(mcpServer, ingress, r.Scheme); err != nil {
+		if err := ctrl.SetControllerReference(mcpServer, service, r.Scheme); err != nil {
 			return err
 		}
 
@@ -610,26 +946,58 @@ func (r *MCPServerReconciler) reconcileIngress(ctx context.Context, mcpServer *m
 	}
 
 	if op != controllerutil.OperationResultNone {
-		logger.Info("Ingress reconciled", "operation", op, "name", ingress.Name)
+		logger.Info("Service reconciled", "operation", op, "name", service.Name)
 	}
 
 	return nil
 }
 
+// checkDeploymentReady reports whether every Deployment of the server has its desired
+// replicas ready, and copies their replica counts and rollout progress into the status.
 func (r *MCPServerReconciler) checkDeploymentReady(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
-	deployment := &appsv1.Deployment{}
-	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, deployment); err != nil {
-		if errors.IsNotFound(err) {
-			return false, nil
+	status := &mcpServer.Status
+	status.Replicas, status.ReadyReplicas, status.UpdatedReplicas, status.UnavailableReplicas = 0, 0, 0, 0
+	status.RolloutComplete = false
+
+	ready, rolledOut := true, true
+	for _, name := range serverDeploymentNames(mcpServer) {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: mcpServer.Namespace}, deployment); err != nil {
+			if errors.IsNotFound(err) {
+				ready, rolledOut = false, false
+				continue
+			}
+			return false, err
+		}
+		status.Replicas += deployment.Status.Replicas
+		status.ReadyReplicas += deployment.Status.ReadyReplicas
+		status.UpdatedReplicas += deployment.Status.UpdatedReplicas
+		status.UnavailableReplicas += deployment.Status.UnavailableReplicas
+
+		desiredReplicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desiredReplicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ReadyReplicas != desiredReplicas {
+			ready = false
+		}
+		if !deploymentRolledOut(deployment, desiredReplicas) {
+			rolledOut = false
 		}
-		return false, err
 	}
+	status.RolloutComplete = rolledOut
+	return ready, nil
+}
 
-	desiredReplicas := int32(1)
-	if deployment.Spec.Replicas != nil {
-		desiredReplicas = *deployment.Spec.Replicas
-	}
-	return deployment.Status.ReadyReplicas == desiredReplicas, nil
+// deploymentRolledOut applies the checks of "kubectl rollout status": the controller has
+// seen the current spec, every replica runs the new pod template, no old pods are left
+// and all new pods are available.
+func deploymentRolledOut(deployment *appsv1.Deployment, desiredReplicas int32) bool {
+	s := deployment.Status
+	return deployment.Generation <= s.ObservedGeneration &&
+		s.UpdatedReplicas == desiredReplicas &&
+		s.Replicas == s.UpdatedReplicas &&
+		s.AvailableReplicas == s.UpdatedReplicas
 }
 
 func (r *MCPServerReconciler) checkServiceReady(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
@@ -644,100 +1012,77 @@ func (r *MCPServerReconciler) checkServiceReady(ctx context.Context, mcpServer *
 	return service.Spec.ClusterIP != "", nil
 }
 
-func (r *MCPServerReconciler) checkIngressReady(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
-	ingress := &networkingv1.Ingress{}
-	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, ingress); err != nil {
-		if errors.IsNotFound(err) {
-			return false, nil
-		}
-		return false, err
-	}
-
-	if len(ingress.Status.LoadBalancer.Ingress) > 0 {
-		return true, nil
-	}
-	return false, nil
-}
-
 func (r *MCPServerReconciler) updateStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, phase, message string, deploymentReady, serviceReady, ingressReady bool) {
 	mcpServer.Status.Phase = phase
 	mcpServer.Status.Message = message
 	mcpServer.Status.DeploymentReady = deploymentReady
 	mcpServer.Status.ServiceReady = serviceReady
 	mcpServer.Status.IngressReady = ingressReady
+	mcpServer.Status.URL = r.serverURL(mcpServer)
+	ready := deploymentReady && serviceReady && ingressReady
+	setReadyCondition(mcpServer, phase, message, ready && phase == "Ready")
+	markObservedGeneration(mcpServer)
+	recordServerReady(mcpServer, ready)
 
 	if err := r.Status().Update(ctx, mcpServer); err != nil {
 		log.FromContext(ctx).Error(err, "Failed to update MCPServer status")
 	}
 }
 
-func (r *MCPServerReconciler) buildEnvVars(envVars []mcpv1alpha1.EnvVar) []corev1.EnvVar {
-	result := make([]corev1.EnvVar, len(envVars))
-	for i, ev := range envVars {
-		result[i] = corev1.EnvVar{
-			Name:  ev.Name,
-			Value: ev.Value,
-		}
-	}
-	return result
+// startSpan starts an operator span as a child of ctx.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
 }
 
-func (r *MCPServerReconciler) buildIngressAnnotations(mcpServer *mcpv1alpha1.MCPServer) map[string]string {
-	annotations := make(map[string]string)
-
-	// Start with user-provided annotations
-	if mcpServer.Spec.IngressAnnotations != nil {
-		for k, v := range mcpServer.Spec.IngressAnnotations {
-			annotations[k] = v
-		}
-	}
-
-	// Add controller-specific annotations based on ingress class
-	ingressClass := mcpServer.Spec.IngressClass
-	if ingressClass == "" {
-		ingressClass = "traefik" // Default to traefik
-	}
-
-	switch ingressClass {
-	case "traefik":
-		// Traefik Ingress Controller annotations
-		if _, exists := annotations["traefik.ingress.kubernetes.io/router.entrypoints"]; !exists {
-			annotations["traefik.ingress.kubernetes.io/router.entrypoints"] = "web"
-		}
-
-	case "nginx":
-		// Nginx Ingress Controller annotations
-		if _, exists := annotations["nginx.ingress.kubernetes.io/rewrite-target"]; !exists {
-			annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/"
-		}
-		if _, exists := annotations["nginx.ingress.kubernetes.io/ssl-redirect"]; !exists {
-			annotations["nginx.ingress.kubernetes.io/ssl-redirect"] = "false"
-		}
-
-	case "istio":
-		// Istio Gateway/VirtualService annotations (Istio uses different approach)
-		// For Istio, you typically use Gateway and VirtualService CRDs instead
-		// This is a placeholder - Istio integration would need separate CRDs
-		if _, exists := annotations["kubernetes.io/ingress.class"]; !exists {
-			annotations["kubernetes.io/ingress.class"] = "istio"
-		}
-
-	default:
-		// Generic ingress annotations for unknown controllers
-		if _, exists := annotations["ingress.kubernetes.io/rewrite-target"]; !exists {
-			annotations["ingress.kubernetes.io/rewrite-target"] = "/"
-		}
+// endSpan records err (if any) on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	span.End()
+}
 
-	return annotations
+// traceResource runs a per-resource reconcile step inside its own span.
+func traceResource(ctx context.Context, kind string, fn func(context.Context) error) error {
+	ctx, span := startSpan(ctx, "Reconcile/"+kind, attribute.String("resource", kind))
+	err := fn(ctx)
+	endSpan(span, err)
+	return err
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&mcpv1alpha1.MCPServer{}).
+		WithOptions(controller.Options{RateLimiter: reconcileRateLimiter()}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&networkingv1.Ingress{}).
-		Complete(r)
+		Owns(&batchv1.Job{}).
+		Watches(&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(requestForServerPod),
+			builder.WithPredicates(predicate.Or(podFailureChanged, podReadinessChanged))).
+		Watches(&mcpv1alpha1.MCPRuntimeConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForAllServers),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isRuntimeConfig))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForEnvFromSecret)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.requestsForEnvFromConfigMap))
+	if httpRoutesAvailable(mgr.GetRESTMapper()) {
+		b = b.Owns(newHTTPRoute(&mcpv1alpha1.MCPServer{}))
+	}
+	if r.MaintenanceNamespace != "" {
+		b = b.Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForAllServers),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.isMaintenanceConfigMap)))
+	}
+	if r.SyncNamespace != "" {
+		b = b.Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForSyncedSecret),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.isSyncSource))).
+			Watches(&corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(r.requestsForSyncedConfigMap),
+				builder.WithPredicates(predicate.NewPredicateFuncs(r.isSyncSource)))
+	}
+	return b.Complete(r)
 }
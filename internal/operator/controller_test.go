@@ -20,10 +20,11 @@ import (
 
 func TestRewriteRegistry(t *testing.T) {
 	tests := []struct {
-		name     string
-		image    string
-		registry string
-		want     string
+		name       string
+		image      string
+		registry   string
+		pathPrefix string
+		want       string
 	}{
 		{
 			name:     "test-image",
@@ -31,11 +32,25 @@ func TestRewriteRegistry(t *testing.T) {
 			registry: "registry.registry.svc.cluster.local:5000",
 			want:     "registry.registry.svc.cluster.local:5000/test-image",
 		},
+		{
+			name:       "path prefix",
+			image:      "docker.io/team/server:1.0",
+			registry:   "harbor.example.com",
+			pathPrefix: "mcp",
+			want:       "harbor.example.com/mcp/team/server:1.0",
+		},
+		{
+			name:       "image already under the prefix",
+			image:      "harbor.example.com/mcp/server:1.0",
+			registry:   "harbor.example.com/",
+			pathPrefix: "/mcp/",
+			want:       "harbor.example.com/mcp/server:1.0",
+		},
 	}
 	for _, test := range tests {
-		got := rewriteRegistry(test.image, test.registry)
+		got := rewriteRegistry(test.image, test.registry, test.pathPrefix)
 		if got != test.want {
-			t.Errorf("rewriteRegistry(%q, %q) = %q, want %q", test.image, test.registry, got, test.want)
+			t.Errorf("rewriteRegistry(%q, %q, %q) = %q, want %q", test.image, test.registry, test.pathPrefix, got, test.want)
 		}
 	}
 }
@@ -406,6 +421,26 @@ func TestApplyDefaultsIfNeeded(t *testing.T) {
 		assertEqual(t, "requeue", requeue, true)
 	})
 
+	t.Run("does not save ingress defaults", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+			Spec:       mcpv1alpha1.MCPServerSpec{Image: "test-image"},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme, DefaultIngressHost: "mcp.example.com", DefaultIngressClass: "nginx"}
+		if _, err := r.applyDefaultsIfNeeded(context.Background(), mcpServer, logr.Discard()); err != nil {
+			t.Fatalf("failed to apply defaults: %v", err)
+		}
+		saved := &mcpv1alpha1.MCPServer{}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: "test-server", Namespace: "default"}, saved); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		assertEqual(t, "port", saved.Spec.Port, int32(8088))
+		assertEqual(t, "ingressHost", saved.Spec.IngressHost, "")
+		assertEqual(t, "ingressPath", saved.Spec.IngressPath, "")
+		assertEqual(t, "ingressClass", saved.Spec.IngressClass, "")
+	})
+
 	t.Run("returns requeue false when defaults already set", func(t *testing.T) {
 		replicas := int32(1)
 		mcpServer := &mcpv1alpha1.MCPServer{
@@ -468,6 +503,29 @@ func TestUpdateStatus(t *testing.T) {
 		assertEqual(t, "phase", mcpServer.Status.Phase, "Ready")
 		assertEqual(t, "message", mcpServer.Status.Message, "All resources reconciled")
 	})
+
+	t.Run("records observed generation and ready condition", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default", Generation: 3},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+		r.updateStatus(context.Background(), mcpServer, "Pending", "Waiting for Deployment", false, true, false)
+		assertEqual(t, "observedGeneration", mcpServer.Status.ObservedGeneration, int64(3))
+		ready := findCondition(mcpServer.Status.Conditions, ConditionReady)
+		if ready == nil {
+			t.Fatal("expected Ready condition")
+		}
+		assertEqual(t, "ready status", ready.Status, metav1.ConditionFalse)
+		assertEqual(t, "ready reason", ready.Reason, "Pending")
+		assertEqual(t, "condition observedGeneration", ready.ObservedGeneration, int64(3))
+
+		r.updateStatus(context.Background(), mcpServer, "Ready", "All resources reconciled", true, true, true)
+		ready = findCondition(mcpServer.Status.Conditions, ConditionReady)
+		assertEqual(t, "ready status", ready.Status, metav1.ConditionTrue)
+		assertEqual(t, "ready reason", ready.Reason, ReasonResourcesReady)
+	})
 }
 
 func TestDeterminePhase(t *testing.T) {
@@ -498,6 +556,43 @@ func TestCheckDeploymentReady(t *testing.T) {
 		}
 		assertEqual(t, "ready", ready, false)
 	})
+
+	t.Run("copies replica counts and rollout progress into the status", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+		}
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 2,
+				AvailableReplicas: 2, UnavailableReplicas: 1,
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer, deployment).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+		ready, err := r.checkDeploymentReady(context.Background(), mcpServer)
+		if err != nil {
+			t.Fatalf("failed to check deployment readiness: %v", err)
+		}
+		assertEqual(t, "ready", ready, true)
+		assertEqual(t, "replicas", mcpServer.Status.Replicas, int32(3))
+		assertEqual(t, "readyReplicas", mcpServer.Status.ReadyReplicas, int32(2))
+		assertEqual(t, "updatedReplicas", mcpServer.Status.UpdatedReplicas, int32(2))
+		assertEqual(t, "unavailableReplicas", mcpServer.Status.UnavailableReplicas, int32(1))
+		assertEqual(t, "rolloutComplete with an old pod left", mcpServer.Status.RolloutComplete, false)
+
+		deployment.Status.Replicas, deployment.Status.UnavailableReplicas = 2, 0
+		if err := client.Status().Update(context.Background(), deployment); err != nil {
+			t.Fatalf("failed to update deployment status: %v", err)
+		}
+		if _, err := r.checkDeploymentReady(context.Background(), mcpServer); err != nil {
+			t.Fatalf("failed to check deployment readiness: %v", err)
+		}
+		assertEqual(t, "unavailableReplicas", mcpServer.Status.UnavailableReplicas, int32(0))
+		assertEqual(t, "rolloutComplete", mcpServer.Status.RolloutComplete, true)
+	})
 }
 
 func TestCheckServiceReady(t *testing.T) {
@@ -584,6 +679,68 @@ func TestReconcileDeployment(t *testing.T) {
 			t.Fatalf("failed to reconcile deployment: %v", err)
 		}
 	})
+
+	t.Run("propagates topology spread and priority class", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "spread-server", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:             "test-image",
+				PriorityClassName: "mcp-critical",
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+					},
+					{
+						MaxSkew:           2,
+						TopologyKey:       "kubernetes.io/hostname",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "mcp"}},
+					},
+				},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		if err := r.reconcileDeployment(context.Background(), mcpServer); err != nil {
+			t.Fatalf("failed to reconcile deployment: %v", err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: "spread-server", Namespace: "default"}, deployment); err != nil {
+			t.Fatalf("failed to get deployment: %v", err)
+		}
+		podSpec := deployment.Spec.Template.Spec
+		assertEqual(t, "priorityClassName", podSpec.PriorityClassName, "mcp-critical")
+		if len(podSpec.TopologySpreadConstraints) != 2 {
+			t.Fatalf("expected 2 topology spread constraints, got %d", len(podSpec.TopologySpreadConstraints))
+		}
+		assertEqual(t, "defaulted selector", podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels[LabelApp], "spread-server")
+		assertEqual(t, "explicit selector", podSpec.TopologySpreadConstraints[1].LabelSelector.MatchLabels["tier"], "mcp")
+		if mcpServer.Spec.TopologySpreadConstraints[0].LabelSelector != nil {
+			t.Fatal("spec constraints must not be mutated")
+		}
+	})
+
+	t.Run("annotates pods with the safe-to-evict policy", func(t *testing.T) {
+		safe := false
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "session-server", Namespace: "default"},
+			Spec:       mcpv1alpha1.MCPServerSpec{Image: "test-image", SafeToEvict: &safe},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		if err := r.reconcileDeployment(context.Background(), mcpServer); err != nil {
+			t.Fatalf("failed to reconcile deployment: %v", err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: "session-server", Namespace: "default"}, deployment); err != nil {
+			t.Fatalf("failed to get deployment: %v", err)
+		}
+		assertEqual(t, "safe-to-evict", deployment.Spec.Template.Annotations[AnnotationSafeToEvict], "false")
+	})
 }
 
 func TestReconcileService(t *testing.T) {
@@ -641,7 +798,10 @@ func TestBuildEnvVars(t *testing.T) {
 			{Name: "FOO", Value: "bar"},
 			{Name: "BAZ", Value: "qux"},
 		}
-		envVars := r.buildEnvVars(input)
+		envVars, err := r.buildEnvVars(&mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{EnvVars: input}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 		assertEqual(t, "len", len(envVars), 2)
 		assertEqual(t, "envVars[0].Name", envVars[0].Name, "FOO")
 		assertEqual(t, "envVars[0].Value", envVars[0].Value, "bar")
@@ -651,7 +811,10 @@ func TestBuildEnvVars(t *testing.T) {
 
 	t.Run("returns empty slice for nil input", func(t *testing.T) {
 		r := MCPServerReconciler{}
-		envVars := r.buildEnvVars(nil)
+		envVars, err := r.buildEnvVars(&mcpv1alpha1.MCPServer{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
 		assertEqual(t, "len", len(envVars), 0)
 	})
 }
@@ -679,6 +842,16 @@ func TestBuildImagePullSecrets(t *testing.T) {
 		pullSecrets := r.buildImagePullSecrets(mcpServer)
 		assertEqual(t, "len", len(pullSecrets), 0)
 	})
+
+	t.Run("falls back to internal registry pull secret", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+		}
+		r := MCPServerReconciler{RegistryPullSecret: "registry-pull-creds"}
+		pullSecrets := r.buildImagePullSecrets(mcpServer)
+		assertEqual(t, "len", len(pullSecrets), 1)
+		assertEqual(t, "pullSecrets[0]", pullSecrets[0].Name, "registry-pull-creds")
+	})
 }
 
 func TestResolveImage(t *testing.T) {
@@ -792,6 +965,38 @@ func TestReconcile(t *testing.T) {
 		}
 		// Should not requeue immediately since all fields are set
 		assertEqual(t, "requeue", result.Requeue, false)
+		// Nothing is ready in the fake cluster; the watches bring the next reconcile and only a
+		// long resync is left as a fallback.
+		assertEqual(t, "requeueAfter", result.RequeueAfter, ResyncNotReady)
+	})
+
+	t.Run("reports suspended phase for zero replicas", func(t *testing.T) {
+		replicas := int32(0)
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "idle-server", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "test-image",
+				ImageTag:     "latest",
+				Port:         8088,
+				ServicePort:  80,
+				Replicas:     &replicas,
+				IngressHost:  "example.com",
+				IngressPath:  "/idle-server/mcp",
+				IngressClass: "traefik",
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "idle-server", Namespace: "default"}}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		updated := &mcpv1alpha1.MCPServer{}
+		if err := client.Get(context.Background(), request.NamespacedName, updated); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		assertEqual(t, "phase", updated.Status.Phase, "Suspended")
 	})
 
 	t.Run("requeues when defaults need to be applied", func(t *testing.T) {
@@ -0,0 +1,180 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// AnnotationSuspendedReplicas records the replica count of a server scaled to zero, so
+// "mcp-runtime server resume" can restore it. The CLI's suspend command uses the same key.
+const AnnotationSuspendedReplicas = "mcpruntime.org/suspended-replicas"
+
+// Crash loop policy actions and defaults.
+const (
+	// CrashLoopActionPause scales the server to zero once maxRestarts is exceeded.
+	CrashLoopActionPause = "pause"
+	// CrashLoopActionNotify only emits a warning event once maxRestarts is exceeded.
+	CrashLoopActionNotify = "notify"
+	// DefaultCrashLoopWindowSeconds is the restart window when windowSeconds is unset.
+	DefaultCrashLoopWindowSeconds = 600
+)
+
+// Crash loop condition, reasons and event reasons.
+const (
+	// ConditionCrashLoopPaused is True while the operator keeps a crash-looping
+	// server scaled to zero.
+	ConditionCrashLoopPaused = "CrashLoopPaused"
+	// ReasonMaxRestartsExceeded is the CrashLoopPaused=True reason and the reason
+	// of the warning event emitted for either action.
+	ReasonMaxRestartsExceeded = "MaxRestartsExceeded"
+	// ReasonResumed is the CrashLoopPaused=False reason once the server is scaled up again.
+	ReasonResumed = "Resumed"
+	// ReasonCrashLoopPaused is the reason of the event emitted when a server is paused.
+	ReasonCrashLoopPaused = "CrashLoopPaused"
+)
+
+// enforceCrashLoopPolicy counts container restarts of the server's pods in the current
+// window of spec.crashLoopPolicy and, once maxRestarts is exceeded, pauses the server or
+// emits a warning event. A paused server stays at zero replicas until it is resumed.
+func (r *MCPServerReconciler) enforceCrashLoopPolicy(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	policy := mcpServer.Spec.CrashLoopPolicy
+	if policy == nil {
+		mcpServer.Status.CrashLoop = nil
+		return nil
+	}
+	if mcpServer.Spec.Replicas != nil && *mcpServer.Spec.Replicas == 0 {
+		// Pods of a scaled-down server go away; count afresh once it is resumed.
+		mcpServer.Status.CrashLoop = nil
+		return nil
+	}
+	if cond := findCondition(mcpServer.Status.Conditions, ConditionCrashLoopPaused); cond != nil && cond.Status == metav1.ConditionTrue {
+		setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+			Type:    ConditionCrashLoopPaused,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonResumed,
+			Message: "Server was scaled up again",
+		})
+	}
+
+	total, err := r.totalRestarts(ctx, mcpServer)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	window := time.Duration(crashLoopWindowSeconds(policy)) * time.Second
+	state := mcpServer.Status.CrashLoop
+	// Restart counts drop when pods are replaced; start a new window then too.
+	if state == nil || now.Sub(state.WindowStart.Time) >= window || total < state.BaselineRestarts {
+		state = &mcpv1alpha1.CrashLoopStatus{WindowStart: now, BaselineRestarts: total}
+		mcpServer.Status.CrashLoop = state
+	}
+	state.Restarts = total - state.BaselineRestarts
+	if state.Restarts <= policy.MaxRestarts {
+		return nil
+	}
+
+	message := fmt.Sprintf("%d container restarts within %s exceed maxRestarts %d", state.Restarts, window, policy.MaxRestarts)
+	if policy.Action == CrashLoopActionNotify {
+		if !state.Notified {
+			state.Notified = true
+			logger.Info("Crash loop policy exceeded", "name", mcpServer.Name, "restarts", state.Restarts)
+			r.event(mcpServer, corev1.EventTypeWarning, ReasonMaxRestartsExceeded, message)
+		}
+		return nil
+	}
+	return r.pauseCrashLoopingServer(ctx, mcpServer, message, logger)
+}
+
+// pauseCrashLoopingServer scales the server to zero, keeping its replica count in the
+// suspended-replicas annotation, and records why in the CrashLoopPaused condition.
+func (r *MCPServerReconciler) pauseCrashLoopingServer(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, message string, logger logr.Logger) error {
+	replicas := int32(1)
+	if mcpServer.Spec.Replicas != nil {
+		replicas = *mcpServer.Spec.Replicas
+	}
+	patch := client.MergeFrom(mcpServer.DeepCopy())
+	if mcpServer.Annotations == nil {
+		mcpServer.Annotations = map[string]string{}
+	}
+	mcpServer.Annotations[AnnotationSuspendedReplicas] = strconv.Itoa(int(replicas))
+	zero := int32(0)
+	mcpServer.Spec.Replicas = &zero
+
+	// Patch replaces the object with the stored one; keep the status computed so far and the
+	// ingress defaults applied in memory.
+	status := mcpServer.Status.DeepCopy()
+	host, ingressPath, class := mcpServer.Spec.IngressHost, mcpServer.Spec.IngressPath, mcpServer.Spec.IngressClass
+	if err := r.Patch(ctx, mcpServer, patch); err != nil {
+		logger.Error(err, "Failed to pause crash-looping MCPServer", "name", mcpServer.Name)
+		return err
+	}
+	mcpServer.Status = *status
+	mcpServer.Spec.IngressHost, mcpServer.Spec.IngressPath, mcpServer.Spec.IngressClass = host, ingressPath, class
+	mcpServer.Status.CrashLoop = nil
+
+	message += "; scaled to zero replicas, resume with \"mcp-runtime server resume " + mcpServer.Name + "\""
+	setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+		Type:    ConditionCrashLoopPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonMaxRestartsExceeded,
+		Message: message,
+	})
+	logger.Info("Paused crash-looping MCPServer", "name", mcpServer.Name, "replicas", replicas)
+	r.event(mcpServer, corev1.EventTypeWarning, ReasonCrashLoopPaused, message)
+	return nil
+}
+
+// crashLoopPausedMessage returns the message of a True CrashLoopPaused condition.
+func crashLoopPausedMessage(mcpServer *mcpv1alpha1.MCPServer) (string, bool) {
+	cond := findCondition(mcpServer.Status.Conditions, ConditionCrashLoopPaused)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return "", false
+	}
+	return "Paused by crash loop policy: " + cond.Message, true
+}
+
+// totalRestarts sums the restart counts of all containers in the server's pods.
+func (r *MCPServerReconciler) totalRestarts(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (int32, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(mcpServer.Namespace),
+		client.MatchingLabels{LabelApp: mcpServer.Name},
+	); err != nil {
+		return 0, err
+	}
+	var total int32
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.InitContainerStatuses {
+			total += cs.RestartCount
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			total += cs.RestartCount
+		}
+	}
+	return total, nil
+}
+
+// crashLoopWindowSeconds returns the policy's window, falling back to the default.
+func crashLoopWindowSeconds(policy *mcpv1alpha1.CrashLoopPolicy) int32 {
+	if policy.WindowSeconds != nil && *policy.WindowSeconds > 0 {
+		return *policy.WindowSeconds
+	}
+	return DefaultCrashLoopWindowSeconds
+}
+
+// event records a Kubernetes event for obj when the reconciler has a recorder.
+func (r *MCPServerReconciler) event(obj runtime.Object, eventType, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(obj, eventType, reason, message)
+	}
+}
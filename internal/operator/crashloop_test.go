@@ -0,0 +1,156 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func crashLoopPod(name string, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{LabelApp: "flaky"}},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", RestartCount: 1}},
+			ContainerStatuses:     []corev1.ContainerStatus{{Name: "server", RestartCount: restarts - 1}},
+		},
+	}
+}
+
+func crashLoopServer(action string) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "flaky", Namespace: "default"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Replicas:        int32Ptr(3),
+			CrashLoopPolicy: &mcpv1alpha1.CrashLoopPolicy{MaxRestarts: 5, Action: action},
+		},
+	}
+}
+
+func TestEnforceCrashLoopPolicyPauses(t *testing.T) {
+	scheme := newHealthTestScheme()
+	server := crashLoopServer("")
+	pod := crashLoopPod("flaky-1", 2)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, pod).
+		WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	recorder := record.NewFakeRecorder(4)
+	r := MCPServerReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+	ctx := context.Background()
+
+	// The first pass opens the window at the current restart count.
+	if err := r.enforceCrashLoopPolicy(ctx, server, logr.Discard()); err != nil {
+		t.Fatalf("enforceCrashLoopPolicy() error: %v", err)
+	}
+	if server.Status.CrashLoop == nil || server.Status.CrashLoop.BaselineRestarts != 2 || server.Status.CrashLoop.Restarts != 0 {
+		t.Fatalf("unexpected window: %+v", server.Status.CrashLoop)
+	}
+
+	pod.Status.ContainerStatuses[0].RestartCount = 7
+	if err := c.Status().Update(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.enforceCrashLoopPolicy(ctx, server, logr.Discard()); err != nil {
+		t.Fatalf("enforceCrashLoopPolicy() error: %v", err)
+	}
+
+	stored := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "flaky", Namespace: "default"}, stored); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "spec.replicas", *stored.Spec.Replicas, int32(0))
+	assertEqual(t, "suspended replicas", stored.Annotations[AnnotationSuspendedReplicas], "3")
+
+	cond := findCondition(server.Status.Conditions, ConditionCrashLoopPaused)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != ReasonMaxRestartsExceeded {
+		t.Fatalf("unexpected CrashLoopPaused condition: %+v", cond)
+	}
+	if !strings.Contains(cond.Message, "6 container restarts") {
+		t.Fatalf("condition message = %q", cond.Message)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.HasPrefix(event, "Warning "+ReasonCrashLoopPaused) {
+			t.Fatalf("unexpected event %q", event)
+		}
+	default:
+		t.Fatal("expected a CrashLoopPaused event")
+	}
+
+	// While suspended the condition stays; scaling up again clears it.
+	if err := r.enforceCrashLoopPolicy(ctx, server, logr.Discard()); err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := crashLoopPausedMessage(server); !ok || !strings.Contains(msg, "server resume flaky") {
+		t.Fatalf("expected paused message, got %q", msg)
+	}
+	server.Spec.Replicas = int32Ptr(3)
+	if err := r.enforceCrashLoopPolicy(ctx, server, logr.Discard()); err != nil {
+		t.Fatal(err)
+	}
+	if cond := findCondition(server.Status.Conditions, ConditionCrashLoopPaused); cond.Status != metav1.ConditionFalse || cond.Reason != ReasonResumed {
+		t.Fatalf("expected CrashLoopPaused=False after resume, got %+v", cond)
+	}
+}
+
+func TestEnforceCrashLoopPolicyNotifiesOncePerWindow(t *testing.T) {
+	scheme := newHealthTestScheme()
+	server := crashLoopServer(CrashLoopActionNotify)
+	server.Status.CrashLoop = &mcpv1alpha1.CrashLoopStatus{WindowStart: metav1.Now(), BaselineRestarts: 1}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, crashLoopPod("flaky-1", 10)).
+		WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	recorder := record.NewFakeRecorder(4)
+	r := MCPServerReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+	for range 2 {
+		if err := r.enforceCrashLoopPolicy(context.Background(), server, logr.Discard()); err != nil {
+			t.Fatalf("enforceCrashLoopPolicy() error: %v", err)
+		}
+	}
+	assertEqual(t, "events", len(recorder.Events), 1)
+	assertEqual(t, "replicas", *server.Spec.Replicas, int32(3))
+	assertEqual(t, "restarts", server.Status.CrashLoop.Restarts, int32(9))
+	if findCondition(server.Status.Conditions, ConditionCrashLoopPaused) != nil {
+		t.Fatal("notify must not set CrashLoopPaused")
+	}
+}
+
+func TestEnforceCrashLoopPolicyWindow(t *testing.T) {
+	scheme := newHealthTestScheme()
+	tests := []struct {
+		name     string
+		start    time.Time
+		baseline int32
+	}{
+		{name: "expired window", start: time.Now().Add(-11 * time.Minute), baseline: 0},
+		{name: "pods replaced", start: time.Now(), baseline: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := crashLoopServer("")
+			server.Status.CrashLoop = &mcpv1alpha1.CrashLoopStatus{WindowStart: metav1.NewTime(tt.start), BaselineRestarts: tt.baseline}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, crashLoopPod("flaky-1", 8)).Build()
+			r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+			if err := r.enforceCrashLoopPolicy(context.Background(), server, logr.Discard()); err != nil {
+				t.Fatalf("enforceCrashLoopPolicy() error: %v", err)
+			}
+			assertEqual(t, "baseline", server.Status.CrashLoop.BaselineRestarts, int32(8))
+			assertEqual(t, "restarts", server.Status.CrashLoop.Restarts, int32(0))
+			stored := &mcpv1alpha1.MCPServer{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(server), stored); err != nil {
+				t.Fatal(err)
+			}
+			assertEqual(t, "replicas", *stored.Spec.Replicas, int32(3))
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// ImpersonatingClientFunc returns a client that acts as username.
+type ImpersonatingClientFunc func(username string) (client.Client, error)
+
+// NewImpersonatingClientFunc returns an ImpersonatingClientFunc that builds uncached clients
+// from cfg, one per user, reused across reconciles.
+func NewImpersonatingClientFunc(cfg *rest.Config, scheme *runtime.Scheme, mapper meta.RESTMapper) ImpersonatingClientFunc {
+	var mu sync.Mutex
+	clients := map[string]client.Client{}
+	return func(username string) (client.Client, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if c, ok := clients[username]; ok {
+			return c, nil
+		}
+		impersonated := rest.CopyConfig(cfg)
+		impersonated.Impersonate = rest.ImpersonationConfig{UserName: username}
+		c, err := client.New(impersonated, client.Options{Scheme: scheme, Mapper: mapper})
+		if err != nil {
+			return nil, err
+		}
+		clients[username] = c
+		return c, nil
+	}
+}
+
+// serviceAccountUsername is the user name the API server gives a ServiceAccount.
+func serviceAccountUsername(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
+
+// deployAsClient writes a server's child resources as the impersonated ServiceAccount and
+// reads and writes the MCPServer itself, including its status, as the operator.
+type deployAsClient struct {
+	client.Client
+	operator client.Client
+}
+
+// operatorObject reports whether obj is written as the operator under spec.deployAs.
+func operatorObject(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *mcpv1alpha1.MCPServer, *mcpv1alpha1.MCPServerList:
+		return true
+	}
+	return false
+}
+
+func (c deployAsClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if operatorObject(obj) {
+		return c.operator.Get(ctx, key, obj, opts...)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c deployAsClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if operatorObject(list) {
+		return c.operator.List(ctx, list, opts...)
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c deployAsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if operatorObject(obj) {
+		return c.operator.Update(ctx, obj, opts...)
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c deployAsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if operatorObject(obj) {
+		return c.operator.Patch(ctx, obj, patch, opts...)
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c deployAsClient) Status() client.SubResourceWriter {
+	return c.operator.Status()
+}
+
+func (c deployAsClient) SubResource(subResource string) client.SubResourceClient {
+	return c.operator.SubResource(subResource)
+}
+
+// deployAsReconciler returns the reconciler that writes mcpServer's child resources: r
+// itself, or with spec.deployAs a copy of r acting as that ServiceAccount. It fails when
+// deployAs is invalid, cannot be impersonated, or is missing while RequireDeployAs is set.
+func (r *MCPServerReconciler) deployAsReconciler(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) (*MCPServerReconciler, error) {
+	name := mcpServer.Spec.DeployAs
+	var message string
+	switch {
+	case name == "" && !r.RequireDeployAs:
+		return r, nil
+	case name == "":
+		message = "spec.deployAs is required: the operator only creates server resources as a ServiceAccount of the server's namespace"
+	case len(validation.IsDNS1123Subdomain(name)) > 0:
+		message = fmt.Sprintf("spec.deployAs %q is not a valid ServiceAccount name", name)
+	case r.ImpersonatingClient == nil:
+		message = "spec.deployAs is not supported: the operator runs without impersonation"
+	default:
+		impersonated, err := r.ImpersonatingClient(serviceAccountUsername(mcpServer.Namespace, name))
+		if err == nil {
+			scoped := *r
+			scoped.Client = deployAsClient{Client: impersonated, operator: r.Client}
+			return &scoped, nil
+		}
+		message = fmt.Sprintf("cannot act as ServiceAccount %s: %v", name, err)
+	}
+	contextMap := map[string]any{
+		"mcpServer": mcpServer.Name,
+		"namespace": mcpServer.Namespace,
+		"deployAs":  name,
+	}
+	err := wrapOperatorError(fmt.Errorf("%w: %s", ErrInvalidDeployAs, message), "Invalid deployAs", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Invalid deployAs")
+	return nil, err
+}
@@ -0,0 +1,145 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestDeployAsWritesChildrenAsServiceAccount(t *testing.T) {
+	scheme := newServerTestScheme()
+	mcpServer := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "tenant-a"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:       "test-image",
+			IngressHost: "example.com",
+			IngressPath: "/test",
+			DeployAs:    "mcp-deployer",
+		},
+	}
+	operatorClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).WithStatusSubresource(mcpServer).Build()
+	tenantClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	var impersonated string
+	r := MCPServerReconciler{
+		Client: operatorClient,
+		Scheme: scheme,
+		ImpersonatingClient: func(username string) (client.Client, error) {
+			impersonated = username
+			return tenantClient, nil
+		},
+	}
+
+	if err := r.reconcileResources(context.Background(), mcpServer, logr.Discard()); err != nil {
+		t.Fatalf("reconcileResources() error: %v", err)
+	}
+	assertEqual(t, "impersonated user", impersonated, "system:serviceaccount:tenant-a:mcp-deployer")
+
+	key := types.NamespacedName{Name: "test-server", Namespace: "tenant-a"}
+	if err := tenantClient.Get(context.Background(), key, &appsv1.Deployment{}); err != nil {
+		t.Fatalf("expected the Deployment to be written as the ServiceAccount: %v", err)
+	}
+	if err := operatorClient.Get(context.Background(), key, &corev1.Service{}); err == nil {
+		t.Fatal("expected no Service written as the operator")
+	}
+}
+
+func TestOwnerReferencesNeedFinalizersRBAC(t *testing.T) {
+	scheme := newServerTestScheme()
+	mcpServer := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "tenant-a"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "test-image", IngressHost: "example.com", IngressPath: "/test"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).WithStatusSubresource(mcpServer).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	if err := r.reconcileResources(context.Background(), mcpServer, logr.Discard()); err != nil {
+		t.Fatalf("reconcileResources() error: %v", err)
+	}
+	var deployment appsv1.Deployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test-server", Namespace: "tenant-a"}, &deployment); err != nil {
+		t.Fatalf("failed to get Deployment: %v", err)
+	}
+	blocks := false
+	for _, ref := range deployment.OwnerReferences {
+		if ref.BlockOwnerDeletion != nil && *ref.BlockOwnerDeletion {
+			blocks = true
+		}
+	}
+	if !blocks {
+		t.Fatal("expected an owner reference with BlockOwnerDeletion; drop the finalizers rule if this changes")
+	}
+
+	data, err := os.ReadFile("../../config/rbac/role.yaml")
+	if err != nil {
+		t.Fatalf("failed to read role.yaml: %v", err)
+	}
+	var role rbacv1.ClusterRole
+	if err := yaml.Unmarshal(data, &role); err != nil {
+		t.Fatalf("failed to parse role.yaml: %v", err)
+	}
+	for _, rule := range role.Rules {
+		if slices.Contains(rule.APIGroups, "mcpruntime.org") && slices.Contains(rule.Resources, "mcpservers/finalizers") && slices.Contains(rule.Verbs, "update") {
+			return
+		}
+	}
+	t.Fatal("expected role.yaml to grant update on mcpservers/finalizers for BlockOwnerDeletion")
+}
+
+func TestDeployAsRoutesMCPServerToOperator(t *testing.T) {
+	scheme := newServerTestScheme()
+	mcpServer := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "tenant-a"}}
+	operatorClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).WithStatusSubresource(mcpServer).Build()
+	c := deployAsClient{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), operator: operatorClient}
+
+	var got mcpv1alpha1.MCPServer
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(mcpServer), &got); err != nil {
+		t.Fatalf("expected the MCPServer to be read as the operator: %v", err)
+	}
+	got.Status.Phase = "Ready"
+	if err := c.Status().Update(context.Background(), &got); err != nil {
+		t.Fatalf("expected the status to be written as the operator: %v", err)
+	}
+}
+
+func TestDeployAsRejected(t *testing.T) {
+	scheme := newServerTestScheme()
+	impersonate := func(string) (client.Client, error) { return nil, errors.New("forbidden") }
+
+	for name, tc := range map[string]struct {
+		deployAs    string
+		require     bool
+		impersonate ImpersonatingClientFunc
+	}{
+		"required but missing": {require: true, impersonate: impersonate},
+		"invalid name":         {deployAs: "Not_Valid", impersonate: impersonate},
+		"impersonation off":    {deployAs: "mcp-deployer"},
+		"impersonation fails":  {deployAs: "mcp-deployer", impersonate: impersonate},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mcpServer := &mcpv1alpha1.MCPServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "tenant-a"},
+				Spec:       mcpv1alpha1.MCPServerSpec{Image: "test-image", DeployAs: tc.deployAs},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).WithStatusSubresource(mcpServer).Build()
+			r := MCPServerReconciler{Client: c, Scheme: scheme, ImpersonatingClient: tc.impersonate, RequireDeployAs: tc.require}
+
+			if _, err := r.deployAsReconciler(context.Background(), mcpServer, logr.Discard()); !errors.Is(err, ErrInvalidDeployAs) {
+				t.Fatalf("expected ErrInvalidDeployAs, got %v", err)
+			}
+			assertEqual(t, "phase", mcpServer.Status.Phase, "Error")
+		})
+	}
+}
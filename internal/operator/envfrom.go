@@ -0,0 +1,124 @@
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// AnnotationEnvChecksum on the pod template holds a checksum of the Secrets and ConfigMaps
+// in spec.envFrom. Pods only read envFrom at start, so a changed checksum rolls the
+// Deployment to pick up the new values.
+const AnnotationEnvChecksum = "mcpruntime.org/env-checksum"
+
+// LabelEnvSource marks Secrets and ConfigMaps referenced by spec.envFrom. The operator
+// caches Secrets and ConfigMaps outside its own namespaces only when they carry it, so
+// changes to unlabeled sources are picked up on the server's next reconcile instead of
+// right away. Synced copies carry it.
+const LabelEnvSource = "mcpruntime.org/env-source"
+
+// envFromChecksum hashes the data of the objects referenced by spec.envFrom, in spec order.
+// A missing object hashes as missing, so creating it later also triggers a rollout. It
+// returns "" when the server references nothing. The objects are read through the API
+// reader, since the cache does not cover every server namespace.
+func (r *MCPServerReconciler) envFromChecksum(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (string, error) {
+	if len(mcpServer.Spec.EnvFrom) == 0 {
+		return "", nil
+	}
+	h := sha256.New()
+	for _, source := range mcpServer.Spec.EnvFrom {
+		switch {
+		case source.SecretRef != nil:
+			var secret corev1.Secret
+			err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: mcpServer.Namespace, Name: source.SecretRef.Name}, &secret)
+			if err := hashEnvSource(h, "Secret", source.SecretRef.Name, err, func() {
+				for _, key := range sortedKeys(secret.Data) {
+					fmt.Fprintf(h, "%s=%x\n", key, secret.Data[key])
+				}
+			}); err != nil {
+				return "", err
+			}
+		case source.ConfigMapRef != nil:
+			var configMap corev1.ConfigMap
+			err := r.apiReader().Get(ctx, types.NamespacedName{Namespace: mcpServer.Namespace, Name: source.ConfigMapRef.Name}, &configMap)
+			if err := hashEnvSource(h, "ConfigMap", source.ConfigMapRef.Name, err, func() {
+				for _, key := range sortedKeys(configMap.Data) {
+					fmt.Fprintf(h, "%s=%q\n", key, configMap.Data[key])
+				}
+				for _, key := range sortedKeys(configMap.BinaryData) {
+					fmt.Fprintf(h, "%s=%x\n", key, configMap.BinaryData[key])
+				}
+			}); err != nil {
+				return "", err
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashEnvSource writes the header of one envFrom source and, when it was read, its data.
+func hashEnvSource(h hash.Hash, kind, name string, getErr error, writeData func()) error {
+	fmt.Fprintf(h, "%s/%s\n", kind, name)
+	if errors.IsNotFound(getErr) {
+		fmt.Fprintln(h, "missing")
+		return nil
+	}
+	if getErr != nil {
+		return fmt.Errorf("read envFrom %s %s: %w", kind, name, getErr)
+	}
+	writeData()
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// requestsForEnvFromSecret enqueues the servers in the Secret's namespace that list it in spec.envFrom.
+func (r *MCPServerReconciler) requestsForEnvFromSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.requestsForEnvFromSource(ctx, obj, func(source corev1.EnvFromSource) bool {
+		return source.SecretRef != nil && source.SecretRef.Name == obj.GetName()
+	})
+}
+
+// requestsForEnvFromConfigMap enqueues the servers in the ConfigMap's namespace that list it in spec.envFrom.
+func (r *MCPServerReconciler) requestsForEnvFromConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.requestsForEnvFromSource(ctx, obj, func(source corev1.EnvFromSource) bool {
+		return source.ConfigMapRef != nil && source.ConfigMapRef.Name == obj.GetName()
+	})
+}
+
+func (r *MCPServerReconciler) requestsForEnvFromSource(ctx context.Context, obj client.Object, references func(corev1.EnvFromSource) bool) []reconcile.Request {
+	var servers mcpv1alpha1.MCPServerList
+	if err := r.List(ctx, &servers, client.InNamespace(obj.GetNamespace())); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "Failed to list MCPServers to requeue", "trigger", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, s := range servers.Items {
+		for _, source := range s.Spec.EnvFrom {
+			if references(source) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: s.Namespace, Name: s.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,174 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func envFromTestServer(name string, sources ...corev1.EnvFromSource) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "test-image", EnvFrom: sources},
+	}
+}
+
+func secretEnv(name string) corev1.EnvFromSource {
+	return corev1.EnvFromSource{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}}
+}
+
+func configMapEnv(name string) corev1.EnvFromSource {
+	return corev1.EnvFromSource{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}}
+}
+
+func TestReconcileDeploymentEnvChecksum(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = appsv1.AddToScheme(scheme)
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "team-a"},
+		Data:       map[string][]byte{"TOKEN": []byte("v1")},
+	}
+	server := envFromTestServer("api", secretEnv("api-keys"), configMapEnv("settings"))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, secret).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	reconcileChecksum := func() string {
+		t.Helper()
+		if err := r.reconcileDeployment(ctx, server); err != nil {
+			t.Fatalf("reconcileDeployment() error: %v", err)
+		}
+		var deployment appsv1.Deployment
+		if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "api"}, &deployment); err != nil {
+			t.Fatalf("get deployment: %v", err)
+		}
+		if got := deployment.Spec.Template.Spec.Containers[0].EnvFrom; len(got) != 2 {
+			t.Fatalf("expected envFrom on the container, got %+v", got)
+		}
+		return deployment.Spec.Template.Annotations[AnnotationEnvChecksum]
+	}
+
+	initial := reconcileChecksum()
+	if initial == "" {
+		t.Fatal("expected an env checksum annotation")
+	}
+	if again := reconcileChecksum(); again != initial {
+		t.Fatalf("checksum changed without a data change: %s != %s", again, initial)
+	}
+
+	// Creating the missing ConfigMap changes the checksum.
+	if err := c.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "team-a"},
+		Data:       map[string]string{"REGION": "eu"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	withConfigMap := reconcileChecksum()
+	if withConfigMap == initial {
+		t.Fatal("expected the checksum to change once the ConfigMap exists")
+	}
+
+	// Updating the Secret changes it again.
+	var stored corev1.Secret
+	if err := c.Get(ctx, client.ObjectKeyFromObject(secret), &stored); err != nil {
+		t.Fatal(err)
+	}
+	stored.Data["TOKEN"] = []byte("v2")
+	if err := c.Update(ctx, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if rotated := reconcileChecksum(); rotated == withConfigMap {
+		t.Fatal("expected the checksum to change after the Secret was updated")
+	}
+}
+
+// namespaceCacheClient serves Secrets and ConfigMaps only from the namespaces it caches, like
+// the manager's cache when the operator runs with a sync or maintenance namespace.
+type namespaceCacheClient struct {
+	client.Client
+	namespaces map[string]bool
+}
+
+func (c namespaceCacheClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	switch obj.(type) {
+	case *corev1.Secret, *corev1.ConfigMap:
+		if !c.namespaces[key.Namespace] {
+			return fmt.Errorf("unable to get: %s because of unknown namespace for the cache", key)
+		}
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestEnvFromChecksumWithNamespaceRestrictedCache(t *testing.T) {
+	scheme := newHealthTestScheme()
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "team-a"},
+		Data:       map[string][]byte{"TOKEN": []byte("v1")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	r := MCPServerReconciler{
+		Client:    namespaceCacheClient{Client: c, namespaces: map[string]bool{"mcp-runtime": true}},
+		APIReader: c,
+		Scheme:    scheme,
+	}
+	server := envFromTestServer("api", secretEnv("api-keys"))
+
+	initial, err := r.envFromChecksum(ctx, server)
+	if err != nil {
+		t.Fatalf("envFromChecksum() error: %v", err)
+	}
+	secret.Data["TOKEN"] = []byte("v2")
+	if err := c.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := r.envFromChecksum(ctx, server)
+	if err != nil {
+		t.Fatalf("envFromChecksum() error: %v", err)
+	}
+	if rotated == initial {
+		t.Fatal("expected the checksum to follow a Secret outside the cached namespaces")
+	}
+}
+
+func TestEnvFromChecksumWithoutSources(t *testing.T) {
+	scheme := newHealthTestScheme()
+	r := MCPServerReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	checksum, err := r.envFromChecksum(context.Background(), envFromTestServer("api"))
+	if err != nil || checksum != "" {
+		t.Fatalf("expected no checksum, got %q, %v", checksum, err)
+	}
+}
+
+func TestRequestsForEnvFromSource(t *testing.T) {
+	scheme := newHealthTestScheme()
+	other := envFromTestServer("other", configMapEnv("api-keys"))
+	elsewhere := envFromTestServer("api")
+	elsewhere.Namespace = "team-b"
+	elsewhere.Spec.EnvFrom = []corev1.EnvFromSource{secretEnv("api-keys")}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		envFromTestServer("api", secretEnv("api-keys")), other, elsewhere,
+	).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "team-a"}}
+	requests := r.requestsForEnvFromSecret(context.Background(), secret)
+	if len(requests) != 1 || requests[0].Name != "api" || requests[0].Namespace != "team-a" {
+		t.Fatalf("expected only team-a/api, got %v", requests)
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "team-a"}}
+	requests = r.requestsForEnvFromConfigMap(context.Background(), configMap)
+	if len(requests) != 1 || requests[0].Name != "other" {
+		t.Fatalf("expected only team-a/other, got %v", requests)
+	}
+}
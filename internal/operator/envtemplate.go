@@ -0,0 +1,140 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// envTemplateRe matches a template field such as {{ .PodIP }} in an env var value.
+var envTemplateRe = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+
+// envRuntimeField is a template field only known once the pod runs, resolved
+// through the downward API.
+type envRuntimeField struct {
+	fieldPath string
+	// helperEnv holds the field for values that embed it in other text; the value
+	// then references it as $(helperEnv), which the kubelet expands.
+	helperEnv string
+}
+
+// envRuntimeFields are the downward API template fields.
+var envRuntimeFields = map[string]envRuntimeField{
+	"PodIP":    {fieldPath: "status.podIP", helperEnv: "MCP_RUNTIME_POD_IP"},
+	"PodName":  {fieldPath: "metadata.name", helperEnv: "MCP_RUNTIME_POD_NAME"},
+	"NodeName": {fieldPath: "spec.nodeName", helperEnv: "MCP_RUNTIME_NODE_NAME"},
+	"HostIP":   {fieldPath: "status.hostIP", helperEnv: "MCP_RUNTIME_HOST_IP"},
+}
+
+// envStaticFields are template fields known at reconcile time, expanded to literals.
+func envStaticFields(mcpServer *mcpv1alpha1.MCPServer) map[string]string {
+	return map[string]string{
+		"Namespace":  mcpServer.Namespace,
+		"ServerName": mcpServer.Name,
+	}
+}
+
+// buildEnvVars converts spec.envVars to container env vars, expanding templates.
+// A value that is exactly one runtime field becomes a downward API fieldRef; runtime
+// fields inside other text go through helper env vars defined ahead of the user's.
+func (r *MCPServerReconciler) buildEnvVars(mcpServer *mcpv1alpha1.MCPServer) ([]corev1.EnvVar, error) {
+	static := envStaticFields(mcpServer)
+	var helpers []corev1.EnvVar
+	helperAdded := map[string]bool{}
+	result := make([]corev1.EnvVar, 0, len(mcpServer.Spec.EnvVars))
+
+	for _, ev := range mcpServer.Spec.EnvVars {
+		if m := envTemplateRe.FindStringSubmatch(strings.TrimSpace(ev.Value)); m != nil && m[0] == strings.TrimSpace(ev.Value) {
+			if field, ok := envRuntimeFields[m[1]]; ok {
+				result = append(result, fieldRefEnvVar(ev.Name, field.fieldPath))
+				continue
+			}
+		}
+
+		var unknown string
+		value := envTemplateRe.ReplaceAllStringFunc(ev.Value, func(match string) string {
+			name := envTemplateRe.FindStringSubmatch(match)[1]
+			if literal, ok := static[name]; ok {
+				return literal
+			}
+			if field, ok := envRuntimeFields[name]; ok {
+				if !helperAdded[field.helperEnv] {
+					helperAdded[field.helperEnv] = true
+					helpers = append(helpers, fieldRefEnvVar(field.helperEnv, field.fieldPath))
+				}
+				return "$(" + field.helperEnv + ")"
+			}
+			if unknown == "" {
+				unknown = name
+			}
+			return match
+		})
+		if unknown != "" {
+			contextMap := map[string]any{"env": ev.Name, "field": unknown}
+			return nil, wrapOperatorError(
+				fmt.Errorf("%w: unknown field %q", ErrInvalidEnvTemplate, unknown),
+				fmt.Sprintf("invalid template in env var %s", ev.Name),
+				contextMap,
+			)
+		}
+		result = append(result, corev1.EnvVar{Name: ev.Name, Value: value})
+	}
+	return append(helpers, result...), nil
+}
+
+// validateEnvTemplates rejects env var templates with unknown fields before any resource
+// is rendered from them.
+func (r *MCPServerReconciler) validateEnvTemplates(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	static := envStaticFields(mcpServer)
+	for _, ev := range mcpServer.Spec.EnvVars {
+		for _, m := range envTemplateRe.FindAllStringSubmatch(ev.Value, -1) {
+			if _, ok := static[m[1]]; ok {
+				continue
+			}
+			if _, ok := envRuntimeFields[m[1]]; ok {
+				continue
+			}
+			message := fmt.Sprintf("spec.envVars %s: unknown template field %q (use one of %s)", ev.Name, m[1], strings.Join(envTemplateFieldNames(static), ", "))
+			contextMap := map[string]any{
+				"mcpServer": mcpServer.Name,
+				"namespace": mcpServer.Namespace,
+				"env":       ev.Name,
+				"field":     m[1],
+			}
+			err := wrapOperatorError(fmt.Errorf("%w: %s", ErrInvalidEnvTemplate, message), "Invalid env var template", contextMap)
+			r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+			logOperatorError(logger, err, "Invalid env var template")
+			return err
+		}
+	}
+	return nil
+}
+
+// envTemplateFieldNames returns the sorted names of every template field.
+func envTemplateFieldNames(static map[string]string) []string {
+	names := make([]string, 0, len(static)+len(envRuntimeFields))
+	for name := range static {
+		names = append(names, name)
+	}
+	for name := range envRuntimeFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func fieldRefEnvVar(name, fieldPath string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: fieldPath},
+		},
+	}
+}
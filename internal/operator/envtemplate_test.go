@@ -0,0 +1,113 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestBuildEnvVarsTemplates(t *testing.T) {
+	newServer := func(envVars ...mcpv1alpha1.EnvVar) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "team-a"},
+			Spec:       mcpv1alpha1.MCPServerSpec{EnvVars: envVars},
+		}
+	}
+	r := MCPServerReconciler{}
+
+	t.Run("runtime field becomes a fieldRef", func(t *testing.T) {
+		envVars, err := r.buildEnvVars(newServer(mcpv1alpha1.EnvVar{Name: "POD_IP", Value: "{{ .PodIP }}"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertEqual(t, "len", len(envVars), 1)
+		assertEqual(t, "value", envVars[0].Value, "")
+		if envVars[0].ValueFrom == nil || envVars[0].ValueFrom.FieldRef == nil {
+			t.Fatal("expected a fieldRef")
+		}
+		assertEqual(t, "fieldPath", envVars[0].ValueFrom.FieldRef.FieldPath, "status.podIP")
+	})
+
+	t.Run("static fields become literals", func(t *testing.T) {
+		envVars, err := r.buildEnvVars(newServer(mcpv1alpha1.EnvVar{Name: "ID", Value: "{{.ServerName}}.{{ .Namespace }}"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertEqual(t, "len", len(envVars), 1)
+		assertEqual(t, "value", envVars[0].Value, "weather.team-a")
+	})
+
+	t.Run("embedded runtime fields use helper env vars", func(t *testing.T) {
+		envVars, err := r.buildEnvVars(newServer(
+			mcpv1alpha1.EnvVar{Name: "ADVERTISE_URL", Value: "http://{{ .PodIP }}:8088/{{ .ServerName }}"},
+			mcpv1alpha1.EnvVar{Name: "BIND", Value: "{{ .PodIP }}:9090"},
+		))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertEqual(t, "len", len(envVars), 3)
+		assertEqual(t, "helper", envVars[0].Name, "MCP_RUNTIME_POD_IP")
+		assertEqual(t, "helper fieldPath", envVars[0].ValueFrom.FieldRef.FieldPath, "status.podIP")
+		assertEqual(t, "advertise", envVars[1].Value, "http://$(MCP_RUNTIME_POD_IP):8088/weather")
+		assertEqual(t, "bind", envVars[2].Value, "$(MCP_RUNTIME_POD_IP):9090")
+	})
+
+	t.Run("unknown field is rejected", func(t *testing.T) {
+		_, err := r.buildEnvVars(newServer(mcpv1alpha1.EnvVar{Name: "X", Value: "{{ .Secret }}"}))
+		if !errors.Is(err, ErrInvalidEnvTemplate) {
+			t.Fatalf("expected ErrInvalidEnvTemplate, got %v", err)
+		}
+	})
+}
+
+func TestReconcileRejectsInvalidEnvTemplate(t *testing.T) {
+	scheme := newHealthTestScheme()
+	replicas := int32(1)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "team-a"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:        "registry.local/weather",
+			ImageTag:     "v1",
+			Port:         8088,
+			ServicePort:  80,
+			Replicas:     &replicas,
+			IngressHost:  "example.com",
+			IngressPath:  "/weather/mcp",
+			IngressClass: "traefik",
+			EnvVars:      []mcpv1alpha1.EnvVar{{Name: "TOKEN", Value: "{{ .Secret }}"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "weather", Namespace: "team-a"}}
+
+	_, err := r.Reconcile(context.Background(), request)
+	if !errors.Is(err, ErrInvalidEnvTemplate) {
+		t.Fatalf("expected ErrInvalidEnvTemplate, got %v", err)
+	}
+	if !errors.Is(err, reconcile.TerminalError(nil)) {
+		t.Fatalf("expected an invalid template to be terminal, got %v", err)
+	}
+	updated := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(context.Background(), request.NamespacedName, updated); err != nil {
+		t.Fatalf("get MCPServer: %v", err)
+	}
+	assertEqual(t, "phase", updated.Status.Phase, "Error")
+	if !strings.Contains(updated.Status.Message, `unknown template field "Secret"`) {
+		t.Fatalf("expected the template error in the status message, got %q", updated.Status.Message)
+	}
+	if err := c.Get(context.Background(), request.NamespacedName, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected no Deployment for an invalid template, got %v", err)
+	}
+}
@@ -19,8 +19,28 @@ var (
 	ErrApplyDefaults       = fmt.Errorf("failed to apply defaults")
 
 	// Validation errors.
-	ErrMissingIngressHost = fmt.Errorf("missing ingress host")
-	ErrMissingIngressPath = fmt.Errorf("missing ingress path")
+	ErrMissingIngressHost     = fmt.Errorf("missing ingress host")
+	ErrMissingIngressPath     = fmt.Errorf("missing ingress path")
+	ErrCanaryUnsupported      = fmt.Errorf("canary not supported by ingress class")
+	ErrInvalidMirror          = fmt.Errorf("invalid mirror configuration")
+	ErrMirrorTargetNotFound   = fmt.Errorf("mirror target not found")
+	ErrInvalidEnvTemplate     = fmt.Errorf("invalid env var template")
+	ErrInvalidAuth            = fmt.Errorf("invalid auth configuration")
+	ErrAuthSecretNotReady     = fmt.Errorf("auth secret not ready")
+	ErrInvalidIngressPath     = fmt.Errorf("invalid ingress path")
+	ErrInvalidImageVariant    = fmt.Errorf("invalid image variant")
+	ErrInvalidDeployAs        = fmt.Errorf("invalid deployAs")
+	ErrInvalidIPFamilies      = fmt.Errorf("invalid IP families")
+	ErrInvalidIngressProvider = fmt.Errorf("invalid ingress provider")
+
+	// Secret sync errors.
+	ErrSecretSyncDisabled   = fmt.Errorf("secret sync disabled")
+	ErrSyncTargetConflict   = fmt.Errorf("sync target not managed by the operator")
+	ErrSyncSourceNotAllowed = fmt.Errorf("sync source not shared")
+
+	// Supply-chain errors.
+	ErrImageSignatureInvalid    = fmt.Errorf("image signature invalid")
+	ErrImageVerifierUnavailable = fmt.Errorf("image signature verifier unavailable")
 
 	// Resource errors.
 	ErrInvalidCPURequest    = fmt.Errorf("invalid CPU request")
@@ -0,0 +1,29 @@
+package operator
+
+import (
+	"strconv"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// AnnotationSafeToEvict tells the cluster autoscaler whether it may evict a pod to
+// remove an underused node.
+const AnnotationSafeToEvict = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// safeToEvict returns spec.safeToEvict, falling back to the operator default. Nil leaves
+// the decision to the autoscaler's own rules.
+func (r *MCPServerReconciler) safeToEvict(mcpServer *mcpv1alpha1.MCPServer) *bool {
+	if mcpServer.Spec.SafeToEvict != nil {
+		return mcpServer.Spec.SafeToEvict
+	}
+	return r.DefaultSafeToEvict
+}
+
+// podAnnotations returns the annotations of the server's pod template.
+func (r *MCPServerReconciler) podAnnotations(mcpServer *mcpv1alpha1.MCPServer) map[string]string {
+	safe := r.safeToEvict(mcpServer)
+	if safe == nil {
+		return nil
+	}
+	return map[string]string{AnnotationSafeToEvict: strconv.FormatBool(*safe)}
+}
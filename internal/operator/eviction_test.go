@@ -0,0 +1,36 @@
+package operator
+
+import (
+	"testing"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestPodAnnotationsSafeToEvict(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name        string
+		spec        *bool
+		defaultSafe *bool
+		want        string
+	}{
+		{name: "unset everywhere adds nothing"},
+		{name: "operator default", defaultSafe: &yes, want: "true"},
+		{name: "spec overrides default", spec: &no, defaultSafe: &yes, want: "false"},
+		{name: "spec without default", spec: &yes, want: "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &MCPServerReconciler{DefaultSafeToEvict: tt.defaultSafe}
+			server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{SafeToEvict: tt.spec}}
+			annotations := r.podAnnotations(server)
+			if tt.want == "" {
+				if annotations != nil {
+					t.Fatalf("expected no annotations, got %v", annotations)
+				}
+				return
+			}
+			assertEqual(t, "safe-to-evict", annotations[AnnotationSafeToEvict], tt.want)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package operator
+
+import (
+	"strconv"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// AnnotationExternalDNS marks the Ingresses of servers with spec.externalDNS enabled. The
+// external-dns deployment of "setup --with-external-dns" only reads Ingresses carrying it
+// (--annotation-filter), so Ingresses of other workloads get no records.
+const AnnotationExternalDNS = "mcpruntime.org/external-dns"
+
+// external-dns annotations read from Ingresses by its ingress source.
+const (
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	externalDNSTTLAnnotation      = "external-dns.alpha.kubernetes.io/ttl"
+	externalDNSTargetAnnotation   = "external-dns.alpha.kubernetes.io/target"
+)
+
+// externalDNSAnnotations returns the external-dns annotations for mcpServer's
+// Ingress, or nil when spec.externalDNS is not enabled.
+func externalDNSAnnotations(mcpServer *mcpv1alpha1.MCPServer) map[string]string {
+	spec := mcpServer.Spec.ExternalDNS
+	if spec == nil || !spec.Enabled {
+		return nil
+	}
+	annotations := map[string]string{AnnotationExternalDNS: "true"}
+	if host := effectiveIngressHost(mcpServer); host != "" {
+		annotations[externalDNSHostnameAnnotation] = host
+	}
+	if spec.TTL > 0 {
+		annotations[externalDNSTTLAnnotation] = strconv.Itoa(int(spec.TTL))
+	}
+	if spec.Target != "" {
+		annotations[externalDNSTargetAnnotation] = spec.Target
+	}
+	return annotations
+}
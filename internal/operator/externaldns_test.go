@@ -0,0 +1,44 @@
+package operator
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestExternalDNSAnnotations(t *testing.T) {
+	newServer := func(spec *mcpv1alpha1.ExternalDNSSpec) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "default"},
+			Spec:       mcpv1alpha1.MCPServerSpec{IngressHost: "mcp.example.com", ExternalDNS: spec},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		if got := externalDNSAnnotations(newServer(nil)); got != nil {
+			t.Fatalf("expected no annotations, got %v", got)
+		}
+		if got := externalDNSAnnotations(newServer(&mcpv1alpha1.ExternalDNSSpec{TTL: 60})); got != nil {
+			t.Fatalf("expected no annotations when not enabled, got %v", got)
+		}
+	})
+
+	t.Run("hostname only", func(t *testing.T) {
+		got := externalDNSAnnotations(newServer(&mcpv1alpha1.ExternalDNSSpec{Enabled: true}))
+		assertEqual(t, "hostname", got[externalDNSHostnameAnnotation], "mcp.example.com")
+		assertEqual(t, "filter", got[AnnotationExternalDNS], "true")
+		assertEqual(t, "annotations", len(got), 2)
+	})
+
+	t.Run("ttl and target", func(t *testing.T) {
+		r := &MCPServerReconciler{}
+		server := newServer(&mcpv1alpha1.ExternalDNSSpec{Enabled: true, TTL: 300, Target: "lb.example.com"})
+		got := r.buildIngressAnnotations(server)
+		assertEqual(t, "hostname", got[externalDNSHostnameAnnotation], "mcp.example.com")
+		assertEqual(t, "ttl", got[externalDNSTTLAnnotation], "300")
+		assertEqual(t, "target", got[externalDNSTargetAnnotation], "lb.example.com")
+		assertEqual(t, "filter", got[AnnotationExternalDNS], "true")
+	})
+}
@@ -0,0 +1,166 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// httpRouteGVK is the Gateway API HTTPRoute kind. The operator does not depend on the
+// Gateway API Go types, so HTTPRoutes are handled as unstructured objects.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// gatewayAPIProvider exposes servers through an HTTPRoute attached to the operator's
+// Gateway (MCP_GATEWAY).
+type gatewayAPIProvider struct{}
+
+func (gatewayAPIProvider) Name() string { return IngressProviderGatewayAPI }
+
+// Unsupported lists the features configured through ingress controller annotations,
+// which HTTPRoutes have no equivalent of here.
+func (gatewayAPIProvider) Unsupported(mcpServer *mcpv1alpha1.MCPServer) []string {
+	var fields []string
+	if canaryEnabled(mcpServer) {
+		fields = append(fields, "spec.canary")
+	}
+	if mirrorEnabled(mcpServer) {
+		fields = append(fields, "spec.mirror")
+	}
+	if authEnabled(mcpServer) {
+		fields = append(fields, "spec.auth")
+	}
+	if mcpServer.Spec.SessionAffinity == SessionAffinityCookie {
+		fields = append(fields, "spec.sessionAffinity cookie")
+	}
+	return fields
+}
+
+func (gatewayAPIProvider) Reconcile(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) error {
+	logger := log.FromContext(ctx)
+
+	if r.Gateway == "" {
+		return fmt.Errorf("%w: the gateway-api ingress provider needs the operator's MCP_GATEWAY (namespace/name of the Gateway routes attach to)", ErrInvalidIngressProvider)
+	}
+	spec := httpRouteSpec(mcpServer, r.Gateway)
+
+	route := newHTTPRoute(mcpServer)
+	op, err := ctrl.CreateOrUpdate(ctx, r.Client, route, func() error {
+		route.SetLabels(map[string]string{LabelApp: mcpServer.Name, LabelManagedBy: LabelManagedByValue})
+		route.SetAnnotations(externalDNSAnnotations(mcpServer))
+		if err := unstructured.SetNestedMap(route.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return ctrl.SetControllerReference(mcpServer, route, r.Scheme)
+	})
+	if meta.IsNoMatchError(err) {
+		return fmt.Errorf("%w: the gateway-api ingress provider needs the Gateway API CRDs (gateway.networking.k8s.io/v1)", ErrInvalidIngressProvider)
+	}
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("HTTPRoute reconciled", "operation", op, "name", route.GetName())
+	}
+	return nil
+}
+
+// Ready reports whether a Gateway has accepted the HTTPRoute.
+func (gatewayAPIProvider) Ready(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
+	route := newHTTPRoute(mcpServer)
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, route); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for _, parent := range parents {
+		parentMap, ok := parent.(map[string]any)
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(parentMap, "conditions")
+		for _, condition := range conditions {
+			conditionMap, ok := condition.(map[string]any)
+			if ok && conditionMap["type"] == "Accepted" && conditionMap["status"] == "True" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (gatewayAPIProvider) Cleanup(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) error {
+	route := newHTTPRoute(mcpServer)
+	if err := r.Delete(ctx, route); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	log.FromContext(ctx).Info("HTTPRoute deleted", "name", route.GetName())
+	return nil
+}
+
+func newHTTPRoute(mcpServer *mcpv1alpha1.MCPServer) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(mcpServer.Name)
+	route.SetNamespace(mcpServer.Namespace)
+	return route
+}
+
+// httpRouteSpec renders the HTTPRoute spec sending the server's host and path to its
+// Service. gateway is "namespace/name", or a name in the server's namespace.
+func httpRouteSpec(mcpServer *mcpv1alpha1.MCPServer, gateway string) map[string]any {
+	parentRef := map[string]any{"name": gateway}
+	if namespace, name, ok := strings.Cut(gateway, "/"); ok {
+		parentRef = map[string]any{"namespace": namespace, "name": name}
+	}
+
+	rule := map[string]any{
+		"matches": []any{
+			map[string]any{"path": map[string]any{"type": "PathPrefix", "value": mcpServer.Spec.IngressPath}},
+		},
+		"backendRefs": []any{
+			map[string]any{"name": mcpServer.Name, "port": int64(mcpServer.Spec.ServicePort)},
+		},
+	}
+	if stripPrefixEnabled(mcpServer) {
+		rule["filters"] = []any{
+			map[string]any{
+				"type": "URLRewrite",
+				"urlRewrite": map[string]any{
+					"path": map[string]any{"type": "ReplacePrefixMatch", "replacePrefixMatch": "/"},
+				},
+			},
+		}
+	}
+
+	spec := map[string]any{
+		"parentRefs": []any{parentRef},
+		"rules":      []any{rule},
+	}
+	if host := effectiveIngressHost(mcpServer); host != "" {
+		spec["hostnames"] = []any{host}
+	}
+	return spec
+}
+
+// httpRoutesAvailable reports whether the cluster serves the HTTPRoute kind, so the
+// controller only watches HTTPRoutes where they exist.
+func httpRoutesAvailable(mapper meta.RESTMapper) bool {
+	_, err := mapper.RESTMapping(httpRouteGVK.GroupKind(), httpRouteGVK.Version)
+	return err == nil
+}
@@ -0,0 +1,185 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// failingWaitingReasons are container waiting reasons that will not resolve
+// without user intervention (bad image, crashing process, broken config).
+var failingWaitingReasons = map[string]bool{
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// deploymentFailure describes why a Deployment is not making progress.
+type deploymentFailure struct {
+	Reason  string
+	Message string
+}
+
+// diagnoseDeployment inspects the conditions of the server's Deployments and the
+// containers of its pods and returns the first concrete failure found, or nil if
+// nothing is known to be wrong (the rollout may simply still be in progress).
+func (r *MCPServerReconciler) diagnoseDeployment(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*deploymentFailure, error) {
+	var deployments []appsv1.Deployment
+	for _, name := range serverDeploymentNames(mcpServer) {
+		deployment := appsv1.Deployment{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: mcpServer.Namespace}, &deployment); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		deployments = append(deployments, deployment)
+	}
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+
+	// Pod-level reasons are more actionable than the deployment-level
+	// ones, so check them first.
+	failure, err := r.diagnosePods(ctx, mcpServer)
+	if err != nil || failure != nil {
+		return failure, err
+	}
+
+	for _, deployment := range deployments {
+		for _, cond := range deployment.Status.Conditions {
+			switch {
+			case cond.Type == appsv1.DeploymentReplicaFailure && cond.Status == corev1.ConditionTrue:
+				return &deploymentFailure{Reason: cond.Reason, Message: cond.Message}, nil
+			case cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse:
+				return &deploymentFailure{Reason: cond.Reason, Message: cond.Message}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// diagnosePods looks for containers stuck in a failing waiting state in the
+// pods owned by the MCPServer deployment.
+func (r *MCPServerReconciler) diagnosePods(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*deploymentFailure, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(mcpServer.Namespace),
+		client.MatchingLabels{LabelApp: mcpServer.Name},
+	); err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		pod := pod
+		statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if cs.State.Waiting == nil || !failingWaitingReasons[cs.State.Waiting.Reason] {
+				continue
+			}
+			waiting := cs.State.Waiting
+			detail := waiting.Message
+			message := fmt.Sprintf("pod %s container %s: %s", pod.Name, cs.Name, waiting.Reason)
+			if waiting.Reason == "ErrImagePull" || waiting.Reason == imagePullBackOff {
+				detail = r.pullErrorMessage(ctx, &pod, waiting)
+				if kind := classifyPullError(detail); kind != "" {
+					message += " (" + kind + ")"
+				}
+			}
+			if detail != "" {
+				message += ": " + detail
+			}
+			return &deploymentFailure{Reason: cs.State.Waiting.Reason, Message: message}, nil
+		}
+	}
+	return nil, nil
+}
+
+// setCondition adds or updates a condition, only bumping LastTransitionTime
+// when the status actually changes.
+func setCondition(conditions *[]mcpv1alpha1.Condition, condition mcpv1alpha1.Condition) {
+	for i := range *conditions {
+		existing := &(*conditions)[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status != condition.Status {
+			existing.Status = condition.Status
+			existing.LastTransitionTime = metav1.Now()
+		}
+		existing.Reason = condition.Reason
+		existing.Message = condition.Message
+		return
+	}
+	condition.LastTransitionTime = metav1.Now()
+	*conditions = append(*conditions, condition)
+}
+
+// findCondition returns the condition with the given type, or nil.
+func findCondition(conditions []mcpv1alpha1.Condition, conditionType string) *mcpv1alpha1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// setDegradedCondition records the deployment failure (or its absence) on the
+// MCPServer status.
+func setDegradedCondition(mcpServer *mcpv1alpha1.MCPServer, failure *deploymentFailure) {
+	if failure == nil {
+		setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+			Type:   ConditionDegraded,
+			Status: metav1.ConditionFalse,
+			Reason: ReasonHealthy,
+		})
+		return
+	}
+	setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+		Type:    ConditionDegraded,
+		Status:  metav1.ConditionTrue,
+		Reason:  failure.Reason,
+		Message: failure.Message,
+	})
+}
+
+// setReadyCondition records whether all child resources are ready. A server that
+// is not ready uses its phase (Pending, PartiallyReady, Suspended, Error) as reason.
+func setReadyCondition(mcpServer *mcpv1alpha1.MCPServer, phase, message string, ready bool) {
+	if ready {
+		setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+			Type:   ConditionReady,
+			Status: metav1.ConditionTrue,
+			Reason: ReasonResourcesReady,
+		})
+		return
+	}
+	setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+		Type:    ConditionReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  phase,
+		Message: message,
+	})
+}
+
+// markObservedGeneration stamps the status and its conditions with the current generation.
+func markObservedGeneration(mcpServer *mcpv1alpha1.MCPServer) {
+	mcpServer.Status.ObservedGeneration = mcpServer.Generation
+	for i := range mcpServer.Status.Conditions {
+		mcpServer.Status.Conditions[i].ObservedGeneration = mcpServer.Generation
+	}
+}
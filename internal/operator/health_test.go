@@ -0,0 +1,180 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newHealthTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = mcpv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+// newServerTestScheme extends newHealthTestScheme with the Ingress a full server reconcile writes.
+func newServerTestScheme() *runtime.Scheme {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	return scheme
+}
+
+func TestDiagnoseDeployment(t *testing.T) {
+	scheme := newHealthTestScheme()
+	mcpServer := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+	}
+	deployment := func(conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"},
+			Status:     appsv1.DeploymentStatus{Conditions: conditions},
+		}
+	}
+
+	t.Run("returns nil when deployment does not exist", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		failure, err := r.diagnoseDeployment(context.Background(), mcpServer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failure != nil {
+			t.Fatalf("expected no failure, got %+v", failure)
+		}
+	})
+
+	t.Run("reports progress deadline exceeded", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment(appsv1.DeploymentCondition{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ProgressDeadlineExceeded",
+			Message: "ReplicaSet has timed out progressing.",
+		})).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		failure, err := r.diagnoseDeployment(context.Background(), mcpServer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failure == nil {
+			t.Fatal("expected failure, got nil")
+		}
+		assertEqual(t, "reason", failure.Reason, "ProgressDeadlineExceeded")
+	})
+
+	t.Run("reports replica failure", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment(appsv1.DeploymentCondition{
+			Type:    appsv1.DeploymentReplicaFailure,
+			Status:  corev1.ConditionTrue,
+			Reason:  "FailedCreate",
+			Message: "exceeded quota",
+		})).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		failure, err := r.diagnoseDeployment(context.Background(), mcpServer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failure == nil {
+			t.Fatal("expected failure, got nil")
+		}
+		assertEqual(t, "reason", failure.Reason, "FailedCreate")
+		assertEqual(t, "message", failure.Message, "exceeded quota")
+	})
+
+	t.Run("prefers container waiting reason from owned pods", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-server-abc",
+				Namespace: "default",
+				Labels:    map[string]string{LabelApp: "test-server"},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "test-server",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image",
+					}},
+				}},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment(appsv1.DeploymentCondition{
+			Type:   appsv1.DeploymentProgressing,
+			Status: corev1.ConditionFalse,
+			Reason: "ProgressDeadlineExceeded",
+		}), pod).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		failure, err := r.diagnoseDeployment(context.Background(), mcpServer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failure == nil {
+			t.Fatal("expected failure, got nil")
+		}
+		assertEqual(t, "reason", failure.Reason, "ImagePullBackOff")
+		if !strings.Contains(failure.Message, "test-server-abc") {
+			t.Fatalf("message %q should mention the pod name", failure.Message)
+		}
+	})
+
+	t.Run("ignores transient waiting reasons", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-server-abc",
+				Namespace: "default",
+				Labels:    map[string]string{LabelApp: "test-server"},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "test-server",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+				}},
+			},
+		}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment(), pod).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		failure, err := r.diagnoseDeployment(context.Background(), mcpServer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if failure != nil {
+			t.Fatalf("expected no failure, got %+v", failure)
+		}
+	})
+}
+
+func TestSetDegradedCondition(t *testing.T) {
+	t.Run("sets degraded true with failure reason", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{}
+		setDegradedCondition(mcpServer, &deploymentFailure{Reason: "CrashLoopBackOff", Message: "back-off restarting"})
+		cond := findCondition(mcpServer.Status.Conditions, ConditionDegraded)
+		if cond == nil {
+			t.Fatal("expected Degraded condition")
+		}
+		assertEqual(t, "status", cond.Status, metav1.ConditionTrue)
+		assertEqual(t, "reason", cond.Reason, "CrashLoopBackOff")
+	})
+
+	t.Run("keeps transition time when status is unchanged", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{}
+		setDegradedCondition(mcpServer, nil)
+		first := findCondition(mcpServer.Status.Conditions, ConditionDegraded).LastTransitionTime
+		setDegradedCondition(mcpServer, nil)
+		cond := findCondition(mcpServer.Status.Conditions, ConditionDegraded)
+		assertEqual(t, "status", cond.Status, metav1.ConditionFalse)
+		assertEqual(t, "conditions", len(mcpServer.Status.Conditions), 1)
+		if !cond.LastTransitionTime.Equal(&first) {
+			t.Fatalf("lastTransitionTime changed from %v to %v", first, cond.LastTransitionTime)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package operator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// recordRolledOutRevision records the server's image as a known-good revision once it is
+// Ready and its Deployments have fully rolled out. Ready alone is not enough: the old pods
+// keep the Deployment ready while a broken new image fails, and recording it then would
+// make it a rollback target.
+func recordRolledOutRevision(mcpServer *mcpv1alpha1.MCPServer, phase string) {
+	if phase == "Ready" && mcpServer.Status.RolloutComplete {
+		recordRevision(mcpServer)
+	}
+}
+
+// recordRevision appends the server's current image to status.history when it
+// differs from the latest entry, keeping at most MaxRevisionHistory entries.
+func recordRevision(mcpServer *mcpv1alpha1.MCPServer) {
+	history := mcpServer.Status.History
+	var next int64 = 1
+	if n := len(history); n > 0 {
+		latest := history[n-1]
+		if latest.Image == mcpServer.Spec.Image && latest.ImageTag == mcpServer.Spec.ImageTag {
+			return
+		}
+		next = latest.Revision + 1
+	}
+	history = append(history, mcpv1alpha1.Revision{
+		Revision: next,
+		Image:    mcpServer.Spec.Image,
+		ImageTag: mcpServer.Spec.ImageTag,
+		ReadyAt:  metav1.Now(),
+	})
+	if len(history) > MaxRevisionHistory {
+		history = history[len(history)-MaxRevisionHistory:]
+	}
+	mcpServer.Status.History = history
+}
@@ -0,0 +1,54 @@
+package operator
+
+import (
+	"testing"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestRecordRevision(t *testing.T) {
+	t.Run("appends new images only", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{Image: "repo/app", ImageTag: "v1"}}
+		recordRevision(mcpServer)
+		recordRevision(mcpServer)
+		assertEqual(t, "history length", len(mcpServer.Status.History), 1)
+
+		mcpServer.Spec.ImageTag = "v2"
+		recordRevision(mcpServer)
+		assertEqual(t, "history length", len(mcpServer.Status.History), 2)
+		assertEqual(t, "revision", mcpServer.Status.History[1].Revision, int64(2))
+		assertEqual(t, "tag", mcpServer.Status.History[1].ImageTag, "v2")
+	})
+
+	t.Run("keeps the last MaxRevisionHistory entries", func(t *testing.T) {
+		mcpServer := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{Image: "repo/app"}}
+		for i := 0; i < MaxRevisionHistory+3; i++ {
+			mcpServer.Spec.ImageTag = string(rune('a' + i))
+			recordRevision(mcpServer)
+		}
+		assertEqual(t, "history length", len(mcpServer.Status.History), MaxRevisionHistory)
+		assertEqual(t, "oldest revision", mcpServer.Status.History[0].Revision, int64(4))
+		assertEqual(t, "latest revision", mcpServer.Status.History[MaxRevisionHistory-1].Revision, int64(MaxRevisionHistory+3))
+	})
+}
+
+func TestRecordRolledOutRevision(t *testing.T) {
+	mcpServer := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{Image: "repo/app", ImageTag: "v1"}}
+	mcpServer.Status.RolloutComplete = true
+	recordRolledOutRevision(mcpServer, "Ready")
+	assertEqual(t, "history length", len(mcpServer.Status.History), 1)
+
+	// v2 is Ready only because the v1 pods still serve.
+	mcpServer.Spec.ImageTag = "v2"
+	mcpServer.Status.RolloutComplete = false
+	recordRolledOutRevision(mcpServer, "Ready")
+	assertEqual(t, "history length during rollout", len(mcpServer.Status.History), 1)
+
+	mcpServer.Status.RolloutComplete = true
+	recordRolledOutRevision(mcpServer, "PartiallyReady")
+	assertEqual(t, "history length when not ready", len(mcpServer.Status.History), 1)
+
+	recordRolledOutRevision(mcpServer, "Ready")
+	assertEqual(t, "history length after rollout", len(mcpServer.Status.History), 2)
+	assertEqual(t, "tag", mcpServer.Status.History[1].ImageTag, "v2")
+}
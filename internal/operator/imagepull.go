@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// imagePullBackOff is the waiting reason after a failed pull; its message no longer
+// carries the registry error, which is only kept in the pod's Failed events.
+const imagePullBackOff = "ImagePullBackOff"
+
+// pullErrorKinds classifies registry errors by substrings of the kubelet message,
+// checked in order: access denied is ambiguous on registries that hide private repositories.
+var pullErrorKinds = []struct {
+	kind    string
+	matches []string
+}{
+	{"access denied or image not found", []string{"pull access denied"}},
+	{"registry authentication failed", []string{"unauthorized", "401", "403", "forbidden", "authentication required", "no basic auth credentials"}},
+	{"image not found", []string{"not found", "manifest unknown", "name unknown", "404"}},
+}
+
+// classifyPullError returns what a failed pull means for the user, or "" when the
+// message does not tell.
+func classifyPullError(message string) string {
+	lower := strings.ToLower(message)
+	for _, k := range pullErrorKinds {
+		for _, m := range k.matches {
+			if strings.Contains(lower, m) {
+				return k.kind
+			}
+		}
+	}
+	return ""
+}
+
+// pullErrorMessage returns the registry error for a container stuck pulling its image:
+// the waiting message for ErrImagePull, or the latest Failed event of the pod during
+// back-off. Events are read through the API reader so they are not cached cluster-wide, and
+// selected by field so only the pod's own events are returned.
+func (r *MCPServerReconciler) pullErrorMessage(ctx context.Context, pod *corev1.Pod, waiting *corev1.ContainerStateWaiting) string {
+	if waiting.Reason != imagePullBackOff {
+		return waiting.Message
+	}
+	var events corev1.EventList
+	if err := r.apiReader().List(ctx, &events, client.InNamespace(pod.Namespace), client.MatchingFields{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": pod.Name,
+	}); err != nil {
+		return waiting.Message
+	}
+	message := waiting.Message
+	var latest *corev1.Event
+	for i := range events.Items {
+		e := &events.Items[i]
+		if e.Reason != "Failed" || !strings.Contains(e.Message, "Failed to pull image") {
+			continue
+		}
+		if latest == nil || latest.LastTimestamp.Before(&e.LastTimestamp) {
+			latest = e
+		}
+	}
+	if latest != nil {
+		message = latest.Message
+	}
+	return message
+}
+
+// failingPodReason returns the first failing container waiting reason of pod, or "".
+func failingPodReason(pod *corev1.Pod) string {
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && failingWaitingReasons[cs.State.Waiting.Reason] {
+			return cs.State.Waiting.Reason
+		}
+	}
+	return ""
+}
+
+// podFailureChanged passes pod updates that start or change a failing waiting state
+// (such as ErrImagePull), so Degraded is set as soon as a pod starts failing.
+var podFailureChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+		newPod, okNew := e.ObjectNew.(*corev1.Pod)
+		if !okOld || !okNew {
+			return false
+		}
+		reason := failingPodReason(newPod)
+		return reason != "" && reason != failingPodReason(oldPod)
+	},
+}
+
+// podReadinessChanged passes pod updates that flip the Ready condition and pod deletions, so
+// status follows pods becoming ready or going away without a timed requeue.
+var podReadinessChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+		newPod, okNew := e.ObjectNew.(*corev1.Pod)
+		if !okOld || !okNew {
+			return false
+		}
+		return podReady(oldPod) != podReady(newPod)
+	},
+}
+
+// requestForServerPod maps a pod of an MCPServer Deployment to its server.
+func requestForServerPod(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[LabelManagedBy] != LabelManagedByValue || labels[LabelApp] == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: labels[LabelApp]}}}
+}
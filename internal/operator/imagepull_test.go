@@ -0,0 +1,182 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestClassifyPullError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{`failed to resolve reference "registry.local/app:v1": unexpected status 401 Unauthorized`, "registry authentication failed"},
+		{`rpc error: code = NotFound desc = failed to pull and unpack image "registry.local/app:v9": not found`, "image not found"},
+		{`manifest unknown: manifest unknown`, "image not found"},
+		{`pull access denied for app, repository does not exist or may require 'docker login'`, "access denied or image not found"},
+		{`Back-off pulling image "registry.local/app:v1"`, ""},
+	}
+	for _, tt := range tests {
+		assertEqual(t, tt.message, classifyPullError(tt.message), tt.want)
+	}
+}
+
+// eventFieldReader applies Event field selectors the way the API server does; the fake
+// client only supports single-field selectors backed by an index.
+type eventFieldReader struct {
+	client.Reader
+	selectors []string
+}
+
+func (r *eventFieldReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	events, ok := list.(*corev1.EventList)
+	if !ok || listOpts.FieldSelector == nil {
+		return r.Reader.List(ctx, list, opts...)
+	}
+	r.selectors = append(r.selectors, listOpts.FieldSelector.String())
+	if err := r.Reader.List(ctx, events, client.InNamespace(listOpts.Namespace)); err != nil {
+		return err
+	}
+	matched := events.Items[:0]
+	for _, e := range events.Items {
+		if listOpts.FieldSelector.Matches(fields.Set{
+			"involvedObject.kind": e.InvolvedObject.Kind,
+			"involvedObject.name": e.InvolvedObject.Name,
+		}) {
+			matched = append(matched, e)
+		}
+	}
+	events.Items = matched
+	return nil
+}
+
+func TestDiagnosePodsImagePull(t *testing.T) {
+	scheme := newHealthTestScheme()
+	mcpServer := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server-abc", Namespace: "default", Labels: map[string]string{LabelApp: "test-server"}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "test-server",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: `Back-off pulling image "registry.local/test-server:v2"`,
+			}},
+		}}},
+	}
+	failedEvent := func(name, podName, message string, at metav1.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: podName, Namespace: "default"},
+			Reason:         "Failed",
+			Message:        message,
+			LastTimestamp:  at,
+		}
+	}
+	older := metav1.Unix(1000, 0)
+	newer := metav1.Unix(2000, 0)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		pod,
+		failedEvent("e1", "test-server-abc", `Failed to pull image "registry.local/test-server:v2": 401 Unauthorized`, older),
+		failedEvent("e2", "test-server-abc", `Failed to pull image "registry.local/test-server:v2": manifest unknown`, newer),
+		failedEvent("e3", "other-pod", `Failed to pull image "registry.local/other:v1": 401 Unauthorized`, newer),
+	).Build()
+	reader := &eventFieldReader{Reader: c}
+	r := MCPServerReconciler{Client: c, APIReader: reader, Scheme: scheme}
+
+	failure, err := r.diagnosePods(context.Background(), mcpServer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failure == nil {
+		t.Fatal("expected a failure")
+	}
+	assertEqual(t, "reason", failure.Reason, "ImagePullBackOff")
+	want := `pod test-server-abc container test-server: ImagePullBackOff (image not found): Failed to pull image "registry.local/test-server:v2": manifest unknown`
+	assertEqual(t, "message", failure.Message, want)
+	if len(reader.selectors) != 1 || reader.selectors[0] != "involvedObject.kind=Pod,involvedObject.name=test-server-abc" {
+		t.Fatalf("expected events to be selected by pod, got %v", reader.selectors)
+	}
+}
+
+func TestPodFailureChanged(t *testing.T) {
+	waiting := func(reason string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		if reason != "" {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+			}}
+		}
+		return pod
+	}
+	tests := []struct {
+		name     string
+		old, new string
+		want     bool
+	}{
+		{"starts pulling failure", "ContainerCreating", "ErrImagePull", true},
+		{"moves to back-off", "ErrImagePull", "ImagePullBackOff", true},
+		{"unchanged failure", "ImagePullBackOff", "ImagePullBackOff", false},
+		{"recovers", "ImagePullBackOff", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podFailureChanged.Update(event.UpdateEvent{ObjectOld: waiting(tt.old), ObjectNew: waiting(tt.new)})
+			assertEqual(t, "update", got, tt.want)
+		})
+	}
+}
+
+func TestPodReadinessChanged(t *testing.T) {
+	pod := func(ready corev1.ConditionStatus) *corev1.Pod {
+		p := &corev1.Pod{}
+		if ready != "" {
+			p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+		}
+		return p
+	}
+	tests := []struct {
+		name     string
+		old, new corev1.ConditionStatus
+		want     bool
+	}{
+		{"becomes ready", corev1.ConditionFalse, corev1.ConditionTrue, true},
+		{"first condition ready", "", corev1.ConditionTrue, true},
+		{"stops being ready", corev1.ConditionTrue, corev1.ConditionFalse, true},
+		{"stays ready", corev1.ConditionTrue, corev1.ConditionTrue, false},
+		{"stays unready", "", corev1.ConditionFalse, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podReadinessChanged.Update(event.UpdateEvent{ObjectOld: pod(tt.old), ObjectNew: pod(tt.new)})
+			assertEqual(t, "update", got, tt.want)
+		})
+	}
+	assertEqual(t, "delete", podReadinessChanged.Delete(event.DeleteEvent{Object: pod(corev1.ConditionTrue)}), true)
+	assertEqual(t, "create", podReadinessChanged.Create(event.CreateEvent{Object: pod("")}), false)
+}
+
+func TestRequestForServerPod(t *testing.T) {
+	managed := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "svc-abc", Namespace: "ns1", Labels: map[string]string{
+		LabelApp: "svc", LabelManagedBy: LabelManagedByValue,
+	}}}
+	requests := requestForServerPod(context.Background(), managed)
+	if len(requests) != 1 || requests[0].Namespace != "ns1" || requests[0].Name != "svc" {
+		t.Fatalf("unexpected requests %v", requests)
+	}
+
+	unmanaged := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "x", Namespace: "ns1", Labels: map[string]string{LabelApp: "svc"}}}
+	if requests := requestForServerPod(context.Background(), unmanaged); len(requests) != 0 {
+		t.Fatalf("expected no requests for unmanaged pod, got %v", requests)
+	}
+}
@@ -0,0 +1,107 @@
+package operator
+
+import (
+	"context"
+	"net"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// ingressControllerServices lists, per ingress class, the Services that
+// expose well-known ingress controllers, in lookup order.
+var ingressControllerServices = map[string][]types.NamespacedName{
+	"traefik": {
+		{Namespace: "traefik", Name: "traefik"},
+		{Namespace: "kube-system", Name: "traefik"},
+	},
+	"nginx": {
+		{Namespace: "ingress-nginx", Name: "ingress-nginx-controller"},
+	},
+}
+
+// ingressControllerCandidates returns the Services to inspect for class,
+// falling back to every known controller for unknown classes.
+func ingressControllerCandidates(class string) []types.NamespacedName {
+	if candidates, ok := ingressControllerServices[class]; ok {
+		return candidates
+	}
+	return append(append([]types.NamespacedName{}, ingressControllerServices["traefik"]...), ingressControllerServices["nginx"]...)
+}
+
+// effectiveIngressHost returns the configured ingress host, or the
+// auto-detected one recorded in status.
+func effectiveIngressHost(mcpServer *mcpv1alpha1.MCPServer) string {
+	if mcpServer.Spec.IngressHost != "" {
+		return mcpServer.Spec.IngressHost
+	}
+	return mcpServer.Status.IngressHost
+}
+
+// resolveIngressHost records the host the Ingress will serve in status.
+// When neither spec.ingressHost nor a default host is set, it is derived from
+// the ingress controller's LoadBalancer address, under the namespace's host prefix.
+// A failed lookup keeps the recorded host; it is only cleared once no controller
+// Service has an address.
+func (r *MCPServerReconciler) resolveIngressHost(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) {
+	if mcpServer.Spec.IngressHost != "" {
+		mcpServer.Status.IngressHost = mcpServer.Spec.IngressHost
+		return
+	}
+
+	host, err := r.detectIngressHost(ctx, mcpServer.Spec.IngressClass)
+	if err != nil {
+		logger.Error(err, "Failed to inspect ingress controller Service")
+		return
+	}
+	if host != "" {
+		prefix, err := r.ingressHostPrefix(ctx, mcpServer.Namespace)
+		if err != nil {
+			logger.Error(err, "Failed to read namespace for its ingress host prefix", "namespace", mcpServer.Namespace)
+			return
+		}
+		host = prefixIngressHost(prefix, host)
+	}
+	if host != "" && host != mcpServer.Status.IngressHost {
+		logger.Info("Using auto-detected ingress host", "name", mcpServer.Name, "host", host)
+	}
+	mcpServer.Status.IngressHost = host
+}
+
+// detectIngressHost returns a host for the first ingress controller Service of
+// class that has a LoadBalancer address, or "" if none does yet.
+func (r *MCPServerReconciler) detectIngressHost(ctx context.Context, class string) (string, error) {
+	for _, key := range ingressControllerCandidates(class) {
+		var svc corev1.Service
+		if err := r.Get(ctx, key, &svc); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+		if host := hostFromLoadBalancer(svc.Status.LoadBalancer.Ingress); host != "" {
+			return host, nil
+		}
+	}
+	return "", nil
+}
+
+// hostFromLoadBalancer prefers a LoadBalancer hostname and otherwise turns an
+// IPv4 address into a wildcard DNS host such as 1.2.3.4.nip.io.
+func hostFromLoadBalancer(ingress []corev1.LoadBalancerIngress) string {
+	for _, lb := range ingress {
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+	}
+	for _, lb := range ingress {
+		if ip := net.ParseIP(lb.IP); ip != nil && ip.To4() != nil {
+			return ip.String() + "." + WildcardDNSSuffix
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestHostFromLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingress []corev1.LoadBalancerIngress
+		want    string
+	}{
+		{"none", nil, ""},
+		{"ipv4", []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}, "203.0.113.10.nip.io"},
+		{"hostname preferred", []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}, {Hostname: "lb.example.com"}}, "lb.example.com"},
+		{"ipv6 ignored", []corev1.LoadBalancerIngress{{IP: "2001:db8::1"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, "host", hostFromLoadBalancer(tt.ingress), tt.want)
+		})
+	}
+}
+
+func TestReconcileDetectsIngressHost(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	traefik := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "traefik", Namespace: "kube-system"},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{IP: "198.51.100.7"}},
+		}},
+	}
+	replicas := int32(1)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "auto", Namespace: "default"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:        "registry.local/app",
+			ImageTag:     "v1",
+			Port:         8088,
+			ServicePort:  80,
+			Replicas:     &replicas,
+			IngressPath:  "/auto/mcp",
+			IngressClass: "traefik",
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, traefik).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	r := MCPServerReconciler{Client: client, Scheme: scheme}
+	key := types.NamespacedName{Name: "auto", Namespace: "default"}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ingress := &networkingv1.Ingress{}
+	if err := client.Get(context.Background(), key, ingress); err != nil {
+		t.Fatalf("expected Ingress: %v", err)
+	}
+	assertEqual(t, "ingress host", ingress.Spec.Rules[0].Host, "198.51.100.7.nip.io")
+
+	updated := &mcpv1alpha1.MCPServer{}
+	if err := client.Get(context.Background(), key, updated); err != nil {
+		t.Fatalf("get MCPServer: %v", err)
+	}
+	assertEqual(t, "status host", updated.Status.IngressHost, "198.51.100.7.nip.io")
+	assertEqual(t, "spec host", updated.Spec.IngressHost, "")
+}
+
+func TestResolveIngressHostKeepsHostOnLookupError(t *testing.T) {
+	scheme := newHealthTestScheme()
+	newServer := func() *mcpv1alpha1.MCPServer {
+		server := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "auto", Namespace: "default"},
+			Spec:       mcpv1alpha1.MCPServerSpec{IngressClass: "traefik"},
+		}
+		server.Status.IngressHost = "198.51.100.7.nip.io"
+		return server
+	}
+
+	t.Run("keeps the host when the lookup fails", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return errors.New("apiserver unavailable")
+			},
+		}).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme}
+		server := newServer()
+
+		r.resolveIngressHost(context.Background(), server, logr.Discard())
+		assertEqual(t, "status host", server.Status.IngressHost, "198.51.100.7.nip.io")
+	})
+
+	t.Run("clears the host once no controller has an address", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme}
+		server := newServer()
+
+		r.resolveIngressHost(context.Background(), server, logr.Discard())
+		assertEqual(t, "status host", server.Status.IngressHost, "")
+	})
+}
@@ -0,0 +1,191 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// NGINX annotations used to strip the ingress path.
+const (
+	nginxRewriteTargetAnnotation = "nginx.ingress.kubernetes.io/rewrite-target"
+	nginxUseRegexAnnotation      = "nginx.ingress.kubernetes.io/use-regex"
+)
+
+// ingressPathRe matches a normalized ingress path: a leading slash followed by URL path
+// characters, so no spaces, query strings or fragments.
+var ingressPathRe = regexp.MustCompile(`^/[A-Za-z0-9._~!$&'()*+,;=:@%/-]*$`)
+
+// normalizeIngressPath adds a missing leading slash and removes trailing, duplicate and
+// dot segments, so "mcp/", "/mcp//" and "/mcp/." all become "/mcp".
+func normalizeIngressPath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return path.Clean(p)
+}
+
+// effectiveIngressPath returns the configured ingress path, or the default one recorded in
+// status.
+func effectiveIngressPath(mcpServer *mcpv1alpha1.MCPServer) string {
+	if p := normalizeIngressPath(mcpServer.Spec.IngressPath); p != "" {
+		return p
+	}
+	return mcpServer.Status.IngressPath
+}
+
+// ingressPathMu serializes choosing and recording default ingress paths, so two servers
+// defaulted at the same time cannot both take a free path. Only the leader reconciles,
+// so a process-wide lock covers the cluster.
+var ingressPathMu sync.Mutex
+
+// baseIngressPath is the default ingress path of mcpServer before collision avoidance:
+// /<name>/mcp, or /<namespace>/<name>/mcp with NamespacedIngressPaths.
+func (r *MCPServerReconciler) baseIngressPath(mcpServer *mcpv1alpha1.MCPServer) string {
+	if r.NamespacedIngressPaths {
+		return "/" + mcpServer.Namespace + "/" + mcpServer.Name + "/mcp"
+	}
+	return "/" + mcpServer.Name + "/mcp"
+}
+
+// defaultIngressPath returns the base path of mcpServer, or, when another server on the
+// same host already routes it, the first free path with a -2, -3, ... suffix on the
+// server's segment, e.g. /weather-2/mcp. The path recorded by an earlier reconcile is kept
+// while it is free. Servers are read past the cache so a path recorded by the previous
+// reconcile is seen. Callers hold ingressPathMu until the path is recorded.
+func (r *MCPServerReconciler) defaultIngressPath(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (string, error) {
+	var list mcpv1alpha1.MCPServerList
+	if err := r.apiReader().List(ctx, &list); err != nil {
+		return "", err
+	}
+	host := effectiveIngressHost(mcpServer)
+	taken := map[string]bool{}
+	for i := range list.Items {
+		other := &list.Items[i]
+		// Canaries share the stable server's path on purpose.
+		if other.Namespace == mcpServer.Namespace && other.Name == mcpServer.Name || canaryEnabled(other) {
+			continue
+		}
+		if effectiveIngressHost(other) == host {
+			taken[effectiveIngressPath(other)] = true
+		}
+	}
+	base := strings.TrimSuffix(r.baseIngressPath(mcpServer), "/mcp")
+	if current := mcpServer.Status.IngressPath; current != "" && !taken[current] && defaultPathOf(current, base) {
+		return current, nil
+	}
+	candidate := base + "/mcp"
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s-%d/mcp", base, n)
+	}
+	return candidate, nil
+}
+
+// defaultPathOf reports whether p is base/mcp or one of its -N variants.
+func defaultPathOf(p, base string) bool {
+	if p == base+"/mcp" {
+		return true
+	}
+	n, ok := strings.CutPrefix(p, base+"-")
+	if !ok {
+		return false
+	}
+	n, ok = strings.CutSuffix(n, "/mcp")
+	return ok && n != "" && strings.Trim(n, "0123456789") == ""
+}
+
+// stripPrefixEnabled reports whether the ingress path is stripped before requests reach the server.
+func stripPrefixEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.IngressStripPrefix && mcpServer.Spec.IngressPath != "/"
+}
+
+// stripPrefixMiddlewareName is the name of the Traefik Middleware stripping the ingress path.
+func stripPrefixMiddlewareName(mcpServer *mcpv1alpha1.MCPServer) string {
+	return mcpServer.Name + "-strip-prefix"
+}
+
+// validateIngressPath rejects ingress paths that are not valid URL paths once normalized,
+// and ingressStripPrefix on ingress classes the operator cannot configure.
+func (r *MCPServerReconciler) validateIngressPath(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	var message string
+	switch ingressPath := mcpServer.Spec.IngressPath; {
+	case !ingressPathRe.MatchString(ingressPath):
+		message = fmt.Sprintf("spec.ingressPath %q must start with / and contain only URL path characters (no spaces, ? or #)", ingressPath)
+	case stripPrefixEnabled(mcpServer) && r.routesThroughIngress(mcpServer) && mcpServer.Spec.IngressClass != "traefik" && mcpServer.Spec.IngressClass != "nginx":
+		message = fmt.Sprintf("spec.ingressStripPrefix requires ingressClass traefik or nginx, got %q", mcpServer.Spec.IngressClass)
+	}
+	if message == "" {
+		return nil
+	}
+	contextMap := map[string]any{
+		"mcpServer":    mcpServer.Name,
+		"namespace":    mcpServer.Namespace,
+		"ingressPath":  mcpServer.Spec.IngressPath,
+		"ingressClass": mcpServer.Spec.IngressClass,
+	}
+	err := wrapOperatorError(fmt.Errorf("%w: %s", ErrInvalidIngressPath, message), "Invalid ingress path", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Invalid ingress path")
+	return err
+}
+
+// ingressRulePath returns the path and path type of the server's Ingress rule. NGINX
+// strips the prefix with a regex rewrite, so the path becomes a regex capturing the rest.
+func ingressRulePath(mcpServer *mcpv1alpha1.MCPServer) (string, networkingv1.PathType) {
+	if stripPrefixEnabled(mcpServer) && mcpServer.Spec.IngressClass == "nginx" {
+		return regexp.QuoteMeta(mcpServer.Spec.IngressPath) + "(/|$)(.*)", networkingv1.PathTypeImplementationSpecific
+	}
+	return mcpServer.Spec.IngressPath, networkingv1.PathTypePrefix
+}
+
+// stripPrefixAnnotations returns the Ingress annotations stripping the ingress path for the
+// server's ingress class, or nil when stripping is off. For Traefik the strip-prefix
+// Middleware runs before any user middleware.
+func stripPrefixAnnotations(mcpServer *mcpv1alpha1.MCPServer, existing map[string]string) map[string]string {
+	if !stripPrefixEnabled(mcpServer) {
+		return nil
+	}
+	switch mcpServer.Spec.IngressClass {
+	case "traefik":
+		middleware := traefikMiddlewareRef(mcpServer.Namespace, stripPrefixMiddlewareName(mcpServer))
+		if user := existing[traefikMiddlewaresAnnotation]; user != "" {
+			middleware += "," + user
+		}
+		return map[string]string{traefikMiddlewaresAnnotation: middleware}
+	case "nginx":
+		return map[string]string{
+			nginxUseRegexAnnotation:      "true",
+			nginxRewriteTargetAnnotation: "/$2",
+		}
+	}
+	return nil
+}
+
+// reconcileStripPrefixMiddleware keeps the Traefik Middleware stripping the ingress path of
+// a traefik-class server in place, and removes it once stripping is turned off.
+func (r *MCPServerReconciler) reconcileStripPrefixMiddleware(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	var spec map[string]any
+	if stripPrefixEnabled(mcpServer) && mcpServer.Spec.IngressClass == "traefik" && r.routesThroughIngress(mcpServer) {
+		spec = map[string]any{
+			"stripPrefix": map[string]any{"prefixes": []any{mcpServer.Spec.IngressPath}},
+		}
+	}
+	err := r.reconcileTraefikMiddleware(ctx, mcpServer, stripPrefixMiddlewareName(mcpServer), spec)
+	if meta.IsNoMatchError(err) {
+		return fmt.Errorf("%w: spec.ingressStripPrefix with ingressClass traefik needs the Traefik Middleware CRD (traefik.io/v1alpha1) and the kubernetescrd provider", ErrInvalidIngressPath)
+	}
+	return err
+}
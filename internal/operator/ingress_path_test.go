@@ -0,0 +1,245 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newIngressPathServer(ingressClass, ingressPath string, stripPrefix bool) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default", UID: "uid-1"},
+		Spec:       mcpv1alpha1.MCPServerSpec{IngressClass: ingressClass, IngressPath: ingressPath, IngressStripPrefix: stripPrefix},
+	}
+}
+
+func TestNormalizeIngressPath(t *testing.T) {
+	tests := map[string]string{
+		"":               "",
+		"  ":             "",
+		"/":              "/",
+		"mcp":            "/mcp",
+		"/weather/mcp/":  "/weather/mcp",
+		"//weather//mcp": "/weather/mcp",
+		"/weather/./mcp": "/weather/mcp",
+		" /weather/mcp ": "/weather/mcp",
+	}
+	for in, want := range tests {
+		assertEqual(t, in, normalizeIngressPath(in), want)
+	}
+}
+
+func TestSetDefaultsNormalizesIngressPath(t *testing.T) {
+	r := &MCPServerReconciler{}
+	server := newIngressPathServer("", "weather/mcp/", false)
+	r.setDefaults(server)
+	assertEqual(t, "ingressPath", server.Spec.IngressPath, "/weather/mcp")
+
+	server = newIngressPathServer("", "/", false)
+	r.setDefaults(server)
+	assertEqual(t, "root ingressPath", server.Spec.IngressPath, "/")
+}
+
+func TestValidateIngressPath(t *testing.T) {
+	scheme := newServerTestScheme()
+	tests := []struct {
+		name    string
+		server  *mcpv1alpha1.MCPServer
+		wantErr bool
+	}{
+		{"plain path", newIngressPathServer("traefik", "/weather/mcp", false), false},
+		{"root", newIngressPathServer("traefik", "/", true), false},
+		{"strip prefix on nginx", newIngressPathServer("nginx", "/weather", true), false},
+		{"space", newIngressPathServer("traefik", "/weather mcp", false), true},
+		{"query string", newIngressPathServer("traefik", "/weather?x=1", false), true},
+		{"no leading slash", newIngressPathServer("traefik", "weather", false), true},
+		{"strip prefix on istio", newIngressPathServer("istio", "/weather", true), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.server).
+				WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+			r := MCPServerReconciler{Client: c, Scheme: scheme}
+			err := r.validateIngressPath(context.Background(), tt.server, logr.Discard())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("validateIngressPath error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidIngressPath) {
+				t.Errorf("expected ErrInvalidIngressPath, got %v", err)
+			}
+		})
+	}
+}
+
+func TestStripPrefixIngress(t *testing.T) {
+	r := &MCPServerReconciler{}
+
+	nginx := newIngressPathServer("nginx", "/weather.v1", true)
+	path, pathType := ingressRulePath(nginx)
+	assertEqual(t, "nginx path", path, `/weather\.v1(/|$)(.*)`)
+	assertEqual(t, "nginx path type", pathType, networkingv1.PathTypeImplementationSpecific)
+	annotations := r.buildIngressAnnotations(nginx)
+	assertEqual(t, "rewrite-target", annotations[nginxRewriteTargetAnnotation], "/$2")
+	assertEqual(t, "use-regex", annotations[nginxUseRegexAnnotation], "true")
+
+	traefik := newIngressPathServer("traefik", "/weather", true)
+	traefik.Spec.IngressAnnotations = map[string]string{traefikMiddlewaresAnnotation: "default-ratelimit@kubernetescrd"}
+	traefik.Spec.Auth = &mcpv1alpha1.AuthSpec{Type: AuthTypeBearer, URL: "http://verifier/check"}
+	path, pathType = ingressRulePath(traefik)
+	assertEqual(t, "traefik path", path, "/weather")
+	assertEqual(t, "traefik path type", pathType, networkingv1.PathTypePrefix)
+	// Requests are authenticated first, then stripped, then passed to user middlewares.
+	assertEqual(t, "middlewares", r.buildIngressAnnotations(traefik)[traefikMiddlewaresAnnotation],
+		"default-test-server-auth@kubernetescrd,default-test-server-strip-prefix@kubernetescrd,default-ratelimit@kubernetescrd")
+
+	off := newIngressPathServer("nginx", "/weather", false)
+	path, _ = ingressRulePath(off)
+	assertEqual(t, "unstripped path", path, "/weather")
+}
+
+func TestReconcileStripPrefixMiddleware(t *testing.T) {
+	scheme := newServerTestScheme()
+	server := newIngressPathServer("traefik", "/weather", true)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	if err := r.reconcileStripPrefixMiddleware(ctx, server); err != nil {
+		t.Fatalf("reconcileStripPrefixMiddleware: %v", err)
+	}
+	middleware := &unstructured.Unstructured{}
+	middleware.SetGroupVersionKind(traefikMiddlewareGVK)
+	key := types.NamespacedName{Name: "test-server-strip-prefix", Namespace: "default"}
+	if err := c.Get(ctx, key, middleware); err != nil {
+		t.Fatalf("get middleware: %v", err)
+	}
+	prefixes, _, _ := unstructured.NestedStringSlice(middleware.Object, "spec", "stripPrefix", "prefixes")
+	if len(prefixes) != 1 || prefixes[0] != "/weather" {
+		t.Fatalf("prefixes = %v, want [/weather]", prefixes)
+	}
+
+	// Turning stripping off removes the Middleware the Ingress still points at.
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Name: "test-server", Namespace: "default",
+		Annotations: stripPrefixAnnotations(server, nil),
+	}}
+	if err := c.Create(ctx, ingress); err != nil {
+		t.Fatalf("create ingress: %v", err)
+	}
+	server.Spec.IngressStripPrefix = false
+	if err := r.reconcileStripPrefixMiddleware(ctx, server); err != nil {
+		t.Fatalf("reconcileStripPrefixMiddleware: %v", err)
+	}
+	if err := c.Get(ctx, key, middleware); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Fatalf("expected middleware to be deleted, got %v", err)
+	}
+}
+
+func TestDefaultIngressPathAvoidsCollisions(t *testing.T) {
+	scheme := newHealthTestScheme()
+	newServer := func(namespace, name, host, path string) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       mcpv1alpha1.MCPServerSpec{Image: "registry.local/app", IngressHost: host, IngressPath: path},
+		}
+	}
+	canary := newServer("team-c", "weather-v2", "mcp.example.com", "/weather-2/mcp")
+	canary.Spec.Canary = &mcpv1alpha1.CanarySpec{Enabled: true, Weight: 10}
+	existing := []client.Object{
+		newServer("team-a", "weather", "mcp.example.com", "/weather/mcp"),
+		newServer("team-b", "search", "other.example.com", "/weather-2/mcp"),
+		canary,
+	}
+
+	recorded := newServer("team-b", "maps", "mcp.example.com", "")
+	recorded.Status.IngressPath = "/maps-3/mcp"
+
+	tests := []struct {
+		name       string
+		namespaced bool
+		server     *mcpv1alpha1.MCPServer
+		want       string
+	}{
+		{"free path", false, newServer("team-b", "maps", "mcp.example.com", ""), "/maps/mcp"},
+		{"taken on the same host", false, newServer("team-b", "weather", "mcp.example.com", ""), "/weather-2/mcp"},
+		{"taken on another host only", false, newServer("team-b", "weather", "new.example.com", ""), "/weather/mcp"},
+		{"namespaced", true, newServer("team-b", "weather", "mcp.example.com", ""), "/team-b/weather/mcp"},
+		{"explicit path kept", false, newServer("team-b", "weather", "mcp.example.com", "/weather/mcp"), "/weather/mcp"},
+		{"recorded path kept", false, recorded, "/maps-3/mcp"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(existing, tc.server)...).
+				WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+			r := &MCPServerReconciler{Client: c, Scheme: scheme, NamespacedIngressPaths: tc.namespaced}
+			specPath := tc.server.Spec.IngressPath
+
+			if err := r.applyIngressDefaults(context.Background(), tc.server, logr.Discard()); err != nil {
+				t.Fatalf("applyIngressDefaults() error: %v", err)
+			}
+			assertEqual(t, "ingressPath", tc.server.Spec.IngressPath, tc.want)
+			saved := &mcpv1alpha1.MCPServer{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(tc.server), saved); err != nil {
+				t.Fatalf("get MCPServer: %v", err)
+			}
+			// The default is recorded in status; the stored spec is left as written.
+			assertEqual(t, "spec ingressPath", saved.Spec.IngressPath, specPath)
+			assertEqual(t, "effective ingressPath", effectiveIngressPath(saved), tc.want)
+		})
+	}
+}
+
+func TestDefaultIngressPathConcurrent(t *testing.T) {
+	scheme := newHealthTestScheme()
+	var servers []*mcpv1alpha1.MCPServer
+	var objects []client.Object
+	for _, namespace := range []string{"team-a", "team-b", "team-c", "team-d"} {
+		server := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: namespace},
+			Spec:       mcpv1alpha1.MCPServerSpec{Image: "registry.local/app", IngressHost: "mcp.example.com"},
+		}
+		servers = append(servers, server)
+		objects = append(objects, server)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	r := &MCPServerReconciler{Client: c, Scheme: scheme}
+
+	errs := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			errs <- r.applyIngressDefaults(context.Background(), server, logr.Discard())
+		}()
+	}
+	for range servers {
+		if err := <-errs; err != nil {
+			t.Fatalf("applyIngressDefaults() error: %v", err)
+		}
+	}
+	var list mcpv1alpha1.MCPServerList
+	if err := c.List(context.Background(), &list); err != nil {
+		t.Fatalf("list MCPServers: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, server := range list.Items {
+		if seen[server.Status.IngressPath] {
+			t.Fatalf("path %s given to two servers", server.Status.IngressPath)
+		}
+		seen[server.Status.IngressPath] = true
+	}
+	for _, want := range []string{"/weather/mcp", "/weather-2/mcp", "/weather-3/mcp", "/weather-4/mcp"} {
+		if !seen[want] {
+			t.Fatalf("expected %s among %v", want, seen)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// Values of spec.ingressProvider and MCP_INGRESS_PROVIDER.
+const (
+	IngressProviderTraefik    = "traefik"
+	IngressProviderNginx      = "nginx"
+	IngressProviderIstio      = "istio"
+	IngressProviderGatewayAPI = "gateway-api"
+	IngressProviderNone       = "none"
+)
+
+// IngressProvider exposes MCP servers outside the cluster through one routing backend.
+// Adding a backend means implementing this interface and registering it in
+// ingressProviders; the reconciler only talks to the interface.
+type IngressProvider interface {
+	// Name is the spec.ingressProvider value selecting the provider.
+	Name() string
+	// Unsupported returns the spec fields of mcpServer the provider cannot configure.
+	Unsupported(mcpServer *mcpv1alpha1.MCPServer) []string
+	// Reconcile creates or updates the routing resources of mcpServer.
+	Reconcile(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) error
+	// Ready reports whether the routing of mcpServer is in place.
+	Ready(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) (bool, error)
+	// Cleanup removes the routing resources of mcpServer once it uses another provider.
+	Cleanup(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) error
+}
+
+// ingressProviders are the providers spec.ingressProvider and MCP_INGRESS_PROVIDER
+// select, by name.
+var ingressProviders = map[string]IngressProvider{
+	IngressProviderTraefik:    ingressResourceProvider{name: IngressProviderTraefik, defaults: traefikIngressDefaults},
+	IngressProviderNginx:      ingressResourceProvider{name: IngressProviderNginx, defaults: nginxIngressDefaults},
+	IngressProviderIstio:      ingressResourceProvider{name: IngressProviderIstio, defaults: istioIngressDefaults},
+	IngressProviderGatewayAPI: gatewayAPIProvider{},
+	IngressProviderNone:       noneProvider{},
+}
+
+// genericIngressProvider serves ingress classes without a provider of their own.
+var genericIngressProvider = ingressResourceProvider{name: "ingress", defaults: genericIngressDefaults}
+
+// ValidateIngressProvider rejects an unknown provider name, such as a mistyped
+// MCP_INGRESS_PROVIDER. An empty name is valid and leaves the choice to ingressClass.
+func ValidateIngressProvider(name string) error {
+	if _, ok := ingressProviders[name]; name != "" && !ok {
+		names := make([]string, 0, len(ingressProviders))
+		for known := range ingressProviders {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("%w: unknown ingress provider %q (use one of %s)", ErrInvalidIngressProvider, name, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// ingressProvider returns the provider exposing mcpServer: spec.ingressProvider, then the
+// operator's IngressProvider, then the provider named by the ingress class.
+func (r *MCPServerReconciler) ingressProvider(mcpServer *mcpv1alpha1.MCPServer) IngressProvider {
+	name := mcpServer.Spec.IngressProvider
+	if name == "" {
+		name = r.IngressProvider
+	}
+	if provider, ok := ingressProviders[name]; ok {
+		return provider
+	}
+	return ingressProviderForClass(mcpServer.Spec.IngressClass)
+}
+
+// ingressProviderForClass returns the Ingress provider for an ingress class.
+func ingressProviderForClass(class string) ingressResourceProvider {
+	if class == "" {
+		class = DefaultIngressClass
+	}
+	if provider, ok := ingressProviders[class].(ingressResourceProvider); ok {
+		return provider
+	}
+	return genericIngressProvider
+}
+
+// providerIngressClass returns the ingress class named by an Ingress-based
+// spec.ingressProvider, or "" to leave the class to the operator's defaults.
+func providerIngressClass(mcpServer *mcpv1alpha1.MCPServer) string {
+	if _, ok := ingressProviders[mcpServer.Spec.IngressProvider].(ingressResourceProvider); ok {
+		return mcpServer.Spec.IngressProvider
+	}
+	return ""
+}
+
+// routesThroughIngress reports whether mcpServer is exposed by an Ingress, which the
+// Traefik middlewares and class-specific annotations apply to.
+func (r *MCPServerReconciler) routesThroughIngress(mcpServer *mcpv1alpha1.MCPServer) bool {
+	_, ok := r.ingressProvider(mcpServer).(ingressResourceProvider)
+	return ok
+}
+
+// reconcileIngress exposes mcpServer through its provider, after removing the resources
+// of the provider that exposed it before.
+func (r *MCPServerReconciler) reconcileIngress(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	provider := r.ingressProvider(mcpServer)
+	if previous := previousIngressProvider(mcpServer); !sameRoutingResources(previous, provider) {
+		if err := previous.Cleanup(ctx, r, mcpServer); err != nil {
+			return err
+		}
+	}
+	if err := provider.Reconcile(ctx, r, mcpServer); err != nil {
+		return err
+	}
+	mcpServer.Status.IngressProvider = provider.Name()
+	return nil
+}
+
+func (r *MCPServerReconciler) checkIngressReady(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
+	return r.ingressProvider(mcpServer).Ready(ctx, r, mcpServer)
+}
+
+// previousIngressProvider returns the provider recorded in status. Servers reconciled
+// before providers were recorded were always exposed by an Ingress.
+func previousIngressProvider(mcpServer *mcpv1alpha1.MCPServer) IngressProvider {
+	if provider, ok := ingressProviders[mcpServer.Status.IngressProvider]; ok {
+		return provider
+	}
+	return genericIngressProvider
+}
+
+// sameRoutingResources reports whether two providers manage the same resources, so that
+// moving between Ingress-based providers updates the Ingress in place.
+func sameRoutingResources(a, b IngressProvider) bool {
+	_, aIngress := a.(ingressResourceProvider)
+	_, bIngress := b.(ingressResourceProvider)
+	return a.Name() == b.Name() || (aIngress && bIngress)
+}
+
+// validateIngressProvider rejects spec fields the server's provider cannot configure.
+func (r *MCPServerReconciler) validateIngressProvider(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	provider := r.ingressProvider(mcpServer)
+	unsupported := provider.Unsupported(mcpServer)
+	if len(unsupported) == 0 {
+		return nil
+	}
+	contextMap := map[string]any{
+		"mcpServer":       mcpServer.Name,
+		"namespace":       mcpServer.Namespace,
+		"ingressProvider": provider.Name(),
+	}
+	message := fmt.Sprintf("%s not supported by the %s ingress provider", strings.Join(unsupported, ", "), provider.Name())
+	err := wrapOperatorError(fmt.Errorf("%w: %s", ErrInvalidIngressProvider, message), "Unsupported ingress provider feature", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Unsupported ingress provider feature")
+	return err
+}
+
+// ingressResourceProvider exposes servers through a networking.k8s.io Ingress, with the
+// default annotations of one ingress controller.
+type ingressResourceProvider struct {
+	name string
+	// defaults adds the controller's annotations the user has not set.
+	defaults func(r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer, annotations map[string]string)
+}
+
+func (p ingressResourceProvider) Name() string { return p.name }
+
+// Unsupported returns nothing: features are checked against the ingress class by their
+// own validations.
+func (p ingressResourceProvider) Unsupported(*mcpv1alpha1.MCPServer) []string { return nil }
+
+func (p ingressResourceProvider) Reconcile(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) error {
+	logger := log.FromContext(ctx)
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mcpServer.Name,
+			Namespace: mcpServer.Namespace,
+		},
+	}
+
+	var mirror map[string]string
+	if mirrorEnabled(mcpServer) {
+		target, err := r.mirrorTarget(ctx, mcpServer)
+		if err != nil {
+			return err
+		}
+		mirror = mirrorAnnotations(target)
+	}
+
+	rulePath, pathType := ingressRulePath(mcpServer)
+	ingressClassName := mcpServer.Spec.IngressClass
+	if ingressClassName == "" {
+		ingressClassName = DefaultIngressClass
+	}
+
+	spec := networkingv1.IngressSpec{
+		IngressClassName: &ingressClassName,
+		Rules: []networkingv1.IngressRule{
+			{
+				Host: effectiveIngressHost(mcpServer),
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     rulePath,
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: mcpServer.Name,
+										Port: networkingv1.ServiceBackendPort{
+											Number: mcpServer.Spec.ServicePort,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	annotations := p.annotations(r, mcpServer)
+	for k, v := range mirror {
+		annotations[k] = v
+	}
+
+	hash, err := specHash(struct {
+		Owner       types.UID
+		Spec        networkingv1.IngressSpec
+		Annotations map[string]string
+	}{mcpServer.UID, spec, annotations})
+	if err != nil {
+		return err
+	}
+
+	synced := func() bool { return inSync(spec, ingress.Spec) && inSync(annotations, ingress.Annotations) }
+	op, err := r.createOrUpdateHashed(ctx, ingress, hash, synced, func() error {
+		ingress.Spec = spec
+		ingress.Annotations = annotations
+		return ctrl.SetControllerReference(mcpServer, ingress, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+
+	if op != controllerutil.OperationResultNone {
+		logger.Info("Ingress reconciled", "operation", op, "name", ingress.Name)
+	}
+	return nil
+}
+
+// Ready reports whether the ingress controller has published an address for the Ingress.
+func (p ingressResourceProvider) Ready(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) (bool, error) {
+	ingress := &networkingv1.Ingress{}
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, ingress); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(ingress.Status.LoadBalancer.Ingress) > 0, nil
+}
+
+func (p ingressResourceProvider) Cleanup(ctx context.Context, r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) error {
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: mcpServer.Name, Namespace: mcpServer.Namespace}}
+	if err := r.Delete(ctx, ingress); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	log.FromContext(ctx).Info("Ingress deleted", "name", ingress.Name)
+	return nil
+}
+
+// annotations returns the Ingress annotations: the user's, then the controller defaults
+// the user has not set, then those of the features the server enables.
+func (p ingressResourceProvider) annotations(r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer) map[string]string {
+	annotations := make(map[string]string, len(mcpServer.Spec.IngressAnnotations))
+	for k, v := range mcpServer.Spec.IngressAnnotations {
+		annotations[k] = v
+	}
+	p.defaults(r, mcpServer, annotations)
+
+	if canaryEnabled(mcpServer) {
+		switch mcpServer.Spec.IngressClass {
+		case "nginx":
+			for k, v := range canaryAnnotations(mcpServer.Spec.Canary) {
+				annotations[k] = v
+			}
+		case "traefik":
+			annotations[annotationTraefikCanary] = traefikCanaryName(mcpServer)
+		}
+	}
+	for k, v := range externalDNSAnnotations(mcpServer) {
+		annotations[k] = v
+	}
+	for k, v := range stripPrefixAnnotations(mcpServer, annotations) {
+		annotations[k] = v
+	}
+	for k, v := range authAnnotations(mcpServer, annotations) {
+		annotations[k] = v
+	}
+	for k, v := range sessionAffinityIngressAnnotations(mcpServer, annotations) {
+		annotations[k] = v
+	}
+	return annotations
+}
+
+// buildIngressAnnotations returns the annotations of mcpServer's Ingress for its ingress class.
+func (r *MCPServerReconciler) buildIngressAnnotations(mcpServer *mcpv1alpha1.MCPServer) map[string]string {
+	return ingressProviderForClass(mcpServer.Spec.IngressClass).annotations(r, mcpServer)
+}
+
+func setDefaultAnnotation(annotations map[string]string, key, value string) {
+	if _, exists := annotations[key]; !exists {
+		annotations[key] = value
+	}
+}
+
+func traefikIngressDefaults(r *MCPServerReconciler, mcpServer *mcpv1alpha1.MCPServer, annotations map[string]string) {
+	setDefaultAnnotation(annotations, traefikEntrypointsAnnotation, r.traefikEntrypoints(mcpServer))
+}
+
+func nginxIngressDefaults(_ *MCPServerReconciler, _ *mcpv1alpha1.MCPServer, annotations map[string]string) {
+	setDefaultAnnotation(annotations, nginxRewriteTargetAnnotation, "/")
+	setDefaultAnnotation(annotations, nginxSSLRedirectAnnotation, "false")
+}
+
+// istioIngressDefaults marks the Ingress for Istio's ingress gateway, which serves
+// Ingresses when its class annotation names istio.
+func istioIngressDefaults(_ *MCPServerReconciler, _ *mcpv1alpha1.MCPServer, annotations map[string]string) {
+	setDefaultAnnotation(annotations, "kubernetes.io/ingress.class", "istio")
+}
+
+func genericIngressDefaults(_ *MCPServerReconciler, _ *mcpv1alpha1.MCPServer, annotations map[string]string) {
+	setDefaultAnnotation(annotations, "ingress.kubernetes.io/rewrite-target", "/")
+}
+
+// noneProvider leaves servers reachable only through their Service inside the cluster.
+type noneProvider struct{}
+
+func (noneProvider) Name() string { return IngressProviderNone }
+
+func (noneProvider) Unsupported(mcpServer *mcpv1alpha1.MCPServer) []string {
+	var fields []string
+	if canaryEnabled(mcpServer) {
+		fields = append(fields, "spec.canary")
+	}
+	if mirrorEnabled(mcpServer) {
+		fields = append(fields, "spec.mirror")
+	}
+	if authEnabled(mcpServer) {
+		fields = append(fields, "spec.auth")
+	}
+	if stripPrefixEnabled(mcpServer) {
+		fields = append(fields, "spec.ingressStripPrefix")
+	}
+	if mcpServer.Spec.SessionAffinity == SessionAffinityCookie {
+		fields = append(fields, "spec.sessionAffinity cookie")
+	}
+	return fields
+}
+
+func (noneProvider) Reconcile(context.Context, *MCPServerReconciler, *mcpv1alpha1.MCPServer) error {
+	return nil
+}
+
+// Ready is always true: there is nothing beyond the Service to wait for.
+func (noneProvider) Ready(context.Context, *MCPServerReconciler, *mcpv1alpha1.MCPServer) (bool, error) {
+	return true, nil
+}
+
+func (noneProvider) Cleanup(context.Context, *MCPServerReconciler, *mcpv1alpha1.MCPServer) error {
+	return nil
+}
@@ -0,0 +1,144 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newProviderServer(provider string) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default", UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			IngressProvider: provider,
+			IngressClass:    "traefik",
+			IngressHost:     "mcp.example.com",
+			IngressPath:     "/test-server/mcp",
+			ServicePort:     80,
+		},
+	}
+}
+
+func TestIngressProviderSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		operator string
+		class    string
+		want     string
+	}{
+		{name: "from ingress class", class: "nginx", want: IngressProviderNginx},
+		{name: "unknown class", class: "haproxy", want: "ingress"},
+		{name: "operator default", operator: IngressProviderGatewayAPI, class: "traefik", want: IngressProviderGatewayAPI},
+		{name: "spec wins", spec: IngressProviderNone, operator: IngressProviderGatewayAPI, class: "traefik", want: IngressProviderNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newProviderServer(tt.spec)
+			server.Spec.IngressClass = tt.class
+			r := MCPServerReconciler{IngressProvider: tt.operator}
+			assertEqual(t, "provider", r.ingressProvider(server).Name(), tt.want)
+		})
+	}
+
+	if err := ValidateIngressProvider(""); err != nil {
+		t.Errorf("expected an empty provider to be valid, got %v", err)
+	}
+	if err := ValidateIngressProvider("gateway"); !errors.Is(err, ErrInvalidIngressProvider) {
+		t.Errorf("expected ErrInvalidIngressProvider, got %v", err)
+	}
+
+	server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{IngressProvider: IngressProviderNginx}}
+	(&MCPServerReconciler{DefaultIngressClass: "traefik"}).setDefaults(server)
+	assertEqual(t, "ingressClass", server.Spec.IngressClass, "nginx")
+}
+
+func TestGatewayAPIProvider(t *testing.T) {
+	scheme := newServerTestScheme()
+	server := newProviderServer(IngressProviderGatewayAPI)
+	server.Spec.IngressStripPrefix = true
+	server.Status.IngressProvider = IngressProviderTraefik
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, ingress).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	if err := r.reconcileIngress(ctx, server); !errors.Is(err, ErrInvalidIngressProvider) {
+		t.Fatalf("expected ErrInvalidIngressProvider without MCP_GATEWAY, got %v", err)
+	}
+
+	r.Gateway = "gateways/public"
+	if err := r.reconcileIngress(ctx, server); err != nil {
+		t.Fatalf("reconcileIngress: %v", err)
+	}
+	assertEqual(t, "status provider", server.Status.IngressProvider, IngressProviderGatewayAPI)
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: "default"}, &networkingv1.Ingress{}); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Fatalf("expected the traefik Ingress to be deleted, got %v", err)
+	}
+
+	route := newHTTPRoute(server)
+	key := types.NamespacedName{Name: "test-server", Namespace: "default"}
+	if err := c.Get(ctx, key, route); err != nil {
+		t.Fatalf("get HTTPRoute: %v", err)
+	}
+	parents, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assertEqual(t, "parentRefs", fmt.Sprint(parents), "[map[name:public namespace:gateways]]")
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assertEqual(t, "hostnames", fmt.Sprint(hostnames), "[mcp.example.com]")
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assertEqual(t, "rules", fmt.Sprint(rules),
+		"[map[backendRefs:[map[name:test-server port:80]] filters:[map[type:URLRewrite urlRewrite:map[path:map[replacePrefixMatch:/ type:ReplacePrefixMatch]]]] matches:[map[path:map[type:PathPrefix value:/test-server/mcp]]]]]")
+
+	ready, err := r.checkIngressReady(ctx, server)
+	if err != nil || ready {
+		t.Fatalf("expected an unaccepted route not to be ready, got %v, %v", ready, err)
+	}
+	_ = unstructured.SetNestedSlice(route.Object, []any{
+		map[string]any{"conditions": []any{map[string]any{"type": "Accepted", "status": "True"}}},
+	}, "status", "parents")
+	if err := c.Update(ctx, route); err != nil {
+		t.Fatalf("update HTTPRoute: %v", err)
+	}
+	if ready, err := r.checkIngressReady(ctx, server); err != nil || !ready {
+		t.Fatalf("expected an accepted route to be ready, got %v, %v", ready, err)
+	}
+}
+
+func TestNoneIngressProvider(t *testing.T) {
+	scheme := newServerTestScheme()
+	server := newProviderServer(IngressProviderNone)
+	server.Status.IngressProvider = IngressProviderGatewayAPI
+	route := newHTTPRoute(server)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, route).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	if err := r.reconcileIngress(ctx, server); err != nil {
+		t.Fatalf("reconcileIngress: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: "default"}, newHTTPRoute(server)); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Fatalf("expected the HTTPRoute to be deleted, got %v", err)
+	}
+	if ready, err := r.checkIngressReady(ctx, server); err != nil || !ready {
+		t.Fatalf("expected the none provider to be ready, got %v, %v", ready, err)
+	}
+	assertEqual(t, "url", r.serverURL(server), "http://test-server.default.svc.cluster.local:80/test-server/mcp")
+
+	server.Spec.Auth = &mcpv1alpha1.AuthSpec{Type: AuthTypeBasic, SecretRef: "users"}
+	err := r.validateIngressProvider(ctx, server, logr.Discard())
+	if !errors.Is(err, ErrInvalidIngressProvider) {
+		t.Fatalf("expected ErrInvalidIngressProvider for spec.auth, got %v", err)
+	}
+	assertEqual(t, "message", server.Status.Message, "spec.auth not supported by the none ingress provider")
+}
@@ -0,0 +1,110 @@
+package operator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// AnnotationDefaultIngressClass marks the cluster's default IngressClass.
+	AnnotationDefaultIngressClass = "ingressclass.kubernetes.io/is-default-class"
+
+	// DefaultIngressClassDetectInterval is how often the IngressClasses are listed again.
+	DefaultIngressClassDetectInterval = 5 * time.Minute
+)
+
+// IngressClassDetector tracks the cluster's default IngressClass, used for servers that set
+// no spec.ingressClass when the operator has no configured default. It is a manager Runnable
+// that lists the IngressClasses on start and then every Interval.
+type IngressClassDetector struct {
+	// Reader lists IngressClasses; the manager's API reader avoids caching them.
+	Reader client.Reader
+	// Interval between detections; zero means DefaultIngressClassDetectInterval.
+	Interval time.Duration
+
+	mu    sync.RWMutex
+	class string
+}
+
+// Class returns the last detected class, or "" if none was found.
+func (d *IngressClassDetector) Class() string {
+	if d == nil {
+		return ""
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.class
+}
+
+// Detect lists the IngressClasses and records the one to use.
+func (d *IngressClassDetector) Detect(ctx context.Context) (string, error) {
+	var classes networkingv1.IngressClassList
+	if err := d.Reader.List(ctx, &classes); err != nil {
+		return "", err
+	}
+	class := pickIngressClass(classes.Items)
+	d.mu.Lock()
+	d.class = class
+	d.mu.Unlock()
+	return class, nil
+}
+
+// Start detects the class until ctx is done. A failed detection keeps the previous class.
+func (d *IngressClassDetector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("ingressclass")
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultIngressClassDetectInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	previous := d.Class()
+	for {
+		if class, err := d.Detect(ctx); err != nil {
+			logger.Error(err, "Failed to list IngressClasses")
+		} else if class != previous {
+			logger.Info("Detected default ingress class", "class", class)
+			previous = class
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica detect the class, since any of them may reconcile.
+func (d *IngressClassDetector) NeedLeaderElection() bool {
+	return false
+}
+
+// pickIngressClass returns the class annotated as the cluster default (the first by name if
+// several are), or the only class when there is exactly one, or "".
+func pickIngressClass(classes []networkingv1.IngressClass) string {
+	names := make([]string, 0, len(classes))
+	defaults := map[string]bool{}
+	for _, class := range classes {
+		names = append(names, class.Name)
+		if class.Annotations[AnnotationDefaultIngressClass] == "true" {
+			defaults[class.Name] = true
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if defaults[name] {
+			return name
+		}
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return ""
+}
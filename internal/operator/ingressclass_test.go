@@ -0,0 +1,89 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func ingressClass(name string, isDefault bool) networkingv1.IngressClass {
+	class := networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if isDefault {
+		class.Annotations = map[string]string{AnnotationDefaultIngressClass: "true"}
+	}
+	return class
+}
+
+func TestPickIngressClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []networkingv1.IngressClass
+		want    string
+	}{
+		{name: "no classes", want: ""},
+		{name: "single class", classes: []networkingv1.IngressClass{ingressClass("nginx", false)}, want: "nginx"},
+		{name: "annotated default", classes: []networkingv1.IngressClass{ingressClass("traefik", false), ingressClass("nginx", true)}, want: "nginx"},
+		{name: "several defaults", classes: []networkingv1.IngressClass{ingressClass("traefik", true), ingressClass("nginx", true)}, want: "nginx"},
+		{name: "several without default", classes: []networkingv1.IngressClass{ingressClass("traefik", false), ingressClass("nginx", false)}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, "class", pickIngressClass(tt.classes), tt.want)
+		})
+	}
+}
+
+func TestIngressClassDetector(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	nginx := ingressClass("nginx", false)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&nginx).Build()
+	detector := &IngressClassDetector{Reader: c}
+
+	if class, err := detector.Detect(context.Background()); err != nil || class != "nginx" {
+		t.Fatalf("Detect() = %q, %v", class, err)
+	}
+	assertEqual(t, "class", detector.Class(), "nginx")
+
+	if err := c.Create(context.Background(), &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "traefik"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := detector.Detect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, "class without a default", detector.Class(), "")
+
+	var nilDetector *IngressClassDetector
+	assertEqual(t, "nil detector", nilDetector.Class(), "")
+}
+
+func TestSetDefaultsDetectedIngressClass(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	nginx := ingressClass("nginx", true)
+	detector := &IngressClassDetector{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&nginx).Build()}
+	if _, err := detector.Detect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	server := func(class string) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "api"}, Spec: mcpv1alpha1.MCPServerSpec{IngressClass: class}}
+	}
+
+	detected := server("")
+	(&MCPServerReconciler{IngressClasses: detector}).setDefaults(detected)
+	assertEqual(t, "detected", detected.Spec.IngressClass, "nginx")
+
+	configured := server("")
+	(&MCPServerReconciler{IngressClasses: detector, DefaultIngressClass: "istio"}).setDefaults(configured)
+	assertEqual(t, "operator default", configured.Spec.IngressClass, "istio")
+
+	explicit := server("traefik")
+	(&MCPServerReconciler{IngressClasses: detector}).setDefaults(explicit)
+	assertEqual(t, "spec", explicit.Spec.IngressClass, "traefik")
+}
@@ -0,0 +1,96 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// ParseIPFamilies parses a comma-separated list of IP families, such as
+// MCP_SERVICE_IP_FAMILIES, primary first. An empty value returns nil.
+func ParseIPFamilies(value string) ([]corev1.IPFamily, error) {
+	var families []corev1.IPFamily
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		family := corev1.IPFamily(field)
+		if family != corev1.IPv4Protocol && family != corev1.IPv6Protocol {
+			return nil, fmt.Errorf("%w: unknown IP family %q (use IPv4 or IPv6)", ErrInvalidIPFamilies, field)
+		}
+		families = append(families, family)
+	}
+	if err := checkIPFamilies(families, ""); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIPFamilies, err)
+	}
+	return families, nil
+}
+
+// serviceIPFamilies returns the ipFamilies and ipFamilyPolicy of mcpServer's Service. Both
+// are nil when neither the server nor the operator sets families, so the cluster default
+// applies and the API server keeps the families of an existing Service.
+func (r *MCPServerReconciler) serviceIPFamilies(mcpServer *mcpv1alpha1.MCPServer) ([]corev1.IPFamily, *corev1.IPFamilyPolicy) {
+	families := r.DefaultIPFamilies
+	if len(mcpServer.Spec.IPFamilies) > 0 {
+		families = make([]corev1.IPFamily, 0, len(mcpServer.Spec.IPFamilies))
+		for _, family := range mcpServer.Spec.IPFamilies {
+			families = append(families, corev1.IPFamily(family))
+		}
+	}
+	var policy corev1.IPFamilyPolicy
+	switch {
+	case mcpServer.Spec.IPFamilyPolicy != "":
+		policy = corev1.IPFamilyPolicy(mcpServer.Spec.IPFamilyPolicy)
+	case len(families) == 2:
+		policy = corev1.IPFamilyPolicyPreferDualStack
+	case len(families) == 1:
+		policy = corev1.IPFamilyPolicySingleStack
+	default:
+		return nil, nil
+	}
+	return families, &policy
+}
+
+// validateIPFamilies rejects IP families the API server would refuse on the Service, so the
+// problem shows in the MCPServer status instead of as a failing Service update.
+func (r *MCPServerReconciler) validateIPFamilies(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	families, policy := r.serviceIPFamilies(mcpServer)
+	if policy == nil {
+		return nil
+	}
+	err := checkIPFamilies(families, *policy)
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	contextMap := map[string]any{
+		"mcpServer":      mcpServer.Name,
+		"namespace":      mcpServer.Namespace,
+		"ipFamilies":     families,
+		"ipFamilyPolicy": *policy,
+	}
+	wrapped := wrapOperatorError(fmt.Errorf("%w: %s", ErrInvalidIPFamilies, message), "Invalid IP families", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, wrapped, "Invalid IP families")
+	return wrapped
+}
+
+// checkIPFamilies checks that families are distinct and fit policy; an empty policy only
+// checks the families.
+func checkIPFamilies(families []corev1.IPFamily, policy corev1.IPFamilyPolicy) error {
+	switch {
+	case len(families) > 2:
+		return fmt.Errorf("at most two IP families can be set, got %d", len(families))
+	case len(families) == 2 && families[0] == families[1]:
+		return fmt.Errorf("ipFamilies lists %s twice", families[0])
+	case len(families) == 2 && policy == corev1.IPFamilyPolicySingleStack:
+		return fmt.Errorf("ipFamilyPolicy SingleStack allows one IP family, got %d", len(families))
+	}
+	return nil
+}
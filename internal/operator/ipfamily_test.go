@@ -0,0 +1,107 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestReconcileServiceIPFamilies(t *testing.T) {
+	scheme := newHealthTestScheme()
+	ctx := context.Background()
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "chat", Namespace: "team-a"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "chat", Port: 8088, ServicePort: 80},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	get := func() *corev1.Service {
+		t.Helper()
+		if err := r.reconcileService(ctx, server); err != nil {
+			t.Fatalf("reconcileService() error: %v", err)
+		}
+		var service corev1.Service
+		if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "chat"}, &service); err != nil {
+			t.Fatal(err)
+		}
+		return &service
+	}
+
+	service := get()
+	if service.Spec.IPFamilies != nil || service.Spec.IPFamilyPolicy != nil {
+		t.Fatalf("expected the cluster default IP families, got %v %v", service.Spec.IPFamilies, service.Spec.IPFamilyPolicy)
+	}
+
+	r.DefaultIPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+	service = get()
+	assertEqual(t, "ipFamilies", fmt.Sprint(service.Spec.IPFamilies), "[IPv4 IPv6]")
+	assertEqual(t, "ipFamilyPolicy", *service.Spec.IPFamilyPolicy, corev1.IPFamilyPolicyPreferDualStack)
+
+	server.Spec.IPFamilies = []string{"IPv6"}
+	server.Spec.IPFamilyPolicy = "RequireDualStack"
+	service = get()
+	assertEqual(t, "ipFamilies", fmt.Sprint(service.Spec.IPFamilies), "[IPv6]")
+	assertEqual(t, "ipFamilyPolicy", *service.Spec.IPFamilyPolicy, corev1.IPFamilyPolicyRequireDualStack)
+}
+
+func TestValidateIPFamilies(t *testing.T) {
+	scheme := newHealthTestScheme()
+	tests := []struct {
+		name     string
+		families []string
+		policy   string
+		wantErr  string
+	}{
+		{name: "dual stack", families: []string{"IPv6", "IPv4"}},
+		{name: "single stack with two families", families: []string{"IPv4", "IPv6"}, policy: "SingleStack", wantErr: "SingleStack allows one IP family"},
+		{name: "duplicate family", families: []string{"IPv6", "IPv6"}, wantErr: "IPv6 twice"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &mcpv1alpha1.MCPServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "chat", Namespace: "team-a"},
+				Spec:       mcpv1alpha1.MCPServerSpec{IPFamilies: tc.families, IPFamilyPolicy: tc.policy},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(server).Build()
+			r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+			err := r.validateIPFamilies(context.Background(), server, logr.Discard())
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateIPFamilies() error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidIPFamilies) || !strings.Contains(server.Status.Message, tc.wantErr) {
+				t.Fatalf("expected ErrInvalidIPFamilies with %q, got %v (%s)", tc.wantErr, err, server.Status.Message)
+			}
+		})
+	}
+}
+
+func TestParseIPFamilies(t *testing.T) {
+	families, err := ParseIPFamilies(" IPv6, IPv4 ")
+	if err != nil {
+		t.Fatalf("ParseIPFamilies() error: %v", err)
+	}
+	assertEqual(t, "families", fmt.Sprint(families), "[IPv6 IPv4]")
+	if families, err := ParseIPFamilies(""); err != nil || families != nil {
+		t.Fatalf("expected no families for an empty value, got %v %v", families, err)
+	}
+	for _, value := range []string{"ipv4", "IPv4,IPv4", "IPv4,IPv6,IPv4"} {
+		if _, err := ParseIPFamilies(value); !errors.Is(err, ErrInvalidIPFamilies) {
+			t.Errorf("ParseIPFamilies(%q): expected ErrInvalidIPFamilies, got %v", value, err)
+		}
+	}
+}
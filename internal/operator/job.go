@@ -0,0 +1,265 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const (
+	// ModeServer runs the image as a Deployment behind a Service and Ingress.
+	ModeServer = "server"
+	// ModeJob runs the image once as a Job.
+	ModeJob = "job"
+	// AnnotationServerGeneration records the MCPServer generation a Job was created
+	// for; a Job from an older generation is replaced.
+	AnnotationServerGeneration = "mcpruntime.org/server-generation"
+)
+
+// Phases of a job-mode MCPServer.
+const (
+	phaseJobPending   = "Pending"
+	phaseJobRunning   = "Running"
+	phaseJobSucceeded = "Succeeded"
+	phaseJobFailed    = "Failed"
+)
+
+// jobMode reports whether mcpServer runs as a one-shot Job.
+func jobMode(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.Mode == ModeJob
+}
+
+// jobFinished reports whether the Job already ran to completion for the current spec.
+// The Job itself may be gone by then (ttlSecondsAfterFinished), so this is read from
+// the status, which outlives it.
+func jobFinished(mcpServer *mcpv1alpha1.MCPServer) bool {
+	phase := mcpServer.Status.Phase
+	return (phase == phaseJobSucceeded || phase == phaseJobFailed) &&
+		mcpServer.Status.ObservedGeneration == mcpServer.Generation
+}
+
+// reconcileJobServer is reconcileServer for job mode: there is no Service or Ingress,
+// and the status follows the Job to completion instead of readiness.
+func (r *MCPServerReconciler) reconcileJobServer(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) (ctrl.Result, error) {
+	if err := r.validateEnvTemplates(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+	if err := r.verifyImageSignature(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+	if err := r.reconcileResources(ctx, mcpServer, logger); err != nil {
+		return requeueResult(err)
+	}
+
+	var job *batchv1.Job
+	existing := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, existing); err == nil {
+		job = existing
+	} else if !errors.IsNotFound(err) {
+		return requeueResult(err)
+	}
+	phase, message, completion := jobPhase(mcpServer, job)
+
+	var failure *deploymentFailure
+	if phase == phaseJobPending || phase == phaseJobRunning {
+		var err error
+		if failure, err = r.diagnosePods(ctx, mcpServer); err != nil {
+			logger.Error(err, "Failed to diagnose Job pods", "name", mcpServer.Name)
+		}
+	}
+	setDegradedCondition(mcpServer, failure)
+	if failure != nil {
+		message = fmt.Sprintf("Job degraded (%s): %s", failure.Reason, failure.Message)
+	}
+	r.updateJobStatus(ctx, mcpServer, phase, message, completion)
+
+	logger.Info("Successfully reconciled MCPServer", "name", mcpServer.Name, "phase", phase)
+	return ctrl.Result{}, nil
+}
+
+// reconcileJob creates the Job for the current generation. Pod templates of a Job are
+// immutable, so a Job from an older generation is deleted and created again on the next
+// reconcile; only backoffLimit and ttlSecondsAfterFinished are updated in place.
+func (r *MCPServerReconciler) reconcileJob(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	logger := log.FromContext(ctx)
+
+	existing := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: mcpServer.Name, Namespace: mcpServer.Namespace}, existing)
+	switch {
+	case errors.IsNotFound(err):
+		if jobFinished(mcpServer) {
+			return nil
+		}
+		job, err := r.buildJob(ctx, mcpServer)
+		if err != nil {
+			return err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return err
+		}
+		logger.Info("Job created", "name", job.Name)
+		return nil
+	case err != nil:
+		return err
+	case existing.DeletionTimestamp != nil:
+		return nil
+	case existing.Annotations[AnnotationServerGeneration] != strconv.FormatInt(mcpServer.Generation, 10):
+		if err := r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Job replaced for new spec", "name", existing.Name)
+		return nil
+	}
+
+	backoffLimit, ttl := jobLimits(mcpServer)
+	if equalInt32Ptr(existing.Spec.BackoffLimit, backoffLimit) && equalInt32Ptr(existing.Spec.TTLSecondsAfterFinished, ttl) {
+		return nil
+	}
+	if backoffLimit != nil {
+		existing.Spec.BackoffLimit = backoffLimit
+	}
+	existing.Spec.TTLSecondsAfterFinished = ttl
+	if err := r.Update(ctx, existing); err != nil {
+		return err
+	}
+	logger.Info("Job reconciled", "operation", "updated", "name", existing.Name)
+	return nil
+}
+
+// buildJob renders the Job of mcpServer: the server container without ports or probes,
+// run until it exits.
+func (r *MCPServerReconciler) buildJob(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*batchv1.Job, error) {
+	image, err := r.resolveImage(ctx, mcpServer)
+	if err != nil {
+		return nil, err
+	}
+	if image, err = r.pinnedImage(ctx, image); err != nil {
+		return nil, err
+	}
+	env, err := r.buildEnvVars(mcpServer)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		LabelApp:       mcpServer.Name,
+		LabelManagedBy: LabelManagedByValue,
+	}
+	container := corev1.Container{
+		Name:            mcpServer.Name,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env:             env,
+		EnvFrom:         mcpServer.Spec.EnvFrom,
+	}
+	if err := applyContainerResources(&container, mergeResourceDefaults(mcpServer.Spec.Resources, r.resourceDefaultsFor(mcpServer.Namespace))); err != nil {
+		return nil, err
+	}
+
+	backoffLimit, ttl := jobLimits(mcpServer)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mcpServer.Name,
+			Namespace:   mcpServer.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{AnnotationServerGeneration: strconv.FormatInt(mcpServer.Generation, 10)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            backoffLimit,
+			TTLSecondsAfterFinished: ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels, Annotations: r.podAnnotations(mcpServer)},
+				Spec: corev1.PodSpec{
+					RestartPolicy:             corev1.RestartPolicyNever,
+					ImagePullSecrets:          r.buildImagePullSecrets(mcpServer),
+					Containers:                []corev1.Container{container},
+					PriorityClassName:         mcpServer.Spec.PriorityClassName,
+					TopologySpreadConstraints: buildTopologySpreadConstraints(mcpServer.Spec.TopologySpreadConstraints, map[string]string{LabelApp: mcpServer.Name}),
+				},
+			},
+		},
+	}
+	if err := ctrl.SetControllerReference(mcpServer, job, r.Scheme); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// jobLimits returns spec.job.backoffLimit and spec.job.ttlSecondsAfterFinished.
+func jobLimits(mcpServer *mcpv1alpha1.MCPServer) (*int32, *int32) {
+	if mcpServer.Spec.Job == nil {
+		return nil, nil
+	}
+	return mcpServer.Spec.Job.BackoffLimit, mcpServer.Spec.Job.TTLSecondsAfterFinished
+}
+
+func equalInt32Ptr(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// jobPhase derives the phase, message and completion time from job, which is nil when
+// the Job does not exist.
+func jobPhase(mcpServer *mcpv1alpha1.MCPServer, job *batchv1.Job) (string, string, *metav1.Time) {
+	if job == nil {
+		if jobFinished(mcpServer) {
+			return mcpServer.Status.Phase, mcpServer.Status.Message, mcpServer.Status.CompletionTime
+		}
+		return phaseJobPending, "Waiting for Job to be created", nil
+	}
+	if job.DeletionTimestamp != nil || job.Annotations[AnnotationServerGeneration] != strconv.FormatInt(mcpServer.Generation, 10) {
+		return phaseJobPending, "Replacing Job for the new spec", nil
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return phaseJobSucceeded, "Job completed", job.Status.CompletionTime
+		case batchv1.JobFailed:
+			message := "Job failed"
+			if cond.Message != "" {
+				message += ": " + cond.Message
+			}
+			return phaseJobFailed, message, nil
+		}
+	}
+	if job.Status.Active > 0 {
+		return phaseJobRunning, fmt.Sprintf("Job running (%d active, %d failed)", job.Status.Active, job.Status.Failed), nil
+	}
+	return phaseJobPending, "Job created", nil
+}
+
+// updateJobStatus is updateStatus for job mode. Ready is True once the Job succeeded;
+// the readiness metric only drops for failed Jobs, so running ones don't alert.
+func (r *MCPServerReconciler) updateJobStatus(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, phase, message string, completion *metav1.Time) {
+	mcpServer.Status.Phase = phase
+	mcpServer.Status.Message = message
+	mcpServer.Status.DeploymentReady = false
+	mcpServer.Status.ServiceReady = false
+	mcpServer.Status.IngressReady = false
+	mcpServer.Status.URL = ""
+	mcpServer.Status.CompletionTime = completion
+	setReadyCondition(mcpServer, phase, message, phase == phaseJobSucceeded)
+	markObservedGeneration(mcpServer)
+	recordServerReady(mcpServer, phase != phaseJobFailed)
+
+	if err := r.Status().Update(ctx, mcpServer); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update MCPServer status")
+	}
+}
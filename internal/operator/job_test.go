@@ -0,0 +1,148 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newJobTestScheme() *runtime.Scheme {
+	scheme := newHealthTestScheme()
+	_ = batchv1.AddToScheme(scheme)
+	return scheme
+}
+
+func newJobServer(generation int64) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch-task", Namespace: "default", Generation: generation, UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:    "registry.local/batch-task",
+			ImageTag: "v1",
+			Mode:     ModeJob,
+			Job:      &mcpv1alpha1.JobSpec{BackoffLimit: int32Ptr(2), TTLSecondsAfterFinished: int32Ptr(300)},
+		},
+	}
+}
+
+func TestReconcileJobCreatesJob(t *testing.T) {
+	scheme := newJobTestScheme()
+	mcpServer := newJobServer(1)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	if err := r.reconcileJob(context.Background(), mcpServer); err != nil {
+		t.Fatalf("reconcileJob: %v", err)
+	}
+	var job batchv1.Job
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "batch-task", Namespace: "default"}, &job); err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	assertEqual(t, "backoffLimit", *job.Spec.BackoffLimit, int32(2))
+	assertEqual(t, "ttl", *job.Spec.TTLSecondsAfterFinished, int32(300))
+	assertEqual(t, "restartPolicy", job.Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyNever)
+	assertEqual(t, "generation", job.Annotations[AnnotationServerGeneration], "1")
+	if len(job.Spec.Template.Spec.Containers) != 1 {
+		t.Fatalf("expected one container, got %d", len(job.Spec.Template.Spec.Containers))
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if len(container.Ports) != 0 || container.ReadinessProbe != nil {
+		t.Errorf("job container should have no ports or probes: %+v", container)
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Name != "batch-task" {
+		t.Errorf("expected owner reference to the MCPServer, got %+v", job.OwnerReferences)
+	}
+}
+
+func TestReconcileJobNotRecreatedAfterCompletion(t *testing.T) {
+	scheme := newJobTestScheme()
+	mcpServer := newJobServer(1)
+	mcpServer.Status.Phase = phaseJobSucceeded
+	mcpServer.Status.ObservedGeneration = 1
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	if err := r.reconcileJob(context.Background(), mcpServer); err != nil {
+		t.Fatalf("reconcileJob: %v", err)
+	}
+	err := c.Get(context.Background(), types.NamespacedName{Name: "batch-task", Namespace: "default"}, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected no Job after TTL cleanup of a finished run, got %v", err)
+	}
+}
+
+func TestReconcileJobReplacesStaleGeneration(t *testing.T) {
+	scheme := newJobTestScheme()
+	mcpServer := newJobServer(2)
+	stale := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name: "batch-task", Namespace: "default",
+		Annotations: map[string]string{AnnotationServerGeneration: "1"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpServer, stale).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	if err := r.reconcileJob(context.Background(), mcpServer); err != nil {
+		t.Fatalf("reconcileJob: %v", err)
+	}
+	err := c.Get(context.Background(), types.NamespacedName{Name: "batch-task", Namespace: "default"}, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected stale Job to be deleted, got %v", err)
+	}
+	if err := r.reconcileJob(context.Background(), mcpServer); err != nil {
+		t.Fatalf("reconcileJob: %v", err)
+	}
+	var job batchv1.Job
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "batch-task", Namespace: "default"}, &job); err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	assertEqual(t, "generation", job.Annotations[AnnotationServerGeneration], "2")
+}
+
+func TestJobPhase(t *testing.T) {
+	mcpServer := newJobServer(1)
+	completed := metav1.Unix(5000, 0)
+	job := func(status batchv1.JobStatus) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationServerGeneration: "1"}},
+			Status:     status,
+		}
+	}
+
+	phase, _, _ := jobPhase(mcpServer, nil)
+	assertEqual(t, "missing", phase, phaseJobPending)
+
+	phase, _, _ = jobPhase(mcpServer, job(batchv1.JobStatus{Active: 1}))
+	assertEqual(t, "active", phase, phaseJobRunning)
+
+	phase, _, completion := jobPhase(mcpServer, job(batchv1.JobStatus{
+		Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+		CompletionTime: &completed,
+	}))
+	assertEqual(t, "complete", phase, phaseJobSucceeded)
+	if completion == nil || !completion.Equal(&completed) {
+		t.Errorf("completion time = %v, want %v", completion, completed)
+	}
+
+	phase, message, _ := jobPhase(mcpServer, job(batchv1.JobStatus{
+		Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}},
+	}))
+	assertEqual(t, "failed", phase, phaseJobFailed)
+	assertEqual(t, "failed message", message, "Job failed: Job has reached the specified backoff limit")
+
+	mcpServer.Status.Phase = phaseJobSucceeded
+	mcpServer.Status.ObservedGeneration = 1
+	mcpServer.Status.CompletionTime = &completed
+	phase, _, completion = jobPhase(mcpServer, nil)
+	assertEqual(t, "finished and cleaned up", phase, phaseJobSucceeded)
+	if completion == nil {
+		t.Error("expected completion time to be kept after the Job is gone")
+	}
+}
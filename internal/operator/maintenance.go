@@ -0,0 +1,100 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const (
+	// MaintenanceConfigMapName is the ConfigMap in the operator namespace that
+	// toggles maintenance mode for the whole operator.
+	MaintenanceConfigMapName = "mcp-runtime-maintenance"
+	// MaintenancePausedKey set to "true" pauses all child resource changes.
+	MaintenancePausedKey = "paused"
+	// MaintenanceReasonKey is an optional note shown in MCPServer status.
+	MaintenanceReasonKey = "reason"
+)
+
+// maintenanceMode reports whether the maintenance ConfigMap pauses reconciliation.
+// A missing or unreadable ConfigMap means normal operation.
+func (r *MCPServerReconciler) maintenanceMode(ctx context.Context, logger logr.Logger) (bool, string) {
+	if r.MaintenanceNamespace == "" {
+		return false, ""
+	}
+	var cm corev1.ConfigMap
+	key := types.NamespacedName{Namespace: r.MaintenanceNamespace, Name: MaintenanceConfigMapName}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "Failed to read maintenance ConfigMap; reconciling normally", "configMap", key.String())
+		}
+		return false, ""
+	}
+	return cm.Data[MaintenancePausedKey] == "true", cm.Data[MaintenanceReasonKey]
+}
+
+// reconcilePaused reports readiness without touching child resources or the spec.
+func (r *MCPServerReconciler) reconcilePaused(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, reason string, logger logr.Logger) (ctrl.Result, error) {
+	deploymentReady, serviceReady, ingressReady, err := r.checkResourceReadiness(ctx, mcpServer)
+	if err != nil {
+		return requeueResult(err)
+	}
+	phase, _ := determinePhase(deploymentReady, serviceReady, ingressReady)
+
+	message := "Maintenance mode: changes are not applied"
+	if reason != "" {
+		message = fmt.Sprintf("%s (%s)", message, reason)
+	}
+	setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+		Type:    ConditionPaused,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonMaintenanceMode,
+		Message: message,
+	})
+	r.updateStatus(ctx, mcpServer, phase, message, deploymentReady, serviceReady, ingressReady)
+	logger.Info("Skipping MCPServer changes in maintenance mode", "name", mcpServer.Name)
+	return ctrl.Result{}, nil
+}
+
+// clearPausedCondition marks a previously paused server as reconciling again.
+func clearPausedCondition(mcpServer *mcpv1alpha1.MCPServer) {
+	if findCondition(mcpServer.Status.Conditions, ConditionPaused) == nil {
+		return
+	}
+	setCondition(&mcpServer.Status.Conditions, mcpv1alpha1.Condition{
+		Type:   ConditionPaused,
+		Status: metav1.ConditionFalse,
+		Reason: ReasonReconciling,
+	})
+}
+
+// isMaintenanceConfigMap matches the maintenance ConfigMap.
+func (r *MCPServerReconciler) isMaintenanceConfigMap(obj client.Object) bool {
+	return obj.GetNamespace() == r.MaintenanceNamespace && obj.GetName() == MaintenanceConfigMapName
+}
+
+// requestsForAllServers enqueues every MCPServer. It runs when maintenance mode is
+// toggled, so resuming applies changes made while paused, and when the
+// MCPRuntimeConfig changes the platform defaults.
+func (r *MCPServerReconciler) requestsForAllServers(ctx context.Context, obj client.Object) []reconcile.Request {
+	var servers mcpv1alpha1.MCPServerList
+	if err := r.List(ctx, &servers); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "Failed to list MCPServers to requeue", "trigger", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(servers.Items))
+	for _, s := range servers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: s.Namespace, Name: s.Name}})
+	}
+	return requests
+}
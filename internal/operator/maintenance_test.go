@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestReconcileMaintenanceMode(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	newServer := func() *mcpv1alpha1.MCPServer {
+		replicas := int32(1)
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "paused-server", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "test-image",
+				ImageTag:     "latest",
+				Port:         8088,
+				ServicePort:  80,
+				Replicas:     &replicas,
+				IngressHost:  "example.com",
+				IngressPath:  "/paused-server/mcp",
+				IngressClass: "traefik",
+			},
+		}
+	}
+	newConfigMap := func(paused string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: MaintenanceConfigMapName, Namespace: "mcp-runtime"},
+			Data:       map[string]string{MaintenancePausedKey: paused, MaintenanceReasonKey: "cluster upgrade"},
+		}
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "paused-server", Namespace: "default"}}
+
+	t.Run("paused leaves child resources untouched", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer(), newConfigMap("true")).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme, MaintenanceNamespace: "mcp-runtime"}
+
+		result, err := r.Reconcile(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertEqual(t, "requeue", result.Requeue, false)
+		if err := client.Get(context.Background(), request.NamespacedName, &appsv1.Deployment{}); !errors.IsNotFound(err) {
+			t.Fatalf("expected no Deployment while paused, got %v", err)
+		}
+
+		updated := &mcpv1alpha1.MCPServer{}
+		if err := client.Get(context.Background(), request.NamespacedName, updated); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		cond := findCondition(updated.Status.Conditions, ConditionPaused)
+		if cond == nil {
+			t.Fatal("expected Paused condition")
+		}
+		assertEqual(t, "status", cond.Status, metav1.ConditionTrue)
+		assertEqual(t, "reason", cond.Reason, ReasonMaintenanceMode)
+		assertEqual(t, "message", updated.Status.Message, "Maintenance mode: changes are not applied (cluster upgrade)")
+	})
+
+	t.Run("resumed reconciles and clears the condition", func(t *testing.T) {
+		server := newServer()
+		server.Status.Conditions = []mcpv1alpha1.Condition{{Type: ConditionPaused, Status: metav1.ConditionTrue, Reason: ReasonMaintenanceMode}}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, newConfigMap("false")).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme, MaintenanceNamespace: "mcp-runtime"}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := client.Get(context.Background(), request.NamespacedName, &appsv1.Deployment{}); err != nil {
+			t.Fatalf("expected Deployment after resume: %v", err)
+		}
+
+		updated := &mcpv1alpha1.MCPServer{}
+		if err := client.Get(context.Background(), request.NamespacedName, updated); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		cond := findCondition(updated.Status.Conditions, ConditionPaused)
+		if cond == nil {
+			t.Fatal("expected Paused condition to be kept")
+		}
+		assertEqual(t, "status", cond.Status, metav1.ConditionFalse)
+		assertEqual(t, "reason", cond.Reason, ReasonReconciling)
+	})
+
+	t.Run("disabled ignores the ConfigMap", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer(), newConfigMap("true")).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := client.Get(context.Background(), request.NamespacedName, &appsv1.Deployment{}); err != nil {
+			t.Fatalf("expected Deployment without maintenance mode: %v", err)
+		}
+	})
+}
+
+func TestRequestsForMaintenance(t *testing.T) {
+	scheme := newHealthTestScheme()
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns1"}},
+		&mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns2"}},
+	).Build()
+	r := MCPServerReconciler{Client: client, Scheme: scheme, MaintenanceNamespace: "mcp-runtime"}
+
+	requests := r.requestsForAllServers(context.Background(), newConfigMapObject(MaintenanceConfigMapName, "mcp-runtime"))
+	assertEqual(t, "requests", len(requests), 2)
+
+	assertEqual(t, "matches", r.isMaintenanceConfigMap(newConfigMapObject(MaintenanceConfigMapName, "mcp-runtime")), true)
+	assertEqual(t, "other name", r.isMaintenanceConfigMap(newConfigMapObject("other", "mcp-runtime")), false)
+	assertEqual(t, "other namespace", r.isMaintenanceConfigMap(newConfigMapObject(MaintenanceConfigMapName, "default")), false)
+}
+
+func newConfigMapObject(name, namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
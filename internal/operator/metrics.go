@@ -0,0 +1,46 @@
+package operator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// serverReady reports per-MCPServer readiness (1 ready, 0 not ready) on the
+// manager's /metrics endpoint; the bundled Grafana dashboard charts it.
+var serverReady = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "mcpruntime_mcpserver_ready",
+		Help: "Whether an MCPServer's Deployment, Service and Ingress are all ready (1) or not (0).",
+	},
+	[]string{"namespace", "name"},
+)
+
+// reconcileRetries counts failed reconciles by error class; conflict and
+// transient failures are retried with backoff, permanent ones are not.
+var reconcileRetries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "mcpruntime_reconcile_retries_total",
+		Help: "Failed MCPServer reconciles by error class (conflict, transient or permanent).",
+	},
+	[]string{"reason"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(serverReady, reconcileRetries, buildInfoGauge)
+}
+
+// recordServerReady publishes the readiness of mcpServer.
+func recordServerReady(mcpServer *mcpv1alpha1.MCPServer, ready bool) {
+	value := 0.0
+	if ready {
+		value = 1
+	}
+	serverReady.WithLabelValues(mcpServer.Namespace, mcpServer.Name).Set(value)
+}
+
+// forgetServer drops the readiness series of a deleted MCPServer.
+func forgetServer(namespace, name string) {
+	serverReady.DeleteLabelValues(namespace, name)
+}
@@ -0,0 +1,55 @@
+package operator
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestRecordServerReady(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "default"}}
+	gauge := serverReady.WithLabelValues("default", "metrics")
+
+	recordServerReady(server, true)
+	assertEqual(t, "ready", testutil.ToFloat64(gauge), 1.0)
+
+	recordServerReady(server, false)
+	assertEqual(t, "not ready", testutil.ToFloat64(gauge), 0.0)
+
+	forgetServer("default", "metrics")
+	assertEqual(t, "series still present", serverReady.DeleteLabelValues("default", "metrics"), false)
+}
+
+func TestSetBuildInfo(t *testing.T) {
+	previous := buildInfo
+	t.Cleanup(func() { buildInfo = previous })
+
+	SetBuildInfo(BuildInfo{Version: "v1.2.3", Commit: "abc1234"})
+	assertEqual(t, "version", OperatorVersion(), "v1.2.3")
+	gauge := buildInfoGauge.WithLabelValues("v1.2.3", "abc1234", previous.Date, runtime.Version())
+	assertEqual(t, "build_info", testutil.ToFloat64(gauge), 1.0)
+	assertEqual(t, "series", testutil.CollectAndCount(buildInfoGauge), 1)
+}
+
+// The manager's /metrics endpoint carries the Go runtime and process series registered by
+// controller-runtime next to the operator's own; `operator profile` docs rely on them.
+func TestRuntimeMetricsRegistered(t *testing.T) {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, family := range families {
+		found[family.GetName()] = true
+	}
+	for _, name := range []string{"go_goroutines", "go_memstats_heap_inuse_bytes", "process_resident_memory_bytes"} {
+		if !found[name] {
+			t.Errorf("expected %s on the manager's /metrics endpoint", name)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// NGINX mirror annotations. ingress-nginx sends a copy of every request matched by the
+// Ingress to the mirror target and discards its response.
+const (
+	nginxMirrorTargetAnnotation      = "nginx.ingress.kubernetes.io/mirror-target"
+	nginxMirrorRequestBodyAnnotation = "nginx.ingress.kubernetes.io/mirror-request-body"
+)
+
+// mirrorEnabled reports whether mcpServer's traffic is mirrored to a shadow server.
+func mirrorEnabled(mcpServer *mcpv1alpha1.MCPServer) bool {
+	return mcpServer.Spec.Mirror != nil && mcpServer.Spec.Mirror.TargetServer != ""
+}
+
+// mirrorPercentage returns the share of requests mirrored, defaulting to all of them.
+func mirrorPercentage(mirror *mcpv1alpha1.MirrorSpec) int32 {
+	if mirror.Percentage == 0 {
+		return 100
+	}
+	return mirror.Percentage
+}
+
+// mirrorAnnotations returns the Ingress annotations mirroring requests to target's Service.
+// The copy carries the rewritten path, so the shadow sees the same path as the server.
+func mirrorAnnotations(target *mcpv1alpha1.MCPServer) map[string]string {
+	port := target.Spec.ServicePort
+	if port == 0 {
+		port = 80
+	}
+	return map[string]string{
+		nginxMirrorTargetAnnotation:      fmt.Sprintf("http://%s.%s.svc.cluster.local:%d$uri$is_args$args", target.Name, target.Namespace, port),
+		nginxMirrorRequestBodyAnnotation: "on",
+	}
+}
+
+// mirrorTarget returns the shadow MCPServer of mcpServer.
+func (r *MCPServerReconciler) mirrorTarget(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) (*mcpv1alpha1.MCPServer, error) {
+	target := &mcpv1alpha1.MCPServer{}
+	key := types.NamespacedName{Namespace: mcpServer.Namespace, Name: mcpServer.Spec.Mirror.TargetServer}
+	if err := r.Get(ctx, key, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// validateMirror rejects mirrors the ingress class cannot render and mirrors to a missing
+// shadow server. A missing shadow is retried, so creating it later starts the mirror.
+func (r *MCPServerReconciler) validateMirror(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	if !mirrorEnabled(mcpServer) {
+		return nil
+	}
+	mirror := mcpServer.Spec.Mirror
+	sentinel := ErrInvalidMirror
+	var message string
+	switch {
+	case mcpServer.Spec.IngressClass != "nginx":
+		message = fmt.Sprintf("spec.mirror requires ingressClass nginx, got %q", mcpServer.Spec.IngressClass)
+	case mirrorPercentage(mirror) != 100:
+		message = fmt.Sprintf("spec.mirror.percentage %d is not supported: ingress-nginx mirrors every request, so use 100", mirror.Percentage)
+	case mirror.TargetServer == mcpServer.Name:
+		message = "spec.mirror.targetServer must name another MCPServer"
+	default:
+		if _, err := r.mirrorTarget(ctx, mcpServer); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			sentinel = ErrMirrorTargetNotFound
+			message = fmt.Sprintf("mirror target MCPServer %s/%s not found", mcpServer.Namespace, mirror.TargetServer)
+		}
+	}
+	if message == "" {
+		return nil
+	}
+	contextMap := map[string]any{
+		"mcpServer":    mcpServer.Name,
+		"namespace":    mcpServer.Namespace,
+		"ingressClass": mcpServer.Spec.IngressClass,
+		"targetServer": mirror.TargetServer,
+	}
+	err := wrapOperatorError(fmt.Errorf("%w: %s", sentinel, message), "Invalid mirror configuration", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Invalid mirror configuration")
+	return err
+}
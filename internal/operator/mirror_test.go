@@ -0,0 +1,91 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestMirrorAnnotations(t *testing.T) {
+	target := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-shadow", Namespace: "default"},
+		Spec:       mcpv1alpha1.MCPServerSpec{ServicePort: 8080},
+	}
+	got := mirrorAnnotations(target)
+	assertEqual(t, "target", got[nginxMirrorTargetAnnotation], "http://app-shadow.default.svc.cluster.local:8080$uri$is_args$args")
+	assertEqual(t, "request body", got[nginxMirrorRequestBodyAnnotation], "on")
+
+	target.Spec.ServicePort = 0
+	assertEqual(t, "default port", mirrorAnnotations(target)[nginxMirrorTargetAnnotation], "http://app-shadow.default.svc.cluster.local:80$uri$is_args$args")
+}
+
+func TestReconcileMirrorIngress(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	newServer := func(name, ingressClass string, mirror *mcpv1alpha1.MirrorSpec) *mcpv1alpha1.MCPServer {
+		replicas := int32(1)
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "registry.local/app",
+				ImageTag:     "v1",
+				Port:         8088,
+				ServicePort:  80,
+				Replicas:     &replicas,
+				IngressHost:  "example.com",
+				IngressPath:  "/" + name + "/mcp",
+				IngressClass: ingressClass,
+				Mirror:       mirror,
+			},
+		}
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "app", Namespace: "default"}}
+
+	t.Run("renders nginx mirror annotations", func(t *testing.T) {
+		server := newServer("app", "nginx", &mcpv1alpha1.MirrorSpec{TargetServer: "app-shadow"})
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, newServer("app-shadow", "nginx", nil)).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ingress := &networkingv1.Ingress{}
+		if err := client.Get(context.Background(), request.NamespacedName, ingress); err != nil {
+			t.Fatalf("expected Ingress: %v", err)
+		}
+		assertEqual(t, "mirror target", ingress.Annotations[nginxMirrorTargetAnnotation], "http://app-shadow.default.svc.cluster.local:80$uri$is_args$args")
+		assertEqual(t, "backend", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name, "app")
+	})
+
+	for name, tc := range map[string]struct {
+		server *mcpv1alpha1.MCPServer
+		want   error
+	}{
+		"ingress class without mirroring": {newServer("app", "traefik", &mcpv1alpha1.MirrorSpec{TargetServer: "app-shadow"}), ErrInvalidMirror},
+		"sampled percentage":              {newServer("app", "nginx", &mcpv1alpha1.MirrorSpec{TargetServer: "app-shadow", Percentage: 10}), ErrInvalidMirror},
+		"mirror to itself":                {newServer("app", "nginx", &mcpv1alpha1.MirrorSpec{TargetServer: "app"}), ErrInvalidMirror},
+		"missing target":                  {newServer("app", "nginx", &mcpv1alpha1.MirrorSpec{TargetServer: "missing"}), ErrMirrorTargetNotFound},
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.server, newServer("app-shadow", "nginx", nil)).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+			r := MCPServerReconciler{Client: client, Scheme: scheme}
+
+			if _, err := r.Reconcile(context.Background(), request); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			updated := &mcpv1alpha1.MCPServer{}
+			if err := client.Get(context.Background(), request.NamespacedName, updated); err != nil {
+				t.Fatalf("get MCPServer: %v", err)
+			}
+			assertEqual(t, "phase", updated.Status.Phase, "Error")
+		})
+	}
+}
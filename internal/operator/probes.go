@@ -0,0 +1,89 @@
+package operator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// Probe defaults. The startup probe allows 30 x 10s = 5 minutes for slow
+// starting servers before the container is restarted.
+const (
+	defaultLivenessInitialDelay  = 5
+	defaultLivenessPeriod        = 10
+	defaultReadinessInitialDelay = 3
+	defaultReadinessPeriod       = 5
+	defaultStartupPeriod         = 10
+	defaultStartupFailures       = 30
+)
+
+// buildProbes returns the liveness, readiness and (optional) startup probes
+// for mcpServer's container, all TCP checks on the server port. Probes the
+// server does not tune use the platform defaults, if any.
+func buildProbes(mcpServer *mcpv1alpha1.MCPServer, defaults *mcpv1alpha1.ProbesSpec) (liveness, readiness, startup *corev1.Probe) {
+	var tuning mcpv1alpha1.ProbesSpec
+	if defaults != nil {
+		tuning = *defaults
+	}
+	if probes := mcpServer.Spec.Probes; probes != nil {
+		if probes.Liveness != nil {
+			tuning.Liveness = probes.Liveness
+		}
+		if probes.Readiness != nil {
+			tuning.Readiness = probes.Readiness
+		}
+		if probes.Startup != nil {
+			tuning.Startup = probes.Startup
+		}
+	}
+	port := mcpServer.Spec.Port
+
+	liveness = tcpProbe(port)
+	liveness.InitialDelaySeconds = defaultLivenessInitialDelay
+	liveness.PeriodSeconds = defaultLivenessPeriod
+	applyProbeSpec(liveness, tuning.Liveness)
+
+	readiness = tcpProbe(port)
+	readiness.InitialDelaySeconds = defaultReadinessInitialDelay
+	readiness.PeriodSeconds = defaultReadinessPeriod
+	applyProbeSpec(readiness, tuning.Readiness)
+
+	if tuning.Startup != nil {
+		startup = tcpProbe(port)
+		startup.PeriodSeconds = defaultStartupPeriod
+		startup.FailureThreshold = defaultStartupFailures
+		applyProbeSpec(startup, tuning.Startup)
+	}
+	return liveness, readiness, startup
+}
+
+func tcpProbe(port int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(port)},
+		},
+	}
+}
+
+// applyProbeSpec overrides probe timing with the fields set in spec.
+func applyProbeSpec(probe *corev1.Probe, spec *mcpv1alpha1.ProbeSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+	if spec.SuccessThreshold != nil {
+		probe.SuccessThreshold = *spec.SuccessThreshold
+	}
+}
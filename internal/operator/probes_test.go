@@ -0,0 +1,49 @@
+package operator
+
+import (
+	"testing"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func TestBuildProbes(t *testing.T) {
+	t.Run("defaults without tuning", func(t *testing.T) {
+		server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{Port: 8088}}
+		liveness, readiness, startup := buildProbes(server, nil)
+
+		assertEqual(t, "liveness delay", liveness.InitialDelaySeconds, int32(5))
+		assertEqual(t, "liveness period", liveness.PeriodSeconds, int32(10))
+		assertEqual(t, "readiness delay", readiness.InitialDelaySeconds, int32(3))
+		assertEqual(t, "readiness period", readiness.PeriodSeconds, int32(5))
+		assertEqual(t, "liveness port", liveness.TCPSocket.Port.IntVal, int32(8088))
+		if startup != nil {
+			t.Fatalf("expected no startup probe, got %+v", startup)
+		}
+	})
+
+	t.Run("tuning and startup probe", func(t *testing.T) {
+		server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{
+			Port: 9000,
+			Probes: &mcpv1alpha1.ProbesSpec{
+				Liveness:  &mcpv1alpha1.ProbeSpec{FailureThreshold: int32Ptr(6), TimeoutSeconds: int32Ptr(5)},
+				Readiness: &mcpv1alpha1.ProbeSpec{InitialDelaySeconds: int32Ptr(0), SuccessThreshold: int32Ptr(2)},
+				Startup:   &mcpv1alpha1.ProbeSpec{FailureThreshold: int32Ptr(60)},
+			},
+		}}
+		liveness, readiness, startup := buildProbes(server, nil)
+
+		assertEqual(t, "liveness failures", liveness.FailureThreshold, int32(6))
+		assertEqual(t, "liveness timeout", liveness.TimeoutSeconds, int32(5))
+		assertEqual(t, "liveness period kept", liveness.PeriodSeconds, int32(10))
+		assertEqual(t, "readiness delay", readiness.InitialDelaySeconds, int32(0))
+		assertEqual(t, "readiness successes", readiness.SuccessThreshold, int32(2))
+		if startup == nil {
+			t.Fatal("expected startup probe")
+		}
+		assertEqual(t, "startup failures", startup.FailureThreshold, int32(60))
+		assertEqual(t, "startup period", startup.PeriodSeconds, int32(10))
+		assertEqual(t, "startup port", startup.TCPSocket.Port.IntVal, int32(9000))
+	})
+}
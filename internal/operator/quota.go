@@ -0,0 +1,131 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// QuotaConfig holds the namespace quota the operator enforces in every
+// namespace that contains MCPServer resources.
+type QuotaConfig struct {
+	RequestsCPU    string
+	RequestsMemory string
+	LimitsCPU      string
+	LimitsMemory   string
+	Pods           string
+	MaxCPU         string
+	MaxMemory      string
+}
+
+// Validate checks that every configured quantity parses.
+func (q *QuotaConfig) Validate() error {
+	for field, value := range map[string]string{
+		"requests.cpu":    q.RequestsCPU,
+		"requests.memory": q.RequestsMemory,
+		"limits.cpu":      q.LimitsCPU,
+		"limits.memory":   q.LimitsMemory,
+		"pods":            q.Pods,
+		"max.cpu":         q.MaxCPU,
+		"max.memory":      q.MaxMemory,
+	} {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("invalid quota %s %q: %w", field, value, err)
+		}
+	}
+	return nil
+}
+
+// capQuantity returns value, lowered to max when above it: the API server rejects a
+// LimitRange whose defaults exceed its max.
+func capQuantity(value string, max resource.Quantity) resource.Quantity {
+	qty := resource.MustParse(value)
+	if qty.Cmp(max) > 0 {
+		return max.DeepCopy()
+	}
+	return qty
+}
+
+// reconcileNamespaceQuota ensures the ResourceQuota and LimitRange exist in
+// namespace. They are shared by all servers in the namespace, so they carry
+// no owner reference and survive MCPServer deletion.
+func (r *MCPServerReconciler) reconcileNamespaceQuota(ctx context.Context, namespace string) error {
+	if r.NamespaceQuota == nil {
+		return nil
+	}
+	q := r.NamespaceQuota
+	logger := log.FromContext(ctx)
+	labels := map[string]string{LabelManagedBy: LabelManagedByValue}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: QuotaName, Namespace: namespace},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, quota, func() error {
+		quota.Labels = labels
+		hard := corev1.ResourceList{}
+		for name, value := range map[corev1.ResourceName]string{
+			corev1.ResourceRequestsCPU:    q.RequestsCPU,
+			corev1.ResourceRequestsMemory: q.RequestsMemory,
+			corev1.ResourceLimitsCPU:      q.LimitsCPU,
+			corev1.ResourceLimitsMemory:   q.LimitsMemory,
+			corev1.ResourcePods:           q.Pods,
+		} {
+			qty, err := resource.ParseQuantity(value)
+			if err != nil {
+				return fmt.Errorf("invalid quota %s %q: %w", name, value, err)
+			}
+			hard[name] = qty
+		}
+		quota.Spec.Hard = hard
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("ResourceQuota reconciled", "operation", op, "namespace", namespace)
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: LimitRangeName, Namespace: namespace},
+	}
+	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, limitRange, func() error {
+		limitRange.Labels = labels
+		maxCPU, err := resource.ParseQuantity(q.MaxCPU)
+		if err != nil {
+			return fmt.Errorf("invalid quota max.cpu %q: %w", q.MaxCPU, err)
+		}
+		maxMemory, err := resource.ParseQuantity(q.MaxMemory)
+		if err != nil {
+			return fmt.Errorf("invalid quota max.memory %q: %w", q.MaxMemory, err)
+		}
+		limitRange.Spec.Limits = []corev1.LimitRangeItem{{
+			Type: corev1.LimitTypeContainer,
+			Default: corev1.ResourceList{
+				corev1.ResourceCPU:    capQuantity(DefaultLimitCPU, maxCPU),
+				corev1.ResourceMemory: capQuantity(DefaultLimitMemory, maxMemory),
+			},
+			DefaultRequest: corev1.ResourceList{
+				corev1.ResourceCPU:    capQuantity(DefaultRequestCPU, maxCPU),
+				corev1.ResourceMemory: capQuantity(DefaultRequestMemory, maxMemory),
+			},
+			Max: corev1.ResourceList{
+				corev1.ResourceCPU:    maxCPU,
+				corev1.ResourceMemory: maxMemory,
+			},
+		}}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		logger.Info("LimitRange reconciled", "operation", op, "namespace", namespace)
+	}
+	return nil
+}
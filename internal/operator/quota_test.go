@@ -0,0 +1,121 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testQuotaConfig() *QuotaConfig {
+	return &QuotaConfig{
+		RequestsCPU:    "4",
+		RequestsMemory: "8Gi",
+		LimitsCPU:      "8",
+		LimitsMemory:   "16Gi",
+		Pods:           "20",
+		MaxCPU:         "1",
+		MaxMemory:      "1Gi",
+	}
+}
+
+func TestQuotaConfigValidate(t *testing.T) {
+	t.Run("accepts valid quantities", func(t *testing.T) {
+		if err := testQuotaConfig().Validate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("rejects invalid quantity", func(t *testing.T) {
+		cfg := testQuotaConfig()
+		cfg.MaxMemory = "huge"
+		if err := cfg.Validate(); err == nil {
+			t.Fatal("expected error for invalid quantity")
+		}
+	})
+}
+
+func TestReconcileNamespaceQuota(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	t.Run("does nothing when quota is not configured", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme}
+		if err := r.reconcileNamespaceQuota(context.Background(), "default"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		quotas := &corev1.ResourceQuotaList{}
+		if err := client.List(context.Background(), quotas); err != nil {
+			t.Fatalf("list quotas: %v", err)
+		}
+		assertEqual(t, "quotas", len(quotas.Items), 0)
+	})
+
+	t.Run("creates quota and limit range", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme, NamespaceQuota: testQuotaConfig()}
+		if err := r.reconcileNamespaceQuota(context.Background(), "team-a"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		quota := &corev1.ResourceQuota{}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: QuotaName, Namespace: "team-a"}, quota); err != nil {
+			t.Fatalf("get quota: %v", err)
+		}
+		if got := quota.Spec.Hard[corev1.ResourceRequestsCPU]; got.Cmp(resource.MustParse("4")) != 0 {
+			t.Fatalf("requests.cpu = %s, want 4", got.String())
+		}
+		if got := quota.Spec.Hard[corev1.ResourcePods]; got.Cmp(resource.MustParse("20")) != 0 {
+			t.Fatalf("pods = %s, want 20", got.String())
+		}
+
+		limitRange := &corev1.LimitRange{}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: LimitRangeName, Namespace: "team-a"}, limitRange); err != nil {
+			t.Fatalf("get limit range: %v", err)
+		}
+		assertEqual(t, "limits", len(limitRange.Spec.Limits), 1)
+		if got := limitRange.Spec.Limits[0].Max[corev1.ResourceMemory]; got.Cmp(resource.MustParse("1Gi")) != 0 {
+			t.Fatalf("max.memory = %s, want 1Gi", got.String())
+		}
+		assertLimitRangeDefaults(t, limitRange.Spec.Limits[0],
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(DefaultLimitCPU), corev1.ResourceMemory: resource.MustParse(DefaultLimitMemory)},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(DefaultRequestCPU), corev1.ResourceMemory: resource.MustParse(DefaultRequestMemory)})
+	})
+
+	t.Run("caps defaults at a max below them", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).Build()
+		cfg := testQuotaConfig()
+		cfg.MaxCPU = "20m"
+		cfg.MaxMemory = "32Mi"
+		r := MCPServerReconciler{Client: client, Scheme: scheme, NamespaceQuota: cfg}
+		if err := r.reconcileNamespaceQuota(context.Background(), "team-b"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		limitRange := &corev1.LimitRange{}
+		if err := client.Get(context.Background(), types.NamespacedName{Name: LimitRangeName, Namespace: "team-b"}, limitRange); err != nil {
+			t.Fatalf("get limit range: %v", err)
+		}
+		capped := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20m"), corev1.ResourceMemory: resource.MustParse("32Mi")}
+		assertLimitRangeDefaults(t, limitRange.Spec.Limits[0], capped, capped)
+	})
+}
+
+func assertLimitRangeDefaults(t *testing.T, item corev1.LimitRangeItem, wantDefault, wantRequest corev1.ResourceList) {
+	t.Helper()
+	for name, want := range wantDefault {
+		if got := item.Default[name]; got.Cmp(want) != 0 {
+			t.Errorf("default %s = %s, want %s", name, got.String(), want.String())
+		}
+	}
+	for name, want := range wantRequest {
+		if got := item.DefaultRequest[name]; got.Cmp(want) != 0 {
+			t.Errorf("default request %s = %s, want %s", name, got.String(), want.String())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package operator
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/time/rate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Per-MCPServer retry backoff: failures are retried after 1s, 2s, 4s, ...
+// up to 5m, and the delay resets after a successful reconcile.
+const (
+	reconcileBackoffBase = time.Second
+	reconcileBackoffMax  = 5 * time.Minute
+
+	// Overall retry budget shared by all MCPServers.
+	reconcileBucketQPS   = 10
+	reconcileBucketBurst = 100
+)
+
+// Reconcile error classes, also the reason label of the retry metric.
+const (
+	// errorClassConflict is an optimistic-concurrency conflict; retried
+	// through the backoff queue without logging an error.
+	errorClassConflict = "conflict"
+	// errorClassTransient covers API timeouts, throttling, unavailable
+	// dependencies and anything unclassified; retried with backoff.
+	errorClassTransient = "transient"
+	// errorClassPermanent is a spec problem that retrying cannot fix; the
+	// next change to the MCPServer triggers a new reconcile.
+	errorClassPermanent = "permanent"
+)
+
+// permanentErrors are the validation sentinels classified as permanent.
+var permanentErrors = []error{
+	ErrMissingIngressPath,
+	ErrInvalidIngressPath,
+	ErrCanaryUnsupported,
+	ErrSyncSourceNotAllowed,
+	ErrInvalidEnvTemplate,
+	ErrInvalidAuth,
+	ErrInvalidImageVariant,
+	ErrInvalidDeployAs,
+	ErrInvalidMirror,
+	ErrInvalidIPFamilies,
+	ErrInvalidIngressProvider,
+	ErrImageSignatureInvalid,
+	ErrInvalidCPURequest,
+	ErrInvalidMemoryRequest,
+	ErrInvalidCPULimit,
+	ErrInvalidMemoryLimit,
+}
+
+// classifyError returns the error class of a reconcile failure.
+func classifyError(err error) string {
+	switch {
+	case apierrors.IsConflict(err):
+		return errorClassConflict
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return errorClassPermanent
+	}
+	for _, permanent := range permanentErrors {
+		if errors.Is(err, permanent) {
+			return errorClassPermanent
+		}
+	}
+	return errorClassTransient
+}
+
+// requeueResult converts a reconcile error into the result returned to
+// controller-runtime: conflicts are requeued with backoff, permanent errors
+// are terminal, and transient errors are returned for backoff retries.
+func requeueResult(err error) (ctrl.Result, error) {
+	if err == nil {
+		return ctrl.Result{}, nil
+	}
+	class := classifyError(err)
+	reconcileRetries.WithLabelValues(class).Inc()
+	switch class {
+	case errorClassConflict:
+		return ctrl.Result{Requeue: true}, nil
+	case errorClassPermanent:
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	default:
+		return ctrl.Result{}, err
+	}
+}
+
+// reconcileRateLimiter backs off retries of each MCPServer exponentially,
+// while the overall bucket (10 qps, burst 100, as in controller-runtime's
+// default) still caps retries across all servers.
+func reconcileRateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(reconcileBackoffBase, reconcileBackoffMax),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(reconcileBucketQPS), reconcileBucketBurst)},
+	)
+}
@@ -0,0 +1,106 @@
+package operator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestClassifyError(t *testing.T) {
+	resource := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	kind := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"conflict", apierrors.NewConflict(resource, "app", errors.New("object modified")), errorClassConflict},
+		{"server timeout", apierrors.NewServerTimeout(resource, "update", 1), errorClassTransient},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), errorClassTransient},
+		{"invalid object", apierrors.NewInvalid(kind, "app", nil), errorClassPermanent},
+		{"bad request", apierrors.NewBadRequest("bad"), errorClassPermanent},
+		{"invalid resources", fmt.Errorf("%w: %w", ErrInvalidCPULimit, errors.New("quantities must match")), errorClassPermanent},
+		{"missing ingress path", fmt.Errorf("%w: %w", ErrMissingIngressPath, errors.New("empty")), errorClassPermanent},
+		{"invalid env template", fmt.Errorf("%w: unknown field %q", ErrInvalidEnvTemplate, "Secret"), errorClassPermanent},
+		{"invalid auth", fmt.Errorf("%w: spec.auth.url is required for oidc auth", ErrInvalidAuth), errorClassPermanent},
+		{"auth secret not ready", fmt.Errorf("%w: auth Secret default/htpasswd not found", ErrAuthSecretNotReady), errorClassTransient},
+		{"invalid image variant", fmt.Errorf("%w: spec.imageVariants[arm64] must name an image", ErrInvalidImageVariant), errorClassPermanent},
+		{"invalid mirror", fmt.Errorf("%w: spec.mirror.targetServer must name another MCPServer", ErrInvalidMirror), errorClassPermanent},
+		{"missing mirror target", fmt.Errorf("%w: mirror target MCPServer default/shadow not found", ErrMirrorTargetNotFound), errorClassTransient},
+		{"invalid IP families", fmt.Errorf("%w: IP family IPv6 is listed twice", ErrInvalidIPFamilies), errorClassPermanent},
+		{"invalid ingress provider", fmt.Errorf("%w: unknown ingress provider %q", ErrInvalidIngressProvider, "gateway"), errorClassPermanent},
+		{"invalid image signature", fmt.Errorf("%w: no signatures found", ErrImageSignatureInvalid), errorClassPermanent},
+		{"image verifier unavailable", fmt.Errorf("%w: context deadline exceeded", ErrImageVerifierUnavailable), errorClassTransient},
+		{"invalid deployAs", fmt.Errorf("%w: spec.deployAs %q is not a valid ServiceAccount name", ErrInvalidDeployAs, "Not_Valid"), errorClassPermanent},
+		{"missing ingress host", fmt.Errorf("%w: %w", ErrMissingIngressHost, errors.New("empty")), errorClassTransient},
+		{"unknown", errors.New("connection refused"), errorClassTransient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, "class", classifyError(tt.err), tt.want)
+		})
+	}
+}
+
+func TestRequeueResult(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		result, err := requeueResult(nil)
+		assertEqual(t, "requeue", result.Requeue, false)
+		assertEqual(t, "error", err, nil)
+	})
+
+	t.Run("conflict requeues without error", func(t *testing.T) {
+		counter := reconcileRetries.WithLabelValues(errorClassConflict)
+		before := testutil.ToFloat64(counter)
+
+		result, err := requeueResult(apierrors.NewConflict(schema.GroupResource{Resource: "services"}, "app", errors.New("modified")))
+		assertEqual(t, "requeue", result.Requeue, true)
+		assertEqual(t, "error", err, nil)
+		assertEqual(t, "retries", testutil.ToFloat64(counter), before+1)
+	})
+
+	t.Run("transient error is returned for backoff", func(t *testing.T) {
+		counter := reconcileRetries.WithLabelValues(errorClassTransient)
+		before := testutil.ToFloat64(counter)
+		cause := errors.New("connection refused")
+
+		_, err := requeueResult(cause)
+		assertEqual(t, "error", err, cause)
+		assertEqual(t, "retries", testutil.ToFloat64(counter), before+1)
+	})
+
+	t.Run("permanent error is terminal", func(t *testing.T) {
+		counter := reconcileRetries.WithLabelValues(errorClassPermanent)
+		before := testutil.ToFloat64(counter)
+
+		_, err := requeueResult(fmt.Errorf("%w: %w", ErrInvalidMemoryRequest, errors.New("bad quantity")))
+		if !errors.Is(err, reconcile.TerminalError(nil)) {
+			t.Fatalf("expected terminal error, got %v", err)
+		}
+		if !errors.Is(err, ErrInvalidMemoryRequest) {
+			t.Fatalf("expected ErrInvalidMemoryRequest to be preserved, got %v", err)
+		}
+		assertEqual(t, "retries", testutil.ToFloat64(counter), before+1)
+	})
+}
+
+func TestReconcileRateLimiter(t *testing.T) {
+	limiter := reconcileRateLimiter()
+	item := reconcile.Request{}
+
+	if got := limiter.When(item); got != reconcileBackoffBase {
+		t.Errorf("first retry = %v, want %v", got, reconcileBackoffBase)
+	}
+	if got := limiter.When(item); got != 2*reconcileBackoffBase {
+		t.Errorf("second retry = %v, want %v", got, 2*reconcileBackoffBase)
+	}
+	limiter.Forget(item)
+	if got := limiter.NumRequeues(item); got != 0 {
+		t.Errorf("requeues after Forget = %d, want 0", got)
+	}
+}
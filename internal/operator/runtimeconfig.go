@@ -0,0 +1,100 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// withRuntimeConfig returns the reconciler to use for one reconcile: a copy with the
+// defaults of the MCPRuntimeConfig named "default", or r itself when there is none.
+// Reading it on every reconcile lets configuration changes apply without a restart.
+func (r *MCPServerReconciler) withRuntimeConfig(ctx context.Context, logger logr.Logger) *MCPServerReconciler {
+	var cfg mcpv1alpha1.MCPRuntimeConfig
+	if err := r.Get(ctx, types.NamespacedName{Name: mcpv1alpha1.MCPRuntimeConfigName}, &cfg); err != nil {
+		if !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			logger.Error(err, "Failed to read MCPRuntimeConfig; using operator defaults")
+		}
+		return r
+	}
+	return r.applyRuntimeConfig(&cfg.Spec)
+}
+
+// applyRuntimeConfig returns a copy of r with the fields set in spec taking precedence
+// over the operator's environment configuration.
+func (r *MCPServerReconciler) applyRuntimeConfig(spec *mcpv1alpha1.MCPRuntimeConfigSpec) *MCPServerReconciler {
+	configured := *r
+	if spec.DefaultIngressHost != "" {
+		configured.DefaultIngressHost = spec.DefaultIngressHost
+	}
+	if spec.DefaultIngressClass != "" {
+		configured.DefaultIngressClass = spec.DefaultIngressClass
+	}
+	if reg := spec.ProvisionedRegistry; reg != nil && reg.URL != "" {
+		// The pull secret already exists in server namespaces, so it is attached
+		// like the internal registry's instead of being built from credentials.
+		configured.ProvisionedRegistry = &RegistryConfig{URL: reg.URL, PathPrefix: reg.PathPrefix}
+		if reg.SecretName != "" {
+			configured.RegistryPullSecret = reg.SecretName
+		}
+	}
+	if spec.DefaultResources != nil {
+		configured.DefaultResources = spec.DefaultResources
+	}
+	if spec.NamespaceResources != nil {
+		configured.NamespaceResources = spec.NamespaceResources
+	}
+	if spec.ProbeDefaults != nil {
+		configured.ProbeDefaults = spec.ProbeDefaults
+	}
+	return &configured
+}
+
+// isRuntimeConfig matches the MCPRuntimeConfig the operator reads.
+func isRuntimeConfig(obj client.Object) bool {
+	return obj.GetName() == mcpv1alpha1.MCPRuntimeConfigName
+}
+
+// resourceDefaultsFor returns the resource defaults for servers in namespace: its
+// NamespaceResources entry, with anything it leaves unset taken from DefaultResources.
+func (r *MCPServerReconciler) resourceDefaultsFor(namespace string) *mcpv1alpha1.ResourceRequirements {
+	nsDefaults, ok := r.NamespaceResources[namespace]
+	if !ok {
+		return r.DefaultResources
+	}
+	merged := mergeResourceDefaults(nsDefaults, r.DefaultResources)
+	return &merged
+}
+
+// mergeResourceDefaults fills the requests and limits spec leaves empty from defaults.
+func mergeResourceDefaults(spec mcpv1alpha1.ResourceRequirements, defaults *mcpv1alpha1.ResourceRequirements) mcpv1alpha1.ResourceRequirements {
+	if defaults == nil {
+		return spec
+	}
+	return mcpv1alpha1.ResourceRequirements{
+		Requests: mergeResourceList(spec.Requests, defaults.Requests),
+		Limits:   mergeResourceList(spec.Limits, defaults.Limits),
+	}
+}
+
+func mergeResourceList(spec, defaults *mcpv1alpha1.ResourceList) *mcpv1alpha1.ResourceList {
+	if defaults == nil {
+		return spec
+	}
+	merged := *defaults
+	if spec != nil {
+		if spec.CPU != "" {
+			merged.CPU = spec.CPU
+		}
+		if spec.Memory != "" {
+			merged.Memory = spec.Memory
+		}
+	}
+	return &merged
+}
@@ -0,0 +1,103 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestWithRuntimeConfig(t *testing.T) {
+	scheme := newHealthTestScheme()
+
+	t.Run("keeps operator defaults without a config", func(t *testing.T) {
+		r := &MCPServerReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), DefaultIngressHost: "env.example.com"}
+		assertEqual(t, "reconciler", r.withRuntimeConfig(context.Background(), logr.Discard()) == r, true)
+	})
+
+	t.Run("overrides operator defaults", func(t *testing.T) {
+		cfg := &mcpv1alpha1.MCPRuntimeConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: mcpv1alpha1.MCPRuntimeConfigName},
+			Spec: mcpv1alpha1.MCPRuntimeConfigSpec{
+				DefaultIngressHost:  "config.example.com",
+				DefaultIngressClass: "nginx",
+				ProvisionedRegistry: &mcpv1alpha1.ProvisionedRegistryRef{URL: "registry.example.com", SecretName: "team-pull"},
+			},
+		}
+		r := &MCPServerReconciler{
+			Client:             fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfg).Build(),
+			DefaultIngressHost: "env.example.com",
+		}
+		configured := r.withRuntimeConfig(context.Background(), logr.Discard())
+		assertEqual(t, "ingress host", configured.DefaultIngressHost, "config.example.com")
+		assertEqual(t, "ingress class", configured.DefaultIngressClass, "nginx")
+		assertEqual(t, "registry", configured.ProvisionedRegistry.URL, "registry.example.com")
+		assertEqual(t, "pull secrets", configured.buildImagePullSecrets(&mcpv1alpha1.MCPServer{})[0].Name, "team-pull")
+		assertEqual(t, "original untouched", r.DefaultIngressHost, "env.example.com")
+
+		mcpServer := &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "weather"}}
+		configured.setDefaults(mcpServer)
+		assertEqual(t, "server ingress class", mcpServer.Spec.IngressClass, "nginx")
+		assertEqual(t, "server ingress host", mcpServer.Spec.IngressHost, "config.example.com")
+	})
+}
+
+func TestMergeResourceDefaults(t *testing.T) {
+	defaults := &mcpv1alpha1.ResourceRequirements{
+		Requests: &mcpv1alpha1.ResourceList{CPU: "100m", Memory: "128Mi"},
+		Limits:   &mcpv1alpha1.ResourceList{CPU: "1", Memory: "512Mi"},
+	}
+	spec := mcpv1alpha1.ResourceRequirements{Limits: &mcpv1alpha1.ResourceList{Memory: "1Gi"}}
+
+	merged := mergeResourceDefaults(spec, defaults)
+	assertEqual(t, "request cpu", merged.Requests.CPU, "100m")
+	assertEqual(t, "limit cpu", merged.Limits.CPU, "1")
+	assertEqual(t, "limit memory", merged.Limits.Memory, "1Gi")
+	assertEqual(t, "defaults untouched", defaults.Limits.Memory, "512Mi")
+
+	assertEqual(t, "no defaults", mergeResourceDefaults(spec, nil).Requests == nil, true)
+}
+
+func TestResourceDefaultsFor(t *testing.T) {
+	r := (&MCPServerReconciler{}).applyRuntimeConfig(&mcpv1alpha1.MCPRuntimeConfigSpec{
+		DefaultResources: &mcpv1alpha1.ResourceRequirements{
+			Requests: &mcpv1alpha1.ResourceList{CPU: "100m", Memory: "128Mi"},
+			Limits:   &mcpv1alpha1.ResourceList{CPU: "1", Memory: "512Mi"},
+		},
+		NamespaceResources: map[string]mcpv1alpha1.ResourceRequirements{
+			"dev": {Requests: &mcpv1alpha1.ResourceList{CPU: "10m", Memory: "32Mi"}},
+		},
+	})
+
+	dev := r.resourceDefaultsFor("dev")
+	assertEqual(t, "dev request cpu", dev.Requests.CPU, "10m")
+	assertEqual(t, "dev limit memory", dev.Limits.Memory, "512Mi")
+
+	prod := r.resourceDefaultsFor("prod")
+	assertEqual(t, "prod request cpu", prod.Requests.CPU, "100m")
+
+	assertEqual(t, "no defaults", (&MCPServerReconciler{}).resourceDefaultsFor("dev") == nil, true)
+}
+
+func TestBuildProbesUsesDefaults(t *testing.T) {
+	period := int32(30)
+	delay := int32(1)
+	defaults := &mcpv1alpha1.ProbesSpec{
+		Liveness:  &mcpv1alpha1.ProbeSpec{PeriodSeconds: &period},
+		Readiness: &mcpv1alpha1.ProbeSpec{PeriodSeconds: &period},
+	}
+	server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{
+		Port:   8088,
+		Probes: &mcpv1alpha1.ProbesSpec{Readiness: &mcpv1alpha1.ProbeSpec{InitialDelaySeconds: &delay}},
+	}}
+
+	liveness, readiness, startup := buildProbes(server, defaults)
+	assertEqual(t, "liveness period", liveness.PeriodSeconds, int32(30))
+	assertEqual(t, "readiness period", readiness.PeriodSeconds, int32(defaultReadinessPeriod))
+	assertEqual(t, "readiness delay", readiness.InitialDelaySeconds, int32(1))
+	assertEqual(t, "startup", startup == nil, true)
+}
@@ -0,0 +1,211 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const (
+	// SyncKindSecret and SyncKindConfigMap are the kinds accepted in spec.syncSecrets.
+	SyncKindSecret    = "Secret"
+	SyncKindConfigMap = "ConfigMap"
+	// AnnotationSyncedFrom marks a copy made by the operator with its source
+	// ("namespace/name"); objects without it are never overwritten.
+	AnnotationSyncedFrom = "mcpruntime.org/synced-from"
+	// LabelSyncable opts a Secret or ConfigMap in the sync namespace into syncing; objects
+	// without it, such as the registry credentials, are never copied.
+	LabelSyncable = "mcpruntime.org/syncable"
+	// AnnotationSyncNamespaces optionally restricts a syncable source to a comma-separated
+	// list of target namespaces.
+	AnnotationSyncNamespaces = "mcpruntime.org/sync-namespaces"
+)
+
+// checkSyncAllowed refuses to copy source into namespace unless the source opted in with
+// LabelSyncable and, when it lists target namespaces, names namespace among them.
+func checkSyncAllowed(source client.Object, namespace string) error {
+	if source.GetLabels()[LabelSyncable] != "true" {
+		return fmt.Errorf("%w: %s/%s is not labeled %s=true", ErrSyncSourceNotAllowed, source.GetNamespace(), source.GetName(), LabelSyncable)
+	}
+	allowed, ok := source.GetAnnotations()[AnnotationSyncNamespaces]
+	if !ok {
+		return nil
+	}
+	var namespaces []string
+	for _, ns := range strings.Split(allowed, ",") {
+		namespaces = append(namespaces, strings.TrimSpace(ns))
+	}
+	if !slices.Contains(namespaces, namespace) {
+		return fmt.Errorf("%w: %s/%s is not shared with namespace %s", ErrSyncSourceNotAllowed, source.GetNamespace(), source.GetName(), namespace)
+	}
+	return nil
+}
+
+// syncKind returns the kind of ref, defaulting to Secret.
+func syncKind(ref mcpv1alpha1.SyncSecretRef) string {
+	if ref.Kind == "" {
+		return SyncKindSecret
+	}
+	return ref.Kind
+}
+
+// reconcileSyncedSecrets copies the Secrets and ConfigMaps listed in spec.syncSecrets
+// from SyncNamespace into the server's namespace. Only sources that pass checkSyncAllowed
+// are copied, since any author of an MCPServer can list any name. Copies are shared by every server in
+// the namespace that lists them, so like the namespace quota they carry no owner reference.
+func (r *MCPServerReconciler) reconcileSyncedSecrets(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	if len(mcpServer.Spec.SyncSecrets) == 0 {
+		return nil
+	}
+	if r.SyncNamespace == "" {
+		return fmt.Errorf("%w: spec.syncSecrets is set but the operator has no sync namespace", ErrSecretSyncDisabled)
+	}
+	if mcpServer.Namespace == r.SyncNamespace {
+		// The sources are already in the server's namespace.
+		return nil
+	}
+	for _, ref := range mcpServer.Spec.SyncSecrets {
+		var err error
+		switch syncKind(ref) {
+		case SyncKindConfigMap:
+			err = r.syncConfigMap(ctx, ref.Name, mcpServer.Namespace)
+		default:
+			err = r.syncSecret(ctx, ref.Name, mcpServer.Namespace)
+		}
+		if err != nil {
+			return fmt.Errorf("sync %s %s/%s: %w", syncKind(ref), r.SyncNamespace, ref.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *MCPServerReconciler) syncSecret(ctx context.Context, name, namespace string) error {
+	var source corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.SyncNamespace, Name: name}, &source); err != nil {
+		return err
+	}
+	if err := checkSyncAllowed(&source, namespace); err != nil {
+		return err
+	}
+	desired := &corev1.Secret{
+		ObjectMeta: r.syncedObjectMeta(name, namespace),
+		Type:       source.Type,
+		Data:       source.Data,
+	}
+	var existing corev1.Secret
+	return r.writeSyncedCopy(ctx, desired, &existing, func() bool {
+		return existing.Type == desired.Type && reflect.DeepEqual(existing.Data, desired.Data)
+	})
+}
+
+func (r *MCPServerReconciler) syncConfigMap(ctx context.Context, name, namespace string) error {
+	var source corev1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.SyncNamespace, Name: name}, &source); err != nil {
+		return err
+	}
+	if err := checkSyncAllowed(&source, namespace); err != nil {
+		return err
+	}
+	desired := &corev1.ConfigMap{
+		ObjectMeta: r.syncedObjectMeta(name, namespace),
+		Data:       source.Data,
+		BinaryData: source.BinaryData,
+	}
+	var existing corev1.ConfigMap
+	return r.writeSyncedCopy(ctx, desired, &existing, func() bool {
+		return reflect.DeepEqual(existing.Data, desired.Data) && reflect.DeepEqual(existing.BinaryData, desired.BinaryData)
+	})
+}
+
+func (r *MCPServerReconciler) syncedObjectMeta(name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      map[string]string{LabelManagedBy: LabelManagedByValue, LabelEnvSource: "true"},
+		Annotations: map[string]string{AnnotationSyncedFrom: r.SyncNamespace + "/" + name},
+	}
+}
+
+// writeSyncedCopy creates desired or updates the copy read into existing when unchanged
+// reports a difference. Copies live outside the operator's cache, so they are read
+// through the API reader.
+func (r *MCPServerReconciler) writeSyncedCopy(ctx context.Context, desired, existing client.Object, unchanged func() bool) error {
+	logger := log.FromContext(ctx)
+	err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if errors.IsNotFound(err) {
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+		logger.Info("Synced object", "name", desired.GetName(), "namespace", desired.GetNamespace())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	source := desired.GetAnnotations()[AnnotationSyncedFrom]
+	if existing.GetAnnotations()[AnnotationSyncedFrom] != source {
+		return fmt.Errorf("%w: %s/%s exists and was not synced from %s", ErrSyncTargetConflict, desired.GetNamespace(), desired.GetName(), source)
+	}
+	if unchanged() {
+		return nil
+	}
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	if err := r.Update(ctx, desired); err != nil {
+		return err
+	}
+	logger.Info("Updated synced object", "name", desired.GetName(), "namespace", desired.GetNamespace())
+	return nil
+}
+
+func (r *MCPServerReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// isSyncSource matches objects in the sync namespace, the only place sources are read from.
+func (r *MCPServerReconciler) isSyncSource(obj client.Object) bool {
+	return obj.GetNamespace() == r.SyncNamespace
+}
+
+// requestsForSyncedSecret enqueues the servers that list the changed Secret.
+func (r *MCPServerReconciler) requestsForSyncedSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.requestsForSyncSource(ctx, SyncKindSecret, obj)
+}
+
+// requestsForSyncedConfigMap enqueues the servers that list the changed ConfigMap.
+func (r *MCPServerReconciler) requestsForSyncedConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.requestsForSyncSource(ctx, SyncKindConfigMap, obj)
+}
+
+func (r *MCPServerReconciler) requestsForSyncSource(ctx context.Context, kind string, obj client.Object) []reconcile.Request {
+	var servers mcpv1alpha1.MCPServerList
+	if err := r.List(ctx, &servers); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "Failed to list MCPServers to requeue", "trigger", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, s := range servers.Items {
+		for _, ref := range s.Spec.SyncSecrets {
+			if ref.Name == obj.GetName() && syncKind(ref) == kind {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: s.Namespace, Name: s.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,148 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func syncTestServer(namespace string, refs ...mcpv1alpha1.SyncSecretRef) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+		Spec:       mcpv1alpha1.MCPServerSpec{SyncSecrets: refs},
+	}
+}
+
+func TestReconcileSyncedSecrets(t *testing.T) {
+	scheme := newHealthTestScheme()
+	ctx := context.Background()
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "mcp-runtime", Labels: map[string]string{LabelSyncable: "true"}},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"token": []byte("v1")},
+	}
+	settings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "mcp-runtime", Labels: map[string]string{LabelSyncable: "true"}},
+		Data:       map[string]string{"region": "eu"},
+	}
+
+	t.Run("copies secrets and configmaps and keeps them updated", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source.DeepCopy(), settings.DeepCopy()).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme, SyncNamespace: "mcp-runtime"}
+		server := syncTestServer("team-a", mcpv1alpha1.SyncSecretRef{Name: "api-keys"}, mcpv1alpha1.SyncSecretRef{Name: "settings", Kind: SyncKindConfigMap})
+
+		if err := r.reconcileSyncedSecrets(ctx, server); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var copied corev1.Secret
+		if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "api-keys"}, &copied); err != nil {
+			t.Fatalf("get secret copy: %v", err)
+		}
+		assertEqual(t, "token", string(copied.Data["token"]), "v1")
+		assertEqual(t, "synced-from", copied.Annotations[AnnotationSyncedFrom], "mcp-runtime/api-keys")
+		var copiedSettings corev1.ConfigMap
+		if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "settings"}, &copiedSettings); err != nil {
+			t.Fatalf("get configmap copy: %v", err)
+		}
+		assertEqual(t, "region", copiedSettings.Data["region"], "eu")
+
+		var updated corev1.Secret
+		if err := c.Get(ctx, client.ObjectKeyFromObject(source), &updated); err != nil {
+			t.Fatalf("get source: %v", err)
+		}
+		updated.Data["token"] = []byte("v2")
+		if err := c.Update(ctx, &updated); err != nil {
+			t.Fatalf("update source: %v", err)
+		}
+		if err := r.reconcileSyncedSecrets(ctx, server); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "api-keys"}, &copied); err != nil {
+			t.Fatalf("get secret copy: %v", err)
+		}
+		assertEqual(t, "token", string(copied.Data["token"]), "v2")
+	})
+
+	t.Run("does not overwrite objects it did not create", func(t *testing.T) {
+		own := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "team-a"},
+			Data:       map[string][]byte{"token": []byte("local")},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source.DeepCopy(), own).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme, SyncNamespace: "mcp-runtime"}
+
+		err := r.reconcileSyncedSecrets(ctx, syncTestServer("team-a", mcpv1alpha1.SyncSecretRef{Name: "api-keys"}))
+		if !errors.Is(err, ErrSyncTargetConflict) {
+			t.Fatalf("expected ErrSyncTargetConflict, got %v", err)
+		}
+	})
+
+	t.Run("refuses sources that did not opt in", func(t *testing.T) {
+		credentials := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "mcp-runtime"},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}
+		restricted := source.DeepCopy()
+		restricted.Annotations = map[string]string{AnnotationSyncNamespaces: "team-b, team-c"}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(credentials, restricted).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme, SyncNamespace: "mcp-runtime"}
+
+		for _, name := range []string{"registry-credentials", "api-keys"} {
+			err := r.reconcileSyncedSecrets(ctx, syncTestServer("team-a", mcpv1alpha1.SyncSecretRef{Name: name}))
+			if !errors.Is(err, ErrSyncSourceNotAllowed) {
+				t.Fatalf("%s: expected ErrSyncSourceNotAllowed, got %v", name, err)
+			}
+			assertEqual(t, name+" class", classifyError(err), errorClassPermanent)
+			var copied corev1.Secret
+			if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: name}, &copied); !apierrors.IsNotFound(err) {
+				t.Fatalf("%s: expected no copy in team-a, got %v", name, err)
+			}
+		}
+		if err := r.reconcileSyncedSecrets(ctx, syncTestServer("team-c", mcpv1alpha1.SyncSecretRef{Name: "api-keys"})); err != nil {
+			t.Fatalf("expected a listed namespace to be allowed, got %v", err)
+		}
+	})
+
+	t.Run("fails when syncing is disabled", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+		err := r.reconcileSyncedSecrets(ctx, syncTestServer("team-a", mcpv1alpha1.SyncSecretRef{Name: "api-keys"}))
+		if !errors.Is(err, ErrSecretSyncDisabled) {
+			t.Fatalf("expected ErrSecretSyncDisabled, got %v", err)
+		}
+	})
+
+	t.Run("fails when the source is missing", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := MCPServerReconciler{Client: c, Scheme: scheme, SyncNamespace: "mcp-runtime"}
+
+		if err := r.reconcileSyncedSecrets(ctx, syncTestServer("team-a", mcpv1alpha1.SyncSecretRef{Name: "api-keys"})); err == nil {
+			t.Fatal("expected error for missing source")
+		}
+	})
+}
+
+func TestRequestsForSyncSource(t *testing.T) {
+	scheme := newHealthTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		syncTestServer("team-a", mcpv1alpha1.SyncSecretRef{Name: "api-keys"}),
+		syncTestServer("team-b", mcpv1alpha1.SyncSecretRef{Name: "api-keys", Kind: SyncKindConfigMap}),
+	).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme, SyncNamespace: "mcp-runtime"}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "mcp-runtime"}}
+	requests := r.requestsForSyncedSecret(context.Background(), secret)
+	if len(requests) != 1 || requests[0].Namespace != "team-a" {
+		t.Fatalf("expected only team-a/api to be requeued, got %v", requests)
+	}
+}
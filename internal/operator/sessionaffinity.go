@@ -0,0 +1,98 @@
+package operator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const (
+	// SessionAffinityClientIP routes every request from a client IP to the same pod.
+	SessionAffinityClientIP = "clientIP"
+	// SessionAffinityCookie pins a client to a pod with a cookie set by the ingress controller.
+	SessionAffinityCookie = "cookie"
+)
+
+// Annotations used for sticky sessions. Traefik reads its sticky cookie settings from the
+// Service; nginx reads affinity from the Ingress.
+const (
+	traefikStickyCookieAnnotation         = "traefik.ingress.kubernetes.io/service.sticky.cookie"
+	traefikStickyCookieNameAnnotation     = "traefik.ingress.kubernetes.io/service.sticky.cookie.name"
+	traefikStickyCookieHTTPOnlyAnnotation = "traefik.ingress.kubernetes.io/service.sticky.cookie.httponly"
+	nginxAffinityAnnotation               = "nginx.ingress.kubernetes.io/affinity"
+	nginxAffinityModeAnnotation           = "nginx.ingress.kubernetes.io/affinity-mode"
+	nginxSessionCookieNameAnnotation      = "nginx.ingress.kubernetes.io/session-cookie-name"
+	nginxUpstreamHashByAnnotation         = "nginx.ingress.kubernetes.io/upstream-hash-by"
+)
+
+// sessionCookieName is the affinity cookie of mcpServer, named per server so clients of
+// several servers on one host keep a cookie for each.
+func sessionCookieName(mcpServer *mcpv1alpha1.MCPServer) string {
+	return "mcp-" + mcpServer.Name
+}
+
+// serviceSessionAffinity returns the Service sessionAffinity for mcpServer. Ingress
+// controllers send traffic straight to the endpoints, so this covers in-cluster clients.
+func serviceSessionAffinity(mcpServer *mcpv1alpha1.MCPServer) corev1.ServiceAffinity {
+	if mcpServer.Spec.SessionAffinity == SessionAffinityClientIP {
+		return corev1.ServiceAffinityClientIP
+	}
+	return corev1.ServiceAffinityNone
+}
+
+// sessionAffinityServiceAnnotations returns the Service annotations enabling Traefik's sticky
+// cookie for a cookie-affinity traefik server, or nil.
+func sessionAffinityServiceAnnotations(mcpServer *mcpv1alpha1.MCPServer) map[string]string {
+	if mcpServer.Spec.SessionAffinity != SessionAffinityCookie || mcpServer.Spec.IngressClass != "traefik" {
+		return nil
+	}
+	return map[string]string{
+		traefikStickyCookieAnnotation:         "true",
+		traefikStickyCookieNameAnnotation:     sessionCookieName(mcpServer),
+		traefikStickyCookieHTTPOnlyAnnotation: "true",
+	}
+}
+
+// applySessionAffinityServiceAnnotations sets the Traefik sticky cookie annotations on the
+// Service, and removes them once cookie affinity is turned off.
+func applySessionAffinityServiceAnnotations(mcpServer *mcpv1alpha1.MCPServer, service *corev1.Service) {
+	for _, key := range []string{traefikStickyCookieAnnotation, traefikStickyCookieNameAnnotation, traefikStickyCookieHTTPOnlyAnnotation} {
+		delete(service.Annotations, key)
+	}
+	annotations := sessionAffinityServiceAnnotations(mcpServer)
+	if len(annotations) == 0 {
+		return
+	}
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		service.Annotations[k] = v
+	}
+}
+
+// sessionAffinityIngressAnnotations returns the nginx annotations keeping a client on one
+// pod: a persistent cookie, or hashing upstreams by client address. Annotations the user
+// already set in existing are left alone.
+func sessionAffinityIngressAnnotations(mcpServer *mcpv1alpha1.MCPServer, existing map[string]string) map[string]string {
+	if mcpServer.Spec.IngressClass != "nginx" {
+		return nil
+	}
+	var annotations map[string]string
+	switch mcpServer.Spec.SessionAffinity {
+	case SessionAffinityCookie:
+		annotations = map[string]string{
+			nginxAffinityAnnotation:          "cookie",
+			nginxAffinityModeAnnotation:      "persistent",
+			nginxSessionCookieNameAnnotation: sessionCookieName(mcpServer),
+		}
+	case SessionAffinityClientIP:
+		annotations = map[string]string{nginxUpstreamHashByAnnotation: "$remote_addr"}
+	}
+	for k := range annotations {
+		if _, exists := existing[k]; exists {
+			delete(annotations, k)
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,105 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func sessionAffinityServer(class, affinity string) *mcpv1alpha1.MCPServer {
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "chat", Namespace: "team-a"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:           "chat",
+			Port:            8088,
+			ServicePort:     80,
+			IngressClass:    class,
+			SessionAffinity: affinity,
+		},
+	}
+}
+
+func TestReconcileServiceSessionAffinity(t *testing.T) {
+	scheme := newHealthTestScheme()
+	ctx := context.Background()
+	server := sessionAffinityServer("traefik", SessionAffinityCookie)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	get := func() *corev1.Service {
+		t.Helper()
+		if err := r.reconcileService(ctx, server); err != nil {
+			t.Fatalf("reconcileService() error: %v", err)
+		}
+		var service corev1.Service
+		if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "chat"}, &service); err != nil {
+			t.Fatal(err)
+		}
+		return &service
+	}
+
+	service := get()
+	assertEqual(t, "sticky cookie", service.Annotations[traefikStickyCookieAnnotation], "true")
+	assertEqual(t, "cookie name", service.Annotations[traefikStickyCookieNameAnnotation], "mcp-chat")
+	assertEqual(t, "service affinity", service.Spec.SessionAffinity, corev1.ServiceAffinityNone)
+
+	server.Spec.SessionAffinity = SessionAffinityClientIP
+	service = get()
+	assertEqual(t, "service affinity", service.Spec.SessionAffinity, corev1.ServiceAffinityClientIP)
+	if _, ok := service.Annotations[traefikStickyCookieAnnotation]; ok {
+		t.Fatalf("expected sticky cookie annotations removed, got %v", service.Annotations)
+	}
+}
+
+func TestSessionAffinityIngressAnnotations(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *mcpv1alpha1.MCPServer
+		existing map[string]string
+		want     map[string]string
+	}{
+		{
+			name:   "nginx cookie",
+			server: sessionAffinityServer("nginx", SessionAffinityCookie),
+			want: map[string]string{
+				nginxAffinityAnnotation:          "cookie",
+				nginxAffinityModeAnnotation:      "persistent",
+				nginxSessionCookieNameAnnotation: "mcp-chat",
+			},
+		},
+		{
+			name:   "nginx client IP",
+			server: sessionAffinityServer("nginx", SessionAffinityClientIP),
+			want:   map[string]string{nginxUpstreamHashByAnnotation: "$remote_addr"},
+		},
+		{
+			name:     "user annotations win",
+			server:   sessionAffinityServer("nginx", SessionAffinityClientIP),
+			existing: map[string]string{nginxUpstreamHashByAnnotation: "$http_x_session"},
+			want:     map[string]string{},
+		},
+		{
+			name:   "traefik sets no ingress annotations",
+			server: sessionAffinityServer("traefik", SessionAffinityCookie),
+		},
+		{
+			name:   "disabled",
+			server: sessionAffinityServer("nginx", ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sessionAffinityIngressAnnotations(tt.server, tt.existing)
+			assertEqual(t, "annotation count", len(got), len(tt.want))
+			for k, v := range tt.want {
+				assertEqual(t, k, got[k], v)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+const testDigest = "sha256:0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+
+type stubVerifier struct {
+	err    error
+	images []string
+}
+
+func (s *stubVerifier) Verify(_ context.Context, image string) (string, error) {
+	s.images = append(s.images, image)
+	if s.err != nil {
+		return "", s.err
+	}
+	return testDigest, nil
+}
+
+func TestSignatureConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SignatureConfig
+		wantErr bool
+	}{
+		{"public key", SignatureConfig{PublicKey: "/keys/cosign.pub"}, false},
+		{"keyless", SignatureConfig{CertificateIdentity: ".*@example.com", CertificateOIDCIssuer: "https://accounts.google.com"}, false},
+		{"nothing configured", SignatureConfig{}, true},
+		{"both modes", SignatureConfig{PublicKey: "/keys/cosign.pub", CertificateIdentity: ".*"}, true},
+		{"keyless without issuer", SignatureConfig{CertificateIdentity: ".*"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			assertEqual(t, "error", err != nil, tt.wantErr)
+		})
+	}
+}
+
+func TestSignatureConfigVerifyArgs(t *testing.T) {
+	t.Run("uses the public key", func(t *testing.T) {
+		cfg := SignatureConfig{PublicKey: "/keys/cosign.pub"}
+		got := strings.Join(cfg.verifyArgs("registry.local/app:v1"), " ")
+		assertEqual(t, "args", got, "verify --output json --key /keys/cosign.pub registry.local/app:v1")
+	})
+
+	t.Run("uses identity and issuer when keyless", func(t *testing.T) {
+		cfg := SignatureConfig{CertificateIdentity: ".*@example.com", CertificateOIDCIssuer: "https://issuer"}
+		got := strings.Join(cfg.verifyArgs("registry.local/app:v1"), " ")
+		assertEqual(t, "args", got, "verify --output json --certificate-identity-regexp .*@example.com --certificate-oidc-issuer https://issuer registry.local/app:v1")
+	})
+}
+
+func TestCosignVerifier(t *testing.T) {
+	stubCosign := func(t *testing.T, bin string, calls *int, args ...string) {
+		t.Helper()
+		original := cosignCommand
+		cosignCommand = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+			*calls++
+			return exec.CommandContext(ctx, bin, args...)
+		}
+		t.Cleanup(func() { cosignCommand = original })
+	}
+	verified := `[{"critical":{"identity":{"docker-reference":"registry.local/app"},"image":{"docker-manifest-digest":"` + testDigest + `"},"type":"cosign container image signature"},"optional":null}]`
+
+	t.Run("caches successful verifications", func(t *testing.T) {
+		calls := 0
+		stubCosign(t, "echo", &calls, verified)
+		v := NewCosignVerifier(SignatureConfig{PublicKey: "/keys/cosign.pub"})
+
+		for i := 0; i < 2; i++ {
+			digest, err := v.Verify(context.Background(), "registry.local/app:v1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertEqual(t, "digest", digest, testDigest)
+		}
+		assertEqual(t, "cosign calls", calls, 1)
+	})
+
+	t.Run("needs a digest in the output", func(t *testing.T) {
+		calls := 0
+		stubCosign(t, "echo", &calls, "[]")
+		v := NewCosignVerifier(SignatureConfig{PublicKey: "/keys/cosign.pub"})
+
+		if _, err := v.Verify(context.Background(), "registry.local/app:v1"); !errors.Is(err, ErrImageVerifierUnavailable) {
+			t.Fatalf("expected ErrImageVerifierUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("returns error for unsigned image", func(t *testing.T) {
+		calls := 0
+		stubCosign(t, "false", &calls)
+		v := NewCosignVerifier(SignatureConfig{PublicKey: "/keys/cosign.pub"})
+
+		_, err := v.Verify(context.Background(), "registry.local/app:v1")
+		if err == nil || errors.Is(err, ErrImageVerifierUnavailable) {
+			t.Fatalf("expected a verification error, got %v", err)
+		}
+	})
+
+	t.Run("reports a missing cosign as unavailable", func(t *testing.T) {
+		calls := 0
+		stubCosign(t, "/nonexistent/cosign", &calls)
+		v := NewCosignVerifier(SignatureConfig{PublicKey: "/keys/cosign.pub"})
+
+		if _, err := v.Verify(context.Background(), "registry.local/app:v1"); !errors.Is(err, ErrImageVerifierUnavailable) {
+			t.Fatalf("expected ErrImageVerifierUnavailable, got %v", err)
+		}
+	})
+}
+
+func TestReconcileVerifiesImageSignature(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	newServer := func() *mcpv1alpha1.MCPServer {
+		replicas := int32(1)
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "signed", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "registry.local/app",
+				ImageTag:     "v1",
+				Port:         8088,
+				ServicePort:  80,
+				Replicas:     &replicas,
+				IngressHost:  "example.com",
+				IngressPath:  "/signed/mcp",
+				IngressClass: "traefik",
+			},
+		}
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "signed", Namespace: "default"}}
+
+	t.Run("refuses to deploy an unsigned image", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer()).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		verifier := &stubVerifier{err: errors.New("no signatures found")}
+		r := MCPServerReconciler{Client: client, Scheme: scheme, ImageVerifier: verifier}
+
+		if _, err := r.Reconcile(context.Background(), request); !errors.Is(err, ErrImageSignatureInvalid) {
+			t.Fatalf("expected ErrImageSignatureInvalid, got %v", err)
+		}
+		assertEqual(t, "verified image", strings.Join(verifier.images, ","), "registry.local/app:v1")
+
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(context.Background(), request.NamespacedName, deployment); err == nil {
+			t.Fatal("expected no Deployment for an unsigned image")
+		}
+
+		updated := &mcpv1alpha1.MCPServer{}
+		if err := client.Get(context.Background(), request.NamespacedName, updated); err != nil {
+			t.Fatalf("get MCPServer: %v", err)
+		}
+		cond := findCondition(updated.Status.Conditions, ConditionImageVerified)
+		if cond == nil {
+			t.Fatal("expected ImageVerified condition")
+		}
+		assertEqual(t, "status", cond.Status, metav1.ConditionFalse)
+		assertEqual(t, "reason", cond.Reason, ReasonSignatureInvalid)
+		assertEqual(t, "phase", updated.Status.Phase, "Error")
+	})
+
+	t.Run("retries when the verifier is unavailable", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer()).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		verifier := &stubVerifier{err: fmt.Errorf("%w: registry timeout", ErrImageVerifierUnavailable)}
+		r := MCPServerReconciler{Client: client, Scheme: scheme, ImageVerifier: verifier}
+
+		_, err := r.Reconcile(context.Background(), request)
+		if !errors.Is(err, ErrImageVerifierUnavailable) || errors.Is(err, ErrImageSignatureInvalid) {
+			t.Fatalf("expected ErrImageVerifierUnavailable only, got %v", err)
+		}
+	})
+
+	t.Run("deploys a signed image", func(t *testing.T) {
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newServer()).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+		r := MCPServerReconciler{Client: client, Scheme: scheme, ImageVerifier: &stubVerifier{}}
+
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deployment := &appsv1.Deployment{}
+		if err := client.Get(context.Background(), request.NamespacedName, deployment); err != nil {
+			t.Fatalf("expected Deployment: %v", err)
+		}
+		assertEqual(t, "image", deployment.Spec.Template.Spec.Containers[0].Image, "registry.local/app:v1@"+testDigest)
+	})
+}
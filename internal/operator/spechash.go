@@ -0,0 +1,65 @@
+package operator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// AnnotationSpecHash on a Deployment, Service or Ingress holds a hash of the state the
+// operator last applied to it. A reconcile whose desired state hashes the same, and whose
+// live object still carries every field the operator sets, skips the update, so unchanged
+// servers cost a cache read instead of an API write. Hand edits to those fields, such as a
+// kubectl scale, are reverted; fields the operator leaves unset, such as API defaults, are
+// not compared.
+const AnnotationSpecHash = "mcpruntime.org/spec-hash"
+
+// specHash returns a hash of the JSON encoding of the desired state.
+func specHash(desired any) (string, error) {
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// inSync reports whether every field set in desired has the same value in live; fields
+// desired leaves unset, such as those the API server defaults, are ignored.
+func inSync(desired, live any) bool {
+	return equality.Semantic.DeepDerivative(desired, live)
+}
+
+// createOrUpdateHashed runs CreateOrUpdate for obj unless the live object already carries
+// hash, was applied by this operator version and synced reports true for it, in which case
+// obj is left as read and OperationResultNone is returned. synced runs on the live object,
+// so it catches hand edits the hash cannot see. mutate sets the state that hash was
+// computed from; the hash and version annotations are added after it.
+func (r *MCPServerReconciler) createOrUpdateHashed(ctx context.Context, obj client.Object, hash string, synced func() bool, mutate func() error) (controllerutil.OperationResult, error) {
+	err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	switch {
+	case err == nil && obj.GetAnnotations()[AnnotationSpecHash] == hash && obj.GetAnnotations()[AnnotationOperatorVersion] == OperatorVersion() && synced():
+		return controllerutil.OperationResultNone, nil
+	case err != nil && !errors.IsNotFound(err):
+		return controllerutil.OperationResultNone, err
+	}
+	return controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if err := mutate(); err != nil {
+			return err
+		}
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AnnotationSpecHash] = hash
+		annotations[AnnotationOperatorVersion] = OperatorVersion()
+		obj.SetAnnotations(annotations)
+		return nil
+	})
+}
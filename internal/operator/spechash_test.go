@@ -0,0 +1,145 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestSpecHash(t *testing.T) {
+	a, err := specHash(map[string]string{"image": "app:v1"})
+	if err != nil {
+		t.Fatalf("specHash() error: %v", err)
+	}
+	b, _ := specHash(map[string]string{"image": "app:v1"})
+	c, _ := specHash(map[string]string{"image": "app:v2"})
+	assertEqual(t, "same state", a, b)
+	if a == c {
+		t.Fatalf("expected different hashes for different states, got %s", a)
+	}
+}
+
+func TestReconcileSkipsUnchangedChildren(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	replicas := int32(1)
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:       "registry.local/app",
+			ImageTag:    "v1",
+			Port:        8088,
+			ServicePort: 80,
+			Replicas:    &replicas,
+			IngressHost: "example.com",
+			IngressPath: "/app/mcp",
+		},
+	}
+	writes := map[string]int{}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(&mcpv1alpha1.MCPServer{}).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			switch obj.(type) {
+			case *appsv1.Deployment:
+				writes["deployment"]++
+			case *corev1.Service:
+				writes["service"]++
+			case *networkingv1.Ingress:
+				writes["ingress"]++
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	request := ctrl.Request{NamespacedName: key}
+
+	// The first reconcile only writes the spec defaults back.
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, request); err != nil {
+			t.Fatalf("reconcile %d: %v", i+1, err)
+		}
+	}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &networkingv1.Ingress{}} {
+		if err := c.Get(ctx, key, obj); err != nil {
+			t.Fatalf("get %T: %v", obj, err)
+		}
+		if obj.GetAnnotations()[AnnotationSpecHash] == "" {
+			t.Fatalf("expected %s on %T", AnnotationSpecHash, obj)
+		}
+		assertEqual(t, "operator version", obj.GetAnnotations()[AnnotationOperatorVersion], OperatorVersion())
+	}
+
+	// A field the API server would default makes the live Deployment differ from the
+	// desired one; the matching hash still skips the update.
+	deployment := &appsv1.Deployment{}
+	_ = c.Get(ctx, key, deployment)
+	history := int32(10)
+	deployment.Spec.RevisionHistoryLimit = &history
+	if err := c.Update(ctx, deployment); err != nil {
+		t.Fatalf("update Deployment: %v", err)
+	}
+	clear(writes)
+
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("unchanged reconcile: %v", err)
+	}
+	if len(writes) != 0 {
+		t.Fatalf("expected no child updates for an unchanged server, got %v", writes)
+	}
+
+	// A hand edit to a field the operator sets is reverted.
+	_ = c.Get(ctx, key, deployment)
+	scaled := int32(5)
+	deployment.Spec.Replicas = &scaled
+	if err := c.Update(ctx, deployment); err != nil {
+		t.Fatalf("scale Deployment: %v", err)
+	}
+	clear(writes)
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("drifted reconcile: %v", err)
+	}
+	assertEqual(t, "deployment writes after drift", writes["deployment"], 1)
+	_ = c.Get(ctx, key, deployment)
+	assertEqual(t, "restored replicas", *deployment.Spec.Replicas, int32(1))
+	clear(writes)
+
+	updated := &mcpv1alpha1.MCPServer{}
+	if err := c.Get(ctx, key, updated); err != nil {
+		t.Fatalf("get MCPServer: %v", err)
+	}
+	updated.Spec.ImageTag = "v2"
+	if err := c.Update(ctx, updated); err != nil {
+		t.Fatalf("update MCPServer: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("changed reconcile: %v", err)
+	}
+	assertEqual(t, "deployment writes", writes["deployment"], 1)
+	assertEqual(t, "service writes", writes["service"], 0)
+	_ = c.Get(ctx, key, deployment)
+	assertEqual(t, "image", deployment.Spec.Template.Spec.Containers[0].Image, "registry.local/app:v2")
+
+	// A new operator version re-applies the children once to stamp its version.
+	previous := buildInfo
+	t.Cleanup(func() { buildInfo = previous })
+	buildInfo.Version = "v9.9.9"
+	clear(writes)
+	if _, err := r.Reconcile(ctx, request); err != nil {
+		t.Fatalf("upgraded reconcile: %v", err)
+	}
+	assertEqual(t, "service writes after upgrade", writes["service"], 1)
+	_ = c.Get(ctx, key, deployment)
+	assertEqual(t, "upgraded version", deployment.Annotations[AnnotationOperatorVersion], "v9.9.9")
+}
@@ -0,0 +1,34 @@
+package operator
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// AnnotationIngressHostPrefix on a namespace puts the servers in it that set no
+// spec.ingressHost under a subdomain of the default or detected host: prefix "team-a"
+// turns mcp.example.com into team-a.mcp.example.com. `mcp-runtime team create` sets it.
+const AnnotationIngressHostPrefix = "mcpruntime.org/ingress-host-prefix"
+
+// ingressHostPrefix returns the ingress host prefix of namespace, or "" if it has none.
+func (r *MCPServerReconciler) ingressHostPrefix(ctx context.Context, namespace string) (string, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return ns.Annotations[AnnotationIngressHostPrefix], nil
+}
+
+// prefixIngressHost puts host under the subdomain prefix.
+func prefixIngressHost(prefix, host string) string {
+	if prefix == "" || host == "" {
+		return host
+	}
+	return prefix + "." + host
+}
@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func teamNamespace(name, prefix string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Annotations: map[string]string{AnnotationIngressHostPrefix: prefix},
+	}}
+}
+
+func TestIngressHostPrefixDefaultHost(t *testing.T) {
+	scheme := newHealthTestScheme()
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "api"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, teamNamespace("team-a", "alpha")).
+		WithStatusSubresource(&mcpv1alpha1.MCPServer{}).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme, DefaultIngressHost: "mcp.example.com"}
+
+	if err := r.applyIngressDefaults(context.Background(), server, logr.Discard()); err != nil {
+		t.Fatalf("applyIngressDefaults() error: %v", err)
+	}
+	assertEqual(t, "spec host", server.Spec.IngressHost, "alpha.mcp.example.com")
+
+	// An explicit host is left alone.
+	server.Spec.IngressHost = "api.example.com"
+	if err := r.applyIngressDefaults(context.Background(), server, logr.Discard()); err != nil {
+		t.Fatalf("applyIngressDefaults() error: %v", err)
+	}
+	assertEqual(t, "spec host", server.Spec.IngressHost, "api.example.com")
+}
+
+func TestIngressHostPrefixDetectedHost(t *testing.T) {
+	scheme := newHealthTestScheme()
+	traefik := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "traefik", Namespace: "traefik"},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+		}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(traefik, teamNamespace("team-a", "alpha")).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	for namespace, want := range map[string]string{"team-a": "alpha.lb.example.com", "default": "lb.example.com"} {
+		server := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+			Spec:       mcpv1alpha1.MCPServerSpec{IngressClass: "traefik"},
+		}
+		r.resolveIngressHost(context.Background(), server, logr.Discard())
+		assertEqual(t, namespace+" status host", server.Status.IngressHost, want)
+	}
+}
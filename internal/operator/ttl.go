@@ -0,0 +1,122 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// AnnotationTTL on an MCPServer is a lifetime such as "72h", counted from the server's
+// creation; the TTL controller deletes the server once it has passed. "server preview
+// create" sets it on branch preview servers.
+const AnnotationTTL = "mcpruntime.org/ttl"
+
+// annotationProtected marks servers the delete protection admission policy guards; the
+// TTL controller leaves them alone rather than retrying a delete that is refused.
+const annotationProtected = "mcpruntime.org/protected"
+
+// TTLReconciler deletes MCPServers whose AnnotationTTL has expired. It runs as its own
+// controller next to MCPServerReconciler and only sees servers carrying the annotation.
+type TTLReconciler struct {
+	client.Client
+
+	// Recorder emits events about expired servers and invalid TTLs.
+	Recorder record.EventRecorder
+
+	// now returns the current time; tests replace it.
+	now func() time.Time
+}
+
+// Reconcile deletes the server once its TTL has passed and otherwise requeues it for the
+// moment it expires.
+func (r *TTLReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var mcpServer mcpv1alpha1.MCPServer
+	if err := r.Get(ctx, req.NamespacedName, &mcpServer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mcpServer.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	expiry, ok, err := serverExpiry(&mcpServer)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		// Retrying cannot fix the annotation; the next edit of the server triggers a reconcile.
+		logger.Error(err, "Ignoring invalid TTL", "name", mcpServer.Name, "namespace", mcpServer.Namespace)
+		r.event(&mcpServer, "Warning", "InvalidTTL", err.Error())
+		return ctrl.Result{}, nil
+	}
+	if remaining := expiry.Sub(r.clock()); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	if mcpServer.Annotations[annotationProtected] == "true" {
+		logger.Info("Keeping expired MCPServer because it is protected", "name", mcpServer.Name, "namespace", mcpServer.Namespace)
+		r.event(&mcpServer, "Warning", "ExpiredProtected", fmt.Sprintf("TTL %s expired, but the server is protected against deletion", mcpServer.Annotations[AnnotationTTL]))
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Deleting expired MCPServer", "name", mcpServer.Name, "namespace", mcpServer.Namespace, "ttl", mcpServer.Annotations[AnnotationTTL])
+	r.event(&mcpServer, "Normal", "Expired", fmt.Sprintf("Deleting the server: TTL %s expired at %s", mcpServer.Annotations[AnnotationTTL], expiry.UTC().Format(time.RFC3339)))
+	uid := mcpServer.UID
+	if err := r.Delete(ctx, &mcpServer, client.Preconditions{UID: &uid}); err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// serverExpiry returns when mcpServer expires under its AnnotationTTL. ok is false for
+// servers without the annotation; err reports an annotation that is not a positive duration.
+func serverExpiry(mcpServer *mcpv1alpha1.MCPServer) (expiry time.Time, ok bool, err error) {
+	value, ok := mcpServer.Annotations[AnnotationTTL]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err == nil && ttl <= 0 {
+		err = fmt.Errorf("must be positive")
+	}
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid %s %q: %w", AnnotationTTL, value, err)
+	}
+	return mcpServer.CreationTimestamp.Add(ttl), true, nil
+}
+
+func (r *TTLReconciler) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *TTLReconciler) event(mcpServer *mcpv1alpha1.MCPServer, eventType, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(mcpServer, eventType, reason, message)
+	}
+}
+
+// hasTTL limits the TTL controller to servers carrying AnnotationTTL.
+func hasTTL(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[AnnotationTTL]
+	return ok
+}
+
+// SetupWithManager sets up the TTL controller with the Manager.
+func (r *TTLReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("mcpserver-ttl").
+		For(&mcpv1alpha1.MCPServer{}, builder.WithPredicates(predicate.NewPredicateFuncs(hasTTL))).
+		Complete(r)
+}
@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestTTLReconciler(t *testing.T) {
+	created := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newServer := func(name string, annotations map[string]string) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "previews", UID: types.UID("uid-" + name),
+			CreationTimestamp: metav1.NewTime(created), Annotations: annotations,
+		}}
+	}
+	servers := []client.Object{
+		newServer("weather-feature-x", map[string]string{AnnotationTTL: "72h"}),
+		newServer("weather-expired", map[string]string{AnnotationTTL: "24h"}),
+		newServer("weather-protected", map[string]string{AnnotationTTL: "24h", annotationProtected: "true"}),
+		newServer("weather-invalid", map[string]string{AnnotationTTL: "forever"}),
+	}
+	c := fake.NewClientBuilder().WithScheme(newHealthTestScheme()).WithObjects(servers...).Build()
+	recorder := record.NewFakeRecorder(8)
+	now := created.Add(48 * time.Hour)
+	r := TTLReconciler{Client: c, Recorder: recorder, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	reconcile := func(name string) ctrl.Result {
+		t.Helper()
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "previews"}})
+		if err != nil {
+			t.Fatalf("reconcile %s: %v", name, err)
+		}
+		return result
+	}
+	exists := func(name string) bool {
+		err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "previews"}, &mcpv1alpha1.MCPServer{})
+		if client.IgnoreNotFound(err) != nil {
+			t.Fatalf("get %s: %v", name, err)
+		}
+		return err == nil
+	}
+
+	assertEqual(t, "requeue", reconcile("weather-feature-x").RequeueAfter, 24*time.Hour)
+	assertEqual(t, "live server kept", exists("weather-feature-x"), true)
+
+	reconcile("weather-expired")
+	assertEqual(t, "expired server deleted", exists("weather-expired"), false)
+	assertEqual(t, "event", <-recorder.Events, "Normal Expired Deleting the server: TTL 24h expired at 2026-01-02T12:00:00Z")
+
+	reconcile("weather-protected")
+	assertEqual(t, "protected server kept", exists("weather-protected"), true)
+	<-recorder.Events
+
+	assertEqual(t, "invalid ttl requeue", reconcile("weather-invalid"), ctrl.Result{})
+	assertEqual(t, "invalid ttl kept", exists("weather-invalid"), true)
+	assertEqual(t, "invalid event", <-recorder.Events,
+		`Warning InvalidTTL invalid mcpruntime.org/ttl "forever": time: invalid duration "forever"`)
+
+	reconcile("missing")
+}
@@ -0,0 +1,63 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// Ingress annotations that indicate TLS termination for a route.
+const (
+	traefikEntrypointsAnnotation = "traefik.ingress.kubernetes.io/router.entrypoints"
+	traefikRouterTLSAnnotation   = "traefik.ingress.kubernetes.io/router.tls"
+	nginxSSLRedirectAnnotation   = "nginx.ingress.kubernetes.io/ssl-redirect"
+	nginxForceSSLAnnotation      = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+)
+
+// serverURL returns the externally reachable URL of an MCPServer, or an empty
+// string while no ingress host is known. Servers with the none ingress provider
+// report their in-cluster Service URL.
+func (r *MCPServerReconciler) serverURL(mcpServer *mcpv1alpha1.MCPServer) string {
+	if r.ingressProvider(mcpServer).Name() == IngressProviderNone {
+		return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", mcpServer.Name, mcpServer.Namespace, mcpServer.Spec.ServicePort, mcpServer.Spec.IngressPath)
+	}
+	host := effectiveIngressHost(mcpServer)
+	if host == "" {
+		return ""
+	}
+	scheme := "http"
+	if r.IngressTLS || mcpServer.Spec.TLSOnly || ingressAnnotationsEnableTLS(mcpServer.Spec.IngressAnnotations) {
+		scheme = "https"
+	}
+	return scheme + "://" + host + mcpServer.Spec.IngressPath
+}
+
+// traefikEntrypoints returns the default Traefik entrypoints for a server: websecure
+// for tlsOnly servers, both entrypoints in dual mode, and web otherwise.
+func (r *MCPServerReconciler) traefikEntrypoints(mcpServer *mcpv1alpha1.MCPServer) string {
+	switch {
+	case mcpServer.Spec.TLSOnly:
+		return "websecure"
+	case r.IngressDual:
+		return "web,websecure"
+	default:
+		return "web"
+	}
+}
+
+// ingressAnnotationsEnableTLS reports whether user-provided ingress annotations
+// route the server through a TLS entrypoint.
+func ingressAnnotationsEnableTLS(annotations map[string]string) bool {
+	if annotations[traefikRouterTLSAnnotation] == "true" ||
+		annotations[nginxSSLRedirectAnnotation] == "true" ||
+		annotations[nginxForceSSLAnnotation] == "true" {
+		return true
+	}
+	for _, entrypoint := range strings.Split(annotations[traefikEntrypointsAnnotation], ",") {
+		if strings.TrimSpace(entrypoint) == "websecure" {
+			return true
+		}
+	}
+	return false
+}
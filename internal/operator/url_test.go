@@ -0,0 +1,77 @@
+package operator
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func TestServerURL(t *testing.T) {
+	newServer := func(host string, annotations map[string]string) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				IngressHost:        host,
+				IngressPath:        "/demo/mcp",
+				IngressAnnotations: annotations,
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		tls    bool
+		server *mcpv1alpha1.MCPServer
+		want   string
+	}{
+		{"http by default", false, newServer("mcp.example.com", nil), "http://mcp.example.com/demo/mcp"},
+		{"https when operator TLS is enabled", true, newServer("mcp.example.com", nil), "https://mcp.example.com/demo/mcp"},
+		{"https for traefik websecure entrypoint", false, newServer("mcp.example.com", map[string]string{traefikEntrypointsAnnotation: "web, websecure"}), "https://mcp.example.com/demo/mcp"},
+		{"https for traefik router tls", false, newServer("mcp.example.com", map[string]string{traefikRouterTLSAnnotation: "true"}), "https://mcp.example.com/demo/mcp"},
+		{"https for nginx ssl redirect", false, newServer("mcp.example.com", map[string]string{nginxForceSSLAnnotation: "true"}), "https://mcp.example.com/demo/mcp"},
+		{"empty without host", true, newServer("", nil), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MCPServerReconciler{IngressTLS: tt.tls}
+			assertEqual(t, "url", r.serverURL(tt.server), tt.want)
+		})
+	}
+
+	t.Run("https for tlsOnly servers", func(t *testing.T) {
+		server := newServer("mcp.example.com", nil)
+		server.Spec.TLSOnly = true
+		r := MCPServerReconciler{}
+		assertEqual(t, "url", r.serverURL(server), "https://mcp.example.com/demo/mcp")
+	})
+
+	t.Run("uses the auto-detected host", func(t *testing.T) {
+		server := newServer("", nil)
+		server.Status.IngressHost = "203.0.113.10.nip.io"
+		r := MCPServerReconciler{}
+		assertEqual(t, "url", r.serverURL(server), "http://203.0.113.10.nip.io/demo/mcp")
+	})
+}
+
+func TestTraefikEntrypoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		dual    bool
+		tlsOnly bool
+		want    string
+	}{
+		{"web by default", false, false, "web"},
+		{"both entrypoints in dual mode", true, false, "web,websecure"},
+		{"websecure for tlsOnly in dual mode", true, true, "websecure"},
+		{"websecure for tlsOnly", false, true, "websecure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &mcpv1alpha1.MCPServer{Spec: mcpv1alpha1.MCPServerSpec{TLSOnly: tt.tlsOnly}}
+			r := MCPServerReconciler{IngressDual: tt.dual}
+			assertEqual(t, "entrypoints", r.buildIngressAnnotations(server)[traefikEntrypointsAnnotation], tt.want)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// variantArchRe matches kubernetes.io/arch values such as amd64, arm64 or ppc64le.
+var variantArchRe = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// variantDeploymentName is the Deployment running the arch variant of mcpServer.
+func variantDeploymentName(mcpServer *mcpv1alpha1.MCPServer, arch string) string {
+	return mcpServer.Name + "-" + arch
+}
+
+// serverDeploymentNames returns the Deployments the server runs: one per image variant,
+// ordered by architecture, or the single Deployment named after the server.
+func serverDeploymentNames(mcpServer *mcpv1alpha1.MCPServer) []string {
+	if len(mcpServer.Spec.ImageVariants) == 0 {
+		return []string{mcpServer.Name}
+	}
+	names := make([]string, 0, len(mcpServer.Spec.ImageVariants))
+	for _, arch := range sortedKeys(mcpServer.Spec.ImageVariants) {
+		names = append(names, variantDeploymentName(mcpServer, arch))
+	}
+	return names
+}
+
+// archAffinity requires nodes of arch.
+func archAffinity(arch string) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      corev1.LabelArchStable,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{arch},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+// validateImageVariants rejects variants with an invalid architecture or no image.
+func (r *MCPServerReconciler) validateImageVariants(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, logger logr.Logger) error {
+	var message string
+	for _, arch := range sortedKeys(mcpServer.Spec.ImageVariants) {
+		switch {
+		case !variantArchRe.MatchString(arch):
+			message = fmt.Sprintf("spec.imageVariants key %q must be a node architecture such as amd64 or arm64", arch)
+		case mcpServer.Spec.ImageVariants[arch] == "":
+			message = fmt.Sprintf("spec.imageVariants[%s] must name an image", arch)
+		default:
+			continue
+		}
+		break
+	}
+	if message == "" {
+		return nil
+	}
+	contextMap := map[string]any{
+		"mcpServer": mcpServer.Name,
+		"namespace": mcpServer.Namespace,
+	}
+	err := wrapOperatorError(fmt.Errorf("%w: %s", ErrInvalidImageVariant, message), "Invalid image variant", contextMap)
+	r.updateStatus(ctx, mcpServer, "Error", message, false, false, false)
+	logOperatorError(logger, err, "Invalid image variant")
+	return err
+}
+
+// serverImages returns the resolved images the server runs: spec.image, or one per variant
+// ordered by architecture.
+func (r *MCPServerReconciler) serverImages(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) ([]string, error) {
+	if len(mcpServer.Spec.ImageVariants) == 0 || jobMode(mcpServer) {
+		image, err := r.resolveImage(ctx, mcpServer)
+		if err != nil {
+			return nil, err
+		}
+		return []string{image}, nil
+	}
+	var images []string
+	for _, arch := range sortedKeys(mcpServer.Spec.ImageVariants) {
+		image, err := r.resolveImageRef(ctx, mcpServer, mcpServer.Spec.ImageVariants[arch])
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// reconcileVariantDeployments runs one Deployment per image variant and removes the
+// Deployments of variants that were dropped, as well as the single server Deployment.
+func (r *MCPServerReconciler) reconcileVariantDeployments(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	keep := map[string]bool{}
+	for _, arch := range sortedKeys(mcpServer.Spec.ImageVariants) {
+		image, err := r.resolveImageRef(ctx, mcpServer, mcpServer.Spec.ImageVariants[arch])
+		if err != nil {
+			return err
+		}
+		if image, err = r.pinnedImage(ctx, image); err != nil {
+			return err
+		}
+		name := variantDeploymentName(mcpServer, arch)
+		if err := r.applyDeployment(ctx, mcpServer, name, image, arch); err != nil {
+			return fmt.Errorf("variant %s: %w", arch, err)
+		}
+		keep[name] = true
+	}
+	return r.deleteStaleDeployments(ctx, mcpServer, keep)
+}
+
+// deleteStaleDeployments deletes the Deployments the server owns that are not in keep, so
+// switching between a single image and image variants leaves no pods of the old layout.
+func (r *MCPServerReconciler) deleteStaleDeployments(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer, keep map[string]bool) error {
+	var deployments appsv1.DeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(mcpServer.Namespace), client.MatchingLabels{LabelApp: mcpServer.Name}); err != nil {
+		return err
+	}
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if keep[deployment.Name] || !metav1.IsControlledBy(deployment, mcpServer) {
+			continue
+		}
+		if err := r.Delete(ctx, deployment); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		log.FromContext(ctx).Info("Deleted stale Deployment", "name", deployment.Name)
+	}
+	return nil
+}
+
+// recordVariants reports in status which image each variant runs, how many of its pods are
+// ready and on which nodes. It clears the field for servers without variants.
+func (r *MCPServerReconciler) recordVariants(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	if len(mcpServer.Spec.ImageVariants) == 0 {
+		mcpServer.Status.Variants = nil
+		return nil
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(mcpServer.Namespace), client.MatchingLabels{LabelApp: mcpServer.Name}); err != nil {
+		return err
+	}
+	nodes := map[string]map[string]bool{}
+	for _, pod := range pods.Items {
+		arch := pod.Labels[LabelArch]
+		if arch == "" || pod.Spec.NodeName == "" || !podReady(&pod) {
+			continue
+		}
+		if nodes[arch] == nil {
+			nodes[arch] = map[string]bool{}
+		}
+		nodes[arch][pod.Spec.NodeName] = true
+	}
+
+	variants := make([]mcpv1alpha1.VariantStatus, 0, len(mcpServer.Spec.ImageVariants))
+	for _, arch := range sortedKeys(mcpServer.Spec.ImageVariants) {
+		image, err := r.resolveImageRef(ctx, mcpServer, mcpServer.Spec.ImageVariants[arch])
+		if err != nil {
+			return err
+		}
+		variant := mcpv1alpha1.VariantStatus{Arch: arch, Image: image, Deployment: variantDeploymentName(mcpServer, arch)}
+		var deployment appsv1.Deployment
+		err = r.Get(ctx, types.NamespacedName{Namespace: mcpServer.Namespace, Name: variant.Deployment}, &deployment)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		variant.ReadyReplicas = deployment.Status.ReadyReplicas
+		if names := sortedKeys(nodes[arch]); len(names) > 0 {
+			variant.Nodes = names
+		}
+		variants = append(variants, variant)
+	}
+	mcpServer.Status.Variants = variants
+	return nil
+}
+
+// podReady reports whether the pod's Ready condition is true.
+func podReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
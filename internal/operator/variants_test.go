@@ -0,0 +1,169 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func variantTestServer(variants map[string]string) *mcpv1alpha1.MCPServer {
+	replicas := int32(2)
+	return &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+		Spec: mcpv1alpha1.MCPServerSpec{
+			Image:         "registry.example.com/api",
+			ImageTag:      "v1",
+			ImageVariants: variants,
+			Replicas:      &replicas,
+			Port:          8088,
+		},
+	}
+}
+
+func listDeploymentNames(t *testing.T, c client.Client) map[string]appsv1.Deployment {
+	t.Helper()
+	var deployments appsv1.DeploymentList
+	if err := c.List(context.Background(), &deployments); err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]appsv1.Deployment{}
+	for _, d := range deployments.Items {
+		byName[d.Name] = d
+	}
+	return byName
+}
+
+func TestReconcileDeploymentImageVariants(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = appsv1.AddToScheme(scheme)
+	ctx := context.Background()
+	server := variantTestServer(map[string]string{"amd64": "registry.example.com/api-amd64", "arm64": "registry.example.com/api-arm64:v2"})
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	// A server that ran a single image switches to variants.
+	single := server.DeepCopy()
+	single.Spec.ImageVariants = nil
+	if err := r.reconcileDeployment(ctx, single); err != nil {
+		t.Fatalf("reconcileDeployment() error: %v", err)
+	}
+	if err := r.reconcileDeployment(ctx, server); err != nil {
+		t.Fatalf("reconcileDeployment() error: %v", err)
+	}
+
+	deployments := listDeploymentNames(t, c)
+	if _, ok := deployments["api"]; ok || len(deployments) != 2 {
+		t.Fatalf("expected only the variant Deployments, got %v", deployments)
+	}
+	arm := deployments["api-arm64"]
+	assertEqual(t, "arm64 image", arm.Spec.Template.Spec.Containers[0].Image, "registry.example.com/api-arm64:v2")
+	assertEqual(t, "amd64 image", deployments["api-amd64"].Spec.Template.Spec.Containers[0].Image, "registry.example.com/api-amd64:v1")
+	assertEqual(t, "selector arch", arm.Spec.Selector.MatchLabels[LabelArch], "arm64")
+	assertEqual(t, "pod arch", arm.Spec.Template.Labels[LabelArch], "arm64")
+	assertReplicas(t, arm.Spec.Replicas, 2)
+	terms := arm.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if req := terms[0].MatchExpressions[0]; req.Key != corev1.LabelArchStable || req.Values[0] != "arm64" {
+		t.Fatalf("unexpected node affinity %+v", req)
+	}
+
+	// Dropping a variant removes its Deployment.
+	server.Spec.ImageVariants = map[string]string{"amd64": "registry.example.com/api-amd64"}
+	if err := r.reconcileDeployment(ctx, server); err != nil {
+		t.Fatalf("reconcileDeployment() error: %v", err)
+	}
+	if deployments = listDeploymentNames(t, c); len(deployments) != 1 {
+		t.Fatalf("expected only api-amd64, got %v", deployments)
+	}
+}
+
+func TestCheckDeploymentReadyImageVariants(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = appsv1.AddToScheme(scheme)
+	server := variantTestServer(map[string]string{"amd64": "api-amd64", "arm64": "api-arm64"})
+	replicas := int32(2)
+	deployment := func(name string, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment("api-amd64", 2), deployment("api-arm64", 1)).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+	if ready, err := r.checkDeploymentReady(context.Background(), server); err != nil || ready {
+		t.Fatalf("expected not ready while arm64 is short a replica, got %v, %v", ready, err)
+	}
+
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment("api-amd64", 2), deployment("api-arm64", 2)).Build()
+	r.Client = c
+	if ready, err := r.checkDeploymentReady(context.Background(), server); err != nil || !ready {
+		t.Fatalf("expected ready, got %v, %v", ready, err)
+	}
+}
+
+func TestRecordVariants(t *testing.T) {
+	scheme := newHealthTestScheme()
+	_ = appsv1.AddToScheme(scheme)
+	server := variantTestServer(map[string]string{"amd64": "registry.example.com/api-amd64", "arm64": "registry.example.com/api-arm64"})
+	pod := func(name, arch, node string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: map[string]string{LabelApp: "api", LabelArch: arch}},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-arm64", Namespace: "team-a"},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+		},
+		pod("arm-1", "arm64", "graviton-b", corev1.ConditionTrue),
+		pod("arm-2", "arm64", "graviton-a", corev1.ConditionTrue),
+		pod("amd-1", "amd64", "x86-a", corev1.ConditionFalse),
+	).Build()
+	r := MCPServerReconciler{Client: c, Scheme: scheme}
+
+	if err := r.recordVariants(context.Background(), server); err != nil {
+		t.Fatalf("recordVariants() error: %v", err)
+	}
+	variants := server.Status.Variants
+	if len(variants) != 2 {
+		t.Fatalf("expected two variants, got %+v", variants)
+	}
+	assertEqual(t, "amd64 image", variants[0].Image, "registry.example.com/api-amd64:v1")
+	assertEqual(t, "amd64 nodes", len(variants[0].Nodes), 0)
+	assertEqual(t, "arm64 deployment", variants[1].Deployment, "api-arm64")
+	assertEqual(t, "arm64 ready", variants[1].ReadyReplicas, int32(2))
+	assertEqual(t, "arm64 nodes", len(variants[1].Nodes), 2)
+	assertEqual(t, "arm64 first node", variants[1].Nodes[0], "graviton-a")
+
+	server.Spec.ImageVariants = nil
+	if err := r.recordVariants(context.Background(), server); err != nil || server.Status.Variants != nil {
+		t.Fatalf("expected variants cleared, got %+v, %v", server.Status.Variants, err)
+	}
+}
+
+func TestValidateImageVariants(t *testing.T) {
+	scheme := newHealthTestScheme()
+	for _, variants := range []map[string]string{{"ARM64": "api"}, {"arm64": ""}} {
+		server := variantTestServer(variants)
+		r := MCPServerReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(server).Build(), Scheme: scheme}
+		if err := r.validateImageVariants(context.Background(), server, logr.Discard()); !errors.Is(err, ErrInvalidImageVariant) {
+			t.Fatalf("%v: expected ErrInvalidImageVariant, got %v", variants, err)
+		}
+	}
+	r := MCPServerReconciler{Scheme: scheme}
+	if err := r.validateImageVariants(context.Background(), variantTestServer(map[string]string{"arm64": "api"}), logr.Discard()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
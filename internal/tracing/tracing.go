@@ -0,0 +1,64 @@
+// Package tracing configures OpenTelemetry tracing for the CLI and operator.
+//
+// Tracing is opt-in: spans are only exported when OTEL_EXPORTER_OTLP_ENDPOINT
+// is set. Otherwise the global no-op tracer provider is left in place and
+// instrumentation has negligible overhead.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// EnvOTLPEndpoint is the standard OpenTelemetry environment variable that
+// enables OTLP export. The exporter also honours the other OTEL_EXPORTER_OTLP_*
+// variables (headers, insecure, protocol-specific endpoints).
+const EnvOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// ShutdownFunc flushes pending spans and releases exporter resources.
+type ShutdownFunc func(context.Context) error
+
+// Enabled reports whether OTLP export is configured.
+func Enabled(getenv func(string) string) bool {
+	return getenv(EnvOTLPEndpoint) != ""
+}
+
+// Setup installs a global tracer provider that exports spans over OTLP/HTTP
+// when OTEL_EXPORTER_OTLP_ENDPOINT is set. It always returns a usable
+// ShutdownFunc, which is a no-op when tracing is disabled.
+func Setup(ctx context.Context, serviceName, serviceVersion string) (ShutdownFunc, error) {
+	noop := func(context.Context) error { return nil }
+	if !Enabled(os.Getenv) {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("service.name", serviceName)}
+	if serviceVersion != "" {
+		attrs = append(attrs, attribute.String("service.version", serviceVersion))
+	}
+	res := resource.NewSchemaless(attrs...)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
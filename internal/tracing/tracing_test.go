@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Run("disabled without endpoint", func(t *testing.T) {
+		if Enabled(func(string) string { return "" }) {
+			t.Fatal("expected tracing to be disabled")
+		}
+	})
+
+	t.Run("enabled with endpoint", func(t *testing.T) {
+		getenv := func(key string) string {
+			if key == EnvOTLPEndpoint {
+				return "http://collector:4318"
+			}
+			return ""
+		}
+		if !Enabled(getenv) {
+			t.Fatal("expected tracing to be enabled")
+		}
+	})
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("returns no-op shutdown when endpoint is unset", func(t *testing.T) {
+		t.Setenv(EnvOTLPEndpoint, "")
+		shutdown, err := Setup(context.Background(), "test", "v0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := shutdown(context.Background()); err != nil {
+			t.Fatalf("shutdown error: %v", err)
+		}
+	})
+
+	t.Run("installs exporter when endpoint is set", func(t *testing.T) {
+		t.Setenv(EnvOTLPEndpoint, "http://127.0.0.1:4318")
+		shutdown, err := Setup(context.Background(), "test", "v0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if shutdown == nil {
+			t.Fatal("expected shutdown func")
+		}
+		// Nothing was recorded, so shutdown does not need to reach the collector.
+		_ = shutdown(context.Background())
+	})
+}
@@ -0,0 +1,198 @@
+// Package client manages MCPServer resources from Go without shelling out to the CLI.
+//
+// It is a thin typed wrapper over a controller-runtime client:
+//
+//	cfg, err := config.GetConfig() // sigs.k8s.io/controller-runtime/pkg/client/config
+//	if err != nil {
+//		return err
+//	}
+//	c, err := client.New(cfg)
+//	if err != nil {
+//		return err
+//	}
+//	server := &mcpv1alpha1.MCPServer{
+//		ObjectMeta: metav1.ObjectMeta{Name: "weather", Labels: map[string]string{"team": "data"}},
+//		Spec:       mcpv1alpha1.MCPServerSpec{Image: "registry.example.com/weather", ImageTag: "v2"},
+//	}
+//	if _, err := c.CreateOrUpdateMCPServer(ctx, server); err != nil {
+//		return err
+//	}
+//	server, err = c.WaitForReady(ctx, server.Namespace, server.Name, 5*time.Minute)
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+// DefaultNamespace is used for servers that name no namespace, as in the CLI.
+const DefaultNamespace = "mcp-servers"
+
+// PhaseReady is the status phase of a server whose Deployment, Service and Ingress are ready.
+const PhaseReady = "Ready"
+
+// defaultPollInterval is how often WaitForReady reads the server.
+const defaultPollInterval = 2 * time.Second
+
+// ErrNotReady is returned by WaitForReady when the server is not Ready in time.
+var ErrNotReady = errors.New("mcpserver not ready")
+
+// Client manages MCPServer resources. It is safe for concurrent use.
+type Client struct {
+	c crclient.Client
+
+	// PollInterval is how often WaitForReady reads the server; zero uses 2s.
+	PollInterval time.Duration
+}
+
+// New returns a Client for the cluster at cfg.
+func New(cfg *rest.Config) (*Client, error) {
+	scheme := runtime.NewScheme()
+	if err := mcpv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("register mcpruntime types: %w", err)
+	}
+	c, err := crclient.New(cfg, crclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+	return NewFromClient(c), nil
+}
+
+// NewFromClient wraps an existing controller-runtime client, whose scheme must include the
+// mcpruntime.org/v1alpha1 types. Tests can pass a fake client.
+func NewFromClient(c crclient.Client) *Client {
+	return &Client{c: c}
+}
+
+// Get returns the named server.
+func (c *Client) Get(ctx context.Context, namespace, name string) (*mcpv1alpha1.MCPServer, error) {
+	server := &mcpv1alpha1.MCPServer{}
+	if err := c.c.Get(ctx, types.NamespacedName{Namespace: namespaceOrDefault(namespace), Name: name}, server); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// CreateOrUpdateMCPServer creates server or updates the stored server to match it. The
+// spec is replaced; labels and annotations are merged into the stored ones so keys added
+// by others (such as the CLI's suspend annotation) are kept. On return server holds the
+// stored object.
+func (c *Client) CreateOrUpdateMCPServer(ctx context.Context, server *mcpv1alpha1.MCPServer) (controllerutil.OperationResult, error) {
+	if server.Name == "" {
+		return controllerutil.OperationResultNone, errors.New("mcpserver name is required")
+	}
+	stored := &mcpv1alpha1.MCPServer{}
+	stored.Name = server.Name
+	stored.Namespace = namespaceOrDefault(server.Namespace)
+	result, err := controllerutil.CreateOrUpdate(ctx, c.c, stored, func() error {
+		stored.Spec = *server.Spec.DeepCopy()
+		stored.Labels = mergeStrings(stored.Labels, server.Labels)
+		stored.Annotations = mergeStrings(stored.Annotations, server.Annotations)
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("apply mcpserver %s/%s: %w", stored.Namespace, stored.Name, err)
+	}
+	stored.DeepCopyInto(server)
+	return result, nil
+}
+
+// ListByLabel returns the servers in namespace whose labels match selector. An empty
+// namespace lists servers in all namespaces.
+func (c *Client) ListByLabel(ctx context.Context, namespace string, selector map[string]string) ([]mcpv1alpha1.MCPServer, error) {
+	var list mcpv1alpha1.MCPServerList
+	opts := []crclient.ListOption{crclient.MatchingLabels(selector)}
+	if namespace != "" {
+		opts = append(opts, crclient.InNamespace(namespace))
+	}
+	if err := c.c.List(ctx, &list, opts...); err != nil {
+		return nil, fmt.Errorf("list mcpservers: %w", err)
+	}
+	return list.Items, nil
+}
+
+// Delete deletes the named server. Deleting a server that does not exist is not an error.
+func (c *Client) Delete(ctx context.Context, namespace, name string) error {
+	server := &mcpv1alpha1.MCPServer{}
+	server.Name = name
+	server.Namespace = namespaceOrDefault(namespace)
+	if err := c.c.Delete(ctx, server); crclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("delete mcpserver %s/%s: %w", server.Namespace, name, err)
+	}
+	return nil
+}
+
+// WaitForReady polls the named server until the operator has reconciled its current
+// generation and reports it Ready, and returns it. It fails with ErrNotReady, carrying the
+// last phase and message, once timeout passes or ctx is done; a zero timeout waits for ctx.
+func (c *Client) WaitForReady(ctx context.Context, namespace, name string, timeout time.Duration) (*mcpv1alpha1.MCPServer, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	state := "not found"
+	for {
+		server, err := c.Get(ctx, namespace, name)
+		switch {
+		case err == nil && IsReady(server):
+			return server, nil
+		case err == nil:
+			state = fmt.Sprintf("phase %q", server.Status.Phase)
+			if server.Status.Message != "" {
+				state += ": " + server.Status.Message
+			}
+		case crclient.IgnoreNotFound(err) != nil && ctx.Err() == nil:
+			state = err.Error()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s/%s %s", ErrNotReady, namespaceOrDefault(namespace), name, state)
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsReady reports whether the operator has reconciled server's current generation and
+// reports it Ready.
+func IsReady(server *mcpv1alpha1.MCPServer) bool {
+	return server.Status.ObservedGeneration == server.Generation && server.Status.Phase == PhaseReady
+}
+
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return DefaultNamespace
+	}
+	return namespace
+}
+
+// mergeStrings returns dst with the entries of src added, allocating dst if needed.
+func mergeStrings(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
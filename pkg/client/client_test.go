@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := mcpv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func TestCreateOrUpdateMCPServer(t *testing.T) {
+	c := NewFromClient(fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build())
+	ctx := context.Background()
+
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Labels: map[string]string{"team": "data"}},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "weather", ImageTag: "v1"},
+	}
+	result, err := c.CreateOrUpdateMCPServer(ctx, server)
+	if err != nil || result != controllerutil.OperationResultCreated {
+		t.Fatalf("create = %s, %v", result, err)
+	}
+	if server.Namespace != DefaultNamespace || server.ResourceVersion == "" {
+		t.Fatalf("expected the stored object back, got %+v", server.ObjectMeta)
+	}
+
+	// Keys set by others survive an update.
+	stored, err := c.Get(ctx, "", "weather")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored.Annotations = map[string]string{"mcpruntime.org/suspended-replicas": "2"}
+	if err := c.c.Update(ctx, stored); err != nil {
+		t.Fatal(err)
+	}
+
+	update := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: DefaultNamespace, Labels: map[string]string{"tier": "gold"}},
+		Spec:       mcpv1alpha1.MCPServerSpec{Image: "weather", ImageTag: "v2"},
+	}
+	if result, err := c.CreateOrUpdateMCPServer(ctx, update); err != nil || result != controllerutil.OperationResultUpdated {
+		t.Fatalf("update = %s, %v", result, err)
+	}
+	if result, err := c.CreateOrUpdateMCPServer(ctx, update.DeepCopy()); err != nil || result != controllerutil.OperationResultNone {
+		t.Fatalf("repeated update = %s, %v", result, err)
+	}
+	stored, err = c.Get(ctx, DefaultNamespace, "weather")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Spec.ImageTag != "v2" || stored.Labels["team"] != "data" || stored.Labels["tier"] != "gold" ||
+		stored.Annotations["mcpruntime.org/suspended-replicas"] != "2" {
+		t.Fatalf("unexpected stored server: labels %v annotations %v spec %+v", stored.Labels, stored.Annotations, stored.Spec)
+	}
+
+	if _, err := c.CreateOrUpdateMCPServer(ctx, &mcpv1alpha1.MCPServer{}); err == nil {
+		t.Fatal("expected an error for a server without a name")
+	}
+}
+
+func TestListByLabelAndDelete(t *testing.T) {
+	server := func(name, namespace, team string) *mcpv1alpha1.MCPServer {
+		return &mcpv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"team": team}}}
+	}
+	c := NewFromClient(fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		server("a", "ns1", "data"), server("b", "ns2", "data"), server("c", "ns1", "web"),
+	).Build())
+	ctx := context.Background()
+
+	names := func(items []mcpv1alpha1.MCPServer) string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Namespace+"/"+item.Name)
+		}
+		return strings.Join(out, ",")
+	}
+	all, err := c.ListByLabel(ctx, "", map[string]string{"team": "data"})
+	if err != nil || names(all) != "ns1/a,ns2/b" {
+		t.Fatalf("ListByLabel(all namespaces) = %s, %v", names(all), err)
+	}
+	ns1, err := c.ListByLabel(ctx, "ns1", nil)
+	if err != nil || names(ns1) != "ns1/a,ns1/c" {
+		t.Fatalf("ListByLabel(ns1) = %s, %v", names(ns1), err)
+	}
+
+	if err := c.Delete(ctx, "ns1", "a"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if err := c.Delete(ctx, "ns1", "a"); err != nil {
+		t.Fatalf("Delete() of a missing server error: %v", err)
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	server := &mcpv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "team-a", Generation: 2},
+		Status:     mcpv1alpha1.MCPServerStatus{ObservedGeneration: 1, Phase: PhaseReady},
+	}
+
+	t.Run("ready once the generation is observed", func(t *testing.T) {
+		gets := 0
+		c := NewFromClient(fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(server.DeepCopy()).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c crclient.WithWatch, key crclient.ObjectKey, obj crclient.Object, opts ...crclient.GetOption) error {
+					if err := c.Get(ctx, key, obj, opts...); err != nil {
+						return err
+					}
+					if gets++; gets >= 3 {
+						s := obj.(*mcpv1alpha1.MCPServer)
+						s.Status.ObservedGeneration = s.Generation
+					}
+					return nil
+				},
+			}).Build())
+		c.PollInterval = time.Millisecond
+
+		got, err := c.WaitForReady(context.Background(), "team-a", "weather", time.Minute)
+		if err != nil {
+			t.Fatalf("WaitForReady() error: %v", err)
+		}
+		if !IsReady(got) || gets != 3 {
+			t.Fatalf("ready after %d reads: %+v", gets, got.Status)
+		}
+	})
+
+	t.Run("timeout reports the last state", func(t *testing.T) {
+		pending := server.DeepCopy()
+		pending.Status = mcpv1alpha1.MCPServerStatus{ObservedGeneration: 2, Phase: "Pending", Message: "image pull backoff"}
+		c := NewFromClient(fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(pending).Build())
+		c.PollInterval = time.Millisecond
+
+		_, err := c.WaitForReady(context.Background(), "team-a", "weather", 20*time.Millisecond)
+		if !errors.Is(err, ErrNotReady) || !strings.Contains(err.Error(), `team-a/weather phase "Pending": image pull backoff`) {
+			t.Fatalf("expected ErrNotReady with the last state, got %v", err)
+		}
+	})
+
+	t.Run("missing server", func(t *testing.T) {
+		c := NewFromClient(fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build())
+		c.PollInterval = time.Millisecond
+
+		_, err := c.WaitForReady(context.Background(), "", "weather", 10*time.Millisecond)
+		if !errors.Is(err, ErrNotReady) || !strings.Contains(err.Error(), "mcp-servers/weather not found") {
+			t.Fatalf("expected ErrNotReady for a missing server, got %v", err)
+		}
+	})
+}
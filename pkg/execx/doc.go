@@ -0,0 +1,23 @@
+// Package execx runs external commands, kubectl in particular, behind small interfaces
+// that validate arguments before anything is executed.
+//
+// Commands are created by an Executor. Every Executor accepts validators that inspect the
+// binary and arguments and can refuse the command before a process is started:
+//
+//	cmd, err := execx.OSExecutor{}.Command(ctx, "git", []string{"rev-parse", "HEAD"},
+//		execx.AllowlistBins("git"), execx.NoShellMeta())
+//
+// Kubectl builds on an Executor. It adds --context when a kubeconfig context is selected,
+// bounds each invocation with a timeout (streaming commands such as logs -f and
+// port-forward are left unbounded) and reports commands stopped by their context as a
+// *ContextError:
+//
+//	kubectl, err := execx.NewKubectl(execx.OSExecutor{})
+//	if err != nil {
+//		return err
+//	}
+//	kubectl.Timeout = time.Minute
+//	out, err := kubectl.Output([]string{"get", "pods", "-n", "mcp-servers", "-o", "json"})
+//
+// Code that only needs to run kubectl should accept a KubectlRunner so tests can pass a fake.
+package execx
@@ -0,0 +1,74 @@
+package execx
+
+import (
+	"context"
+	"io"
+	"os/exec"
+)
+
+// Command represents a command that can be executed.
+type Command interface {
+	Output() ([]byte, error)
+	CombinedOutput() ([]byte, error)
+	Run() error
+	SetStdout(w io.Writer)
+	SetStderr(w io.Writer)
+	SetStdin(r io.Reader)
+}
+
+// Executor creates commands for execution.
+// The command is killed if ctx is cancelled or its deadline passes before it exits.
+type Executor interface {
+	Command(ctx context.Context, name string, args []string, validators ...Validator) (Command, error)
+}
+
+// Spec is the binary and arguments of a command, as seen by validators.
+type Spec struct {
+	Name string
+	Args []string
+}
+
+// Validator inspects a command before it is created and returns an error to refuse it.
+type Validator func(Spec) error
+
+// OSExecutor is the production Executor using os/exec.
+type OSExecutor struct {
+	// CommandContext creates the underlying process; nil uses exec.CommandContext.
+	// Tests replace it to stub processes.
+	CommandContext func(ctx context.Context, name string, args ...string) *exec.Cmd
+}
+
+// Command runs the validators and returns the command, or the first validator error.
+func (e OSExecutor) Command(ctx context.Context, name string, args []string, validators ...Validator) (Command, error) {
+	if err := Validate(Spec{Name: name, Args: args}, validators...); err != nil {
+		return nil, err
+	}
+	newCmd := e.CommandContext
+	if newCmd == nil {
+		newCmd = exec.CommandContext
+	}
+	// #nosec G204 -- callers pass validators that constrain the binary and arguments.
+	return &execCmd{cmd: newCmd(ctx, name, args...)}, nil
+}
+
+// Validate runs validators against spec and returns the first error.
+func Validate(spec Spec, validators ...Validator) error {
+	for _, validate := range validators {
+		if err := validate(spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execCmd wraps exec.Cmd to implement the Command interface.
+type execCmd struct {
+	cmd *exec.Cmd
+}
+
+func (c *execCmd) Output() ([]byte, error)         { return c.cmd.Output() }
+func (c *execCmd) CombinedOutput() ([]byte, error) { return c.cmd.CombinedOutput() }
+func (c *execCmd) Run() error                      { return c.cmd.Run() }
+func (c *execCmd) SetStdout(w io.Writer)           { c.cmd.Stdout = w }
+func (c *execCmd) SetStderr(w io.Writer)           { c.cmd.Stderr = w }
+func (c *execCmd) SetStdin(r io.Reader)            { c.cmd.Stdin = r }
@@ -0,0 +1,253 @@
+package execx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// KubectlRunner captures the kubectl methods used by code that runs kubectl. Accept it
+// instead of *Kubectl so tests can substitute a fake.
+type KubectlRunner interface {
+	CommandArgs(args []string) (Command, error)
+	CommandArgsContext(ctx context.Context, args []string) (Command, error)
+	Run(args []string) error
+	RunWithOutput(args []string, stdout, stderr io.Writer) error
+}
+
+// Kubectl runs kubectl through an Executor with validation.
+// It holds no mutable state once configured and is safe for concurrent use.
+type Kubectl struct {
+	// Exec creates the kubectl processes.
+	Exec Executor
+	// Validators are applied to every invocation.
+	Validators []Validator
+	// Timeout bounds each invocation; zero disables the limit.
+	Timeout time.Duration
+	// Context, when set, is passed as --context to every invocation.
+	Context string
+}
+
+var _ KubectlRunner = (*Kubectl)(nil)
+
+// NewKubectl returns a Kubectl that refuses control characters and paths outside the
+// working directory. The error is that of os.Getwd.
+func NewKubectl(exec Executor) (*Kubectl, error) {
+	root, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return &Kubectl{
+		Exec: exec,
+		Validators: []Validator{
+			NoControlChars(), // Prevent YAML/command injection via control chars
+			PathUnder(root),
+		},
+	}, nil
+}
+
+// CommandArgs builds a kubectl command that is bounded only by the timeout.
+func (k *Kubectl) CommandArgs(args []string) (Command, error) {
+	return k.CommandArgsContext(context.Background(), args)
+}
+
+// CommandArgsContext builds a kubectl command with the given arguments.
+// Validates arguments against the configured validators before building.
+// The command is killed when ctx is cancelled or the per-command timeout expires, and then
+// fails with a *ContextError.
+func (k *Kubectl) CommandArgsContext(ctx context.Context, args []string) (Command, error) {
+	timeout := k.TimeoutFor(args)
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	verb := ""
+	if len(args) > 0 {
+		verb = args[0]
+	}
+	cmd, err := k.Exec.Command(ctx, "kubectl", k.WithContext(args), k.Validators...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &contextCommand{Command: cmd, ctx: ctx, cancel: cancel, verb: verb, timeout: timeout}, nil
+}
+
+// WithContext prepends --context for the selected context unless args already name one.
+func (k *Kubectl) WithContext(args []string) []string {
+	if k.Context == "" {
+		return args
+	}
+	for _, arg := range args {
+		if arg == "--context" || strings.HasPrefix(arg, "--context=") {
+			return args
+		}
+	}
+	return append([]string{"--context", k.Context}, args...)
+}
+
+// TimeoutFor returns the timeout for a kubectl invocation. Streaming and transfer commands
+// (exec, cp, port-forward, proxy, attach, follow/watch, attached run) are unbounded, as is
+// rollout status without its own --timeout, and commands carrying their own --timeout get
+// that long plus the client timeout as grace.
+func (k *Kubectl) TimeoutFor(args []string) time.Duration {
+	if k.Timeout <= 0 {
+		return 0
+	}
+	verb, rest := kubectlVerb(args)
+	switch verb {
+	case "port-forward", "attach", "exec", "proxy", "cp":
+		return 0
+	}
+	unbounded := verb == "rollout" && len(rest) > 0 && rest[0] == "status"
+	for i, arg := range args {
+		switch {
+		case arg == "-f" && verb == "logs",
+			arg == "--follow", arg == "-w", arg == "--watch",
+			arg == "-i" && verb == "run":
+			return 0
+		case strings.HasPrefix(arg, "--timeout="):
+			if d, err := time.ParseDuration(strings.TrimPrefix(arg, "--timeout=")); err == nil {
+				return d + k.Timeout
+			}
+		case arg == "--timeout" && i+1 < len(args):
+			if d, err := time.ParseDuration(args[i+1]); err == nil {
+				return d + k.Timeout
+			}
+		}
+	}
+	if unbounded {
+		return 0
+	}
+	return k.Timeout
+}
+
+// kubectlGlobalValueFlags are the flags that may precede the verb and take a separate value.
+var kubectlGlobalValueFlags = map[string]bool{
+	"-n": true, "--namespace": true, "--context": true, "--kubeconfig": true,
+	"--cluster": true, "--user": true, "-s": true, "--server": true,
+}
+
+// kubectlVerb returns the first non-flag argument of a kubectl invocation and the
+// arguments after it.
+func kubectlVerb(args []string) (string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return arg, args[i+1:]
+		}
+		if kubectlGlobalValueFlags[arg] {
+			i++
+		}
+	}
+	return "", nil
+}
+
+// Output runs kubectl with the given arguments and returns stdout.
+func (k *Kubectl) Output(args []string) ([]byte, error) {
+	cmd, err := k.CommandArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.Output()
+}
+
+// CombinedOutput runs kubectl with the given arguments and returns combined stdout/stderr.
+func (k *Kubectl) CombinedOutput(args []string) ([]byte, error) {
+	cmd, err := k.CommandArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.CombinedOutput()
+}
+
+// Run runs kubectl with the given arguments.
+func (k *Kubectl) Run(args []string) error {
+	cmd, err := k.CommandArgs(args)
+	if err != nil {
+		return err
+	}
+	return cmd.Run()
+}
+
+// RunWithOutput runs kubectl with the given arguments, piping to the provided writers.
+func (k *Kubectl) RunWithOutput(args []string, stdout, stderr io.Writer) error {
+	cmd, err := k.CommandArgs(args)
+	if err != nil {
+		return err
+	}
+	cmd.SetStdout(stdout)
+	cmd.SetStderr(stderr)
+	return cmd.Run()
+}
+
+// ContextError reports a command that failed because its context was cancelled or its
+// timeout expired. errors.Is matches both the command's error and the context error,
+// so callers can test for context.DeadlineExceeded or context.Canceled.
+type ContextError struct {
+	// Verb is the first argument of the command, such as "get".
+	Verb string
+	// Timeout is the per-command timeout that applied, zero if none.
+	Timeout time.Duration
+	// Err is the error returned by the command.
+	Err error
+	// Cause is the context error, context.DeadlineExceeded or context.Canceled.
+	Cause error
+}
+
+// TimedOut reports whether the command ran out of time rather than being interrupted.
+func (e *ContextError) TimedOut() bool {
+	return errors.Is(e.Cause, context.DeadlineExceeded)
+}
+
+func (e *ContextError) Error() string {
+	if !e.TimedOut() {
+		return fmt.Sprintf("kubectl %s interrupted: %v", e.Verb, e.Err)
+	}
+	if e.Timeout > 0 {
+		return fmt.Sprintf("kubectl %s timed out after %s: %v", e.Verb, e.Timeout, e.Err)
+	}
+	return fmt.Sprintf("kubectl %s timed out: %v", e.Verb, e.Err)
+}
+
+func (e *ContextError) Unwrap() []error {
+	return []error{e.Err, e.Cause}
+}
+
+// contextCommand releases its context once the command has run and reports
+// deadline and interrupt failures as a *ContextError.
+type contextCommand struct {
+	Command
+	ctx     context.Context
+	cancel  context.CancelFunc
+	verb    string
+	timeout time.Duration
+}
+
+func (c *contextCommand) Output() ([]byte, error) {
+	defer c.cancel()
+	out, err := c.Command.Output()
+	return out, c.wrapErr(err)
+}
+
+func (c *contextCommand) CombinedOutput() ([]byte, error) {
+	defer c.cancel()
+	out, err := c.Command.CombinedOutput()
+	return out, c.wrapErr(err)
+}
+
+func (c *contextCommand) Run() error {
+	defer c.cancel()
+	return c.wrapErr(c.Command.Run())
+}
+
+func (c *contextCommand) wrapErr(err error) error {
+	if err == nil || c.ctx.Err() == nil {
+		return err
+	}
+	return &ContextError{Verb: c.verb, Timeout: c.timeout, Err: err, Cause: c.ctx.Err()}
+}
@@ -0,0 +1,149 @@
+package execx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeCommand runs run, or blocks until its context is done when run is nil.
+type fakeCommand struct {
+	ctx    context.Context
+	run    func() ([]byte, error)
+	stdout io.Writer
+}
+
+func (c *fakeCommand) Output() ([]byte, error) {
+	if c.run == nil {
+		<-c.ctx.Done()
+		return nil, errors.New("signal: killed")
+	}
+	return c.run()
+}
+func (c *fakeCommand) CombinedOutput() ([]byte, error) { return c.Output() }
+func (c *fakeCommand) Run() error {
+	out, err := c.Output()
+	if c.stdout != nil {
+		_, _ = c.stdout.Write(out)
+	}
+	return err
+}
+func (c *fakeCommand) SetStdout(w io.Writer) { c.stdout = w }
+func (c *fakeCommand) SetStderr(io.Writer)   {}
+func (c *fakeCommand) SetStdin(io.Reader)    {}
+
+// fakeExecutor records the arguments of every command it creates.
+type fakeExecutor struct {
+	args [][]string
+	run  func() ([]byte, error)
+}
+
+func (e *fakeExecutor) Command(ctx context.Context, name string, args []string, validators ...Validator) (Command, error) {
+	if err := Validate(Spec{Name: name, Args: args}, validators...); err != nil {
+		return nil, err
+	}
+	e.args = append(e.args, args)
+	return &fakeCommand{ctx: ctx, run: e.run}, nil
+}
+
+func TestKubectlTimeoutFor(t *testing.T) {
+	kubectl := &Kubectl{Timeout: time.Minute}
+	tests := []struct {
+		name string
+		args []string
+		want time.Duration
+	}{
+		{"default", []string{"get", "pods"}, time.Minute},
+		{"explicit timeout", []string{"wait", "pod/x", "--timeout=60s"}, 2 * time.Minute},
+		{"follow logs", []string{"logs", "-l", "app=x", "-f"}, 0},
+		{"port-forward", []string{"port-forward", "svc/registry", "5000:5000"}, 0},
+		{"copy", []string{"cp", "/tmp/image.tar", "registry/helper:/tmp/image.tar"}, 0},
+		{"copy after context", []string{"--context", "prod", "cp", "/tmp/a", "ns/pod:/tmp/a"}, 0},
+		{"exec", []string{"exec", "-n", "registry", "deploy/registry", "--", "registry", "garbage-collect"}, 0},
+		{"exec after namespace", []string{"-n", "registry", "exec", "deploy/registry", "--", "ls"}, 0},
+		{"follow logs after namespace", []string{"-n", "team-a", "logs", "deploy/api", "-f"}, 0},
+		{"rollout status", []string{"rollout", "status", "deploy/registry", "-n", "registry"}, 0},
+		{"rollout status with timeout", []string{"rollout", "status", "deploy/registry", "--timeout=5m"}, 6 * time.Minute},
+		{"rollout restart", []string{"rollout", "restart", "deploy/registry"}, time.Minute},
+		{"get files named cp", []string{"get", "configmap", "cp"}, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kubectl.TimeoutFor(tt.args); got != tt.want {
+				t.Fatalf("TimeoutFor(%v) = %s, want %s", tt.args, got, tt.want)
+			}
+		})
+	}
+	if got := (&Kubectl{}).TimeoutFor([]string{"get", "pods"}); got != 0 {
+		t.Fatalf("expected no timeout, got %s", got)
+	}
+}
+
+func TestKubectlRun(t *testing.T) {
+	exec := &fakeExecutor{run: func() ([]byte, error) { return []byte("pod/x\n"), nil }}
+	kubectl := &Kubectl{Exec: exec, Validators: []Validator{NoControlChars()}, Context: "prod"}
+
+	var stdout bytes.Buffer
+	if err := kubectl.RunWithOutput([]string{"get", "pods"}, &stdout, io.Discard); err != nil {
+		t.Fatalf("RunWithOutput() error: %v", err)
+	}
+	if stdout.String() != "pod/x\n" {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+	if _, err := kubectl.Output([]string{"--context=dev", "get", "pods"}); err != nil {
+		t.Fatalf("Output() error: %v", err)
+	}
+	if got := strings.Join(exec.args[0], " "); got != "--context prod get pods" {
+		t.Fatalf("args = %q, want the selected context prepended", got)
+	}
+	if got := strings.Join(exec.args[1], " "); got != "--context=dev get pods" {
+		t.Fatalf("args = %q, want an explicit context kept", got)
+	}
+
+	if err := kubectl.Run([]string{"get", "pods", "-l", "app=x\n"}); !errors.Is(err, ErrControlChars) {
+		t.Fatalf("expected ErrControlChars, got %v", err)
+	}
+	if len(exec.args) != 2 {
+		t.Fatalf("a refused command must not be created")
+	}
+}
+
+func TestKubectlContextError(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		kubectl := &Kubectl{Exec: &fakeExecutor{}, Timeout: 10 * time.Millisecond, Context: "prod"}
+		err := kubectl.Run([]string{"get", "pods"})
+		var ctxErr *ContextError
+		if !errors.As(err, &ctxErr) || !ctxErr.TimedOut() || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected a timeout ContextError, got %v", err)
+		}
+		if !strings.HasPrefix(err.Error(), "kubectl get timed out after 10ms") {
+			t.Fatalf("Error() = %q", err.Error())
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cmd, err := (&Kubectl{Exec: &fakeExecutor{}}).CommandArgsContext(ctx, []string{"get", "pods"})
+		if err != nil {
+			t.Fatalf("CommandArgsContext() error: %v", err)
+		}
+		err = cmd.Run()
+		var ctxErr *ContextError
+		if !errors.As(err, &ctxErr) || ctxErr.TimedOut() || !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected a cancellation ContextError, got %v", err)
+		}
+	})
+
+	t.Run("plain failure", func(t *testing.T) {
+		failure := errors.New("exit status 1")
+		kubectl := &Kubectl{Exec: &fakeExecutor{run: func() ([]byte, error) { return nil, failure }}, Timeout: time.Minute}
+		if err := kubectl.Run([]string{"get", "pods"}); err != failure {
+			t.Fatalf("expected the command error unchanged, got %v", err)
+		}
+	})
+}
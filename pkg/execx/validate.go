@@ -0,0 +1,129 @@
+package execx
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Errors returned by the validators in this package.
+var (
+	ErrBinaryNotAllowed = errors.New("exec: binary not allowed")
+	ErrShellMeta        = errors.New("exec: shell metacharacters not allowed")
+	ErrControlChars     = errors.New("exec: control characters not allowed")
+	ErrPathEscapesRoot  = errors.New("exec: path escapes root")
+	ErrFlagForbidden    = errors.New("exec: flag not allowed")
+	ErrNamespaceDenied  = errors.New("exec: namespace not allowed")
+)
+
+// AllowlistBins refuses binaries other than the allowed names.
+func AllowlistBins(allowed ...string) Validator {
+	set := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		set[name] = struct{}{}
+	}
+	return func(spec Spec) error {
+		if _, ok := set[spec.Name]; !ok {
+			return ErrBinaryNotAllowed
+		}
+		return nil
+	}
+}
+
+// NoShellMeta refuses arguments containing shell metacharacters.
+func NoShellMeta() Validator {
+	return func(spec Spec) error {
+		for _, arg := range spec.Args {
+			if strings.ContainsAny(arg, "&|;<>()$`\\") {
+				return ErrShellMeta
+			}
+		}
+		return nil
+	}
+}
+
+// NoControlChars refuses arguments containing carriage returns, newlines or tabs, which
+// could inject extra lines into generated YAML or command output.
+func NoControlChars() Validator {
+	return func(spec Spec) error {
+		for _, arg := range spec.Args {
+			if strings.ContainsAny(arg, "\r\n\t") {
+				return ErrControlChars
+			}
+		}
+		return nil
+	}
+}
+
+// PathUnder refuses arguments that, read as paths relative to root, point outside it.
+// "-" (stdin) is always allowed.
+func PathUnder(root string) Validator {
+	absRoot := root
+	if abs, err := filepath.Abs(root); err == nil {
+		absRoot = abs
+	}
+	return func(spec Spec) error {
+		for _, arg := range spec.Args {
+			if arg == "-" {
+				continue
+			}
+			candidate := arg
+			if !filepath.IsAbs(candidate) {
+				candidate = filepath.Join(absRoot, candidate)
+			}
+			candidate = filepath.Clean(candidate)
+			rel, err := filepath.Rel(absRoot, candidate)
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return ErrPathEscapesRoot
+			}
+		}
+		return nil
+	}
+}
+
+// ForbidFlags refuses arguments that are one of flags, given alone ("--token") or with a
+// value ("--token=abc").
+func ForbidFlags(flags ...string) Validator {
+	return func(spec Spec) error {
+		for _, arg := range spec.Args {
+			for _, flag := range flags {
+				if arg == flag || strings.HasPrefix(arg, flag+"=") {
+					return fmt.Errorf("%w: %s", ErrFlagForbidden, flag)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// AllowNamespaces refuses kubectl-style namespace flags (-n, --namespace) naming other
+// namespaces than allowed, and --all-namespaces (-A). Commands without a namespace flag
+// pass; their namespace comes from the kubeconfig or the manifest.
+func AllowNamespaces(allowed ...string) Validator {
+	set := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		set[name] = struct{}{}
+	}
+	return func(spec Spec) error {
+		for i, arg := range spec.Args {
+			namespace, found := "", true
+			switch {
+			case arg == "-A" || arg == "--all-namespaces" || arg == "--all-namespaces=true":
+				return fmt.Errorf("%w: %s", ErrNamespaceDenied, arg)
+			case (arg == "-n" || arg == "--namespace") && i+1 < len(spec.Args):
+				namespace = spec.Args[i+1]
+			case strings.HasPrefix(arg, "--namespace="):
+				namespace = strings.TrimPrefix(arg, "--namespace=")
+			case strings.HasPrefix(arg, "-n="):
+				namespace = strings.TrimPrefix(arg, "-n=")
+			default:
+				found = false
+			}
+			if _, ok := set[namespace]; found && !ok {
+				return fmt.Errorf("%w: %s", ErrNamespaceDenied, namespace)
+			}
+		}
+		return nil
+	}
+}
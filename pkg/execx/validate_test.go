@@ -0,0 +1,61 @@
+package execx
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name      string
+		validator Validator
+		spec      Spec
+		want      error
+	}{
+		{"allowed binary", AllowlistBins("kubectl"), Spec{Name: "kubectl"}, nil},
+		{"unlisted binary", AllowlistBins("kubectl"), Spec{Name: "rm", Args: []string{"-rf", "/"}}, ErrBinaryNotAllowed},
+		{"plain args", NoShellMeta(), Spec{Args: []string{"get", "pods", "-n", "default"}}, nil},
+		{"pipe", NoShellMeta(), Spec{Args: []string{"echo", "|", "cat"}}, ErrShellMeta},
+		{"subshell", NoShellMeta(), Spec{Args: []string{"$(whoami)"}}, ErrShellMeta},
+		{"no control chars", NoControlChars(), Spec{Args: []string{"name=x"}}, nil},
+		{"newline", NoControlChars(), Spec{Args: []string{"name=x\nkind: Secret"}}, ErrControlChars},
+		{"path inside root", PathUnder(root), Spec{Args: []string{"manifests/server.yaml", filepath.Join(root, "a")}}, nil},
+		{"stdin", PathUnder(root), Spec{Args: []string{"apply", "-f", "-"}}, nil},
+		{"relative escape", PathUnder(root), Spec{Args: []string{"../secret"}}, ErrPathEscapesRoot},
+		{"absolute escape", PathUnder(root), Spec{Args: []string{filepath.Dir(root)}}, ErrPathEscapesRoot},
+		{"allowed flag", ForbidFlags("--token"), Spec{Args: []string{"get", "pods", "--tokens-file"}}, nil},
+		{"forbidden flag", ForbidFlags("--token"), Spec{Args: []string{"get", "pods", "--token", "abc"}}, ErrFlagForbidden},
+		{"forbidden flag value", ForbidFlags("--as"), Spec{Args: []string{"get", "pods", "--as=admin"}}, ErrFlagForbidden},
+		{"allowed namespace", AllowNamespaces("mcp-servers"), Spec{Args: []string{"get", "pods", "-n", "mcp-servers"}}, nil},
+		{"no namespace", AllowNamespaces("mcp-servers"), Spec{Args: []string{"get", "nodes"}}, nil},
+		{"denied namespace", AllowNamespaces("mcp-servers"), Spec{Args: []string{"get", "secrets", "--namespace=kube-system"}}, ErrNamespaceDenied},
+		{"all namespaces", AllowNamespaces("mcp-servers"), Spec{Args: []string{"get", "pods", "-A"}}, ErrNamespaceDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validator(tt.spec); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOSExecutor(t *testing.T) {
+	cmd, err := OSExecutor{}.Command(t.Context(), "echo", []string{"hello"}, AllowlistBins("echo"), NoShellMeta())
+	if err != nil {
+		t.Fatalf("Command() error: %v", err)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Output() error: %v", err)
+	}
+	if string(out) != "hello\n" {
+		t.Fatalf("Output() = %q, want %q", out, "hello\n")
+	}
+
+	if _, err := (OSExecutor{}).Command(t.Context(), "sh", []string{"-c", "id"}, AllowlistBins("echo")); !errors.Is(err, ErrBinaryNotAllowed) {
+		t.Fatalf("expected ErrBinaryNotAllowed, got %v", err)
+	}
+}
@@ -44,11 +44,56 @@ func TestCLIHelpGoldens(t *testing.T) {
 		{name: "pipeline_help", args: []string{"pipeline", "--help"}, golden: "mcp-runtime_pipeline_help.golden"},
 		{name: "pipeline_generate_help", args: []string{"pipeline", "generate", "--help"}, golden: "mcp-runtime_pipeline_generate_help.golden"},
 		{name: "pipeline_deploy_help", args: []string{"pipeline", "deploy", "--help"}, golden: "mcp-runtime_pipeline_deploy_help.golden"},
+		{name: "pipeline_run_help", args: []string{"pipeline", "run", "--help"}, golden: "mcp-runtime_pipeline_run_help.golden"},
 		{name: "cluster_help", args: []string{"cluster", "--help"}, golden: "mcp-runtime_cluster_help.golden"},
 		{name: "cluster_init_help", args: []string{"cluster", "init", "--help"}, golden: "mcp-runtime_cluster_init_help.golden"},
 		{name: "cluster_status_help", args: []string{"cluster", "status", "--help"}, golden: "mcp-runtime_cluster_status_help.golden"},
 		{name: "cluster_config_help", args: []string{"cluster", "config", "--help"}, golden: "mcp-runtime_cluster_config_help.golden"},
 		{name: "cluster_provision_help", args: []string{"cluster", "provision", "--help"}, golden: "mcp-runtime_cluster_provision_help.golden"},
+		{name: "ingress_help", args: []string{"ingress", "--help"}, golden: "mcp-runtime_ingress_help.golden"},
+		{name: "ingress_hosts_help", args: []string{"ingress", "hosts", "--help"}, golden: "mcp-runtime_ingress_hosts_help.golden"},
+		{name: "registry_df_help", args: []string{"registry", "df", "--help"}, golden: "mcp-runtime_registry_df_help.golden"},
+		{name: "context_help", args: []string{"context", "--help"}, golden: "mcp-runtime_context_help.golden"},
+		{name: "context_list_help", args: []string{"context", "list", "--help"}, golden: "mcp-runtime_context_list_help.golden"},
+		{name: "context_use_help", args: []string{"context", "use", "--help"}, golden: "mcp-runtime_context_use_help.golden"},
+		{name: "doctor_help", args: []string{"doctor", "--help"}, golden: "mcp-runtime_doctor_help.golden"},
+		{name: "server_suspend_help", args: []string{"server", "suspend", "--help"}, golden: "mcp-runtime_server_suspend_help.golden"},
+		{name: "server_resume_help", args: []string{"server", "resume", "--help"}, golden: "mcp-runtime_server_resume_help.golden"},
+		{name: "rbac_help", args: []string{"rbac", "--help"}, golden: "mcp-runtime_rbac_help.golden"},
+		{name: "rbac_report_help", args: []string{"rbac", "report", "--help"}, golden: "mcp-runtime_rbac_report_help.golden"},
+		{name: "backup_help", args: []string{"backup", "--help"}, golden: "mcp-runtime_backup_help.golden"},
+		{name: "backup_create_help", args: []string{"backup", "create", "--help"}, golden: "mcp-runtime_backup_create_help.golden"},
+		{name: "backup_restore_help", args: []string{"backup", "restore", "--help"}, golden: "mcp-runtime_backup_restore_help.golden"},
+		{name: "operator_help", args: []string{"operator", "--help"}, golden: "mcp-runtime_operator_help.golden"},
+		{name: "operator_pause_help", args: []string{"operator", "pause", "--help"}, golden: "mcp-runtime_operator_pause_help.golden"},
+		{name: "operator_resume_help", args: []string{"operator", "resume", "--help"}, golden: "mcp-runtime_operator_resume_help.golden"},
+		{name: "operator_status_help", args: []string{"operator", "status", "--help"}, golden: "mcp-runtime_operator_status_help.golden"},
+		{name: "smoke_test_help", args: []string{"smoke-test", "--help"}, golden: "mcp-runtime_smoke_test_help.golden"},
+		{name: "server_scaffold_help", args: []string{"server", "scaffold", "--help"}, golden: "mcp-runtime_server_scaffold_help.golden"},
+		{name: "server_rollback_help", args: []string{"server", "rollback", "--help"}, golden: "mcp-runtime_server_rollback_help.golden"},
+		{name: "registry_tags_help", args: []string{"registry", "tags", "--help"}, golden: "mcp-runtime_registry_tags_help.golden"},
+		{name: "config_help", args: []string{"config", "--help"}, golden: "mcp-runtime_config_help.golden"},
+		{name: "config_set_help", args: []string{"config", "set", "--help"}, golden: "mcp-runtime_config_set_help.golden"},
+		{name: "config_get_help", args: []string{"config", "get", "--help"}, golden: "mcp-runtime_config_get_help.golden"},
+		{name: "config_unset_help", args: []string{"config", "unset", "--help"}, golden: "mcp-runtime_config_unset_help.golden"},
+		{name: "server_clone_help", args: []string{"server", "clone", "--help"}, golden: "mcp-runtime_server_clone_help.golden"},
+		{name: "observability_help", args: []string{"observability", "--help"}, golden: "mcp-runtime_observability_help.golden"},
+		{name: "observability_export_dashboards_help", args: []string{"observability", "export-dashboards", "--help"}, golden: "mcp-runtime_observability_export_dashboards_help.golden"},
+		{name: "dashboard_help", args: []string{"dashboard", "--help"}, golden: "mcp-runtime_dashboard_help.golden"},
+		{name: "registry_show_config_help", args: []string{"registry", "show-config", "--help"}, golden: "mcp-runtime_registry_show_config_help.golden"},
+		{name: "registry_prune_unreferenced_help", args: []string{"registry", "prune-unreferenced", "--help"}, golden: "mcp-runtime_registry_prune_unreferenced_help.golden"},
+		{name: "server_apply_help", args: []string{"server", "apply", "--help"}, golden: "mcp-runtime_server_apply_help.golden"},
+		{name: "server_dev_help", args: []string{"server", "dev", "--help"}, golden: "mcp-runtime_server_dev_help.golden"},
+		{name: "operator_profile_help", args: []string{"operator", "profile", "--help"}, golden: "mcp-runtime_operator_profile_help.golden"},
+		{name: "team_help", args: []string{"team", "--help"}, golden: "mcp-runtime_team_help.golden"},
+		{name: "team_create_help", args: []string{"team", "create", "--help"}, golden: "mcp-runtime_team_create_help.golden"},
+		{name: "cluster_delete_help", args: []string{"cluster", "delete", "--help"}, golden: "mcp-runtime_cluster_delete_help.golden"},
+		{name: "serve_api_help", args: []string{"serve-api", "--help"}, golden: "mcp-runtime_serve_api_help.golden"},
+		{name: "events_help", args: []string{"events", "--help"}, golden: "mcp-runtime_events_help.golden"},
+		{name: "clean_help", args: []string{"clean", "--help"}, golden: "mcp-runtime_clean_help.golden"},
+		{name: "server_diff_help", args: []string{"server", "diff", "--help"}, golden: "mcp-runtime_server_diff_help.golden"},
+		{name: "server_migrate_help", args: []string{"server", "migrate", "--help"}, golden: "mcp-runtime_server_migrate_help.golden"},
+		{name: "server_preview_create_help", args: []string{"server", "preview", "create", "--help"}, golden: "mcp-runtime_server_preview_create_help.golden"},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,157 @@
+//go:build chaos
+
+package integration
+
+// The chaos suite runs the operator against envtest with a client that fails a share of
+// API calls with conflicts, throttling and timeouts, and checks that every MCPServer still
+// converges. Run with: make test-chaos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mcpv1alpha1 "mcp-runtime/api/v1alpha1"
+	"mcp-runtime/internal/operator"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// chaosErrorRate is the share of operator API calls failed by the suite.
+const chaosErrorRate = 0.2
+
+func TestControllerConvergesUnderChaos(t *testing.T) {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	assetsDir := os.Getenv("KUBEBUILDER_ASSETS")
+	if assetsDir == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set; skipping envtest chaos suite")
+	}
+	if _, err := os.Stat(filepath.Join(assetsDir, "etcd")); err != nil {
+		t.Skipf("envtest binaries not found in KUBEBUILDER_ASSETS: %v", err)
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		BinaryAssetsDirectory: assetsDir,
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start test environment: %v", err)
+	}
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			t.Errorf("failed to stop test environment: %v", err)
+		}
+	}()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = mcpv1alpha1.AddToScheme(scheme)
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: server.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	injector := operator.NewChaosInjector(chaosErrorRate, time.Now().UnixNano())
+	reconciler := &operator.MCPServerReconciler{
+		Client:    injector.Client(mgr.GetClient()),
+		APIReader: injector.Reader(mgr.GetAPIReader()),
+		Scheme:    mgr.GetScheme(),
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		t.Fatalf("SetupWithManager failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			t.Logf("manager stopped: %v", err)
+		}
+	}()
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	createNamespace(t, k8sClient, "test-chaos")
+
+	const servers = 5
+	for i := 0; i < servers; i++ {
+		replicas := int32(1)
+		mcpServer := &mcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("chaos-%d", i), Namespace: "test-chaos"},
+			Spec: mcpv1alpha1.MCPServerSpec{
+				Image:        "nginx",
+				ImageTag:     "alpine",
+				Port:         80,
+				ServicePort:  80,
+				Replicas:     &replicas,
+				IngressHost:  "chaos.example.com",
+				IngressPath:  fmt.Sprintf("/chaos-%d", i),
+				IngressClass: "nginx",
+			},
+		}
+		if err := k8sClient.Create(ctx, mcpServer); err != nil {
+			t.Fatalf("failed to create MCPServer: %v", err)
+		}
+	}
+
+	for i := 0; i < servers; i++ {
+		key := types.NamespacedName{Name: fmt.Sprintf("chaos-%d", i), Namespace: "test-chaos"}
+		if err := waitForConverged(ctx, k8sClient, key, 90*time.Second); err != nil {
+			t.Fatalf("%s did not converge with %d injected errors: %v", key, injector.Injected(), err)
+		}
+	}
+	if injector.Injected() == 0 {
+		t.Fatal("no errors were injected")
+	}
+	t.Logf("all servers converged despite %d injected errors", injector.Injected())
+}
+
+// waitForConverged waits until the operator has observed the server's latest spec and
+// created its Deployment, Service and Ingress.
+func waitForConverged(ctx context.Context, c client.Client, key types.NamespacedName, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := converged(ctx, c, key)
+		if err == nil || time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func converged(ctx context.Context, c client.Client, key types.NamespacedName) error {
+	var server mcpv1alpha1.MCPServer
+	if err := c.Get(ctx, key, &server); err != nil {
+		return err
+	}
+	if server.Status.ObservedGeneration != server.Generation {
+		return fmt.Errorf("observed generation %d, want %d", server.Status.ObservedGeneration, server.Generation)
+	}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &networkingv1.Ingress{}} {
+		if err := c.Get(ctx, key, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}